@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/benchmark"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/logutil"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// default verbosity of the benchmark, consensus logs every verified slot which would dominate the measurement
+const benchmarkVerbosity = "info,consensus=warn"
+
+// benchmarkCommand measures the verification pipeline with synthetic chains
+var benchmarkCommand = &cli.Command{
+	Name:   "benchmark",
+	Usage:  "Feeds synthetic vanguard and pandora streams through the verification pipeline and prints throughput, latency percentiles and database write amplification as json",
+	Action: runBenchmark,
+	Flags: cmd.WrapFlags([]cli.Flag{
+		cmd.DataDirFlag,
+		cmd.BenchmarkSlotsFlag,
+		cmd.BenchmarkVanguardRateFlag,
+		cmd.BenchmarkPandoraRateFlag,
+		cmd.VerificationWorkersFlag,
+		cmd.VerificationBatchSizeFlag,
+		cmd.VerbosityFlag,
+	}),
+}
+
+// runBenchmark runs the benchmark on a temporary database inside the data directory, so the disk of the node is
+// measured without touching its database
+func runBenchmark(cliCtx *cli.Context) error {
+	verbosity := benchmarkVerbosity
+	if cliCtx.IsSet(cmd.VerbosityFlag.Name) {
+		verbosity = cliCtx.String(cmd.VerbosityFlag.Name)
+	}
+	if err := logutil.SetVerbosity(verbosity); err != nil {
+		return err
+	}
+
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+	if err := fileutil.MkdirAll(dataDir); err != nil {
+		return errors.Wrap(err, "could not create data directory")
+	}
+	benchmarkDir, err := os.MkdirTemp(dataDir, "benchmark-")
+	if err != nil {
+		return errors.Wrap(err, "could not create benchmark directory")
+	}
+	defer func() {
+		if err := os.RemoveAll(benchmarkDir); err != nil {
+			log.WithError(err).Error("Failed to remove benchmark directory")
+		}
+	}()
+
+	result, err := benchmark.Run(cliCtx.Context, &benchmark.Config{
+		DataDir:               filepath.Join(benchmarkDir, "orchestrator"),
+		Slots:                 cliCtx.Uint64(cmd.BenchmarkSlotsFlag.Name),
+		VanguardRate:          cliCtx.Float64(cmd.BenchmarkVanguardRateFlag.Name),
+		PandoraRate:           cliCtx.Float64(cmd.BenchmarkPandoraRateFlag.Name),
+		VerificationWorkers:   cliCtx.Int(cmd.VerificationWorkersFlag.Name),
+		VerificationBatchSize: cliCtx.Int(cmd.VerificationBatchSizeFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(os.Stdout).Encode(result)
+}
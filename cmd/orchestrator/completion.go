@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+// bashCompletionScript asks the binary for completions of the words typed so far, so completions of subcommands
+// and flags always match the installed version
+const bashCompletionScript = `_%[1]s_bash_autocomplete() {
+  if [[ "${COMP_WORDS[0]}" != "source" ]]; then
+    local cur opts
+    COMPREPLY=()
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    if [[ "$cur" == "-"* ]]; then
+      opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} ${cur} --generate-bash-completion 2>/dev/null )
+    else
+      opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} --generate-bash-completion 2>/dev/null )
+    fi
+    COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
+    return 0
+  fi
+}
+
+complete -o bashdefault -o default -o nospace -F _%[1]s_bash_autocomplete %[1]s
+`
+
+// zshCompletionScript works like bashCompletionScript
+const zshCompletionScript = `#compdef %[1]s
+
+_%[1]s() {
+  local -a opts
+  local cur
+  cur=${words[-1]}
+  if [[ "$cur" == "-"* ]]; then
+    opts=("${(@f)$(_CLI_ZSH_AUTOCOMPLETE_HACK=1 ${words[@]:0:#words[@]-1} ${cur} --generate-bash-completion 2>/dev/null)}")
+  else
+    opts=("${(@f)$(_CLI_ZSH_AUTOCOMPLETE_HACK=1 ${words[@]:0:#words[@]-1} --generate-bash-completion 2>/dev/null)}")
+  fi
+
+  if [[ "${opts[1]}" != "" ]]; then
+    _describe 'values' opts
+  else
+    _files
+  fi
+}
+
+compdef _%[1]s %[1]s
+`
+
+// completionCommand prints shell completion scripts, e.g. `source <(orchestrator completion bash)`
+var completionCommand = &cli.Command{
+	Name:  "completion",
+	Usage: "Prints the shell completion script of bash, zsh or fish",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "bash",
+			Usage:  "Prints bash completion script, load it by: source <(orchestrator completion bash)",
+			Action: printScriptCompletion(bashCompletionScript),
+		},
+		{
+			Name:   "zsh",
+			Usage:  "Prints zsh completion script, load it by: source <(orchestrator completion zsh)",
+			Action: printScriptCompletion(zshCompletionScript),
+		},
+		{
+			Name:   "fish",
+			Usage:  "Prints fish completion script, load it by: orchestrator completion fish | source",
+			Action: printFishCompletion,
+		},
+	},
+}
+
+func printScriptCompletion(script string) cli.ActionFunc {
+	return func(cliCtx *cli.Context) error {
+		_, err := fmt.Fprintf(cliCtx.App.Writer, script, cliCtx.App.Name)
+		return err
+	}
+}
+
+// printFishCompletion prints completions of all commands and flags, fish does not ask the binary for them
+func printFishCompletion(cliCtx *cli.Context) error {
+	script, err := cliCtx.App.ToFishCompletion()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(cliCtx.App.Writer, script)
+	return err
+}
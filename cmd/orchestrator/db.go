@@ -0,0 +1,209 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/kv"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// dbCommand groups maintenance operations of orchestrator database. They open the database exclusively, so they
+// fail while the node is running. Operations which remove data ask for confirmation unless --force is given.
+var dbCommand = &cli.Command{
+	Name:  "db",
+	Usage: "Inspects and maintains orchestrator database of a stopped node",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "inspect",
+			Usage:  "Prints verification progress markers and number of entries of every bucket as json",
+			Action: inspectDB,
+			Flags:  cmd.WrapFlags([]cli.Flag{cmd.DataDirFlag}),
+		},
+		{
+			Name:   "backup",
+			Usage:  "Writes a consistent copy of the database into the backups directory of the database",
+			Action: backupDB,
+			Flags:  cmd.WrapFlags([]cli.Flag{cmd.DataDirFlag}),
+		},
+		{
+			Name:   "restore",
+			Usage:  "Replaces the database with a copy of the backup file",
+			Action: restoreDB,
+			Flags:  cmd.WrapFlags([]cli.Flag{cmd.DataDirFlag, cmd.BackupFileFlag, cmd.ForceFlag}),
+		},
+		{
+			Name:   "prune",
+			Usage:  "Removes verification results of finalized slots before the given slot",
+			Action: pruneDB,
+			Flags:  cmd.WrapFlags([]cli.Flag{cmd.DataDirFlag, cmd.BeforeSlotFlag, cmd.ForceFlag}),
+		},
+		{
+			Name:   "compact",
+			Usage:  "Copies the database into a new file, which gives space freed by pruning back to the file system",
+			Action: compactDB,
+			Flags:  cmd.WrapFlags([]cli.Flag{cmd.DataDirFlag}),
+		},
+		{
+			Name:   "rollback",
+			Usage:  "Removes verification results after the given slot, so verification continues after it on the next start",
+			Action: rollbackDB,
+			Flags:  cmd.WrapFlags([]cli.Flag{cmd.DataDirFlag, cmd.ToSlotFlag, cmd.ForceFlag}),
+		},
+	},
+}
+
+func inspectDB(cliCtx *cli.Context) error {
+	d, closeDB, err := openDB(cliCtx)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+	inspection, err := d.Inspect()
+	if err != nil {
+		return err
+	}
+	return printJSON(inspection)
+}
+
+func backupDB(cliCtx *cli.Context) error {
+	d, closeDB, err := openDB(cliCtx)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+	result, err := d.Backup(cliCtx.Context)
+	if err != nil {
+		return err
+	}
+	return printJSON(result)
+}
+
+func restoreDB(cliCtx *cli.Context) error {
+	backupFile := cliCtx.String(cmd.BackupFileFlag.Name)
+	if backupFile == "" {
+		return errors.Errorf("%s must be provided", cmd.BackupFileFlag.Name)
+	}
+	// opening the database makes sure that it is not used by a running node
+	_, closeDB, err := openDB(cliCtx)
+	if err != nil {
+		return err
+	}
+	closeDB()
+
+	confirmed, err := confirmDBAction(cliCtx, fmt.Sprintf("This will replace your orchestrator database with backup %s. "+
+		"Verification results stored after the backup are lost - do you want to proceed? (Y/N)", backupFile))
+	if err != nil || !confirmed {
+		return err
+	}
+	return kv.RestoreBackup(dbPath(cliCtx), backupFile)
+}
+
+func pruneDB(cliCtx *cli.Context) error {
+	if !cliCtx.IsSet(cmd.BeforeSlotFlag.Name) {
+		return errors.Errorf("%s must be provided", cmd.BeforeSlotFlag.Name)
+	}
+	beforeSlot := cliCtx.Uint64(cmd.BeforeSlotFlag.Name)
+	d, closeDB, err := openDB(cliCtx)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	confirmed, err := confirmDBAction(cliCtx, fmt.Sprintf("This will remove verification results of the slots "+
+		"before slot %d from your orchestrator database - do you want to proceed? (Y/N)", beforeSlot))
+	if err != nil || !confirmed {
+		return err
+	}
+	result, err := d.PruneBefore(beforeSlot)
+	if err != nil {
+		return err
+	}
+	log.Info("Run db compact to give the freed space back to the file system")
+	return printJSON(result)
+}
+
+// compactDB schedules compaction and opens the database again, compaction runs when the database is opened
+func compactDB(cliCtx *cli.Context) error {
+	d, closeDB, err := openDB(cliCtx)
+	if err != nil {
+		return err
+	}
+	err = d.ScheduleCompaction()
+	closeDB()
+	if err != nil {
+		return err
+	}
+	_, closeDB, err = openDB(cliCtx)
+	if err != nil {
+		return err
+	}
+	closeDB()
+	return nil
+}
+
+func rollbackDB(cliCtx *cli.Context) error {
+	if !cliCtx.IsSet(cmd.ToSlotFlag.Name) {
+		return errors.Errorf("%s must be provided", cmd.ToSlotFlag.Name)
+	}
+	toSlot := cliCtx.Uint64(cmd.ToSlotFlag.Name)
+	d, closeDB, err := openDB(cliCtx)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	confirmed, err := confirmDBAction(cliCtx, fmt.Sprintf("This will remove verification results after slot %d "+
+		"from your orchestrator database - do you want to proceed? (Y/N)", toSlot))
+	if err != nil || !confirmed {
+		return err
+	}
+	return d.RollbackTo(toSlot)
+}
+
+// dbPath resolves the database directory from the data directory given to the db command or to the node, e.g.
+// by config file
+func dbPath(cliCtx *cli.Context) string {
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+	for _, ctx := range cliCtx.Lineage() {
+		if ctx.IsSet(cmd.DataDirFlag.Name) {
+			dataDir = ctx.String(cmd.DataDirFlag.Name)
+			break
+		}
+	}
+	return filepath.Join(dataDir, kv.OrchestratorNodeDbDirName)
+}
+
+// openDB opens orchestrator database, it fails while the database is used by a running node
+func openDB(cliCtx *cli.Context) (db.Database, func(), error) {
+	path := dbPath(cliCtx)
+	d, err := db.NewDB(cliCtx.Context, path, &kv.Config{})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not open orchestrator database at %s", path)
+	}
+	closeDB := func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database")
+		}
+	}
+	return d, closeDB, nil
+}
+
+// confirmDBAction asks for confirmation of an operation which removes data, unless --force is given
+func confirmDBAction(cliCtx *cli.Context, actionText string) (bool, error) {
+	if cliCtx.Bool(cmd.ForceFlag.Name) {
+		return true, nil
+	}
+	return cmd.ConfirmAction(cliCtx, actionText, "Database has not been changed.")
+}
+
+func printJSON(value interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
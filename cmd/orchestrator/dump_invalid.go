@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/kv"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// dumpInvalidCommand prints mismatch evidence of rejected slots from orchestrator database
+var dumpInvalidCommand = &cli.Command{
+	Name:   "dump-invalid",
+	Usage:  "Prints vanguard shard info and pandora header of the slots which have been rejected by verification as json",
+	Action: dumpInvalid,
+	Flags: cmd.WrapFlags([]cli.Flag{
+		cmd.DataDirFlag,
+		cmd.FromSlotFlag,
+		cmd.ToSlotFlag,
+	}),
+}
+
+// dumpInvalid reads mismatch evidences in the given slot range and writes them to stdout, one per line
+func dumpInvalid(cliCtx *cli.Context) error {
+	fromSlot := cliCtx.Uint64(cmd.FromSlotFlag.Name)
+	toSlot := cliCtx.Uint64(cmd.ToSlotFlag.Name)
+	if fromSlot > toSlot {
+		return errors.New("from-slot must not be greater than to-slot")
+	}
+
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.OrchestratorNodeDbDirName)
+	d, err := db.NewDB(cliCtx.Context, dbPath, &kv.Config{})
+	if err != nil {
+		return errors.Wrap(err, "could not open orchestrator database")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database")
+		}
+	}()
+
+	evidences, err := d.MismatchEvidences(fromSlot, toSlot)
+	if err != nil {
+		return errors.Wrap(err, "could not read mismatch evidences")
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	for _, evidence := range evidences {
+		if err := encoder.Encode(evidence); err != nil {
+			return err
+		}
+	}
+	log.WithField("fromSlot", fromSlot).WithField("toSlot", toSlot).
+		WithField("invalidSlots", len(evidences)).Info("Dumped mismatch evidences")
+	return nil
+}
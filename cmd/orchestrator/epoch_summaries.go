@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/kv"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// epochSummariesCommand prints stored epoch summaries from orchestrator database
+var epochSummariesCommand = &cli.Command{
+	Name:   "epoch-summaries",
+	Usage:  "Prints per-epoch summaries of verified, skipped and invalid slots, confirmation latency and proposer participation as json",
+	Action: epochSummaries,
+	Flags: cmd.WrapFlags([]cli.Flag{
+		cmd.DataDirFlag,
+		cmd.FromEpochFlag,
+		cmd.ToEpochFlag,
+	}),
+}
+
+// epochSummaries reads epoch summaries in the given epoch range and writes them to stdout, one per line
+func epochSummaries(cliCtx *cli.Context) error {
+	fromEpoch := cliCtx.Uint64(cmd.FromEpochFlag.Name)
+	toEpoch := cliCtx.Uint64(cmd.ToEpochFlag.Name)
+	if fromEpoch > toEpoch {
+		return errors.New("from-epoch must not be greater than to-epoch")
+	}
+
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.OrchestratorNodeDbDirName)
+	d, err := db.NewDB(cliCtx.Context, dbPath, &kv.Config{})
+	if err != nil {
+		return errors.Wrap(err, "could not open orchestrator database")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database")
+		}
+	}()
+
+	summaries, err := d.EpochSummaries(fromEpoch, toEpoch)
+	if err != nil {
+		return errors.Wrap(err, "could not read epoch summaries")
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	for _, summary := range summaries {
+		if err := encoder.Encode(summary); err != nil {
+			return err
+		}
+	}
+	log.WithField("fromEpoch", fromEpoch).WithField("toEpoch", toEpoch).
+		WithField("epochs", len(summaries)).Info("Dumped epoch summaries")
+	return nil
+}
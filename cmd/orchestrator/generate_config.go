@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"github.com/urfave/cli/v2/altsrc"
+)
+
+const configTemplateHeader = `# Config file of orchestrator client, it is loaded with --%s flag.
+# Keys are flag names, flags given on the command line take precedence over this file.
+# Every flag is listed with its default value, uncomment a flag to change it.
+`
+
+// generateConfigCommand writes a config file template with all flags of the node
+var generateConfigCommand = &cli.Command{
+	Name:   "generate-config",
+	Usage:  "Writes a commented yaml or toml config file template which lists every flag with its default value",
+	Action: generateConfig,
+	Flags: cmd.WrapFlags([]cli.Flag{
+		cmd.ConfigFormatFlag,
+		cmd.ConfigOutputFlag,
+	}),
+}
+
+// generateConfig writes the config file template to the output file or stdout
+func generateConfig(cliCtx *cli.Context) error {
+	format := cliCtx.String(cmd.ConfigFormatFlag.Name)
+	if format != "yaml" && format != "toml" {
+		return errors.Errorf("unknown config format %s", format)
+	}
+	var buf bytes.Buffer
+	writeConfigTemplate(&buf, format)
+
+	output := cliCtx.String(cmd.ConfigOutputFlag.Name)
+	if output == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	if err := fileutil.WriteFileAtomic(output, buf.Bytes(), 0600); err != nil {
+		return errors.Wrap(err, "could not write config file")
+	}
+	log.WithField("path", output).WithField("format", format).Info("Wrote config file template")
+	return nil
+}
+
+// writeConfigTemplate writes the flags of the node grouped like the help output
+func writeConfigTemplate(w io.Writer, format string) {
+	remaining := make(map[string]bool, len(appFlags))
+	for _, f := range appFlags {
+		remaining[f.Names()[0]] = true
+	}
+	delete(remaining, cmd.ConfigFileFlag.Name)
+
+	fmt.Fprintf(w, configTemplateHeader, cmd.ConfigFileFlag.Name)
+	for _, group := range appHelpFlagGroups {
+		var flags []cli.Flag
+		for _, f := range group.Flags {
+			if remaining[f.Names()[0]] {
+				flags = append(flags, f)
+				delete(remaining, f.Names()[0])
+			}
+		}
+		writeConfigGroup(w, format, group.Name, flags)
+	}
+
+	var others []cli.Flag
+	for _, f := range appFlags {
+		if remaining[f.Names()[0]] {
+			others = append(others, f)
+		}
+	}
+	writeConfigGroup(w, format, "other", others)
+}
+
+func writeConfigGroup(w io.Writer, format, name string, flags []cli.Flag) {
+	if len(flags) == 0 {
+		return
+	}
+	sort.Sort(cli.FlagsByName(flags))
+	fmt.Fprintf(w, "\n# %s OPTIONS\n", strings.ToUpper(name))
+	for _, f := range flags {
+		f = unwrapFlag(f)
+		key := f.Names()[0]
+		if format == "toml" {
+			// flag names contain dots, which separate tables of unquoted toml keys
+			key = strconv.Quote(key) + " ="
+		} else {
+			key += ":"
+		}
+		if docFlag, ok := f.(cli.DocGenerationFlag); ok {
+			fmt.Fprintf(w, "\n# %s\n", docFlag.GetUsage())
+		}
+		fmt.Fprintf(w, "# %s %s\n", key, configDefault(f))
+	}
+}
+
+// configDefault formats the default value of a flag, the format is valid in both yaml and toml
+func configDefault(f cli.Flag) string {
+	switch t := f.(type) {
+	case *cli.BoolFlag:
+		return strconv.FormatBool(t.Value)
+	case *cli.IntFlag, *cli.Int64Flag, *cli.UintFlag, *cli.Uint64Flag, *cli.Float64Flag:
+		return t.(cli.DocGenerationFlag).GetValue()
+	case *cli.StringSliceFlag:
+		var values []string
+		if t.Value != nil {
+			values = t.Value.Value()
+		}
+		quoted := make([]string, 0, len(values))
+		for _, value := range values {
+			quoted = append(quoted, strconv.Quote(value))
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	case cli.DocGenerationFlag:
+		return strconv.Quote(t.GetValue())
+	}
+	return `""`
+}
+
+// unwrapFlag returns the flag which is wrapped by WrapFlags
+func unwrapFlag(f cli.Flag) cli.Flag {
+	switch t := f.(type) {
+	case *altsrc.BoolFlag:
+		return t.BoolFlag
+	case *altsrc.DurationFlag:
+		return t.DurationFlag
+	case *altsrc.GenericFlag:
+		return t.GenericFlag
+	case *altsrc.Float64Flag:
+		return t.Float64Flag
+	case *altsrc.IntFlag:
+		return t.IntFlag
+	case *altsrc.StringFlag:
+		return t.StringFlag
+	case *altsrc.StringSliceFlag:
+		return t.StringSliceFlag
+	case *altsrc.Uint64Flag:
+		return t.Uint64Flag
+	case *altsrc.UintFlag:
+		return t.UintFlag
+	}
+	return f
+}
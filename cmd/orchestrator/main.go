@@ -7,6 +7,8 @@ import (
 	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
 	"github.com/lukso-network/lukso-orchestrator/shared/journald"
 	"github.com/lukso-network/lukso-orchestrator/shared/logutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/sentry"
 	"github.com/lukso-network/lukso-orchestrator/shared/version"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
@@ -18,20 +20,99 @@ import (
 
 var appFlags = []cli.Flag{
 	cmd.VanguardGRPCEndpoint,
+	cmd.VanguardTLSCACertFlag,
+	cmd.VanguardTLSClientCertFlag,
+	cmd.VanguardTLSClientKeyFlag,
 	cmd.PandoraRPCEndpoint,
+	cmd.PandoraRPCNamespace,
+	cmd.PandoraSubscriptionMethod,
+	cmd.PandoraChainID,
+	cmd.PendingTimeoutFlag,
+	cmd.VerificationWorkersFlag,
+	cmd.VerificationBatchSizeFlag,
+	cmd.ConfirmationDepthFlag,
+	cmd.OrphanMaxAgeFlag,
+	cmd.DivergenceWarnSlotsFlag,
+	cmd.DivergenceAlertSlotsFlag,
+	cmd.ClockSkewThresholdFlag,
+	cmd.AlertWebhookFlag,
+	cmd.RPCJWTSecretFlag,
+	cmd.RPCAdminFlag,
+	cmd.RPCTLSCertFlag,
+	cmd.RPCTLSKeyFlag,
+	cmd.RPCAPIKeysFileFlag,
+	cmd.RPCAllowUnprotectedFlag,
+	cmd.RPCSocketActivationFlag,
+	cmd.RPCRateLimitFlag,
+	cmd.RPCRateLimitBurstFlag,
+	cmd.RPCExpensiveRateLimitFlag,
+	cmd.RPCExpensiveRateLimitBurstFlag,
+	cmd.RPCShutdownTimeoutFlag,
+	cmd.RPCSlowThresholdFlag,
+	cmd.RPCLogFlag,
+	cmd.RPCLogVerbosityFlag,
+	cmd.RPCMaxPageSizeFlag,
+	cmd.RPCSubscriptionBufferSizeFlag,
+	cmd.RPCSubscriptionBufferPolicyFlag,
+	cmd.RelayBufferSizeFlag,
+	cmd.RelayBufferPolicyFlag,
+	cmd.ReadinessMaxLagFlag,
+	cmd.MetricsEnabledFlag,
+	cmd.MetricsHostFlag,
+	cmd.MetricsPortFlag,
+	cmd.PprofFlag,
+	cmd.TracingEndpointFlag,
+	cmd.SentryDSNFlag,
+	cmd.ReplayFlag,
+	cmd.ShutdownTimeoutFlag,
+	cmd.PIDFileFlag,
+	cmd.NetworksFileFlag,
+	cmd.SyncFromFlag,
+	cmd.SyncAPIKeyFlag,
+	cmd.HALeaseFileFlag,
+	cmd.HANodeIDFlag,
+	cmd.HALeaseDurationFlag,
+	cmd.FollowerFlag,
+	cmd.StatusLogPeriodFlag,
+	cmd.MemoryLimitFlag,
 	cmd.VerbosityFlag,
 	cmd.IPCPathFlag,
+	cmd.IPCFileFlag,
+	cmd.IPCPermissionsFlag,
+	cmd.IPCGroupFlag,
 	cmd.HTTPEnabledFlag,
 	cmd.HTTPListenAddrFlag,
 	cmd.HTTPPortFlag,
+	cmd.HTTPCORSDomainsFlag,
+	cmd.HTTPVirtualHostsFlag,
+	cmd.HTTPCompressionFlag,
+	cmd.GraphQLEnabledFlag,
+	cmd.DashboardEnabledFlag,
 	cmd.WSEnabledFlag,
 	cmd.WSListenAddrFlag,
 	cmd.WSPortFlag,
+	cmd.WSMaxConnectionsFlag,
+	cmd.WSMaxSubscriptionsFlag,
+	cmd.WSIdleTimeoutFlag,
+	cmd.WSCompressionFlag,
 	cmd.DataDirFlag,
+	cmd.L15Flag,
+	cmd.L16Flag,
+	cmd.DevFlag,
+	cmd.SlotsPerEpochFlag,
+	cmd.SecondsPerSlotFlag,
+	cmd.GenesisTimeFlag,
 	cmd.ClearDB,
 	cmd.ForceClearDB,
+	cmd.NonInteractiveFlag,
 	cmd.LogFileName,
+	cmd.LogMaxSizeFlag,
+	cmd.LogMaxBackupsFlag,
+	cmd.AuditLogFlag,
+	cmd.AuditLogMaxSizeFlag,
+	cmd.AuditLogMaxBackupsFlag,
 	cmd.LogFormat,
+	cmd.ConfigFileFlag,
 }
 
 func init() {
@@ -44,9 +125,38 @@ func main() {
 	app.Usage = "Orchestrator client orchestrates pandora and vanguard client"
 	app.Action = startNode
 	app.Version = version.Version()
+	// completions of subcommands and flags are requested by completion scripts with --generate-bash-completion
+	app.EnableBashCompletion = true
 
-	app.Flags = appFlags
+	app.Flags = cmd.WithDeprecatedFlags(appFlags)
+	app.Commands = []*cli.Command{
+		dumpInvalidCommand,
+		epochSummariesCommand,
+		reverifyCommand,
+		benchmarkCommand,
+		generateConfigCommand,
+		dbCommand,
+		migrateDataDirCommand,
+		validateConfigCommand,
+		versionCommand,
+		completionCommand,
+	}
+	app.Commands = append(app.Commands, platformCommands...)
 	app.Before = func(ctx *cli.Context) error {
+		// flags of config file must be loaded before any flag is read
+		if err := cmd.LoadFlagsFromConfig(ctx, ctx.App.Flags); err != nil {
+			return err
+		}
+		if err := cmd.ApplyDeprecatedFlags(ctx); err != nil {
+			return err
+		}
+		if err := cmd.ApplyNetworkPreset(ctx); err != nil {
+			return err
+		}
+		if err := cmd.ApplyChainConfig(ctx); err != nil {
+			return err
+		}
+
 		format := ctx.String(cmd.LogFormat.Name)
 		switch format {
 		case "text":
@@ -64,7 +174,7 @@ func main() {
 			}
 			logrus.SetFormatter(f)
 		case "json":
-			logrus.SetFormatter(&logrus.JSONFormatter{})
+			logrus.SetFormatter(logutil.NewJSONFormatter())
 		case "journald":
 			if err := journald.Enable(); err != nil {
 				return err
@@ -75,7 +185,8 @@ func main() {
 
 		logFileName := ctx.String(cmd.LogFileName.Name)
 		if logFileName != "" {
-			if err := logutil.ConfigurePersistentLogging(logFileName); err != nil {
+			maxSize, maxBackups := ctx.Int(cmd.LogMaxSizeFlag.Name), ctx.Int(cmd.LogMaxBackupsFlag.Name)
+			if err := logutil.ConfigurePersistentLogging(logFileName, maxSize, maxBackups); err != nil {
 				log.WithError(err).Error("Failed to configuring logging to disk.")
 			}
 		}
@@ -86,7 +197,9 @@ func main() {
 
 	defer func() {
 		if x := recover(); x != nil {
-			log.Errorf("Runtime panic: %v\n%v", x, string(runtimeDebug.Stack()))
+			stack := runtimeDebug.Stack()
+			log.Errorf("Runtime panic: %v\n%v", x, string(stack))
+			sentry.CapturePanic(x, stack)
 			panic(x)
 		}
 	}()
@@ -98,17 +211,22 @@ func main() {
 
 // startNode
 func startNode(ctx *cli.Context) error {
-	verbosity := ctx.String(cmd.VerbosityFlag.Name)
-	level, err := logrus.ParseLevel(verbosity)
-	if err != nil {
+	if err := logutil.SetVerbosity(ctx.String(cmd.VerbosityFlag.Name)); err != nil {
 		return err
 	}
-	logrus.SetLevel(level)
+
+	// metrics must be enabled before any service registers its metrics
+	if ctx.Bool(cmd.MetricsEnabledFlag.Name) {
+		metrics.Enable()
+	}
+
+	if ctx.Bool(cmd.ReplayFlag.Name) {
+		return replay(ctx)
+	}
 
 	orchestrator, err := node.New(ctx)
 	if err != nil {
 		return err
 	}
-	orchestrator.Start()
-	return nil
+	return runNode(orchestrator)
 }
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/kv"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// migrateDataDirCommand moves the database of a previous data directory layout to where the node looks for it
+var migrateDataDirCommand = &cli.Command{
+	Name: "migrate-datadir",
+	Usage: "Moves the database of a previous data directory layout into the current layout, the database is " +
+		"backed up before it is moved",
+	Action: migrateDataDir,
+	Flags:  cmd.WrapFlags([]cli.Flag{cmd.ForceFlag}),
+}
+
+// legacyLayout is a location of the database in data directories of previous releases
+type legacyLayout struct {
+	description string
+	// dbDir returns the database directory of the layout within the data directory which has been given by the
+	// user, it returns empty string when the layout does not apply to the network
+	dbDir func(baseDir string, network *params.NetworkConfig) string
+}
+
+var legacyLayouts = []legacyLayout{
+	{
+		description: "database file in the root of data directory",
+		dbDir: func(baseDir string, _ *params.NetworkConfig) string {
+			return baseDir
+		},
+	},
+	{
+		description: "database of a network preset outside of the network subfolder",
+		dbDir: func(baseDir string, network *params.NetworkConfig) string {
+			if network.Name == "" {
+				return ""
+			}
+			return filepath.Join(baseDir, kv.OrchestratorNodeDbDirName)
+		},
+	},
+}
+
+func migrateDataDir(cliCtx *cli.Context) error {
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+	network := params.ActiveNetworkConfig()
+	baseDir := dataDir
+	if network.Name != "" {
+		// network presets store data in a subfolder of the given data directory
+		baseDir = filepath.Dir(dataDir)
+	}
+	targetDir := filepath.Join(dataDir, kv.OrchestratorNodeDbDirName)
+	target := filepath.Join(targetDir, kv.DatabaseFileName)
+
+	var legacyDir string
+	var layout legacyLayout
+	for _, l := range legacyLayouts {
+		dir := l.dbDir(baseDir, network)
+		if dir != "" && dir != targetDir && fileutil.FileExists(filepath.Join(dir, kv.DatabaseFileName)) {
+			legacyDir, layout = dir, l
+			break
+		}
+	}
+	if legacyDir == "" {
+		log.WithField("path", target).Info("No database of a previous data directory layout found, nothing to migrate")
+		return nil
+	}
+	source := filepath.Join(legacyDir, kv.DatabaseFileName)
+	if fileutil.FileExists(target) {
+		return errors.Errorf("found %s at %s, but database already exists at %s. Remove or move one of them manually",
+			layout.description, source, target)
+	}
+
+	confirmed, err := confirmDBAction(cliCtx, fmt.Sprintf("Found %s at %s. This will move it to %s - do you "+
+		"want to proceed? (Y/N)", layout.description, source, target))
+	if err != nil || !confirmed {
+		return err
+	}
+
+	// opening the database makes sure that it is valid and not used by a running node
+	d, err := db.NewDB(cliCtx.Context, legacyDir, &kv.Config{})
+	if err != nil {
+		return errors.Wrapf(err, "could not open database at %s", source)
+	}
+	backup, err := d.Backup(cliCtx.Context)
+	if closeErr := d.Close(); closeErr != nil {
+		log.WithError(closeErr).Error("Failed to close database")
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := fileutil.MkdirAll(targetDir); err != nil {
+		return errors.Wrap(err, "could not create database directory")
+	}
+	if err := fileutil.ReplaceFile(source, target); err != nil {
+		return errors.Wrap(err, "could not move database")
+	}
+	log.WithField("from", source).WithField("to", target).WithField("backup", backup.Path).
+		Info("Migrated database into current data directory layout")
+	return nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/consensus"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/kv"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// replay runs the consensus service over the orchestrator database only and prints the replay summary as json
+func replay(cliCtx *cli.Context) error {
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.OrchestratorNodeDbDirName)
+	d, err := db.NewDB(cliCtx.Context, dbPath, &kv.Config{
+		InitialMMapSize: cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not open orchestrator database")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database")
+		}
+	}()
+
+	// no chain services are given, replay must not reach any network
+	svc := consensus.New(cliCtx.Context, &consensus.Config{
+		VerifiedSlotInfoDB: d,
+		InvalidSlotInfoDB:  d,
+	})
+	summary, err := svc.Replay()
+	if err != nil {
+		return errors.Wrap(err, "could not replay verification results")
+	}
+	return json.NewEncoder(os.Stdout).Encode(summary)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	ethRpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// reverifyCommand asks a running orchestrator node to re-verify a slot range over IPC
+var reverifyCommand = &cli.Command{
+	Name:   "reverify",
+	Usage:  "Refetches a slot range from both chains and recomputes verification on a running orchestrator node",
+	Action: reverify,
+	Flags: cmd.WrapFlags([]cli.Flag{
+		cmd.IPCPathFlag,
+		cmd.IPCFileFlag,
+		cmd.FromSlotFlag,
+		cmd.ToSlotFlag,
+	}),
+}
+
+// reverify calls `admin_reverify` of the running orchestrator node and prints the result as json
+func reverify(cliCtx *cli.Context) error {
+	ipcEndpoint := cmd.IpcEndpoint(cliCtx)
+	if ipcEndpoint == "" {
+		return errors.New("ipcpath or ipc-file of the running orchestrator node must be provided")
+	}
+	if !cliCtx.IsSet(cmd.ToSlotFlag.Name) {
+		return errors.New("to-slot must be provided")
+	}
+	if err := fileutil.ValidateIpcEndpoint(ipcEndpoint); err != nil {
+		return err
+	}
+
+	client, err := ethRpc.DialIPC(cliCtx.Context, ipcEndpoint)
+	if err != nil {
+		return errors.Wrap(err, "could not connect to orchestrator node")
+	}
+	defer client.Close()
+
+	var result *types.ReverifyResult
+	fromSlot := cliCtx.Uint64(cmd.FromSlotFlag.Name)
+	toSlot := cliCtx.Uint64(cmd.ToSlotFlag.Name)
+	if err := client.CallContext(cliCtx.Context, &result, "admin_reverify", fromSlot, toSlot); err != nil {
+		return errors.Wrap(err, "could not re-verify slot range")
+	}
+	return json.NewEncoder(os.Stdout).Encode(result)
+}
@@ -0,0 +1,17 @@
+// +build !windows
+
+package main
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/node"
+	"github.com/urfave/cli/v2"
+)
+
+// platformCommands are the commands which are only available on this platform, windows service is
+// managed on windows only
+var platformCommands []*cli.Command
+
+// runNode runs the node in the foreground until it is closed
+func runNode(orchestrator *node.OrchestratorNode) error {
+	return orchestrator.Start()
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/node"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+const (
+	windowsServiceName        = "lukso-orchestrator"
+	windowsServiceDisplayName = "LUKSO Orchestrator"
+	// time to wait for the service to reach the requested state
+	windowsServiceStateTimeout = time.Minute
+)
+
+// windowsServiceCommand manages the windows service which runs the orchestrator node
+var windowsServiceCommand = &cli.Command{
+	Name:  "windows-service",
+	Usage: "Installs, removes, starts and stops the windows service of orchestrator node",
+	Subcommands: []*cli.Command{
+		{
+			Name: "install",
+			Usage: "Installs the windows service, arguments after install are the flags of the node, e.g. " +
+				"install --datadir C:\\orchestrator --log-file C:\\orchestrator\\orchestrator.log",
+			SkipFlagParsing: true,
+			Action:          installWindowsService,
+		},
+		{
+			Name:   "uninstall",
+			Usage:  "Removes the windows service",
+			Action: uninstallWindowsService,
+		},
+		{
+			Name:   "start",
+			Usage:  "Starts the installed windows service",
+			Action: startWindowsService,
+		},
+		{
+			Name:   "stop",
+			Usage:  "Stops the running windows service",
+			Action: stopWindowsService,
+		},
+	},
+}
+
+// platformCommands are the commands which are only available on this platform
+var platformCommands = []*cli.Command{windowsServiceCommand}
+
+// runNode runs the node as windows service when it is started by the service control manager, or in the
+// foreground otherwise
+func runNode(orchestrator *node.OrchestratorNode) error {
+	isService, err := svc.IsWindowsService()
+	if err != nil {
+		return errors.Wrap(err, "could not detect windows service")
+	}
+	if !isService {
+		return orchestrator.Start()
+	}
+	handler := &windowsServiceHandler{node: orchestrator}
+	if err := svc.Run(windowsServiceName, handler); err != nil {
+		return err
+	}
+	return handler.err
+}
+
+// windowsServiceHandler maps requests of the service control manager to Start and Close of the node
+type windowsServiceHandler struct {
+	node *node.OrchestratorNode
+	err  error
+}
+
+func (h *windowsServiceHandler) Execute(_ []string, requests <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	status <- svc.Status{State: svc.StartPending}
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- h.node.Start()
+	}()
+	status <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+
+	for {
+		select {
+		case err := <-stopped:
+			return h.exit(err)
+		case request := <-requests:
+			switch request.Cmd {
+			case svc.Interrogate:
+				status <- request.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				log.Info("Windows service is stopping")
+				status <- svc.Status{State: svc.StopPending}
+				h.node.Close()
+				return h.exit(<-stopped)
+			}
+		}
+	}
+}
+
+// exit reports the failure of the node as service specific exit code
+func (h *windowsServiceHandler) exit(err error) (bool, uint32) {
+	if err != nil {
+		h.err = err
+		return true, 1
+	}
+	return false, 0
+}
+
+func installWindowsService(cliCtx *cli.Context) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "could not find orchestrator executable")
+	}
+	manager, err := mgr.Connect()
+	if err != nil {
+		return errors.Wrap(err, "could not connect to service control manager")
+	}
+	defer manager.Disconnect()
+
+	if service, err := manager.OpenService(windowsServiceName); err == nil {
+		service.Close()
+		return errors.Errorf("windows service %s is already installed", windowsServiceName)
+	}
+	service, err := manager.CreateService(windowsServiceName, exe, mgr.Config{
+		DisplayName: windowsServiceDisplayName,
+		Description: "Verifies vanguard and pandora chains of LUKSO network",
+		StartType:   mgr.StartAutomatic,
+	}, cliCtx.Args().Slice()...)
+	if err != nil {
+		return errors.Wrap(err, "could not install windows service")
+	}
+	defer service.Close()
+	log.WithField("service", windowsServiceName).WithField("args", cliCtx.Args().Slice()).
+		Info("Installed windows service")
+	return nil
+}
+
+func uninstallWindowsService(*cli.Context) error {
+	return withWindowsService(func(service *mgr.Service) error {
+		if err := service.Delete(); err != nil {
+			return errors.Wrap(err, "could not remove windows service")
+		}
+		log.WithField("service", windowsServiceName).Info("Removed windows service")
+		return nil
+	})
+}
+
+func startWindowsService(*cli.Context) error {
+	return withWindowsService(func(service *mgr.Service) error {
+		if err := service.Start(); err != nil {
+			return errors.Wrap(err, "could not start windows service")
+		}
+		if err := waitForWindowsService(service, svc.Running); err != nil {
+			return err
+		}
+		log.WithField("service", windowsServiceName).Info("Started windows service")
+		return nil
+	})
+}
+
+func stopWindowsService(*cli.Context) error {
+	return withWindowsService(func(service *mgr.Service) error {
+		if _, err := service.Control(svc.Stop); err != nil {
+			return errors.Wrap(err, "could not stop windows service")
+		}
+		if err := waitForWindowsService(service, svc.Stopped); err != nil {
+			return err
+		}
+		log.WithField("service", windowsServiceName).Info("Stopped windows service")
+		return nil
+	})
+}
+
+// withWindowsService opens the installed service of orchestrator node
+func withWindowsService(fn func(service *mgr.Service) error) error {
+	manager, err := mgr.Connect()
+	if err != nil {
+		return errors.Wrap(err, "could not connect to service control manager")
+	}
+	defer manager.Disconnect()
+	service, err := manager.OpenService(windowsServiceName)
+	if err != nil {
+		return errors.Wrapf(err, "windows service %s is not installed", windowsServiceName)
+	}
+	defer service.Close()
+	return fn(service)
+}
+
+// waitForWindowsService waits until the service reaches the state
+func waitForWindowsService(service *mgr.Service, state svc.State) error {
+	deadline := time.Now().Add(windowsServiceStateTimeout)
+	for {
+		status, err := service.Query()
+		if err != nil {
+			return errors.Wrap(err, "could not query windows service")
+		}
+		if status.State == state {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.Errorf("windows service has not reached state %d in %v", state, windowsServiceStateTimeout)
+		}
+		time.Sleep(300 * time.Millisecond)
+	}
+}
@@ -41,25 +41,104 @@ var appHelpFlagGroups = []flagGroup{
 	{
 		Name: "cmd",
 		Flags: []cli.Flag{
+			cmd.ConfigFileFlag,
 			cmd.DataDirFlag,
+			cmd.L15Flag,
+			cmd.L16Flag,
+			cmd.DevFlag,
+			cmd.SlotsPerEpochFlag,
+			cmd.SecondsPerSlotFlag,
+			cmd.GenesisTimeFlag,
 			cmd.VerbosityFlag,
 			cmd.ForceClearDB,
+			cmd.NonInteractiveFlag,
 			cmd.ClearDB,
 			cmd.BoltMMapInitialSizeFlag,
+			cmd.PendingTimeoutFlag,
+			cmd.VerificationWorkersFlag,
+			cmd.VerificationBatchSizeFlag,
+			cmd.ConfirmationDepthFlag,
+			cmd.OrphanMaxAgeFlag,
+			cmd.DivergenceWarnSlotsFlag,
+			cmd.DivergenceAlertSlotsFlag,
+			cmd.ClockSkewThresholdFlag,
+			cmd.ReplayFlag,
+			cmd.ShutdownTimeoutFlag,
+			cmd.PIDFileFlag,
+			cmd.NetworksFileFlag,
+			cmd.SyncFromFlag,
+			cmd.SyncAPIKeyFlag,
+			cmd.HALeaseFileFlag,
+			cmd.HANodeIDFlag,
+			cmd.HALeaseDurationFlag,
+			cmd.FollowerFlag,
+			cmd.StatusLogPeriodFlag,
+			cmd.MemoryLimitFlag,
 		},
 	},
 	{
 		Name: "orchestrator",
 		Flags: []cli.Flag{
 			cmd.IPCPathFlag,
+			cmd.IPCFileFlag,
+			cmd.IPCPermissionsFlag,
+			cmd.IPCGroupFlag,
 			cmd.HTTPEnabledFlag,
 			cmd.HTTPListenAddrFlag,
 			cmd.HTTPPortFlag,
+			cmd.HTTPCORSDomainsFlag,
+			cmd.HTTPVirtualHostsFlag,
+			cmd.HTTPCompressionFlag,
+			cmd.GraphQLEnabledFlag,
+			cmd.DashboardEnabledFlag,
+			cmd.ReadinessMaxLagFlag,
 			cmd.WSEnabledFlag,
 			cmd.WSListenAddrFlag,
 			cmd.WSPortFlag,
+			cmd.WSMaxConnectionsFlag,
+			cmd.WSMaxSubscriptionsFlag,
+			cmd.WSIdleTimeoutFlag,
+			cmd.WSCompressionFlag,
+			cmd.RPCJWTSecretFlag,
+			cmd.RPCAdminFlag,
+			cmd.RPCTLSCertFlag,
+			cmd.RPCTLSKeyFlag,
+			cmd.RPCAPIKeysFileFlag,
+			cmd.RPCAllowUnprotectedFlag,
+			cmd.RPCSocketActivationFlag,
+			cmd.RPCRateLimitFlag,
+			cmd.RPCRateLimitBurstFlag,
+			cmd.RPCExpensiveRateLimitFlag,
+			cmd.RPCExpensiveRateLimitBurstFlag,
+			cmd.RPCShutdownTimeoutFlag,
+			cmd.RPCSlowThresholdFlag,
+			cmd.RPCLogFlag,
+			cmd.RPCLogVerbosityFlag,
+			cmd.RPCMaxPageSizeFlag,
+			cmd.RPCSubscriptionBufferSizeFlag,
+			cmd.RPCSubscriptionBufferPolicyFlag,
+			cmd.RelayBufferSizeFlag,
+			cmd.RelayBufferPolicyFlag,
 			cmd.VanguardGRPCEndpoint,
+			cmd.VanguardTLSCACertFlag,
+			cmd.VanguardTLSClientCertFlag,
+			cmd.VanguardTLSClientKeyFlag,
 			cmd.PandoraRPCEndpoint,
+			cmd.PandoraRPCNamespace,
+			cmd.PandoraSubscriptionMethod,
+			cmd.PandoraChainID,
+			cmd.AlertWebhookFlag,
+		},
+	},
+	{
+		Name: "metrics",
+		Flags: []cli.Flag{
+			cmd.MetricsEnabledFlag,
+			cmd.MetricsHostFlag,
+			cmd.MetricsPortFlag,
+			cmd.PprofFlag,
+			cmd.TracingEndpointFlag,
+			cmd.SentryDSNFlag,
 		},
 	},
 	{
@@ -67,6 +146,11 @@ var appHelpFlagGroups = []flagGroup{
 		Flags: []cli.Flag{
 			cmd.LogFormat,
 			cmd.LogFileName,
+			cmd.LogMaxSizeFlag,
+			cmd.LogMaxBackupsFlag,
+			cmd.AuditLogFlag,
+			cmd.AuditLogMaxSizeFlag,
+			cmd.AuditLogMaxBackupsFlag,
 		},
 	},
 }
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/pandorachain"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/logutil"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// time to wait for a vanguard or pandora node to respond while the config is validated
+var endpointCheckTimeout = 5 * time.Second
+
+// validateConfigCommand checks the configuration of the node without starting it
+var validateConfigCommand = &cli.Command{
+	Name: "validate-config",
+	Usage: "Checks flags and config file, datadir writability, reachability of vanguard and pandora nodes and " +
+		"pandora chain id, then prints a report without starting the node. Exits with an error when a check fails",
+	Action: validateConfig,
+}
+
+// configCheck is a single check of the configuration, it returns the detail of a passed check
+type configCheck struct {
+	name  string
+	check func(ctx context.Context, cliCtx *cli.Context) (string, error)
+}
+
+var configChecks = []configCheck{
+	{name: "verbosity", check: checkVerbosity},
+	{name: "datadir", check: checkDataDir},
+	{name: "ipc", check: checkIPCEndpoint},
+	{name: "rpc-jwt-secret", check: checkJWTSecret},
+	{name: "rpc-api-keys", check: checkAPIKeys},
+	{name: "vanguard", check: checkVanguardEndpoint},
+	{name: "pandora", check: checkPandoraEndpoint},
+}
+
+// validateConfig runs all checks of the configuration and prints the report to stdout. Flags of the config file
+// have already been loaded, so they are checked as well.
+func validateConfig(cliCtx *cli.Context) error {
+	if failed := runConfigChecks(cliCtx.Context, cliCtx, configChecks, os.Stdout); failed > 0 {
+		return cli.Exit(fmt.Sprintf("config validation failed, %d of %d checks failed", failed, len(configChecks)), 1)
+	}
+	return nil
+}
+
+// runConfigChecks runs the checks one after another, writes a line per check and returns the number of failed
+// checks
+func runConfigChecks(ctx context.Context, cliCtx *cli.Context, checks []configCheck, w io.Writer) int {
+	failed := 0
+	for _, c := range checks {
+		detail, err := c.check(ctx, cliCtx)
+		if err != nil {
+			failed++
+			fmt.Fprintf(w, "FAIL  %-16s %v\n", c.name, err)
+			continue
+		}
+		fmt.Fprintf(w, "OK    %-16s %s\n", c.name, detail)
+	}
+	return failed
+}
+
+func checkVerbosity(_ context.Context, cliCtx *cli.Context) (string, error) {
+	verbosity := cliCtx.String(cmd.VerbosityFlag.Name)
+	if _, err := logutil.ParseVerbosity(verbosity); err != nil {
+		return "", err
+	}
+	return verbosity, nil
+}
+
+// checkDataDir checks that files can be created in the data directory, or in its nearest existing parent when
+// the data directory is created on start
+func checkDataDir(_ context.Context, cliCtx *cli.Context) (string, error) {
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+	dir := dataDir
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return "", errors.Errorf("%s is not a directory", dir)
+			}
+			break
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", err
+		}
+		dir = parent
+	}
+
+	file, err := ioutil.TempFile(dir, ".validate-config")
+	if err != nil {
+		return "", errors.Wrapf(err, "%s is not writable", dir)
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Remove(file.Name()); err != nil {
+		return "", err
+	}
+	if dir != dataDir {
+		return fmt.Sprintf("%s will be created in writable %s", dataDir, dir), nil
+	}
+	return fmt.Sprintf("%s is writable", dataDir), nil
+}
+
+func checkIPCEndpoint(_ context.Context, cliCtx *cli.Context) (string, error) {
+	endpoint := cmd.IpcEndpoint(cliCtx)
+	if endpoint == "" {
+		return "disabled", nil
+	}
+	if err := fileutil.ValidateIpcEndpoint(endpoint); err != nil {
+		return "", err
+	}
+	return endpoint, nil
+}
+
+func checkJWTSecret(_ context.Context, cliCtx *cli.Context) (string, error) {
+	path := cliCtx.String(cmd.RPCJWTSecretFlag.Name)
+	if path == "" {
+		if cliCtx.Bool(cmd.RPCAdminFlag.Name) {
+			return "", errors.Errorf("--%s requires --%s", cmd.RPCAdminFlag.Name, cmd.RPCJWTSecretFlag.Name)
+		}
+		return "not set", nil
+	}
+	if _, err := rpc.ReadJWTSecret(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func checkAPIKeys(_ context.Context, cliCtx *cli.Context) (string, error) {
+	path := cliCtx.String(cmd.RPCAPIKeysFileFlag.Name)
+	if path == "" {
+		return "not set", nil
+	}
+	keys, err := rpc.ReadAPIKeys(path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s, %d keys", path, len(keys)), nil
+}
+
+func checkVanguardEndpoint(ctx context.Context, cliCtx *cli.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, endpointCheckTimeout)
+	defer cancel()
+	endpoint := cliCtx.String(cmd.VanguardGRPCEndpoint.Name)
+	tlsConfig, err := vanguardchain.LoadTLSConfig(
+		cliCtx.String(cmd.VanguardTLSCACertFlag.Name),
+		cliCtx.String(cmd.VanguardTLSClientCertFlag.Name),
+		cliCtx.String(cmd.VanguardTLSClientKeyFlag.Name),
+	)
+	if err != nil {
+		return "", err
+	}
+	version, err := vanguardchain.CheckEndpoint(ctx, endpoint, tlsConfig)
+	if err != nil {
+		return "", errors.Wrap(err, endpoint)
+	}
+	return fmt.Sprintf("%s reachable, version %s", endpoint, version), nil
+}
+
+func checkPandoraEndpoint(ctx context.Context, cliCtx *cli.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, endpointCheckTimeout)
+	defer cancel()
+	endpoint := cliCtx.String(cmd.PandoraRPCEndpoint.Name)
+	chainID, err := pandorachain.CheckEndpoint(ctx, endpoint, cliCtx.Uint64(cmd.PandoraChainID.Name))
+	if err != nil {
+		return "", errors.Wrap(err, endpoint)
+	}
+	return fmt.Sprintf("%s reachable, chain id %s", endpoint, chainID), nil
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/version"
+	"github.com/urfave/cli/v2"
+)
+
+// versionCommand prints the build metadata which is injected through linker options
+var versionCommand = &cli.Command{
+	Name:   "version",
+	Usage:  "Prints version, git commit, build date and Go version of the orchestrator client",
+	Action: printVersion,
+}
+
+func printVersion(cliCtx *cli.Context) error {
+	w := cliCtx.App.Writer
+	fmt.Fprintln(w, "Orchestrator")
+	fmt.Fprintf(w, "Version: %s\n", version.SemanticVersion())
+	fmt.Fprintf(w, "Git commit: %s\n", version.GitCommit())
+	fmt.Fprintf(w, "Build date: %s\n", version.BuildDate())
+	fmt.Fprintf(w, "Go version: %s\n", version.GoVersion())
+	fmt.Fprintf(w, "OS/Arch: %s\n", version.Platform())
+	return nil
+}
@@ -0,0 +1,5 @@
+package alert
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "alert")
@@ -0,0 +1,87 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	// time to wait for the webhook to respond
+	webhookTimeout = 5 * time.Second
+	// maximum number of alerts which wait for delivery. New alerts are dropped when the queue is full.
+	webhookQueueSize = 64
+)
+
+// WebhookSink posts alerts as JSON payload to the given URL. Alerts are delivered in background, so
+// the consensus pipeline is never blocked by a slow webhook.
+type WebhookSink struct {
+	ctx    context.Context
+	url    string
+	client *http.Client
+	queue  chan *types.Alert
+}
+
+// NewWebhookSink creates a webhook alert sink and starts delivering alerts until the context is cancelled
+func NewWebhookSink(ctx context.Context, url string) *WebhookSink {
+	sink := &WebhookSink{
+		ctx:    ctx,
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+		queue:  make(chan *types.Alert, webhookQueueSize),
+	}
+	go sink.run()
+	return sink
+}
+
+// Alert queues the alert for delivery
+func (w *WebhookSink) Alert(alert *types.Alert) {
+	select {
+	case w.queue <- alert:
+	default:
+		log.WithField("kind", alert.Kind).WithField("slot", alert.Slot).Warn("Alert queue is full, dropping alert")
+	}
+}
+
+// run delivers the queued alerts one by one
+func (w *WebhookSink) run() {
+	for {
+		select {
+		case alert := <-w.queue:
+			if err := w.post(alert); err != nil {
+				log.WithError(err).WithField("kind", alert.Kind).WithField("slot", alert.Slot).
+					Warn("Failed to deliver alert to webhook")
+			}
+		case <-w.ctx.Done():
+			return
+		}
+	}
+}
+
+// post sends a single alert to the webhook
+func (w *WebhookSink) post(alert *types.Alert) error {
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		return errors.Wrap(err, "could not encode alert")
+	}
+	req, err := http.NewRequestWithContext(w.ctx, http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "could not create webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "could not send webhook request")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
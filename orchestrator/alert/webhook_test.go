@@ -0,0 +1,41 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// TestWebhookSink_Alert checks that alerts are posted to the webhook as JSON payload
+func TestWebhookSink_Alert(t *testing.T) {
+	received := make(chan *types.Alert, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var alert *types.Alert
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		received <- alert
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink := NewWebhookSink(ctx, server.URL)
+	sink.Alert(&types.Alert{Kind: types.AlertMismatch, Slot: 10, Message: "sharding info mismatch"})
+
+	select {
+	case alert := <-received:
+		assert.Equal(t, types.AlertMismatch, alert.Kind)
+		assert.Equal(t, uint64(10), alert.Slot)
+		assert.Equal(t, "sharding info mismatch", alert.Message)
+	case <-time.After(time.Second):
+		t.Fatal("alert has not been delivered to webhook")
+	}
+}
@@ -0,0 +1,248 @@
+// Package audit writes every externally significant decision of the orchestrator as a JSON line to an append only
+// audit log: received consensus infos, verified and invalidated slots, reorgs and dropped subscriptions to
+// vanguard and pandora. Operators can reconstruct from it what the orchestrator decided and when.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/event"
+	conIface "github.com/lukso-network/lukso-orchestrator/orchestrator/consensus/iface"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain/iface"
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/logutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// events which are written to the audit log
+const (
+	EventConsensusInfo    = "consensus_info"
+	EventVerified         = "verified"
+	EventInvalidated      = "invalidated"
+	EventVerification     = "verification"
+	EventReorg            = "reorg"
+	EventSubscriptionDrop = "subscription_drop"
+)
+
+// auditMetrics collects records written to and lost by the audit log
+var auditMetrics = metrics.NewCollector("audit")
+
+// ReorgFeed sends reorgs of vanguard chain
+type ReorgFeed interface {
+	SubscribeShutdownSignalEvent(chan<- *types.Reorg) event.Subscription
+}
+
+// Config defines the audit log file and the feeds of the audited events
+type Config struct {
+	// Path of the audit log file, it is created when it does not exist and appended to otherwise
+	Path string
+	// Perm of a created audit log file
+	Perm os.FileMode
+	// MaxSize in bytes which rotates the audit log file, zero disables rotation
+	MaxSize int64
+	// MaxBackups is the number of rotated audit log files which are kept
+	MaxBackups int
+
+	ConsensusInfoFeed      iface.ConsensusInfoFeed
+	VerificationResultFeed conIface.VerifiedSlotInfoFeed
+	ReorgFeed              ReorgFeed
+
+	// Network is the name of an additional network whose metrics are collected apart, empty for the main network
+	Network string
+}
+
+// Record is a line of the audit log
+type Record struct {
+	Time  time.Time   `json:"time"`
+	Event string      `json:"event"`
+	Data  interface{} `json:"data"`
+}
+
+type consensusInfoData struct {
+	Epoch          uint64 `json:"epoch"`
+	EpochStartTime uint64 `json:"epochStartTime"`
+	Validators     int    `json:"validators"`
+	FinalizedSlot  uint64 `json:"finalizedSlot"`
+	Reorg          bool   `json:"reorg,omitempty"`
+}
+
+type verificationData struct {
+	Slot              uint64       `json:"slot"`
+	Status            types.Status `json:"status"`
+	VanguardBlockHash common.Hash  `json:"vanguardBlockHash"`
+	PandoraHeaderHash common.Hash  `json:"pandoraHeaderHash"`
+}
+
+type reorgData struct {
+	NewSlot       uint64        `json:"newSlot"`
+	VanParentHash hexutil.Bytes `json:"vanParentHash"`
+	PanParentHash hexutil.Bytes `json:"panParentHash"`
+}
+
+type subscriptionDropData struct {
+	Chain        string `json:"chain"`
+	Subscription string `json:"subscription"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Service subscribes to the audited events and appends them to the audit log, every record is synced to disk
+// before the next one is written
+type Service struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+	runError  error
+	done      chan struct{}
+
+	config  *Config
+	file    *logutil.RotatingFile
+	written metrics.Counter
+	failed  metrics.Counter
+}
+
+// NewService opens the audit log file of the config, so a file which can not be written fails the node on start
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	file, err := logutil.OpenRotatingFile(cfg.Path, cfg.Perm, cfg.MaxSize, cfg.MaxBackups)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open audit log")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+	return &Service{
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		config:  cfg,
+		file:    file,
+		written: auditMetrics.Sub(cfg.Network).Counter("records"),
+		failed:  auditMetrics.Sub(cfg.Network).Counter("failed"),
+	}, nil
+}
+
+// Start subscribes to the audited events
+func (s *Service) Start() {
+	if s.isRunning {
+		log.Error("Attempted to start audit service when it was already started")
+		return
+	}
+	s.isRunning = true
+	log.WithField("path", s.config.Path).Info("Writing audit log")
+	go s.run()
+}
+
+// Stop ends the subscriptions and closes the audit log file
+func (s *Service) Stop() error {
+	s.cancel()
+	if s.isRunning {
+		s.isRunning = false
+		<-s.done
+	}
+	return s.file.Close()
+}
+
+// Status returns the error of the latest record which could not be written
+func (s *Service) Status() error {
+	return s.runError
+}
+
+func (s *Service) run() {
+	defer close(s.done)
+	defer shared.RecoverService(s)
+
+	var subs []event.Subscription
+	defer func() {
+		for _, sub := range subs {
+			sub.Unsubscribe()
+		}
+	}()
+	dropCh := make(chan *shared.SubscriptionDrop, 1)
+	subs = append(subs, shared.SubscribeSubscriptionDrops(dropCh))
+	var (
+		consensusInfoCh      chan *types.MinimalEpochConsensusInfoV2
+		verificationResultCh chan *types.VerificationResult
+		reorgCh              chan *types.Reorg
+	)
+	if s.config.ConsensusInfoFeed != nil {
+		consensusInfoCh = make(chan *types.MinimalEpochConsensusInfoV2, 1)
+		subs = append(subs, s.config.ConsensusInfoFeed.SubscribeMinConsensusInfoEvent(consensusInfoCh))
+	}
+	if s.config.VerificationResultFeed != nil {
+		verificationResultCh = make(chan *types.VerificationResult, 1)
+		subs = append(subs, s.config.VerificationResultFeed.SubscribeVerificationResultEvent(verificationResultCh))
+	}
+	if s.config.ReorgFeed != nil {
+		reorgCh = make(chan *types.Reorg, 1)
+		subs = append(subs, s.config.ReorgFeed.SubscribeShutdownSignalEvent(reorgCh))
+	}
+
+	for {
+		select {
+		case consensusInfo := <-consensusInfoCh:
+			s.write(EventConsensusInfo, &consensusInfoData{
+				Epoch:          consensusInfo.Epoch,
+				EpochStartTime: consensusInfo.EpochStartTime,
+				Validators:     len(consensusInfo.ValidatorList),
+				FinalizedSlot:  consensusInfo.FinalizedSlot,
+				Reorg:          consensusInfo.ReorgInfo != nil,
+			})
+		case result := <-verificationResultCh:
+			s.write(verificationEvent(result.Status), &verificationData{
+				Slot:              result.Slot,
+				Status:            result.Status,
+				VanguardBlockHash: result.VanguardBlockHash,
+				PandoraHeaderHash: result.PandoraHeaderHash,
+			})
+		case reorg := <-reorgCh:
+			s.write(EventReorg, &reorgData{
+				NewSlot:       reorg.NewSlot,
+				VanParentHash: reorg.VanParentHash,
+				PanParentHash: reorg.PanParentHash,
+			})
+		case drop := <-dropCh:
+			data := &subscriptionDropData{Chain: drop.Chain, Subscription: drop.Subscription}
+			if drop.Err != nil {
+				data.Error = drop.Err.Error()
+			}
+			s.write(EventSubscriptionDrop, data)
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// verificationEvent returns the audit event of a verification result of the given status
+func verificationEvent(status types.Status) string {
+	switch status {
+	case types.Verified:
+		return EventVerified
+	case types.Invalid:
+		return EventInvalidated
+	}
+	return EventVerification
+}
+
+// write appends a record of the event to the audit log and syncs it to disk
+func (s *Service) write(eventName string, data interface{}) {
+	line, err := json.Marshal(&Record{Time: time.Now().UTC(), Event: eventName, Data: data})
+	if err == nil {
+		_, err = s.file.Write(append(line, '\n'))
+	}
+	if err == nil {
+		err = s.file.Sync()
+	}
+	if err != nil {
+		s.failed.Inc(1)
+		s.runError = errors.Wrap(err, "could not write audit log")
+		log.WithError(err).WithField("event", eventName).Error("Could not write audit record")
+		return
+	}
+	s.written.Inc(1)
+	s.runError = nil
+}
@@ -0,0 +1,158 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+type mockFeeds struct {
+	consensusInfoFeed      event.Feed
+	verificationResultFeed event.Feed
+	reorgFeed              event.Feed
+}
+
+func (m *mockFeeds) SubscribeMinConsensusInfoEvent(ch chan<- *types.MinimalEpochConsensusInfoV2) event.Subscription {
+	return m.consensusInfoFeed.Subscribe(ch)
+}
+
+func (m *mockFeeds) SubscribeVerificationResultEvent(ch chan<- *types.VerificationResult) event.Subscription {
+	return m.verificationResultFeed.Subscribe(ch)
+}
+
+func (m *mockFeeds) SubscribeShutdownSignalEvent(ch chan<- *types.Reorg) event.Subscription {
+	return m.reorgFeed.Subscribe(ch)
+}
+
+// sendWhenSubscribed sends the event once the service has subscribed to the feed
+func sendWhenSubscribed(t *testing.T, feed *event.Feed, value interface{}) {
+	deadline := time.Now().Add(time.Second)
+	for feed.Send(value) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("audit service has not subscribed to feed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// readRecords waits until the audit log has n records and decodes them
+func readRecords(t *testing.T, path string, n int) []map[string]interface{} {
+	deadline := time.Now().Add(time.Second)
+	for {
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		var records []map[string]interface{}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var record map[string]interface{}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+			records = append(records, record)
+		}
+		require.NoError(t, f.Close())
+		if len(records) >= n {
+			return records
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("audit log has %d records, want %d", len(records), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestService_WritesEvents checks that every audited event is appended to the audit log as a JSON line
+func TestService_WritesEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	feeds := &mockFeeds{}
+	svc, err := NewService(context.Background(), &Config{
+		Path:                   path,
+		Perm:                   0600,
+		ConsensusInfoFeed:      feeds,
+		VerificationResultFeed: feeds,
+		ReorgFeed:              feeds,
+	})
+	require.NoError(t, err)
+	svc.Start()
+
+	sendWhenSubscribed(t, &feeds.consensusInfoFeed, &types.MinimalEpochConsensusInfoV2{
+		Epoch:          3,
+		ValidatorList:  []string{"0x01", "0x02"},
+		EpochStartTime: 1000,
+		FinalizedSlot:  64,
+	})
+	readRecords(t, path, 1)
+	sendWhenSubscribed(t, &feeds.verificationResultFeed, &types.VerificationResult{
+		Slot:              97,
+		Status:            types.Verified,
+		VanguardBlockHash: common.HexToHash("0x11"),
+		PandoraHeaderHash: common.HexToHash("0x22"),
+	})
+	readRecords(t, path, 2)
+	sendWhenSubscribed(t, &feeds.verificationResultFeed, &types.VerificationResult{Slot: 98, Status: types.Invalid})
+	readRecords(t, path, 3)
+	sendWhenSubscribed(t, &feeds.reorgFeed, &types.Reorg{NewSlot: 90, VanParentHash: []byte{0xaa}, PanParentHash: []byte{0xbb}})
+	readRecords(t, path, 4)
+	shared.ReportSubscriptionDrop("vanguard", "chainHead", errors.New("connection reset"))
+	records := readRecords(t, path, 5)
+	require.NoError(t, svc.Stop())
+
+	require.Equal(t, 5, len(records))
+	for _, record := range records {
+		_, err := time.Parse(time.RFC3339Nano, record["time"].(string))
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, EventConsensusInfo, records[0]["event"])
+	consensusInfo := records[0]["data"].(map[string]interface{})
+	assert.Equal(t, float64(3), consensusInfo["epoch"])
+	assert.Equal(t, float64(2), consensusInfo["validators"])
+	assert.Equal(t, float64(64), consensusInfo["finalizedSlot"])
+
+	assert.Equal(t, EventVerified, records[1]["event"])
+	verified := records[1]["data"].(map[string]interface{})
+	assert.Equal(t, float64(97), verified["slot"])
+	assert.Equal(t, common.HexToHash("0x11").Hex(), verified["vanguardBlockHash"])
+	assert.Equal(t, common.HexToHash("0x22").Hex(), verified["pandoraHeaderHash"])
+
+	assert.Equal(t, EventInvalidated, records[2]["event"])
+	assert.Equal(t, float64(98), records[2]["data"].(map[string]interface{})["slot"])
+
+	assert.Equal(t, EventReorg, records[3]["event"])
+	reorg := records[3]["data"].(map[string]interface{})
+	assert.Equal(t, float64(90), reorg["newSlot"])
+	assert.Equal(t, "0xaa", reorg["vanParentHash"])
+
+	assert.Equal(t, EventSubscriptionDrop, records[4]["event"])
+	drop := records[4]["data"].(map[string]interface{})
+	assert.Equal(t, "vanguard", drop["chain"])
+	assert.Equal(t, "chainHead", drop["subscription"])
+	assert.Equal(t, "connection reset", drop["error"])
+}
+
+// TestService_AppendsToExistingLog checks that records of earlier runs are kept
+func TestService_AppendsToExistingLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	for i := 0; i < 2; i++ {
+		svc, err := NewService(context.Background(), &Config{Path: path, Perm: 0600})
+		require.NoError(t, err)
+		svc.write(EventReorg, &reorgData{NewSlot: uint64(i)})
+		require.NoError(t, svc.Status())
+		require.NoError(t, svc.Stop())
+	}
+
+	records := readRecords(t, path, 2)
+	require.Equal(t, 2, len(records))
+	assert.Equal(t, float64(0), records[0]["data"].(map[string]interface{})["newSlot"])
+	assert.Equal(t, float64(1), records[1]["data"].(map[string]interface{})["newSlot"])
+}
@@ -0,0 +1,203 @@
+// Package benchmark feeds synthetic vanguard shard infos and pandora headers at configurable rates through the real
+// cache, consensus and database pipeline of orchestrator. It reports throughput, latency percentiles and write
+// amplification of the database, so batch sizes and worker counts can be tuned against measurements.
+package benchmark
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/consensus"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/kv"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// period in which the benchmark checks that the pipeline has not failed
+const statusCheckPeriod = time.Second
+
+// Config of a benchmark run
+type Config struct {
+	// DataDir is the directory in which the benchmark creates its own database
+	DataDir string
+	// Slots is the number of synthetic slots which are fed through the pipeline
+	Slots uint64
+	// VanguardRate is the number of vanguard shard infos streamed per second, zero streams them as fast as the
+	// pipeline takes them
+	VanguardRate float64
+	// PandoraRate is the number of pandora headers streamed per second, zero streams them as fast as the pipeline
+	// takes them
+	PandoraRate float64
+	// VerificationWorkers is the number of workers which verify matched slots concurrently
+	VerificationWorkers int
+	// VerificationBatchSize is the maximum number of queued matched slots which are verified together
+	VerificationBatchSize int
+}
+
+// Result of a benchmark run
+type Result struct {
+	Slots                 uint64  `json:"slots"`
+	VerificationWorkers   int     `json:"verificationWorkers"`
+	VerificationBatchSize int     `json:"verificationBatchSize"`
+	VerifiedSlots         uint64  `json:"verifiedSlots"`
+	InvalidSlots          uint64  `json:"invalidSlots"`
+	ElapsedMs             float64 `json:"elapsedMs"`
+	SlotsPerSecond        float64 `json:"slotsPerSecond"`
+	// latencies from the time both sides of a slot are streamed until its verification result is published
+	LatencyP50Ms float64 `json:"latencyP50Ms"`
+	LatencyP90Ms float64 `json:"latencyP90Ms"`
+	LatencyP99Ms float64 `json:"latencyP99Ms"`
+	LatencyMaxMs float64 `json:"latencyMaxMs"`
+	// database writes of the run and bytes written per byte of stored data
+	Database           *types.DatabaseWriteStats `json:"database"`
+	WriteAmplification float64                   `json:"writeAmplification"`
+}
+
+// Run feeds the configured number of slots through a consensus service which stores its results in a new database
+// in the data directory, and measures the pipeline until all slots are verified
+func Run(ctx context.Context, cfg *Config) (*Result, error) {
+	if cfg.Slots == 0 {
+		return nil, errors.New("at least one slot must be fed through the pipeline")
+	}
+	if cfg.VanguardRate < 0 || cfg.PandoraRate < 0 {
+		return nil, errors.New("streaming rates must not be negative")
+	}
+
+	store, err := kv.NewKVStore(ctx, cfg.DataDir, &kv.Config{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create benchmark database")
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.WithError(err).Error("Failed to close benchmark database")
+		}
+	}()
+	if latestSlot := store.LatestSavedVerifiedSlot(); latestSlot > 0 {
+		return nil, errors.Errorf("benchmark database in %s already holds verified slots up to %d", cfg.DataDir,
+			latestSlot)
+	}
+	statsBefore, err := store.WriteStats()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	f := newFeeder(cfg.Slots)
+	svc := consensus.New(ctx, &consensus.Config{
+		VerifiedSlotInfoDB:           store,
+		InvalidSlotInfoDB:            store,
+		VanguardPendingShardingCache: cache.NewVanShardInfoCache(math.MaxInt32),
+		PandoraPendingHeaderCache:    cache.NewPanHeaderCache(),
+		VanguardShardFeed:            f,
+		PandoraHeaderFeed:            f,
+		VerificationWorkers:          cfg.VerificationWorkers,
+		VerificationBatchSize:        cfg.VerificationBatchSize,
+	})
+	resultCh := make(chan *types.VerificationResult, 256)
+	resultSub := svc.SubscribeVerificationResultEvent(resultCh)
+	defer resultSub.Unsubscribe()
+
+	log.WithField("slots", cfg.Slots).WithField("vanguardRate", cfg.VanguardRate).
+		WithField("pandoraRate", cfg.PandoraRate).Info("Starting benchmark of verification pipeline")
+	start := time.Now()
+	svc.Start()
+	defer func() {
+		if err := svc.Stop(); err != nil {
+			log.WithError(err).Error("Failed to stop consensus service")
+		}
+	}()
+	go f.streamVanguard(ctx, cfg.VanguardRate)
+	go f.streamPandora(ctx, cfg.PandoraRate)
+
+	result := &Result{
+		Slots:                 cfg.Slots,
+		VerificationWorkers:   cfg.VerificationWorkers,
+		VerificationBatchSize: cfg.VerificationBatchSize,
+	}
+	statusTicker := time.NewTicker(statusCheckPeriod)
+	defer statusTicker.Stop()
+	// a slot which is backfilled while it is streamed may be verified twice, only its first result counts
+	latencies := make(map[uint64]time.Duration, cfg.Slots)
+	for uint64(len(latencies)) < cfg.Slots {
+		select {
+		case verificationResult := <-resultCh:
+			if _, seen := latencies[verificationResult.Slot]; seen {
+				continue
+			}
+			completedAt, ok := f.completedAt(verificationResult.Slot)
+			if !ok {
+				return nil, errors.Errorf("slot %d has been verified before both of its sides were streamed",
+					verificationResult.Slot)
+			}
+			latencies[verificationResult.Slot] = time.Since(completedAt)
+			if verificationResult.Status == types.Invalid {
+				result.InvalidSlots++
+			} else {
+				result.VerifiedSlots++
+			}
+		case <-statusTicker.C:
+			if err := svc.Status(); err != nil {
+				return nil, errors.Wrap(err, "verification pipeline failed")
+			}
+		case err := <-resultSub.Err():
+			return nil, errors.Wrap(err, "verification results are not delivered anymore")
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "benchmark interrupted after %d of %d slots", len(latencies),
+				cfg.Slots)
+		}
+	}
+	elapsed := time.Since(start)
+	result.ElapsedMs = milliseconds(elapsed)
+	result.SlotsPerSecond = float64(cfg.Slots) / elapsed.Seconds()
+	result.setLatencies(latencies)
+
+	statsAfter, err := store.WriteStats()
+	if err != nil {
+		return nil, err
+	}
+	result.Database = &types.DatabaseWriteStats{
+		PageWrites:   statsAfter.PageWrites - statsBefore.PageWrites,
+		BytesWritten: statsAfter.BytesWritten - statsBefore.BytesWritten,
+		LiveBytes:    statsAfter.LiveBytes - statsBefore.LiveBytes,
+	}
+	if result.Database.LiveBytes > 0 {
+		result.WriteAmplification = float64(result.Database.BytesWritten) / float64(result.Database.LiveBytes)
+	}
+
+	log.WithField("slotsPerSecond", result.SlotsPerSecond).WithField("latencyP99Ms", result.LatencyP99Ms).
+		WithField("writeAmplification", result.WriteAmplification).Info("Finished benchmark of verification pipeline")
+	return result, nil
+}
+
+// setLatencies computes the latency percentiles of the verified slots
+func (r *Result) setLatencies(latencies map[uint64]time.Duration) {
+	sorted := make([]time.Duration, 0, len(latencies))
+	for _, latency := range latencies {
+		sorted = append(sorted, latency)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	r.LatencyP50Ms = milliseconds(percentile(sorted, 50))
+	r.LatencyP90Ms = milliseconds(percentile(sorted, 90))
+	r.LatencyP99Ms = milliseconds(percentile(sorted, 99))
+	r.LatencyMaxMs = milliseconds(percentile(sorted, 100))
+}
+
+// percentile returns the nearest-rank percentile of sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
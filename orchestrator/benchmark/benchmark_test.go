@@ -0,0 +1,69 @@
+package benchmark
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	slots := uint64(3 * params.SlotsPerEpoch)
+	result, err := Run(ctx, &Config{
+		DataDir:               t.TempDir(),
+		Slots:                 slots,
+		VerificationWorkers:   2,
+		VerificationBatchSize: 16,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, slots, result.VerifiedSlots)
+	assert.Equal(t, uint64(0), result.InvalidSlots)
+	assert.Equal(t, true, result.SlotsPerSecond > 0)
+	assert.Equal(t, true, result.LatencyP50Ms <= result.LatencyP99Ms)
+	assert.Equal(t, true, result.LatencyP99Ms <= result.LatencyMaxMs)
+	assert.Equal(t, true, result.Database.LiveBytes > 0)
+	assert.Equal(t, true, result.WriteAmplification > 1)
+}
+
+// TestRun_Rates checks that slots are not verified faster than the slower chain streams them
+func TestRun_Rates(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := Run(ctx, &Config{
+		DataDir:      t.TempDir(),
+		Slots:        20,
+		VanguardRate: 100,
+		PandoraRate:  50,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), result.VerifiedSlots)
+	// the last header is streamed 19 intervals of 20ms after the first one
+	assert.Equal(t, true, result.ElapsedMs >= 380)
+}
+
+func TestRun_InvalidConfig(t *testing.T) {
+	_, err := Run(context.Background(), &Config{DataDir: t.TempDir()})
+	assert.ErrorContains(t, "at least one slot", err)
+
+	_, err = Run(context.Background(), &Config{DataDir: t.TempDir(), Slots: 1, PandoraRate: -1})
+	assert.ErrorContains(t, "must not be negative", err)
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+	assert.Equal(t, 50*time.Millisecond, percentile(sorted, 50))
+	assert.Equal(t, 99*time.Millisecond, percentile(sorted, 99))
+	assert.Equal(t, 100*time.Millisecond, percentile(sorted, 100))
+	assert.Equal(t, time.Millisecond, percentile(sorted, 0))
+	assert.Equal(t, time.Duration(0), percentile(nil, 50))
+}
@@ -0,0 +1,219 @@
+package benchmark
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared/slotutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// feeder replaces vanguard and pandora chain services of the consensus service. It streams synthetic slots at
+// configured rates and serves the slots which have already been streamed to active queries of the pipeline.
+type feeder struct {
+	shardInfos []*types.VanguardShardInfo // shard info of slot n is at index n-1
+	headers    []*eth1Types.Header        // header of slot n is at index n-1
+
+	shardInfoFeed     event.Feed
+	headerInfoFeed    event.Feed
+	shutdownFeed      event.Feed
+	canonicalHeadFeed event.Feed
+
+	vanguardHead uint64 // latest streamed vanguard slot, accessed atomically
+	pandoraHead  uint64 // latest streamed pandora slot, accessed atomically
+
+	lock       sync.Mutex
+	subscribed int                  // number of subscriptions of shard infos and headers
+	ready      chan struct{}        // closed once the pipeline subscribes to both shard infos and headers
+	streamed   map[uint64]int       // number of sides of the slot which have been streamed
+	complete   map[uint64]time.Time // time at which both sides of the slot have been streamed
+}
+
+// newFeeder generates the given number of matching slots starting from slot 1
+func newFeeder(slots uint64) *feeder {
+	f := &feeder{
+		shardInfos: make([]*types.VanguardShardInfo, 0, slots),
+		headers:    make([]*eth1Types.Header, 0, slots),
+		streamed:   make(map[uint64]int),
+		complete:   make(map[uint64]time.Time),
+		ready:      make(chan struct{}),
+	}
+	parentHash := eth1Types.EmptyRootHash
+	for slot := uint64(1); slot <= slots; slot++ {
+		header := testutil.NewEth1Header(slot)
+		header.ParentHash = parentHash
+		parentHash = header.Hash()
+
+		blockHash := make([]byte, 32)
+		binary.BigEndian.PutUint64(blockHash[24:], slot)
+		shardInfo := &types.VanguardShardInfo{
+			Slot:      slot,
+			ShardInfo: testutil.NewPandoraShard(header),
+			BlockHash: blockHash,
+		}
+		// vanguard finalizes the epoch before the previous one, so finalization is part of the measured writes
+		if epoch := slotutil.ToEpoch(slot); epoch >= 2 {
+			shardInfo.FinalizedEpoch = epoch - 2
+			shardInfo.FinalizedSlot = slotutil.EpochStartSlot(epoch - 2)
+		}
+		f.shardInfos = append(f.shardInfos, shardInfo)
+		f.headers = append(f.headers, header)
+	}
+	return f
+}
+
+// streamVanguard streams shard infos of all slots at the given rate per second, zero rate streams them as fast as
+// the pipeline consumes them
+func (f *feeder) streamVanguard(ctx context.Context, rate float64) {
+	f.stream(ctx, rate, &f.vanguardHead, func(slot uint64) {
+		f.shardInfoFeed.Send(f.shardInfos[slot-1])
+	})
+}
+
+// streamPandora streams headers of all slots at the given rate per second, zero rate streams them as fast as
+// the pipeline consumes them
+func (f *feeder) streamPandora(ctx context.Context, rate float64) {
+	f.stream(ctx, rate, &f.pandoraHead, func(slot uint64) {
+		f.headerInfoFeed.Send(&types.PandoraHeaderInfo{Slot: slot, Header: f.headers[slot-1]})
+	})
+}
+
+// stream sends slots one by one. Slots are scheduled from the start of the stream, so a send which is delayed by
+// back pressure of the pipeline does not lower the average rate. A slot is marked as streamed before it is sent,
+// so time which it waits for the pipeline to take it counts into its latency.
+func (f *feeder) stream(ctx context.Context, rate float64, head *uint64, send func(slot uint64)) {
+	// events which are sent before the pipeline subscribes would be lost
+	select {
+	case <-f.ready:
+	case <-ctx.Done():
+		return
+	}
+	start := time.Now()
+	for slot := uint64(1); slot <= uint64(len(f.headers)); slot++ {
+		if rate > 0 {
+			due := start.Add(time.Duration(float64(slot-1) / rate * float64(time.Second)))
+			select {
+			case <-time.After(time.Until(due)):
+			case <-ctx.Done():
+				return
+			}
+		} else if ctx.Err() != nil {
+			return
+		}
+		f.markStreamed(slot)
+		atomic.StoreUint64(head, slot)
+		send(slot)
+	}
+}
+
+// markStreamed counts a streamed side of the slot and records when the slot is complete
+func (f *feeder) markStreamed(slot uint64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.streamed[slot]++
+	if f.streamed[slot] == 2 {
+		delete(f.streamed, slot)
+		f.complete[slot] = time.Now()
+	}
+}
+
+// completedAt returns the time at which both sides of the slot have been streamed
+func (f *feeder) completedAt(slot uint64) (time.Time, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	completedAt, ok := f.complete[slot]
+	return completedAt, ok
+}
+
+// markSubscribed releases the streams once both shard infos and headers are subscribed
+func (f *feeder) markSubscribed() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.subscribed++
+	if f.subscribed == 2 {
+		close(f.ready)
+	}
+}
+
+func (f *feeder) SubscribeShardInfoEvent(ch chan<- *types.VanguardShardInfo) event.Subscription {
+	defer f.markSubscribed()
+	return f.shardInfoFeed.Subscribe(ch)
+}
+
+func (f *feeder) SubscribeShutdownSignalEvent(ch chan<- *types.Reorg) event.Subscription {
+	return f.shutdownFeed.Subscribe(ch)
+}
+
+func (f *feeder) SubscribeCanonicalHeadEvent(ch chan<- *types.CanonicalHead) event.Subscription {
+	return f.canonicalHeadFeed.Subscribe(ch)
+}
+
+func (f *feeder) SubscribeHeaderInfoEvent(ch chan<- *types.PandoraHeaderInfo) event.Subscription {
+	defer f.markSubscribed()
+	return f.headerInfoFeed.Subscribe(ch)
+}
+
+// ReSubscribeBlocksEvent does nothing, synthetic chains are never reorganized
+func (f *feeder) ReSubscribeBlocksEvent() error {
+	return nil
+}
+
+// StopSubscription does nothing, synthetic chains are never reorganized
+func (f *feeder) StopSubscription() {}
+
+// StopPandoraSubscription does nothing, synthetic chains are never reorganized
+func (f *feeder) StopPandoraSubscription() {}
+
+// ResumePandoraSubscription does nothing, synthetic chains are never reorganized
+func (f *feeder) ResumePandoraSubscription() error {
+	return nil
+}
+
+// ShardInfoBySlot returns the shard info of the slot once it has been streamed
+func (f *feeder) ShardInfoBySlot(slot uint64) (*types.VanguardShardInfo, error) {
+	if slot == 0 || slot > atomic.LoadUint64(&f.vanguardHead) {
+		return nil, nil
+	}
+	return f.shardInfos[slot-1], nil
+}
+
+// ShardInfosByEpoch returns the streamed shard infos of the epoch
+func (f *feeder) ShardInfosByEpoch(epoch uint64) ([]*types.VanguardShardInfo, error) {
+	shardInfos := make([]*types.VanguardShardInfo, 0, slotutil.SlotsPerEpoch())
+	for slot := slotutil.EpochStartSlot(epoch); slot <= slotutil.EpochEndSlot(epoch); slot++ {
+		if shardInfo, _ := f.ShardInfoBySlot(slot); shardInfo != nil {
+			shardInfos = append(shardInfos, shardInfo)
+		}
+	}
+	return shardInfos, nil
+}
+
+// HeadSlot returns the latest streamed vanguard slot
+func (f *feeder) HeadSlot() (uint64, error) {
+	return atomic.LoadUint64(&f.vanguardHead), nil
+}
+
+// HeadersByRange returns the streamed headers of the range
+func (f *feeder) HeadersByRange(from, to uint64) ([]*eth1Types.Header, error) {
+	if from == 0 || to > atomic.LoadUint64(&f.pandoraHead) {
+		return nil, errors.Errorf("headers %d-%d have not been produced", from, to)
+	}
+	return f.headers[from-1 : to], nil
+}
+
+// LatestBlockNumber returns the number of the latest streamed header
+func (f *feeder) LatestBlockNumber() (uint64, error) {
+	return atomic.LoadUint64(&f.pandoraHead), nil
+}
+
+// ConfirmBlock accepts the verdicts which the pipeline pushes to pandora
+func (f *feeder) ConfirmBlock(*types.BlockStatus) error {
+	return nil
+}
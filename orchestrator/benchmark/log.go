@@ -0,0 +1,5 @@
+package benchmark
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "benchmark")
@@ -69,6 +69,11 @@ func (c *PanHeaderCache) GetAll() ([]*eth1Types.Header, error) {
 	return pendingHeaders, nil
 }
 
+// Len returns the number of cached pandora headers
+func (c *PanHeaderCache) Len() int {
+	return c.cache.Len()
+}
+
 // Clear the pandora header cache.
 func (c *PanHeaderCache) Purge() {
 	c.lock.Lock()
@@ -55,3 +55,8 @@ func (c *VanShardingInfoCache) Purge() {
 	c.cache.Purge()
 	c.lock.Unlock()
 }
+
+// Len returns the number of cached sharding infos
+func (vc *VanShardingInfoCache) Len() int {
+	return vc.cache.Len()
+}
@@ -0,0 +1,31 @@
+package consensus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// raiseAlert sends the alert to all the registered alert sinks
+func (s *Service) raiseAlert(alert *types.Alert) {
+	if len(s.alertSinks) == 0 {
+		return
+	}
+	alert.Timestamp = time.Now().Unix()
+	for _, sink := range s.alertSinks {
+		sink.Alert(alert)
+	}
+}
+
+// alertEquivocation raises an alert when a chain has delivered two different blocks for the same slot
+func (s *Service) alertEquivocation(slot uint64, chain string, knownHash, newHash common.Hash) {
+	log.WithField("slot", slot).WithField("chain", chain).WithField("knownHash", knownHash).
+		WithField("newHash", newHash).Warn("Received two different blocks for the same slot")
+	s.raiseAlert(&types.Alert{
+		Kind:    types.AlertEquivocation,
+		Slot:    slot,
+		Message: fmt.Sprintf("%s delivered %s and %s for the same slot", chain, knownHash.Hex(), newHash.Hex()),
+	})
+}
@@ -0,0 +1,48 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+type mockAlertSink struct {
+	alerts []*types.Alert
+}
+
+func (m *mockAlertSink) Alert(alert *types.Alert) {
+	m.alerts = append(m.alerts, alert)
+}
+
+// TestService_Alerts checks that mismatches and equivocations are sent to alert sinks
+func TestService_Alerts(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 4)
+	svc, _ := setup(ctx, t)
+	sink := &mockAlertSink{}
+	svc.alertSinks = append(svc.alertSinks, sink)
+
+	// slot 1 gets a header which belongs to another slot
+	invalidShardInfo := testutil.NewVanguardShardInfo(1, headerInfos[1].Header)
+	require.NoError(t, svc.verifyShardingInfo(1, invalidShardInfo, headerInfos[1].Header))
+	require.Equal(t, 1, len(sink.alerts))
+	assert.Equal(t, types.AlertMismatch, sink.alerts[0].Kind)
+	assert.Equal(t, uint64(1), sink.alerts[0].Slot)
+	assert.Equal(t, reasonExtraDataSlot, sink.alerts[0].Message)
+
+	// pandora delivers two different headers for slot 3
+	require.NoError(t, svc.processPandoraHeader(headerInfos[2]))
+	require.NoError(t, svc.processPandoraHeader(&types.PandoraHeaderInfo{Slot: 3, Header: headerInfos[1].Header}))
+	require.Equal(t, 2, len(sink.alerts))
+	assert.Equal(t, types.AlertEquivocation, sink.alerts[1].Kind)
+	assert.Equal(t, uint64(3), sink.alerts[1].Slot)
+
+	// vanguard shard info of slot 2 is delivered twice without alert
+	require.NoError(t, svc.processVanguardShardInfo(shardInfos[1]))
+	require.NoError(t, svc.processVanguardShardInfo(shardInfos[1]))
+	assert.Equal(t, 2, len(sink.alerts))
+}
@@ -0,0 +1,93 @@
+package consensus
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// isCanonical checks that vanguard block of a matched slot is on the canonical chain which is selected by
+// vanguard fork choice. Before the first canonical head arrives, every slot is considered as canonical.
+func (s *Service) isCanonical(v *slotVerification) bool {
+	head := s.canonicalHead
+	if head == nil {
+		return true
+	}
+	if bytes.Equal(head.BlockHash.Bytes(), v.shardInfo.BlockHash) {
+		return true
+	}
+	// fork choice has not selected any block of this slot yet
+	if v.slot > head.Slot {
+		return false
+	}
+	canonicalShardInfo, err := s.vanguardService.ShardInfoBySlot(v.slot)
+	if err != nil {
+		// do not block verification when vanguard can not be queried
+		log.WithError(err).WithField("slot", v.slot).Warn("Failed to query canonical vanguard block, assuming canonical")
+		return true
+	}
+	// no canonical block in this slot, so the block is on a fork
+	if canonicalShardInfo == nil {
+		return false
+	}
+	return bytes.Equal(canonicalShardInfo.BlockHash, v.shardInfo.BlockHash)
+}
+
+// holdNonCanonical keeps a valid slot which is not on the canonical chain as pending until vanguard fork
+// choice selects it, instead of confirming it and invalidating it later.
+func (s *Service) holdNonCanonical(v *slotVerification) error {
+	if _, held := s.heldSlots[v.slot]; !held {
+		s.heldSince[v.slot] = time.Now()
+		if err := s.verifiedSlotInfoDB.SaveSlotStatus(v.slot, types.Pending); err != nil {
+			log.WithField("slot", v.slot).WithError(err).Error("Failed to store pending slot status")
+			return err
+		}
+	}
+	s.removePending(v.slot)
+	s.heldSlots[v.slot] = v
+	log.WithField("slot", v.slot).WithField("canonicalHeadSlot", s.canonicalHead.Slot).
+		Debug("Vanguard block is not canonical yet, holding slot as pending")
+	return nil
+}
+
+// onCanonicalHead updates canonical head of vanguard chain and commits held slots which have become canonical
+func (s *Service) onCanonicalHead(head *types.CanonicalHead) error {
+	if head == nil {
+		return nil
+	}
+	s.canonicalHead = head
+
+	held := make([]*slotVerification, 0, len(s.heldSlots))
+	for _, v := range s.heldSlots {
+		held = append(held, v)
+	}
+	sort.Slice(held, func(i, j int) bool {
+		return held[i].slot < held[j].slot
+	})
+
+	for _, v := range held {
+		if !s.isCanonical(v) {
+			continue
+		}
+		s.releaseHeld(v.slot)
+		log.WithField("slot", v.slot).Debug("Held vanguard block has become canonical")
+		if err := s.commitVerification(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseHeld forgets a held slot, e.g. when it has been committed or evicted
+func (s *Service) releaseHeld(slot uint64) {
+	delete(s.heldSlots, slot)
+	delete(s.heldSince, slot)
+}
+
+// resetHeld forgets all the held slots, e.g. when reorg reverts them
+func (s *Service) resetHeld() {
+	s.heldSlots = make(map[uint64]*slotVerification)
+	s.heldSince = make(map[uint64]time.Time)
+}
@@ -0,0 +1,57 @@
+package consensus
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// TestService_HoldNonCanonical checks that slots which are not on vanguard canonical chain are kept as pending
+// and verified once fork choice selects them
+func TestService_HoldNonCanonical(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 5)
+	svc, mfs := setup(ctx, t)
+	// vanguard blocks of test shard infos share the same hash, so every slot gets its own block
+	for _, shardInfo := range shardInfos {
+		shardInfo.BlockHash = common.BigToHash(new(big.Int).SetUint64(shardInfo.Slot)).Bytes()
+	}
+
+	// slot 2 is canonical, slot 3 is on a fork, slot 4 is ahead of the head
+	mfs.shardInfos[2] = shardInfos[1]
+	mfs.shardInfos[3] = shardInfos[0]
+	svc.canonicalHead = &types.CanonicalHead{Slot: 3, BlockHash: common.BytesToHash(shardInfos[0].BlockHash)}
+
+	for i := 1; i < 4; i++ {
+		svc.enqueueVerification(headerInfos[i].Slot, shardInfos[i], headerInfos[i].Header)
+	}
+	require.NoError(t, svc.flushVerifications())
+
+	status, err := svc.verifiedSlotInfoDB.SlotStatus(2)
+	require.NoError(t, err)
+	assert.Equal(t, types.Verified, status)
+	for _, slot := range []uint64{3, 4} {
+		status, err := svc.verifiedSlotInfoDB.SlotStatus(slot)
+		require.NoError(t, err)
+		assert.Equal(t, types.Pending, status)
+	}
+	assert.Equal(t, 2, len(svc.heldSlots))
+
+	// fork choice selects slot 4, so it is verified. Slot 3 stays on the fork
+	require.NoError(t, svc.onCanonicalHead(&types.CanonicalHead{
+		Slot:      4,
+		BlockHash: common.BytesToHash(shardInfos[3].BlockHash),
+	}))
+	status, err = svc.verifiedSlotInfoDB.SlotStatus(4)
+	require.NoError(t, err)
+	assert.Equal(t, types.Verified, status)
+	assert.Equal(t, 1, len(svc.heldSlots))
+
+	svc.resetHeld()
+	assert.Equal(t, 0, len(svc.heldSlots))
+}
@@ -0,0 +1,106 @@
+package consensus
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/slotutil"
+	"github.com/pkg/errors"
+)
+
+// resumeFromCheckpoint loads the verification checkpoint and backfills the slots between the checkpoint
+// and the heads of both chains, so that verification continues from the next slot after a restart
+// instead of relying only on live events.
+func (s *Service) resumeFromCheckpoint() error {
+	checkpoint, err := s.verifiedSlotInfoDB.VerificationCheckpoint()
+	if err != nil {
+		return errors.Wrap(err, "could not load verification checkpoint")
+	}
+	vanHeadSlot, err := s.vanguardService.HeadSlot()
+	if err != nil {
+		return errors.Wrap(err, "could not fetch vanguard head slot")
+	}
+	panHeadNumber, err := s.pandoraService.LatestBlockNumber()
+	if err != nil {
+		return errors.Wrap(err, "could not fetch pandora latest block number")
+	}
+	if vanHeadSlot <= checkpoint.Slot {
+		log.WithField("checkpointSlot", checkpoint.Slot).WithField("vanguardHeadSlot", vanHeadSlot).
+			Info("Verification is up to date with vanguard head")
+		return nil
+	}
+
+	log.WithField("checkpointSlot", checkpoint.Slot).WithField("vanguardHeadSlot", vanHeadSlot).
+		WithField("pandoraHeadNumber", panHeadNumber).Info("Resuming verification from checkpoint")
+
+	backfilled := 0
+	for slot := checkpoint.Slot + 1; slot <= vanHeadSlot; {
+		if s.ctx.Err() != nil {
+			return s.ctx.Err()
+		}
+		// whole epochs behind the live head are verified as a batch, the rest is processed slot by slot
+		epoch := slotutil.ToEpoch(slot)
+		if slotutil.IsEpochStart(slot) && slotutil.EpochEndSlot(epoch) < vanHeadSlot {
+			if err := s.flushVerifications(); err != nil {
+				return err
+			}
+			verified, err := s.verifyEpoch(epoch, panHeadNumber)
+			if err == nil {
+				backfilled += verified
+				slot += slotutil.SlotsPerEpoch()
+				continue
+			}
+			if err != errPandoraBehind {
+				return err
+			}
+		}
+
+		enqueued, err := s.backfillSlot(slot, panHeadNumber)
+		if err == errPandoraBehind {
+			// the rest will come from live events
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if enqueued {
+			backfilled++
+		}
+		if len(s.verificationBatch) >= s.verificationBatchSize {
+			if err := s.flushVerifications(); err != nil {
+				return err
+			}
+		}
+		slot++
+	}
+	if err := s.flushVerifications(); err != nil {
+		return err
+	}
+
+	log.WithField("backfilledSlots", backfilled).
+		WithField("latestVerifiedSlot", s.verifiedSlotInfoDB.LatestSavedVerifiedSlot()).
+		Info("Finished backfilling slots after checkpoint")
+	return nil
+}
+
+// backfillSlot fetches both sides of a single slot and enqueues it for verification. It returns false when
+// vanguard has no block with pandora shard in the slot.
+func (s *Service) backfillSlot(slot uint64, panHeadNumber uint64) (bool, error) {
+	shardInfo, err := s.vanguardService.ShardInfoBySlot(slot)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not fetch vanguard shard info of slot %d", slot)
+	}
+	if shardInfo == nil {
+		return false, s.markSkipped(slot)
+	}
+	if shardInfo.ShardInfo == nil {
+		return false, nil
+	}
+	blockNumber := shardInfo.ShardInfo.BlockNumber
+	if blockNumber > panHeadNumber {
+		return false, errPandoraBehind
+	}
+	headers, err := s.pandoraService.HeadersByRange(blockNumber, blockNumber)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not fetch pandora header of slot %d", slot)
+	}
+	s.enqueueVerification(slot, shardInfo, headers[0])
+	return true, nil
+}
@@ -0,0 +1,46 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// TestService_ResumeFromCheckpoint checks that slots between checkpoint and heads of both chains are backfilled
+func TestService_ResumeFromCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 7)
+	svc, mockedFeed := setup(ctx, t)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, svc.verifyShardingInfo(headerInfos[i].Slot, shardInfos[i], headerInfos[i].Header))
+	}
+	for i := 2; i < len(headerInfos); i++ {
+		mockedFeed.headers[headerInfos[i].Header.Number.Uint64()] = headerInfos[i].Header
+		mockedFeed.shardInfos[shardInfos[i].Slot] = shardInfos[i]
+	}
+	// pandora is behind vanguard, so slot 5 and 6 must come from live events
+	mockedFeed.headSlot = 6
+	mockedFeed.latestBlockNumber = headerInfos[3].Header.Number.Uint64()
+
+	require.NoError(t, svc.resumeFromCheckpoint())
+	assert.Equal(t, uint64(4), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+
+	for slot := uint64(3); slot <= 6; slot++ {
+		status, err := svc.verifiedSlotInfoDB.SlotStatus(slot)
+		require.NoError(t, err)
+		if slot <= 4 {
+			assert.Equal(t, types.Verified, status)
+		} else {
+			assert.Equal(t, types.Unknown, status)
+		}
+	}
+
+	checkpoint, err := svc.verifiedSlotInfoDB.VerificationCheckpoint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), checkpoint.Slot)
+	assert.Equal(t, headerInfos[3].Header.Hash(), checkpoint.PandoraHeaderHash)
+}
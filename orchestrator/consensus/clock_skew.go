@@ -0,0 +1,66 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/slotutil"
+)
+
+// number of latest canonical heads whose arrival is compared with the start of their slot
+const clockSkewWindow = 8
+
+// observeHeadTiming compares the arrival of a canonical head with the start of its slot by the local clock. A
+// head arrives shortly after its slot starts, so the smallest offset of the latest heads is the skew of the local
+// clock against vanguard slot timing: late heads only make offsets larger. Skew beyond the threshold is logged
+// once when it is crossed, since a drifting clock breaks timeouts of pending slots silently. Heads which are
+// older than an epoch are streamed while vanguard catches up and are not taken into account.
+func (s *Service) observeHeadTiming(slot uint64, now time.Time) {
+	slotStart, ok := slotutil.SlotStartTime(slot)
+	if !ok {
+		return
+	}
+	offset := now.Sub(slotStart)
+	epochDuration := time.Duration(slotutil.SlotsPerEpoch()*params.ActiveNetworkConfig().SecondsPerSlot) * time.Second
+	if offset > epochDuration {
+		return
+	}
+	s.headOffsets = append(s.headOffsets, offset)
+	if len(s.headOffsets) > clockSkewWindow {
+		s.headOffsets = s.headOffsets[len(s.headOffsets)-clockSkewWindow:]
+	}
+	if len(s.headOffsets) < clockSkewWindow {
+		return
+	}
+
+	skew := s.headOffsets[0]
+	for _, offset := range s.headOffsets[1:] {
+		if offset < skew {
+			skew = offset
+		}
+	}
+	s.metrics.clockSkew.Update(skew.Milliseconds())
+	if s.clockSkewThreshold == 0 {
+		return
+	}
+
+	skewed := skew > s.clockSkewThreshold || skew < -s.clockSkewThreshold
+	if skewed == s.clockSkewed {
+		return
+	}
+	s.clockSkewed = skewed
+	logger := log.WithField("skew", skew).WithField("threshold", s.clockSkewThreshold).WithField("headSlot", slot)
+	if wallClockSlot, ok := slotutil.SlotAt(now); ok {
+		logger = logger.WithField("wallClockSlot", wallClockSlot)
+	}
+	if !skewed {
+		logger.Info("Local clock is in line with vanguard slot timing again")
+		return
+	}
+	direction := "ahead of"
+	if skew < 0 {
+		direction = "behind"
+	}
+	logger.Warnf("Local clock is %s vanguard slot timing, pending slots may time out too early or too late. "+
+		"Check time synchronization (NTP) of the host", direction)
+}
@@ -0,0 +1,61 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+// TestService_ObserveHeadTiming checks that skew of local clock is detected by the earliest head arrival
+func TestService_ObserveHeadTiming(t *testing.T) {
+	previous := params.ActiveNetworkConfig()
+	defer params.UseNetworkConfig(previous)
+	params.UseNetworkConfig(&params.NetworkConfig{GenesisTime: 1000, SecondsPerSlot: 6, SlotsPerEpoch: 32})
+
+	hook := logTest.NewGlobal()
+	svc, _ := setup(context.Background(), t)
+	svc.clockSkewThreshold = 2 * time.Second
+	slotStart := func(slot uint64) time.Time {
+		return time.Unix(int64(1000+slot*6), 0)
+	}
+
+	// heads which are streamed while vanguard catches up are ignored
+	svc.observeHeadTiming(10, slotStart(200))
+	assert.Equal(t, 0, len(svc.headOffsets))
+
+	// late heads do not make a skew as long as some heads arrive in time
+	for slot := uint64(100); slot < 100+clockSkewWindow; slot++ {
+		delay := 500 * time.Millisecond
+		if slot%2 == 0 {
+			delay = 5 * time.Second
+		}
+		svc.observeHeadTiming(slot, slotStart(slot).Add(delay))
+	}
+	assert.Equal(t, false, svc.clockSkewed)
+
+	// local clock is 3 seconds ahead
+	for slot := uint64(200); slot < 200+clockSkewWindow; slot++ {
+		svc.observeHeadTiming(slot, slotStart(slot).Add(3*time.Second))
+	}
+	assert.Equal(t, true, svc.clockSkewed)
+	require.LogsContain(t, hook, "Local clock is ahead of vanguard slot timing")
+	hook.Reset()
+
+	// local clock is 3 seconds behind
+	for slot := uint64(300); slot < 300+clockSkewWindow; slot++ {
+		svc.observeHeadTiming(slot, slotStart(slot).Add(-3*time.Second))
+	}
+	assert.Equal(t, true, svc.clockSkewed)
+	require.LogsDoNotContain(t, hook, "Local clock is behind", "warning is logged once when threshold is crossed")
+
+	for slot := uint64(400); slot < 400+clockSkewWindow; slot++ {
+		svc.observeHeadTiming(slot, slotStart(slot).Add(time.Second))
+	}
+	assert.Equal(t, false, svc.clockSkewed)
+	require.LogsContain(t, hook, "Local clock is in line with vanguard slot timing again")
+}
@@ -0,0 +1,58 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/tracing"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// errRevertedUnconfirmed finishes the trace of an unconfirmed slot which has been reverted by reorg
+var errRevertedUnconfirmed = errors.New("reverted by reorg before confirmation")
+
+// reportVerified sends verification result of a verified slot to subscribers once confirmation depth is
+// reached. Every slot which has got enough subsequent verified slots with the new verified slot is reported
+// in slot order.
+func (s *Service) reportVerified(verificationResult *types.VerificationResult) {
+	if s.confirmationDepth == 0 {
+		s.publishVerificationResult(verificationResult)
+		return
+	}
+
+	s.unconfirmedSlots = append(s.unconfirmedSlots, verificationResult)
+	remaining := s.unconfirmedSlots[:0]
+	for _, unconfirmed := range s.unconfirmedSlots {
+		if unconfirmed.Slot+s.confirmationDepth > verificationResult.Slot {
+			remaining = append(remaining, unconfirmed)
+			continue
+		}
+		log.WithField("slot", unconfirmed.Slot).WithField("confirmationDepth", s.confirmationDepth).
+			Debug("Verified slot is confirmed")
+		unconfirmed.Trace.Stage("confirmed")
+		s.publishVerificationResult(unconfirmed)
+	}
+	s.unconfirmedSlots = remaining
+}
+
+// dropUnconfirmed forgets unconfirmed slots after the given slot, e.g. slots which have been reverted by reorg
+func (s *Service) dropUnconfirmed(afterSlot uint64) {
+	remaining := s.unconfirmedSlots[:0]
+	for _, unconfirmed := range s.unconfirmedSlots {
+		if unconfirmed.Slot <= afterSlot {
+			remaining = append(remaining, unconfirmed)
+			continue
+		}
+		unconfirmed.Trace.Finish(errRevertedUnconfirmed)
+	}
+	s.unconfirmedSlots = remaining
+}
+
+// publishVerificationResult sends the verification result to subscribers, finishes the trace of its slot and
+// records it for epoch summaries
+func (s *Service) publishVerificationResult(verificationResult *types.VerificationResult) {
+	s.recordPublished(verificationResult, time.Now())
+	nsent := s.verificationResultFeed.Send(verificationResult)
+	verificationResult.Trace.Stage("notified", tracing.Int("subscribers", int64(nsent)))
+	verificationResult.Trace.Finish(nil)
+}
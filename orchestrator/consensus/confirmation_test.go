@@ -0,0 +1,63 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// TestService_ConfirmationDepth checks that verified slot is reported after enough subsequent verified slots
+func TestService_ConfirmationDepth(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 6)
+	svc, _ := setup(ctx, t)
+	svc.confirmationDepth = 2
+
+	verifiedCh := make(chan *types.VerificationResult, 10)
+	sub := svc.SubscribeVerificationResultEvent(verifiedCh)
+	defer sub.Unsubscribe()
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, svc.verifyShardingInfo(headerInfos[i].Slot, shardInfos[i], headerInfos[i].Header))
+	}
+	assertNoVerificationResult(t, verifiedCh)
+
+	// slot 3 confirms slot 1, slot 5 confirms slot 2 and slot 3
+	require.NoError(t, svc.verifyShardingInfo(headerInfos[2].Slot, shardInfos[2], headerInfos[2].Header))
+	verificationResult := receiveVerificationResult(t, verifiedCh)
+	assert.Equal(t, uint64(1), verificationResult.Slot)
+	assert.Equal(t, headerInfos[0].Header.Hash(), verificationResult.PandoraHeaderHash)
+	assertNoVerificationResult(t, verifiedCh)
+
+	require.NoError(t, svc.verifyShardingInfo(headerInfos[4].Slot, shardInfos[4], headerInfos[4].Header))
+	assert.Equal(t, headerInfos[1].Header.Hash(), receiveVerificationResult(t, verifiedCh).PandoraHeaderHash)
+	assert.Equal(t, headerInfos[2].Header.Hash(), receiveVerificationResult(t, verifiedCh).PandoraHeaderHash)
+
+	// reverted slots are never reported
+	svc.dropUnconfirmed(4)
+	assert.Equal(t, 0, len(svc.unconfirmedSlots))
+}
+
+// receiveVerificationResult waits for the next verification result which the feed delivers to the channel
+func receiveVerificationResult(t *testing.T, ch <-chan *types.VerificationResult) *types.VerificationResult {
+	select {
+	case verificationResult := <-ch:
+		return verificationResult
+	case <-time.After(time.Second):
+		t.Fatal("verification result is not reported")
+		return nil
+	}
+}
+
+// assertNoVerificationResult checks that the feed delivers no verification result to the channel
+func assertNoVerificationResult(t *testing.T, ch <-chan *types.VerificationResult) {
+	select {
+	case verificationResult := <-ch:
+		t.Fatalf("verification result of slot %d is reported", verificationResult.Slot)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
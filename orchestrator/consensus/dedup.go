@@ -0,0 +1,64 @@
+package consensus
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// number of recently seen events of both chains which are remembered to drop redelivered events
+const seenEventsSize = 1024
+
+// sources of incoming events
+const (
+	vanguardSource = "vanguard"
+	pandoraSource  = "pandora"
+)
+
+// seenKey identifies an event of one chain by its slot and hash
+type seenKey struct {
+	source string
+	slot   uint64
+	hash   common.Hash
+}
+
+// seenEvents remembers the recent events of both chains, so that events which are redelivered by reconnects and
+// backfills are dropped ahead of the verified slot db and the caches. An event of a slot with another hash is never a
+// duplicate, it is processed and reported as equivocation. Events are forgotten only on reorg, when the slots are
+// verified again.
+type seenEvents struct {
+	cache *lru.Cache
+}
+
+func newSeenEvents(size int) *seenEvents {
+	cache, err := lru.New(size)
+	if err != nil {
+		panic(err)
+	}
+	return &seenEvents{cache: cache}
+}
+
+// duplicate reports whether the event has been seen already and remembers it otherwise
+func (e *seenEvents) duplicate(source string, slot uint64, hash common.Hash) bool {
+	key := seenKey{source: source, slot: slot, hash: hash}
+	if e.cache.Contains(key) {
+		return true
+	}
+	e.cache.Add(key, struct{}{})
+	return false
+}
+
+// purge forgets all events
+func (e *seenEvents) purge() {
+	e.cache.Purge()
+}
+
+// pandoraRedelivered tells whether the pandora header has been delivered before
+func (s *Service) pandoraRedelivered(headerInfo *types.PandoraHeaderInfo) bool {
+	return s.seen.duplicate(pandoraSource, headerInfo.Slot, headerInfo.Header.Hash())
+}
+
+// vanguardRedelivered tells whether the vanguard shard info has been delivered before
+func (s *Service) vanguardRedelivered(shardInfo *types.VanguardShardInfo) bool {
+	return s.seen.duplicate(vanguardSource, shardInfo.Slot, common.BytesToHash(shardInfo.BlockHash))
+}
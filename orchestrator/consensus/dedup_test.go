@@ -0,0 +1,69 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// TestService_DropDuplicateEvents checks that redelivered events are dropped ahead of the caches, also after their
+// slots have left the caches, while events with another hash are processed
+func TestService_DropDuplicateEvents(t *testing.T) {
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = false }()
+
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 3)
+	svc, _ := setup(ctx, t)
+	svc.metrics.duplicateEvents = metrics.NewCounter()
+
+	svc.handleVanguardShardInfo(shardInfos[0])
+	svc.handleVanguardShardInfo(shardInfos[0])
+	svc.handlePandoraHeaderInfo(headerInfos[1])
+	svc.handlePandoraHeaderInfo(headerInfos[1])
+	assert.Equal(t, int64(2), svc.metrics.duplicateEvents.Count())
+	assert.Equal(t, 2, len(svc.pendingQueue))
+
+	// another header of the same slot is no duplicate
+	equivocation := &types.PandoraHeaderInfo{Slot: 2, Header: testutil.NewEth1Header(5)}
+	svc.handlePandoraHeaderInfo(equivocation)
+	assert.Equal(t, int64(2), svc.metrics.duplicateEvents.Count())
+	cached, _ := svc.pandoraPendingHeaderCache.Get(ctx, 2)
+	require.NotNil(t, cached)
+	assert.Equal(t, equivocation.Header.Hash(), cached.Hash())
+
+	// the first header is still remembered after the equivocation
+	svc.handlePandoraHeaderInfo(headerInfos[1])
+	assert.Equal(t, int64(3), svc.metrics.duplicateEvents.Count())
+
+	// the shard info of an evicted slot is not processed again when it is redelivered
+	svc.vanguardPendingShardingCache.Purge()
+	svc.handleVanguardShardInfo(shardInfos[0])
+	assert.Equal(t, int64(4), svc.metrics.duplicateEvents.Count())
+	evicted, _ := svc.vanguardPendingShardingCache.Get(ctx, 1)
+	assert.Equal(t, true, evicted == nil)
+}
+
+// TestSeenEvents checks that seen events are told apart by chain, slot and hash and are forgotten by purge
+func TestSeenEvents(t *testing.T) {
+	seen := newSeenEvents(seenEventsSize)
+	hash, otherHash := common.HexToHash("0x01"), common.HexToHash("0x02")
+	for slot := uint64(1); slot <= 3; slot++ {
+		assert.Equal(t, false, seen.duplicate(vanguardSource, slot, hash))
+		assert.Equal(t, false, seen.duplicate(pandoraSource, slot, hash))
+	}
+	assert.Equal(t, false, seen.duplicate(pandoraSource, 2, otherHash))
+	assert.Equal(t, true, seen.duplicate(pandoraSource, 2, hash))
+	assert.Equal(t, true, seen.duplicate(pandoraSource, 2, otherHash))
+	assert.Equal(t, true, seen.duplicate(vanguardSource, 3, hash))
+
+	seen.purge()
+	assert.Equal(t, false, seen.duplicate(vanguardSource, 3, hash))
+	assert.Equal(t, false, seen.duplicate(pandoraSource, 2, hash))
+}
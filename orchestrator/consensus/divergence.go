@@ -0,0 +1,71 @@
+package consensus
+
+import (
+	"fmt"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// divergenceLevel tells how far the heads of vanguard and pandora have diverged
+type divergenceLevel int
+
+const (
+	divergenceNone divergenceLevel = iota
+	divergenceWarning
+	divergenceAlert
+)
+
+// vanguardHeadSlot returns the latest slot of vanguard which is known by streamed blocks or canonical head
+func (s *Service) vanguardHeadSlot() uint64 {
+	headSlot := s.lastVanguardSlot
+	if s.canonicalHead != nil && s.canonicalHead.Slot > headSlot {
+		headSlot = s.canonicalHead.Slot
+	}
+	return headSlot
+}
+
+// checkDivergence compares vanguard head slot with the slot of the latest pandora header. Divergence which
+// exceeds the warning threshold is logged and divergence which exceeds the alert threshold raises an alert, both
+// once when the threshold is crossed. It is the earliest signal that one of the clients is stuck. Nothing is
+// checked until both chains have delivered a slot.
+func (s *Service) checkDivergence() {
+	vanguardSlot, pandoraSlot := s.vanguardHeadSlot(), s.lastPandoraSlot
+	if vanguardSlot == 0 || pandoraSlot == 0 {
+		return
+	}
+	s.metrics.divergence.Update(int64(vanguardSlot) - int64(pandoraSlot))
+
+	behind, ahead, divergence := "pandora", "vanguard", vanguardSlot-pandoraSlot
+	if pandoraSlot > vanguardSlot {
+		behind, ahead, divergence = "vanguard", "pandora", pandoraSlot-vanguardSlot
+	}
+	level := divergenceNone
+	switch {
+	case s.divergenceAlertSlots > 0 && divergence > s.divergenceAlertSlots:
+		level = divergenceAlert
+	case s.divergenceWarnSlots > 0 && divergence > s.divergenceWarnSlots:
+		level = divergenceWarning
+	}
+	if level == s.divergenceLevel {
+		return
+	}
+	previous := s.divergenceLevel
+	s.divergenceLevel = level
+
+	logger := log.WithField("vanguardSlot", vanguardSlot).WithField("pandoraSlot", pandoraSlot).
+		WithField("divergence", divergence)
+	switch {
+	case level == divergenceAlert:
+		logger.WithField("behind", behind).Error("Chains have diverged beyond alert threshold, a client may be stuck")
+		s.raiseAlert(&types.Alert{
+			Kind: types.AlertDivergence,
+			Slot: vanguardSlot,
+			Message: fmt.Sprintf("%s is %d slots behind %s: vanguard head slot %d, latest pandora header slot %d",
+				behind, divergence, ahead, vanguardSlot, pandoraSlot),
+		})
+	case level == divergenceWarning && previous == divergenceNone:
+		logger.WithField("behind", behind).Warn("Chains are diverging")
+	case level == divergenceNone:
+		logger.Info("Chains are in sync again")
+	}
+}
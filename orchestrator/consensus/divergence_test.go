@@ -0,0 +1,74 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+// TestService_CheckDivergence checks that divergence of chain heads is reported once per crossed threshold
+func TestService_CheckDivergence(t *testing.T) {
+	hook := logTest.NewGlobal()
+	svc, _ := setup(context.Background(), t)
+	sink := &mockAlertSink{}
+	svc.alertSinks = append(svc.alertSinks, sink)
+	svc.divergenceWarnSlots = 4
+	svc.divergenceAlertSlots = 16
+
+	// nothing is known about pandora yet
+	svc.lastVanguardSlot = 100
+	svc.checkDivergence()
+	assert.Equal(t, divergenceNone, svc.divergenceLevel)
+
+	svc.lastPandoraSlot = 96
+	svc.checkDivergence()
+	assert.Equal(t, divergenceNone, svc.divergenceLevel)
+
+	svc.lastVanguardSlot = 101
+	svc.checkDivergence()
+	assert.Equal(t, divergenceWarning, svc.divergenceLevel)
+	require.LogsContain(t, hook, "Chains are diverging")
+	assert.Equal(t, 0, len(sink.alerts))
+
+	svc.lastVanguardSlot = 113
+	svc.checkDivergence()
+	svc.lastVanguardSlot = 120
+	svc.checkDivergence()
+	assert.Equal(t, divergenceAlert, svc.divergenceLevel)
+	require.Equal(t, 1, len(sink.alerts), "alert is raised once")
+	assert.Equal(t, types.AlertDivergence, sink.alerts[0].Kind)
+	assert.Equal(t, uint64(113), sink.alerts[0].Slot)
+	assert.Equal(t, "pandora is 17 slots behind vanguard: vanguard head slot 113, latest pandora header slot 96",
+		sink.alerts[0].Message)
+
+	svc.lastPandoraSlot = 120
+	svc.checkDivergence()
+	assert.Equal(t, divergenceNone, svc.divergenceLevel)
+	require.LogsContain(t, hook, "Chains are in sync again")
+
+	// vanguard may be the stuck one
+	svc.lastPandoraSlot = 140
+	svc.checkDivergence()
+	require.Equal(t, 2, len(sink.alerts))
+	assert.Equal(t, "vanguard is 20 slots behind pandora: vanguard head slot 120, latest pandora header slot 140",
+		sink.alerts[1].Message)
+}
+
+// TestService_CheckDivergence_Disabled checks that zero thresholds disable warnings and alerts
+func TestService_CheckDivergence_Disabled(t *testing.T) {
+	svc, _ := setup(context.Background(), t)
+	sink := &mockAlertSink{}
+	svc.alertSinks = append(svc.alertSinks, sink)
+	svc.divergenceWarnSlots = 0
+	svc.divergenceAlertSlots = 0
+
+	svc.lastVanguardSlot = 1000
+	svc.lastPandoraSlot = 1
+	svc.checkDivergence()
+	assert.Equal(t, divergenceNone, svc.divergenceLevel)
+	assert.Equal(t, 0, len(sink.alerts))
+}
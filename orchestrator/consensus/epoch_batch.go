@@ -0,0 +1,170 @@
+package consensus
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/slotutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// errPandoraBehind is returned when pandora has not produced the headers of the requested slots yet
+var errPandoraBehind = errors.New("pandora has not reached the requested slots yet")
+
+// verifyEpoch fetches all the slots of an epoch from both chains with a single request per chain, verifies
+// them concurrently and stores the verdicts in a single db transaction. It is used during catch-up, live
+// slots are processed one by one.
+func (s *Service) verifyEpoch(epoch uint64, panHeadNumber uint64) (int, error) {
+	shardInfos, err := s.vanguardService.ShardInfosByEpoch(epoch)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not fetch vanguard shard infos of epoch %d", epoch)
+	}
+	shardInfos = withShardInfo(shardInfos)
+	if len(shardInfos) == 0 {
+		return 0, s.commitEpoch(epoch, nil)
+	}
+
+	fromNumber, toNumber := blockNumberRange(shardInfos)
+	if toNumber > panHeadNumber {
+		return 0, errPandoraBehind
+	}
+	batch, err := s.matchHeaders(shardInfos, fromNumber, toNumber)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not match pandora headers of epoch %d", epoch)
+	}
+	verifyConcurrently(batch, s.verificationWorkers)
+
+	if err := s.commitEpoch(epoch, batch); err != nil {
+		return 0, err
+	}
+	log.WithField("epoch", epoch).WithField("slots", len(batch)).Debug("Verified epoch batch")
+	return len(batch), nil
+}
+
+// commitEpoch stores verdicts of an epoch batch together with skipped slots of the epoch in a single db
+// transaction and then notifies subscribers about the verdicts in slot order.
+func (s *Service) commitEpoch(epoch uint64, batch []*slotVerification) error {
+	verdicts := make([]*types.SlotVerdict, 0, slotutil.SlotsPerEpoch())
+	skipped := make([]*types.SlotVerdict, 0)
+	next := 0
+	for slot := slotutil.EpochStartSlot(epoch); slot <= slotutil.EpochEndSlot(epoch); slot++ {
+		if next < len(batch) && batch[next].slot == slot {
+			next++
+			continue
+		}
+		skipped = append(skipped, &types.SlotVerdict{Slot: slot, Status: types.Skipped})
+	}
+	for _, v := range batch {
+		verdict := &types.SlotVerdict{
+			Slot:   v.slot,
+			Status: types.Verified,
+			SlotInfo: &types.SlotInfo{
+				PandoraHeaderHash: v.header.Hash(),
+				VanguardBlockHash: common.BytesToHash(v.shardInfo.BlockHash),
+			},
+		}
+		if !v.valid {
+			verdict.Status = types.Invalid
+			verdict.Evidence = &types.MismatchEvidence{
+				Slot:              v.slot,
+				Reason:            v.reason,
+				VanguardShardInfo: v.shardInfo,
+				PandoraHeader:     v.header,
+			}
+		}
+		verdicts = append(verdicts, verdict)
+	}
+	if err := s.verifiedSlotInfoDB.SaveVerificationBatch(append(verdicts, skipped...)); err != nil {
+		log.WithError(err).WithField("epoch", epoch).Error("Failed to store verification batch")
+		return err
+	}
+
+	for _, verdict := range skipped {
+		s.removePending(verdict.Slot)
+		s.pandoraPendingHeaderCache.Remove(s.ctx, verdict.Slot)
+		s.dequeueShardInfo(verdict.Slot)
+		s.countSkipped()
+	}
+	for i, verdict := range verdicts {
+		s.removePending(verdict.Slot)
+		verificationResult := &types.VerificationResult{
+			Slot:              verdict.Slot,
+			VanguardBlockHash: verdict.SlotInfo.VanguardBlockHash,
+			PandoraHeaderHash: verdict.SlotInfo.PandoraHeaderHash,
+			Status:            verdict.Status,
+		}
+		if verdict.Status == types.Invalid {
+			s.metrics.invalidSlots.Inc(1)
+			log.WithField("slot", verdict.Slot).WithField("reason", verdict.Evidence.Reason).Info("Invalid sharding info")
+			s.raiseAlert(&types.Alert{
+				Kind:              types.AlertMismatch,
+				Slot:              verdict.Slot,
+				Message:           verdict.Evidence.Reason,
+				VanguardBlockHash: verdict.SlotInfo.VanguardBlockHash,
+				PandoraHeaderHash: verdict.SlotInfo.PandoraHeaderHash,
+			})
+			s.dequeueShardInfo(verdict.Slot)
+			s.verificationResultFeed.Send(verificationResult)
+			continue
+		}
+		s.updateFinalized(batch[i].shardInfo)
+		s.countVerified()
+		s.pandoraPendingHeaderCache.Remove(s.ctx, verdict.Slot)
+		s.vanguardPendingShardingCache.Remove(s.ctx, verdict.Slot)
+		s.dequeueShardInfo(verdict.Slot)
+		s.reportVerified(verificationResult)
+	}
+	return nil
+}
+
+// blockNumberRange returns the lowest and the highest pandora block number of the shard infos, which must not be empty
+func blockNumberRange(shardInfos []*types.VanguardShardInfo) (uint64, uint64) {
+	fromNumber := shardInfos[0].ShardInfo.BlockNumber
+	toNumber := fromNumber
+	for _, shardInfo := range shardInfos {
+		if shardInfo.ShardInfo.BlockNumber < fromNumber {
+			fromNumber = shardInfo.ShardInfo.BlockNumber
+		}
+		if shardInfo.ShardInfo.BlockNumber > toNumber {
+			toNumber = shardInfo.ShardInfo.BlockNumber
+		}
+	}
+	return fromNumber, toNumber
+}
+
+// matchHeaders fetches the pandora headers of the given block number range in batched requests and matches them with
+// the shard infos
+func (s *Service) matchHeaders(
+	shardInfos []*types.VanguardShardInfo,
+	fromNumber, toNumber uint64,
+) ([]*slotVerification, error) {
+	headers, err := s.pandoraService.HeadersByRange(fromNumber, toNumber)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not fetch pandora headers %d to %d", fromNumber, toNumber)
+	}
+	headersByNumber := make(map[uint64]*eth1Types.Header, len(headers))
+	for _, header := range headers {
+		headersByNumber[header.Number.Uint64()] = header
+	}
+
+	batch := make([]*slotVerification, 0, len(shardInfos))
+	for _, shardInfo := range shardInfos {
+		header, exists := headersByNumber[shardInfo.ShardInfo.BlockNumber]
+		if !exists {
+			return nil, errors.Errorf("pandora header %d of slot %d is missing", shardInfo.ShardInfo.BlockNumber, shardInfo.Slot)
+		}
+		batch = append(batch, &slotVerification{slot: shardInfo.Slot, shardInfo: shardInfo, header: header})
+	}
+	return batch, nil
+}
+
+// withShardInfo filters out vanguard blocks without pandora shard
+func withShardInfo(shardInfos []*types.VanguardShardInfo) []*types.VanguardShardInfo {
+	filtered := shardInfos[:0]
+	for _, shardInfo := range shardInfos {
+		if shardInfo != nil && shardInfo.ShardInfo != nil {
+			filtered = append(filtered, shardInfo)
+		}
+	}
+	return filtered
+}
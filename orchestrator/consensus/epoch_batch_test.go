@@ -0,0 +1,79 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/slotutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// TestService_VerifyEpoch checks that all the slots of an epoch are verified and stored as a single batch
+func TestService_VerifyEpoch(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 71)
+	svc, mockedFeed := setup(ctx, t)
+
+	// slot 40 gets a header which belongs to another slot
+	shardInfos[39] = testutil.NewVanguardShardInfo(40, headerInfos[40].Header)
+	for i := range headerInfos {
+		mockedFeed.headers[headerInfos[i].Header.Number.Uint64()] = headerInfos[i].Header
+		mockedFeed.shardInfos[shardInfos[i].Slot] = shardInfos[i]
+	}
+	// vanguard proposal of slot 50 is missed
+	delete(mockedFeed.shardInfos, 50)
+
+	// pandora has not produced the whole epoch yet
+	_, err := svc.verifyEpoch(1, 50)
+	assert.Equal(t, errPandoraBehind, err)
+
+	verified, err := svc.verifyEpoch(1, 70)
+	require.NoError(t, err)
+	assert.Equal(t, int(slotutil.SlotsPerEpoch())-1, verified)
+	assert.Equal(t, uint64(63), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+
+	for slot := uint64(32); slot < 64; slot++ {
+		status, err := svc.verifiedSlotInfoDB.SlotStatus(slot)
+		require.NoError(t, err)
+		if slot == 40 {
+			assert.Equal(t, types.Invalid, status)
+			continue
+		}
+		if slot == 50 {
+			assert.Equal(t, types.Skipped, status)
+			continue
+		}
+		assert.Equal(t, types.Verified, status)
+	}
+	evidence, err := svc.invalidSlotInfoDB.MismatchEvidence(40)
+	require.NoError(t, err)
+	require.NotNil(t, evidence)
+	assert.Equal(t, reasonExtraDataSlot, evidence.Reason)
+}
+
+// TestService_ResumeFromCheckpoint_EpochBatch checks that catch-up verifies whole epochs as batches and
+// the slots at the live head one by one
+func TestService_ResumeFromCheckpoint_EpochBatch(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 71)
+	svc, mockedFeed := setup(ctx, t)
+
+	for i := range headerInfos {
+		mockedFeed.headers[headerInfos[i].Header.Number.Uint64()] = headerInfos[i].Header
+		mockedFeed.shardInfos[shardInfos[i].Slot] = shardInfos[i]
+	}
+	mockedFeed.headSlot = 70
+	mockedFeed.latestBlockNumber = headerInfos[69].Header.Number.Uint64()
+
+	require.NoError(t, svc.resumeFromCheckpoint())
+	assert.Equal(t, uint64(70), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+	assert.Equal(t, uint64(70), svc.VerificationProgress().VerifiedSlots)
+	for slot := uint64(1); slot <= 70; slot++ {
+		status, err := svc.verifiedSlotInfoDB.SlotStatus(slot)
+		require.NoError(t, err)
+		assert.Equal(t, types.Verified, status)
+	}
+}
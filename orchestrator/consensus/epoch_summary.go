@@ -0,0 +1,122 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/slotutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// epochLatency sums confirmation latencies of the slots of an epoch which have been reported as verified
+type epochLatency struct {
+	total time.Duration
+	slots uint64
+}
+
+// epochSummaries keeps the state of epoch summaries which is not stored in db
+type epochSummaries struct {
+	started   bool
+	nextEpoch uint64 // first epoch which has not been summarized yet
+	latencies map[uint64]*epochLatency
+}
+
+// recordPublished records confirmation latency of a reported verified slot and summarizes the epochs which
+// verification has moved past. The epoch of the first reported slot is only summarized when the slot starts
+// it, since latencies of the earlier slots are not known.
+func (s *Service) recordPublished(verificationResult *types.VerificationResult, now time.Time) {
+	slot := verificationResult.Slot
+	epoch := slotutil.ToEpoch(slot)
+	if !s.summaries.started {
+		s.summaries.started = true
+		s.summaries.nextEpoch = epoch
+		if !slotutil.IsEpochStart(slot) {
+			s.summaries.nextEpoch++
+		}
+		s.summaries.latencies = make(map[uint64]*epochLatency)
+	}
+	if epoch < s.summaries.nextEpoch {
+		return
+	}
+
+	if verificationResult.Status == types.Verified {
+		if slotStart, ok := slotutil.SlotStartTime(slot); ok && !now.Before(slotStart) {
+			latency := s.summaries.latencies[epoch]
+			if latency == nil {
+				latency = &epochLatency{}
+				s.summaries.latencies[epoch] = latency
+			}
+			latency.total += now.Sub(slotStart)
+			latency.slots++
+		}
+	}
+
+	for ; s.summaries.nextEpoch < epoch; s.summaries.nextEpoch++ {
+		s.summarizeEpoch(s.summaries.nextEpoch, now)
+		delete(s.summaries.latencies, s.summaries.nextEpoch)
+	}
+}
+
+// summarizeEpoch stores the summary of the epoch by the slot statuses which are stored in db
+func (s *Service) summarizeEpoch(epoch uint64, now time.Time) {
+	summary, err := s.epochSummary(epoch, now)
+	if err != nil {
+		log.WithError(err).WithField("epoch", epoch).Warn("Failed to summarize epoch")
+		return
+	}
+	if err := s.verifiedSlotInfoDB.SaveEpochSummary(summary); err != nil {
+		log.WithError(err).WithField("epoch", epoch).Warn("Failed to store epoch summary")
+		return
+	}
+	log.WithField("epoch", epoch).
+		WithField("verified", summary.VerifiedSlots).
+		WithField("skipped", summary.SkippedSlots).
+		WithField("invalid", summary.InvalidSlots).
+		WithField("avgConfirmationLatencyMs", summary.AvgConfirmationLatencyMs).
+		WithField("proposerParticipation", summary.ProposerParticipation).
+		Info("Epoch summary")
+}
+
+// epochSummary builds the summary of the epoch
+func (s *Service) epochSummary(epoch uint64, now time.Time) (*types.EpochSummary, error) {
+	fromSlot, toSlot := slotutil.EpochStartSlot(epoch), slotutil.EpochEndSlot(epoch)
+	statuses, err := s.verifiedSlotInfoDB.SlotStatuses(fromSlot, toSlot)
+	if err != nil {
+		return nil, err
+	}
+	summary := &types.EpochSummary{
+		Epoch:     epoch,
+		FromSlot:  fromSlot,
+		ToSlot:    toSlot,
+		Timestamp: now.Unix(),
+	}
+	for slot := fromSlot; slot <= toSlot; slot++ {
+		switch statuses[slot] {
+		case types.Verified, types.Finalized:
+			summary.VerifiedSlots++
+		case types.Skipped:
+			summary.SkippedSlots++
+			if s.proposerProvider == nil {
+				continue
+			}
+			if proposer, err := s.proposerProvider.ProposerForSlot(slot); err == nil {
+				summary.MissedProposers = append(summary.MissedProposers, proposer)
+			}
+		case types.Invalid:
+			summary.InvalidSlots++
+		case types.Orphaned:
+			summary.OrphanedSlots++
+		case types.Pending:
+			summary.PendingSlots++
+		default:
+			summary.UnknownSlots++
+		}
+	}
+	if seen := toSlot - fromSlot + 1 - summary.UnknownSlots; seen > 0 {
+		summary.ProposerParticipation = float64(seen-summary.SkippedSlots) / float64(seen)
+	}
+	if latency := s.summaries.latencies[epoch]; latency != nil && latency.slots > 0 {
+		summary.ConfirmedSlots = latency.slots
+		summary.AvgConfirmationLatencyMs = uint64((latency.total / time.Duration(latency.slots)).Milliseconds())
+	}
+	return summary, nil
+}
@@ -0,0 +1,63 @@
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+type mockProposerProvider struct{}
+
+func (mockProposerProvider) ProposerForSlot(slot uint64) (string, error) {
+	return fmt.Sprintf("0x%02x", slot), nil
+}
+
+// TestService_EpochSummary checks that an epoch is summarized once a slot of a later epoch is reported
+func TestService_EpochSummary(t *testing.T) {
+	previous := params.ActiveNetworkConfig()
+	defer params.UseNetworkConfig(previous)
+	params.UseNetworkConfig(&params.NetworkConfig{GenesisTime: 1000, SecondsPerSlot: 6, SlotsPerEpoch: 4})
+
+	svc, _ := setup(context.Background(), t)
+	svc.proposerProvider = mockProposerProvider{}
+	statuses := map[uint64]types.Status{4: types.Verified, 5: types.Skipped, 6: types.Invalid, 7: types.Finalized}
+	for slot, status := range statuses {
+		require.NoError(t, svc.verifiedSlotInfoDB.SaveSlotStatus(slot, status))
+	}
+
+	// epoch 0 is not summarized, since latencies of its earlier slots are not known
+	svc.recordPublished(&types.VerificationResult{Slot: 3, Status: types.Verified}, time.Unix(1030, 0))
+	svc.recordPublished(&types.VerificationResult{Slot: 4, Status: types.Verified}, time.Unix(1026, 0))
+	svc.recordPublished(&types.VerificationResult{Slot: 6, Status: types.Invalid}, time.Unix(1040, 0))
+	svc.recordPublished(&types.VerificationResult{Slot: 7, Status: types.Verified}, time.Unix(1046, 0))
+	summary, err := svc.verifiedSlotInfoDB.EpochSummary(1)
+	require.NoError(t, err)
+	assert.Equal(t, true, summary == nil)
+
+	svc.recordPublished(&types.VerificationResult{Slot: 9, Status: types.Verified}, time.Unix(1060, 0))
+	summary, err = svc.verifiedSlotInfoDB.EpochSummary(0)
+	require.NoError(t, err)
+	assert.Equal(t, true, summary == nil)
+	summary, err = svc.verifiedSlotInfoDB.EpochSummary(1)
+	require.NoError(t, err)
+	require.NotNil(t, summary)
+	assert.Equal(t, uint64(4), summary.FromSlot)
+	assert.Equal(t, uint64(7), summary.ToSlot)
+	assert.Equal(t, uint64(2), summary.VerifiedSlots)
+	assert.Equal(t, uint64(1), summary.SkippedSlots)
+	assert.Equal(t, uint64(1), summary.InvalidSlots)
+	assert.Equal(t, uint64(0), summary.UnknownSlots)
+	assert.Equal(t, 0.75, summary.ProposerParticipation)
+	assert.DeepEqual(t, []string{"0x05"}, summary.MissedProposers)
+	// slot 4 starts at 1024 and is reported at 1026, slot 7 starts at 1042 and is reported at 1046
+	assert.Equal(t, uint64(2), summary.ConfirmedSlots)
+	assert.Equal(t, uint64(3000), summary.AvgConfirmationLatencyMs)
+	assert.Equal(t, int64(1060), summary.Timestamp)
+	assert.Equal(t, 1, len(svc.summaries.latencies))
+}
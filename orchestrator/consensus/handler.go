@@ -1,47 +1,122 @@
 package consensus
 
 import (
+	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
 )
 
-// processPandoraHeader
+// errReplacedShardInfo finishes the trace of vanguard shard info which is replaced by another block of its slot
+var errReplacedShardInfo = errors.New("replaced by another vanguard block of the slot")
+
+// processPandoraHeader caches the pandora header and enqueues it for verification when vanguard shard
+// info of the same slot has already arrived. Otherwise the slot is marked as pending.
 func (s *Service) processPandoraHeader(headerInfo *types.PandoraHeaderInfo) error {
 	slot := headerInfo.Slot
-	s.pandoraPendingHeaderCache.Put(s.ctx, slot, headerInfo.Header)
+	if cachedHeader, _ := s.pandoraPendingHeaderCache.Get(s.ctx, slot); cachedHeader != nil &&
+		cachedHeader.Hash() != headerInfo.Header.Hash() {
+		s.alertEquivocation(slot, "pandora", cachedHeader.Hash(), headerInfo.Header.Hash())
+	}
+	if err := s.pandoraPendingHeaderCache.Put(s.ctx, slot, headerInfo.Header); err != nil {
+		s.metrics.headerCacheFailures.Inc(1)
+		return errors.Wrap(err, "could not cache pandora header")
+	}
+	if slot > s.lastPandoraSlot {
+		s.lastPandoraSlot = slot
+	}
 	vanShardInfo, _ := s.vanguardPendingShardingCache.Get(s.ctx, slot)
 	if vanShardInfo != nil {
-		return s.verifyShardingInfo(slot, vanShardInfo, headerInfo.Header)
+		s.enqueueVerification(slot, vanShardInfo, headerInfo.Header)
+		return nil
 	}
-	return nil
+	return s.markPending(slot)
 }
 
-// processVanguardShardInfo
+// processVanguardShardInfo caches the vanguard shard info and enqueues it for verification when pandora
+// header of the same slot has already arrived. Otherwise the slot is marked as pending.
 func (s *Service) processVanguardShardInfo(vanShardInfo *types.VanguardShardInfo) error {
 	slot := vanShardInfo.Slot
-	s.vanguardPendingShardingCache.Put(s.ctx, slot, vanShardInfo)
+	s.skipGap(slot)
+	if cachedShardInfo, _ := s.vanguardPendingShardingCache.Get(s.ctx, slot); cachedShardInfo != nil &&
+		!bytes.Equal(cachedShardInfo.BlockHash, vanShardInfo.BlockHash) {
+		s.alertEquivocation(slot, "vanguard", common.BytesToHash(cachedShardInfo.BlockHash),
+			common.BytesToHash(vanShardInfo.BlockHash))
+		cachedShardInfo.Trace.Finish(errReplacedShardInfo)
+	}
+	if err := s.vanguardPendingShardingCache.Put(s.ctx, slot, vanShardInfo); err != nil {
+		vanShardInfo.Trace.Finish(err)
+		return errors.Wrap(err, "could not cache vanguard shard info")
+	}
+	vanShardInfo.Trace.Stage("cached")
 	headerInfo, _ := s.pandoraPendingHeaderCache.Get(s.ctx, slot)
 	if headerInfo != nil {
-		return s.verifyShardingInfo(slot, vanShardInfo, headerInfo)
+		s.enqueueVerification(slot, vanShardInfo, headerInfo)
+		return nil
 	}
+	return s.markPending(slot)
+}
+
+// markPending stores pending status for a slot which has been received only from one chain
+func (s *Service) markPending(slot uint64) error {
+	if err := s.verifiedSlotInfoDB.SaveSlotStatus(slot, types.Pending); err != nil {
+		log.WithField("slot", slot).WithError(err).Error("Failed to store pending slot status")
+		return err
+	}
+	s.addPending(slot)
+	log.WithField("slot", slot).Debug("Waiting for the other chain, slot is pending")
 	return nil
 }
 
+// verifyExtraDataSlot checks that the slot which is encoded in pandora header extra data is the same slot
+// which vanguard reports for the shard.
+func verifyExtraDataSlot(slot uint64, header *eth1Types.Header) bool {
+	var extraData types.PanExtraDataWithBLSSig
+	if err := rlp.DecodeBytes(header.Extra, &extraData); err != nil {
+		log.WithError(err).WithField("slot", slot).Error("Failed to decode pandora extra data")
+		return false
+	}
+	if extraData.Slot != slot {
+		log.WithField("vanguardSlot", slot).WithField("pandoraSlot", extraData.Slot).
+			Error("extra data slot mismatched")
+		return false
+	}
+	return true
+}
+
 // verifyShardingInfo
 func (s *Service) verifyShardingInfo(slot uint64, vanShardInfo *types.VanguardShardInfo, header *eth1Types.Header) error {
+	verification := &slotVerification{slot: slot, shardInfo: vanShardInfo, header: header}
+	verification.verify()
+	return s.commitVerification(verification)
+}
+
+// commitVerification stores the verification verdict of a slot and notifies subscribers about it.
+// Both sides of a rejected slot are kept as mismatch evidence.
+func (s *Service) commitVerification(verification *slotVerification) (err error) {
+	slot, vanShardInfo, header := verification.slot, verification.shardInfo, verification.header
+	defer func() {
+		if err != nil {
+			vanShardInfo.Trace.Finish(err)
+		}
+	}()
 	slotInfo := &types.SlotInfo{
 		PandoraHeaderHash: header.Hash(),
 		VanguardBlockHash: common.BytesToHash(vanShardInfo.BlockHash[:]),
 	}
-	status := CompareShardingInfo(header, vanShardInfo.ShardInfo)
-	slotInfoWithStatus := &types.SlotInfoWithStatus{
+	verificationResult := &types.VerificationResult{
+		Slot:              slot,
 		PandoraHeaderHash: header.Hash(),
 		VanguardBlockHash: common.BytesToHash(vanShardInfo.BlockHash[:]),
+		Trace:             vanShardInfo.Trace,
 	}
-	if !status {
+	s.removePending(slot)
+	if !verification.valid {
 		// store invalid slot info into invalid slot info bucket
 		if err := s.invalidSlotInfoDB.SaveInvalidSlotInfo(slot, slotInfo); err != nil {
 			log.WithField("slot", slot).WithField(
@@ -49,56 +124,119 @@ func (s *Service) verifyShardingInfo(slot uint64, vanShardInfo *types.VanguardSh
 				"Failed to store invalid slot info")
 			return err
 		}
-		slotInfoWithStatus.Status = types.Invalid
-		log.WithField("slot", slot).Info("Invalid sharding info")
+		if err := s.invalidSlotInfoDB.SaveMismatchEvidence(&types.MismatchEvidence{
+			Slot:              slot,
+			Reason:            verification.reason,
+			VanguardShardInfo: vanShardInfo,
+			PandoraHeader:     header,
+		}); err != nil {
+			log.WithField("slot", slot).WithError(err).Error("Failed to store mismatch evidence")
+			return err
+		}
+		if err := s.verifiedSlotInfoDB.SaveSlotStatus(slot, types.Invalid); err != nil {
+			log.WithField("slot", slot).WithError(err).Error("Failed to store invalid slot status")
+			return err
+		}
+		vanShardInfo.Trace.Stage("persisted")
+		s.dequeueShardInfo(slot)
+		verificationResult.Status = types.Invalid
+		s.metrics.invalidSlots.Inc(1)
+		log.WithField("slot", slot).WithField("reason", verification.reason).Info("Invalid sharding info")
+		s.raiseAlert(&types.Alert{
+			Kind:              types.AlertMismatch,
+			Slot:              slot,
+			Message:           verification.reason,
+			VanguardBlockHash: slotInfo.VanguardBlockHash,
+			PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+		})
 		// sending verified slot info to rpc service
-		s.verifiedSlotInfoFeed.Send(slotInfoWithStatus)
+		s.publishVerificationResult(verificationResult)
 		return nil
 	}
 
-	// store verified slot info into verified slot info bucket
-	if err := s.verifiedSlotInfoDB.SaveVerifiedSlotInfo(slot, slotInfo); err != nil {
+	// store verified slot info with latest verified markers as a single checkpoint
+	if err := s.verifiedSlotInfoDB.SaveVerificationCheckpoint(slot, slotInfo); err != nil {
 		log.WithField("slot", slot).WithField(
 			"slotInfo", fmt.Sprintf("%+v", slotInfo)).WithError(err).Error("Failed to store verified slot info")
 		return err
 	}
 
-	// storing latest verified slot into db
-	if err := s.verifiedSlotInfoDB.SaveLatestVerifiedSlot(s.ctx, slot); err != nil {
-		log.WithError(err).Error("Failed to store latest verified slot")
-	}
-
-	// storing latest verified pandora header hash into db
-	if err := s.verifiedSlotInfoDB.SaveLatestVerifiedHeaderHash(slotInfo.PandoraHeaderHash); err != nil {
-		log.WithError(err).Error("Failed to store latest verified slot")
+	if err := s.verifiedSlotInfoDB.SaveSlotStatus(slot, types.Verified); err != nil {
+		log.WithField("slot", slot).WithError(err).Error("Failed to store verified slot status")
+		return err
 	}
+	vanShardInfo.Trace.Stage("persisted")
 
-	// Storing latest finalized slot and epoch
-	if s.verifiedSlotInfoDB.LatestLatestFinalizedEpoch() < vanShardInfo.FinalizedEpoch {
-		if err := s.verifiedSlotInfoDB.SaveLatestFinalizedSlot(vanShardInfo.FinalizedSlot); err != nil {
-			log.WithError(err).Warn("Failed to store new finalized info")
-		}
-
-		if err := s.verifiedSlotInfoDB.SaveLatestFinalizedEpoch(vanShardInfo.FinalizedEpoch); err != nil {
-			log.WithError(err).Warn("Failed to store new finalized epoch")
-		}
-		log.WithField("newFinalizedSlot", vanShardInfo.FinalizedSlot).
-			WithField("newFinalizedEpoch", vanShardInfo.FinalizedEpoch).Debug("Saved latest finalized info")
-	}
+	s.updateFinalized(vanShardInfo)
 
-	slotInfoWithStatus.Status = types.Verified
+	verificationResult.Status = types.Verified
+	s.countVerified()
 	//removing previous cached slots which dont verified yet. By convention, they are skipped
 	s.pandoraPendingHeaderCache.Remove(s.ctx, slot)
 	s.vanguardPendingShardingCache.Remove(s.ctx, slot)
+	s.dequeueShardInfo(slot)
 	log.WithField("slot", slot).Info("Successfully verified sharding info")
-	// sending verified slot info to rpc service
-	s.verifiedSlotInfoFeed.Send(slotInfoWithStatus)
+	// sending verified slot info to rpc service after enough confirmations
+	s.reportVerified(verificationResult)
 	return nil
 }
 
-func (s *Service) reorgDB(revertSlot uint64) error {
+// updateFinalized stores latest finalized slot and epoch which are reported by vanguard shard info
+func (s *Service) updateFinalized(vanShardInfo *types.VanguardShardInfo) {
+	if s.verifiedSlotInfoDB.LatestLatestFinalizedEpoch() >= vanShardInfo.FinalizedEpoch {
+		return
+	}
+	s.markFinalized(s.verifiedSlotInfoDB.LatestLatestFinalizedSlot(), vanShardInfo.FinalizedSlot)
+	if err := s.verifiedSlotInfoDB.SaveLatestFinalizedSlot(vanShardInfo.FinalizedSlot); err != nil {
+		log.WithError(err).Warn("Failed to store new finalized info")
+	}
+
+	if err := s.verifiedSlotInfoDB.SaveLatestFinalizedEpoch(vanShardInfo.FinalizedEpoch); err != nil {
+		log.WithError(err).Warn("Failed to store new finalized epoch")
+	}
+	if err := s.verifiedSlotInfoDB.SaveFinalizationRecord(vanShardInfo.Slot, vanShardInfo.FinalizedSlot); err != nil {
+		log.WithError(err).Warn("Failed to store finalization history")
+	}
+	log.WithField("newFinalizedSlot", vanShardInfo.FinalizedSlot).
+		WithField("newFinalizedEpoch", vanShardInfo.FinalizedEpoch).Debug("Saved latest finalized info")
+}
+
+// markFinalized upgrades verified slot statuses to finalized from previous finalized slot to
+// new finalized slot.
+func (s *Service) markFinalized(prevFinalizedSlot, finalizedSlot uint64) {
+	if finalizedSlot <= prevFinalizedSlot {
+		return
+	}
+	finalized, err := s.verifiedSlotInfoDB.FinalizeSlotStatuses(prevFinalizedSlot+1, finalizedSlot)
+	if err != nil {
+		log.WithError(err).WithField("finalizedSlot", finalizedSlot).Warn("Failed to mark verified slots as finalized")
+		return
+	}
+	log.WithField("fromSlot", prevFinalizedSlot+1).WithField("toSlot", finalizedSlot).
+		WithField("finalizedSlots", finalized).Debug("Marked verified slots as finalized")
+}
+
+// reorgDB walks back the verified slots after revertSlot, flips their status to invalid, notifies
+// subscribers about the invalidation and records the action in reorg audit log.
+func (s *Service) reorgDB(revertSlot uint64, newSlot uint64) error {
+	latestVerifiedSlot := s.verifiedSlotInfoDB.LatestSavedVerifiedSlot()
+	invalidatedSlotInfos := make(map[uint64]*types.SlotInfo)
+	invalidatedSlots := make([]uint64, 0)
+	for slot := revertSlot + 1; slot <= latestVerifiedSlot; slot++ {
+		slotInfo, err := s.verifiedSlotInfoDB.VerifiedSlotInfo(slot)
+		if err != nil {
+			log.WithError(err).WithField("slot", slot).Error("failed to retrieve verified slot info in reorg phase")
+			return err
+		}
+		if slotInfo == nil {
+			continue
+		}
+		invalidatedSlotInfos[slot] = slotInfo
+		invalidatedSlots = append(invalidatedSlots, slot)
+	}
+
 	// Removing slot infos from verified slot info db
-	if err := s.verifiedSlotInfoDB.RemoveRangeVerifiedInfo(revertSlot+1, s.verifiedSlotInfoDB.LatestSavedVerifiedSlot()); err != nil {
+	if err := s.verifiedSlotInfoDB.RemoveRangeVerifiedInfo(revertSlot+1, latestVerifiedSlot); err != nil {
 		log.WithError(err).Error("found error while reverting orchestrator database in reorg phase")
 		return err
 	}
@@ -107,5 +245,45 @@ func (s *Service) reorgDB(revertSlot uint64) error {
 		log.WithError(err).Error("failed to update latest verified slot info in reorg phase")
 		return err
 	}
+
+	// queued vanguard shard infos are dropped along with the pending cache
+	if err := s.verifiedSlotInfoDB.PurgePendingShardInfos(); err != nil {
+		log.WithError(err).Error("failed to purge queued vanguard shard infos in reorg phase")
+		return err
+	}
+
+	for _, slot := range invalidatedSlots {
+		slotInfo := invalidatedSlotInfos[slot]
+		if err := s.verifiedSlotInfoDB.SaveSlotStatus(slot, types.Invalid); err != nil {
+			log.WithError(err).WithField("slot", slot).Error("failed to store invalid slot status in reorg phase")
+			return err
+		}
+		// sending invalidated slot info to rpc service and pandora
+		s.verificationResultFeed.Send(&types.VerificationResult{
+			Slot:              slot,
+			VanguardBlockHash: slotInfo.VanguardBlockHash,
+			PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+			Status:            types.Invalid,
+		})
+	}
+
+	record := &types.ReorgAuditRecord{
+		Timestamp:            time.Now().Unix(),
+		NewSlot:              newSlot,
+		RevertSlot:           revertSlot,
+		InvalidatedSlots:     invalidatedSlots,
+		InvalidatedSlotInfos: make([]*types.SlotInfo, 0, len(invalidatedSlots)),
+	}
+	for _, slot := range invalidatedSlots {
+		record.InvalidatedSlotInfos = append(record.InvalidatedSlotInfos, invalidatedSlotInfos[slot])
+	}
+	if err := s.verifiedSlotInfoDB.SaveReorgAuditRecord(record); err != nil {
+		log.WithError(err).Error("failed to store reorg audit record")
+		return err
+	}
+
+	s.metrics.reorgedSlots.Inc(int64(len(invalidatedSlots)))
+	log.WithField("revertSlot", revertSlot).WithField("newSlot", newSlot).
+		WithField("invalidatedSlots", len(invalidatedSlots)).Info("Invalidated verified slots due to reorg")
 	return nil
 }
@@ -0,0 +1,82 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func Test_VerifyExtraDataSlot(t *testing.T) {
+	header := testutil.NewEth1Header(10)
+	assert.Equal(t, true, verifyExtraDataSlot(10, header))
+	assert.Equal(t, false, verifyExtraDataSlot(11, header))
+
+	header.Extra = []byte{0x01}
+	assert.Equal(t, false, verifyExtraDataSlot(10, header))
+}
+
+// Test_ReorgDB checks that verified slots after revert slot are invalidated and recorded in reorg audit log
+func Test_ReorgDB(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 6)
+	svc, _ := setup(ctx, t)
+
+	for i := range headerInfos {
+		require.NoError(t, svc.verifyShardingInfo(headerInfos[i].Slot, shardInfos[i], headerInfos[i].Header))
+	}
+	require.Equal(t, uint64(5), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+
+	invalidatedCh := make(chan *types.VerificationResult, 10)
+	sub := svc.SubscribeVerificationResultEvent(invalidatedCh)
+	defer sub.Unsubscribe()
+
+	require.NoError(t, svc.reorgDB(2, 4))
+	assert.Equal(t, uint64(2), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+
+	for slot := uint64(3); slot <= 5; slot++ {
+		status, err := svc.verifiedSlotInfoDB.SlotStatus(slot)
+		require.NoError(t, err)
+		assert.Equal(t, types.Invalid, status)
+
+		slotInfo, err := svc.verifiedSlotInfoDB.VerifiedSlotInfo(slot)
+		require.NoError(t, err)
+		assert.Equal(t, true, slotInfo == nil)
+
+		invalidated := <-invalidatedCh
+		assert.Equal(t, types.Invalid, invalidated.Status)
+		assert.Equal(t, headerInfos[slot-1].Header.Hash(), invalidated.PandoraHeaderHash)
+	}
+
+	records, err := svc.verifiedSlotInfoDB.ReorgAuditRecords()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, uint64(2), records[0].RevertSlot)
+	assert.Equal(t, uint64(4), records[0].NewSlot)
+	assert.DeepEqual(t, []uint64{3, 4, 5}, records[0].InvalidatedSlots)
+}
+
+// Test_MarkFinalized checks that verified slots up to finalized slot are upgraded to finalized
+func Test_MarkFinalized(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 6)
+	svc, _ := setup(ctx, t)
+
+	for i := range headerInfos {
+		require.NoError(t, svc.verifyShardingInfo(headerInfos[i].Slot, shardInfos[i], headerInfos[i].Header))
+	}
+
+	svc.markFinalized(0, 3)
+	for slot := uint64(1); slot <= 5; slot++ {
+		status, err := svc.verifiedSlotInfoDB.SlotStatus(slot)
+		require.NoError(t, err)
+		if slot <= 3 {
+			assert.Equal(t, types.Finalized, status)
+		} else {
+			assert.Equal(t, types.Verified, status)
+		}
+	}
+}
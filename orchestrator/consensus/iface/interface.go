@@ -6,5 +6,18 @@ import (
 )
 
 type VerifiedSlotInfoFeed interface {
-	SubscribeVerifiedSlotInfoEvent(chan<- *types.SlotInfoWithStatus) event.Subscription
+	SubscribeVerificationResultEvent(chan<- *types.VerificationResult) event.Subscription
+}
+
+type ProgressReporter interface {
+	VerificationProgress() *types.VerificationProgress
+}
+
+// AlertSink receives alerts of verification mismatches, equivocations and prolonged unmatched slots
+type AlertSink interface {
+	Alert(alert *types.Alert)
+}
+
+type Reverifier interface {
+	Reverify(fromSlot, toSlot uint64) (*types.ReverifyResult, error)
 }
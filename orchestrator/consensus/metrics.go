@@ -0,0 +1,67 @@
+package consensus
+
+import (
+	"sync/atomic"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/slotutil"
+)
+
+// serviceMetrics holds consensus service metrics, so that dashboards can alert when verification falls behind
+type serviceMetrics struct {
+	verifiedSlots    metrics.Meter
+	invalidSlots     metrics.Counter
+	reorgedSlots     metrics.Counter
+	verificationLag  metrics.Gauge
+	wallClockLag     metrics.Gauge
+	pendingQueueSize metrics.Gauge
+	divergence       metrics.Gauge
+	clockSkew        metrics.Gauge
+	duplicateEvents  metrics.Counter
+	// pandora headers which could not be inserted into the pending cache
+	headerCacheFailures metrics.Counter
+}
+
+// newServiceMetrics registers consensus service metrics of the given network, empty for the main network
+func newServiceMetrics(network string) *serviceMetrics {
+	collector := metrics.NewCollector("consensus").Sub(network)
+	return &serviceMetrics{
+		verifiedSlots:       collector.Meter("slots/verified"),
+		invalidSlots:        collector.Counter("slots/invalid"),
+		reorgedSlots:        collector.Counter("slots/reorged"),
+		verificationLag:     collector.Gauge("lag"),
+		wallClockLag:        collector.Gauge("lag/wallclock"),
+		pendingQueueSize:    collector.Gauge("pending"),
+		divergence:          collector.Gauge("divergence"),
+		clockSkew:           collector.Gauge("clockskew"),
+		duplicateEvents:     collector.Counter("events/duplicate"),
+		headerCacheFailures: collector.Counter("cache/pandora/failed"),
+	}
+}
+
+// updateGauges refreshes verification lag behind vanguard head and behind wall clock slot, pending queue depth and
+// divergence of chain heads.
+// Lag behind wall clock slot is only known when genesis time and slot duration are configured.
+func (s *Service) updateGauges() {
+	headSlot := s.vanguardHeadSlot()
+	latestVerifiedSlot := s.verifiedSlotInfoDB.LatestSavedVerifiedSlot()
+	lag := uint64(0)
+	if headSlot > latestVerifiedSlot {
+		lag = headSlot - latestVerifiedSlot
+	}
+	atomic.StoreUint64(&s.verificationLag, lag)
+	s.metrics.verificationLag.Update(int64(lag))
+	if wallClockSlot, ok := slotutil.CurrentSlot(); ok {
+		wallClockLag := uint64(0)
+		if wallClockSlot > latestVerifiedSlot {
+			wallClockLag = wallClockSlot - latestVerifiedSlot
+		}
+		s.metrics.wallClockLag.Update(int64(wallClockLag))
+	}
+
+	s.processingLock.Lock()
+	pending := len(s.pendingQueue)
+	s.processingLock.Unlock()
+	s.metrics.pendingQueueSize.Update(int64(pending + len(s.heldSlots)))
+	s.checkDivergence()
+}
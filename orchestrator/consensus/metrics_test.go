@@ -0,0 +1,68 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// TestService_UpdateGauges checks verification lag and pending queue depth gauges
+func TestService_UpdateGauges(t *testing.T) {
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = false }()
+
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 4)
+	svc, _ := setup(ctx, t)
+	svc.metrics = &serviceMetrics{
+		verifiedSlots:    metrics.NewMeter(),
+		invalidSlots:     metrics.NewCounter(),
+		reorgedSlots:     metrics.NewCounter(),
+		verificationLag:  metrics.NewGauge(),
+		pendingQueueSize: metrics.NewGauge(),
+	}
+	defer svc.metrics.verifiedSlots.Stop()
+
+	require.NoError(t, svc.verifyShardingInfo(headerInfos[0].Slot, shardInfos[0], headerInfos[0].Header))
+	require.NoError(t, svc.processVanguardShardInfo(shardInfos[2]))
+	svc.canonicalHead = &types.CanonicalHead{Slot: 5, BlockHash: common.BytesToHash(shardInfos[2].BlockHash)}
+	svc.updateGauges()
+
+	assert.Equal(t, int64(1), svc.metrics.verifiedSlots.Count())
+	assert.Equal(t, int64(4), svc.metrics.verificationLag.Value())
+	assert.Equal(t, int64(1), svc.metrics.pendingQueueSize.Value())
+}
+
+// failingHeaderCache is a pandora header cache which refuses every header
+type failingHeaderCache struct {
+	cache.PandoraHeaderCache
+}
+
+func (c *failingHeaderCache) Put(ctx context.Context, slot uint64, header *eth1Types.Header) error {
+	return errors.New("cache is full")
+}
+
+// TestService_HeaderCacheFailures checks that pandora headers which can not be cached are counted
+func TestService_HeaderCacheFailures(t *testing.T) {
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = false }()
+
+	ctx := context.Background()
+	headerInfos, _ := getHeaderInfosAndShardInfos(1, 3)
+	svc, _ := setup(ctx, t)
+	svc.pandoraPendingHeaderCache = &failingHeaderCache{svc.pandoraPendingHeaderCache}
+	svc.metrics.headerCacheFailures = metrics.NewCounter()
+
+	for _, headerInfo := range headerInfos {
+		assert.ErrorContains(t, "could not cache pandora header", svc.processPandoraHeader(headerInfo))
+	}
+	assert.Equal(t, int64(2), svc.metrics.headerCacheFailures.Count())
+}
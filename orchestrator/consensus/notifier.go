@@ -0,0 +1,42 @@
+package consensus
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// runVerdictPusher subscribes to verification verdicts and pushes them to pandora node, so pandora
+// can promote verified headers to canonical or drop invalid ones. Verdicts are pushed only while this
+// orchestrator leads, the standby verifies the same slots without competing with the leader.
+func (s *Service) runVerdictPusher() {
+	defer shared.RecoverService(s)
+	verdictCh := make(chan *types.VerificationResult, 100)
+	verdictSub := s.SubscribeVerificationResultEvent(verdictCh)
+	defer verdictSub.Unsubscribe()
+
+	for {
+		select {
+		case verificationResult := <-verdictCh:
+			if verificationResult.Status != types.Verified && verificationResult.Status != types.Invalid {
+				continue
+			}
+			if s.leadership != nil && !s.leadership.IsLeader() {
+				continue
+			}
+			blockStatus := &types.BlockStatus{
+				Hash:          verificationResult.PandoraHeaderHash,
+				Status:        verificationResult.Status,
+				FinalizedSlot: s.verifiedSlotInfoDB.LatestLatestFinalizedSlot(),
+			}
+			if err := s.pandoraService.ConfirmBlock(blockStatus); err != nil {
+				log.WithError(err).WithField("slot", verificationResult.Slot).
+					WithField("headerHash", verificationResult.PandoraHeaderHash).
+					WithField("status", verificationResult.Status).Warn("Failed to push verification verdict to pandora")
+			}
+		case <-verdictSub.Err():
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
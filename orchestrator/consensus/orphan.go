@@ -0,0 +1,74 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// reasons of evicting a pending slot which are stored in orphan bucket
+const (
+	orphanReasonUnmatched    = "unmatched"
+	orphanReasonNonCanonical = "non-canonical"
+)
+
+// evictOrphans moves the slots which have stayed pending longer than orphan max age out of the hot path.
+// Unmatched slots and slots which are held on a non-canonical fork are stored into orphan bucket.
+func (s *Service) evictOrphans(now time.Time) {
+	s.processingLock.Lock()
+	expired := make(map[uint64]time.Time)
+	for slot, entry := range s.pendingQueue {
+		if now.Sub(entry.added) > s.orphanMaxAge {
+			expired[slot] = entry.added
+		}
+	}
+	s.processingLock.Unlock()
+
+	for slot, added := range expired {
+		orphan := &types.OrphanSlot{
+			Slot:         slot,
+			Reason:       orphanReasonUnmatched,
+			PendingSince: added.Unix(),
+			EvictedAt:    now.Unix(),
+		}
+		if vanShardInfo, _ := s.vanguardPendingShardingCache.Get(s.ctx, slot); vanShardInfo != nil {
+			orphan.VanguardBlockHash = common.BytesToHash(vanShardInfo.BlockHash)
+		}
+		if header, _ := s.pandoraPendingHeaderCache.Get(s.ctx, slot); header != nil {
+			orphan.PandoraHeaderHash = header.Hash()
+		}
+		s.evictOrphan(orphan)
+	}
+
+	for slot, since := range s.heldSince {
+		if now.Sub(since) <= s.orphanMaxAge {
+			continue
+		}
+		v := s.heldSlots[slot]
+		s.evictOrphan(&types.OrphanSlot{
+			Slot:              slot,
+			Reason:            orphanReasonNonCanonical,
+			PendingSince:      since.Unix(),
+			EvictedAt:         now.Unix(),
+			VanguardBlockHash: common.BytesToHash(v.shardInfo.BlockHash),
+			PandoraHeaderHash: v.header.Hash(),
+		})
+	}
+}
+
+// evictOrphan stores the orphan slot and forgets everything about the slot in memory
+func (s *Service) evictOrphan(orphan *types.OrphanSlot) {
+	if err := s.verifiedSlotInfoDB.SaveOrphanSlot(orphan); err != nil {
+		log.WithError(err).WithField("slot", orphan.Slot).Warn("Failed to store orphan slot")
+		return
+	}
+	s.removePending(orphan.Slot)
+	s.releaseHeld(orphan.Slot)
+	s.pandoraPendingHeaderCache.Remove(s.ctx, orphan.Slot)
+	s.vanguardPendingShardingCache.Remove(s.ctx, orphan.Slot)
+	s.dequeueShardInfo(orphan.Slot)
+	log.WithField("slot", orphan.Slot).WithField("reason", orphan.Reason).
+		WithField("pendingFor", time.Duration(orphan.EvictedAt-orphan.PendingSince)*time.Second).
+		Warn("Evicted pending slot as orphan")
+}
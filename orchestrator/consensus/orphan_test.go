@@ -0,0 +1,52 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// TestService_EvictOrphans checks that slots which stay pending beyond maximum age are moved to orphan bucket
+func TestService_EvictOrphans(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 5)
+	svc, _ := setup(ctx, t)
+
+	// slot 2 is unmatched, slot 3 is held on a fork
+	require.NoError(t, svc.processPandoraHeader(headerInfos[1]))
+	svc.canonicalHead = &types.CanonicalHead{Slot: 2, BlockHash: common.BytesToHash(shardInfos[0].BlockHash)}
+	v := &slotVerification{slot: 3, shardInfo: shardInfos[2], header: headerInfos[2].Header, valid: true}
+	require.NoError(t, svc.holdNonCanonical(v))
+
+	// nothing is evicted before maximum age
+	svc.evictOrphans(time.Now())
+	assert.Equal(t, 1, len(svc.pendingQueue))
+	assert.Equal(t, 1, len(svc.heldSlots))
+
+	svc.evictOrphans(time.Now().Add(svc.orphanMaxAge + time.Minute))
+	assert.Equal(t, 0, len(svc.pendingQueue))
+	assert.Equal(t, 0, len(svc.heldSlots))
+
+	orphan, err := svc.verifiedSlotInfoDB.OrphanSlot(2)
+	require.NoError(t, err)
+	require.NotNil(t, orphan)
+	assert.Equal(t, orphanReasonUnmatched, orphan.Reason)
+	assert.Equal(t, headerInfos[1].Header.Hash(), orphan.PandoraHeaderHash)
+
+	orphan, err = svc.verifiedSlotInfoDB.OrphanSlot(3)
+	require.NoError(t, err)
+	require.NotNil(t, orphan)
+	assert.Equal(t, orphanReasonNonCanonical, orphan.Reason)
+	assert.Equal(t, common.BytesToHash(shardInfos[2].BlockHash), orphan.VanguardBlockHash)
+
+	for _, slot := range []uint64{2, 3} {
+		status, err := svc.verifiedSlotInfoDB.SlotStatus(slot)
+		require.NoError(t, err)
+		assert.Equal(t, types.Orphaned, status)
+	}
+}
@@ -0,0 +1,114 @@
+package consensus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// pendingEntry keeps track of a slot which has been received only from one chain
+type pendingEntry struct {
+	added     time.Time
+	since     time.Time
+	queried   bool
+	unmatched bool
+}
+
+// addPending puts slot into pending queue if it is not there already
+func (s *Service) addPending(slot uint64) {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+	if _, exists := s.pendingQueue[slot]; exists {
+		return
+	}
+	now := time.Now()
+	s.pendingQueue[slot] = &pendingEntry{added: now, since: now}
+}
+
+// removePending removes slot from pending queue after verification
+func (s *Service) removePending(slot uint64) {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+	delete(s.pendingQueue, slot)
+}
+
+// resetPending drops all the pending slots, e.g. after reorg
+func (s *Service) resetPending() {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+	s.pendingQueue = make(map[uint64]*pendingEntry)
+}
+
+// processExpiredPending walks over pending queue and actively queries the missing side of the slots
+// which have been waiting longer than pending timeout. When the other side could not be found after
+// querying, the slot is declared as unmatched and stays in the queue until the other side arrives or
+// the slot is evicted as orphan.
+func (s *Service) processExpiredPending() {
+	now := time.Now()
+	for slot, entry := range s.pendingQueue {
+		if entry.unmatched || now.Sub(entry.since) < s.pendingTimeout {
+			continue
+		}
+		if entry.queried {
+			// missed vanguard proposal is not a matching error
+			if s.vanguardSkipped(slot) {
+				if err := s.markSkipped(slot); err != nil {
+					log.WithError(err).WithField("slot", slot).Warn("Failed to mark slot as skipped")
+				}
+				continue
+			}
+			log.WithField("slot", slot).WithField("pendingFor", now.Sub(entry.since)).
+				Warn("Slot is still unmatched after querying the other chain")
+			entry.unmatched = true
+			s.countUnmatched()
+			s.raiseAlert(&types.Alert{
+				Kind:    types.AlertUnmatched,
+				Slot:    slot,
+				Message: fmt.Sprintf("slot is still unmatched after %s", now.Sub(entry.added).Round(time.Second)),
+			})
+			continue
+		}
+
+		// requeue the slot and query the missing side
+		entry.queried = true
+		entry.since = now
+		if err := s.queryMissingSide(slot); err != nil {
+			log.WithError(err).WithField("slot", slot).Debug("Failed to query missing side of pending slot")
+		}
+	}
+}
+
+// queryMissingSide fetches vanguard shard info or pandora header of a pending slot from the chain which
+// has not delivered it yet and feeds it into the verification pipeline.
+func (s *Service) queryMissingSide(slot uint64) error {
+	vanShardInfo, _ := s.vanguardPendingShardingCache.Get(s.ctx, slot)
+	panHeader, _ := s.pandoraPendingHeaderCache.Get(s.ctx, slot)
+
+	switch {
+	case vanShardInfo != nil && panHeader == nil:
+		blockNumber := vanShardInfo.ShardInfo.BlockNumber
+		headers, err := s.pandoraService.HeadersByRange(blockNumber, blockNumber)
+		if err != nil {
+			return err
+		}
+		log.WithField("slot", slot).WithField("blockNumber", blockNumber).Debug("Fetched missing pandora header")
+		return s.processPandoraHeader(&types.PandoraHeaderInfo{Slot: slot, Header: headers[0]})
+	case vanShardInfo == nil && panHeader != nil:
+		shardInfo, err := s.vanguardService.ShardInfoBySlot(slot)
+		if err != nil {
+			return err
+		}
+		if shardInfo == nil {
+			log.WithField("slot", slot).Debug("Vanguard has no canonical block for pending slot")
+			if headSlot, err := s.vanguardService.HeadSlot(); err == nil && slot <= headSlot {
+				return s.markSkipped(slot)
+			}
+			return nil
+		}
+		log.WithField("slot", slot).Debug("Fetched missing vanguard shard info")
+		s.queueShardInfo(shardInfo)
+		return s.processVanguardShardInfo(shardInfo)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+// TestService_PendingQueue checks that missing side of a pending slot is queried after pending timeout
+func TestService_PendingQueue(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 4)
+	svc, mockedFeed := setup(ctx, t)
+	svc.pendingTimeout = 200 * time.Millisecond
+	defer svc.Stop()
+
+	// pandora header of slot 1 can be queried, vanguard shard info of slot 2 can be queried
+	mockedFeed.headers[headerInfos[0].Header.Number.Uint64()] = headerInfos[0].Header
+	mockedFeed.shardInfos[shardInfos[1].Slot] = shardInfos[1]
+
+	svc.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	// only vanguard shard info of slot 1 arrives, pandora header is queried after timeout
+	mockedFeed.shardInfoFeed.Send(shardInfos[0])
+	time.Sleep(100 * time.Millisecond)
+	status, err := svc.verifiedSlotInfoDB.SlotStatus(1)
+	require.NoError(t, err)
+	assert.Equal(t, types.Pending, status)
+
+	time.Sleep(500 * time.Millisecond)
+	status, err = svc.verifiedSlotInfoDB.SlotStatus(1)
+	require.NoError(t, err)
+	assert.Equal(t, types.Verified, status)
+
+	// only pandora header of slot 2 arrives, vanguard shard info is queried after timeout
+	mockedFeed.headerInfoFeed.Send(headerInfos[1])
+	time.Sleep(100 * time.Millisecond)
+	status, err = svc.verifiedSlotInfoDB.SlotStatus(2)
+	require.NoError(t, err)
+	assert.Equal(t, types.Pending, status)
+
+	time.Sleep(500 * time.Millisecond)
+	status, err = svc.verifiedSlotInfoDB.SlotStatus(2)
+	require.NoError(t, err)
+	assert.Equal(t, types.Verified, status)
+
+	// slot 3 can not be queried from pandora chain
+	mockedFeed.shardInfoFeed.Send(shardInfos[2])
+	time.Sleep(time.Second)
+	status, err = svc.verifiedSlotInfoDB.SlotStatus(3)
+	require.NoError(t, err)
+	assert.Equal(t, types.Pending, status)
+	assert.LogsContain(t, hook, "Slot is still unmatched after querying the other chain")
+}
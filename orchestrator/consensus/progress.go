@@ -0,0 +1,75 @@
+package consensus
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// time between two updates of verification rate
+var verificationRatePeriod = time.Minute
+
+// verificationProgress keeps the counters of verification progress which are not stored in db
+type verificationProgress struct {
+	unmatchedSlots     uint64
+	skippedSlots       uint64
+	verifiedSlots      uint64
+	verifiedSlotsRate  float64
+	lastVerifiedSlots  uint64
+	lastProgressReport time.Time
+}
+
+// VerificationProgress returns the current verification progress of the consensus service
+func (s *Service) VerificationProgress() *types.VerificationProgress {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+
+	return &types.VerificationProgress{
+		LatestVerifiedSlot:     s.verifiedSlotInfoDB.LatestSavedVerifiedSlot(),
+		LatestFinalizedSlot:    s.verifiedSlotInfoDB.LatestLatestFinalizedSlot(),
+		LatestFinalizedEpoch:   s.verifiedSlotInfoDB.LatestLatestFinalizedEpoch(),
+		PendingSlots:           uint64(len(s.pendingQueue)),
+		UnmatchedSlots:         s.progress.unmatchedSlots,
+		SkippedSlots:           s.progress.skippedSlots,
+		VerifiedSlots:          s.progress.verifiedSlots,
+		VerifiedSlotsPerMinute: s.progress.verifiedSlotsRate,
+		VerificationLag:        atomic.LoadUint64(&s.verificationLag),
+	}
+}
+
+// countVerified increases the number of verified slots
+func (s *Service) countVerified() {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+	s.progress.verifiedSlots++
+	s.metrics.verifiedSlots.Mark(1)
+}
+
+// countUnmatched increases the number of slots which could not be matched with the other chain
+func (s *Service) countUnmatched() {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+	s.progress.unmatchedSlots++
+}
+
+// countSkipped increases the number of slots without vanguard block
+func (s *Service) countSkipped() {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+	s.progress.skippedSlots++
+}
+
+// updateVerificationRate calculates number of verified slots per minute since the last report
+func (s *Service) updateVerificationRate(now time.Time) {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+
+	elapsed := now.Sub(s.progress.lastProgressReport)
+	if !s.progress.lastProgressReport.IsZero() && elapsed > 0 {
+		verified := s.progress.verifiedSlots - s.progress.lastVerifiedSlots
+		s.progress.verifiedSlotsRate = float64(verified) / elapsed.Minutes()
+	}
+	s.progress.lastVerifiedSlots = s.progress.verifiedSlots
+	s.progress.lastProgressReport = now
+}
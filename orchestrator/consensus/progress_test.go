@@ -0,0 +1,34 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+// TestService_VerificationProgress checks verified, pending and unmatched slot counters and verification rate
+func TestService_VerificationProgress(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 5)
+	svc, _ := setup(ctx, t)
+
+	now := time.Now()
+	svc.updateVerificationRate(now)
+	for i := range headerInfos {
+		require.NoError(t, svc.verifyShardingInfo(headerInfos[i].Slot, shardInfos[i], headerInfos[i].Header))
+	}
+	svc.addPending(10)
+	svc.addPending(11)
+	svc.countUnmatched()
+	svc.updateVerificationRate(now.Add(2 * time.Minute))
+
+	progress := svc.VerificationProgress()
+	assert.Equal(t, uint64(4), progress.LatestVerifiedSlot)
+	assert.Equal(t, uint64(4), progress.VerifiedSlots)
+	assert.Equal(t, uint64(2), progress.PendingSlots)
+	assert.Equal(t, uint64(1), progress.UnmatchedSlots)
+	assert.Equal(t, float64(2), progress.VerifiedSlotsPerMinute)
+}
@@ -0,0 +1,115 @@
+package consensus
+
+import (
+	"math"
+	"sort"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// reasons of a replayed verdict which could not be re-derived or differs from the stored one
+const (
+	reasonVerifiedInfoMissing = "verified slot info missing"
+	reasonInvalidInfoMissing  = "invalid slot info missing"
+)
+
+// Replay re-derives verification results of every slot with stored status purely from the data in db, without
+// touching any of the chains. Slots with stored mismatch evidence are verified again from both stored sides,
+// other slots are checked against their stored slot infos. Replayed results are stored into a separate bucket,
+// stored verification results are never changed.
+func (s *Service) Replay() (*types.ReplaySummary, error) {
+	statuses, err := s.verifiedSlotInfoDB.SlotStatuses(0, math.MaxUint64)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read stored slot statuses")
+	}
+	slots := make([]uint64, 0, len(statuses))
+	for slot := range statuses {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i] < slots[j]
+	})
+
+	summary := &types.ReplaySummary{MismatchedSlots: make([]uint64, 0)}
+	results := make([]*types.ReplayResult, 0, len(slots))
+	for _, slot := range slots {
+		result, reverified, err := s.replaySlot(slot, statuses[slot])
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+		summary.ReplayedSlots++
+		if reverified {
+			summary.ReverifiedSlots++
+		}
+		if replayedStatusOf(result.StoredStatus) != result.ReplayedStatus {
+			summary.MismatchedSlots = append(summary.MismatchedSlots, slot)
+			log.WithField("slot", slot).WithField("storedStatus", result.StoredStatus).
+				WithField("replayedStatus", result.ReplayedStatus).WithField("reason", result.Reason).
+				Warn("Replayed verdict differs from stored one")
+		}
+	}
+
+	if err := s.verifiedSlotInfoDB.SaveReplayResults(results); err != nil {
+		return nil, errors.Wrap(err, "could not store replay results")
+	}
+	log.WithField("replayed", summary.ReplayedSlots).WithField("reverified", summary.ReverifiedSlots).
+		WithField("mismatched", len(summary.MismatchedSlots)).Info("Replayed stored verification results")
+	return summary, nil
+}
+
+// replaySlot re-derives the verdict of a single slot. It returns true when the slot has been verified
+// again from its stored mismatch evidence.
+func (s *Service) replaySlot(slot uint64, stored types.Status) (*types.ReplayResult, bool, error) {
+	result := &types.ReplayResult{Slot: slot, StoredStatus: stored, ReplayedStatus: stored}
+
+	verifiedInfo, err := s.verifiedSlotInfoDB.VerifiedSlotInfo(slot)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "could not read verified slot info of slot %d", slot)
+	}
+	evidence, err := s.invalidSlotInfoDB.MismatchEvidence(slot)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "could not read mismatch evidence of slot %d", slot)
+	}
+	// evidence is stale when the slot has been accepted with another header afterwards
+	if evidence != nil && evidence.VanguardShardInfo != nil && evidence.PandoraHeader != nil &&
+		(verifiedInfo == nil || verifiedInfo.PandoraHeaderHash == evidence.PandoraHeader.Hash()) {
+		v := &slotVerification{slot: slot, shardInfo: evidence.VanguardShardInfo, header: evidence.PandoraHeader}
+		v.verify()
+		result.ReplayedStatus = types.Invalid
+		result.Reason = v.reason
+		if v.valid {
+			result.ReplayedStatus = types.Verified
+		}
+		return result, true, nil
+	}
+
+	switch stored {
+	case types.Verified, types.Finalized:
+		result.ReplayedStatus = types.Verified
+		if verifiedInfo == nil {
+			result.ReplayedStatus = types.Unknown
+			result.Reason = reasonVerifiedInfoMissing
+		}
+	case types.Invalid:
+		invalidInfo, err := s.invalidSlotInfoDB.InvalidSlotInfo(slot)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "could not read invalid slot info of slot %d", slot)
+		}
+		if invalidInfo == nil {
+			result.ReplayedStatus = types.Unknown
+			result.Reason = reasonInvalidInfoMissing
+		}
+	}
+	return result, false, nil
+}
+
+// replayedStatusOf returns the status which replay derives for a slot with the given stored status.
+// Replay does not know about finality, so finalized slots are replayed as verified.
+func replayedStatusOf(stored types.Status) types.Status {
+	if stored == types.Finalized {
+		return types.Verified
+	}
+	return stored
+}
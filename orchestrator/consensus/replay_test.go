@@ -0,0 +1,62 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// TestService_Replay checks that replay re-derives verdicts from stored data only and reports the slots
+// whose stored verdict cannot be reproduced
+func TestService_Replay(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 6)
+	svc, _ := setup(ctx, t)
+
+	// slot 4 gets a header which belongs to another slot
+	shardInfos[3] = testutil.NewVanguardShardInfo(4, headerInfos[4].Header)
+	for i := range headerInfos {
+		svc.enqueueVerification(headerInfos[i].Slot, shardInfos[i], headerInfos[i].Header)
+	}
+	require.NoError(t, svc.flushVerifications())
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveSlotStatus(6, types.Skipped))
+	_, err := svc.verifiedSlotInfoDB.FinalizeSlotStatuses(1, 1)
+	require.NoError(t, err)
+
+	summary, err := svc.Replay()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), summary.ReplayedSlots)
+	assert.Equal(t, uint64(1), summary.ReverifiedSlots)
+	assert.DeepEqual(t, []uint64{}, summary.MismatchedSlots)
+
+	// stored status of slot 2 is corrupted and evidence of slot 4 no longer shows a mismatch
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveSlotStatus(2, types.Invalid))
+	require.NoError(t, svc.invalidSlotInfoDB.SaveMismatchEvidence(&types.MismatchEvidence{
+		Slot:              4,
+		Reason:            reasonShardingInfo,
+		VanguardShardInfo: testutil.NewVanguardShardInfo(4, headerInfos[3].Header),
+		PandoraHeader:     headerInfos[3].Header,
+	}))
+
+	summary, err = svc.Replay()
+	require.NoError(t, err)
+	assert.DeepEqual(t, []uint64{2, 4}, summary.MismatchedSlots)
+
+	results, err := svc.verifiedSlotInfoDB.ReplayResults()
+	require.NoError(t, err)
+	require.Equal(t, 6, len(results))
+	assert.Equal(t, types.Unknown, results[1].ReplayedStatus)
+	assert.Equal(t, reasonInvalidInfoMissing, results[1].Reason)
+	assert.Equal(t, types.Invalid, results[3].StoredStatus)
+	assert.Equal(t, types.Verified, results[3].ReplayedStatus)
+	assert.Equal(t, types.Skipped, results[5].ReplayedStatus)
+
+	// stored verification results are untouched
+	status, err := svc.verifiedSlotInfoDB.SlotStatus(4)
+	require.NoError(t, err)
+	assert.Equal(t, types.Invalid, status)
+}
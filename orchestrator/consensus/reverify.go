@@ -0,0 +1,154 @@
+package consensus
+
+import (
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// maximum number of slots which can be re-verified by a single request
+var maxReverifySlots = uint64(10000)
+
+var (
+	errInvalidSlotRange  = errors.New("invalid slot range, from slot is greater than to slot")
+	errTooManySlots      = errors.New("slot range is too big to re-verify")
+	errReverifyInProcess = errors.New("another re-verification is in process")
+)
+
+// Reverify refetches vanguard shard infos and pandora headers of the given slot range from both chains,
+// recomputes verification and overwrites the stored results. Latest verified markers are not moved.
+func (s *Service) Reverify(fromSlot, toSlot uint64) (*types.ReverifyResult, error) {
+	if fromSlot > toSlot {
+		return nil, errInvalidSlotRange
+	}
+	if toSlot-fromSlot >= maxReverifySlots {
+		return nil, errors.Wrapf(errTooManySlots, "maximum %d slots", maxReverifySlots)
+	}
+	if !atomic.CompareAndSwapUint32(&s.reverifying, 0, 1) {
+		return nil, errReverifyInProcess
+	}
+	defer atomic.StoreUint32(&s.reverifying, 0)
+
+	result := &types.ReverifyResult{
+		FromSlot:     fromSlot,
+		ToSlot:       toSlot,
+		ChangedSlots: make([]uint64, 0),
+	}
+	// slots are counted from the start of the range, so that a range which ends at the maximum slot terminates
+	shardInfos := make([]*types.VanguardShardInfo, 0)
+	for i := uint64(0); i <= toSlot-fromSlot; i++ {
+		slot := fromSlot + i
+		shardInfo, err := s.vanguardService.ShardInfoBySlot(slot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not fetch vanguard shard info of slot %d", slot)
+		}
+		if shardInfo == nil || shardInfo.ShardInfo == nil {
+			result.MissingSlots++
+			continue
+		}
+		shardInfos = append(shardInfos, shardInfo)
+	}
+
+	batch := make([]*slotVerification, 0)
+	if len(shardInfos) > 0 {
+		fromNumber, toNumber := blockNumberRange(shardInfos)
+		var err error
+		if batch, err = s.matchHeaders(shardInfos, fromNumber, toNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	verifyConcurrently(batch, s.verificationWorkers)
+
+	latestVerifiedSlot := s.verifiedSlotInfoDB.LatestSavedVerifiedSlot()
+	latestVerifiedRemoved := false
+	for _, v := range batch {
+		changed, err := s.storeReverification(v)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			result.ChangedSlots = append(result.ChangedSlots, v.slot)
+			latestVerifiedRemoved = latestVerifiedRemoved || (!v.valid && v.slot == latestVerifiedSlot)
+		}
+		if v.valid {
+			result.VerifiedSlots++
+		} else {
+			result.InvalidSlots++
+		}
+	}
+
+	// latest verified slot has been rejected, so move latest verified markers to the previous verified slot
+	if latestVerifiedRemoved {
+		if err := s.verifiedSlotInfoDB.UpdateVerifiedSlotInfo(latestVerifiedSlot); err != nil {
+			return nil, errors.Wrap(err, "could not update latest verified slot")
+		}
+	}
+
+	log.WithField("fromSlot", fromSlot).WithField("toSlot", toSlot).
+		WithField("verified", result.VerifiedSlots).WithField("invalid", result.InvalidSlots).
+		WithField("missing", result.MissingSlots).WithField("changed", len(result.ChangedSlots)).
+		Info("Re-verified slot range")
+	return result, nil
+}
+
+// storeReverification overwrites stored verification result of a slot and notifies subscribers about
+// the new verdict. It returns true when the verdict differs from the stored one.
+func (s *Service) storeReverification(v *slotVerification) (bool, error) {
+	slotInfo := &types.SlotInfo{
+		PandoraHeaderHash: v.header.Hash(),
+		VanguardBlockHash: common.BytesToHash(v.shardInfo.BlockHash[:]),
+	}
+	prevStatus, err := s.verifiedSlotInfoDB.SlotStatus(v.slot)
+	if err != nil {
+		return false, err
+	}
+
+	status := types.Invalid
+	if v.valid {
+		status = types.Verified
+		if prevStatus == types.Finalized {
+			status = types.Finalized
+		}
+		if err := s.verifiedSlotInfoDB.SaveVerifiedSlotInfo(v.slot, slotInfo); err != nil {
+			return false, errors.Wrapf(err, "could not store verified slot info of slot %d", v.slot)
+		}
+	} else {
+		if err := s.verifiedSlotInfoDB.RemoveRangeVerifiedInfo(v.slot, v.slot); err != nil {
+			return false, errors.Wrapf(err, "could not remove verified slot info of slot %d", v.slot)
+		}
+		if err := s.invalidSlotInfoDB.SaveInvalidSlotInfo(v.slot, slotInfo); err != nil {
+			return false, errors.Wrapf(err, "could not store invalid slot info of slot %d", v.slot)
+		}
+		if err := s.invalidSlotInfoDB.SaveMismatchEvidence(&types.MismatchEvidence{
+			Slot:              v.slot,
+			Reason:            v.reason,
+			VanguardShardInfo: v.shardInfo,
+			PandoraHeader:     v.header,
+		}); err != nil {
+			return false, errors.Wrapf(err, "could not store mismatch evidence of slot %d", v.slot)
+		}
+	}
+	if err := s.verifiedSlotInfoDB.SaveSlotStatus(v.slot, status); err != nil {
+		return false, errors.Wrapf(err, "could not store slot status of slot %d", v.slot)
+	}
+
+	changed := prevStatus != status
+	if changed {
+		if status == types.Invalid {
+			s.metrics.invalidSlots.Inc(1)
+		}
+		log.WithField("slot", v.slot).WithField("previousStatus", prevStatus).
+			WithField("status", status).Warn("Re-verification changed slot status")
+		// sending new verdict to rpc service and pandora
+		s.verificationResultFeed.Send(&types.VerificationResult{
+			Slot:              v.slot,
+			PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+			VanguardBlockHash: slotInfo.VanguardBlockHash,
+			Status:            status,
+		})
+	}
+	return changed, nil
+}
@@ -0,0 +1,61 @@
+package consensus
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// TestService_Reverify checks that stored verification results are overwritten by re-verification
+func TestService_Reverify(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 5)
+	svc, mockedFeed := setup(ctx, t)
+
+	for i := range headerInfos {
+		require.NoError(t, svc.verifyShardingInfo(headerInfos[i].Slot, shardInfos[i], headerInfos[i].Header))
+		mockedFeed.headers[headerInfos[i].Header.Number.Uint64()] = headerInfos[i].Header
+		mockedFeed.shardInfos[shardInfos[i].Slot] = shardInfos[i]
+	}
+	require.Equal(t, uint64(4), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+
+	// vanguard reports a different shard for slot 4 now
+	mockedFeed.shardInfos[4] = testutil.NewVanguardShardInfo(4, headerInfos[2].Header)
+	mockedFeed.shardInfos[4].ShardInfo.BlockNumber = headerInfos[3].Header.Number.Uint64()
+
+	_, err := svc.Reverify(5, 1)
+	assert.ErrorContains(t, errInvalidSlotRange.Error(), err)
+
+	mockedFeed.headerRangeRequests = 0
+	result, err := svc.Reverify(1, 5)
+	require.NoError(t, err)
+	// headers of all slots are fetched by a single request
+	assert.Equal(t, 1, mockedFeed.headerRangeRequests)
+	assert.Equal(t, uint64(3), result.VerifiedSlots)
+	assert.Equal(t, uint64(1), result.InvalidSlots)
+	assert.Equal(t, uint64(1), result.MissingSlots)
+	assert.DeepEqual(t, []uint64{4}, result.ChangedSlots)
+
+	status, err := svc.verifiedSlotInfoDB.SlotStatus(4)
+	require.NoError(t, err)
+	assert.Equal(t, types.Invalid, status)
+	slotInfo, err := svc.verifiedSlotInfoDB.VerifiedSlotInfo(4)
+	require.NoError(t, err)
+	assert.Equal(t, true, slotInfo == nil)
+	assert.Equal(t, uint64(3), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+
+	evidence, err := svc.invalidSlotInfoDB.MismatchEvidence(4)
+	require.NoError(t, err)
+	require.NotNil(t, evidence)
+	assert.Equal(t, reasonShardingInfo, evidence.Reason)
+
+	// range which ends at the maximum slot terminates
+	result, err = svc.Reverify(math.MaxUint64-1, math.MaxUint64)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), result.MissingSlots)
+}
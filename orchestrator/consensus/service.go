@@ -3,15 +3,22 @@ package consensus
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/event"
 
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	conIface "github.com/lukso-network/lukso-orchestrator/orchestrator/consensus/iface"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/ha"
 	iface2 "github.com/lukso-network/lukso-orchestrator/orchestrator/pandorachain/iface"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain/iface"
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/eventbuffer"
+	"github.com/lukso-network/lukso-orchestrator/shared/feed"
+	"github.com/lukso-network/lukso-orchestrator/shared/slotutil"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 )
 
@@ -23,8 +30,43 @@ type Config struct {
 
 	VanguardShardFeed iface.VanguardService
 	PandoraHeaderFeed iface2.PandoraService
+
+	// PendingTimeout is the time to wait for the other chain before querying it actively
+	PendingTimeout time.Duration
+	// VerificationWorkers is the number of workers which verify matched slots concurrently
+	VerificationWorkers int
+	// VerificationBatchSize is the maximum number of queued matched slots which are verified together
+	VerificationBatchSize int
+	// ConfirmationDepth is the number of subsequent verified slots before a slot is reported as verified
+	ConfirmationDepth uint64
+	// OrphanMaxAge is the maximum time a slot may stay pending before it is evicted as orphan
+	OrphanMaxAge time.Duration
+	// DivergenceWarnSlots is the divergence of vanguard head and latest pandora header in slots which is logged
+	// as warning, zero disables the warning
+	DivergenceWarnSlots uint64
+	// DivergenceAlertSlots is the divergence of vanguard head and latest pandora header in slots which raises an
+	// alert, zero disables the alert
+	DivergenceAlertSlots uint64
+	// ClockSkewThreshold is the skew of local clock against vanguard slot timing which is logged as warning,
+	// zero disables the warning
+	ClockSkewThreshold time.Duration
+	// AlertSinks receive alerts of mismatches, equivocations, prolonged unmatched slots and chain divergence
+	AlertSinks []conIface.AlertSink
+	// ProposerProvider resolves the proposers of skipped slots in epoch summaries, they are omitted when it is nil
+	ProposerProvider iface.ProposerProvider
+	// Leadership holds back verdicts from pandora while this orchestrator does not lead, nil pushes them always
+	Leadership ha.Leadership
+	// Network is the name of an additional network whose metrics are collected apart, empty for the main network
+	Network string
 }
 
+const (
+	// default time to wait for the other chain before querying it actively
+	defaultPendingTimeout = 30 * time.Second
+	// default maximum time a slot may stay pending before it is evicted as orphan
+	defaultOrphanMaxAge = 10 * time.Minute
+)
+
 // Service This part could be moved to other place during refactor, might be registered as a service
 type Service struct {
 	isRunning      bool
@@ -33,16 +75,53 @@ type Service struct {
 	cancel         context.CancelFunc
 	runError       error
 
-	scope                        event.SubscriptionScope
 	verifiedSlotInfoDB           db.VerifiedSlotInfoDB
 	invalidSlotInfoDB            db.InvalidSlotInfoDB
 	vanguardPendingShardingCache cache.VanguardShardCache
 	pandoraPendingHeaderCache    cache.PandoraHeaderCache
 
-	vanguardService      iface.VanguardService
-	pandoraService       iface2.PandoraService
-	verifiedSlotInfoFeed event.Feed
-	reorgInProgress      bool
+	vanguardService        iface.VanguardService
+	pandoraService         iface2.PandoraService
+	verificationResultFeed *feed.Feed
+	reorgInProgress        bool
+
+	pendingQueue   map[uint64]*pendingEntry
+	pendingTimeout time.Duration
+	orphanMaxAge   time.Duration
+
+	verificationBatch     []*slotVerification
+	verificationBatchSize int
+	verificationWorkers   int
+
+	lastVanguardSlot uint64
+	lastPandoraSlot  uint64
+
+	divergenceWarnSlots  uint64
+	divergenceAlertSlots uint64
+	divergenceLevel      divergenceLevel
+
+	clockSkewThreshold time.Duration
+	clockSkewed        bool
+	headOffsets        []time.Duration // offsets of the latest canonical heads from the start of their slots
+
+	confirmationDepth uint64
+	unconfirmedSlots  []*types.VerificationResult
+
+	canonicalHead *types.CanonicalHead
+	heldSlots     map[uint64]*slotVerification
+	heldSince     map[uint64]time.Time
+
+	alertSinks []conIface.AlertSink
+
+	proposerProvider iface.ProposerProvider
+	summaries        epochSummaries
+	leadership       ha.Leadership
+
+	seen            *seenEvents
+	progress        verificationProgress
+	metrics         *serviceMetrics
+	reverifying     uint32
+	verificationLag uint64 // number of slots verification is behind vanguard head, accessed atomically
 }
 
 //
@@ -50,6 +129,26 @@ func New(ctx context.Context, cfg *Config) (service *Service) {
 	ctx, cancel := context.WithCancel(ctx)
 	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
 
+	pendingTimeout := cfg.PendingTimeout
+	if pendingTimeout == 0 {
+		pendingTimeout = defaultPendingTimeout
+	}
+
+	orphanMaxAge := cfg.OrphanMaxAge
+	if orphanMaxAge == 0 {
+		orphanMaxAge = defaultOrphanMaxAge
+	}
+
+	verificationWorkers := cfg.VerificationWorkers
+	if verificationWorkers < 1 {
+		verificationWorkers = defaultVerificationWorkers
+	}
+
+	verificationBatchSize := cfg.VerificationBatchSize
+	if verificationBatchSize < 1 {
+		verificationBatchSize = defaultVerificationBatchSize
+	}
+
 	return &Service{
 		ctx:                          ctx,
 		cancel:                       cancel,
@@ -59,6 +158,23 @@ func New(ctx context.Context, cfg *Config) (service *Service) {
 		pandoraPendingHeaderCache:    cfg.PandoraPendingHeaderCache,
 		vanguardService:              cfg.VanguardShardFeed,
 		pandoraService:               cfg.PandoraHeaderFeed,
+		pendingQueue:                 make(map[uint64]*pendingEntry),
+		pendingTimeout:               pendingTimeout,
+		orphanMaxAge:                 orphanMaxAge,
+		verificationWorkers:          verificationWorkers,
+		verificationBatchSize:        verificationBatchSize,
+		confirmationDepth:            cfg.ConfirmationDepth,
+		divergenceWarnSlots:          cfg.DivergenceWarnSlots,
+		divergenceAlertSlots:         cfg.DivergenceAlertSlots,
+		clockSkewThreshold:           cfg.ClockSkewThreshold,
+		heldSlots:                    make(map[uint64]*slotVerification),
+		heldSince:                    make(map[uint64]time.Time),
+		seen:                         newSeenEvents(seenEventsSize),
+		metrics:                      newServiceMetrics(cfg.Network),
+		alertSinks:                   cfg.AlertSinks,
+		proposerProvider:             cfg.ProposerProvider,
+		leadership:                   cfg.Leadership,
+		verificationResultFeed:       feed.New(feed.NetworkName(cfg.Network, "consensus/verificationresult"), (*types.VerificationResult)(nil), eventbuffer.DefaultConfig),
 	}
 }
 
@@ -68,66 +184,69 @@ func (s *Service) Start() {
 		return
 	}
 	s.isRunning = true
+	go s.runVerdictPusher()
 	go func() {
+		defer shared.RecoverService(s)
 		log.Info("Starting consensus service")
-		vanShardInfoCh := make(chan *types.VanguardShardInfo, 1)
+		vanShardInfoCh := make(chan *types.VanguardShardInfo, s.verificationBatchSize)
 		reorgSignalCh := make(chan *types.Reorg, 1)
-		panHeaderInfoCh := make(chan *types.PandoraHeaderInfo, 1)
+		panHeaderInfoCh := make(chan *types.PandoraHeaderInfo, s.verificationBatchSize)
+		canonicalHeadCh := make(chan *types.CanonicalHead, 1)
 
 		vanShardInfoSub := s.vanguardService.SubscribeShardInfoEvent(vanShardInfoCh)
 		vanShutdownSub := s.vanguardService.SubscribeShutdownSignalEvent(reorgSignalCh)
 		panHeaderInfoSub := s.pandoraService.SubscribeHeaderInfoEvent(panHeaderInfoCh)
+		canonicalHeadSub := s.vanguardService.SubscribeCanonicalHeadEvent(canonicalHeadCh)
+
+		pendingTicker := time.NewTicker(s.pendingTimeout / 2)
+		defer pendingTicker.Stop()
+		rateTicker := time.NewTicker(verificationRatePeriod)
+		defer rateTicker.Stop()
+		// lag behind wall clock slot grows while no slots are verified, slots are not ticked when genesis is unknown
+		var slotTicks <-chan uint64
+		if slotTicker, ok := slotutil.NewSlotTicker(); ok {
+			defer slotTicker.Done()
+			slotTicks = slotTicker.C()
+		}
+
+		if err := s.resumeFromCheckpoint(); err != nil {
+			log.WithError(err).Warn("Failed to resume verification from checkpoint, continuing with live events")
+		}
+		if err := s.restoreQueuedShardInfos(); err != nil {
+			log.WithError(err).Warn("Failed to restore queued vanguard shard infos")
+		}
 
 		for {
 			select {
-			case newPanHeaderInfo := <-panHeaderInfoCh:
-
+			case now := <-rateTicker.C:
+				s.updateVerificationRate(now)
+			case <-slotTicks:
+				s.updateGauges()
+			case <-pendingTicker.C:
 				if s.reorgInProgress {
-					log.WithField("slot", newPanHeaderInfo.Slot).Info("Reorg is progressing, so skipping new pandora header")
 					continue
 				}
-
-				if slotInfo, _ := s.verifiedSlotInfoDB.VerifiedSlotInfo(newPanHeaderInfo.Slot); slotInfo != nil {
-					if slotInfo.PandoraHeaderHash == newPanHeaderInfo.Header.Hash() {
-						log.WithField("slot", newPanHeaderInfo.Slot).
-							WithField("headerHash", newPanHeaderInfo.Header.Hash()).
-							Info("Pandora header is already in verified slot info db")
-
-						s.verifiedSlotInfoFeed.Send(&types.SlotInfoWithStatus{
-							VanguardBlockHash: slotInfo.VanguardBlockHash,
-							PandoraHeaderHash: slotInfo.PandoraHeaderHash,
-							Status:            types.Verified,
-						})
-
-						continue
-					}
-				}
-
-				if err := s.processPandoraHeader(newPanHeaderInfo); err != nil {
-					log.WithField("error", err).Error("error found while processing pandora header")
-					return
-				}
+				s.processExpiredPending()
+				s.evictOrphans(time.Now())
+				s.verifyBatch()
+			case newPanHeaderInfo := <-panHeaderInfoCh:
+				s.handlePandoraHeaderInfo(newPanHeaderInfo)
+				s.drainAndVerify(panHeaderInfoCh, vanShardInfoCh)
 			case newVanShardInfo := <-vanShardInfoCh:
-
+				s.handleVanguardShardInfo(newVanShardInfo)
+				s.drainAndVerify(panHeaderInfoCh, vanShardInfoCh)
+			case canonicalHead := <-canonicalHeadCh:
+				if canonicalHead != nil {
+					s.observeHeadTiming(canonicalHead.Slot, time.Now())
+				}
 				if s.reorgInProgress {
-					log.WithField("slot", newVanShardInfo.Slot).Info("Reorg is progressing, so skipping new vanguard shard")
 					continue
 				}
-
-				if slotInfo, _ := s.verifiedSlotInfoDB.VerifiedSlotInfo(newVanShardInfo.Slot); slotInfo != nil {
-					blockHashHex := common.BytesToHash(newVanShardInfo.BlockHash[:])
-					if slotInfo.VanguardBlockHash == blockHashHex {
-						log.WithField("slot", newVanShardInfo.Slot).
-							WithField("shardInfoHash", hexutil.Encode(newVanShardInfo.ShardInfo.Hash)).
-							Info("Vanguard shard info is already in verified slot info db")
-
-						continue
-					}
-				}
-
-				if err := s.processVanguardShardInfo(newVanShardInfo); err != nil {
-					log.WithField("error", err).Error("error found while processing vanguard sharding info")
-					return
+				// matched slots must be checked against the new head as well
+				s.verifyBatch()
+				if err := s.onCanonicalHead(canonicalHead); err != nil {
+					log.WithField("error", err).Error("error found while committing canonical slots")
+					s.runError = err
 				}
 			case reorgInfo := <-reorgSignalCh:
 				if reorgInfo == nil {
@@ -141,13 +260,20 @@ func (s *Service) Start() {
 				log.WithField("curSlot", reorgInfo.NewSlot).WithField("revertSlot", finalizedSlot).
 					WithField("finalizedEpoch", finalizedEpoch).Warn("Triggered reorg event")
 
-				if err := s.reorgDB(finalizedSlot); err != nil {
+				if err := s.reorgDB(finalizedSlot, reorgInfo.NewSlot); err != nil {
 					log.WithError(err).Warn("Failed to revert verified info db, exiting consensus go routine")
 					return
 				}
 				// Removing slot infos from vanguard cache and pandora cache
 				s.vanguardPendingShardingCache.Purge()
+				s.seen.purge()
 				s.pandoraPendingHeaderCache.Purge()
+				s.resetPending()
+				s.verificationBatch = nil
+				s.resetHeld()
+				s.lastVanguardSlot = 0
+				s.lastPandoraSlot = 0
+				s.dropUnconfirmed(finalizedSlot)
 				log.Debug("Starting subscription for vanguard and pandora")
 
 				// disconnect subscription
@@ -160,6 +286,7 @@ func (s *Service) Start() {
 				vanShardInfoSub.Unsubscribe()
 				vanShutdownSub.Unsubscribe()
 				panHeaderInfoSub.Unsubscribe()
+				canonicalHeadSub.Unsubscribe()
 				log.Info("Received cancelled context,closing existing consensus service")
 				return
 			}
@@ -167,10 +294,112 @@ func (s *Service) Start() {
 	}()
 }
 
+// drainAndVerify takes the already queued pandora headers and vanguard shard infos without blocking,
+// so that slots which arrive in bursts (e.g. catch-up after downtime) are verified together by the worker pool.
+func (s *Service) drainAndVerify(
+	panHeaderInfoCh <-chan *types.PandoraHeaderInfo,
+	vanShardInfoCh <-chan *types.VanguardShardInfo,
+) {
+	for i := 0; i < s.verificationBatchSize; i++ {
+		select {
+		case newPanHeaderInfo := <-panHeaderInfoCh:
+			s.handlePandoraHeaderInfo(newPanHeaderInfo)
+			continue
+		case newVanShardInfo := <-vanShardInfoCh:
+			s.handleVanguardShardInfo(newVanShardInfo)
+			continue
+		default:
+		}
+		break
+	}
+	s.verifyBatch()
+}
+
+// verifyBatch verifies the matched slots and keeps the error for service status
+func (s *Service) verifyBatch() {
+	if err := s.flushVerifications(); err != nil {
+		log.WithField("error", err).Error("error found while verifying sharding info")
+		s.runError = err
+	}
+	s.updateGauges()
+}
+
+// handlePandoraHeaderInfo processes a new pandora header unless it has already been verified
+func (s *Service) handlePandoraHeaderInfo(newPanHeaderInfo *types.PandoraHeaderInfo) {
+	if s.reorgInProgress {
+		log.WithField("slot", newPanHeaderInfo.Slot).Info("Reorg is progressing, so skipping new pandora header")
+		return
+	}
+
+	if s.pandoraRedelivered(newPanHeaderInfo) {
+		log.WithField("slot", newPanHeaderInfo.Slot).Debug("Dropping redelivered pandora header")
+		s.metrics.duplicateEvents.Inc(1)
+		return
+	}
+
+	if slotInfo, _ := s.verifiedSlotInfoDB.VerifiedSlotInfo(newPanHeaderInfo.Slot); slotInfo != nil {
+		if slotInfo.PandoraHeaderHash == newPanHeaderInfo.Header.Hash() {
+			log.WithField("slot", newPanHeaderInfo.Slot).
+				WithField("headerHash", newPanHeaderInfo.Header.Hash()).
+				Info("Pandora header is already in verified slot info db")
+
+			s.verificationResultFeed.Send(&types.VerificationResult{
+				Slot:              newPanHeaderInfo.Slot,
+				VanguardBlockHash: slotInfo.VanguardBlockHash,
+				PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+				Status:            types.Verified,
+			})
+			return
+		}
+		s.alertEquivocation(newPanHeaderInfo.Slot, "pandora", slotInfo.PandoraHeaderHash, newPanHeaderInfo.Header.Hash())
+	}
+
+	if err := s.processPandoraHeader(newPanHeaderInfo); err != nil {
+		log.WithField("error", err).Error("error found while processing pandora header")
+		s.runError = err
+		return
+	}
+	s.runError = nil
+}
+
+// handleVanguardShardInfo processes a new vanguard shard info unless it has already been verified
+func (s *Service) handleVanguardShardInfo(newVanShardInfo *types.VanguardShardInfo) {
+	if s.reorgInProgress {
+		log.WithField("slot", newVanShardInfo.Slot).Info("Reorg is progressing, so skipping new vanguard shard")
+		return
+	}
+
+	if s.vanguardRedelivered(newVanShardInfo) {
+		log.WithField("slot", newVanShardInfo.Slot).Debug("Dropping redelivered vanguard shard info")
+		s.metrics.duplicateEvents.Inc(1)
+		return
+	}
+
+	if slotInfo, _ := s.verifiedSlotInfoDB.VerifiedSlotInfo(newVanShardInfo.Slot); slotInfo != nil {
+		blockHashHex := common.BytesToHash(newVanShardInfo.BlockHash[:])
+		if slotInfo.VanguardBlockHash == blockHashHex {
+			log.WithField("slot", newVanShardInfo.Slot).
+				WithField("shardInfoHash", hexutil.Encode(newVanShardInfo.ShardInfo.Hash)).
+				Info("Vanguard shard info is already in verified slot info db")
+			s.dequeueShardInfo(newVanShardInfo.Slot)
+			return
+		}
+		s.alertEquivocation(newVanShardInfo.Slot, "vanguard", slotInfo.VanguardBlockHash, blockHashHex)
+	}
+
+	if err := s.processVanguardShardInfo(newVanShardInfo); err != nil {
+		log.WithField("error", err).Error("error found while processing vanguard sharding info")
+		s.runError = err
+		return
+	}
+	s.runError = nil
+}
+
 func (s *Service) Stop() error {
 	if s.cancel != nil {
 		defer s.cancel()
 	}
+	s.verificationResultFeed.Close()
 	return nil
 }
 
@@ -186,6 +415,6 @@ func (s *Service) Status() error {
 	return nil
 }
 
-func (s *Service) SubscribeVerifiedSlotInfoEvent(ch chan<- *types.SlotInfoWithStatus) event.Subscription {
-	return s.scope.Track(s.verifiedSlotInfoFeed.Subscribe(ch))
+func (s *Service) SubscribeVerificationResultEvent(ch chan<- *types.VerificationResult) event.Subscription {
+	return s.verificationResultFeed.Subscribe(ch)
 }
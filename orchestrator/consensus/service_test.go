@@ -6,6 +6,7 @@ import (
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 	logTest "github.com/sirupsen/logrus/hooks/test"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -73,3 +74,90 @@ func TestService(t *testing.T) {
 		})
 	}
 }
+
+// TestService_PushVerdicts checks that verification verdicts are pushed to pandora service
+func TestService_PushVerdicts(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 3)
+	svc, mockedFeed := setup(ctx, t)
+	defer svc.Stop()
+	svc.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	for i := 0; i < len(headerInfos); i++ {
+		mockedFeed.shardInfoFeed.Send(shardInfos[i])
+		mockedFeed.headerInfoFeed.Send(headerInfos[i])
+
+		select {
+		case blockStatus := <-mockedFeed.confirmedBlocks:
+			assert.Equal(t, headerInfos[i].Header.Hash(), blockStatus.Hash)
+			assert.Equal(t, types.Verified, blockStatus.Status)
+		case <-time.After(time.Second):
+			t.Fatalf("verdict for slot %d is not pushed to pandora", headerInfos[i].Slot)
+		}
+	}
+}
+
+type testLeadership struct {
+	leader int32
+}
+
+func (l *testLeadership) IsLeader() bool {
+	return atomic.LoadInt32(&l.leader) == 1
+}
+
+func (l *testLeadership) Term() <-chan struct{} {
+	return nil
+}
+
+// TestService_HoldVerdicts checks that verdicts are pushed to pandora only while the orchestrator leads
+func TestService_HoldVerdicts(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 3)
+	svc, mockedFeed := setup(ctx, t)
+	leadership := &testLeadership{}
+	svc.leadership = leadership
+	defer svc.Stop()
+	svc.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	mockedFeed.shardInfoFeed.Send(shardInfos[0])
+	mockedFeed.headerInfoFeed.Send(headerInfos[0])
+	select {
+	case blockStatus := <-mockedFeed.confirmedBlocks:
+		t.Fatalf("verdict of header %s is pushed by a standby", blockStatus.Hash.Hex())
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	atomic.StoreInt32(&leadership.leader, 1)
+	mockedFeed.shardInfoFeed.Send(shardInfos[1])
+	mockedFeed.headerInfoFeed.Send(headerInfos[1])
+	select {
+	case blockStatus := <-mockedFeed.confirmedBlocks:
+		assert.Equal(t, headerInfos[1].Header.Hash(), blockStatus.Hash)
+	case <-time.After(time.Second):
+		t.Fatal("verdict is not pushed once the orchestrator leads")
+	}
+}
+
+// TestService_SlotStatus checks that pending and verified statuses are persisted for each slot
+func TestService_SlotStatus(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 2)
+	svc, mockedFeed := setup(ctx, t)
+	defer svc.Stop()
+	svc.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	mockedFeed.shardInfoFeed.Send(shardInfos[0])
+	time.Sleep(100 * time.Millisecond)
+	status, err := svc.verifiedSlotInfoDB.SlotStatus(shardInfos[0].Slot)
+	require.NoError(t, err)
+	assert.Equal(t, types.Pending, status)
+
+	mockedFeed.headerInfoFeed.Send(headerInfos[0])
+	time.Sleep(100 * time.Millisecond)
+	status, err = svc.verifiedSlotInfoDB.SlotStatus(shardInfos[0].Slot)
+	require.NoError(t, err)
+	assert.Equal(t, types.Verified, status)
+}
@@ -0,0 +1,48 @@
+package consensus
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// queueShardInfo persists a vanguard shard info which has been fetched by the consensus service itself until its slot
+// gets a verdict or is evicted. Streamed shard infos are queued by the vanguard service when they are received.
+func (s *Service) queueShardInfo(vanShardInfo *types.VanguardShardInfo) {
+	if err := s.verifiedSlotInfoDB.SavePendingShardInfo(vanShardInfo); err != nil {
+		log.WithError(err).WithField("slot", vanShardInfo.Slot).Warn("Failed to queue vanguard shard info")
+	}
+}
+
+// dequeueShardInfo removes the persisted vanguard shard info of a slot which has left the pending cache
+func (s *Service) dequeueShardInfo(slot uint64) {
+	if err := s.verifiedSlotInfoDB.RemovePendingShardInfo(slot); err != nil {
+		log.WithError(err).WithField("slot", slot).Warn("Failed to remove queued vanguard shard info")
+	}
+}
+
+// restoreQueuedShardInfos processes the vanguard shard infos which had been received but not verified before the
+// last shutdown. Shard infos of slots which have been resolved meanwhile, e.g. by resuming from the checkpoint, are
+// removed from the queue.
+func (s *Service) restoreQueuedShardInfos() error {
+	shardInfos, err := s.verifiedSlotInfoDB.PendingShardInfos()
+	if err != nil {
+		return errors.Wrap(err, "could not load queued vanguard shard infos")
+	}
+	restored := 0
+	for _, shardInfo := range shardInfos {
+		status, err := s.verifiedSlotInfoDB.SlotStatus(shardInfo.Slot)
+		if err != nil {
+			return errors.Wrapf(err, "could not load status of slot %d", shardInfo.Slot)
+		}
+		if status != types.Pending && status != types.Unknown {
+			s.dequeueShardInfo(shardInfo.Slot)
+			continue
+		}
+		s.handleVanguardShardInfo(shardInfo)
+		restored++
+	}
+	if restored > 0 {
+		log.WithField("restoredSlots", restored).Info("Restored queued vanguard shard infos")
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// TestService_RestoreQueuedShardInfos checks that vanguard shard infos which have not been verified before a restart
+// are pending again after it
+func TestService_RestoreQueuedShardInfos(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 4)
+	svc, mfs := setup(ctx, t)
+
+	for _, shardInfo := range shardInfos {
+		// vanguard service queues shard infos when they are received
+		require.NoError(t, svc.verifiedSlotInfoDB.SavePendingShardInfo(shardInfo))
+		require.NoError(t, svc.processVanguardShardInfo(shardInfo))
+	}
+	// slot 1 is verified and slot 2 has been rejected meanwhile, only slot 3 is still unmatched
+	require.NoError(t, svc.processPandoraHeader(headerInfos[0]))
+	require.NoError(t, svc.flushVerifications())
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveSlotStatus(2, types.Invalid))
+
+	queued, err := svc.verifiedSlotInfoDB.PendingShardInfos()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(queued))
+	assert.Equal(t, uint64(2), queued[0].Slot)
+	assert.Equal(t, uint64(3), queued[1].Slot)
+
+	restarted := New(ctx, &Config{
+		VerifiedSlotInfoDB:           svc.verifiedSlotInfoDB,
+		InvalidSlotInfoDB:            svc.invalidSlotInfoDB,
+		VanguardPendingShardingCache: cache.NewVanShardInfoCache(1024),
+		PandoraPendingHeaderCache:    cache.NewPanHeaderCache(),
+		VanguardShardFeed:            mfs,
+		PandoraHeaderFeed:            mfs,
+	})
+	require.NoError(t, restarted.restoreQueuedShardInfos())
+
+	restored, _ := restarted.vanguardPendingShardingCache.Get(ctx, 3)
+	require.NotNil(t, restored)
+	assert.DeepEqual(t, shardInfos[2].ShardInfo.Hash, restored.ShardInfo.Hash)
+	_, pending := restarted.pendingQueue[3]
+	assert.Equal(t, true, pending)
+	rejected, _ := restarted.vanguardPendingShardingCache.Get(ctx, 2)
+	assert.Equal(t, true, rejected == nil)
+
+	queued, err = restarted.verifiedSlotInfoDB.PendingShardInfos()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(queued))
+	assert.Equal(t, uint64(3), queued[0].Slot)
+
+	// matching the restored slot verifies it and empties the queue
+	require.NoError(t, restarted.processPandoraHeader(headerInfos[2]))
+	require.NoError(t, restarted.flushVerifications())
+	status, err := restarted.verifiedSlotInfoDB.SlotStatus(3)
+	require.NoError(t, err)
+	assert.Equal(t, types.Verified, status)
+	queued, err = restarted.verifiedSlotInfoDB.PendingShardInfos()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(queued))
+}
+
+// TestService_DequeueShardInfos checks that queued vanguard shard infos are removed once their slots are verified
+// or rejected
+func TestService_DequeueShardInfos(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 4)
+	svc, _ := setup(ctx, t)
+
+	// slot 2 gets a vanguard block which points to the pandora header of slot 3
+	shardInfos[1] = testutil.NewVanguardShardInfo(2, headerInfos[2].Header)
+	for _, shardInfo := range shardInfos[:2] {
+		require.NoError(t, svc.verifiedSlotInfoDB.SavePendingShardInfo(shardInfo))
+		require.NoError(t, svc.processVanguardShardInfo(shardInfo))
+	}
+	queued, err := svc.verifiedSlotInfoDB.PendingShardInfos()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(queued))
+
+	for _, headerInfo := range headerInfos[:2] {
+		require.NoError(t, svc.processPandoraHeader(headerInfo))
+	}
+	require.NoError(t, svc.flushVerifications())
+
+	status, err := svc.verifiedSlotInfoDB.SlotStatus(1)
+	require.NoError(t, err)
+	assert.Equal(t, types.Verified, status)
+	status, err = svc.verifiedSlotInfoDB.SlotStatus(2)
+	require.NoError(t, err)
+	assert.Equal(t, types.Invalid, status)
+
+	queued, err = svc.verifiedSlotInfoDB.PendingShardInfos()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(queued))
+}
@@ -0,0 +1,55 @@
+package consensus
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// markSkipped records a slot without vanguard block (missed proposal) as skipped, so that it does not linger
+// as pending. Slots which already have a verdict are not touched.
+func (s *Service) markSkipped(slot uint64) error {
+	status, err := s.verifiedSlotInfoDB.SlotStatus(slot)
+	if err != nil {
+		return err
+	}
+	if status != types.Unknown && status != types.Pending {
+		return nil
+	}
+	if err := s.verifiedSlotInfoDB.SaveSlotStatus(slot, types.Skipped); err != nil {
+		log.WithField("slot", slot).WithError(err).Error("Failed to store skipped slot status")
+		return err
+	}
+	s.removePending(slot)
+	s.pandoraPendingHeaderCache.Remove(s.ctx, slot)
+	s.dequeueShardInfo(slot)
+	s.countSkipped()
+	log.WithField("slot", slot).Debug("Vanguard has no block in slot, slot is skipped")
+	return nil
+}
+
+// skipGap marks the slots between the previous vanguard block and the new one as skipped. Vanguard blocks
+// are streamed in slot order, so there is no block in these slots.
+func (s *Service) skipGap(slot uint64) {
+	if s.lastVanguardSlot != 0 && slot > s.lastVanguardSlot+1 {
+		for gapSlot := s.lastVanguardSlot + 1; gapSlot < slot; gapSlot++ {
+			if err := s.markSkipped(gapSlot); err != nil {
+				log.WithError(err).WithField("slot", gapSlot).Warn("Failed to mark slot as skipped")
+			}
+		}
+	}
+	if slot > s.lastVanguardSlot {
+		s.lastVanguardSlot = slot
+	}
+}
+
+// vanguardSkipped checks that vanguard head has passed the slot without a canonical block in it
+func (s *Service) vanguardSkipped(slot uint64) bool {
+	if vanShardInfo, _ := s.vanguardPendingShardingCache.Get(s.ctx, slot); vanShardInfo != nil {
+		return false
+	}
+	headSlot, err := s.vanguardService.HeadSlot()
+	if err != nil || slot > headSlot {
+		return false
+	}
+	shardInfo, err := s.vanguardService.ShardInfoBySlot(slot)
+	return err == nil && shardInfo == nil
+}
@@ -0,0 +1,53 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// TestService_SkipGap checks that slots between two vanguard blocks are recorded as skipped
+func TestService_SkipGap(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 5)
+	svc, _ := setup(ctx, t)
+
+	// orphaned pandora header of slot 3 is pending
+	require.NoError(t, svc.processPandoraHeader(headerInfos[2]))
+	require.NoError(t, svc.processVanguardShardInfo(shardInfos[0]))
+	require.NoError(t, svc.processVanguardShardInfo(shardInfos[3]))
+
+	for _, slot := range []uint64{2, 3} {
+		status, err := svc.verifiedSlotInfoDB.SlotStatus(slot)
+		require.NoError(t, err)
+		assert.Equal(t, types.Skipped, status)
+	}
+	progress := svc.VerificationProgress()
+	assert.Equal(t, uint64(2), progress.SkippedSlots)
+	// slot 1 and 4 are waiting for pandora headers
+	assert.Equal(t, uint64(2), progress.PendingSlots)
+}
+
+// TestService_SkippedPendingSlot checks that pending slot without vanguard block is not counted as unmatched
+func TestService_SkippedPendingSlot(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, _ := getHeaderInfosAndShardInfos(1, 5)
+	svc, mockedFeed := setup(ctx, t)
+	svc.pendingTimeout = time.Millisecond
+	mockedFeed.headSlot = 10
+
+	require.NoError(t, svc.processPandoraHeader(headerInfos[1]))
+	time.Sleep(10 * time.Millisecond)
+	svc.processExpiredPending()
+
+	status, err := svc.verifiedSlotInfoDB.SlotStatus(2)
+	require.NoError(t, err)
+	assert.Equal(t, types.Skipped, status)
+	progress := svc.VerificationProgress()
+	assert.Equal(t, uint64(0), progress.UnmatchedSlots)
+	assert.Equal(t, uint64(0), progress.PendingSlots)
+}
@@ -4,22 +4,41 @@ import (
 	"context"
 	"testing"
 
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/feed"
+	"github.com/lukso-network/lukso-orchestrator/shared/slotutil"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
 )
 
 type mockFeedService struct {
-	headerInfoFeed           event.Feed
-	shardInfoFeed            event.Feed
-	subscriptionShutdownFeed event.Feed
-	scope                    event.SubscriptionScope
+	headerInfoFeed           feed.Feed
+	shardInfoFeed            feed.Feed
+	subscriptionShutdownFeed feed.Feed
+	canonicalHeadFeed        feed.Feed
+	confirmedBlocks          chan *types.BlockStatus
+
+	// headers and shard infos which can be queried actively
+	headers    map[uint64]*eth1Types.Header
+	shardInfos map[uint64]*types.VanguardShardInfo
+	// number of HeadersByRange requests
+	headerRangeRequests int
+
+	// heads of both chains
+	headSlot          uint64
+	latestBlockNumber uint64
 }
 
 func (mc *mockFeedService) SubscribeShutdownSignalEvent(signals chan<- *types.Reorg) event.Subscription {
-	return mc.scope.Track(mc.subscriptionShutdownFeed.Subscribe(signals))
+	return mc.subscriptionShutdownFeed.Subscribe(signals)
+}
+
+func (mc *mockFeedService) SubscribeCanonicalHeadEvent(ch chan<- *types.CanonicalHead) event.Subscription {
+	return mc.canonicalHeadFeed.Subscribe(ch)
 }
 
 func (mc *mockFeedService) ReSubscribeBlocksEvent() error {
@@ -30,6 +49,20 @@ func (mc *mockFeedService) StopSubscription() {
 	panic("implement me")
 }
 
+func (mc *mockFeedService) ShardInfoBySlot(slot uint64) (*types.VanguardShardInfo, error) {
+	return mc.shardInfos[slot], nil
+}
+
+func (mc *mockFeedService) ShardInfosByEpoch(epoch uint64) ([]*types.VanguardShardInfo, error) {
+	shardInfos := make([]*types.VanguardShardInfo, 0)
+	for slot := slotutil.EpochStartSlot(epoch); slot <= slotutil.EpochEndSlot(epoch); slot++ {
+		if shardInfo, exists := mc.shardInfos[slot]; exists {
+			shardInfos = append(shardInfos, shardInfo)
+		}
+	}
+	return shardInfos, nil
+}
+
 func (mc *mockFeedService) StopPandoraSubscription() {
 	panic("implement StopPandoraSubscription")
 }
@@ -38,17 +71,47 @@ func (mc *mockFeedService) ResumePandoraSubscription() error {
 	panic("implement ResumePandoraSubscription")
 }
 
+func (mc *mockFeedService) HeadersByRange(from, to uint64) ([]*eth1Types.Header, error) {
+	mc.headerRangeRequests++
+	headers := make([]*eth1Types.Header, 0)
+	for blockNumber := from; blockNumber <= to; blockNumber++ {
+		header, exists := mc.headers[blockNumber]
+		if !exists {
+			return nil, errors.Errorf("header %d not found", blockNumber)
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
+
+func (mc *mockFeedService) HeadSlot() (uint64, error) {
+	return mc.headSlot, nil
+}
+
+func (mc *mockFeedService) LatestBlockNumber() (uint64, error) {
+	return mc.latestBlockNumber, nil
+}
+
+func (mc *mockFeedService) ConfirmBlock(blockStatus *types.BlockStatus) error {
+	mc.confirmedBlocks <- blockStatus
+	return nil
+}
+
 func (mc *mockFeedService) SubscribeHeaderInfoEvent(ch chan<- *types.PandoraHeaderInfo) event.Subscription {
-	return mc.scope.Track(mc.headerInfoFeed.Subscribe(ch))
+	return mc.headerInfoFeed.Subscribe(ch)
 }
 
 func (mc *mockFeedService) SubscribeShardInfoEvent(ch chan<- *types.VanguardShardInfo) event.Subscription {
-	return mc.scope.Track(mc.shardInfoFeed.Subscribe(ch))
+	return mc.shardInfoFeed.Subscribe(ch)
 }
 
 func setup(ctx context.Context, t *testing.T) (*Service, *mockFeedService) {
 	testDB := testDB.SetupDB(t)
-	mfs := new(mockFeedService)
+	mfs := &mockFeedService{
+		confirmedBlocks: make(chan *types.BlockStatus, 100),
+		headers:         make(map[uint64]*eth1Types.Header),
+		shardInfos:      make(map[uint64]*types.VanguardShardInfo),
+	}
 
 	cfg := &Config{
 		VerifiedSlotInfoDB:           testDB,
@@ -0,0 +1,141 @@
+package consensus
+
+import (
+	"sort"
+	"sync"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/tracing"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+const (
+	// default maximum number of matched slots which are verified together in a single batch
+	defaultVerificationBatchSize = 256
+	// default number of workers which verify matched slots concurrently
+	defaultVerificationWorkers = 1
+)
+
+// reasons of rejecting a matched slot which are stored in mismatch evidence
+const (
+	reasonExtraDataSlot = "pandora extra data slot mismatch"
+	reasonShardingInfo  = "sharding info mismatch"
+)
+
+// slotVerification holds both sides of a matched slot and the verification verdict
+type slotVerification struct {
+	slot      uint64
+	shardInfo *types.VanguardShardInfo
+	header    *eth1Types.Header
+	valid     bool
+	reason    string
+}
+
+// verify runs all the verification checks of a matched slot and keeps the verdict with the reason of rejection
+func (v *slotVerification) verify() {
+	switch {
+	case !verifyExtraDataSlot(v.slot, v.header):
+		v.reason = reasonExtraDataSlot
+	case !CompareShardingInfo(v.header, v.shardInfo.ShardInfo):
+		v.reason = reasonShardingInfo
+	default:
+		v.valid = true
+	}
+	v.shardInfo.Trace.Stage("verified", tracing.Bool("valid", v.valid))
+}
+
+// enqueueVerification puts a matched slot into the verification batch. The batch is verified
+// by the worker pool when flushVerifications is called.
+func (s *Service) enqueueVerification(slot uint64, vanShardInfo *types.VanguardShardInfo, header *eth1Types.Header) {
+	vanShardInfo.Trace.Stage("matched")
+	s.verificationBatch = append(s.verificationBatch, &slotVerification{
+		slot:      slot,
+		shardInfo: vanShardInfo,
+		header:    header,
+	})
+}
+
+// flushVerifications verifies all the enqueued slots concurrently and commits the results in slot order.
+func (s *Service) flushVerifications() error {
+	if len(s.verificationBatch) == 0 {
+		return nil
+	}
+	batch := sortAndDedupe(s.verificationBatch)
+	s.verificationBatch = nil
+
+	verifyConcurrently(batch, s.verificationWorkers)
+
+	log.WithField("slots", len(batch)).WithField("workers", s.verificationWorkers).
+		Debug("Verified batch of matched slots")
+
+	for _, v := range batch {
+		if v.valid && !s.isCanonical(v) {
+			if err := s.holdNonCanonical(v); err != nil {
+				return err
+			}
+			continue
+		}
+		s.releaseHeld(v.slot)
+		if err := s.commitVerification(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyConcurrently runs verification of the given slots with the given number of workers.
+// Verification of a slot does not depend on any other slot, so slots can be verified in any order.
+func verifyConcurrently(batch []*slotVerification, workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(batch) {
+		workers = len(batch)
+	}
+
+	jobs := make(chan *slotVerification, len(batch))
+	for _, v := range batch {
+		jobs <- v
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	var panicOnce sync.Once
+	var workerPanic interface{}
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if reason := recover(); reason != nil {
+					panicOnce.Do(func() { workerPanic = reason })
+				}
+			}()
+			for v := range jobs {
+				v.verify()
+			}
+		}()
+	}
+	wg.Wait()
+	// panic of a worker is raised again in the calling goroutine, so it is recovered as a crash of the service
+	if workerPanic != nil {
+		panic(workerPanic)
+	}
+}
+
+// sortAndDedupe sorts matched slots in ascending slot order. When a slot has been matched more than once,
+// only the latest match is kept.
+func sortAndDedupe(batch []*slotVerification) []*slotVerification {
+	latest := make(map[uint64]*slotVerification, len(batch))
+	for _, v := range batch {
+		latest[v.slot] = v
+	}
+	sorted := make([]*slotVerification, 0, len(latest))
+	for _, v := range latest {
+		sorted = append(sorted, v)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].slot < sorted[j].slot
+	})
+	return sorted
+}
@@ -0,0 +1,59 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// TestService_FlushVerifications checks that matched slots are verified by the worker pool and
+// committed in slot order
+func TestService_FlushVerifications(t *testing.T) {
+	ctx := context.Background()
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 9)
+	svc, _ := setup(ctx, t)
+	svc.verificationWorkers = 4
+
+	verifiedCh := make(chan *types.VerificationResult, 10)
+	sub := svc.SubscribeVerificationResultEvent(verifiedCh)
+	defer sub.Unsubscribe()
+
+	// slot 4 gets a header which belongs to another slot
+	shardInfos[3] = testutil.NewVanguardShardInfo(4, headerInfos[4].Header)
+
+	// enqueue in reverse order, slot 8 is matched twice
+	for i := len(headerInfos) - 1; i >= 0; i-- {
+		svc.enqueueVerification(headerInfos[i].Slot, shardInfos[i], headerInfos[i].Header)
+	}
+	svc.enqueueVerification(headerInfos[7].Slot, shardInfos[7], headerInfos[7].Header)
+
+	require.NoError(t, svc.flushVerifications())
+	assert.Equal(t, 0, len(svc.verificationBatch))
+	assert.Equal(t, uint64(8), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+
+	for i, headerInfo := range headerInfos {
+		verified := <-verifiedCh
+		assert.Equal(t, headerInfo.Header.Hash(), verified.PandoraHeaderHash)
+
+		status, err := svc.verifiedSlotInfoDB.SlotStatus(headerInfo.Slot)
+		require.NoError(t, err)
+		if i == 3 {
+			assert.Equal(t, types.Invalid, verified.Status)
+			assert.Equal(t, types.Invalid, status)
+			continue
+		}
+		assert.Equal(t, types.Verified, verified.Status)
+		assert.Equal(t, types.Verified, status)
+	}
+	assert.Equal(t, 0, len(verifiedCh))
+
+	evidence, err := svc.invalidSlotInfoDB.MismatchEvidence(4)
+	require.NoError(t, err)
+	require.NotNil(t, evidence)
+	assert.Equal(t, reasonShardingInfo, evidence.Reason)
+	assert.Equal(t, headerInfos[3].Header.Hash(), evidence.PandoraHeader.Hash())
+}
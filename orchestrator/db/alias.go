@@ -14,4 +14,6 @@ type VerifiedSlotInfoDB = iface.VerifiedSlotDatabase
 
 type InvalidSlotInfoDB = iface.InvalidSlotDatabase
 
+type MaintenanceDB = iface.MaintenanceDatabase
+
 type Database = iface.Database
@@ -11,6 +11,8 @@ import (
 type ReadOnlyConsensusInfoDatabase interface {
 	ConsensusInfo(ctx context.Context, epoch uint64) (*types.MinimalEpochConsensusInfo, error)
 	ConsensusInfos(fromEpoch uint64) ([]*types.MinimalEpochConsensusInfo, error)
+	ConsensusInfoRange(fromEpoch, toEpoch uint64, limit int) ([]*types.MinimalEpochConsensusInfo, error)
+	ConsensusInfoRoot(epoch uint64) (common.Hash, error)
 	LatestSavedEpoch() uint64
 }
 
@@ -25,10 +27,22 @@ type ConsensusInfoAccessDatabase interface {
 type ReadOnlyVerifiedSlotInfoDatabase interface {
 	VerifiedSlotInfo(slot uint64) (*types.SlotInfo, error)
 	VerifiedSlotInfos(fromSlot uint64) (map[uint64]*types.SlotInfo, error)
+	PandoraBlockStatuses(hashes []common.Hash) ([]*types.PandoraBlockStatus, error)
 	LatestSavedVerifiedSlot() uint64
 	LatestVerifiedHeaderHash() common.Hash
 	LatestLatestFinalizedSlot() uint64
 	LatestLatestFinalizedEpoch() uint64
+	SlotStatus(slot uint64) (types.Status, error)
+	SlotStatuses(fromSlot, toSlot uint64) (map[uint64]types.Status, error)
+	ReorgAuditRecords() ([]*types.ReorgAuditRecord, error)
+	HeadAt(asOfSlot uint64) (*types.HistoricalHead, error)
+	BlockStatusAt(hash common.Hash, asOfSlot uint64) (*types.HistoricalBlockStatus, error)
+	VerificationCheckpoint() (*types.VerificationCheckpoint, error)
+	OrphanSlot(slot uint64) (*types.OrphanSlot, error)
+	ReplayResults() ([]*types.ReplayResult, error)
+	EpochSummary(epoch uint64) (*types.EpochSummary, error)
+	EpochSummaries(fromEpoch, toEpoch uint64) ([]*types.EpochSummary, error)
+	PendingShardInfos() ([]*types.VanguardShardInfo, error)
 }
 
 type VerifiedSlotDatabase interface {
@@ -41,16 +55,40 @@ type VerifiedSlotDatabase interface {
 	SaveLatestFinalizedEpoch(latestFinalizedEpoch uint64) error
 	RemoveRangeVerifiedInfo(fromSlot, toSlot uint64) error
 	UpdateVerifiedSlotInfo(slot uint64) error
+	SaveSlotStatus(slot uint64, status types.Status) error
+	FinalizeSlotStatuses(fromSlot, toSlot uint64) (int, error)
+	SaveReorgAuditRecord(record *types.ReorgAuditRecord) error
+	SaveFinalizationRecord(slot, finalizedSlot uint64) error
+	SaveVerificationCheckpoint(slot uint64, slotInfo *types.SlotInfo) error
+	SaveVerificationBatch(verdicts []*types.SlotVerdict) error
+	SaveOrphanSlot(orphan *types.OrphanSlot) error
+	SaveReplayResults(results []*types.ReplayResult) error
+	SaveEpochSummary(summary *types.EpochSummary) error
+	SavePendingShardInfo(shardInfo *types.VanguardShardInfo) error
+	RemovePendingShardInfo(slot uint64) error
+	PurgePendingShardInfos() error
 }
 
 type ReadOnlyInvalidSlotInfoDatabase interface {
 	InvalidSlotInfo(slots uint64) (*types.SlotInfo, error)
+	MismatchEvidence(slot uint64) (*types.MismatchEvidence, error)
+	MismatchEvidences(fromSlot, toSlot uint64) ([]*types.MismatchEvidence, error)
 }
 
 type InvalidSlotDatabase interface {
 	ReadOnlyInvalidSlotInfoDatabase
 
 	SaveInvalidSlotInfo(slot uint64, slotInfo *types.SlotInfo) error
+	SaveMismatchEvidence(evidence *types.MismatchEvidence) error
+}
+
+// MaintenanceDatabase defines maintenance operations which operators trigger on a running node.
+type MaintenanceDatabase interface {
+	Backup(ctx context.Context) (*types.BackupResult, error)
+	PruneBefore(slot uint64) (*types.PruneResult, error)
+	ScheduleCompaction() error
+	Inspect() (*types.DatabaseInspection, error)
+	RollbackTo(slot uint64) error
 }
 
 // Database interface with full access.
@@ -63,6 +101,13 @@ type Database interface {
 
 	InvalidSlotDatabase
 
+	MaintenanceDatabase
+
+	// ChainConfig returns the chain parameters stored on first run, nil is returned for brand new db
+	ChainConfig() (*types.ChainConfig, error)
+	SaveChainConfig(config *types.ChainConfig) error
+
 	DatabasePath() string
+	MMapSize() int64
 	ClearDB() error
 }
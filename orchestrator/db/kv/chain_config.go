@@ -0,0 +1,42 @@
+package kv
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// ChainConfig returns the chain parameters which the database has been created with. Nil is returned for
+// brand new db.
+func (s *Store) ChainConfig() (*types.ChainConfig, error) {
+	var config *types.ChainConfig
+	err := s.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(latestInfoMarkerBucket).Get(chainConfigKey)
+		if value == nil {
+			return nil
+		}
+		return decode(value, &config)
+	})
+	return config, err
+}
+
+// SaveChainConfig stores the chain parameters which the database is used with
+func (s *Store) SaveChainConfig(config *types.ChainConfig) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		enc, err := encode(config)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(latestInfoMarkerBucket).Put(chainConfigKey, enc)
+	})
+}
+
+// slotsPerEpoch returns the number of slots in an epoch which the database has been created with, so offline
+// maintenance works without network flags. The number of active network config is used for brand new db.
+func (s *Store) slotsPerEpoch() uint64 {
+	config, err := s.ChainConfig()
+	if err != nil || config == nil || config.SlotsPerEpoch == 0 {
+		return params.ActiveNetworkConfig().SlotsPerEpoch
+	}
+	return config.SlotsPerEpoch
+}
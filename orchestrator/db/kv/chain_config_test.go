@@ -0,0 +1,23 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_ChainConfig(t *testing.T) {
+	db := setupDB(t, true)
+
+	config, err := db.ChainConfig()
+	require.NoError(t, err)
+	assert.Equal(t, (*types.ChainConfig)(nil), config)
+
+	stored := &types.ChainConfig{SlotsPerEpoch: 32, SecondsPerSlot: 6, GenesisTime: 1633082400}
+	require.NoError(t, db.SaveChainConfig(stored))
+	config, err = db.ChainConfig()
+	require.NoError(t, err)
+	assert.DeepEqual(t, stored, config)
+}
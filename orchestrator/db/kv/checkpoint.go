@@ -0,0 +1,66 @@
+package kv
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// SaveVerificationCheckpoint stores verified slot info together with latest verified slot and latest verified
+// header hash markers in a single transaction, so a crash can never leave markers pointing to a missing slot info.
+func (s *Store) SaveVerificationCheckpoint(slot uint64, slotInfo *types.SlotInfo) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		slotBytes := bytesutil.Uint64ToBytesBigEndian(slot)
+		enc, err := encode(slotInfo)
+		if err != nil {
+			return err
+		}
+		if err := tx.Bucket(verifiedSlotInfosBucket).Put(slotBytes, enc); err != nil {
+			return err
+		}
+		if err := putPandoraHashIndex(tx, slot, slotInfo.PandoraHeaderHash); err != nil {
+			return err
+		}
+
+		markerBkt := tx.Bucket(latestInfoMarkerBucket)
+		if err := markerBkt.Put(latestSavedVerifiedSlotKey, slotBytes); err != nil {
+			return err
+		}
+		if err := markerBkt.Put(latestHeaderHashKey, slotInfo.PandoraHeaderHash.Bytes()); err != nil {
+			return err
+		}
+
+		if status := s.verifiedSlotInfoCache.Set(slot, slotInfo, 0); !status {
+			log.WithField("slot", slot).Warn("could not store verified slot info into cache")
+		}
+		return nil
+	})
+}
+
+// VerificationCheckpoint returns latest verified slot with its verified hashes which are read
+// within a single transaction. Zero checkpoint is returned for brand new db.
+func (s *Store) VerificationCheckpoint() (*types.VerificationCheckpoint, error) {
+	checkpoint := &types.VerificationCheckpoint{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		markerBkt := tx.Bucket(latestInfoMarkerBucket)
+		slotBytes := markerBkt.Get(latestSavedVerifiedSlotKey)
+		if slotBytes == nil {
+			return nil
+		}
+		checkpoint.Slot = bytesutil.BytesToUint64BigEndian(slotBytes)
+		checkpoint.PandoraHeaderHash = common.BytesToHash(markerBkt.Get(latestHeaderHashKey))
+
+		value := tx.Bucket(verifiedSlotInfosBucket).Get(slotBytes)
+		if value == nil {
+			return nil
+		}
+		var slotInfo *types.SlotInfo
+		if err := decode(value, &slotInfo); err != nil {
+			return err
+		}
+		checkpoint.VanguardBlockHash = slotInfo.VanguardBlockHash
+		return nil
+	})
+	return checkpoint, err
+}
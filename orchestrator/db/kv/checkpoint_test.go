@@ -0,0 +1,36 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_VerificationCheckpoint(t *testing.T) {
+	db := setupDB(t, true)
+
+	checkpoint, err := db.VerificationCheckpoint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), checkpoint.Slot)
+
+	slotInfo := &types.SlotInfo{
+		PandoraHeaderHash: common.HexToHash("0x01"),
+		VanguardBlockHash: common.HexToHash("0x02"),
+	}
+	require.NoError(t, db.SaveVerificationCheckpoint(10, slotInfo))
+
+	checkpoint, err = db.VerificationCheckpoint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), checkpoint.Slot)
+	assert.Equal(t, slotInfo.PandoraHeaderHash, checkpoint.PandoraHeaderHash)
+	assert.Equal(t, slotInfo.VanguardBlockHash, checkpoint.VanguardBlockHash)
+	assert.Equal(t, uint64(10), db.LatestSavedVerifiedSlot())
+	assert.Equal(t, slotInfo.PandoraHeaderHash, db.LatestVerifiedHeaderHash())
+
+	storedSlotInfo, err := db.VerifiedSlotInfo(10)
+	require.NoError(t, err)
+	assert.DeepEqual(t, slotInfo, storedSlotInfo)
+}
@@ -1,10 +1,12 @@
 package kv
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	"github.com/boltdb/bolt"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
 	eventTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
 	"github.com/pkg/errors"
@@ -71,7 +73,45 @@ func (s *Store) ConsensusInfos(fromEpoch uint64) (
 	return consensusInfos, nil
 }
 
-// SaveConsensusInfo
+// ConsensusInfoRange iterates stored consensus infos in [fromEpoch, toEpoch] range in epoch order and returns
+// at most limit of them. Missing epochs are skipped. Zero limit means no limit.
+func (s *Store) ConsensusInfoRange(fromEpoch, toEpoch uint64, limit int) (
+	[]*eventTypes.MinimalEpochConsensusInfo, error,
+) {
+	consensusInfos := make([]*eventTypes.MinimalEpochConsensusInfo, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(consensusInfosBucket).Cursor()
+		toKey := bytesutil.Uint64ToBytesBigEndian(toEpoch)
+		for k, v := cursor.Seek(bytesutil.Uint64ToBytesBigEndian(fromEpoch)); k != nil && bytes.Compare(k, toKey) <= 0; k, v = cursor.Next() {
+			if limit > 0 && len(consensusInfos) >= limit {
+				return nil
+			}
+			var consensusInfo *eventTypes.MinimalEpochConsensusInfo
+			if err := decode(v, &consensusInfo); err != nil {
+				return err
+			}
+			consensusInfos = append(consensusInfos, consensusInfo)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return consensusInfos, nil
+}
+
+// ConsensusInfoRoot returns ssz hash tree root of the stored consensus info of the epoch, it is empty when the
+// consensus info is not stored or its root could not be computed
+func (s *Store) ConsensusInfoRoot(epoch uint64) (common.Hash, error) {
+	var root common.Hash
+	err := s.db.View(func(tx *bolt.Tx) error {
+		root = common.BytesToHash(tx.Bucket(consensusInfoRootBucket).Get(bytesutil.Uint64ToBytesBigEndian(epoch)))
+		return nil
+	})
+	return root, err
+}
+
+// SaveConsensusInfo stores the consensus info along with its ssz hash tree root
 func (s *Store) SaveConsensusInfo(
 	ctx context.Context,
 	consensusInfo *eventTypes.MinimalEpochConsensusInfo,
@@ -93,8 +133,13 @@ func (s *Store) SaveConsensusInfo(
 		if err := bkt.Put(epochBytes, enc); err != nil {
 			return err
 		}
-		// update latest epoch
-		return nil
+		rootBkt := tx.Bucket(consensusInfoRootBucket)
+		root, err := consensusInfo.HashTreeRoot()
+		if err != nil {
+			log.WithError(err).WithField("epoch", consensusInfo.Epoch).Warn("Could not compute root of consensus info")
+			return rootBkt.Delete(epochBytes)
+		}
+		return rootBkt.Put(epochBytes, root[:])
 	})
 }
 
@@ -104,12 +149,16 @@ func (s *Store) RemoveRangeConsensusInfo(startEpoch, endEpoch uint64) error {
 
 	return s.db.Update(func(tx *bolt.Tx) error {
 		bkt := tx.Bucket(consensusInfosBucket)
+		rootBkt := tx.Bucket(consensusInfoRootBucket)
 		for i := startEpoch; i <= endEpoch; i++ {
 			s.consensusInfoCache.Del(i)
 			epochBytes := bytesutil.Uint64ToBytesBigEndian(i)
 			if err := bkt.Delete(epochBytes); err != nil {
 				return err
 			}
+			if err := rootBkt.Delete(epochBytes); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -2,6 +2,7 @@ package kv
 
 import (
 	"context"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
@@ -110,3 +111,55 @@ func TestStore_LatestEpoch_ClosingDB_OpeningDB(t *testing.T) {
 
 	require.NoError(t, db.Close())
 }
+
+func TestStore_ConsensusInfoRange(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := setupDB(t, true)
+	totalConsensusInfos := make([]*eventTypes.MinimalEpochConsensusInfo, 20)
+	for i := 0; i < 20; i++ {
+		// epoch 7 is missing in db
+		if i == 7 {
+			continue
+		}
+		consensusInfo := testutil.NewMinimalConsensusInfo(uint64(i))
+		epochInfo := consensusInfo.ConvertToEpochInfo()
+		totalConsensusInfos[i] = epochInfo
+		require.NoError(t, db.SaveConsensusInfo(ctx, epochInfo))
+	}
+
+	retrievedConsensusInfos, err := db.ConsensusInfoRange(5, 9, 0)
+	require.NoError(t, err)
+	expected := append(append([]*eventTypes.MinimalEpochConsensusInfo{}, totalConsensusInfos[5:7]...), totalConsensusInfos[8:10]...)
+	assert.DeepEqual(t, expected, retrievedConsensusInfos)
+
+	retrievedConsensusInfos, err = db.ConsensusInfoRange(5, 100, 3)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(retrievedConsensusInfos))
+	assert.Equal(t, uint64(8), retrievedConsensusInfos[2].Epoch)
+}
+
+func TestStore_ConsensusInfoRoot(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, true)
+
+	consensusInfo := testutil.NewMinimalConsensusInfo(3).ConvertToEpochInfo()
+	require.NoError(t, db.SaveConsensusInfo(ctx, consensusInfo))
+	root, err := db.ConsensusInfoRoot(3)
+	require.NoError(t, err)
+	expectedRoot, err := consensusInfo.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash(expectedRoot), root)
+
+	// consensus info which can not be hashed has no root
+	consensusInfo.ValidatorList = []string{"0x01"}
+	require.NoError(t, db.SaveConsensusInfo(ctx, consensusInfo))
+	root, err = db.ConsensusInfoRoot(3)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{}, root)
+
+	require.NoError(t, db.RemoveRangeConsensusInfo(3, 3))
+	root, err = db.ConsensusInfoRoot(3)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{}, root)
+}
@@ -0,0 +1,51 @@
+package kv
+
+import (
+	"bytes"
+
+	"github.com/boltdb/bolt"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// EpochSummary returns stored summary of the given epoch. Nil is returned when the epoch has not been summarized.
+func (s *Store) EpochSummary(epoch uint64) (*types.EpochSummary, error) {
+	var summary *types.EpochSummary
+	err := s.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(epochSummariesBucket).Get(bytesutil.Uint64ToBytesBigEndian(epoch))
+		if value == nil {
+			return nil
+		}
+		return decode(value, &summary)
+	})
+	return summary, err
+}
+
+// EpochSummaries returns stored summaries in [fromEpoch, toEpoch] range in epoch order
+func (s *Store) EpochSummaries(fromEpoch, toEpoch uint64) ([]*types.EpochSummary, error) {
+	summaries := make([]*types.EpochSummary, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(epochSummariesBucket).Cursor()
+		toKey := bytesutil.Uint64ToBytesBigEndian(toEpoch)
+		for k, v := cursor.Seek(bytesutil.Uint64ToBytesBigEndian(fromEpoch)); k != nil && bytes.Compare(k, toKey) <= 0; k, v = cursor.Next() {
+			var summary *types.EpochSummary
+			if err := decode(v, &summary); err != nil {
+				return err
+			}
+			summaries = append(summaries, summary)
+		}
+		return nil
+	})
+	return summaries, err
+}
+
+// SaveEpochSummary stores the summary of an epoch. Summary of the same epoch is overwritten.
+func (s *Store) SaveEpochSummary(summary *types.EpochSummary) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		enc, err := encode(summary)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(epochSummariesBucket).Put(bytesutil.Uint64ToBytesBigEndian(summary.Epoch), enc)
+	})
+}
@@ -0,0 +1,40 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_EpochSummary(t *testing.T) {
+	db := setupDB(t, true)
+
+	summary, err := db.EpochSummary(3)
+	require.NoError(t, err)
+	assert.Equal(t, true, summary == nil)
+
+	for epoch := uint64(1); epoch <= 5; epoch++ {
+		require.NoError(t, db.SaveEpochSummary(&types.EpochSummary{
+			Epoch:           epoch,
+			VerifiedSlots:   30,
+			SkippedSlots:    2,
+			MissedProposers: []string{"0xab"},
+		}))
+	}
+
+	summary, err = db.EpochSummary(3)
+	require.NoError(t, err)
+	require.NotNil(t, summary)
+	assert.Equal(t, uint64(3), summary.Epoch)
+	assert.Equal(t, uint64(30), summary.VerifiedSlots)
+	assert.DeepEqual(t, []string{"0xab"}, summary.MissedProposers)
+
+	summaries, err := db.EpochSummaries(2, 4)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(summaries))
+	for i, summary := range summaries {
+		assert.Equal(t, uint64(i+2), summary.Epoch)
+	}
+}
@@ -0,0 +1,225 @@
+package kv
+
+import (
+	"bytes"
+
+	"github.com/boltdb/bolt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// SaveFinalizationRecord stores the finalized slot which vanguard has reported at the given slot, so the finalized
+// slot of a past slot can be looked up
+func (s *Store) SaveFinalizationRecord(slot, finalizedSlot uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(finalizationBucket).Put(
+			bytesutil.Uint64ToBytesBigEndian(slot), bytesutil.Uint64ToBytesBigEndian(finalizedSlot))
+	})
+}
+
+// HeadAt reconstructs the verified head which the orchestrator had as of the given slot within a single transaction.
+// Slots which have been invalidated since are taken from the reorg audit records, records which have been stored by
+// older versions do not have the hashes of the invalidated slots so the head is returned without hashes. Pruned
+// slots are not resolved.
+func (s *Store) HeadAt(asOfSlot uint64) (*types.HistoricalHead, error) {
+	head := &types.HistoricalHead{AsOfSlot: asOfSlot}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		records, err := reorgAuditRecords(tx)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			if record.NewSlot <= asOfSlot {
+				head.LatestReorg = record
+			}
+		}
+		view := newVerifiedAsOf(records, asOfSlot)
+		slot, slotInfo, err := view.head(tx)
+		if err != nil {
+			return err
+		}
+		head.Slot = slot
+		if slotInfo != nil {
+			head.PandoraHeaderHash = slotInfo.PandoraHeaderHash
+			head.VanguardBlockHash = slotInfo.VanguardBlockHash
+		}
+		head.FinalizedSlot = finalizedSlotAt(tx, asOfSlot)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return head, nil
+}
+
+// BlockStatusAt returns the status which the pandora header with the given hash had as of the given slot within a
+// single transaction. A header which had been invalidated by a reorg by then is returned with the reorg, a header
+// which had not been verified by then is unknown. Nil is returned when the header has been neither verified nor
+// rejected.
+func (s *Store) BlockStatusAt(hash common.Hash, asOfSlot uint64) (*types.HistoricalBlockStatus, error) {
+	var status *types.HistoricalBlockStatus
+	err := s.db.View(func(tx *bolt.Tx) error {
+		records, err := reorgAuditRecords(tx)
+		if err != nil {
+			return err
+		}
+		status = &types.HistoricalBlockStatus{
+			AsOfSlot:           asOfSlot,
+			PandoraBlockStatus: types.PandoraBlockStatus{PandoraHeaderHash: hash, Status: types.Unknown},
+		}
+		found := false
+		if slotBytes := tx.Bucket(pandoraHashIndexBucket).Get(hash.Bytes()); slotBytes != nil {
+			status.Slot = bytesutil.BytesToUint64BigEndian(slotBytes)
+			found = true
+		}
+		for _, record := range records {
+			for i, slotInfo := range record.InvalidatedSlotInfos {
+				if slotInfo == nil || slotInfo.PandoraHeaderHash != hash || i >= len(record.InvalidatedSlots) {
+					continue
+				}
+				status.Slot = record.InvalidatedSlots[i]
+				status.VanguardBlockHash = slotInfo.VanguardBlockHash
+				found = true
+				if record.NewSlot <= asOfSlot {
+					status.Reorg = record
+				}
+			}
+		}
+		if !found {
+			status = nil
+			return nil
+		}
+
+		verified, err := newVerifiedAsOf(records, asOfSlot).slotInfo(tx, status.Slot)
+		if err != nil {
+			return err
+		}
+		invalid, err := slotInfoAt(tx.Bucket(invalidSlotInfosBucket), status.Slot)
+		if err != nil {
+			return err
+		}
+		switch {
+		case status.Slot > asOfSlot:
+			// the slot had not been reached yet
+		case verified != nil && verified.PandoraHeaderHash == hash:
+			// a header which has been verified again after a reorg is no longer reported with the reorg
+			status.VanguardBlockHash = verified.VanguardBlockHash
+			status.Status = types.Verified
+			status.Reorg = nil
+			if status.Slot <= finalizedSlotAt(tx, asOfSlot) {
+				status.Status = types.Finalized
+			}
+		case status.Reorg != nil:
+			status.Status = types.Invalid
+		case invalid != nil && invalid.PandoraHeaderHash == hash:
+			status.VanguardBlockHash = invalid.VanguardBlockHash
+			status.Status = types.Invalid
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// verifiedAsOf resolves the slots which had been verified as of a past slot. Every reorg since has removed the
+// verified slots after its revert slot, so currently verified slots up to the lowest of these revert slots had been
+// verified already, while the slots above it which had been verified are found in the records of the reorgs.
+type verifiedAsOf struct {
+	reverted map[uint64]*types.SlotInfo // verified slots which have been invalidated since
+	limit    uint64                     // currently verified slots up to limit had been verified
+}
+
+func newVerifiedAsOf(records []*types.ReorgAuditRecord, asOfSlot uint64) *verifiedAsOf {
+	view := &verifiedAsOf{reverted: make(map[uint64]*types.SlotInfo), limit: asOfSlot}
+	for _, record := range records {
+		if record.NewSlot <= asOfSlot {
+			continue
+		}
+		for i, slot := range record.InvalidatedSlots {
+			if _, ok := view.reverted[slot]; ok || slot > view.limit {
+				continue
+			}
+			slotInfo := &types.SlotInfo{}
+			if i < len(record.InvalidatedSlotInfos) && record.InvalidatedSlotInfos[i] != nil {
+				slotInfo = record.InvalidatedSlotInfos[i]
+			}
+			view.reverted[slot] = slotInfo
+		}
+		if record.RevertSlot < view.limit {
+			view.limit = record.RevertSlot
+		}
+	}
+	return view
+}
+
+// slotInfo returns the verified block of the slot, nil when the slot had not been verified
+func (v *verifiedAsOf) slotInfo(tx *bolt.Tx, slot uint64) (*types.SlotInfo, error) {
+	if slotInfo, ok := v.reverted[slot]; ok {
+		return slotInfo, nil
+	}
+	if slot > v.limit {
+		return nil, nil
+	}
+	return slotInfoAt(tx.Bucket(verifiedSlotInfosBucket), slot)
+}
+
+// head returns the latest verified slot with its block, reverted slots are always above the limit
+func (v *verifiedAsOf) head(tx *bolt.Tx) (uint64, *types.SlotInfo, error) {
+	if len(v.reverted) > 0 {
+		var headSlot uint64
+		for slot := range v.reverted {
+			if slot > headSlot {
+				headSlot = slot
+			}
+		}
+		return headSlot, v.reverted[headSlot], nil
+	}
+	k, value := lastAtOrBefore(tx.Bucket(verifiedSlotInfosBucket), bytesutil.Uint64ToBytesBigEndian(v.limit))
+	if k == nil {
+		return 0, nil, nil
+	}
+	var slotInfo *types.SlotInfo
+	if err := decode(value, &slotInfo); err != nil {
+		return 0, nil, err
+	}
+	return bytesutil.BytesToUint64BigEndian(k), slotInfo, nil
+}
+
+// finalizedSlotAt returns the latest finalized slot which had been reported up to the given slot
+func finalizedSlotAt(tx *bolt.Tx, slot uint64) uint64 {
+	_, value := lastAtOrBefore(tx.Bucket(finalizationBucket), bytesutil.Uint64ToBytesBigEndian(slot))
+	if value == nil {
+		return 0
+	}
+	return bytesutil.BytesToUint64BigEndian(value)
+}
+
+// slotInfoAt returns the slot info which is stored for the slot in the given bucket
+func slotInfoAt(bkt *bolt.Bucket, slot uint64) (*types.SlotInfo, error) {
+	value := bkt.Get(bytesutil.Uint64ToBytesBigEndian(slot))
+	if value == nil {
+		return nil, nil
+	}
+	var slotInfo *types.SlotInfo
+	if err := decode(value, &slotInfo); err != nil {
+		return nil, err
+	}
+	return slotInfo, nil
+}
+
+// lastAtOrBefore returns the entry with the greatest key which is not greater than the given key
+func lastAtOrBefore(bkt *bolt.Bucket, key []byte) ([]byte, []byte) {
+	cursor := bkt.Cursor()
+	k, v := cursor.Seek(key)
+	switch {
+	case k == nil:
+		return cursor.Last()
+	case bytes.Equal(k, key):
+		return k, v
+	default:
+		return cursor.Prev()
+	}
+}
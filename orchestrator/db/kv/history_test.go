@@ -0,0 +1,120 @@
+package kv
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// setupReorgHistory verifies slots 1 to 5, reorgs slots 4 and 5 away at slot 6 and verifies slots 4 and 6 of the
+// new fork. Slot 1 is finalized at slot 3.
+func setupReorgHistory(t *testing.T) (*Store, map[string]*types.SlotInfo) {
+	db := setupDB(t, true)
+	blocks := map[string]*types.SlotInfo{
+		"1":  {PandoraHeaderHash: common.HexToHash("0x01"), VanguardBlockHash: common.HexToHash("0xa1")},
+		"2":  {PandoraHeaderHash: common.HexToHash("0x02"), VanguardBlockHash: common.HexToHash("0xa2")},
+		"3":  {PandoraHeaderHash: common.HexToHash("0x03"), VanguardBlockHash: common.HexToHash("0xa3")},
+		"4":  {PandoraHeaderHash: common.HexToHash("0x04"), VanguardBlockHash: common.HexToHash("0xa4")},
+		"5":  {PandoraHeaderHash: common.HexToHash("0x05"), VanguardBlockHash: common.HexToHash("0xa5")},
+		"4'": {PandoraHeaderHash: common.HexToHash("0x14"), VanguardBlockHash: common.HexToHash("0xb4")},
+		"6'": {PandoraHeaderHash: common.HexToHash("0x16"), VanguardBlockHash: common.HexToHash("0xb6")},
+	}
+	for slot := uint64(1); slot <= 5; slot++ {
+		require.NoError(t, db.SaveVerifiedSlotInfo(slot, blocks[fmt.Sprint(slot)]))
+		require.NoError(t, db.SaveSlotStatus(slot, types.Verified))
+	}
+	require.NoError(t, db.SaveFinalizationRecord(3, 1))
+
+	require.NoError(t, db.RemoveRangeVerifiedInfo(4, 5))
+	require.NoError(t, db.SaveSlotStatus(4, types.Invalid))
+	require.NoError(t, db.SaveSlotStatus(5, types.Invalid))
+	require.NoError(t, db.SaveReorgAuditRecord(&types.ReorgAuditRecord{
+		NewSlot:              6,
+		RevertSlot:           3,
+		InvalidatedSlots:     []uint64{4, 5},
+		InvalidatedSlotInfos: []*types.SlotInfo{blocks["4"], blocks["5"]},
+	}))
+	require.NoError(t, db.SaveVerifiedSlotInfo(4, blocks["4'"]))
+	require.NoError(t, db.SaveVerifiedSlotInfo(6, blocks["6'"]))
+	return db, blocks
+}
+
+func TestStore_HeadAt(t *testing.T) {
+	db, blocks := setupReorgHistory(t)
+
+	head, err := db.HeadAt(5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), head.Slot)
+	assert.Equal(t, blocks["5"].PandoraHeaderHash, head.PandoraHeaderHash)
+	assert.Equal(t, blocks["5"].VanguardBlockHash, head.VanguardBlockHash)
+	assert.Equal(t, uint64(1), head.FinalizedSlot)
+	assert.Equal(t, true, head.LatestReorg == nil)
+
+	head, err = db.HeadAt(7)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), head.Slot)
+	assert.Equal(t, blocks["6'"].PandoraHeaderHash, head.PandoraHeaderHash)
+	require.NotNil(t, head.LatestReorg)
+	assert.Equal(t, uint64(3), head.LatestReorg.RevertSlot)
+
+	head, err = db.HeadAt(2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), head.Slot)
+	assert.Equal(t, blocks["2"].PandoraHeaderHash, head.PandoraHeaderHash)
+	assert.Equal(t, uint64(0), head.FinalizedSlot)
+}
+
+func TestStore_HeadAt_RecordWithoutHashes(t *testing.T) {
+	db := setupDB(t, true)
+	require.NoError(t, db.SaveVerifiedSlotInfo(1, &types.SlotInfo{PandoraHeaderHash: common.HexToHash("0x01")}))
+	require.NoError(t, db.SaveReorgAuditRecord(&types.ReorgAuditRecord{
+		NewSlot:          4,
+		RevertSlot:       1,
+		InvalidatedSlots: []uint64{2, 3},
+	}))
+
+	head, err := db.HeadAt(3)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), head.Slot)
+	assert.Equal(t, common.Hash{}, head.PandoraHeaderHash)
+}
+
+func TestStore_BlockStatusAt(t *testing.T) {
+	db, blocks := setupReorgHistory(t)
+
+	tests := []struct {
+		name     string
+		block    string
+		asOfSlot uint64
+		slot     uint64
+		status   types.Status
+		reorged  bool
+	}{
+		{name: "verified before reorg", block: "5", asOfSlot: 5, slot: 5, status: types.Verified},
+		{name: "invalidated by reorg", block: "5", asOfSlot: 7, slot: 5, status: types.Invalid, reorged: true},
+		{name: "new fork before reorg", block: "4'", asOfSlot: 5, slot: 4, status: types.Unknown},
+		{name: "new fork after reorg", block: "4'", asOfSlot: 7, slot: 4, status: types.Verified},
+		{name: "slot not reached", block: "6'", asOfSlot: 5, slot: 6, status: types.Unknown},
+		{name: "not finalized yet", block: "1", asOfSlot: 2, slot: 1, status: types.Verified},
+		{name: "finalized", block: "1", asOfSlot: 7, slot: 1, status: types.Finalized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := db.BlockStatusAt(blocks[tt.block].PandoraHeaderHash, tt.asOfSlot)
+			require.NoError(t, err)
+			require.NotNil(t, status)
+			assert.Equal(t, tt.asOfSlot, status.AsOfSlot)
+			assert.Equal(t, tt.slot, status.Slot)
+			assert.Equal(t, tt.status, status.Status)
+			assert.Equal(t, tt.reorged, status.Reorg != nil)
+		})
+	}
+
+	status, err := db.BlockStatusAt(common.HexToHash("0xff"), 7)
+	require.NoError(t, err)
+	assert.Equal(t, true, status == nil)
+}
@@ -37,6 +37,6 @@ func (s *Store) SaveInvalidSlotInfo(slot uint64, slotInfo *types.SlotInfo) error
 		if err := bkt.Put(slotBytes, enc); err != nil {
 			return err
 		}
-		return nil
+		return putPandoraHashIndex(tx, slot, slotInfo.PandoraHeaderHash)
 	})
 }
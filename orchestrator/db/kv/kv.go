@@ -22,6 +22,8 @@ const (
 	DatabaseFileName = "orchestrator.db"
 
 	boltAllocSize = 8 * 1024 * 1024
+	// boltMaxMMapStep is the step in which bolt grows memory maps larger than 1GB
+	boltMaxMMapStep = 1 << 30
 )
 
 // Config for the bolt db kv store.
@@ -34,6 +36,7 @@ type Store struct {
 	isRunning             bool
 	db                    *bolt.DB
 	databasePath          string
+	initialMMapSize       int
 	consensusInfoCache    *ristretto.Cache
 	verifiedSlotInfoCache *ristretto.Cache
 
@@ -55,6 +58,7 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 		}
 	}
 	datafile := path.Join(dirPath, DatabaseFileName)
+	compactIfScheduled(dirPath, datafile)
 	boltDB, err := bolt.Open(
 		datafile,
 		params.OrchestratorIoConfig().ReadWritePermissions,
@@ -91,6 +95,7 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 		ctx:                   ctx,
 		db:                    boltDB,
 		databasePath:          dirPath,
+		initialMMapSize:       config.InitialMMapSize,
 		consensusInfoCache:    consensusInfoCache,
 		verifiedSlotInfoCache: verifiedSlotInfoCache,
 	}
@@ -99,8 +104,18 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 		return createBuckets(
 			tx,
 			consensusInfosBucket,
+			consensusInfoRootBucket,
 			verifiedSlotInfosBucket,
 			invalidSlotInfosBucket,
+			invalidEvidenceBucket,
+			slotStatusBucket,
+			reorgAuditBucket,
+			orphanSlotsBucket,
+			replayResultsBucket,
+			epochSummariesBucket,
+			pandoraHashIndexBucket,
+			finalizationBucket,
+			pendingShardInfosBucket,
 			latestInfoMarkerBucket,
 		)
 	}); err != nil {
@@ -142,6 +157,29 @@ func (s *Store) DatabasePath() string {
 	return s.databasePath
 }
 
+// MMapSize returns the size in bytes of bolt db's memory map. Bolt does not expose it, so it is derived from the
+// size of the database file the same way bolt sizes the map when the file grows.
+func (s *Store) MMapSize() int64 {
+	info, err := os.Stat(path.Join(s.databasePath, DatabaseFileName))
+	if err != nil {
+		return 0
+	}
+	size := info.Size()
+	if minSize := int64(s.initialMMapSize); size < minSize {
+		size = minSize
+	}
+	// the map doubles from 32KB until 1GB and grows in steps of 1GB afterwards
+	for i := uint(15); i <= 30; i++ {
+		if size <= 1<<i {
+			return 1 << i
+		}
+	}
+	if remainder := size % boltMaxMMapStep; remainder > 0 {
+		size += boltMaxMMapStep - remainder
+	}
+	return size
+}
+
 // createBuckets
 func createBuckets(tx *bolt.Tx, buckets ...[]byte) error {
 	for _, bucket := range buckets {
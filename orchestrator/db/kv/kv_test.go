@@ -31,3 +31,12 @@ func TestKV_Start_Stop(t *testing.T) {
 	require.NoError(t, kv.Close())
 	kv = setupDB(t, false)
 }
+
+func TestStore_MMapSize(t *testing.T) {
+	db, err := NewKVStore(context.Background(), t.TempDir(), &Config{InitialMMapSize: 1 << 20})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	require.Equal(t, int64(1<<20), db.MMapSize())
+}
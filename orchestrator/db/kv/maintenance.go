@@ -0,0 +1,366 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// BackupsDirName is the name of the directory within the database directory where backups are written.
+	BackupsDirName = "backups"
+	// compactionMarkerFileName marks the database to be compacted on the next start.
+	compactionMarkerFileName = "compact-on-start"
+)
+
+// Backup writes a consistent copy of the database into the backups directory of the database directory.
+func (s *Store) Backup(ctx context.Context) (*types.BackupResult, error) {
+	backupsDir := path.Join(s.databasePath, BackupsDirName)
+	if err := fileutil.MkdirAll(backupsDir); err != nil {
+		return nil, errors.Wrap(err, "could not create backups directory")
+	}
+
+	result := new(types.BackupResult)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// latest verified slot is read within the copied transaction so it matches the backup
+		if slotBytes := tx.Bucket(latestInfoMarkerBucket).Get(latestSavedVerifiedSlotKey); slotBytes != nil {
+			result.Slot = bytesutil.BytesToUint64BigEndian(slotBytes)
+		}
+		result.Path = path.Join(backupsDir, fmt.Sprintf("orchestrator_%d_slot_%d.backup", time.Now().Unix(), result.Slot))
+		result.Size = tx.Size()
+		return fileutil.WriteAtomic(result.Path, params.OrchestratorIoConfig().ReadWritePermissions, func(w io.Writer) error {
+			_, err := tx.WriteTo(w)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not write database backup")
+	}
+	log.WithField("path", result.Path).WithField("slot", result.Slot).Info("Database backup written")
+	return result, nil
+}
+
+// PruneBefore removes verification results of the slots before the given slot, the pandora hash index entries
+// of these slots and consensus infos of the epochs which end before it. Only finalized slots can be pruned, so
+// that reorgs and re-verification of pending slots never need pruned data. Reorg audit records, epoch
+// summaries and finalization history are kept.
+func (s *Store) PruneBefore(slot uint64) (*types.PruneResult, error) {
+	finalizedSlot := s.LatestLatestFinalizedSlot()
+	if slot > finalizedSlot {
+		return nil, errors.Errorf("cannot prune beyond latest finalized slot %d", finalizedSlot)
+	}
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	result := &types.PruneResult{BeforeSlot: slot, BeforeEpoch: slot / s.slotsPerEpoch()}
+	slotKey := bytesutil.Uint64ToBytesBigEndian(slot)
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		prunedSlots, err := deleteKeysBefore(tx.Bucket(slotStatusBucket), slotKey)
+		if err != nil {
+			return err
+		}
+		result.PrunedSlots = prunedSlots
+		result.PrunedEntries += prunedSlots
+
+		for _, bucket := range [][]byte{
+			verifiedSlotInfosBucket,
+			invalidSlotInfosBucket,
+			invalidEvidenceBucket,
+			orphanSlotsBucket,
+			replayResultsBucket,
+			pendingShardInfosBucket,
+		} {
+			pruned, err := deleteKeysBefore(tx.Bucket(bucket), slotKey)
+			if err != nil {
+				return err
+			}
+			result.PrunedEntries += pruned
+		}
+
+		// pandora hash index is keyed by header hash, so the whole index has to be scanned
+		indexBkt := tx.Bucket(pandoraHashIndexBucket)
+		var staleHashes [][]byte
+		if err := indexBkt.ForEach(func(k, v []byte) error {
+			if bytes.Compare(v, slotKey) < 0 {
+				staleHashes = append(staleHashes, append([]byte{}, k...))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, hash := range staleHashes {
+			if err := indexBkt.Delete(hash); err != nil {
+				return err
+			}
+		}
+		result.PrunedEntries += uint64(len(staleHashes))
+
+		prunedEpochs, err := deleteKeysBefore(tx.Bucket(consensusInfosBucket), bytesutil.Uint64ToBytesBigEndian(result.BeforeEpoch))
+		if err != nil {
+			return err
+		}
+		if _, err := deleteKeysBefore(tx.Bucket(consensusInfoRootBucket), bytesutil.Uint64ToBytesBigEndian(result.BeforeEpoch)); err != nil {
+			return err
+		}
+		result.PrunedEpochs = prunedEpochs
+		result.PrunedEntries += prunedEpochs
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not prune database")
+	}
+	s.consensusInfoCache.Clear()
+	s.verifiedSlotInfoCache.Clear()
+
+	log.WithField("beforeSlot", slot).WithField("prunedSlots", result.PrunedSlots).
+		WithField("prunedEpochs", result.PrunedEpochs).WithField("prunedEntries", result.PrunedEntries).
+		Info("Pruned database")
+	return result, nil
+}
+
+// deleteKeysBefore deletes all the keys of the bucket which sort before the given key
+func deleteKeysBefore(bkt *bolt.Bucket, before []byte) (uint64, error) {
+	var keys [][]byte
+	cursor := bkt.Cursor()
+	for k, _ := cursor.First(); k != nil && bytes.Compare(k, before) < 0; k, _ = cursor.Next() {
+		keys = append(keys, append([]byte{}, k...))
+	}
+	for _, k := range keys {
+		if err := bkt.Delete(k); err != nil {
+			return 0, err
+		}
+	}
+	return uint64(len(keys)), nil
+}
+
+// Inspect counts the entries of every bucket and reads the markers of verification progress
+func (s *Store) Inspect() (*types.DatabaseInspection, error) {
+	inspection := &types.DatabaseInspection{
+		Path:    path.Join(s.databasePath, DatabaseFileName),
+		Buckets: make(map[string]int),
+	}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		inspection.Size = tx.Size()
+		markerBkt := tx.Bucket(latestInfoMarkerBucket)
+		inspection.LatestVerifiedSlot = bytesutil.BytesToUint64BigEndian(markerBkt.Get(latestSavedVerifiedSlotKey))
+		inspection.LatestFinalizedSlot = bytesutil.BytesToUint64BigEndian(markerBkt.Get(latestFinalizedSlotKey))
+		inspection.LatestFinalizedEpoch = bytesutil.BytesToUint64BigEndian(markerBkt.Get(latestFinalizedEpochKey))
+		inspection.LatestEpoch = bytesutil.BytesToUint64BigEndian(markerBkt.Get(lastStoredEpochKey))
+		return tx.ForEach(func(name []byte, bkt *bolt.Bucket) error {
+			inspection.Buckets[string(name)] = bkt.Stats().KeyN
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not inspect database")
+	}
+	return inspection, nil
+}
+
+// WriteStats returns the amount of data written by committed transactions and the amount of data stored in buckets,
+// so that write amplification of the database can be measured
+func (s *Store) WriteStats() (*types.DatabaseWriteStats, error) {
+	dbStats := s.db.Stats()
+	stats := &types.DatabaseWriteStats{
+		PageWrites:   int64(dbStats.TxStats.Write),
+		BytesWritten: int64(dbStats.TxStats.PageAlloc),
+	}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(_ []byte, bkt *bolt.Bucket) error {
+			stats.LiveBytes += int64(bkt.Stats().LeafInuse)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read database write stats")
+	}
+	return stats, nil
+}
+
+// RollbackTo removes verification results after the given slot, so that verification continues after it on the
+// next start. Finalized markers are moved back as well when the slot is before the latest finalized slot.
+func (s *Store) RollbackTo(slot uint64) error {
+	latestSlot := s.LatestSavedVerifiedSlot()
+	if slot >= latestSlot {
+		return errors.Errorf("slot %d is not before latest verified slot %d", slot, latestSlot)
+	}
+	if err := s.RemoveRangeVerifiedInfo(slot+1, latestSlot); err != nil {
+		return errors.Wrap(err, "could not remove verified slot infos")
+	}
+	if s.LatestLatestFinalizedSlot() > slot {
+		if err := s.SaveLatestFinalizedSlot(slot); err != nil {
+			return err
+		}
+		if err := s.SaveLatestFinalizedEpoch(slot / s.slotsPerEpoch()); err != nil {
+			return err
+		}
+	}
+	if err := s.UpdateVerifiedSlotInfo(slot); err != nil {
+		return errors.Wrap(err, "could not update latest verified slot")
+	}
+	// markers are reset when no slot info is stored up to the slot
+	if s.LatestSavedVerifiedSlot() > slot {
+		if err := s.SaveLatestVerifiedSlot(s.ctx, 0); err != nil {
+			return err
+		}
+		if err := s.SaveLatestVerifiedHeaderHash(common.Hash{}); err != nil {
+			return err
+		}
+	}
+	log.WithField("slot", slot).WithField("previousSlot", latestSlot).Info("Rolled back database")
+	return nil
+}
+
+// RestoreBackup replaces the database in the directory with a copy of the backup file. The backup is checked
+// to be an orchestrator database before anything is replaced. The database must not be open.
+func RestoreBackup(dirPath, backupPath string) error {
+	ioConfig := params.OrchestratorIoConfig()
+	if err := os.MkdirAll(dirPath, 0700); err != nil {
+		return errors.Wrap(err, "could not create database directory")
+	}
+	backup, err := bolt.Open(backupPath, ioConfig.ReadWritePermissions, &bolt.Options{Timeout: ioConfig.BoltTimeout, ReadOnly: true})
+	if err != nil {
+		return errors.Wrap(err, "could not open backup")
+	}
+	datafile := path.Join(dirPath, DatabaseFileName)
+	err = backup.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(latestInfoMarkerBucket) == nil {
+			return errors.Errorf("%s is not an orchestrator database backup", backupPath)
+		}
+		// the database is replaced only by a complete copy of the backup
+		return fileutil.WriteAtomic(datafile, ioConfig.ReadWritePermissions, func(w io.Writer) error {
+			_, err := tx.WriteTo(w)
+			return err
+		})
+	})
+	if closeErr := backup.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not restore backup")
+	}
+	log.WithField("backup", backupPath).WithField("path", datafile).Info("Restored database from backup")
+	return nil
+}
+
+// ScheduleCompaction marks the database to be compacted on the next start. Bolt never shrinks its file, so space
+// freed by pruning is only given back by copying the database, which needs exclusive access to it.
+func (s *Store) ScheduleCompaction() error {
+	marker := path.Join(s.databasePath, compactionMarkerFileName)
+	if err := fileutil.WriteFileAtomic(marker, []byte{}, params.OrchestratorIoConfig().ReadWritePermissions); err != nil {
+		return errors.Wrap(err, "could not schedule database compaction")
+	}
+	log.Info("Database compaction scheduled on next start")
+	return nil
+}
+
+// compactIfScheduled compacts the database file before it is opened when compaction has been scheduled. Failed
+// compaction keeps the original database and is retried on the next start.
+func compactIfScheduled(dirPath, datafile string) {
+	marker := path.Join(dirPath, compactionMarkerFileName)
+	if !fileutil.FileExists(marker) {
+		return
+	}
+	start := time.Now()
+	sizeBefore, err := fileSize(datafile)
+	if err != nil {
+		log.WithError(err).Warn("Could not compact database, retrying on next start")
+		return
+	}
+	compacted := datafile + ".compact"
+	if err := compact(datafile, compacted); err != nil {
+		if removeErr := os.Remove(compacted); removeErr != nil && !os.IsNotExist(removeErr) {
+			log.WithError(removeErr).Warn("Could not remove partially compacted database")
+		}
+		log.WithError(err).Warn("Could not compact database, retrying on next start")
+		return
+	}
+	if err := fileutil.ReplaceFile(compacted, datafile); err != nil {
+		log.WithError(err).Warn("Could not replace database with compacted copy, retrying on next start")
+		return
+	}
+	if err := os.Remove(marker); err != nil {
+		log.WithError(err).Warn("Could not remove database compaction marker")
+	} else if err := fileutil.SyncDir(dirPath); err != nil {
+		log.WithError(err).Warn("Could not sync database directory")
+	}
+	sizeAfter, _ := fileSize(datafile)
+	log.WithField("sizeBefore", sizeBefore).WithField("sizeAfter", sizeAfter).
+		WithField("elapsed", time.Since(start)).Info("Compacted database")
+}
+
+// compact copies all the buckets of the database at srcPath into a new database at dstPath
+func compact(srcPath, dstPath string) error {
+	ioConfig := params.OrchestratorIoConfig()
+	src, err := bolt.Open(srcPath, ioConfig.ReadWritePermissions, &bolt.Options{Timeout: ioConfig.BoltTimeout, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := src.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	dst, err := bolt.Open(dstPath, ioConfig.ReadWritePermissions, &bolt.Options{Timeout: ioConfig.BoltTimeout})
+	if err != nil {
+		return err
+	}
+
+	err = src.View(func(srcTx *bolt.Tx) error {
+		return srcTx.ForEach(func(name []byte, srcBkt *bolt.Bucket) error {
+			return dst.Update(func(dstTx *bolt.Tx) error {
+				dstBkt, err := dstTx.CreateBucket(name)
+				if err != nil {
+					return err
+				}
+				return copyBucket(srcBkt, dstBkt)
+			})
+		})
+	})
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func copyBucket(src, dst *bolt.Bucket) error {
+	// keys are mostly slot or epoch numbers which are appended in order, so pages can be filled up
+	dst.FillPercent = 1.0
+	if err := dst.SetSequence(src.Sequence()); err != nil {
+		return err
+	}
+	return src.ForEach(func(k, v []byte) error {
+		if v == nil {
+			nested, err := dst.CreateBucket(k)
+			if err != nil {
+				return err
+			}
+			return copyBucket(src.Bucket(k), nested)
+		}
+		return dst.Put(k, v)
+	})
+}
+
+func fileSize(file string) (int64, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
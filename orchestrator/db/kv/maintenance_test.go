@@ -0,0 +1,192 @@
+package kv
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/boltdb/bolt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// fillDB stores verified slots and consensus infos of the first three epochs
+func fillDB(t *testing.T, db *Store) {
+	ctx := context.Background()
+	for epoch := uint64(0); epoch < 3; epoch++ {
+		require.NoError(t, db.SaveConsensusInfo(ctx, &types.MinimalEpochConsensusInfo{Epoch: epoch, ValidatorList: []string{"0x01"}}))
+	}
+	for slot := uint64(0); slot < 3*params.SlotsPerEpoch; slot++ {
+		slotInfo := &types.SlotInfo{
+			PandoraHeaderHash: common.BytesToHash([]byte{byte(slot), 1}),
+			VanguardBlockHash: common.BytesToHash([]byte{byte(slot), 2}),
+		}
+		require.NoError(t, db.SaveVerifiedSlotInfo(slot, slotInfo))
+		require.NoError(t, db.SaveSlotStatus(slot, types.Verified))
+	}
+	require.NoError(t, db.SaveLatestVerifiedSlot(ctx, 3*params.SlotsPerEpoch-1))
+	require.NoError(t, db.SaveLatestFinalizedSlot(2*params.SlotsPerEpoch))
+}
+
+func TestStore_Backup(t *testing.T) {
+	db := setupDB(t, true)
+	fillDB(t, db)
+
+	result, err := db.Backup(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3*params.SlotsPerEpoch-1), result.Slot)
+	assert.Equal(t, path.Join(db.DatabasePath(), BackupsDirName), path.Dir(result.Path))
+
+	backup, err := bolt.Open(result.Path, params.OrchestratorIoConfig().ReadWritePermissions, &bolt.Options{ReadOnly: true})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, backup.Close())
+	}()
+	require.NoError(t, backup.View(func(tx *bolt.Tx) error {
+		assert.Equal(t, 3*params.SlotsPerEpoch, tx.Bucket(slotStatusBucket).Stats().KeyN)
+		assert.Equal(t, 3, tx.Bucket(consensusInfosBucket).Stats().KeyN)
+		return nil
+	}))
+}
+
+func TestStore_PruneBefore(t *testing.T) {
+	db := setupDB(t, true)
+	fillDB(t, db)
+
+	_, err := db.PruneBefore(2*params.SlotsPerEpoch + 1)
+	assert.ErrorContains(t, "cannot prune beyond latest finalized slot", err)
+
+	result, err := db.PruneBefore(params.SlotsPerEpoch + 8)
+	require.NoError(t, err)
+	assert.DeepEqual(t, &types.PruneResult{
+		BeforeSlot:    params.SlotsPerEpoch + 8,
+		BeforeEpoch:   1,
+		PrunedSlots:   params.SlotsPerEpoch + 8,
+		PrunedEpochs:  1,
+		PrunedEntries: 3*(params.SlotsPerEpoch+8) + 1,
+	}, result)
+
+	slotInfo, err := db.VerifiedSlotInfo(params.SlotsPerEpoch + 7)
+	require.NoError(t, err)
+	assert.Equal(t, true, slotInfo == nil)
+	slotInfo, err = db.VerifiedSlotInfo(params.SlotsPerEpoch + 8)
+	require.NoError(t, err)
+	assert.NotNil(t, slotInfo)
+
+	consensusInfo, err := db.ConsensusInfo(context.Background(), 0)
+	assert.Equal(t, true, err != nil || consensusInfo == nil)
+	consensusInfo, err = db.ConsensusInfo(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), consensusInfo.Epoch)
+
+	statuses, err := db.PandoraBlockStatuses([]common.Hash{
+		common.BytesToHash([]byte{byte(params.SlotsPerEpoch + 7), 1}),
+		common.BytesToHash([]byte{byte(params.SlotsPerEpoch + 8), 1}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, true, statuses[0] == nil)
+	assert.Equal(t, uint64(params.SlotsPerEpoch+8), statuses[1].Slot)
+}
+
+func TestStore_ScheduleCompaction(t *testing.T) {
+	dbPath := t.TempDir()
+	db, err := NewKVStore(context.Background(), dbPath, &Config{})
+	require.NoError(t, err)
+	fillDB(t, db)
+	_, err = db.PruneBefore(2 * params.SlotsPerEpoch)
+	require.NoError(t, err)
+	require.NoError(t, db.ScheduleCompaction())
+	require.NoError(t, db.Close())
+
+	db, err = NewKVStore(context.Background(), dbPath, &Config{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	_, err = os.Stat(path.Join(dbPath, compactionMarkerFileName))
+	assert.Equal(t, true, os.IsNotExist(err))
+
+	assert.Equal(t, uint64(3*params.SlotsPerEpoch-1), db.LatestSavedVerifiedSlot())
+	slotInfo, err := db.VerifiedSlotInfo(2 * params.SlotsPerEpoch)
+	require.NoError(t, err)
+	assert.Equal(t, common.BytesToHash([]byte{byte(2 * params.SlotsPerEpoch), 1}), slotInfo.PandoraHeaderHash)
+}
+
+func TestStore_Inspect(t *testing.T) {
+	db := setupDB(t, true)
+	fillDB(t, db)
+
+	inspection, err := db.Inspect()
+	require.NoError(t, err)
+	assert.Equal(t, path.Join(db.DatabasePath(), DatabaseFileName), inspection.Path)
+	assert.Equal(t, uint64(3*params.SlotsPerEpoch-1), inspection.LatestVerifiedSlot)
+	assert.Equal(t, uint64(2*params.SlotsPerEpoch), inspection.LatestFinalizedSlot)
+	assert.Equal(t, 3*params.SlotsPerEpoch, inspection.Buckets[string(verifiedSlotInfosBucket)])
+	assert.Equal(t, 3, inspection.Buckets[string(consensusInfosBucket)])
+	assert.Equal(t, 0, inspection.Buckets[string(orphanSlotsBucket)])
+}
+
+func TestStore_WriteStats(t *testing.T) {
+	db := setupDB(t, true)
+	before, err := db.WriteStats()
+	require.NoError(t, err)
+	fillDB(t, db)
+
+	after, err := db.WriteStats()
+	require.NoError(t, err)
+	assert.Equal(t, true, after.PageWrites > before.PageWrites)
+	assert.Equal(t, true, after.LiveBytes > before.LiveBytes)
+	// every committed transaction writes at least a whole page, more than the data it stores
+	assert.Equal(t, true, after.BytesWritten-before.BytesWritten > after.LiveBytes-before.LiveBytes)
+}
+
+func TestStore_RollbackTo(t *testing.T) {
+	db := setupDB(t, true)
+	fillDB(t, db)
+
+	assert.ErrorContains(t, "is not before latest verified slot", db.RollbackTo(3*params.SlotsPerEpoch-1))
+
+	slot := uint64(params.SlotsPerEpoch + 4)
+	require.NoError(t, db.RollbackTo(slot))
+	assert.Equal(t, slot, db.LatestSavedVerifiedSlot())
+	assert.Equal(t, slot, db.LatestLatestFinalizedSlot())
+	assert.Equal(t, uint64(1), db.LatestLatestFinalizedEpoch())
+	assert.Equal(t, common.BytesToHash([]byte{byte(slot), 1}), db.LatestVerifiedHeaderHash())
+
+	slotInfo, err := db.VerifiedSlotInfo(slot + 1)
+	require.NoError(t, err)
+	assert.Equal(t, true, slotInfo == nil)
+	slotInfo, err = db.VerifiedSlotInfo(slot)
+	require.NoError(t, err)
+	assert.NotNil(t, slotInfo)
+}
+
+func TestRestoreBackup(t *testing.T) {
+	dbPath := t.TempDir()
+	db, err := NewKVStore(context.Background(), dbPath, &Config{})
+	require.NoError(t, err)
+	fillDB(t, db)
+	backup, err := db.Backup(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, db.RollbackTo(params.SlotsPerEpoch))
+	require.NoError(t, db.Close())
+
+	notOrchestratorDB := path.Join(t.TempDir(), "other.db")
+	other, err := bolt.Open(notOrchestratorDB, params.OrchestratorIoConfig().ReadWritePermissions, nil)
+	require.NoError(t, err)
+	require.NoError(t, other.Close())
+	assert.ErrorContains(t, "is not an orchestrator database backup", RestoreBackup(dbPath, notOrchestratorDB))
+
+	require.NoError(t, RestoreBackup(dbPath, backup.Path))
+	db, err = NewKVStore(context.Background(), dbPath, &Config{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	assert.Equal(t, uint64(3*params.SlotsPerEpoch-1), db.LatestSavedVerifiedSlot())
+	assert.Equal(t, uint64(2*params.SlotsPerEpoch), db.LatestLatestFinalizedSlot())
+}
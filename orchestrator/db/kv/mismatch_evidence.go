@@ -0,0 +1,54 @@
+package kv
+
+import (
+	"bytes"
+
+	"github.com/boltdb/bolt"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// MismatchEvidence returns stored mismatch evidence of the given slot. Nil is returned when
+// the slot has not been rejected.
+func (s *Store) MismatchEvidence(slot uint64) (*types.MismatchEvidence, error) {
+	var evidence *types.MismatchEvidence
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(invalidEvidenceBucket)
+		value := bkt.Get(bytesutil.Uint64ToBytesBigEndian(slot))
+		if value == nil {
+			return nil
+		}
+		return decode(value, &evidence)
+	})
+	return evidence, err
+}
+
+// MismatchEvidences returns stored mismatch evidences in [fromSlot, toSlot] range in slot order
+func (s *Store) MismatchEvidences(fromSlot, toSlot uint64) ([]*types.MismatchEvidence, error) {
+	evidences := make([]*types.MismatchEvidence, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(invalidEvidenceBucket).Cursor()
+		toKey := bytesutil.Uint64ToBytesBigEndian(toSlot)
+		for k, v := cursor.Seek(bytesutil.Uint64ToBytesBigEndian(fromSlot)); k != nil && bytes.Compare(k, toKey) <= 0; k, v = cursor.Next() {
+			var evidence *types.MismatchEvidence
+			if err := decode(v, &evidence); err != nil {
+				return err
+			}
+			evidences = append(evidences, evidence)
+		}
+		return nil
+	})
+	return evidences, err
+}
+
+// SaveMismatchEvidence stores both sides of a rejected slot. Evidence of the same slot is overwritten.
+func (s *Store) SaveMismatchEvidence(evidence *types.MismatchEvidence) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(invalidEvidenceBucket)
+		enc, err := encode(evidence)
+		if err != nil {
+			return err
+		}
+		return bkt.Put(bytesutil.Uint64ToBytesBigEndian(evidence.Slot), enc)
+	})
+}
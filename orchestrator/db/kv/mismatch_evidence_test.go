@@ -0,0 +1,43 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_MismatchEvidence(t *testing.T) {
+	db := setupDB(t, true)
+
+	evidence, err := db.MismatchEvidence(5)
+	require.NoError(t, err)
+	assert.Equal(t, true, evidence == nil)
+
+	for slot := uint64(1); slot <= 5; slot++ {
+		header := testutil.NewEth1Header(slot)
+		require.NoError(t, db.SaveMismatchEvidence(&types.MismatchEvidence{
+			Slot:              slot,
+			Reason:            "sharding info mismatch",
+			VanguardShardInfo: testutil.NewVanguardShardInfo(slot, header),
+			PandoraHeader:     header,
+		}))
+	}
+
+	evidence, err = db.MismatchEvidence(5)
+	require.NoError(t, err)
+	require.NotNil(t, evidence)
+	assert.Equal(t, uint64(5), evidence.Slot)
+	assert.Equal(t, "sharding info mismatch", evidence.Reason)
+	assert.Equal(t, testutil.NewEth1Header(5).Hash(), evidence.PandoraHeader.Hash())
+	assert.DeepEqual(t, testutil.NewEth1Header(5).Hash().Bytes(), evidence.VanguardShardInfo.ShardInfo.Hash)
+
+	evidences, err := db.MismatchEvidences(2, 4)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(evidences))
+	for i, evidence := range evidences {
+		assert.Equal(t, uint64(i+2), evidence.Slot)
+	}
+}
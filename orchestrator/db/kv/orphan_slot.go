@@ -0,0 +1,36 @@
+package kv
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// OrphanSlot returns the evicted orphan slot. Nil is returned when the slot has not been evicted.
+func (s *Store) OrphanSlot(slot uint64) (*types.OrphanSlot, error) {
+	var orphan *types.OrphanSlot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(orphanSlotsBucket).Get(bytesutil.Uint64ToBytesBigEndian(slot))
+		if value == nil {
+			return nil
+		}
+		return decode(value, &orphan)
+	})
+	return orphan, err
+}
+
+// SaveOrphanSlot stores an evicted pending slot into orphan bucket and flips its status to orphaned
+// in a single transaction.
+func (s *Store) SaveOrphanSlot(orphan *types.OrphanSlot) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		slotBytes := bytesutil.Uint64ToBytesBigEndian(orphan.Slot)
+		enc, err := encode(orphan)
+		if err != nil {
+			return err
+		}
+		if err := tx.Bucket(orphanSlotsBucket).Put(slotBytes, enc); err != nil {
+			return err
+		}
+		return tx.Bucket(slotStatusBucket).Put(slotBytes, []byte(types.Orphaned))
+	})
+}
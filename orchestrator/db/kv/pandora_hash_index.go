@@ -0,0 +1,60 @@
+package kv
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// PandoraBlockStatuses resolves stored statuses of the given pandora header hashes within a single transaction.
+// Statuses are returned in the order of the given hashes, nil is returned for the hashes which have been neither
+// verified nor rejected. The index is never pruned, so a hash whose slot has been re-verified with another header
+// since is not resolved.
+func (s *Store) PandoraBlockStatuses(hashes []common.Hash) ([]*types.PandoraBlockStatus, error) {
+	statuses := make([]*types.PandoraBlockStatus, len(hashes))
+	err := s.db.View(func(tx *bolt.Tx) error {
+		indexBkt := tx.Bucket(pandoraHashIndexBucket)
+		statusBkt := tx.Bucket(slotStatusBucket)
+		for i, hash := range hashes {
+			slotBytes := indexBkt.Get(hash.Bytes())
+			if slotBytes == nil {
+				continue
+			}
+			status := types.Status(statusBkt.Get(slotBytes))
+			var infoBkt *bolt.Bucket
+			switch status {
+			case types.Verified, types.Finalized:
+				infoBkt = tx.Bucket(verifiedSlotInfosBucket)
+			case types.Invalid:
+				infoBkt = tx.Bucket(invalidSlotInfosBucket)
+			default:
+				continue
+			}
+			value := infoBkt.Get(slotBytes)
+			if value == nil {
+				continue
+			}
+			var slotInfo *types.SlotInfo
+			if err := decode(value, &slotInfo); err != nil {
+				return err
+			}
+			if slotInfo.PandoraHeaderHash != hash {
+				continue
+			}
+			statuses[i] = &types.PandoraBlockStatus{
+				Slot:              bytesutil.BytesToUint64BigEndian(slotBytes),
+				PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+				VanguardBlockHash: slotInfo.VanguardBlockHash,
+				Status:            status,
+			}
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// putPandoraHashIndex maps pandora header hash to its slot within the given transaction
+func putPandoraHashIndex(tx *bolt.Tx, slot uint64, hash common.Hash) error {
+	return tx.Bucket(pandoraHashIndexBucket).Put(hash.Bytes(), bytesutil.Uint64ToBytesBigEndian(slot))
+}
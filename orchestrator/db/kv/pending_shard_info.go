@@ -0,0 +1,53 @@
+package kv
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// PendingShardInfos returns the queued vanguard shard infos which have not been verified yet, ordered by slot
+func (s *Store) PendingShardInfos() ([]*types.VanguardShardInfo, error) {
+	var shardInfos []*types.VanguardShardInfo
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(pendingShardInfosBucket).ForEach(func(k, v []byte) error {
+			var shardInfo *types.VanguardShardInfo
+			if err := decode(v, &shardInfo); err != nil {
+				return err
+			}
+			shardInfos = append(shardInfos, shardInfo)
+			return nil
+		})
+	})
+	return shardInfos, err
+}
+
+// SavePendingShardInfo queues a vanguard shard info which has been received but not verified yet, a queued shard
+// info of the same slot is replaced
+func (s *Store) SavePendingShardInfo(shardInfo *types.VanguardShardInfo) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		enc, err := encode(shardInfo)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(pendingShardInfosBucket).Put(bytesutil.Uint64ToBytesBigEndian(shardInfo.Slot), enc)
+	})
+}
+
+// RemovePendingShardInfo removes the queued vanguard shard info of the slot
+func (s *Store) RemovePendingShardInfo(slot uint64) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(pendingShardInfosBucket).Delete(bytesutil.Uint64ToBytesBigEndian(slot))
+	})
+}
+
+// PurgePendingShardInfos removes all queued vanguard shard infos
+func (s *Store) PurgePendingShardInfos() error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(pendingShardInfosBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucket(pendingShardInfosBucket)
+		return err
+	})
+}
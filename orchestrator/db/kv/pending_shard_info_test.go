@@ -0,0 +1,38 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestStore_PendingShardInfos(t *testing.T) {
+	db := setupDB(t, true)
+	for _, slot := range []uint64{7, 3, 5} {
+		require.NoError(t, db.SavePendingShardInfo(testutil.NewVanguardShardInfo(slot, testutil.NewEth1Header(slot))))
+	}
+	// a shard info of the same slot replaces the queued one
+	replacement := testutil.NewVanguardShardInfo(5, testutil.NewEth1Header(6))
+	require.NoError(t, db.SavePendingShardInfo(replacement))
+
+	shardInfos, err := db.PendingShardInfos()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(shardInfos))
+	assert.Equal(t, uint64(3), shardInfos[0].Slot)
+	assert.Equal(t, uint64(5), shardInfos[1].Slot)
+	assert.Equal(t, uint64(7), shardInfos[2].Slot)
+	assert.DeepEqual(t, replacement.ShardInfo.Hash, shardInfos[1].ShardInfo.Hash)
+
+	require.NoError(t, db.RemovePendingShardInfo(5))
+	shardInfos, err = db.PendingShardInfos()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(shardInfos))
+	assert.Equal(t, uint64(7), shardInfos[1].Slot)
+
+	require.NoError(t, db.PurgePendingShardInfos())
+	shardInfos, err = db.PendingShardInfos()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(shardInfos))
+}
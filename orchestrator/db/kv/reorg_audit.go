@@ -0,0 +1,49 @@
+package kv
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// SaveReorgAuditRecord appends a reorg audit record into db. Records are keyed by an increasing
+// sequence number so they are always iterated in insertion order.
+func (s *Store) SaveReorgAuditRecord(record *types.ReorgAuditRecord) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(reorgAuditBucket)
+		seq, err := bkt.NextSequence()
+		if err != nil {
+			return err
+		}
+		enc, err := encode(record)
+		if err != nil {
+			return err
+		}
+		return bkt.Put(bytesutil.Uint64ToBytesBigEndian(seq), enc)
+	})
+}
+
+// ReorgAuditRecords returns all the stored reorg audit records in insertion order
+func (s *Store) ReorgAuditRecords() ([]*types.ReorgAuditRecord, error) {
+	var records []*types.ReorgAuditRecord
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		records, err = reorgAuditRecords(tx)
+		return err
+	})
+	return records, err
+}
+
+// reorgAuditRecords returns the reorg audit records in insertion order within the given transaction
+func reorgAuditRecords(tx *bolt.Tx) ([]*types.ReorgAuditRecord, error) {
+	records := make([]*types.ReorgAuditRecord, 0)
+	err := tx.Bucket(reorgAuditBucket).ForEach(func(k, v []byte) error {
+		var record *types.ReorgAuditRecord
+		if err := decode(v, &record); err != nil {
+			return err
+		}
+		records = append(records, record)
+		return nil
+	})
+	return records, err
+}
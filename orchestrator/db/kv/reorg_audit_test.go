@@ -0,0 +1,29 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_ReorgAuditRecords(t *testing.T) {
+	db := setupDB(t, true)
+
+	records, err := db.ReorgAuditRecords()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(records))
+
+	expected := []*types.ReorgAuditRecord{
+		{Timestamp: 1, NewSlot: 10, RevertSlot: 5, InvalidatedSlots: []uint64{6, 7, 8}},
+		{Timestamp: 2, NewSlot: 20, RevertSlot: 15, InvalidatedSlots: []uint64{16}},
+	}
+	for _, record := range expected {
+		require.NoError(t, db.SaveReorgAuditRecord(record))
+	}
+
+	records, err = db.ReorgAuditRecords()
+	require.NoError(t, err)
+	assert.DeepEqual(t, expected, records)
+}
@@ -89,14 +89,5 @@ func (s *Store) UpdateVerifiedSlotInfo(slot uint64) error {
 	log.WithField("slot", slotNumber).WithField("latestVerifiedSlot", slotNumber).
 		Debug("Latest slot till latest finalized slot, updating verified markers")
 
-	err = s.SaveLatestVerifiedSlot(s.ctx, slotNumber)
-	if err != nil {
-		return err
-	}
-
-	err = s.SaveLatestVerifiedHeaderHash(slotInfo.PandoraHeaderHash)
-	if err != nil {
-		return err
-	}
-	return nil
+	return s.SaveVerificationCheckpoint(slotNumber, slotInfo)
 }
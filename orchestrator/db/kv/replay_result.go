@@ -0,0 +1,47 @@
+package kv
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// SaveReplayResults replaces results of the previous replay with the given results in a single transaction.
+// Replay results are kept apart from the stored verification results, so replay never changes them.
+func (s *Store) SaveReplayResults(results []*types.ReplayResult) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(replayResultsBucket); err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+		bkt, err := tx.CreateBucket(replayResultsBucket)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			enc, err := encode(result)
+			if err != nil {
+				return err
+			}
+			if err := bkt.Put(bytesutil.Uint64ToBytesBigEndian(result.Slot), enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ReplayResults returns results of the latest replay in slot order
+func (s *Store) ReplayResults() ([]*types.ReplayResult, error) {
+	results := make([]*types.ReplayResult, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(replayResultsBucket).ForEach(func(k, v []byte) error {
+			var result *types.ReplayResult
+			if err := decode(v, &result); err != nil {
+				return err
+			}
+			results = append(results, result)
+			return nil
+		})
+	})
+	return results, err
+}
@@ -3,13 +3,24 @@ package kv
 var (
 	// 3 buckets for containing orchestrator data
 	consensusInfosBucket    = []byte("consensus-info")
+	consensusInfoRootBucket = []byte("consensus-info-root") // ssz hash tree roots of consensus infos by epoch
 	verifiedSlotInfosBucket = []byte("verified-slots")
 	invalidSlotInfosBucket  = []byte("invalid-slots")
-	latestInfoMarkerBucket  = []byte("latest-info-marker") // Only use for storing the following keys
+	invalidEvidenceBucket   = []byte("invalid-evidence")
+	slotStatusBucket        = []byte("slot-status")
+	reorgAuditBucket        = []byte("reorg-audit")
+	orphanSlotsBucket       = []byte("orphan-slots")
+	replayResultsBucket     = []byte("replay-results")
+	epochSummariesBucket    = []byte("epoch-summaries")
+	pandoraHashIndexBucket  = []byte("pandora-hash-index")
+	finalizationBucket      = []byte("finalization-history") // finalized slot by the slot which has reported it
+	pendingShardInfosBucket = []byte("pending-shard-infos")  // received vanguard shard infos which are not verified yet
+	latestInfoMarkerBucket  = []byte("latest-info-marker")   // Only use for storing the following keys
 
 	latestHeaderHashKey        = []byte("latest-header-hash")
 	lastStoredEpochKey         = []byte("last-epoch")
 	latestSavedVerifiedSlotKey = []byte("latest-verified-slot")
 	latestFinalizedSlotKey     = []byte("latest-finalized-slot")
 	latestFinalizedEpochKey    = []byte("latest-finalized-epoch")
+	chainConfigKey             = []byte("chain-config")
 )
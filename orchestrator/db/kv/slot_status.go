@@ -0,0 +1,73 @@
+package kv
+
+import (
+	"bytes"
+
+	"github.com/boltdb/bolt"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// SlotStatus returns stored verification status of the given slot. Unknown is returned when
+// the slot has not been processed yet.
+func (s *Store) SlotStatus(slot uint64) (types.Status, error) {
+	status := types.Unknown
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(slotStatusBucket)
+		value := bkt.Get(bytesutil.Uint64ToBytesBigEndian(slot))
+		if value == nil {
+			return nil
+		}
+		status = types.Status(value)
+		return nil
+	})
+	return status, err
+}
+
+// SlotStatuses returns stored verification statuses in [fromSlot, toSlot] range
+func (s *Store) SlotStatuses(fromSlot, toSlot uint64) (map[uint64]types.Status, error) {
+	statuses := make(map[uint64]types.Status)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(slotStatusBucket).Cursor()
+		toKey := bytesutil.Uint64ToBytesBigEndian(toSlot)
+		for k, v := cursor.Seek(bytesutil.Uint64ToBytesBigEndian(fromSlot)); k != nil && bytes.Compare(k, toKey) <= 0; k, v = cursor.Next() {
+			statuses[bytesutil.BytesToUint64BigEndian(k)] = types.Status(v)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// FinalizeSlotStatuses upgrades verified slot statuses to finalized in [fromSlot, toSlot] range
+// within a single transaction. It returns the number of finalized slots.
+func (s *Store) FinalizeSlotStatuses(fromSlot, toSlot uint64) (int, error) {
+	finalized := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(slotStatusBucket)
+		cursor := bkt.Cursor()
+		toKey := bytesutil.Uint64ToBytesBigEndian(toSlot)
+		// collect keys first, bolt cursor may be invalidated by mutations during traversal
+		verifiedKeys := make([][]byte, 0)
+		for k, v := cursor.Seek(bytesutil.Uint64ToBytesBigEndian(fromSlot)); k != nil && bytes.Compare(k, toKey) <= 0; k, v = cursor.Next() {
+			if types.Status(v) == types.Verified {
+				verifiedKeys = append(verifiedKeys, append([]byte{}, k...))
+			}
+		}
+		for _, k := range verifiedKeys {
+			if err := bkt.Put(k, []byte(types.Finalized)); err != nil {
+				return err
+			}
+		}
+		finalized = len(verifiedKeys)
+		return nil
+	})
+	return finalized, err
+}
+
+// SaveSlotStatus stores verification status of the given slot
+func (s *Store) SaveSlotStatus(slot uint64, status types.Status) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(slotStatusBucket)
+		return bkt.Put(bytesutil.Uint64ToBytesBigEndian(slot), []byte(status))
+	})
+}
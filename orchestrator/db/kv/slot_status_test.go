@@ -0,0 +1,70 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_SlotStatus(t *testing.T) {
+	db := setupDB(t, true)
+
+	status, err := db.SlotStatus(10)
+	require.NoError(t, err)
+	assert.Equal(t, types.Unknown, status)
+
+	require.NoError(t, db.SaveSlotStatus(10, types.Pending))
+	status, err = db.SlotStatus(10)
+	require.NoError(t, err)
+	assert.Equal(t, types.Pending, status)
+
+	require.NoError(t, db.SaveSlotStatus(10, types.Verified))
+	status, err = db.SlotStatus(10)
+	require.NoError(t, err)
+	assert.Equal(t, types.Verified, status)
+
+	// removing verified info also removes slot status
+	require.NoError(t, db.RemoveRangeVerifiedInfo(5, 15))
+	status, err = db.SlotStatus(10)
+	require.NoError(t, err)
+	assert.Equal(t, types.Unknown, status)
+}
+
+func TestStore_FinalizeSlotStatuses(t *testing.T) {
+	db := setupDB(t, true)
+
+	require.NoError(t, db.SaveSlotStatus(1, types.Verified))
+	require.NoError(t, db.SaveSlotStatus(2, types.Invalid))
+	require.NoError(t, db.SaveSlotStatus(3, types.Verified))
+	require.NoError(t, db.SaveSlotStatus(4, types.Verified))
+
+	finalized, err := db.FinalizeSlotStatuses(1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 2, finalized)
+
+	expected := map[uint64]types.Status{
+		1: types.Finalized,
+		2: types.Invalid,
+		3: types.Finalized,
+		4: types.Verified,
+	}
+	for slot, expectedStatus := range expected {
+		status, err := db.SlotStatus(slot)
+		require.NoError(t, err)
+		assert.Equal(t, expectedStatus, status)
+	}
+}
+
+func TestStore_SlotStatuses(t *testing.T) {
+	db := setupDB(t, true)
+
+	require.NoError(t, db.SaveSlotStatus(1, types.Verified))
+	require.NoError(t, db.SaveSlotStatus(2, types.Skipped))
+	require.NoError(t, db.SaveSlotStatus(5, types.Invalid))
+
+	statuses, err := db.SlotStatuses(2, 5)
+	require.NoError(t, err)
+	assert.DeepEqual(t, map[uint64]types.Status{2: types.Skipped, 5: types.Invalid}, statuses)
+}
@@ -0,0 +1,76 @@
+package kv
+
+import (
+	"github.com/boltdb/bolt"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// SaveVerificationBatch stores verdicts of a batch of slots in a single transaction. Verified slots are stored
+// into verified slot info bucket, rejected slots into invalid slot info bucket with their mismatch evidence.
+// Only the status of skipped slots is stored. Latest verified markers point to the last verified slot of the batch.
+func (s *Store) SaveVerificationBatch(verdicts []*types.SlotVerdict) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	verified := make(map[uint64]*types.SlotInfo)
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		var latestVerified *types.SlotVerdict
+		for _, verdict := range verdicts {
+			slotBytes := bytesutil.Uint64ToBytesBigEndian(verdict.Slot)
+			if err := tx.Bucket(slotStatusBucket).Put(slotBytes, []byte(verdict.Status)); err != nil {
+				return err
+			}
+			if verdict.Status == types.Skipped {
+				continue
+			}
+
+			enc, err := encode(verdict.SlotInfo)
+			if err != nil {
+				return err
+			}
+
+			infoBkt := tx.Bucket(verifiedSlotInfosBucket)
+			if verdict.Status == types.Invalid {
+				infoBkt = tx.Bucket(invalidSlotInfosBucket)
+				if verdict.Evidence != nil {
+					encEvidence, err := encode(verdict.Evidence)
+					if err != nil {
+						return err
+					}
+					if err := tx.Bucket(invalidEvidenceBucket).Put(slotBytes, encEvidence); err != nil {
+						return err
+					}
+				}
+			} else {
+				latestVerified = verdict
+				verified[verdict.Slot] = verdict.SlotInfo
+			}
+			if err := infoBkt.Put(slotBytes, enc); err != nil {
+				return err
+			}
+			if err := putPandoraHashIndex(tx, verdict.Slot, verdict.SlotInfo.PandoraHeaderHash); err != nil {
+				return err
+			}
+		}
+
+		if latestVerified == nil {
+			return nil
+		}
+		markerBkt := tx.Bucket(latestInfoMarkerBucket)
+		if err := markerBkt.Put(latestSavedVerifiedSlotKey, bytesutil.Uint64ToBytesBigEndian(latestVerified.Slot)); err != nil {
+			return err
+		}
+		return markerBkt.Put(latestHeaderHashKey, latestVerified.SlotInfo.PandoraHeaderHash.Bytes())
+	})
+	if err != nil {
+		return err
+	}
+
+	for slot, slotInfo := range verified {
+		if status := s.verifiedSlotInfoCache.Set(slot, slotInfo, 0); !status {
+			log.WithField("slot", slot).Warn("could not store verified slot info into cache")
+		}
+	}
+	return nil
+}
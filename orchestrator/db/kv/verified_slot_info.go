@@ -115,7 +115,7 @@ func (s *Store) SaveVerifiedSlotInfo(slot uint64, slotInfo *types.SlotInfo) erro
 		if err := bkt.Put(slotBytes, enc); err != nil {
 			return err
 		}
-		return nil
+		return putPandoraHashIndex(tx, slot, slotInfo.PandoraHeaderHash)
 	})
 }
 
@@ -215,6 +215,7 @@ func (s *Store) RemoveRangeVerifiedInfo(fromSlot, toSlot uint64) error {
 	// storing latest epoch number into db
 	return s.db.Update(func(tx *bolt.Tx) error {
 		bkt := tx.Bucket(verifiedSlotInfosBucket)
+		statusBkt := tx.Bucket(slotStatusBucket)
 
 		for slotNum := fromSlot; slotNum <= toSlot; slotNum++ {
 			removingSlotNumber := bytesutil.Uint64ToBytesBigEndian(slotNum)
@@ -223,6 +224,9 @@ func (s *Store) RemoveRangeVerifiedInfo(fromSlot, toSlot uint64) error {
 			if err != nil {
 				return err
 			}
+			if err := statusBkt.Delete(removingSlotNumber); err != nil {
+				return err
+			}
 		}
 		log.Debug("success:: all slots are removed from the verified database")
 		return nil
@@ -0,0 +1,284 @@
+// Package ha elects the leader of orchestrators which run against the same vanguard and pandora pair. Every
+// orchestrator keeps verifying into its own database, so a follower takes over with current state, but only the
+// leader serves the authoritative feeds which vanguard and pandora subscribe to.
+//
+// The leader holds a lease in a file on storage which all orchestrators of the pair share, e.g. an NFS mount,
+// and renews it three times per lease duration. A follower claims an expired lease and becomes leader when its
+// claim is still in place on the next renewal, so two followers which claim at the same time do not both lead.
+// Clocks of the hosts must be synchronized far better than the lease duration.
+package ha
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+	"github.com/pkg/errors"
+)
+
+// haMetrics collects leadership of the node
+var haMetrics = metrics.NewCollector("ha")
+
+// Config defines the lease which is shared by the orchestrators of a pair
+type Config struct {
+	// LeaseFile is the path of the lease on shared storage
+	LeaseFile string
+	// NodeID identifies the orchestrator in the lease, it must differ between orchestrators of the pair
+	NodeID string
+	// LeaseDuration is the time after which a lease which has not been renewed can be taken over
+	LeaseDuration time.Duration
+	// Perm of a created lease file, 0600 by default
+	Perm os.FileMode
+}
+
+// lease is the content of the lease file
+type lease struct {
+	Holder string `json:"holder"`
+	// Expires is the unix time in milliseconds at which the lease can be taken over
+	Expires int64 `json:"expires"`
+}
+
+func (l *lease) expired(now time.Time) bool {
+	return l.Holder == "" || now.UnixNano()/int64(time.Millisecond) >= l.Expires
+}
+
+// Elector takes part in the election of the leader and reports whether this orchestrator leads
+type Elector struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+	done      chan struct{}
+
+	config *Config
+	now    func() time.Time
+
+	lock     sync.Mutex
+	leader   bool
+	claimed  bool          // a claim of the lease waits for confirmation on the next renewal
+	term     chan struct{} // closed when the current leadership ends
+	leaseErr error         // error of the latest lease access
+
+	leaderGauge  metrics.Gauge
+	transitions  metrics.Counter
+	leaseFailure metrics.Counter
+}
+
+// closed is returned as term of an orchestrator which does not lead
+var closed = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
+// NewElector creates an elector of the lease of the config
+func NewElector(ctx context.Context, cfg *Config) (*Elector, error) {
+	if cfg.LeaseFile == "" {
+		return nil, errors.New("lease file of high availability mode is not set")
+	}
+	if cfg.NodeID == "" {
+		return nil, errors.New("node id of high availability mode is not set")
+	}
+	if cfg.LeaseDuration < time.Second {
+		return nil, errors.Errorf("lease duration %s of high availability mode is shorter than a second", cfg.LeaseDuration)
+	}
+	if cfg.Perm == 0 {
+		cfg.Perm = 0600
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+	return &Elector{
+		ctx:          ctx,
+		cancel:       cancel,
+		done:         make(chan struct{}),
+		config:       cfg,
+		now:          time.Now,
+		term:         closed,
+		leaderGauge:  haMetrics.Gauge("leader"),
+		transitions:  haMetrics.Counter("transitions"),
+		leaseFailure: haMetrics.Counter("lease/failures"),
+	}, nil
+}
+
+// Start takes part in the election until the elector is stopped
+func (e *Elector) Start() {
+	if e.isRunning {
+		log.Error("Attempted to start leader election when it was already started")
+		return
+	}
+	e.isRunning = true
+	log.WithField("nodeId", e.config.NodeID).WithField("leaseFile", e.config.LeaseFile).
+		WithField("leaseDuration", e.config.LeaseDuration).Info("Started leader election")
+	go e.run()
+}
+
+// Stop releases the lease when this orchestrator leads, so the follower takes over without waiting for expiry
+func (e *Elector) Stop() error {
+	e.cancel()
+	if !e.isRunning {
+		return nil
+	}
+	e.isRunning = false
+	<-e.done
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.leader {
+		e.stepDown("stopped")
+		if err := e.writeLease(&lease{Holder: e.config.NodeID}); err != nil {
+			return errors.Wrap(err, "could not release lease")
+		}
+	}
+	return nil
+}
+
+// Status returns the error of the latest access to the lease file
+func (e *Elector) Status() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.leaseErr
+}
+
+// IsLeader reports whether this orchestrator leads
+func (e *Elector) IsLeader() bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.leader
+}
+
+// Term returns a channel which is closed when the current leadership of this orchestrator ends. The channel is
+// closed already when this orchestrator does not lead.
+func (e *Elector) Term() <-chan struct{} {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.term
+}
+
+func (e *Elector) run() {
+	defer close(e.done)
+	defer shared.RecoverService(e)
+
+	ticker := time.NewTicker(e.config.LeaseDuration / 3)
+	defer ticker.Stop()
+	e.tick()
+	for {
+		select {
+		case <-ticker.C:
+			e.tick()
+		case <-e.ctx.Done():
+			return
+		}
+	}
+}
+
+// tick renews the lease of the leader, or claims an expired lease and confirms the claim on the next tick
+func (e *Elector) tick() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	now := e.now()
+	current, err := e.readLease()
+	if err != nil {
+		e.failed(err)
+		return
+	}
+	renewed := &lease{
+		Holder:  e.config.NodeID,
+		Expires: now.Add(e.config.LeaseDuration).UnixNano() / int64(time.Millisecond),
+	}
+	switch {
+	case current.Holder == e.config.NodeID && (e.leader || e.claimed || !current.expired(now)):
+		// the lease is ours: renew it, a claim is confirmed since no other orchestrator has overwritten it.
+		// A lease which has not expired is ours after a restart.
+		if err := e.writeLease(renewed); err != nil {
+			e.failed(err)
+			return
+		}
+		e.leaseErr = nil
+		e.claimed = false
+		if !e.leader {
+			e.stepUp()
+		}
+	case current.expired(now):
+		if e.leader {
+			e.stepDown("lease expired before it was renewed")
+		}
+		if err := e.writeLease(renewed); err != nil {
+			e.failed(err)
+			return
+		}
+		e.leaseErr = nil
+		e.claimed = true
+		log.WithField("previousHolder", current.Holder).Info("Claimed expired lease")
+	default:
+		e.leaseErr = nil
+		e.claimed = false
+		if e.leader {
+			e.stepDown("lease has been taken over by " + current.Holder)
+		}
+	}
+}
+
+// failed steps down when the lease can not be accessed, since it may be taken over meanwhile. The caller must
+// hold e.lock.
+func (e *Elector) failed(err error) {
+	e.leaseFailure.Inc(1)
+	e.leaseErr = err
+	e.claimed = false
+	if e.leader {
+		e.stepDown(err.Error())
+		return
+	}
+	log.WithError(err).Warn("Could not access lease file")
+}
+
+// stepUp makes this orchestrator the leader. The caller must hold e.lock.
+func (e *Elector) stepUp() {
+	e.leader = true
+	e.term = make(chan struct{})
+	e.leaderGauge.Update(1)
+	e.transitions.Inc(1)
+	log.WithField("nodeId", e.config.NodeID).Info("Became leader, serving authoritative feeds")
+}
+
+// stepDown makes this orchestrator a follower and ends subscriptions of the leadership. The caller must hold e.lock.
+func (e *Elector) stepDown(reason string) {
+	e.leader = false
+	close(e.term)
+	e.term = closed
+	e.leaderGauge.Update(0)
+	e.transitions.Inc(1)
+	log.WithField("nodeId", e.config.NodeID).WithField("reason", reason).Warn("Lost leadership, following")
+}
+
+// readLease reads the lease file, a missing file is an expired lease
+func (e *Elector) readLease() (*lease, error) {
+	data, err := ioutil.ReadFile(e.config.LeaseFile)
+	if os.IsNotExist(err) {
+		return &lease{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read lease file")
+	}
+	current := &lease{}
+	if err := json.Unmarshal(data, current); err != nil {
+		return nil, errors.Wrap(err, "could not decode lease file")
+	}
+	return current, nil
+}
+
+// writeLease replaces the lease file atomically, so other orchestrators never read a partially written lease
+func (e *Elector) writeLease(l *lease) error {
+	data, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	if err := fileutil.WriteFileAtomic(e.config.LeaseFile, data, e.config.Perm); err != nil {
+		return errors.Wrap(err, "could not write lease file")
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package ha
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func newTestElector(t *testing.T, leaseFile, nodeID string, now *time.Time) *Elector {
+	elector, err := NewElector(context.Background(), &Config{
+		LeaseFile:     leaseFile,
+		NodeID:        nodeID,
+		LeaseDuration: 15 * time.Second,
+	})
+	require.NoError(t, err)
+	elector.now = func() time.Time { return *now }
+	return elector
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestNewElector_InvalidConfig(t *testing.T) {
+	_, err := NewElector(context.Background(), &Config{NodeID: "a", LeaseDuration: time.Minute})
+	assert.ErrorContains(t, "lease file", err)
+	_, err = NewElector(context.Background(), &Config{LeaseFile: "lease", LeaseDuration: time.Minute})
+	assert.ErrorContains(t, "node id", err)
+	_, err = NewElector(context.Background(), &Config{LeaseFile: "lease", NodeID: "a", LeaseDuration: time.Millisecond})
+	assert.ErrorContains(t, "shorter than a second", err)
+}
+
+// TestElector_Failover checks that exactly one of two orchestrators leads, and the standby takes over the lease
+// once the leader stops renewing it
+func TestElector_Failover(t *testing.T) {
+	leaseFile := filepath.Join(t.TempDir(), "lease.json")
+	now := time.Unix(1600000000, 0)
+	a := newTestElector(t, leaseFile, "a", &now)
+	b := newTestElector(t, leaseFile, "b", &now)
+
+	// the first claim of the missing lease wins once it is confirmed
+	a.tick()
+	b.tick()
+	assert.Equal(t, false, a.IsLeader())
+	a.tick()
+	b.tick()
+	assert.Equal(t, true, a.IsLeader())
+	assert.Equal(t, false, b.IsLeader())
+	term := a.Term()
+	assert.Equal(t, false, isClosed(term))
+	assert.Equal(t, true, isClosed(b.Term()))
+
+	// the leader renews its lease, the standby keeps following
+	now = now.Add(10 * time.Second)
+	a.tick()
+	now = now.Add(10 * time.Second)
+	b.tick()
+	assert.Equal(t, true, a.IsLeader())
+	assert.Equal(t, false, b.IsLeader())
+
+	// the leader stops renewing, the standby claims the expired lease and the old leader steps down
+	now = now.Add(16 * time.Second)
+	b.tick()
+	b.tick()
+	assert.Equal(t, true, b.IsLeader())
+	a.tick()
+	assert.Equal(t, false, a.IsLeader())
+	assert.Equal(t, true, isClosed(term))
+	assert.NoError(t, b.Status())
+}
+
+// TestElector_StopReleasesLease checks that a stopped leader releases its lease, so the standby takes over
+// without waiting for expiry
+func TestElector_StopReleasesLease(t *testing.T) {
+	leaseFile := filepath.Join(t.TempDir(), "lease.json")
+	now := time.Unix(1600000000, 0)
+	a := newTestElector(t, leaseFile, "a", &now)
+	b := newTestElector(t, leaseFile, "b", &now)
+
+	a.tick()
+	a.tick()
+	require.Equal(t, true, a.IsLeader())
+	a.Start()
+	term := a.Term()
+	require.NoError(t, a.Stop())
+	assert.Equal(t, false, a.IsLeader())
+	assert.Equal(t, true, isClosed(term))
+
+	b.tick()
+	b.tick()
+	assert.Equal(t, true, b.IsLeader())
+}
+
+// TestElector_LeaseFailure checks that the leader steps down when its lease can not be renewed
+func TestElector_LeaseFailure(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Unix(1600000000, 0)
+	a := newTestElector(t, filepath.Join(dir, "lease.json"), "a", &now)
+	a.tick()
+	a.tick()
+	require.Equal(t, true, a.IsLeader())
+
+	a.config.LeaseFile = filepath.Join(dir, "missing", "lease.json")
+	a.tick()
+	a.tick()
+	assert.Equal(t, false, a.IsLeader())
+	assert.ErrorContains(t, "could not write lease file", a.Status())
+}
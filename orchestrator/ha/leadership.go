@@ -0,0 +1,15 @@
+package ha
+
+// Leadership reports whether this orchestrator leads its high availability pair. Only the leader serves event
+// subscriptions and block hash confirmations and pushes verification verdicts to pandora, the other orchestrator
+// keeps verifying into its own database.
+type Leadership interface {
+	IsLeader() bool
+	// Term returns a channel which is closed when the current leadership ends
+	Term() <-chan struct{}
+}
+
+var (
+	_ Leadership = (*Elector)(nil)
+	_ Leadership = (*Standby)(nil)
+)
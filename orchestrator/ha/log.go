@@ -0,0 +1,5 @@
+package ha
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "ha")
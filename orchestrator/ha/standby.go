@@ -0,0 +1,60 @@
+package ha
+
+import (
+	"sync"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+)
+
+// Standby is the leadership of a cold standby orchestrator. It verifies everything like the leader, so its
+// database stays current, but it serves the authoritative feeds and pushes verdicts to pandora only once an
+// operator promotes it. Unlike the elector it never steps down, a promoted standby leads until it is stopped.
+type Standby struct {
+	lock     sync.Mutex
+	promoted bool
+	term     chan struct{} // closed until the standby is promoted
+
+	leaderGauge metrics.Gauge
+	transitions metrics.Counter
+}
+
+// NewStandby creates the leadership of a standby which has not been promoted
+func NewStandby() *Standby {
+	s := &Standby{
+		term:        closed,
+		leaderGauge: haMetrics.Gauge("leader"),
+		transitions: haMetrics.Counter("transitions"),
+	}
+	s.leaderGauge.Update(0)
+	return s
+}
+
+// IsLeader reports whether the standby has been promoted
+func (s *Standby) IsLeader() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.promoted
+}
+
+// Term returns a channel which is closed while the standby has not been promoted, a promoted standby leads
+// until it is stopped so the channel of its leadership is never closed
+func (s *Standby) Term() <-chan struct{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.term
+}
+
+// Promote makes the standby the leader, it returns false when the standby has been promoted already
+func (s *Standby) Promote() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.promoted {
+		return false
+	}
+	s.promoted = true
+	s.term = make(chan struct{})
+	s.leaderGauge.Update(1)
+	s.transitions.Inc(1)
+	log.Info("Promoted standby to leader, serving authoritative feeds")
+	return true
+}
@@ -0,0 +1,23 @@
+package ha
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+func TestStandby_Promote(t *testing.T) {
+	s := NewStandby()
+	assert.Equal(t, false, s.IsLeader())
+	assert.Equal(t, true, isClosed(s.Term()))
+
+	assert.Equal(t, true, s.Promote())
+	assert.Equal(t, true, s.IsLeader())
+	term := s.Term()
+	assert.Equal(t, false, isClosed(term))
+
+	// a second promotion keeps the leadership
+	assert.Equal(t, false, s.Promote())
+	assert.Equal(t, true, s.IsLeader())
+	assert.Equal(t, false, isClosed(term))
+}
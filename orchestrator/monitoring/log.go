@@ -0,0 +1,5 @@
+package monitoring
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "monitoring")
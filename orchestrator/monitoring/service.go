@@ -0,0 +1,104 @@
+package monitoring
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+)
+
+// path on which metrics are served
+const metricsPath = "/metrics"
+
+// path on which pprof profiles are served
+const pprofPath = "/debug/pprof/"
+
+// time to wait for in-flight scrapes while the service is stopped
+const shutdownTimeout = 5 * time.Second
+
+// Service serves all the metrics registered by orchestrator services in prometheus text format
+type Service struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+	runError  error
+
+	addr     string
+	mux      *http.ServeMux
+	server   *http.Server
+	listener net.Listener
+}
+
+// NewService creates a metrics service which serves metrics of the given registry on the given address
+func NewService(ctx context.Context, addr string, registry metrics.Registry) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, metrics.Handler(registry))
+	return &Service{
+		ctx:    ctx,
+		cancel: cancel,
+		addr:   addr,
+		mux:    mux,
+		server: &http.Server{Handler: mux},
+	}
+}
+
+// EnableProfiling serves the standard net/http/pprof handlers next to metrics. It must be called before Start.
+func (s *Service) EnableProfiling() {
+	s.mux.HandleFunc(pprofPath, pprof.Index)
+	s.mux.HandleFunc(pprofPath+"cmdline", pprof.Cmdline)
+	s.mux.HandleFunc(pprofPath+"profile", pprof.Profile)
+	s.mux.HandleFunc(pprofPath+"symbol", pprof.Symbol)
+	s.mux.HandleFunc(pprofPath+"trace", pprof.Trace)
+	log.WithField("path", pprofPath).Info("Enabled pprof handlers on metrics server")
+}
+
+// Start starts listening for metrics scrapes
+func (s *Service) Start() {
+	if s.isRunning {
+		log.Error("Attempted to start metrics service when it was already started")
+		return
+	}
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		log.WithError(err).WithField("address", s.addr).Error("Could not start metrics server")
+		s.runError = err
+		return
+	}
+	s.listener = listener
+	s.isRunning = true
+	log.WithField("url", "http://"+listener.Addr().String()+metricsPath).Info("Metrics server started")
+
+	go func() {
+		defer shared.RecoverService(s)
+		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.WithError(err).Error("Metrics server failed")
+			s.runError = err
+		}
+	}()
+}
+
+// Stop shuts the metrics server down
+func (s *Service) Stop() error {
+	if s.cancel != nil {
+		defer s.cancel()
+	}
+	if !s.isRunning {
+		return nil
+	}
+	s.isRunning = false
+	ctx, cancel := context.WithTimeout(s.ctx, shutdownTimeout)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
+
+// Status returns an error when metrics server could not be started or has failed
+func (s *Service) Status() error {
+	return s.runError
+}
@@ -0,0 +1,60 @@
+package monitoring
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+// TestService_ServesMetrics checks that registered metrics are served in prometheus format
+func TestService_ServesMetrics(t *testing.T) {
+	metrics.Enabled = true
+	registry := metrics.NewRegistry()
+	counter := metrics.NewCounter()
+	require.NoError(t, registry.Register("consensus/slots/invalid", counter))
+	counter.Inc(3)
+
+	svc := NewService(context.Background(), "127.0.0.1:0", registry)
+	svc.Start()
+	require.NoError(t, svc.Status())
+	defer func() {
+		require.NoError(t, svc.Stop())
+	}()
+
+	resp, err := http.Get("http://" + svc.listener.Addr().String() + metricsPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, true, strings.Contains(string(body), "consensus_slots_invalid 3"))
+}
+
+// TestService_EnableProfiling checks that pprof handlers are only served when profiling is enabled
+func TestService_EnableProfiling(t *testing.T) {
+	svc := NewService(context.Background(), "127.0.0.1:0", metrics.NewRegistry())
+	svc.Start()
+	resp, err := http.Get("http://" + svc.listener.Addr().String() + pprofPath)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.NoError(t, svc.Stop())
+
+	svc = NewService(context.Background(), "127.0.0.1:0", metrics.NewRegistry())
+	svc.EnableProfiling()
+	svc.Start()
+	defer func() {
+		require.NoError(t, svc.Stop())
+	}()
+	resp, err = http.Get("http://" + svc.listener.Addr().String() + pprofPath + "goroutine?debug=1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,83 @@
+package node
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// checkChainConfig stores the chain parameters of the network config on first run and makes sure that later runs
+// use the same parameters, since stored slots and epochs are meaningless with other parameters. It returns the
+// network config whose unknown seconds per slot or genesis time are taken from the database, newly known ones are
+// stored.
+func checkChainConfig(d db.Database, config *params.NetworkConfig) (*params.NetworkConfig, error) {
+	network := *config
+	current := &types.ChainConfig{
+		SlotsPerEpoch:  network.SlotsPerEpoch,
+		SecondsPerSlot: network.SecondsPerSlot,
+		GenesisTime:    network.GenesisTime,
+	}
+	stored, err := d.ChainConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read chain config of database")
+	}
+	if stored == nil {
+		log.WithField("slotsPerEpoch", current.SlotsPerEpoch).WithField("secondsPerSlot", current.SecondsPerSlot).
+			WithField("genesisTime", current.GenesisTime).Info("Storing chain config of new database")
+		if err := d.SaveChainConfig(current); err != nil {
+			return nil, err
+		}
+		return &network, nil
+	}
+
+	merged, err := mergeChainConfig(stored, current)
+	if err != nil {
+		return nil, errors.Wrapf(err, "database at %s has been created with other chain parameters, use the same "+
+			"parameters or another data directory", d.DatabasePath())
+	}
+	if *merged != *stored {
+		if err := d.SaveChainConfig(merged); err != nil {
+			return nil, err
+		}
+	}
+	network.SecondsPerSlot = merged.SecondsPerSlot
+	network.GenesisTime = merged.GenesisTime
+	return &network, nil
+}
+
+// checkNetworkChainConfig makes sure that an additional network has the chain parameters of the main network.
+// Slot and epoch math of every service follows the active network config, so networks of other chain parameters
+// can not be verified in the same process.
+func checkNetworkChainConfig(network string, config *params.NetworkConfig) error {
+	main := params.ActiveNetworkConfig()
+	if config.SlotsPerEpoch != main.SlotsPerEpoch || config.SecondsPerSlot != main.SecondsPerSlot ||
+		config.GenesisTime != main.GenesisTime {
+		return errors.Errorf("network %s has other chain parameters than the main network: slots per epoch %d, "+
+			"seconds per slot %d, genesis time %d, main network %d, %d, %d", network, config.SlotsPerEpoch,
+			config.SecondsPerSlot, config.GenesisTime, main.SlotsPerEpoch, main.SecondsPerSlot, main.GenesisTime)
+	}
+	return nil
+}
+
+// mergeChainConfig fails when the parameters differ, zero seconds per slot and genesis time are not known and
+// they are filled from the other config
+func mergeChainConfig(stored, current *types.ChainConfig) (*types.ChainConfig, error) {
+	if stored.SlotsPerEpoch != current.SlotsPerEpoch {
+		return nil, errors.Errorf("slots per epoch %d, stored %d", current.SlotsPerEpoch, stored.SlotsPerEpoch)
+	}
+	merged := *stored
+	if current.SecondsPerSlot != 0 {
+		if stored.SecondsPerSlot != 0 && stored.SecondsPerSlot != current.SecondsPerSlot {
+			return nil, errors.Errorf("seconds per slot %d, stored %d", current.SecondsPerSlot, stored.SecondsPerSlot)
+		}
+		merged.SecondsPerSlot = current.SecondsPerSlot
+	}
+	if current.GenesisTime != 0 {
+		if stored.GenesisTime != 0 && stored.GenesisTime != current.GenesisTime {
+			return nil, errors.Errorf("genesis time %d, stored %d", current.GenesisTime, stored.GenesisTime)
+		}
+		merged.GenesisTime = current.GenesisTime
+	}
+	return &merged, nil
+}
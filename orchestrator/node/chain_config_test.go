@@ -0,0 +1,60 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/kv"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func Test_MergeChainConfig(t *testing.T) {
+	stored := &types.ChainConfig{SlotsPerEpoch: 32, SecondsPerSlot: 6}
+
+	merged, err := mergeChainConfig(stored, &types.ChainConfig{SlotsPerEpoch: 32, GenesisTime: 1000})
+	require.NoError(t, err)
+	assert.DeepEqual(t, &types.ChainConfig{SlotsPerEpoch: 32, SecondsPerSlot: 6, GenesisTime: 1000}, merged)
+
+	_, err = mergeChainConfig(stored, &types.ChainConfig{SlotsPerEpoch: 16, SecondsPerSlot: 6})
+	assert.ErrorContains(t, "slots per epoch 16, stored 32", err)
+
+	_, err = mergeChainConfig(stored, &types.ChainConfig{SlotsPerEpoch: 32, SecondsPerSlot: 12})
+	assert.ErrorContains(t, "seconds per slot 12, stored 6", err)
+}
+
+func Test_CheckChainConfig(t *testing.T) {
+	d, err := db.NewDB(context.Background(), t.TempDir(), &kv.Config{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, d.Close())
+	}()
+	active := params.ActiveNetworkConfig()
+
+	config, err := checkChainConfig(d, &params.NetworkConfig{Name: "testnet", SlotsPerEpoch: 32, SecondsPerSlot: 6})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), config.SecondsPerSlot)
+
+	// stored parameters fill in unknown ones without changing the config which the process runs on
+	config, err = checkChainConfig(d, &params.NetworkConfig{Name: "testnet", SlotsPerEpoch: 32, GenesisTime: 1000})
+	require.NoError(t, err)
+	assert.DeepEqual(t, &params.NetworkConfig{Name: "testnet", SlotsPerEpoch: 32, SecondsPerSlot: 6, GenesisTime: 1000},
+		config)
+	assert.Equal(t, active, params.ActiveNetworkConfig())
+
+	_, err = checkChainConfig(d, &params.NetworkConfig{SlotsPerEpoch: 16})
+	assert.ErrorContains(t, "slots per epoch 16, stored 32", err)
+}
+
+func Test_CheckNetworkChainConfig(t *testing.T) {
+	main := *params.ActiveNetworkConfig()
+	require.NoError(t, checkNetworkChainConfig("testnet", &main))
+
+	other := main
+	other.SecondsPerSlot++
+	assert.ErrorContains(t, "network testnet has other chain parameters than the main network",
+		checkNetworkChainConfig("testnet", &other))
+}
@@ -0,0 +1,40 @@
+package node
+
+import (
+	"sort"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// logEffectiveConfig logs the resolved value of every flag in a single entry, along with the flags which have
+// been given on the command line, by environment variables and in config file, so it is clear what the node runs with
+func logEffectiveConfig(cliCtx *cli.Context) {
+	log.WithFields(effectiveConfigFields(cmd.EffectiveConfig(cliCtx, cliCtx.App.Flags))).Info("Effective configuration")
+}
+
+// effectiveConfigFields returns a field per flag and the sorted names of flags set by command line, environment
+// and config file
+func effectiveConfigFields(config map[string]*cmd.ConfigValue) logrus.Fields {
+	fields := make(logrus.Fields, len(config)+3)
+	setByFlag, setByEnv, setByConfig := make([]string, 0), make([]string, 0), make([]string, 0)
+	for name, value := range config {
+		fields[name] = value.Value
+		switch value.Source {
+		case cmd.SourceFlag:
+			setByFlag = append(setByFlag, name)
+		case cmd.SourceEnv:
+			setByEnv = append(setByEnv, name)
+		case cmd.SourceConfig:
+			setByConfig = append(setByConfig, name)
+		}
+	}
+	sort.Strings(setByFlag)
+	sort.Strings(setByEnv)
+	sort.Strings(setByConfig)
+	fields["set-by-flag"] = setByFlag
+	fields["set-by-env"] = setByEnv
+	fields["set-by-config"] = setByConfig
+	return fields
+}
@@ -0,0 +1,23 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+func Test_EffectiveConfigFields(t *testing.T) {
+	fields := effectiveConfigFields(map[string]*cmd.ConfigValue{
+		"verbosity":         {Value: "debug", Source: cmd.SourceFlag},
+		"datadir":           {Value: "/data", Source: cmd.SourceFlag},
+		"http.port":         {Value: "7877", Source: cmd.SourceConfig},
+		"status-log-period": {Value: "1m0s", Source: cmd.SourceDefault},
+		"http.addr":         {Value: "0.0.0.0", Source: cmd.SourceEnv},
+	})
+	assert.Equal(t, "debug", fields["verbosity"])
+	assert.Equal(t, "1m0s", fields["status-log-period"])
+	assert.DeepEqual(t, []string{"datadir", "verbosity"}, fields["set-by-flag"])
+	assert.DeepEqual(t, []string{"http.addr"}, fields["set-by-env"])
+	assert.DeepEqual(t, []string{"http.port"}, fields["set-by-config"])
+}
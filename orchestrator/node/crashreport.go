@@ -0,0 +1,84 @@
+package node
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/version"
+	"github.com/pkg/errors"
+)
+
+// crashReportsDirName is the name of the directory within the data directory where crash reports are written
+const crashReportsDirName = "crashreports"
+
+// crashReport holds everything needed to investigate a crash of a service without the logs of the node
+type crashReport struct {
+	time             time.Time
+	version          string
+	crash            *shared.ServiceCrash
+	lastVerifiedSlot uint64
+	config           map[string]string // effective values of flags with secrets redacted
+}
+
+// writeCrashReport writes the report into a new file of the directory and returns the path of the file
+func writeCrashReport(dir string, report *crashReport) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", errors.Wrap(err, "could not create crash reports directory")
+	}
+	service := strings.TrimLeft(report.crash.Service.String(), "*")
+	name := fmt.Sprintf("crash_%d_%s.txt", report.time.Unix(), strings.ReplaceAll(service, ".", "_"))
+	path := filepath.Join(dir, name)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Orchestrator crash report\n\n")
+	fmt.Fprintf(&buf, "Time: %s\n", report.time.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&buf, "Version: %s\n", report.version)
+	fmt.Fprintf(&buf, "Service: %s\n", service)
+	fmt.Fprintf(&buf, "Reason: %v\n", report.crash.Reason)
+	fmt.Fprintf(&buf, "Last verified slot: %d\n", report.lastVerifiedSlot)
+	fmt.Fprintf(&buf, "\nConfig:\n")
+	names := make([]string, 0, len(report.config))
+	for name := range report.config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&buf, "  %s = %s\n", name, report.config[name])
+	}
+	fmt.Fprintf(&buf, "\nStack:\n%s", report.crash.Stack)
+
+	if err := fileutil.WriteFileAtomic(path, buf.Bytes(), 0600); err != nil {
+		return "", errors.Wrap(err, "could not write crash report")
+	}
+	return path, nil
+}
+
+// reportCrash writes the crash report of the crashed service into the data directory
+func (o *OrchestratorNode) reportCrash(crash *shared.ServiceCrash) {
+	config := make(map[string]string)
+	for name, value := range cmd.EffectiveConfig(o.cliCtx, o.cliCtx.App.Flags) {
+		config[name] = value.Value
+	}
+	report := &crashReport{
+		time:             time.Now(),
+		version:          version.Version(),
+		crash:            crash,
+		lastVerifiedSlot: o.db.LatestSavedVerifiedSlot(),
+		config:           config,
+	}
+	dir := filepath.Join(o.cliCtx.String(cmd.DataDirFlag.Name), crashReportsDirName)
+	path, err := writeCrashReport(dir, report)
+	if err != nil {
+		log.WithError(err).Error("Could not write crash report")
+		return
+	}
+	log.WithField("path", path).Error("Wrote crash report, please attach it to the bug report")
+}
@@ -0,0 +1,47 @@
+package node
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func Test_WriteCrashReport(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), crashReportsDirName)
+	report := &crashReport{
+		time:    time.Unix(1620000000, 0),
+		version: "v0.1.0",
+		crash: &shared.ServiceCrash{
+			Service: reflect.TypeOf(&healthTestService{}),
+			Reason:  "index out of range",
+			Stack:   []byte("goroutine 1 [running]:\n"),
+		},
+		lastVerifiedSlot: 42,
+		config:           map[string]string{"verbosity": "debug", "datadir": "/data"},
+	}
+
+	path, err := writeCrashReport(dir, report)
+	require.NoError(t, err)
+	assert.Equal(t, dir, filepath.Dir(path))
+	assert.Equal(t, "crash_1620000000_node_healthTestService.txt", filepath.Base(path))
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	for _, expected := range []string{
+		"Version: v0.1.0",
+		"Service: node.healthTestService",
+		"Reason: index out of range",
+		"Last verified slot: 42",
+		"  datadir = /data\n  verbosity = debug\n",
+		"goroutine 1 [running]:",
+	} {
+		assert.Equal(t, true, strings.Contains(string(content), expected), expected)
+	}
+}
@@ -0,0 +1,176 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// time to wait between two collections of service statuses
+var serviceHealthPeriod = 5 * time.Second
+
+// serviceHealth is the latest collected state of a registered service
+type serviceHealth struct {
+	started bool
+	state   shared.ServiceState
+	err     error
+	since   time.Time // time of the latest change of the state
+}
+
+// healthMonitor periodically collects `Status() error` of every registered service and logs the services
+// whose state changes. The latest collected states back readiness check and node status of admin api.
+// Every check pings systemd watchdog and systemd is notified once all services have been started.
+type healthMonitor struct {
+	services *shared.ServiceRegistry
+	systemd  *systemdNotifier
+
+	lock     sync.RWMutex
+	checked  bool
+	states   map[string]*serviceHealth
+	restarts map[string]uint64
+}
+
+func newHealthMonitor(services *shared.ServiceRegistry, systemd *systemdNotifier) *healthMonitor {
+	return &healthMonitor{
+		services: services,
+		systemd:  systemd,
+		states:   make(map[string]*serviceHealth),
+		restarts: make(map[string]uint64),
+	}
+}
+
+// recordRestart counts a restart of the crashed service
+func (m *healthMonitor) recordRestart(name string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.restarts[name]++
+}
+
+// run collects service statuses until the context is cancelled
+func (m *healthMonitor) run(ctx context.Context) {
+	ticker := time.NewTicker(m.systemd.checkPeriod(serviceHealthPeriod))
+	defer ticker.Stop()
+
+	m.check()
+	for {
+		select {
+		case <-ticker.C:
+			m.check()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check collects the state of every registered service and logs the changes since the previous check
+func (m *healthMonitor) check() {
+	notStarted := make(map[string]bool)
+	for _, kind := range m.services.NotStarted() {
+		notStarted[kind.String()] = true
+	}
+	statuses := m.services.Statuses()
+	states := m.services.States()
+	startErrs := m.services.StartErrors()
+	now := time.Now()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for kind, err := range statuses {
+		name := kind.String()
+		if err == nil {
+			// a service which failed to start may not report the failure itself
+			err = startErrs[kind]
+		}
+		current := &serviceHealth{started: !notStarted[name], state: states[kind], err: err, since: now}
+		previous := m.states[name]
+		if previous != nil && previous.started == current.started && previous.state == current.state &&
+			errorString(previous.err) == errorString(current.err) {
+			continue
+		}
+		m.states[name] = current
+		logServiceHealth(name, previous, current)
+	}
+	m.checked = true
+
+	if len(notStarted) == 0 {
+		m.systemd.ready()
+	}
+	m.systemd.pingWatchdog()
+}
+
+// logServiceHealth logs the change of service state, nothing is logged for healthy services on the first check
+func logServiceHealth(name string, previous, current *serviceHealth) {
+	entry := log.WithField("service", name)
+	switch {
+	case current.err != nil:
+		entry.WithError(current.err).Warn("Service is unhealthy")
+	case previous != nil && previous.err != nil:
+		entry.Info("Service is healthy again")
+	case current.started && previous != nil:
+		entry.Info("Service has been started")
+	}
+}
+
+// serviceStatuses returns the latest collected state of registered services sorted by name. States are
+// collected right away when they have not been collected yet.
+func (m *healthMonitor) serviceStatuses() []*types.ServiceStatus {
+	m.lock.RLock()
+	checked := m.checked
+	m.lock.RUnlock()
+	if !checked {
+		m.check()
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	statuses := make([]*types.ServiceStatus, 0, len(m.states))
+	for name, state := range m.states {
+		status := &types.ServiceStatus{
+			Name:     name,
+			Started:  state.started,
+			State:    string(state.state),
+			Since:    uint64(state.since.Unix()),
+			Restarts: m.restarts[name],
+		}
+		if state.err != nil {
+			status.Error = state.err.Error()
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// err returns the reason why the node is not healthy, based on the latest collected service states
+func (m *healthMonitor) err() error {
+	statuses := m.serviceStatuses()
+	var notStarted []string
+	for _, status := range statuses {
+		if !status.Started {
+			notStarted = append(notStarted, status.Name)
+		}
+	}
+	if len(notStarted) > 0 {
+		return fmt.Errorf("services are not started: %v", notStarted)
+	}
+	for _, status := range statuses {
+		if status.Error != "" {
+			return fmt.Errorf("service %s is not healthy: %s", status.Name, status.Error)
+		}
+	}
+	return nil
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,66 @@
+package node
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+type healthTestService struct {
+	started chan struct{}
+	err     error
+}
+
+func (s *healthTestService) Start()        { close(s.started) }
+func (s *healthTestService) Stop() error   { return nil }
+func (s *healthTestService) Status() error { return s.err }
+
+// Test_HealthMonitor checks that service states are aggregated and their changes are logged
+func Test_HealthMonitor(t *testing.T) {
+	hook := logTest.NewGlobal()
+	registry := shared.NewServiceRegistry()
+	svc := &healthTestService{started: make(chan struct{})}
+	require.NoError(t, registry.RegisterService(svc))
+	monitor := newHealthMonitor(registry, nil)
+
+	assert.ErrorContains(t, "services are not started", monitor.err())
+	statuses := monitor.serviceStatuses()
+	require.Equal(t, 1, len(statuses))
+	assert.Equal(t, false, statuses[0].Started)
+
+	require.NoError(t, registry.StartAll())
+	<-svc.started
+	// states are only updated by the periodic check
+	assert.ErrorContains(t, "services are not started", monitor.err())
+	waitForHealthy(t, monitor)
+	require.LogsContain(t, hook, "Service has been started")
+
+	svc.err = errors.New("subscription has stopped")
+	monitor.check()
+	assert.ErrorContains(t, "subscription has stopped", monitor.err())
+	require.LogsContain(t, hook, "Service is unhealthy")
+	assert.Equal(t, "subscription has stopped", monitor.serviceStatuses()[0].Error)
+
+	svc.err = nil
+	monitor.check()
+	require.NoError(t, monitor.err())
+	require.LogsContain(t, hook, "Service is healthy again")
+	registry.StopAll()
+}
+
+// waitForHealthy checks states until the started service is reported, Start of registry returns asynchronously
+func waitForHealthy(t *testing.T, monitor *healthMonitor) {
+	for i := 0; i < 100; i++ {
+		monitor.check()
+		if monitor.err() == nil {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("service has not been reported as started")
+}
@@ -0,0 +1,81 @@
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/pkg/errors"
+)
+
+// name of the file in data directory which is locked by the running orchestrator node
+const instanceLockFileName = "orchestrator.lock"
+
+// instanceLock is held by the running orchestrator node for its data directory, so a second node on the same
+// data directory fails with a clear error instead of timing out on the lock of the database
+type instanceLock struct {
+	path string
+	file *os.File
+}
+
+// acquireInstanceLock locks the data directory and writes the pid of the process into the lock file
+func acquireInstanceLock(datadir string) (*instanceLock, error) {
+	if err := os.MkdirAll(datadir, params.OrchestratorIoConfig().ReadWriteExecutePermissions); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(datadir, instanceLockFileName)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open instance lock file")
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		if pid := readPID(path); pid != "" {
+			return nil, errors.Errorf("another orchestrator node (pid %s) is already running on data directory %s",
+				pid, datadir)
+		}
+		return nil, errors.Wrapf(err, "another orchestrator node is already running on data directory %s", datadir)
+	}
+	if err := f.Truncate(0); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &instanceLock{path: path, file: f}, nil
+}
+
+// release unlocks the data directory and removes the lock file. The file is closed first, as an open file can not
+// be removed on windows.
+func (l *instanceLock) release() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// readPID reads the pid of lock or pid file, empty string is returned when it can not be read
+func readPID(path string) string {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// writePIDFile writes the pid of the process to the pid file, a file left by a previous run is replaced
+func writePIDFile(path string) error {
+	if pid := readPID(path); pid != "" {
+		log.WithField("path", path).WithField("pid", pid).Warn("Replacing pid file of previous orchestrator node")
+	}
+	return fileutil.WriteFileAtomic(path, []byte(strconv.Itoa(os.Getpid())+"\n"), 0644)
+}
@@ -0,0 +1,13 @@
+// +build !windows
+
+package node
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile takes an exclusive lock of the file without blocking, it is released when the file is closed
+func lockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+}
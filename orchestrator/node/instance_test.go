@@ -0,0 +1,39 @@
+// +build !windows
+
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+// Test_InstanceLock checks that a second node on the same data directory is rejected with the pid of the first
+func Test_InstanceLock(t *testing.T) {
+	datadir := filepath.Join(t.TempDir(), "orchestrator")
+	lock, err := acquireInstanceLock(datadir)
+	require.NoError(t, err)
+	pid := strconv.Itoa(os.Getpid())
+	assert.Equal(t, pid, readPID(filepath.Join(datadir, instanceLockFileName)))
+
+	_, err = acquireInstanceLock(datadir)
+	assert.ErrorContains(t, "another orchestrator node (pid "+pid+") is already running", err)
+
+	require.NoError(t, lock.release())
+	lock, err = acquireInstanceLock(datadir)
+	require.NoError(t, err)
+	require.NoError(t, lock.release())
+}
+
+func Test_WritePIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orchestrator.pid")
+	require.NoError(t, writePIDFile(path))
+	assert.Equal(t, strconv.Itoa(os.Getpid()), readPID(path))
+	// pid file of a previous run is replaced
+	require.NoError(t, writePIDFile(path))
+	assert.Equal(t, strconv.Itoa(os.Getpid()), readPID(path))
+}
@@ -0,0 +1,190 @@
+package node
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/consensus"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// subfolder of the data directory which holds the data directories of additional networks
+const networksDirName = "networks"
+
+// registerNetworks creates a node for every network of the networks file. Each of them verifies its own chains
+// into its own database, while the node of the main network serves them over rpc and stops them on close.
+func (o *OrchestratorNode) registerNetworks(cliCtx *cli.Context) error {
+	path := cliCtx.String(cmd.NetworksFileFlag.Name)
+	if path == "" {
+		return nil
+	}
+	networks, err := cmd.LoadNetworks(path)
+	if err != nil {
+		return err
+	}
+	for _, network := range networks {
+		networkCtx, err := networkContext(cliCtx, network)
+		if err != nil {
+			return errors.Wrapf(err, "invalid flags of network %s", network.Name)
+		}
+		node, err := newNode(networkCtx, network.Name, o)
+		if err != nil {
+			return errors.Wrapf(err, "could not create node of network %s", network.Name)
+		}
+		o.networks = append(o.networks, node)
+		log.WithField("network", network.Name).
+			WithField("datadir", networkCtx.String(cmd.DataDirFlag.Name)).
+			WithField("vanguardGRPCUrl", networkCtx.String(cmd.VanguardGRPCEndpoint.Name)).
+			WithField("pandoraHttpUrl", networkCtx.String(cmd.PandoraRPCEndpoint.Name)).
+			Info("Registered network")
+	}
+	return nil
+}
+
+// networkContext returns the flags of an additional network. Flags which the network sets and flags which are
+// never taken over from the main network are defined in a flag set of the network, every other flag is looked up
+// in the context of the main network. The data directory of the network is a subfolder of the main one.
+func networkContext(parent *cli.Context, network *cmd.Network) (*cli.Context, error) {
+	set := flag.NewFlagSet(network.Name, flag.ContinueOnError)
+	defined := make(map[string]bool)
+	define := func(f cli.Flag) error {
+		name := f.Names()[0]
+		if defined[name] {
+			return nil
+		}
+		defined[name] = true
+		detached, err := detachedFlag(f)
+		if err != nil {
+			return err
+		}
+		return detached.Apply(set)
+	}
+
+	// pid file and high availability lease belong to the process, which the main network runs
+	reset := append([]cli.Flag{cmd.DataDirFlag, cmd.PIDFileFlag, cmd.HALeaseFileFlag}, cmd.NetworkOwnFlags...)
+	for _, f := range reset {
+		if err := define(f); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range cmd.NetworkFlags {
+		values, ok := network.Flags[f.Names()[0]]
+		if !ok {
+			continue
+		}
+		if err := define(f); err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			if err := set.Set(f.Names()[0], value); err != nil {
+				return nil, errors.Wrapf(err, "invalid value %q of %s", value, f.Names()[0])
+			}
+		}
+	}
+	dataDir := filepath.Join(parent.String(cmd.DataDirFlag.Name), networksDirName, network.Name)
+	if err := set.Set(cmd.DataDirFlag.Name, dataDir); err != nil {
+		return nil, err
+	}
+	return cli.NewContext(parent.App, set, parent), nil
+}
+
+// detachedFlag returns a copy of the flag which ignores environment variables of the main network and does not
+// share its value with the flag set of the main network
+func detachedFlag(f cli.Flag) (cli.Flag, error) {
+	switch t := f.(type) {
+	case *cli.StringFlag:
+		c := *t
+		c.EnvVars, c.Destination = nil, nil
+		return &c, nil
+	case *cli.StringSliceFlag:
+		c := *t
+		c.EnvVars, c.Destination, c.Value = nil, nil, nil
+		return &c, nil
+	case *cli.IntFlag:
+		c := *t
+		c.EnvVars, c.Destination = nil, nil
+		return &c, nil
+	case *cli.Uint64Flag:
+		c := *t
+		c.EnvVars, c.Destination = nil, nil
+		return &c, nil
+	case *cli.DurationFlag:
+		c := *t
+		c.EnvVars, c.Destination = nil, nil
+		return &c, nil
+	default:
+		return nil, fmt.Errorf("flag %s of type %T can not be set for network", f.Names()[0], f)
+	}
+}
+
+// networkRPCConfigs returns the feeds and databases of additional networks which rpc service serves in the
+// namespaces of the networks
+func (o *OrchestratorNode) networkRPCConfigs() ([]*rpc.NetworkConfig, error) {
+	configs := make([]*rpc.NetworkConfig, 0, len(o.networks))
+	for _, network := range o.networks {
+		var consensusSvc *consensus.Service
+		if err := network.services.FetchService(&consensusSvc); err != nil {
+			return nil, err
+		}
+		configs = append(configs, &rpc.NetworkConfig{
+			Namespace:                    network.network,
+			ConsensusInfoFeed:            network.vanguardRelay,
+			VerifiedSlotInfoFeed:         consensusSvc,
+			ProgressReporter:             consensusSvc,
+			Reverifier:                   consensusSvc,
+			ProposerProvider:             network.vanguardRelay,
+			ConfirmationDepth:            network.cliCtx.Uint64(cmd.ConfirmationDepthFlag.Name),
+			Db:                           network.db,
+			VanguardPendingShardingCache: network.vanShardInfoCache,
+			PandoraPendingHeaderCache:    network.pandoraInfoCache,
+		})
+	}
+	return configs, nil
+}
+
+// startNetwork starts the services of an additional network, which is stopped by the node of the main network
+func (o *OrchestratorNode) startNetwork() error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.logger().Info("Starting network")
+	if err := o.services.StartAll(); err != nil {
+		return errors.Wrapf(err, "could not start services of network %s", o.network)
+	}
+	go o.health.run(o.ctx)
+	go o.newSupervisor().run(o.ctx)
+	go o.runStatusSummary(o.cliCtx.Duration(cmd.StatusLogPeriodFlag.Name))
+	return nil
+}
+
+// networksReady reports whether every additional network is healthy and its verification does not lag behind its
+// vanguard head more than maxLag slots
+func (o *OrchestratorNode) networksReady(maxLag uint64) error {
+	for _, network := range o.networks {
+		if err := network.health.err(); err != nil {
+			return errors.Wrapf(err, "network %s", network.network)
+		}
+		var consensusSvc *consensus.Service
+		if err := network.services.FetchService(&consensusSvc); err != nil {
+			return errors.Wrapf(err, "network %s", network.network)
+		}
+		if lag := consensusSvc.VerificationProgress().VerificationLag; lag > maxLag {
+			return fmt.Errorf("verification of network %s lags %d slots behind vanguard head, maximum %d slots",
+				network.network, lag, maxLag)
+		}
+	}
+	return nil
+}
+
+// logger returns the logger of the node, which names the network of an additional network
+func (o *OrchestratorNode) logger() *logrus.Entry {
+	if o.network == "" {
+		return log
+	}
+	return log.WithField("network", o.network)
+}
@@ -0,0 +1,59 @@
+package node
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestNetworkContext(t *testing.T) {
+	dataDir := t.TempDir()
+	set := newTestFlagSet(t, dataDir)
+	for _, f := range []cli.Flag{
+		cmd.VanguardGRPCEndpoint,
+		cmd.PandoraChainID,
+		cmd.ConfirmationDepthFlag,
+		cmd.AuditLogFlag,
+		cmd.PIDFileFlag,
+	} {
+		require.NoError(t, f.Apply(set))
+	}
+	require.NoError(t, set.Set(cmd.ConfirmationDepthFlag.Name, "12"))
+	require.NoError(t, set.Set(cmd.AuditLogFlag.Name, "/var/log/orchestrator/audit.log"))
+	require.NoError(t, set.Set(cmd.PIDFileFlag.Name, "/run/orchestrator.pid"))
+	require.NoError(t, set.Set(cmd.PandoraChainID.Name, "4004181"))
+	parent := cli.NewContext(&cli.App{}, set, nil)
+
+	networkCtx, err := networkContext(parent, &cmd.Network{
+		Name: "testnet",
+		Flags: map[string][]string{
+			cmd.VanguardGRPCEndpoint.Name: {"127.0.0.1:4001"},
+			cmd.PandoraChainID.Name:       {"4004182"},
+			cmd.AlertWebhookFlag.Name:     {"http://hooks.example/1", "http://hooks.example/2"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(dataDir, "networks", "testnet"), networkCtx.String(cmd.DataDirFlag.Name))
+	assert.Equal(t, "127.0.0.1:4001", networkCtx.String(cmd.VanguardGRPCEndpoint.Name))
+	assert.Equal(t, uint64(4004182), networkCtx.Uint64(cmd.PandoraChainID.Name))
+	assert.DeepEqual(t, []string{"http://hooks.example/1", "http://hooks.example/2"},
+		networkCtx.StringSlice(cmd.AlertWebhookFlag.Name))
+	// flags which the network does not set are taken over from the main network, except for its own files
+	assert.Equal(t, uint64(12), networkCtx.Uint64(cmd.ConfirmationDepthFlag.Name))
+	assert.Equal(t, "", networkCtx.String(cmd.AuditLogFlag.Name))
+	assert.Equal(t, "", networkCtx.String(cmd.PIDFileFlag.Name))
+	// main network keeps its values
+	assert.Equal(t, uint64(4004181), parent.Uint64(cmd.PandoraChainID.Name))
+	assert.Equal(t, dataDir, parent.String(cmd.DataDirFlag.Name))
+
+	_, err = networkContext(parent, &cmd.Network{
+		Name:  "devnet",
+		Flags: map[string][]string{cmd.PandoraChainID.Name: {"devnet"}},
+	})
+	assert.ErrorContains(t, "invalid value", err)
+}
@@ -2,18 +2,31 @@ package node
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common/math"
 	ethRpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/alert"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/audit"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/consensus"
+	conIface "github.com/lukso-network/lukso-orchestrator/orchestrator/consensus/iface"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/kv"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/ha"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/monitoring"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/pandorachain"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/snapshot"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain"
 	"github.com/lukso-network/lukso-orchestrator/shared"
 	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
 	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/secrets"
+	"github.com/lukso-network/lukso-orchestrator/shared/sentry"
+	"github.com/lukso-network/lukso-orchestrator/shared/tracing"
 	"github.com/lukso-network/lukso-orchestrator/shared/version"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -21,6 +34,8 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"sync"
 	"syscall"
 )
@@ -34,8 +49,36 @@ type OrchestratorNode struct {
 
 	// service storage
 	services *shared.ServiceRegistry
+	health   *healthMonitor
+	systemd  *systemdNotifier
 	lock     sync.RWMutex
 	stop     chan struct{} // Channel to wait for termination notifications.
+	failure  error         // reason of stopping the node after a service failure, returned by Start
+
+	// cleanup tasks run on close
+	shutdownHooks []ShutdownHook
+	hooksLock     sync.Mutex
+
+	// chain services as seen by consensus and rpc services, they follow restarted chain services
+	vanguardRelay *vanguardRelay
+	pandoraRelay  *pandoraRelay
+	restartLock   sync.Mutex  // serializes restarts of chain services
+	vanguardTLS   *tls.Config // tls config of vanguard connection, nil for an insecure connection
+
+	// leader election of a high availability pair, nil when the node runs alone
+	elector *ha.Elector
+	// leadership of a follower which an operator promotes, nil unless the node is started as follower
+	standby *ha.Standby
+
+	// name of an additional network and the node which verifies the main network, empty and nil for the main
+	// network itself, which holds the nodes of additional networks
+	network  string
+	parent   *OrchestratorNode
+	networks []*OrchestratorNode
+
+	// lock of data directory and pid file of the running node
+	instanceLock *instanceLock
+	pidFile      string
 
 	//kv database with cache
 	db db.Database
@@ -48,19 +91,49 @@ type OrchestratorNode struct {
 // New creates a new node instance, sets up configuration options, and registers
 // every required service to the node.
 func New(cliCtx *cli.Context) (*OrchestratorNode, error) {
+	return newNode(cliCtx, "", nil)
+}
+
+// newNode creates the node of the main network when parent is nil, otherwise the node of an additional network
+// which leaves process wide services, systemd notifications and rpc servers to the node of the main network
+func newNode(cliCtx *cli.Context, network string, parent *OrchestratorNode) (*OrchestratorNode, error) {
 	registry := shared.NewServiceRegistry()
+	var systemd *systemdNotifier
+	if parent == nil {
+		systemd = newSystemdNotifier()
+	}
 	ctx, cancel := context.WithCancel(cliCtx.Context)
 
 	orchestrator := &OrchestratorNode{
 		cliCtx:            cliCtx,
+		network:           network,
+		parent:            parent,
 		ctx:               ctx,
 		cancel:            cancel,
 		services:          registry,
+		health:            newHealthMonitor(registry, systemd),
+		systemd:           systemd,
 		stop:              make(chan struct{}),
 		pandoraInfoCache:  cache.NewPanHeaderCache(),
 		vanShardInfoCache: cache.NewVanShardInfoCache(math.MaxInt32),
 	}
 
+	lock, err := acquireInstanceLock(cliCtx.String(cmd.DataDirFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	orchestrator.instanceLock = lock
+	if pidFile := cliCtx.String(cmd.PIDFileFlag.Name); pidFile != "" {
+		if err := writePIDFile(pidFile); err != nil {
+			return nil, errors.Wrap(err, "could not write pid file")
+		}
+		orchestrator.pidFile = pidFile
+	}
+
+	if err := orchestrator.syncSnapshot(cliCtx); err != nil {
+		return nil, err
+	}
+
 	if err := orchestrator.startDB(orchestrator.cliCtx); err != nil {
 		return nil, err
 	}
@@ -77,6 +150,24 @@ func New(cliCtx *cli.Context) (*OrchestratorNode, error) {
 		return nil, err
 	}
 
+	if parent == nil {
+		if err := orchestrator.registerMetricsService(cliCtx); err != nil {
+			return nil, err
+		}
+
+		if err := orchestrator.registerTracingService(cliCtx); err != nil {
+			return nil, err
+		}
+
+		if err := orchestrator.registerErrorReporter(cliCtx); err != nil {
+			return nil, err
+		}
+
+		if err := orchestrator.registerElectorService(cliCtx); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := orchestrator.registerVanguardChainService(cliCtx); err != nil {
 		return nil, err
 	}
@@ -89,6 +180,18 @@ func New(cliCtx *cli.Context) (*OrchestratorNode, error) {
 		return nil, err
 	}
 
+	if err := orchestrator.registerAuditService(cliCtx); err != nil {
+		return nil, err
+	}
+
+	if parent != nil {
+		return orchestrator, nil
+	}
+
+	if err := orchestrator.registerNetworks(cliCtx); err != nil {
+		return nil, err
+	}
+
 	if err := orchestrator.registerRPCService(cliCtx); err != nil {
 		return nil, err
 	}
@@ -96,6 +199,52 @@ func New(cliCtx *cli.Context) (*OrchestratorNode, error) {
 	return orchestrator, nil
 }
 
+// syncSnapshot downloads the verification state of a trusted orchestrator when the node has no database yet.
+// The state is downloaded into a temporary database which replaces the database only once it is complete, so an
+// interrupted sync is started again on the next start.
+func (o *OrchestratorNode) syncSnapshot(cliCtx *cli.Context) error {
+	endpoint := cliCtx.String(cmd.SyncFromFlag.Name)
+	if endpoint == "" {
+		return nil
+	}
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.OrchestratorNodeDbDirName)
+	if fileutil.FileExists(filepath.Join(dbPath, kv.DatabaseFileName)) {
+		log.WithField("database-path", dbPath).Info("Database exists, skipping snapshot sync")
+		return nil
+	}
+	apiKey, err := secrets.Resolve(cliCtx.String(cmd.SyncAPIKeyFlag.Name))
+	if err != nil {
+		return errors.Wrap(err, "could not load api key of snapshot sync")
+	}
+	remote, err := snapshot.Dial(o.ctx, endpoint, apiKey)
+	if err != nil {
+		return errors.Wrap(err, "could not connect to trusted orchestrator")
+	}
+	defer remote.Close()
+
+	syncPath := dbPath + ".sync"
+	if err := os.RemoveAll(syncPath); err != nil {
+		return errors.Wrap(err, "could not remove database of interrupted snapshot sync")
+	}
+	d, err := db.NewDB(o.ctx, syncPath, &kv.Config{
+		InitialMMapSize: cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = snapshot.Sync(o.ctx, remote, d)
+	if closeErr := d.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrapf(err, "could not sync from trusted orchestrator %s", endpoint)
+	}
+	if err := kv.RestoreBackup(dbPath, filepath.Join(syncPath, kv.DatabaseFileName)); err != nil {
+		return err
+	}
+	return os.RemoveAll(syncPath)
+}
+
 // startDB initialize KV db and cache
 func (o *OrchestratorNode) startDB(cliCtx *cli.Context) error {
 	baseDir := cliCtx.String(cmd.DataDirFlag.Name)
@@ -117,7 +266,7 @@ func (o *OrchestratorNode) startDB(cliCtx *cli.Context) error {
 		actionText := "This will delete your orchestrator database stored in your data directory. " +
 			"Your database backups will not be removed - do you want to proceed? (Y/N)"
 		deniedText := "Database will not be deleted. No changes have been made."
-		clearDBConfirmed, err = cmd.ConfirmAction(actionText, deniedText)
+		clearDBConfirmed, err = cmd.ConfirmAction(cliCtx, actionText, deniedText)
 		if err != nil {
 			return err
 		}
@@ -138,30 +287,188 @@ func (o *OrchestratorNode) startDB(cliCtx *cli.Context) error {
 			return errors.Wrap(err, "could not create new database")
 		}
 	}
+	network, err := checkChainConfig(d, params.ActiveNetworkConfig())
+	if err == nil && o.parent != nil {
+		err = checkNetworkChainConfig(o.network, network)
+	}
+	if err != nil {
+		if closeErr := d.Close(); closeErr != nil {
+			log.WithError(closeErr).Error("Failed to close database")
+		}
+		return err
+	}
+	// only the main network selects the config which the process runs on
+	if o.parent == nil {
+		params.UseNetworkConfig(network)
+	}
 
 	o.db = d
 	return nil
 }
 
+// registerMetricsService registers the server of prometheus metrics when metrics or profiling are enabled
+func (o *OrchestratorNode) registerMetricsService(cliCtx *cli.Context) error {
+	pprofEnabled := cliCtx.Bool(cmd.PprofFlag.Name)
+	if !cliCtx.Bool(cmd.MetricsEnabledFlag.Name) && !pprofEnabled {
+		return nil
+	}
+	addr := fmt.Sprintf("%s:%d", cliCtx.String(cmd.MetricsHostFlag.Name), cliCtx.Int(cmd.MetricsPortFlag.Name))
+	svc := monitoring.NewService(o.ctx, addr, metrics.DefaultRegistry())
+	if pprofEnabled {
+		svc.EnableProfiling()
+	}
+	log.WithField("address", addr).Info("Registered metrics service")
+	return o.services.RegisterService(svc)
+}
+
+// registerTracingService registers the exporter of traces when a tracing endpoint is given. Tracing is enabled
+// before chain services are registered, so every slot they receive is traced.
+func (o *OrchestratorNode) registerTracingService(cliCtx *cli.Context) error {
+	endpoint := cliCtx.String(cmd.TracingEndpointFlag.Name)
+	if endpoint == "" {
+		return nil
+	}
+	exporter, err := tracing.NewExporter(o.ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	tracing.Enable(exporter)
+	log.WithField("endpoint", cmd.RedactValue(cmd.TracingEndpointFlag.Name, exporter.Endpoint())).
+		Info("Registered tracing service")
+	return o.services.RegisterService(exporter)
+}
+
+// registerElectorService registers leader election when a lease file is given, or the standby of a follower. Both orchestrators of a high
+// availability pair verify into their own database, so the standby takes over with current state, but only the
+// leader serves event subscriptions and reports ready. The elector is stopped after rpc service, so the lease is
+// released once subscriptions have ended.
+func (o *OrchestratorNode) registerElectorService(cliCtx *cli.Context) error {
+	leaseFile := cliCtx.String(cmd.HALeaseFileFlag.Name)
+	if cliCtx.Bool(cmd.FollowerFlag.Name) {
+		if leaseFile != "" {
+			return errors.Errorf("--%s can not be combined with --%s, the elector promotes the standby of a "+
+				"high availability pair", cmd.FollowerFlag.Name, cmd.HALeaseFileFlag.Name)
+		}
+		o.standby = ha.NewStandby()
+		log.Info("Started as follower, serving authoritative feeds once promoted by admin_promote")
+		return nil
+	}
+	if leaseFile == "" {
+		return nil
+	}
+	nodeID := cliCtx.String(cmd.HANodeIDFlag.Name)
+	if nodeID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return errors.Wrap(err, "could not get host name as node id of high availability mode")
+		}
+		nodeID = hostname
+	}
+	elector, err := ha.NewElector(o.ctx, &ha.Config{
+		LeaseFile:     leaseFile,
+		NodeID:        nodeID,
+		LeaseDuration: cliCtx.Duration(cmd.HALeaseDurationFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	o.elector = elector
+	log.WithField("nodeId", nodeID).WithField("leaseFile", leaseFile).Info("Registered leader election service")
+	return o.services.RegisterService(elector)
+}
+
+// registerErrorReporter registers the reporter of crashes and repeated errors when a sentry dsn is given. The
+// reporter receives errors of every logger of the node, so it is hooked into logrus as soon as it is created.
+func (o *OrchestratorNode) registerErrorReporter(cliCtx *cli.Context) error {
+	dsn, err := secrets.Resolve(cliCtx.String(cmd.SentryDSNFlag.Name))
+	if err != nil {
+		return errors.Wrap(err, "could not load sentry dsn")
+	}
+	if dsn == "" {
+		return nil
+	}
+	network := params.ActiveNetworkConfig().Name
+	if network == "" {
+		network = "custom"
+	}
+	reporter, err := sentry.NewReporter(o.ctx, &sentry.Config{
+		DSN:     dsn,
+		Release: sentry.ClientName + "@" + version.SemanticVersion(),
+		Tags: map[string]string{
+			"version": version.SemanticVersion(),
+			"commit":  version.GitCommit(),
+			"network": network,
+			"go":      runtime.Version(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	sentry.Enable(reporter)
+	logrus.AddHook(reporter)
+	log.WithField("network", network).Info("Registered error reporter")
+	return o.services.RegisterService(reporter)
+}
+
 // registerVanguardChainService
 func (o *OrchestratorNode) registerVanguardChainService(cliCtx *cli.Context) error {
-	vanguardGRPCUrl := cliCtx.String(cmd.VanguardGRPCEndpoint.Name)
+	buffer, err := relayBufferConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	o.vanguardTLS, err = vanguardchain.LoadTLSConfig(
+		cliCtx.String(cmd.VanguardTLSCACertFlag.Name),
+		cliCtx.String(cmd.VanguardTLSClientCertFlag.Name),
+		cliCtx.String(cmd.VanguardTLSClientKeyFlag.Name),
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not load tls config of vanguard connection")
+	}
+	if o.vanguardTLS != nil && len(o.vanguardTLS.Certificates) > 0 {
+		log.WithField("clientCert", cliCtx.String(cmd.VanguardTLSClientCertFlag.Name)).
+			Info("Presenting tls client certificate to vanguard node")
+	}
+	svc, err := o.newVanguardChainService(cliCtx.String(cmd.VanguardGRPCEndpoint.Name))
+	if err != nil {
+		return err
+	}
+	o.vanguardRelay = newVanguardRelay(o.network, svc, buffer)
+	return o.services.RegisterService(svc)
+}
+
+// newVanguardChainService creates vanguard chain service which connects to the given endpoint
+func (o *OrchestratorNode) newVanguardChainService(vanguardGRPCUrl string) (*vanguardchain.Service, error) {
 	svc, err := vanguardchain.NewService(
 		o.ctx,
+		o.network,
 		vanguardGRPCUrl,
+		o.vanguardTLS,
 		o.db,
 		o.vanShardInfoCache,
 	)
 	if err != nil {
-		return nil
+		return nil, err
 	}
 	log.WithField("vanguardGRPCUrl", vanguardGRPCUrl).Info("Registered vanguard chain service")
-	return o.services.RegisterService(svc)
+	return svc, nil
 }
 
 // registerPandoraChainService
 func (o *OrchestratorNode) registerPandoraChainService(cliCtx *cli.Context) error {
-	pandoraRPCUrl := cliCtx.String(cmd.PandoraRPCEndpoint.Name)
+	buffer, err := relayBufferConfig(cliCtx)
+	if err != nil {
+		return err
+	}
+	svc, err := o.newPandoraChainService(cliCtx.String(cmd.PandoraRPCEndpoint.Name))
+	if err != nil {
+		return err
+	}
+	o.pandoraRelay = newPandoraRelay(o.network, svc, buffer)
+	return o.services.RegisterService(svc)
+}
+
+// newPandoraChainService creates pandora chain service which connects to the given endpoint
+func (o *OrchestratorNode) newPandoraChainService(pandoraRPCUrl string) (*pandorachain.Service, error) {
 	dialRPCClient := func(endpoint string) (*ethRpc.Client, error) {
 		rpcClient, err := ethRpc.Dial(endpoint)
 		if err != nil {
@@ -169,13 +476,16 @@ func (o *OrchestratorNode) registerPandoraChainService(cliCtx *cli.Context) erro
 		}
 		return rpcClient, nil
 	}
-	namespace := "eth"
-	svc, err := pandorachain.NewService(o.ctx, pandoraRPCUrl, namespace, o.db, o.pandoraInfoCache, dialRPCClient)
+	namespace := o.cliCtx.String(cmd.PandoraRPCNamespace.Name)
+	subMethod := o.cliCtx.String(cmd.PandoraSubscriptionMethod.Name)
+	chainID := o.cliCtx.Uint64(cmd.PandoraChainID.Name)
+	svc, err := pandorachain.NewService(o.ctx, o.network, pandoraRPCUrl, namespace, subMethod, chainID, o.db, o.pandoraInfoCache, dialRPCClient)
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	log.WithField("pandoraHttpUrl", pandoraRPCUrl).Info("Registered pandora chain service")
-	return o.services.RegisterService(svc)
+	log.WithField("pandoraHttpUrl", pandoraRPCUrl).WithField("namespace", namespace).
+		WithField("subscriptionMethod", subMethod).Info("Registered pandora chain service")
+	return svc, nil
 }
 
 // registerConsensusService
@@ -190,43 +500,103 @@ func (o *OrchestratorNode) registerConsensusService(cliCtx *cli.Context) error {
 		return err
 	}
 
+	alertSinks, err := o.alertSinks(cliCtx)
+	if err != nil {
+		return err
+	}
 	svc := consensus.New(o.ctx, &consensus.Config{
 		VerifiedSlotInfoDB:           o.db,
 		InvalidSlotInfoDB:            o.db,
 		VanguardPendingShardingCache: o.vanShardInfoCache,
 		PandoraPendingHeaderCache:    o.pandoraInfoCache,
-		VanguardShardFeed:            vanguardShardFeed,
-		PandoraHeaderFeed:            pandoraHeaderFeed,
+		VanguardShardFeed:            o.vanguardRelay,
+		PandoraHeaderFeed:            o.pandoraRelay,
+		PendingTimeout:               cliCtx.Duration(cmd.PendingTimeoutFlag.Name),
+		VerificationWorkers:          cliCtx.Int(cmd.VerificationWorkersFlag.Name),
+		VerificationBatchSize:        cliCtx.Int(cmd.VerificationBatchSizeFlag.Name),
+		ConfirmationDepth:            cliCtx.Uint64(cmd.ConfirmationDepthFlag.Name),
+		OrphanMaxAge:                 cliCtx.Duration(cmd.OrphanMaxAgeFlag.Name),
+		DivergenceWarnSlots:          cliCtx.Uint64(cmd.DivergenceWarnSlotsFlag.Name),
+		DivergenceAlertSlots:         cliCtx.Uint64(cmd.DivergenceAlertSlotsFlag.Name),
+		ClockSkewThreshold:           cliCtx.Duration(cmd.ClockSkewThresholdFlag.Name),
+		AlertSinks:                   alertSinks,
+		ProposerProvider:             o.vanguardRelay,
+		Leadership:                   o.leadership(),
+		Network:                      o.network,
 	})
 
 	log.Info("Registered consensus service")
-	return o.services.RegisterService(svc)
+	// consensus service is started once vanguard and pandora chain services are connected. It receives their
+	// events through relays, so it keeps running when one of them is restarted.
+	return o.services.RegisterService(svc, vanguardShardFeed, pandoraHeaderFeed)
 }
 
-// register RPC server
-func (o *OrchestratorNode) registerRPCService(cliCtx *cli.Context) error {
-	var consensusInfoFeed *vanguardchain.Service
-	if err := o.services.FetchService(&consensusInfoFeed); err != nil {
+// alertSinks creates alert sinks of consensus pipeline from the configured webhooks. Webhook urls often carry
+// tokens, so they may be referenced as env:NAME or file:PATH secrets, and only the references are logged.
+func (o *OrchestratorNode) alertSinks(cliCtx *cli.Context) ([]conIface.AlertSink, error) {
+	webhooks := cliCtx.StringSlice(cmd.AlertWebhookFlag.Name)
+	sinks := make([]conIface.AlertSink, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		endpoint, err := secrets.Resolve(webhook)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not load alert webhook")
+		}
+		log.WithField("webhook", cmd.RedactValue(cmd.AlertWebhookFlag.Name, webhook)).Info("Registered alert webhook")
+		sinks = append(sinks, alert.NewWebhookSink(o.ctx, endpoint))
+	}
+	return sinks, nil
+}
+
+// registerAuditService registers the writer of audit log when an audit log file is given. It does not depend on
+// other services, so it is started and subscribed before consensus service makes any decision.
+func (o *OrchestratorNode) registerAuditService(cliCtx *cli.Context) error {
+	path := cliCtx.String(cmd.AuditLogFlag.Name)
+	if path == "" {
+		return nil
+	}
+	var consensusSvc *consensus.Service
+	if err := o.services.FetchService(&consensusSvc); err != nil {
+		return err
+	}
+	svc, err := audit.NewService(o.ctx, &audit.Config{
+		Path:                   path,
+		Perm:                   params.OrchestratorIoConfig().ReadWritePermissions,
+		MaxSize:                int64(cliCtx.Int(cmd.AuditLogMaxSizeFlag.Name)) * 1024 * 1024,
+		MaxBackups:             cliCtx.Int(cmd.AuditLogMaxBackupsFlag.Name),
+		ConsensusInfoFeed:      o.vanguardRelay,
+		VerificationResultFeed: consensusSvc,
+		ReorgFeed:              o.vanguardRelay,
+		Network:                o.network,
+	})
+	if err != nil {
 		return err
 	}
+	log.WithField("path", path).Info("Registered audit service")
+	return o.services.RegisterService(svc)
+}
 
+// register RPC server
+func (o *OrchestratorNode) registerRPCService(cliCtx *cli.Context) error {
 	var verifiedSlotInfoFeed *consensus.Service
 	if err := o.services.FetchService(&verifiedSlotInfoFeed); err != nil {
 		return err
 	}
 
-	var ipcapiURL string
-	if cliCtx.String(cmd.IPCPathFlag.Name) != "" {
-		ipcFilePath := cliCtx.String(cmd.IPCPathFlag.Name)
-		ipcapiURL = fileutil.IpcEndpoint(filepath.Join(ipcFilePath, cmd.DefaultIpcPath), "")
-
-		log.WithField("ipcFilePath", ipcFilePath).WithField(
-			"ipcPath", ipcapiURL).Info("ipc file path")
+	ipcapiURL := cmd.IpcEndpoint(cliCtx)
+	ipcPermissions, err := cmd.IpcPermissions(cliCtx)
+	if err != nil {
+		return err
+	}
+	if ipcapiURL != "" {
+		log.WithField("ipcPath", ipcapiURL).WithField(
+			"permissions", fmt.Sprintf("%#o", ipcPermissions)).Info("ipc file path")
 	}
 
 	httpEnable := cliCtx.Bool(cmd.HTTPEnabledFlag.Name)
 	httpListenAddr := cliCtx.String(cmd.HTTPListenAddrFlag.Name)
 	httpPort := cliCtx.Int(cmd.HTTPPortFlag.Name)
+	httpCors := splitAndTrim(cliCtx.String(cmd.HTTPCORSDomainsFlag.Name))
+	httpVirtualHosts := splitAndTrim(cliCtx.String(cmd.HTTPVirtualHostsFlag.Name))
 	wsEnable := cliCtx.Bool(cmd.WSEnabledFlag.Name)
 	wsListenerAddr := cliCtx.String(cmd.WSListenAddrFlag.Name)
 	wsPort := cliCtx.Int(cmd.WSPortFlag.Name)
@@ -235,38 +605,210 @@ func (o *OrchestratorNode) registerRPCService(cliCtx *cli.Context) error {
 		"httpPort", httpPort).WithField("wsEnable", wsEnable).WithField(
 		"wsListenerAddr", wsListenerAddr).WithField("wsPort", wsPort).Debug("rpc server configuration")
 
+	var jwtSecret []byte
+	if jwtSecretPath := cliCtx.String(cmd.RPCJWTSecretFlag.Name); jwtSecretPath != "" {
+		secret, err := rpc.ReadJWTSecret(jwtSecretPath)
+		if err != nil {
+			return err
+		}
+		jwtSecret = secret
+		log.WithField("jwtSecretPath", jwtSecretPath).Info("Enabled jwt authentication of http and ws rpc")
+	}
+	var apiKeys []rpc.APIKey
+	if apiKeysPath := cliCtx.String(cmd.RPCAPIKeysFileFlag.Name); apiKeysPath != "" {
+		keys, err := rpc.ReadAPIKeys(apiKeysPath)
+		if err != nil {
+			return err
+		}
+		apiKeys = keys
+		log.WithField("apiKeysPath", apiKeysPath).WithField("keys", len(apiKeys)).
+			Info("Enabled api key authentication of http rpc")
+	}
+	adminRPC := cliCtx.Bool(cmd.RPCAdminFlag.Name)
+	if adminRPC && len(jwtSecret) == 0 {
+		return errors.Errorf("--%s requires --%s, admin methods must not be exposed without authentication",
+			cmd.RPCAdminFlag.Name, cmd.RPCJWTSecretFlag.Name)
+	}
+
+	rpcLogTransports := splitAndTrim(cliCtx.String(cmd.RPCLogFlag.Name))
+	rpcLogVerbosity := cliCtx.String(cmd.RPCLogVerbosityFlag.Name)
+	requestLog, err := rpc.ParseRequestLogConfig(rpcLogTransports, rpcLogVerbosity)
+	if err != nil {
+		return err
+	}
+	if len(rpcLogTransports) > 0 {
+		log.WithField("transports", rpcLogTransports).WithField("verbosity", rpcLogVerbosity).
+			Info("Enabled rpc request logging")
+	}
+
+	tlsCert, tlsKey := cliCtx.String(cmd.RPCTLSCertFlag.Name), cliCtx.String(cmd.RPCTLSKeyFlag.Name)
+	tlsConfig, err := rpc.LoadTLSConfig(tlsCert, tlsKey)
+	if err != nil {
+		return err
+	}
+	if tlsConfig != nil {
+		log.WithField("tlsCert", tlsCert).Info("Enabled tls termination of http and ws rpc")
+	}
+
+	subscriptionBuffer, err := cmd.EventBufferConfig(cliCtx, cmd.RPCSubscriptionBufferSizeFlag.Name,
+		cmd.RPCSubscriptionBufferPolicyFlag.Name)
+	if err != nil {
+		return err
+	}
+
+	networkConfigs, err := o.networkRPCConfigs()
+	if err != nil {
+		return err
+	}
+
 	svc, err := rpc.NewService(o.ctx, &rpc.Config{
-		ConsensusInfoFeed: consensusInfoFeed,
+		ConsensusInfoFeed: o.vanguardRelay,
 		Db:                o.db,
 		IPCPath:           ipcapiURL,
+		IPCPermissions:    ipcPermissions,
+		IPCGroup:          cliCtx.String(cmd.IPCGroupFlag.Name),
+		SocketActivation:  cliCtx.Bool(cmd.RPCSocketActivationFlag.Name),
 		HTTPEnable:        httpEnable,
 		HTTPHost:          httpListenAddr,
 		HTTPPort:          httpPort,
+		HTTPCors:          httpCors,
+		HTTPVirtualHosts:  httpVirtualHosts,
+		HTTPCompression:   cliCtx.Bool(cmd.HTTPCompressionFlag.Name),
+		GraphQLEnable:     cliCtx.Bool(cmd.GraphQLEnabledFlag.Name),
+		DashboardEnable:   cliCtx.Bool(cmd.DashboardEnabledFlag.Name),
 		WSEnable:          wsEnable,
 		WSHost:            wsListenerAddr,
 		WSPort:            wsPort,
+		WSCompression:     cliCtx.Bool(cmd.WSCompressionFlag.Name),
 
 		VanguardPendingShardingCache: o.vanShardInfoCache,
 		PandoraPendingHeaderCache:    o.pandoraInfoCache,
 		VerifiedSlotInfoFeed:         verifiedSlotInfoFeed,
+		ProgressReporter:             verifiedSlotInfoFeed,
+		Reverifier:                   verifiedSlotInfoFeed,
+		ProposerProvider:             o.vanguardRelay,
+		ConfirmationDepth:            cliCtx.Uint64(cmd.ConfirmationDepthFlag.Name),
+		JWTSecret:                    jwtSecret,
+		APIKeys:                      apiKeys,
+		AllowUnprotected:             cliCtx.Bool(cmd.RPCAllowUnprotectedFlag.Name),
+		AdminRPCEnabled:              adminRPC,
+		TLSConfig:                    tlsConfig,
+		RateLimit: rpc.RateLimitConfig{
+			RequestsPerSecond:          cliCtx.Float64(cmd.RPCRateLimitFlag.Name),
+			Burst:                      cliCtx.Int(cmd.RPCRateLimitBurstFlag.Name),
+			ExpensiveRequestsPerSecond: cliCtx.Float64(cmd.RPCExpensiveRateLimitFlag.Name),
+			ExpensiveBurst:             cliCtx.Int(cmd.RPCExpensiveRateLimitBurstFlag.Name),
+		},
+		NodeStatusProvider:   o.nodeStatus,
+		ServiceRestarter:     o.RestartService,
+		Promoter:             o.promote,
+		ShutdownTimeout:      cliCtx.Duration(cmd.RPCShutdownTimeoutFlag.Name),
+		SlowRequestThreshold: cliCtx.Duration(cmd.RPCSlowThresholdFlag.Name),
+		WSMaxConnections:     cliCtx.Int(cmd.WSMaxConnectionsFlag.Name),
+		WSMaxSubscriptions:   cliCtx.Int(cmd.WSMaxSubscriptionsFlag.Name),
+		WSIdleTimeout:        cliCtx.Duration(cmd.WSIdleTimeoutFlag.Name),
+		RequestLog:           requestLog,
+		MaxPageSize:          cliCtx.Int(cmd.RPCMaxPageSizeFlag.Name),
+		SubscriptionBuffer:   subscriptionBuffer,
+		Networks:             networkConfigs,
+		Leadership:           o.leadership(),
+		ReadinessCheck:       o.readinessCheck(cliCtx.Uint64(cmd.ReadinessMaxLagFlag.Name), verifiedSlotInfoFeed),
 	})
 	if err != nil {
 		return nil
 	}
 
 	log.Info("Registered RPC service")
+	// rpc service does not wait for its feeds to be started, so health endpoints are served while chains connect.
+	// It is still stopped before them, as it is registered after them.
 	return o.services.RegisterService(svc)
 }
 
-// Start the OrchestratorNode and kicks off every registered service.
-func (o *OrchestratorNode) Start() {
+// readinessCheck reports whether every registered service has been started and was healthy on the latest
+// health check, including the connections with both chains, and verification does not lag behind vanguard
+// head more than maxLag slots
+func (o *OrchestratorNode) readinessCheck(maxLag uint64, progressReporter conIface.ProgressReporter) func() error {
+	return func() error {
+		if err := o.health.err(); err != nil {
+			return err
+		}
+		if lag := progressReporter.VerificationProgress().VerificationLag; lag > maxLag {
+			return fmt.Errorf("verification lags %d slots behind vanguard head, maximum %d slots", lag, maxLag)
+		}
+		if err := o.networksReady(maxLag); err != nil {
+			return err
+		}
+		if o.elector != nil && !o.elector.IsLeader() {
+			return errors.New("standby of high availability pair, the leader serves traffic")
+		}
+		if o.standby != nil && !o.standby.IsLeader() {
+			return errors.New("follower which has not been promoted, the leader serves traffic")
+		}
+		return nil
+	}
+}
+
+// leadership returns the elector or standby which rpc subscriptions and verdicts to pandora require leadership
+// of, nil when the node runs alone. Additional networks follow the leadership of the main network.
+func (o *OrchestratorNode) leadership() ha.Leadership {
+	if o.parent != nil {
+		return o.parent.leadership()
+	}
+	if o.elector != nil {
+		return o.elector
+	}
+	if o.standby != nil {
+		return o.standby
+	}
+	return nil
+}
+
+// promote makes the follower the leader on request of admin api, it returns false when it leads already
+func (o *OrchestratorNode) promote() (bool, error) {
+	if o.standby == nil {
+		return false, errors.Errorf("node has not been started with --%s", cmd.FollowerFlag.Name)
+	}
+	return o.standby.Promote(), nil
+}
+
+// splitAndTrim splits input separated by a comma and trims excessive white space from the substrings
+func splitAndTrim(input string) []string {
+	values := make([]string, 0)
+	for _, value := range strings.Split(input, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// Start the OrchestratorNode and kicks off every registered service. It blocks until the node is closed.
+// SIGHUP reloads the config file of the node.
+func (o *OrchestratorNode) Start() error {
 	o.lock.Lock()
 
 	log.WithFields(logrus.Fields{
 		"version": version.Version(),
 	}).Info("Starting orchestrator node")
+	logEffectiveConfig(o.cliCtx)
 
-	o.services.StartAll()
+	if err := o.services.StartAll(); err != nil {
+		o.lock.Unlock()
+		o.Close()
+		return errors.Wrap(err, "could not start services")
+	}
+	for _, network := range o.networks {
+		if err := network.startNetwork(); err != nil {
+			o.lock.Unlock()
+			o.Close()
+			return err
+		}
+	}
+	go o.health.run(o.ctx)
+	go o.reloadOnSignal()
+	go o.newSupervisor().run(o.ctx)
+	go o.runStatusSummary(o.cliCtx.Duration(cmd.StatusLogPeriodFlag.Name))
+	go newResourceMonitor(o.cliCtx.Uint64(cmd.MemoryLimitFlag.Name)*1024*1024, o.db.MMapSize).run(o.ctx.Done())
 
 	stop := o.stop
 	o.lock.Unlock()
@@ -289,17 +831,48 @@ func (o *OrchestratorNode) Start() {
 
 	// Wait for stop channel to be closed.
 	<-stop
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+	return o.failure
 }
 
 // Close handles graceful shutdown of the system.
 func (b *OrchestratorNode) Close() {
 	b.lock.Lock()
 	defer b.lock.Unlock()
+	select {
+	case <-b.stop:
+		// already closed
+		return
+	default:
+	}
 
-	log.Info("Stopping orchestrator node")
-	b.services.StopAll()
-	if err := b.db.Close(); err != nil {
-		log.Errorf("Failed to close database: %v", err)
+	b.logger().Info("Stopping orchestrator node")
+	b.systemd.stopping()
+	stopWithTimeout(b.cliCtx.Duration(cmd.ShutdownTimeoutFlag.Name), func() {
+		b.services.StopAll()
+		b.runShutdownHooks()
+		if b.vanguardRelay != nil {
+			b.vanguardRelay.close()
+		}
+		if b.pandoraRelay != nil {
+			b.pandoraRelay.close()
+		}
+		if err := b.db.Close(); err != nil {
+			log.Errorf("Failed to close database: %v", err)
+		}
+	})
+	// additional networks are closed after rpc service of the main network, which serves them, has been stopped
+	for _, network := range b.networks {
+		network.Close()
+	}
+	if b.pidFile != "" {
+		if err := os.Remove(b.pidFile); err != nil {
+			log.WithError(err).Error("Failed to remove pid file")
+		}
+	}
+	if err := b.instanceLock.release(); err != nil {
+		log.WithError(err).Error("Failed to release lock of data directory")
 	}
 	b.cancel()
 	close(b.stop)
@@ -2,6 +2,7 @@ package node
 
 import (
 	"flag"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/ha"
 	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
 	logTest "github.com/sirupsen/logrus/hooks/test"
@@ -11,14 +12,29 @@ import (
 	"testing"
 )
 
+// newTestFlagSet returns flags of a node which stores its data in the given directory. Event buffer flags are applied
+// with their defaults.
+func newTestFlagSet(t *testing.T, dataDir string) *flag.FlagSet {
+	set := flag.NewFlagSet("test", 0)
+	set.String("datadir", dataDir, "node data directory")
+	for _, f := range []cli.Flag{
+		cmd.RelayBufferSizeFlag,
+		cmd.RelayBufferPolicyFlag,
+		cmd.RPCSubscriptionBufferSizeFlag,
+		cmd.RPCSubscriptionBufferPolicyFlag,
+	} {
+		require.NoError(t, f.Apply(set))
+	}
+	return set
+}
+
 // Test that beacon chain node can register all services and close.
 func Test_Node_RegisterServices(t *testing.T) {
 	hook := logTest.NewGlobal()
 	tmp := filepath.Join(t.TempDir(), "datadirtest")
 
 	app := cli.App{}
-	set := flag.NewFlagSet("test", 0)
-	set.String("datadir", tmp, "Data directory for storing consensus metadata and block headers")
+	set := newTestFlagSet(t, tmp)
 
 	context := cli.NewContext(&app, set, nil)
 	node, err := New(context)
@@ -35,8 +51,7 @@ func Test_ClearDB(t *testing.T) {
 	tmp := filepath.Join(t.TempDir(), "datadirtest")
 
 	app := cli.App{}
-	set := flag.NewFlagSet("test", 0)
-	set.String("datadir", tmp, "node data directory")
+	set := newTestFlagSet(t, tmp)
 	set.Bool(cmd.ForceClearDB.Name, true, "force clear db")
 
 	context := cli.NewContext(&app, set, nil)
@@ -46,3 +61,88 @@ func Test_ClearDB(t *testing.T) {
 	require.LogsContain(t, hook, "Removing database")
 	require.NoError(t, os.RemoveAll(tmp))
 }
+
+func Test_SplitAndTrim(t *testing.T) {
+	require.DeepEqual(t, []string{"localhost", "example.com"}, splitAndTrim(" localhost, ,example.com "))
+	require.DeepEqual(t, []string{}, splitAndTrim(""))
+}
+
+// Test_NodeStatus checks that node status reports registered services, caches and db
+func Test_NodeStatus(t *testing.T) {
+	tmp := filepath.Join(t.TempDir(), "datadirtest")
+
+	app := cli.App{}
+	set := newTestFlagSet(t, tmp)
+
+	context := cli.NewContext(&app, set, nil)
+	node, err := New(context)
+	require.NoError(t, err)
+	defer node.Close()
+
+	status := node.nodeStatus(node.ctx)
+	require.NotEqual(t, "", status.Version)
+	require.Equal(t, 2, len(status.Caches))
+	require.Equal(t, true, len(status.Services) > 0)
+	require.Equal(t, true, status.DBSize > 0)
+	for _, service := range status.Services {
+		require.Equal(t, false, service.Started)
+	}
+}
+
+// Test_Node_Follower checks that a follower does not lead until it is promoted
+func Test_Node_Follower(t *testing.T) {
+	tmp := filepath.Join(t.TempDir(), "datadirtest")
+
+	app := cli.App{}
+	set := newTestFlagSet(t, tmp)
+	set.Bool(cmd.FollowerFlag.Name, true, "follower")
+
+	context := cli.NewContext(&app, set, nil)
+	node, err := New(context)
+	require.NoError(t, err)
+	defer node.Close()
+
+	require.NotNil(t, node.leadership())
+	require.Equal(t, false, node.leadership().IsLeader())
+	promoted, err := node.promote()
+	require.NoError(t, err)
+	require.Equal(t, true, promoted)
+	require.Equal(t, true, node.leadership().IsLeader())
+	promoted, err = node.promote()
+	require.NoError(t, err)
+	require.Equal(t, false, promoted)
+}
+
+// Test_Node_Lease checks that rpc subscriptions and verdicts to pandora follow the elector in high availability mode
+func Test_Node_Lease(t *testing.T) {
+	tmp := filepath.Join(t.TempDir(), "datadirtest")
+
+	app := cli.App{}
+	set := newTestFlagSet(t, tmp)
+	set.String(cmd.HALeaseFileFlag.Name, filepath.Join(tmp, "lease.json"), "lease file")
+	set.Duration(cmd.HALeaseDurationFlag.Name, cmd.DefaultHALeaseDuration, "lease duration")
+
+	context := cli.NewContext(&app, set, nil)
+	node, err := New(context)
+	require.NoError(t, err)
+	defer node.Close()
+
+	require.NotNil(t, node.elector)
+	require.Equal(t, ha.Leadership(node.elector), node.leadership())
+	// the elector has not claimed the lease yet
+	require.Equal(t, false, node.leadership().IsLeader())
+}
+
+// Test_Node_FollowerWithLease checks that a follower can not take part in leader election
+func Test_Node_FollowerWithLease(t *testing.T) {
+	tmp := filepath.Join(t.TempDir(), "datadirtest")
+
+	app := cli.App{}
+	set := newTestFlagSet(t, tmp)
+	set.Bool(cmd.FollowerFlag.Name, true, "follower")
+	set.String(cmd.HALeaseFileFlag.Name, filepath.Join(tmp, "lease.json"), "lease file")
+
+	context := cli.NewContext(&app, set, nil)
+	_, err := New(context)
+	require.ErrorContains(t, "can not be combined", err)
+}
@@ -0,0 +1,342 @@
+package node
+
+import (
+	"sync"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/pandorachain"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/eventbuffer"
+	"github.com/lukso-network/lukso-orchestrator/shared/feed"
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// size of the channels which receive events of a chain service before they are buffered
+const relayChannelSize = 16
+
+// nodeMetrics collects metrics of the node itself, services collect their own ones
+var nodeMetrics = metrics.NewCollector("node")
+
+// relayBufferConfig reads the buffer of chain events relays. Consensus service consumes every relayed event, so it
+// is never dropped as a slow subscriber.
+func relayBufferConfig(cliCtx *cli.Context) (eventbuffer.Config, error) {
+	buffer, err := cmd.EventBufferConfig(cliCtx, cmd.RelayBufferSizeFlag.Name, cmd.RelayBufferPolicyFlag.Name)
+	if err != nil {
+		return eventbuffer.Config{}, err
+	}
+	if buffer.Policy == eventbuffer.DropSubscriber {
+		return eventbuffer.Config{}, errors.Errorf("invalid %s %s, chain events can not drop their consumer",
+			cmd.RelayBufferPolicyFlag.Name, buffer.Policy)
+	}
+	return buffer, nil
+}
+
+// vanguardRelay stands for the registered vanguard chain service towards consensus and rpc services. Events
+// of the attached service are relayed to own feeds, so subscribers keep their subscriptions when the service
+// is restarted and attached again. Events are buffered in order of arrival by the buffer config, when the buffer
+// is full they are dropped by its policy. Reorgs bypass the buffer, so that no drop policy evicts them, and are
+// relayed after the events which have been buffered before them.
+type vanguardRelay struct {
+	lock    sync.RWMutex
+	service *vanguardchain.Service
+	detach  chan struct{}
+	buffer  eventbuffer.Config
+	events  chan interface{}
+	reorgs  chan *types.Reorg // unbuffered, hands a reorg over to forward once it is its turn
+	stop    chan struct{}
+	dropped metrics.Counter
+
+	consensusInfoFeed *feed.Feed
+	shardInfoFeed     *feed.Feed
+	reorgFeed         *feed.Feed
+	canonicalHeadFeed *feed.Feed
+}
+
+func newVanguardRelay(network string, service *vanguardchain.Service, buffer eventbuffer.Config) *vanguardRelay {
+	relay := newDetachedVanguardRelay(network, buffer)
+	go relay.forward(relay.stop)
+	relay.attach(service)
+	return relay
+}
+
+// newDetachedVanguardRelay creates a relay of the given network, empty for the main network, which neither forwards
+// events nor has a service attached
+func newDetachedVanguardRelay(network string, buffer eventbuffer.Config) *vanguardRelay {
+	return &vanguardRelay{
+		buffer:  buffer,
+		events:  make(chan interface{}, buffer.Size),
+		reorgs:  make(chan *types.Reorg),
+		stop:    make(chan struct{}),
+		dropped: nodeMetrics.Sub(network).Counter("relay/vanguard/dropped"),
+
+		consensusInfoFeed: feed.New(feed.NetworkName(network, "relay/vanguard/consensusinfo"), (*types.MinimalEpochConsensusInfoV2)(nil), eventbuffer.DefaultConfig),
+		shardInfoFeed:     feed.New(feed.NetworkName(network, "relay/vanguard/shardinfo"), (*types.VanguardShardInfo)(nil), eventbuffer.DefaultConfig),
+		reorgFeed:         feed.New(feed.NetworkName(network, "relay/vanguard/reorg"), (*types.Reorg)(nil), eventbuffer.DefaultConfig),
+		canonicalHeadFeed: feed.New(feed.NetworkName(network, "relay/vanguard/canonicalhead"), (*types.CanonicalHead)(nil), eventbuffer.DefaultConfig),
+	}
+}
+
+// attach relays events of the given service, events of the previously attached service are not relayed anymore
+func (r *vanguardRelay) attach(service *vanguardchain.Service) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.detach != nil {
+		close(r.detach)
+	}
+	r.service = service
+	r.detach = make(chan struct{})
+
+	consensusInfoCh := make(chan *types.MinimalEpochConsensusInfoV2, relayChannelSize)
+	shardInfoCh := make(chan *types.VanguardShardInfo, relayChannelSize)
+	reorgCh := make(chan *types.Reorg, 1)
+	canonicalHeadCh := make(chan *types.CanonicalHead, 1)
+	subs := []event.Subscription{
+		service.SubscribeMinConsensusInfoEvent(consensusInfoCh),
+		service.SubscribeShardInfoEvent(shardInfoCh),
+		service.SubscribeShutdownSignalEvent(reorgCh),
+		service.SubscribeCanonicalHeadEvent(canonicalHeadCh),
+	}
+	go func(detach <-chan struct{}) {
+		defer func() {
+			for _, sub := range subs {
+				sub.Unsubscribe()
+			}
+		}()
+		for {
+			select {
+			case consensusInfo := <-consensusInfoCh:
+				r.relay(consensusInfo, detach)
+			case shardInfo := <-shardInfoCh:
+				r.relay(shardInfo, detach)
+			case reorg := <-reorgCh:
+				r.relayReorg(reorg, detach)
+			case canonicalHead := <-canonicalHeadCh:
+				r.relay(canonicalHead, detach)
+			case <-detach:
+				return
+			}
+		}
+	}(r.detach)
+}
+
+// relay buffers an event of the attached service by the policy of the buffer
+func (r *vanguardRelay) relay(ev interface{}, detach <-chan struct{}) {
+	eventbuffer.Send(r.events, ev, r.buffer.Policy, detach, r.dropped)
+}
+
+// relayReorg hands a reorg over to forward. Events of the attached service are relayed one after another, so the
+// buffer holds no later event until the reorg has been taken.
+func (r *vanguardRelay) relayReorg(reorg *types.Reorg, detach <-chan struct{}) {
+	select {
+	case r.reorgs <- reorg:
+	case <-detach:
+	}
+}
+
+// forward sends buffered events to the feeds of relay until the relay is closed
+func (r *vanguardRelay) forward(stop <-chan struct{}) {
+	for {
+		select {
+		case ev := <-r.events:
+			r.send(ev)
+		case reorg := <-r.reorgs:
+			// events which have been buffered before the reorg are sent first to keep the order of arrival
+			for i := len(r.events); i > 0; i-- {
+				r.send(<-r.events)
+			}
+			r.reorgFeed.Send(reorg)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// send sends a buffered event to the feed of its type
+func (r *vanguardRelay) send(ev interface{}) {
+	switch ev := ev.(type) {
+	case *types.MinimalEpochConsensusInfoV2:
+		r.consensusInfoFeed.Send(ev)
+	case *types.VanguardShardInfo:
+		r.shardInfoFeed.Send(ev)
+	case *types.CanonicalHead:
+		r.canonicalHeadFeed.Send(ev)
+	}
+}
+
+// current returns the attached vanguard chain service
+func (r *vanguardRelay) current() *vanguardchain.Service {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.service
+}
+
+// close stops relaying events and ends the subscriptions of relay feeds
+func (r *vanguardRelay) close() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.detach != nil {
+		close(r.detach)
+		r.detach = nil
+	}
+	select {
+	case <-r.stop:
+	default:
+		close(r.stop)
+	}
+	r.consensusInfoFeed.Close()
+	r.shardInfoFeed.Close()
+	r.reorgFeed.Close()
+	r.canonicalHeadFeed.Close()
+}
+
+func (r *vanguardRelay) SubscribeMinConsensusInfoEvent(ch chan<- *types.MinimalEpochConsensusInfoV2) event.Subscription {
+	return r.consensusInfoFeed.Subscribe(ch)
+}
+
+func (r *vanguardRelay) SubscribeShardInfoEvent(ch chan<- *types.VanguardShardInfo) event.Subscription {
+	return r.shardInfoFeed.Subscribe(ch)
+}
+
+func (r *vanguardRelay) SubscribeShutdownSignalEvent(ch chan<- *types.Reorg) event.Subscription {
+	return r.reorgFeed.Subscribe(ch)
+}
+
+func (r *vanguardRelay) SubscribeCanonicalHeadEvent(ch chan<- *types.CanonicalHead) event.Subscription {
+	return r.canonicalHeadFeed.Subscribe(ch)
+}
+
+func (r *vanguardRelay) ReSubscribeBlocksEvent() error {
+	return r.current().ReSubscribeBlocksEvent()
+}
+
+func (r *vanguardRelay) StopSubscription() {
+	r.current().StopSubscription()
+}
+
+func (r *vanguardRelay) ShardInfoBySlot(slot uint64) (*types.VanguardShardInfo, error) {
+	return r.current().ShardInfoBySlot(slot)
+}
+
+func (r *vanguardRelay) ShardInfosByEpoch(epoch uint64) ([]*types.VanguardShardInfo, error) {
+	return r.current().ShardInfosByEpoch(epoch)
+}
+
+func (r *vanguardRelay) HeadSlot() (uint64, error) {
+	return r.current().HeadSlot()
+}
+
+func (r *vanguardRelay) ProposerForSlot(slot uint64) (string, error) {
+	return r.current().ProposerForSlot(slot)
+}
+
+// pandoraRelay stands for the registered pandora chain service towards consensus service, like vanguardRelay
+type pandoraRelay struct {
+	lock    sync.RWMutex
+	service *pandorachain.Service
+	detach  chan struct{}
+	buffer  eventbuffer.Config
+	events  chan *types.PandoraHeaderInfo
+	stop    chan struct{}
+	dropped metrics.Counter
+
+	headerInfoFeed *feed.Feed
+}
+
+func newPandoraRelay(network string, service *pandorachain.Service, buffer eventbuffer.Config) *pandoraRelay {
+	relay := &pandoraRelay{
+		buffer:  buffer,
+		events:  make(chan *types.PandoraHeaderInfo, buffer.Size),
+		stop:    make(chan struct{}),
+		dropped: nodeMetrics.Sub(network).Counter("relay/pandora/dropped"),
+
+		headerInfoFeed: feed.New(feed.NetworkName(network, "relay/pandora/headerinfo"), (*types.PandoraHeaderInfo)(nil), eventbuffer.DefaultConfig),
+	}
+	go relay.forward(relay.stop)
+	relay.attach(service)
+	return relay
+}
+
+// attach relays events of the given service, events of the previously attached service are not relayed anymore
+func (r *pandoraRelay) attach(service *pandorachain.Service) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.detach != nil {
+		close(r.detach)
+	}
+	r.service = service
+	r.detach = make(chan struct{})
+
+	headerInfoCh := make(chan *types.PandoraHeaderInfo, relayChannelSize)
+	sub := service.SubscribeHeaderInfoEvent(headerInfoCh)
+	go func(detach <-chan struct{}) {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case headerInfo := <-headerInfoCh:
+				eventbuffer.Send(r.events, headerInfo, r.buffer.Policy, detach, r.dropped)
+			case <-detach:
+				return
+			}
+		}
+	}(r.detach)
+}
+
+// forward sends buffered events to the feed of relay until the relay is closed
+func (r *pandoraRelay) forward(stop <-chan struct{}) {
+	for {
+		select {
+		case headerInfo := <-r.events:
+			r.headerInfoFeed.Send(headerInfo)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// current returns the attached pandora chain service
+func (r *pandoraRelay) current() *pandorachain.Service {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.service
+}
+
+// close stops relaying events and ends the subscriptions of relay feed
+func (r *pandoraRelay) close() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.detach != nil {
+		close(r.detach)
+		r.detach = nil
+		close(r.stop)
+	}
+	r.headerInfoFeed.Close()
+}
+
+func (r *pandoraRelay) SubscribeHeaderInfoEvent(ch chan<- *types.PandoraHeaderInfo) event.Subscription {
+	return r.headerInfoFeed.Subscribe(ch)
+}
+
+func (r *pandoraRelay) StopPandoraSubscription() {
+	r.current().StopPandoraSubscription()
+}
+
+func (r *pandoraRelay) ResumePandoraSubscription() error {
+	return r.current().ResumePandoraSubscription()
+}
+
+func (r *pandoraRelay) HeadersByRange(from, to uint64) ([]*eth1Types.Header, error) {
+	return r.current().HeadersByRange(from, to)
+}
+
+func (r *pandoraRelay) LatestBlockNumber() (uint64, error) {
+	return r.current().LatestBlockNumber()
+}
+
+func (r *pandoraRelay) ConfirmBlock(blockStatus *types.BlockStatus) error {
+	return r.current().ConfirmBlock(blockStatus)
+}
@@ -0,0 +1,68 @@
+package node
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/eventbuffer"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// TestVanguardRelay_Reorg checks that a reorg is neither evicted from a full drop-oldest buffer by later events nor
+// relayed ahead of the events which have been buffered before it
+func TestVanguardRelay_Reorg(t *testing.T) {
+	relay := newDetachedVanguardRelay("", eventbuffer.Config{Size: 2, Policy: eventbuffer.DropOldest})
+	defer relay.close()
+	shardInfoCh := make(chan *types.VanguardShardInfo, 10)
+	reorgCh := make(chan *types.Reorg, 1)
+	shardInfoSub := relay.SubscribeShardInfoEvent(shardInfoCh)
+	defer shardInfoSub.Unsubscribe()
+	reorgSub := relay.SubscribeShutdownSignalEvent(reorgCh)
+	defer reorgSub.Unsubscribe()
+
+	// buffer is full and slot 1 has been dropped before forwarding starts
+	detach := make(chan struct{})
+	for slot := uint64(1); slot <= 3; slot++ {
+		relay.relay(&types.VanguardShardInfo{Slot: slot}, detach)
+	}
+	go func() {
+		relay.relayReorg(&types.Reorg{NewSlot: 3}, detach)
+		for slot := uint64(4); slot <= 8; slot++ {
+			relay.relay(&types.VanguardShardInfo{Slot: slot}, detach)
+		}
+	}()
+	go relay.forward(relay.stop)
+
+	select {
+	case reorg := <-reorgCh:
+		assert.Equal(t, uint64(3), reorg.NewSlot)
+	case <-time.After(time.Second):
+		t.Fatal("reorg has not been relayed")
+	}
+	for _, slot := range []uint64{2, 3} {
+		select {
+		case shardInfo := <-shardInfoCh:
+			assert.Equal(t, slot, shardInfo.Slot)
+		case <-time.After(time.Second):
+			t.Fatalf("shard info of slot %d has not been relayed", slot)
+		}
+	}
+}
+
+// TestVanguardRelay_Dropped checks that events dropped by the buffer are counted when metrics have been enabled
+// after the start of the process, e.g. by the config file
+func TestVanguardRelay_Dropped(t *testing.T) {
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = false }()
+	// relays of other tests may have registered the counter while metrics were disabled
+	metrics.DefaultRegistry.Unregister(nodeMetrics.Name("relay/vanguard/dropped"))
+
+	relay := newDetachedVanguardRelay("", eventbuffer.Config{Size: 1, Policy: eventbuffer.DropOldest})
+	defer relay.close()
+	detach := make(chan struct{})
+	relay.relay(&types.VanguardShardInfo{Slot: 1}, detach)
+	relay.relay(&types.VanguardShardInfo{Slot: 2}, detach)
+	assert.Equal(t, int64(1), relay.dropped.Count())
+}
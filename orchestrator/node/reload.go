@@ -0,0 +1,108 @@
+package node
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/logutil"
+)
+
+// flags of rpc rate limits which are applied to running rpc servers on reload
+var rateLimitFlags = map[string]bool{
+	cmd.RPCRateLimitFlag.Name:               true,
+	cmd.RPCRateLimitBurstFlag.Name:          true,
+	cmd.RPCExpensiveRateLimitFlag.Name:      true,
+	cmd.RPCExpensiveRateLimitBurstFlag.Name: true,
+}
+
+// reloadOnSignal reloads the config file on every SIGHUP until the node is closed
+func (o *OrchestratorNode) reloadOnSignal() {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+	for {
+		select {
+		case <-sighup:
+			log.Info("Got hangup signal, reloading config file")
+			o.reloadConfig()
+		case <-o.ctx.Done():
+			return
+		}
+	}
+}
+
+// reloadConfig reads the config file again and applies the changes which are safe at runtime: log verbosity,
+// vanguard and pandora endpoints, whose services are restarted, and rpc rate limits. Other changes are
+// reported as requiring a restart of the node.
+func (o *OrchestratorNode) reloadConfig() {
+	changed, err := cmd.ReloadFlagsFromConfig(o.cliCtx, o.cliCtx.App.Flags)
+	if err != nil {
+		log.WithError(err).Error("Could not reload config file")
+		return
+	}
+	if len(changed) == 0 {
+		log.Info("Config file has no changes")
+		return
+	}
+
+	var applied, restartRequired, rateLimitChanges []string
+	for _, name := range changed {
+		switch {
+		case name == cmd.VerbosityFlag.Name:
+			if err := logutil.SetVerbosity(o.cliCtx.String(name)); err != nil {
+				log.WithError(err).WithField("flag", name).Error("Could not apply reloaded flag")
+				continue
+			}
+		case name == cmd.VanguardGRPCEndpoint.Name:
+			if err := o.RestartService(vanguardChainServiceName, o.cliCtx.String(name)); err != nil {
+				log.WithError(err).WithField("flag", name).Error("Could not apply reloaded flag")
+				continue
+			}
+		case name == cmd.PandoraRPCEndpoint.Name:
+			if err := o.RestartService(pandoraChainServiceName, o.cliCtx.String(name)); err != nil {
+				log.WithError(err).WithField("flag", name).Error("Could not apply reloaded flag")
+				continue
+			}
+		case rateLimitFlags[name]:
+			rateLimitChanges = append(rateLimitChanges, name)
+			continue
+		default:
+			restartRequired = append(restartRequired, name)
+			continue
+		}
+		applied = append(applied, name)
+	}
+
+	if len(rateLimitChanges) > 0 {
+		if err := o.updateRateLimit(); err != nil {
+			log.WithError(err).Warn("Could not apply reloaded rate limits")
+			restartRequired = append(restartRequired, rateLimitChanges...)
+		} else {
+			applied = append(applied, rateLimitChanges...)
+		}
+	}
+
+	if len(applied) > 0 {
+		log.WithField("flags", applied).Info("Applied reloaded config")
+	}
+	if len(restartRequired) > 0 {
+		log.WithField("flags", restartRequired).Warn("Changed flags of config file require restart of the node")
+	}
+}
+
+// updateRateLimit applies rate limit flags to the running rpc service
+func (o *OrchestratorNode) updateRateLimit() error {
+	var rpcService *rpc.Service
+	if err := o.services.FetchService(&rpcService); err != nil {
+		return err
+	}
+	return rpcService.UpdateRateLimit(rpc.RateLimitConfig{
+		RequestsPerSecond:          o.cliCtx.Float64(cmd.RPCRateLimitFlag.Name),
+		Burst:                      o.cliCtx.Int(cmd.RPCRateLimitBurstFlag.Name),
+		ExpensiveRequestsPerSecond: o.cliCtx.Float64(cmd.RPCExpensiveRateLimitFlag.Name),
+		ExpensiveBurst:             o.cliCtx.Int(cmd.RPCExpensiveRateLimitBurstFlag.Name),
+	})
+}
@@ -0,0 +1,155 @@
+package node
+
+import (
+	"os"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+)
+
+// time to wait between two collections of runtime resource metrics and checks of the soft memory limit
+var resourceCollectionPeriod = 10 * time.Second
+
+// bounds of GC percent applied by the soft memory limit, the lower one keeps the collector from running all the
+// time when live heap reaches the limit
+const (
+	minGCPercent = 10
+	maxGCPercent = 1 << 20
+)
+
+// resourceMonitor exposes goroutines, heap, open file descriptors and bolt memory map of the process as metrics
+// and keeps the heap below the soft memory limit by running the garbage collector more often, the way
+// GOMEMLIMIT does, so that vanguard and pandora running on the same host are not starved of memory.
+type resourceMonitor struct {
+	memoryLimit   uint64 // in bytes, 0 disables the limit
+	baseGCPercent int    // GC percent configured by GOGC
+	gcPercent     int    // GC percent currently applied
+	mmapSize      func() int64
+
+	goroutines      metrics.Gauge
+	heapAlloc       metrics.Gauge
+	heapSys         metrics.Gauge
+	openFDs         metrics.Gauge
+	dbMMapSize      metrics.Gauge
+	gcPercentGauge  metrics.Gauge
+	limitExceedings metrics.Counter
+}
+
+func newResourceMonitor(memoryLimit uint64, mmapSize func() int64) *resourceMonitor {
+	gcPercent := debug.SetGCPercent(100)
+	debug.SetGCPercent(gcPercent)
+	return &resourceMonitor{
+		memoryLimit:     memoryLimit,
+		baseGCPercent:   gcPercent,
+		gcPercent:       gcPercent,
+		mmapSize:        mmapSize,
+		goroutines:      nodeMetrics.Gauge("runtime/goroutines"),
+		heapAlloc:       nodeMetrics.Gauge("runtime/heap/alloc"),
+		heapSys:         nodeMetrics.Gauge("runtime/heap/sys"),
+		openFDs:         nodeMetrics.Gauge("process/fds/open"),
+		dbMMapSize:      nodeMetrics.Gauge("db/mmap/size"),
+		gcPercentGauge:  nodeMetrics.Gauge("runtime/gc/percent"),
+		limitExceedings: nodeMetrics.Counter("runtime/memlimit/exceeded"),
+	}
+}
+
+// run collects resource metrics until the node is closed
+func (m *resourceMonitor) run(done <-chan struct{}) {
+	if m.memoryLimit > 0 {
+		log.WithField("limitMB", m.memoryLimit/1024/1024).Info("Enabled soft memory limit")
+	}
+	ticker := time.NewTicker(resourceCollectionPeriod)
+	defer ticker.Stop()
+
+	m.collect()
+	for {
+		select {
+		case <-ticker.C:
+			m.collect()
+		case <-done:
+			return
+		}
+	}
+}
+
+// collect updates resource metrics and adjusts the garbage collector to the soft memory limit
+func (m *resourceMonitor) collect() {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	m.goroutines.Update(int64(runtime.NumGoroutine()))
+	m.heapAlloc.Update(int64(stats.HeapAlloc))
+	m.heapSys.Update(int64(stats.HeapSys))
+	if fds, err := openFileDescriptors(); err == nil {
+		m.openFDs.Update(int64(fds))
+	}
+	if m.mmapSize != nil {
+		m.dbMMapSize.Update(m.mmapSize())
+	}
+
+	if m.memoryLimit > 0 {
+		m.applyMemoryLimit(&stats)
+	}
+	m.gcPercentGauge.Update(int64(m.gcPercent))
+}
+
+// applyMemoryLimit sets the GC percent which triggers the next collection at the limit. Memory is returned to
+// the operating system right away when the heap already exceeds the limit.
+func (m *resourceMonitor) applyMemoryLimit(stats *runtime.MemStats) {
+	live := stats.HeapAlloc
+	if m.gcPercent > 0 && stats.NextGC > 0 {
+		// heap target is live heap of the previous collection grown by the GC percent
+		live = stats.NextGC * 100 / uint64(100+m.gcPercent)
+	}
+	if percent := gcPercentForLimit(live, m.memoryLimit, m.baseGCPercent); percent != m.gcPercent {
+		debug.SetGCPercent(percent)
+		m.gcPercent = percent
+	}
+	if stats.HeapAlloc > m.memoryLimit {
+		m.limitExceedings.Inc(1)
+		log.WithField("heapMB", stats.HeapAlloc/1024/1024).
+			WithField("limitMB", m.memoryLimit/1024/1024).
+			Warn("Heap exceeds soft memory limit, returning memory to operating system")
+		debug.FreeOSMemory()
+	}
+}
+
+// gcPercentForLimit returns the GC percent at which the heap grown from the live heap reaches the limit. It is
+// never above the configured percent and never below minGCPercent. Configured percent is negative when GOGC
+// disables the collector, then the limit alone triggers collections.
+func gcPercentForLimit(live, limit uint64, base int) int {
+	if live == 0 {
+		return base
+	}
+	if live >= limit {
+		return minGCPercent
+	}
+	percent := (limit - live) * 100 / live
+	if base >= 0 && percent > uint64(base) {
+		return base
+	}
+	if percent < minGCPercent {
+		return minGCPercent
+	}
+	if percent > uint64(maxGCPercent) {
+		return maxGCPercent
+	}
+	return int(percent)
+}
+
+// openFileDescriptors counts file descriptors of the process, it fails on systems without procfs
+func openFileDescriptors() (int, error) {
+	dir, err := os.Open("/proc/self/fd")
+	if err != nil {
+		return 0, err
+	}
+	defer dir.Close()
+	names, err := dir.Readdirnames(-1)
+	if err != nil {
+		return 0, err
+	}
+	// the descriptor of the opened directory itself is not counted
+	return len(names) - 1, nil
+}
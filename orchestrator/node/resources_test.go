@@ -0,0 +1,36 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+func TestGCPercentForLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		live  uint64
+		limit uint64
+		base  int
+		want  int
+	}{
+		{name: "far below limit keeps configured percent", live: 100, limit: 1000, base: 100, want: 100},
+		{name: "near limit lowers percent", live: 800, limit: 1000, base: 100, want: 25},
+		{name: "at limit applies minimal percent", live: 1000, limit: 1000, base: 100, want: minGCPercent},
+		{name: "above limit applies minimal percent", live: 1200, limit: 1000, base: 100, want: minGCPercent},
+		{name: "close to limit applies minimal percent", live: 990, limit: 1000, base: 100, want: minGCPercent},
+		{name: "disabled collector is triggered by limit", live: 100, limit: 1000, base: -1, want: 900},
+		{name: "empty heap keeps configured percent", live: 0, limit: 1000, base: 100, want: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, gcPercentForLimit(tt.live, tt.limit, tt.base))
+		})
+	}
+}
+
+func TestResourceMonitor_Collect(t *testing.T) {
+	monitor := newResourceMonitor(0, func() int64 { return 1 << 20 })
+	monitor.collect()
+	assert.Equal(t, monitor.baseGCPercent, monitor.gcPercent)
+}
@@ -0,0 +1,57 @@
+package node
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/pkg/errors"
+)
+
+// names of the services which can be restarted while the node is running
+const (
+	vanguardChainServiceName = "vanguardchain"
+	pandoraChainServiceName  = "pandorachain"
+)
+
+// RestartService stops the named chain service and starts a new instance which connects to the given endpoint,
+// or to the configured endpoint when it is empty. Consensus and rpc services receive the events of the new
+// instance through the relays, so verification continues from the stored state instead of catching up again.
+func (o *OrchestratorNode) RestartService(name, endpoint string) error {
+	o.restartLock.Lock()
+	defer o.restartLock.Unlock()
+
+	switch name {
+	case vanguardChainServiceName:
+		if endpoint == "" {
+			endpoint = o.cliCtx.String(cmd.VanguardGRPCEndpoint.Name)
+		}
+		svc, err := o.newVanguardChainService(endpoint)
+		if err != nil {
+			return errors.Wrap(err, "could not create vanguard chain service")
+		}
+		previous := o.vanguardRelay.current()
+		o.vanguardRelay.attach(svc)
+		if err := o.services.ReplaceService(svc); err != nil {
+			o.vanguardRelay.attach(previous)
+			return errors.Wrap(err, "could not replace vanguard chain service")
+		}
+	case pandoraChainServiceName:
+		if endpoint == "" {
+			endpoint = o.cliCtx.String(cmd.PandoraRPCEndpoint.Name)
+		}
+		svc, err := o.newPandoraChainService(endpoint)
+		if err != nil {
+			return errors.Wrap(err, "could not create pandora chain service")
+		}
+		previous := o.pandoraRelay.current()
+		o.pandoraRelay.attach(svc)
+		if err := o.services.ReplaceService(svc); err != nil {
+			o.pandoraRelay.attach(previous)
+			return errors.Wrap(err, "could not replace pandora chain service")
+		}
+	default:
+		return errors.Errorf("service %s can not be restarted, restartable services are %s and %s",
+			name, vanguardChainServiceName, pandoraChainServiceName)
+	}
+
+	log.WithField("service", name).WithField("endpoint", endpoint).Info("Restarted service")
+	return nil
+}
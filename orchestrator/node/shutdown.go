@@ -0,0 +1,75 @@
+package node
+
+import (
+	"context"
+	"os"
+	"runtime/pprof"
+	"time"
+)
+
+// time given to all shutdown hooks together
+var shutdownHooksTimeout = 10 * time.Second
+
+// ShutdownHook is a cleanup task which runs while the node is closed, after services have been stopped and
+// before the database is closed. The context expires when hooks take too long.
+type ShutdownHook func(ctx context.Context) error
+
+// RegisterShutdownHook registers a cleanup task, hooks run in the order of registration when the node is closed
+func (o *OrchestratorNode) RegisterShutdownHook(hook ShutdownHook) {
+	o.hooksLock.Lock()
+	defer o.hooksLock.Unlock()
+	o.shutdownHooks = append(o.shutdownHooks, hook)
+}
+
+// runShutdownHooks runs registered hooks one after another, a failed hook does not prevent the following ones
+func (o *OrchestratorNode) runShutdownHooks() {
+	o.hooksLock.Lock()
+	hooks := o.shutdownHooks
+	o.shutdownHooks = nil
+	o.hooksLock.Unlock()
+	if len(hooks) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownHooksTimeout)
+	defer cancel()
+	for i, hook := range hooks {
+		if err := ctx.Err(); err != nil {
+			log.WithField("skipped", len(hooks)-i).Error("Shutdown hooks did not finish in time")
+			return
+		}
+		if err := hook(ctx); err != nil {
+			log.WithError(err).WithField("hook", i).Error("Shutdown hook failed")
+		}
+	}
+}
+
+// exit terminates the process when shutdown hangs, it is replaced in tests
+var exit = os.Exit
+
+// stopWithTimeout runs stop and waits for it at most timeout. When stop hangs, stacks of all goroutines are
+// written to stderr, so the hanging service can be found, and the process exits with an error. Zero timeout
+// waits forever.
+func stopWithTimeout(timeout time.Duration, stop func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		stop()
+	}()
+	if timeout <= 0 {
+		<-done
+		return
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		log.WithField("timeout", timeout).Error("Orchestrator node did not stop in time, dumping goroutines and exiting")
+		if err := pprof.Lookup("goroutine").WriteTo(os.Stderr, 2); err != nil {
+			log.WithError(err).Error("Could not dump goroutines")
+		}
+		exit(1)
+	}
+}
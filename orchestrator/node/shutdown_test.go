@@ -0,0 +1,75 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func Test_StopWithTimeout(t *testing.T) {
+	hook := logTest.NewGlobal()
+	defer func(e func(int)) { exit = e }(exit)
+	exitCode := -1
+	exit = func(code int) { exitCode = code }
+
+	stopped := false
+	stopWithTimeout(time.Second, func() { stopped = true })
+	assert.Equal(t, true, stopped)
+	assert.Equal(t, -1, exitCode)
+
+	hang := make(chan struct{})
+	defer close(hang)
+	stopWithTimeout(10*time.Millisecond, func() { <-hang })
+	assert.Equal(t, 1, exitCode)
+	require.LogsContain(t, hook, "did not stop in time")
+}
+
+func TestOrchestratorNode_RunShutdownHooks(t *testing.T) {
+	hook := logTest.NewGlobal()
+	o := &OrchestratorNode{}
+	var order []int
+	o.RegisterShutdownHook(func(ctx context.Context) error {
+		order = append(order, 1)
+		return errors.New("flush failed")
+	})
+	o.RegisterShutdownHook(func(ctx context.Context) error {
+		_, hasDeadline := ctx.Deadline()
+		assert.Equal(t, true, hasDeadline)
+		order = append(order, 2)
+		return nil
+	})
+
+	o.runShutdownHooks()
+	assert.DeepEqual(t, []int{1, 2}, order)
+	require.LogsContain(t, hook, "Shutdown hook failed")
+
+	// hooks run only once
+	o.runShutdownHooks()
+	assert.DeepEqual(t, []int{1, 2}, order)
+}
+
+func TestOrchestratorNode_RunShutdownHooks_Timeout(t *testing.T) {
+	hook := logTest.NewGlobal()
+	defer func(timeout time.Duration) { shutdownHooksTimeout = timeout }(shutdownHooksTimeout)
+	shutdownHooksTimeout = 10 * time.Millisecond
+
+	o := &OrchestratorNode{}
+	secondRun := false
+	o.RegisterShutdownHook(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	o.RegisterShutdownHook(func(ctx context.Context) error {
+		secondRun = true
+		return nil
+	})
+
+	o.runShutdownHooks()
+	assert.Equal(t, false, secondRun)
+	require.LogsContain(t, hook, "Shutdown hooks did not finish in time")
+}
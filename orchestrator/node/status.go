@@ -0,0 +1,59 @@
+package node
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/consensus"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/kv"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/pandorachain"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/version"
+)
+
+// maximum time to wait for client versions of connected nodes
+var endpointStatusTimeout = 2 * time.Second
+
+// nodeStatus collects status of the orchestrator node and its services
+func (o *OrchestratorNode) nodeStatus(ctx context.Context) *types.NodeStatus {
+	status := &types.NodeStatus{
+		Version:   version.Version(),
+		Endpoints: make([]*types.EndpointStatus, 0),
+		DBPath:    o.db.DatabasePath(),
+		Caches: []*types.CacheStatus{
+			{Name: "pandoraHeaders", Entries: o.pandoraInfoCache.Len()},
+			{Name: "vanguardShardInfos", Entries: o.vanShardInfoCache.Len()},
+		},
+		Services: o.health.serviceStatuses(),
+	}
+
+	endpointCtx, cancel := context.WithTimeout(ctx, endpointStatusTimeout)
+	defer cancel()
+	var vanguardService *vanguardchain.Service
+	if err := o.services.FetchService(&vanguardService); err == nil {
+		status.Endpoints = append(status.Endpoints, vanguardService.EndpointStatus(endpointCtx))
+	}
+	var pandoraService *pandorachain.Service
+	if err := o.services.FetchService(&pandoraService); err == nil {
+		status.Endpoints = append(status.Endpoints, pandoraService.EndpointStatus(endpointCtx))
+	}
+
+	checkpoint, err := o.db.VerificationCheckpoint()
+	if err != nil {
+		log.WithError(err).Warn("Could not read verification checkpoint")
+	}
+	status.Checkpoint = checkpoint
+
+	var consensusService *consensus.Service
+	if err := o.services.FetchService(&consensusService); err == nil {
+		status.Progress = consensusService.VerificationProgress()
+	}
+
+	if info, err := os.Stat(filepath.Join(o.db.DatabasePath(), kv.DatabaseFileName)); err == nil {
+		status.DBSize = info.Size()
+	}
+	return status
+}
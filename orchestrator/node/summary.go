@@ -0,0 +1,82 @@
+package node
+
+import (
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/consensus"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/slotutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/sirupsen/logrus"
+)
+
+// runStatusSummary logs the status summary every period until the node is closed, zero period disables it
+func (o *OrchestratorNode) runStatusSummary(period time.Duration) {
+	if period <= 0 {
+		return
+	}
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			o.logStatusSummary()
+		case <-o.ctx.Done():
+			return
+		}
+	}
+}
+
+// logStatusSummary logs a single line with verification progress and chain connections, the heartbeat which
+// operators know from geth and prysm
+func (o *OrchestratorNode) logStatusSummary() {
+	var progress *types.VerificationProgress
+	var consensusService *consensus.Service
+	if err := o.services.FetchService(&consensusService); err == nil {
+		progress = consensusService.VerificationProgress()
+	}
+	fields := statusSummaryFields(progress, o.vanguardRelay.current().Connected(), o.pandoraRelay.current().Connected())
+	if err := o.health.err(); err != nil {
+		fields["health"] = err.Error()
+	}
+	network := params.ActiveNetworkConfig()
+	if network.Name != "" {
+		fields["network"] = network.Name
+	}
+	if leadership := o.leadership(); leadership != nil {
+		fields["leader"] = leadership.IsLeader()
+	}
+	if o.network != "" {
+		fields["network"] = o.network
+	}
+	if slot, ok := slotutil.CurrentSlot(); ok {
+		fields["wallClockSlot"] = slot
+	}
+	log.WithFields(fields).Info("Orchestrator status")
+}
+
+// statusSummaryFields returns the fields of status summary, epoch is the one of vanguard head
+func statusSummaryFields(progress *types.VerificationProgress, vanguardConnected, pandoraConnected bool) logrus.Fields {
+	fields := logrus.Fields{
+		"vanguard": connectionState(vanguardConnected),
+		"pandora":  connectionState(pandoraConnected),
+	}
+	if progress == nil {
+		return fields
+	}
+	headSlot := progress.LatestVerifiedSlot + progress.VerificationLag
+	fields["epoch"] = slotutil.ToEpoch(headSlot)
+	fields["verifiedSlot"] = progress.LatestVerifiedSlot
+	fields["finalizedSlot"] = progress.LatestFinalizedSlot
+	fields["lag"] = progress.VerificationLag
+	fields["pendingSlots"] = progress.PendingSlots
+	fields["verifiedSlotsPerMinute"] = progress.VerifiedSlotsPerMinute
+	return fields
+}
+
+func connectionState(connected bool) string {
+	if connected {
+		return "connected"
+	}
+	return "disconnected"
+}
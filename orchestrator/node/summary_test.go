@@ -0,0 +1,32 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/sirupsen/logrus"
+)
+
+func Test_StatusSummaryFields(t *testing.T) {
+	assert.DeepEqual(t, logrus.Fields{"vanguard": "disconnected", "pandora": "connected"},
+		statusSummaryFields(nil, false, true))
+
+	fields := statusSummaryFields(&types.VerificationProgress{
+		LatestVerifiedSlot:     100,
+		LatestFinalizedSlot:    64,
+		PendingSlots:           3,
+		VerifiedSlotsPerMinute: 4.5,
+		VerificationLag:        30,
+	}, true, true)
+	assert.DeepEqual(t, logrus.Fields{
+		"vanguard":               "connected",
+		"pandora":                "connected",
+		"epoch":                  uint64(4),
+		"verifiedSlot":           uint64(100),
+		"finalizedSlot":          uint64(64),
+		"lag":                    uint64(30),
+		"pendingSlots":           uint64(3),
+		"verifiedSlotsPerMinute": 4.5,
+	}, fields)
+}
@@ -0,0 +1,157 @@
+package node
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/pkg/errors"
+)
+
+// restarts of a crashed service are delayed by exponential backoff between these delays
+var (
+	supervisorInitialBackoff = time.Second
+	supervisorMaxBackoff     = time.Minute
+)
+
+// restarts of a service are forgotten once it has run without crash for this period
+var supervisorResetPeriod = 10 * time.Minute
+
+// number of restarts of a service in a row after which the node is stopped
+const supervisorMaxRestarts = 5
+
+// supervisor reports crashes of service goroutines and restarts the crashed services. Services which can not be
+// restarted, or which keep crashing, fail the whole node, so it does not keep running half alive.
+type supervisor struct {
+	registry    *shared.ServiceRegistry
+	restartable map[reflect.Type]string // names of restartable services by type
+	restart     func(name string) error
+	report      func(crash *shared.ServiceCrash)
+	fail        func(err error)
+	health      *healthMonitor
+
+	restarts  map[reflect.Type]int
+	lastCrash map[reflect.Type]time.Time
+}
+
+func newSupervisor(
+	registry *shared.ServiceRegistry,
+	restartable map[reflect.Type]string,
+	restart func(name string) error,
+	report func(crash *shared.ServiceCrash),
+	fail func(err error),
+	health *healthMonitor,
+) *supervisor {
+	return &supervisor{
+		registry:    registry,
+		restartable: restartable,
+		restart:     restart,
+		report:      report,
+		fail:        fail,
+		health:      health,
+		restarts:    make(map[reflect.Type]int),
+		lastCrash:   make(map[reflect.Type]time.Time),
+	}
+}
+
+// run handles crashes of the services of its registry until the context is cancelled. Crashes of services of
+// other networks are left to the supervisors of their nodes.
+func (s *supervisor) run(ctx context.Context) {
+	crashes := make(chan *shared.ServiceCrash, 16)
+	sub := shared.SubscribeServiceCrashes(crashes)
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case crash := <-crashes:
+			if crash.Registry != s.registry {
+				continue
+			}
+			s.handle(ctx, crash)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// handle reports the crash and restarts the crashed service with backoff until it is restarted or the restart limit is reached
+func (s *supervisor) handle(ctx context.Context, crash *shared.ServiceCrash) {
+	s.report(crash)
+	kind := crash.Service
+	name, ok := s.restartable[kind]
+	if !ok {
+		s.fail(errors.Wrap(crash, "service can not be restarted"))
+		return
+	}
+	if time.Since(s.lastCrash[kind]) > supervisorResetPeriod {
+		s.restarts[kind] = 0
+	}
+	s.lastCrash[kind] = time.Now()
+
+	for {
+		if s.restarts[kind] >= supervisorMaxRestarts {
+			s.fail(errors.Wrapf(crash, "service has been restarted %d times", s.restarts[kind]))
+			return
+		}
+		s.restarts[kind]++
+		backoff := restartBackoff(s.restarts[kind])
+		log.WithField("service", name).WithField("attempt", s.restarts[kind]).WithField("backoff", backoff).
+			Warn("Restarting crashed service")
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		err := s.restart(name)
+		s.health.recordRestart(kind.String())
+		if err == nil {
+			return
+		}
+		log.WithError(err).WithField("service", name).Error("Could not restart crashed service")
+	}
+}
+
+// restartBackoff returns the delay of the given restart attempt, starting at one
+func restartBackoff(attempt int) time.Duration {
+	backoff := supervisorInitialBackoff
+	for i := 1; i < attempt && backoff < supervisorMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > supervisorMaxBackoff {
+		backoff = supervisorMaxBackoff
+	}
+	return backoff
+}
+
+// newSupervisor creates the supervisor of node services, vanguard and pandora chain services are restarted
+// with their current endpoint
+func (o *OrchestratorNode) newSupervisor() *supervisor {
+	restartable := map[reflect.Type]string{
+		reflect.TypeOf(o.vanguardRelay.current()): vanguardChainServiceName,
+		reflect.TypeOf(o.pandoraRelay.current()):  pandoraChainServiceName,
+	}
+	restart := func(name string) error {
+		endpoint := o.vanguardRelay.current().Endpoint()
+		if name == pandoraChainServiceName {
+			endpoint = o.pandoraRelay.current().Endpoint()
+		}
+		return o.RestartService(name, endpoint)
+	}
+	return newSupervisor(o.services, restartable, restart, o.reportCrash, o.fail, o.health)
+}
+
+// fail stops the node after a failure of a service, Start returns the failure. A failure of an additional network
+// stops the node of the main network with all its networks.
+func (o *OrchestratorNode) fail(err error) {
+	if o.parent != nil {
+		o.parent.fail(errors.Wrapf(err, "network %s failed", o.network))
+		return
+	}
+	log.WithError(err).Error("Stopping orchestrator node after service failure")
+	o.lock.Lock()
+	if o.failure == nil {
+		o.failure = err
+	}
+	o.lock.Unlock()
+	go o.Close()
+}
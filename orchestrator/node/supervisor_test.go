@@ -0,0 +1,115 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestRestartBackoff(t *testing.T) {
+	assert.Equal(t, supervisorInitialBackoff, restartBackoff(1))
+	assert.Equal(t, 4*supervisorInitialBackoff, restartBackoff(3))
+	assert.Equal(t, supervisorMaxBackoff, restartBackoff(20))
+}
+
+// Test_Supervisor checks that crashed services are restarted until the limit and other services fail the node
+func Test_Supervisor(t *testing.T) {
+	defer func(backoff time.Duration) { supervisorInitialBackoff = backoff }(supervisorInitialBackoff)
+	supervisorInitialBackoff = time.Millisecond
+
+	var restarted []string
+	var failure error
+	reports := 0
+	restartErr := errors.New("endpoint is not reachable")
+	monitor := newHealthMonitor(shared.NewServiceRegistry(), nil)
+	restartable := reflect.TypeOf(&healthTestService{})
+	s := newSupervisor(
+		shared.NewServiceRegistry(),
+		map[reflect.Type]string{restartable: "test"},
+		func(name string) error {
+			restarted = append(restarted, name)
+			if len(restarted) == 2 {
+				return restartErr
+			}
+			return nil
+		},
+		func(crash *shared.ServiceCrash) { reports++ },
+		func(err error) { failure = err },
+		monitor,
+	)
+	crash := &shared.ServiceCrash{Service: restartable, Reason: "failed"}
+
+	s.handle(context.Background(), crash)
+	assert.DeepEqual(t, []string{"test"}, restarted)
+	assert.Equal(t, 1, reports)
+	require.NoError(t, failure)
+
+	// failed restart is retried
+	s.handle(context.Background(), crash)
+	assert.Equal(t, 3, len(restarted))
+	assert.Equal(t, uint64(3), monitor.restarts[restartable.String()])
+
+	for len(restarted) < supervisorMaxRestarts {
+		s.handle(context.Background(), crash)
+	}
+	s.handle(context.Background(), crash)
+	assert.Equal(t, supervisorMaxRestarts, len(restarted))
+	assert.ErrorContains(t, "restarted 5 times", failure)
+
+	failure = nil
+	s.handle(context.Background(), &shared.ServiceCrash{Service: reflect.TypeOf(s), Reason: "failed"})
+	assert.ErrorContains(t, "service can not be restarted", failure)
+}
+
+// Test_Supervisor_OtherNetwork checks that a supervisor leaves crashes of services of another registry alone
+func Test_Supervisor_OtherNetwork(t *testing.T) {
+	defer func(backoff time.Duration) { supervisorInitialBackoff = backoff }(supervisorInitialBackoff)
+	supervisorInitialBackoff = time.Millisecond
+
+	own, other := &healthTestService{}, &healthTestService{}
+	registry, otherRegistry := shared.NewServiceRegistry(), shared.NewServiceRegistry()
+	require.NoError(t, registry.RegisterService(own))
+	require.NoError(t, otherRegistry.RegisterService(other))
+
+	restarted := make(chan string, 2)
+	s := newSupervisor(
+		registry,
+		map[reflect.Type]string{reflect.TypeOf(own): "test"},
+		func(name string) error {
+			restarted <- name
+			return nil
+		},
+		func(crash *shared.ServiceCrash) {},
+		func(err error) { t.Errorf("unexpected failure: %v", err) },
+		newHealthMonitor(registry, nil),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.run(ctx)
+	// the subscription of the supervisor is set up in the background
+	time.Sleep(50 * time.Millisecond)
+
+	crash := func(service shared.Service) {
+		defer shared.RecoverService(service)
+		panic("failed")
+	}
+	crash(other)
+	select {
+	case <-restarted:
+		t.Fatal("service of another registry has been restarted")
+	case <-time.After(100 * time.Millisecond):
+	}
+	crash(own)
+	select {
+	case name := <-restarted:
+		assert.Equal(t, "test", name)
+	case <-time.After(time.Second):
+		t.Fatal("crashed service has not been restarted")
+	}
+}
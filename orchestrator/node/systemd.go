@@ -0,0 +1,88 @@
+package node
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// systemdNotifier reports the state of the node to systemd when it runs as a service of Type=notify, and pings
+// the watchdog of the service when WatchdogSec= is set. All methods are no-ops otherwise.
+type systemdNotifier struct {
+	socket   string
+	watchdog time.Duration // watchdog timeout of the service, zero when watchdog is disabled
+
+	readyOnce sync.Once
+}
+
+// newSystemdNotifier reads the notification socket and watchdog timeout which are passed by systemd
+func newSystemdNotifier() *systemdNotifier {
+	n := &systemdNotifier{socket: os.Getenv("NOTIFY_SOCKET")}
+	usec, err := strconv.ParseUint(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec == 0 {
+		return n
+	}
+	// watchdog of another process is not ours to ping
+	if pid, err := strconv.Atoi(os.Getenv("WATCHDOG_PID")); err == nil && pid != os.Getpid() {
+		return n
+	}
+	n.watchdog = time.Duration(usec) * time.Microsecond
+	return n
+}
+
+// notify sends the state to the notification socket, abstract sockets start with @
+func (n *systemdNotifier) notify(state string) error {
+	if n == nil || n.socket == "" {
+		return nil
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: n.socket, Net: "unixgram"})
+	if err != nil {
+		return errors.Wrap(err, "could not connect to systemd notification socket")
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return errors.Wrap(err, "could not notify systemd")
+	}
+	return nil
+}
+
+// ready tells systemd once that the node has started all its services
+func (n *systemdNotifier) ready() {
+	if n == nil {
+		return
+	}
+	n.readyOnce.Do(func() {
+		if err := n.notify("READY=1\nSTATUS=All services have been started"); err != nil {
+			log.WithError(err).Warn("Could not notify systemd about readiness")
+		}
+	})
+}
+
+// pingWatchdog tells systemd that the node is alive
+func (n *systemdNotifier) pingWatchdog() {
+	if n == nil || n.watchdog == 0 {
+		return
+	}
+	if err := n.notify("WATCHDOG=1"); err != nil {
+		log.WithError(err).Warn("Could not ping systemd watchdog")
+	}
+}
+
+// stopping tells systemd that the node is shutting down
+func (n *systemdNotifier) stopping() {
+	if err := n.notify("STOPPING=1"); err != nil {
+		log.WithError(err).Warn("Could not notify systemd about shutdown")
+	}
+}
+
+// checkPeriod returns the period of health checks, which ping the watchdog at least twice per its timeout
+func (n *systemdNotifier) checkPeriod(period time.Duration) time.Duration {
+	if n != nil && n.watchdog > 0 && n.watchdog/2 < period {
+		return n.watchdog / 2
+	}
+	return period
+}
@@ -0,0 +1,62 @@
+// +build !windows
+
+package node
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func Test_NewSystemdNotifier(t *testing.T) {
+	defer os.Unsetenv("NOTIFY_SOCKET")
+	defer os.Unsetenv("WATCHDOG_USEC")
+	defer os.Unsetenv("WATCHDOG_PID")
+
+	require.NoError(t, os.Setenv("NOTIFY_SOCKET", "/run/systemd/notify"))
+	require.NoError(t, os.Setenv("WATCHDOG_USEC", "20000000"))
+	n := newSystemdNotifier()
+	assert.Equal(t, "/run/systemd/notify", n.socket)
+	assert.Equal(t, 20*time.Second, n.watchdog)
+	assert.Equal(t, 5*time.Second, n.checkPeriod(5*time.Second))
+	assert.Equal(t, 10*time.Second, n.checkPeriod(time.Minute))
+
+	require.NoError(t, os.Setenv("WATCHDOG_PID", strconv.Itoa(os.Getpid()+1)))
+	assert.Equal(t, time.Duration(0), newSystemdNotifier().watchdog)
+}
+
+func Test_SystemdNotifier(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "notify")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	require.NoError(t, err)
+	defer conn.Close()
+	receive := func() string {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		buf := make([]byte, 256)
+		n, err := conn.Read(buf)
+		require.NoError(t, err)
+		return string(buf[:n])
+	}
+
+	n := &systemdNotifier{socket: socket, watchdog: time.Second}
+	n.ready()
+	n.ready()
+	n.pingWatchdog()
+	n.stopping()
+	assert.Equal(t, "READY=1\nSTATUS=All services have been started", receive())
+	// readiness is sent once
+	assert.Equal(t, "WATCHDOG=1", receive())
+	assert.Equal(t, "STOPPING=1", receive())
+
+	// notifier without socket does nothing
+	var disabled *systemdNotifier
+	disabled.ready()
+	disabled.pingWatchdog()
+	disabled.stopping()
+}
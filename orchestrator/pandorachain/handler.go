@@ -2,6 +2,8 @@ package pandorachain
 
 import (
 	"context"
+	"time"
+
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
@@ -11,9 +13,19 @@ import (
 //	- cache and store header and header hash with status
 //  - send to consensus service for checking header with vanguard header for confirmation
 func (s *Service) OnNewPendingHeader(ctx context.Context, header *eth1Types.Header) error {
+	if s.isWrongNetwork() {
+		log.WithField("headerHash", header.Hash()).Warn("Pandora node is on a different network, skipping header")
+		return nil
+	}
+
+	start := time.Now()
+	defer s.metrics.headerProcessingTimer.UpdateSince(start)
+	s.metrics.headersReceived.Inc(1)
+
 	var panExtraDataWithSig types.PanExtraDataWithBLSSig
 	if err := rlp.DecodeBytes(header.Extra, &panExtraDataWithSig); err != nil {
 		log.WithError(err).Error("Failed to decode extra data fields")
+		s.metrics.headersFailed.Inc(1)
 		return err
 	}
 
@@ -0,0 +1,79 @@
+package pandorachain
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// maximum number of requests sent in a single batch call to pandora node
+var headersBatchSize = uint64(100)
+
+var (
+	errInvalidHeaderRange = errors.New("invalid header range, from is greater than to")
+	errNotConnected       = errors.New("pandora client is not connected")
+)
+
+// HeadersByRange fetches pandora headers from block number `from` to `to` (inclusive) using batched
+// `eth_getBlockByNumber` requests. Missing headers are reported as an error.
+func (s *Service) HeadersByRange(from, to uint64) ([]*eth1Types.Header, error) {
+	if from > to {
+		return nil, errInvalidHeaderRange
+	}
+	client := s.client()
+	if client == nil {
+		return nil, errNotConnected
+	}
+
+	headers := make([]*eth1Types.Header, 0, to-from+1)
+	for start := from; start <= to; start += headersBatchSize {
+		end := start + headersBatchSize - 1
+		if end > to || end < start {
+			end = to
+		}
+
+		batchHeaders := make([]*eth1Types.Header, end-start+1)
+		batch := make([]rpc.BatchElem, end-start+1)
+		for i := range batch {
+			batch[i] = rpc.BatchElem{
+				Method: "eth_getBlockByNumber",
+				Args:   []interface{}{hexutil.EncodeUint64(start + uint64(i)), false},
+				Result: &batchHeaders[i],
+			}
+		}
+		if err := client.BatchCallContext(s.ctx, batch); err != nil {
+			return nil, errors.Wrap(err, "could not send batch request to pandora node")
+		}
+		for i, elem := range batch {
+			if elem.Error != nil {
+				return nil, errors.Wrapf(elem.Error, "could not fetch pandora header %d", start+uint64(i))
+			}
+			if batchHeaders[i] == nil {
+				return nil, errors.Errorf("pandora header %d not found", start+uint64(i))
+			}
+		}
+		headers = append(headers, batchHeaders...)
+
+		// prevent overflow when `to` is the maximum block number
+		if end == to {
+			break
+		}
+	}
+
+	log.WithField("from", from).WithField("to", to).Debug("Fetched pandora headers by range")
+	return headers, nil
+}
+
+// LatestBlockNumber returns the number of the latest block which pandora node knows about
+func (s *Service) LatestBlockNumber() (uint64, error) {
+	client := s.client()
+	if client == nil {
+		return 0, errNotConnected
+	}
+	var blockNumber hexutil.Uint64
+	if err := client.CallContext(s.ctx, &blockNumber, "eth_blockNumber"); err != nil {
+		return 0, errors.Wrap(err, "could not query pandora block number")
+	}
+	return uint64(blockNumber), nil
+}
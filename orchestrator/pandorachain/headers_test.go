@@ -0,0 +1,50 @@
+package pandorachain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+// Test_PandoraSvc_HeadersByRange checks batch header fetching across several batches
+func Test_PandoraSvc_HeadersByRange(t *testing.T) {
+	ctx := context.Background()
+	defer func(size uint64) { headersBatchSize = size }(headersBatchSize)
+	headersBatchSize = 4
+
+	inProcServer, _ := SetupInProcServer(t)
+	defer inProcServer.Stop()
+
+	panSvc := SetupPandoraSvc(ctx, t, DialInProcClient(inProcServer))
+	panSvc.rpcClient = rpc.DialInProc(inProcServer)
+
+	headers, err := panSvc.HeadersByRange(3, 13)
+	require.NoError(t, err)
+	require.Equal(t, 11, len(headers))
+	for i, header := range headers {
+		assert.Equal(t, uint64(3+i), header.Number.Uint64())
+	}
+
+	_, err = panSvc.HeadersByRange(5, 4)
+	assert.ErrorContains(t, errInvalidHeaderRange.Error(), err)
+}
+
+// Test_PandoraSvc_LatestBlockNumber checks latest block number query
+func Test_PandoraSvc_LatestBlockNumber(t *testing.T) {
+	ctx := context.Background()
+
+	inProcServer, _ := SetupInProcServer(t)
+	defer inProcServer.Stop()
+
+	panSvc := SetupPandoraSvc(ctx, t, DialInProcClient(inProcServer))
+	_, err := panSvc.LatestBlockNumber()
+	assert.ErrorContains(t, errNotConnected.Error(), err)
+
+	panSvc.rpcClient = rpc.DialInProc(inProcServer)
+	blockNumber, err := panSvc.LatestBlockNumber()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(testLatestBlockNumber), blockNumber)
+}
@@ -0,0 +1,135 @@
+package pandorachain
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// time to wait between two pandora connection health checks.
+var healthCheckPeriod = 10 * time.Second
+
+var (
+	errChainIDMismatch = errors.New("pandora chain id does not match with configured chain id")
+)
+
+// checkChainID probes `eth_chainId` from pandora node and verifies it with the configured chain id.
+// Verification is skipped when chain id is not configured.
+func (s *Service) checkChainID() error {
+	_, err := checkChainID(s.ctx, s.client(), s.chainID)
+	return err
+}
+
+// checkChainID queries chain id of pandora node and verifies it with the expected one, zero skips verification
+func checkChainID(ctx context.Context, client *rpc.Client, expected uint64) (*big.Int, error) {
+	var chainID hexutil.Big
+	if err := client.CallContext(ctx, &chainID, "eth_chainId"); err != nil {
+		return nil, errors.Wrap(err, "could not query pandora chain id")
+	}
+	if expected != 0 && (*big.Int)(&chainID).Cmp(new(big.Int).SetUint64(expected)) != 0 {
+		return nil, errors.Wrapf(errChainIDMismatch, "expected %d, got %s", expected, (*big.Int)(&chainID).String())
+	}
+	return (*big.Int)(&chainID), nil
+}
+
+// CheckEndpoint connects to the pandora node of the endpoint and verifies its chain id with the expected one,
+// without starting the service. Zero expected chain id skips verification. It returns chain id of the node.
+func CheckEndpoint(ctx context.Context, endpoint string, expectedChainID uint64) (*big.Int, error) {
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not dial pandora node")
+	}
+	defer client.Close()
+	return checkChainID(ctx, client, expectedChainID)
+}
+
+// runHealthCheck periodically probes pandora connection and keeps the result in health error
+// which is exposed through service status.
+func (s *Service) runHealthCheck() {
+	defer shared.RecoverService(s)
+	ticker := time.NewTicker(healthCheckPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			client := s.connectedClient()
+			if client == nil {
+				continue
+			}
+			_, err := checkChainID(s.ctx, client, s.chainID)
+			prevErr := s.healthError()
+			s.setHealthError(err)
+
+			if err != nil && prevErr == nil {
+				log.WithError(err).WithField("endpoint", s.endpoint).Error("Pandora connection is unhealthy")
+			} else if err == nil && prevErr != nil {
+				log.WithField("endpoint", s.endpoint).Info("Pandora connection is healthy again")
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// Endpoint returns the rpc endpoint of pandora node
+func (s *Service) Endpoint() string {
+	return s.endpoint
+}
+
+// Connected reports whether the service is connected with pandora node
+func (s *Service) Connected() bool {
+	s.connLock.RLock()
+	defer s.connLock.RUnlock()
+	return s.connected
+}
+
+// EndpointStatus reports the connection state and client version of the pandora node
+func (s *Service) EndpointStatus(ctx context.Context) *types.EndpointStatus {
+	client := s.connectedClient()
+	status := &types.EndpointStatus{
+		Name:      "pandora",
+		Endpoint:  s.endpoint,
+		Connected: client != nil,
+	}
+	if err := s.healthError(); err != nil {
+		status.Error = err.Error()
+	} else if err := s.runErr(); err != nil {
+		status.Error = err.Error()
+	}
+	if client == nil {
+		return status
+	}
+	var clientVersion string
+	if err := client.CallContext(ctx, &clientVersion, "web3_clientVersion"); err != nil {
+		log.WithError(err).Debug("Could not query pandora client version")
+		return status
+	}
+	status.Version = clientVersion
+	return status
+}
+
+// healthError returns the last pandora connection health check error
+func (s *Service) healthError() error {
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	return s.healthErr
+}
+
+// setHealthError stores the latest pandora connection health check error
+func (s *Service) setHealthError(err error) {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+	s.healthErr = err
+}
+
+// isWrongNetwork returns true when pandora node reports different chain id than the configured one
+func (s *Service) isWrongNetwork() bool {
+	return errors.Is(s.healthError(), errChainIDMismatch)
+}
@@ -1,6 +1,7 @@
 package iface
 
 import (
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 )
@@ -9,4 +10,7 @@ type PandoraService interface {
 	SubscribeHeaderInfoEvent(chan<- *types.PandoraHeaderInfo) event.Subscription
 	StopPandoraSubscription()
 	ResumePandoraSubscription() error
+	HeadersByRange(from, to uint64) ([]*eth1Types.Header, error)
+	LatestBlockNumber() (uint64, error)
+	ConfirmBlock(blockStatus *types.BlockStatus) error
 }
@@ -0,0 +1,29 @@
+package pandorachain
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+)
+
+// pandoraMetrics collects pandora chain service metrics of the main network
+var pandoraMetrics = metrics.NewCollector("pandorachain")
+
+// serviceMetrics holds pandora chain service metrics. Metric names do not contain the pandora endpoint, so that
+// series are kept when the endpoint changes. Orchestrators which are scraped into the same prometheus are told
+// apart by their scrape target.
+type serviceMetrics struct {
+	headersReceived       metrics.Counter
+	headersFailed         metrics.Counter
+	reconnects            metrics.Counter
+	headerProcessingTimer metrics.Timer
+}
+
+// newServiceMetrics registers pandora chain service metrics of the given network, empty for the main network
+func newServiceMetrics(network string) *serviceMetrics {
+	collector := pandoraMetrics.Sub(network)
+	return &serviceMetrics{
+		headersReceived:       collector.Counter("headers/received"),
+		headersFailed:         collector.Counter("headers/failed"),
+		reconnects:            collector.Counter("subscription/reconnects"),
+		headerProcessingTimer: collector.Timer("headers/processing"),
+	}
+}
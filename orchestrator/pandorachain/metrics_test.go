@@ -0,0 +1,33 @@
+package pandorachain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+// Test_ServiceMetrics checks that received headers, headers which can not be decoded and their processing time
+// are counted
+func Test_ServiceMetrics(t *testing.T) {
+	// metrics are enabled after the start of the process, e.g. by the config file
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = false }()
+	for _, name := range []string{"headers/received", "headers/failed", "headers/processing"} {
+		metrics.DefaultRegistry.Unregister(pandoraMetrics.Name(name))
+	}
+	ctx := context.Background()
+	panSvc := SetupPandoraSvc(ctx, t, DialRPCClient())
+
+	require.NoError(t, panSvc.OnNewPendingHeader(ctx, testutil.NewEth1Header(1)))
+	invalidHeader := testutil.NewEth1Header(2)
+	invalidHeader.Extra = []byte{0x01}
+	require.NotNil(t, panSvc.OnNewPendingHeader(ctx, invalidHeader))
+
+	assert.Equal(t, int64(2), panSvc.metrics.headersReceived.Count())
+	assert.Equal(t, int64(1), panSvc.metrics.headersFailed.Count())
+	assert.Equal(t, int64(2), panSvc.metrics.headerProcessingTimer.Count())
+}
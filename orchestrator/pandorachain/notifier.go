@@ -0,0 +1,32 @@
+package pandorachain
+
+import (
+	"context"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// maximum time to wait for pandora node to acknowledge a verification verdict
+var confirmBlockTimeout = 5 * time.Second
+
+// ConfirmBlock pushes verification verdict of a pandora header to pandora node by `orc_confirmBlock`
+// so that pandora can promote the block to canonical or drop it without polling orchestrator.
+func (s *Service) ConfirmBlock(blockStatus *types.BlockStatus) error {
+	client := s.connectedClient()
+	if client == nil {
+		return errNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(s.ctx, confirmBlockTimeout)
+	defer cancel()
+
+	var accepted bool
+	if err := client.CallContext(ctx, &accepted, "orc_confirmBlock", blockStatus); err != nil {
+		return errors.Wrap(err, "could not push verification verdict to pandora node")
+	}
+	log.WithField("headerHash", blockStatus.Hash).WithField("status", blockStatus.Status).
+		WithField("accepted", accepted).Debug("Pushed verification verdict to pandora node")
+	return nil
+}
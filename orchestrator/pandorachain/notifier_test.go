@@ -0,0 +1,34 @@
+package pandorachain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// Test_PandoraSvc_ConfirmBlock checks that verification verdict reaches pandora node
+func Test_PandoraSvc_ConfirmBlock(t *testing.T) {
+	ctx := context.Background()
+	inProcServer, panService := SetupInProcServer(t)
+	defer inProcServer.Stop()
+
+	panSvc := SetupPandoraSvc(ctx, t, DialInProcClient(inProcServer))
+	blockStatus := &types.BlockStatus{
+		Hash:          common.HexToHash("0x34"),
+		Status:        types.Verified,
+		FinalizedSlot: 12,
+	}
+	assert.ErrorContains(t, errNotConnected.Error(), panSvc.ConfirmBlock(blockStatus))
+
+	panSvc.rpcClient = rpc.DialInProc(inProcServer)
+	panSvc.connected = true
+	require.NoError(t, panSvc.ConfirmBlock(blockStatus))
+
+	confirmed := <-panService.confirmedCh
+	assert.DeepEqual(t, blockStatus, confirmed)
+}
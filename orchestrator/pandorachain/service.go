@@ -10,11 +10,20 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/backoff"
+	"github.com/lukso-network/lukso-orchestrator/shared/eventbuffer"
+	"github.com/lukso-network/lukso-orchestrator/shared/feed"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 )
 
-// time to wait before trying to reconnect.
-var reConPeriod = 2 * time.Second
+// delays between attempts to reconnect with pandora node, they are taken by services when they are created
+var reconnectBackoff = backoff.Config{
+	InitialDelay: 2 * time.Second,
+	MaxDelay:     30 * time.Second,
+	Multiplier:   2,
+	Jitter:       0.2,
+}
 
 // DialRPCFn dials to the given endpoint
 type DialRPCFn func(endpoint string) (*rpc.Client, error)
@@ -34,8 +43,17 @@ type Service struct {
 	connected bool
 	endpoint  string
 	rpcClient *rpc.Client
+	connLock  sync.RWMutex // guards connected, rpcClient, isRunning and runError, which are read by other services
 	dialRPCFn DialRPCFn
 	namespace string
+	subMethod string
+	chainID   uint64
+	healthErr error
+	ready     chan struct{}
+	readyOnce sync.Once
+
+	syncCheckPeriod  time.Duration
+	reconnectBackoff backoff.Config
 
 	// subscription
 	conInfoSubErrCh      chan error
@@ -47,15 +65,20 @@ type Service struct {
 	db    db.Database
 	cache cache.PandoraHeaderCache
 
-	scope                 event.SubscriptionScope
-	pandoraHeaderInfoFeed event.Feed
+	pandoraHeaderInfoFeed *feed.Feed
+
+	metrics *serviceMetrics
 }
 
-// NewService creates new service with pandora ws or ipc endpoint, pandora service namespace and db
+// NewService creates new service of the given network, empty for the main network, with pandora ws or ipc
+// endpoint, pandora service namespace, pending header subscription method, expected chain id and db
 func NewService(
 	ctx context.Context,
+	network string,
 	endpoint string,
 	namespace string,
+	subMethod string,
+	chainID uint64,
 	db db.Database,
 	cache cache.PandoraHeaderCache,
 	dialRPCFn DialRPCFn,
@@ -64,15 +87,23 @@ func NewService(
 	ctx, cancel := context.WithCancel(ctx)
 	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
 	return &Service{
-		ctx:             ctx,
-		cancel:          cancel,
-		endpoint:        endpoint,
-		dialRPCFn:       dialRPCFn,
-		namespace:       namespace,
-		conInfoSubErrCh: make(chan error),
-		conDisconnect:   make(chan struct{}),
-		db:              db,
-		cache:           cache,
+		ctx:              ctx,
+		cancel:           cancel,
+		endpoint:         endpoint,
+		dialRPCFn:        dialRPCFn,
+		namespace:        namespace,
+		subMethod:        subMethod,
+		chainID:          chainID,
+		conInfoSubErrCh:  make(chan error),
+		conDisconnect:    make(chan struct{}),
+		ready:            make(chan struct{}),
+		syncCheckPeriod:  syncCheckPeriod,
+		reconnectBackoff: reconnectBackoff,
+		db:               db,
+		cache:            cache,
+		metrics:          newServiceMetrics(network),
+
+		pandoraHeaderInfoFeed: feed.New(feed.NetworkName(network, "pandora/headerinfo"), (*types.PandoraHeaderInfo)(nil), eventbuffer.DefaultConfig),
 	}, nil
 }
 
@@ -82,8 +113,10 @@ func (s *Service) Start() {
 	if s.endpoint == "" {
 		return
 	}
+	go s.runHealthCheck()
 	go func() {
-		s.isRunning = true
+		defer shared.RecoverService(s)
+		s.setRunning(true)
 		s.waitForConnection()
 		if s.ctx.Err() != nil {
 			log.Info("Context closed, exiting pandora goroutine")
@@ -98,78 +131,126 @@ func (s *Service) Stop() error {
 		defer s.cancel()
 	}
 	s.closeClients()
-	s.scope.Close()
+	s.pandoraHeaderInfoFeed.Close()
 
 	return nil
 }
 
 func (s *Service) Status() error {
+	s.connLock.RLock()
+	running, runError := s.isRunning, s.runError
+	s.connLock.RUnlock()
 	// Service don't start
-	if !s.isRunning {
+	if !running {
 		return nil
 	}
 	// get error from run function
-	if s.runError != nil {
-		return s.runError
+	if runError != nil {
+		return runError
 	}
-	return nil
+	// get error from connection health check
+	return s.healthError()
+}
+
+// Ready returns a channel which is closed once the first connection with pandora chain is established
+func (s *Service) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// markReady notifies dependent services that pandora chain is connected
+func (s *Service) markReady() {
+	s.readyOnce.Do(func() {
+		close(s.ready)
+	})
 }
 
 // closes down our active eth1 clients.
 func (s *Service) closeClients() {
-	if s.rpcClient != nil {
-		s.rpcClient.Close()
+	if client := s.client(); client != nil {
+		client.Close()
+	}
+}
+
+// client returns the rpc client of pandora node, nil before the node has been dialed
+func (s *Service) client() *rpc.Client {
+	s.connLock.RLock()
+	defer s.connLock.RUnlock()
+	return s.rpcClient
+}
+
+// connectedClient returns the rpc client of pandora node while the service is connected, nil otherwise
+func (s *Service) connectedClient() *rpc.Client {
+	s.connLock.RLock()
+	defer s.connLock.RUnlock()
+	if !s.connected {
+		return nil
 	}
+	return s.rpcClient
+}
+
+// setConnected records whether the service is connected and subscribed to pandora node
+func (s *Service) setConnected(connected bool) {
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+	s.connected = connected
+}
+
+// runErr returns the last error of connecting or subscribing to pandora chain
+func (s *Service) runErr() error {
+	s.connLock.RLock()
+	defer s.connLock.RUnlock()
+	return s.runError
+}
+
+// setRunError records the last error of connecting or subscribing to pandora chain
+func (s *Service) setRunError(err error) {
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+	s.runError = err
+}
+
+// setRunning records whether the main loop of the service is running
+func (s *Service) setRunning(running bool) {
+	s.connLock.Lock()
+	defer s.connLock.Unlock()
+	s.isRunning = running
 }
 
 // waitForConnection waits for a connection with pandora chain. Until a successful connection and subscription with
 // pandora chain, it retries again and again.
 func (s *Service) waitForConnection() {
 	log.Debug("Waiting for the connection")
-	var err error
-	if err = s.connectToChain(); err == nil {
-		log.WithField("endpoint", s.endpoint).Info("Connected and subscribed to pandora chain")
-		s.connected = true
-		return
-	}
-	log.WithError(err).Warn("Could not connect or subscribe to pandora chain")
-	s.runError = err
-	ticker := time.NewTicker(reConPeriod)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			log.WithField("endpoint", s.endpoint).Debug("Dialing pandora node")
-			var errConnect error
-			if errConnect = s.connectToChain(); errConnect != nil {
-				log.WithError(errConnect).Warn("Could not connect or subscribe to pandora chain")
-				s.runError = errConnect
-				continue
-			}
-			s.connected = true
-			s.runError = nil
-			log.WithField("endpoint", s.endpoint).Info("Connected and subscribed to pandora chain")
-			return
-		case <-s.ctx.Done():
-			log.Info("Received cancelled context, closing existing pandora client connection service")
-			return
+	err := backoff.Retry(s.ctx, s.reconnectBackoff, func() error {
+		log.WithField("endpoint", s.endpoint).Debug("Dialing pandora node")
+		if err := s.connectToChain(); err != nil {
+			log.WithError(err).Warn("Could not connect or subscribe to pandora chain")
+			s.setRunError(err)
+			return err
 		}
+		return nil
+	})
+	if err != nil {
+		log.Info("Received cancelled context, closing existing pandora client connection service")
+		return
 	}
+	s.setConnected(true)
+	s.markReady()
+	s.setRunError(nil)
+	log.WithField("endpoint", s.endpoint).Info("Connected and subscribed to pandora chain")
 }
 
 // run subscribes to all the services for the ETH1.0 chain.
 func (s *Service) run(done <-chan struct{}) {
 	log.Debug("Pandora chain service is starting")
-	s.runError = nil
+	s.setRunError(nil)
 
 	// the loop waits for any error which comes from consensus info subscription
 	// if any subscription error happens, it will try to reconnect and re-subscribe with pandora chain again.
 	for {
 		select {
 		case <-done:
-			s.isRunning = false
-			s.runError = nil
+			s.setRunning(false)
+			s.setRunError(nil)
 			log.Info("Context closed, exiting pandora chain service goroutine")
 			return
 		case err := <-s.conInfoSubErrCh:
@@ -196,12 +277,26 @@ func (s *Service) ResumePandoraSubscription() error {
 
 // connectToChain dials to pandora chain and creates rpcClient and subscribe
 func (s *Service) connectToChain() error {
-	if s.rpcClient == nil {
+	if s.client() == nil {
 		panRPCClient, err := s.dialRPCFn(s.endpoint)
 		if err != nil {
 			return err
 		}
+		s.connLock.Lock()
 		s.rpcClient = panRPCClient
+		s.connLock.Unlock()
+	}
+
+	// refuse to connect with pandora node from wrong network
+	if err := s.checkChainID(); err != nil {
+		s.setHealthError(err)
+		return err
+	}
+	s.setHealthError(nil)
+
+	// delay header processing until pandora node is synced
+	if err := s.waitForSync(); err != nil {
+		return err
 	}
 
 	// connect to pandora subscription
@@ -213,13 +308,19 @@ func (s *Service) connectToChain() error {
 
 // retryToConnectAndSubscribe retries to pandora chain in case of any failure.
 func (s *Service) retryToConnectAndSubscribe(err error) {
-	s.runError = err
-	s.connected = false
+	s.setRunError(err)
+	s.setConnected(false)
+	s.metrics.reconnects.Inc(1)
 	// Back off for a while before resuming dialing the pandora node.
-	time.Sleep(reConPeriod)
-	go s.waitForConnection()
+	if err := backoff.Sleep(s.ctx, s.reconnectBackoff.Delay(1)); err != nil {
+		return
+	}
+	go func() {
+		defer shared.RecoverService(s)
+		s.waitForConnection()
+	}()
 	// Reset run error in the event of a successful connection.
-	s.runError = nil
+	s.setRunError(nil)
 }
 
 // subscribe subscribes to pandora events
@@ -233,7 +334,7 @@ func (s *Service) subscribe() error {
 		Debug("Start subscribing to pandora client for pending headers")
 
 	// subscribe to pandora client for pending headers
-	sub, err := s.SubscribePendingHeaders(s.ctx, filter, s.namespace, s.rpcClient)
+	sub, err := s.SubscribePendingHeaders(s.ctx, filter, s.namespace, s.subMethod, s.client())
 	if err != nil {
 		log.WithError(err).Warn("Could not subscribe to pandora client for new pending headers")
 		return err
@@ -243,5 +344,5 @@ func (s *Service) subscribe() error {
 }
 
 func (s *Service) SubscribeHeaderInfoEvent(ch chan<- *types.PandoraHeaderInfo) event.Subscription {
-	return s.scope.Track(s.pandoraHeaderInfoFeed.Subscribe(ch))
+	return s.pandoraHeaderInfoFeed.Subscribe(ch)
 }
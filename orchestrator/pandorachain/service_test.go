@@ -2,9 +2,12 @@ package pandorachain
 
 import (
 	"context"
+	"github.com/lukso-network/lukso-orchestrator/shared/backoff"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
 	"github.com/pkg/errors"
 	logTest "github.com/sirupsen/logrus/hooks/test"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -14,7 +17,8 @@ import (
 func Test_PandoraSvc_StartStop(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ctx := context.Background()
-	reConPeriod = 1 * time.Second
+	defer func(config backoff.Config) { reconnectBackoff = config }(reconnectBackoff)
+	reconnectBackoff = backoff.Constant(time.Second)
 
 	inProcServer, _ := SetupInProcServer(t)
 	defer inProcServer.Stop()
@@ -25,6 +29,12 @@ func Test_PandoraSvc_StartStop(t *testing.T) {
 	time.Sleep(1 * time.Second)
 	assert.LogsContain(t, hook, "Connected and subscribed to pandora chain")
 
+	select {
+	case <-panSvc.Ready():
+	default:
+		t.Error("pandora service should be ready after connection")
+	}
+
 	hook.Reset()
 	assert.NoError(t, panSvc.Stop())
 }
@@ -33,7 +43,8 @@ func Test_PandoraSvc_StartStop(t *testing.T) {
 func Test_PandoraSvc_RetrySub(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ctx := context.Background()
-	reConPeriod = 1 * time.Second
+	defer func(config backoff.Config) { reconnectBackoff = config }(reconnectBackoff)
+	reconnectBackoff = backoff.Constant(time.Second)
 
 	inProcServer, _ := SetupInProcServer(t)
 	defer inProcServer.Stop()
@@ -51,3 +62,74 @@ func Test_PandoraSvc_RetrySub(t *testing.T) {
 	hook.Reset()
 	assert.NoError(t, panSvc.Stop())
 }
+
+// Test_PandoraSvc_WaitForSync checks that pandora service does not subscribe to pending headers
+// until pandora node reports that it is synced.
+func Test_PandoraSvc_WaitForSync(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	defer func(period time.Duration) { syncCheckPeriod = period }(syncCheckPeriod)
+	syncCheckPeriod = 500 * time.Millisecond
+
+	inProcServer, panService := SetupInProcServer(t)
+	defer inProcServer.Stop()
+	atomic.StoreUint32(&panService.syncing, 1)
+
+	panSvc := SetupPandoraSvc(ctx, t, DialInProcClient(inProcServer))
+	panSvc.Start()
+
+	time.Sleep(1 * time.Second)
+	assert.LogsContain(t, hook, "Pandora node is syncing, waiting before processing pending headers")
+	assert.LogsDoNotContain(t, hook, "Connected and subscribed to pandora chain")
+
+	atomic.StoreUint32(&panService.syncing, 0)
+	time.Sleep(1 * time.Second)
+	assert.LogsContain(t, hook, "Pandora node is synced, resuming header processing")
+	assert.LogsContain(t, hook, "Connected and subscribed to pandora chain")
+
+	hook.Reset()
+	assert.NoError(t, panSvc.Stop())
+}
+
+// Test_PandoraSvc_ChainIDMismatch checks that pandora service does not subscribe to pandora node
+// which reports different chain id than the configured one
+func Test_PandoraSvc_ChainIDMismatch(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	defer func(config backoff.Config) { reconnectBackoff = config }(reconnectBackoff)
+	reconnectBackoff = backoff.Constant(time.Second)
+
+	inProcServer, panService := SetupInProcServer(t)
+	defer inProcServer.Stop()
+	atomic.StoreUint64(&panService.chainID, testChainID+1)
+
+	panSvc := SetupPandoraSvc(ctx, t, DialInProcClient(inProcServer))
+	panSvc.Start()
+
+	time.Sleep(1 * time.Second)
+	assert.LogsDoNotContain(t, hook, "Connected and subscribed to pandora chain")
+	assert.ErrorContains(t, errChainIDMismatch.Error(), panSvc.Status())
+
+	atomic.StoreUint64(&panService.chainID, testChainID)
+	time.Sleep(2 * time.Second)
+	assert.LogsContain(t, hook, "Connected and subscribed to pandora chain")
+	assert.NoError(t, panSvc.Status())
+
+	hook.Reset()
+	assert.NoError(t, panSvc.Stop())
+}
+
+// Test_CheckEndpoint checks that chain id of pandora node is verified without starting pandora service
+func Test_CheckEndpoint(t *testing.T) {
+	inProcServer, _ := SetupInProcServer(t)
+	defer inProcServer.Stop()
+	httpServer := httptest.NewServer(inProcServer)
+	defer httpServer.Close()
+
+	chainID, err := CheckEndpoint(context.Background(), httpServer.URL, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(testChainID), chainID.Uint64())
+
+	_, err = CheckEndpoint(context.Background(), httpServer.URL, testChainID+1)
+	assert.ErrorContains(t, errChainIDMismatch.Error(), err)
+}
@@ -5,6 +5,7 @@ import (
 
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 	"github.com/pkg/errors"
 )
@@ -18,10 +19,11 @@ func (s *Service) SubscribePendingHeaders(
 	ctx context.Context,
 	crit *types.PandoraPendingHeaderFilter,
 	namespace string,
+	subMethod string,
 	client *rpc.Client,
 ) (*rpc.ClientSubscription, error) {
 	ch := make(chan *eth1Types.Header)
-	sub, err := client.Subscribe(ctx, namespace, ch, "newPendingBlockHeaders", crit)
+	sub, err := client.Subscribe(ctx, namespace, ch, subMethod, crit)
 	if nil != err {
 		return nil, err
 	}
@@ -29,6 +31,7 @@ func (s *Service) SubscribePendingHeaders(
 
 	// Start up a dispatcher to feed into the callback
 	go func() {
+		defer shared.RecoverService(s)
 		for {
 			select {
 			case newPendingHeader := <-ch:
@@ -44,6 +47,7 @@ func (s *Service) SubscribePendingHeaders(
 				return
 			case err := <-sub.Err():
 				log.WithError(err).Debug("Got subscription error")
+				shared.ReportSubscriptionDrop("pandora", "pendingBlockHeaders", err)
 				s.conInfoSubErrCh <- err
 				return
 			case <-ctx.Done():
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/backoff"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
@@ -16,7 +17,8 @@ import (
 func Test_PandoraSvc_PendingHeaderSub(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ctx := context.Background()
-	reConPeriod = 1 * time.Second
+	defer func(config backoff.Config) { reconnectBackoff = config }(reconnectBackoff)
+	reconnectBackoff = backoff.Constant(time.Second)
 
 	inProcServer, panService := SetupInProcServer(t)
 	defer inProcServer.Stop()
@@ -26,7 +28,7 @@ func Test_PandoraSvc_PendingHeaderSub(t *testing.T) {
 	filter := &types.PandoraPendingHeaderFilter{
 		FromBlockHash: common.HexToHash("0000000000000000000000000000000000000000000000000000000000000034"),
 	}
-	panSvc.SubscribePendingHeaders(ctx, filter, "eth", client)
+	panSvc.SubscribePendingHeaders(ctx, filter, "eth", "newPendingBlockHeaders", client)
 	// Prepare new pandora header with extraData with BLS signature
 	newPanHeader := testutil.NewEth1Header(1)
 	panService.pendingHeaderCh <- newPanHeader
@@ -0,0 +1,72 @@
+package pandorachain
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// time to wait before re-checking the sync status of pandora node, it is taken by services when they are created
+var syncCheckPeriod = 5 * time.Second
+
+// syncProgress is the subset of the `eth_syncing` response which is used for logging.
+type syncProgress struct {
+	StartingBlock hexutil.Uint64 `json:"startingBlock"`
+	CurrentBlock  hexutil.Uint64 `json:"currentBlock"`
+	HighestBlock  hexutil.Uint64 `json:"highestBlock"`
+}
+
+// syncStatus queries `eth_syncing` from pandora node. It returns nil progress when pandora is fully synced.
+func (s *Service) syncStatus() (*syncProgress, error) {
+	var raw json.RawMessage
+	if err := s.client().CallContext(s.ctx, &raw, "eth_syncing"); err != nil {
+		return nil, errors.Wrap(err, "could not query pandora sync status")
+	}
+	// pandora returns false when it is not syncing
+	if bytes.Equal(raw, []byte("false")) {
+		return nil, nil
+	}
+	var progress syncProgress
+	if err := json.Unmarshal(raw, &progress); err != nil {
+		return nil, errors.Wrap(err, "could not decode pandora sync status")
+	}
+	return &progress, nil
+}
+
+// waitForSync blocks until pandora node reports that it is synced. Headers from a node which is still
+// syncing belong to an unrelated chain state, so subscription is delayed until sync completes.
+func (s *Service) waitForSync() error {
+	progress, err := s.syncStatus()
+	if err != nil {
+		return err
+	}
+	if progress == nil {
+		log.Debug("Pandora node is synced")
+		return nil
+	}
+
+	ticker := time.NewTicker(s.syncCheckPeriod)
+	defer ticker.Stop()
+
+	for {
+		log.WithField("currentBlock", uint64(progress.CurrentBlock)).
+			WithField("highestBlock", uint64(progress.HighestBlock)).
+			Info("Pandora node is syncing, waiting before processing pending headers")
+
+		select {
+		case <-ticker.C:
+			if progress, err = s.syncStatus(); err != nil {
+				return err
+			}
+			if progress == nil {
+				log.WithField("endpoint", s.endpoint).Info("Pandora node is synced, resuming header processing")
+				return nil
+			}
+		case <-s.ctx.Done():
+			return errors.New("context cancelled while waiting for pandora sync")
+		}
+	}
+}
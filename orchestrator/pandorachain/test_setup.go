@@ -2,21 +2,57 @@ package pandorachain
 
 import (
 	"context"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"math/big"
+	"sync/atomic"
 	"testing"
 )
 
+// testChainID is the chain id which is reported by mocked pandora server
+const testChainID = 808
+
+// testLatestBlockNumber is the latest block number which is reported by mocked pandora server
+const testLatestBlockNumber = 1024
+
 // pandoraChainService
 type pandoraChainService struct {
 	unsubscribed    chan string
 	pendingHeaderCh chan *eth1Types.Header
+	syncing         uint32
+	chainID         uint64
+	confirmedCh     chan *types.BlockStatus
+}
+
+// ConfirmBlock is registered under "orc" namespace and receives verification verdicts
+func (s *pandoraChainService) ConfirmBlock(blockStatus *types.BlockStatus) (bool, error) {
+	s.confirmedCh <- blockStatus
+	return true, nil
+}
+
+// ChainId
+func (s *pandoraChainService) ChainId() (*hexutil.Big, error) {
+	return (*hexutil.Big)(new(big.Int).SetUint64(atomic.LoadUint64(&s.chainID))), nil
+}
+
+// Syncing
+func (s *pandoraChainService) Syncing() (interface{}, error) {
+	if atomic.LoadUint32(&s.syncing) == 0 {
+		return false, nil
+	}
+	return map[string]hexutil.Uint64{
+		"startingBlock": 0,
+		"currentBlock":  1,
+		"highestBlock":  10,
+	}, nil
 }
 
 // Unsubscribe
@@ -26,6 +62,16 @@ func (s *pandoraChainService) Unsubscribe(subid string) {
 	}
 }
 
+// GetBlockByNumber returns a header for every requested block number
+func (s *pandoraChainService) GetBlockByNumber(number hexutil.Uint64, fullTx bool) (*eth1Types.Header, error) {
+	return testutil.NewEth1Header(uint64(number)), nil
+}
+
+// BlockNumber
+func (s *pandoraChainService) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(testLatestBlockNumber)
+}
+
 // NewPendingBlockHeaders
 func (s *pandoraChainService) NewPendingBlockHeaders(
 	ctx context.Context, filter types.PandoraPendingHeaderFilter,
@@ -67,10 +113,15 @@ func SetupInProcServer(t *testing.T) (*rpc.Server, *pandoraChainService) {
 	panService := &pandoraChainService{
 		unsubscribed:    make(chan string),
 		pendingHeaderCh: make(chan *eth1Types.Header),
+		chainID:         testChainID,
+		confirmedCh:     make(chan *types.BlockStatus, 1),
 	}
 	if err := server.RegisterName("eth", panService); err != nil {
 		panic(err)
 	}
+	if err := server.RegisterName("orc", panService); err != nil {
+		panic(err)
+	}
 	return server, panService
 }
 
@@ -82,8 +133,11 @@ func SetupPandoraSvc(ctx context.Context, t *testing.T, dialRPCFn DialRPCFn) *Se
 
 	svc, err := NewService(
 		ctx,
+		"",
 		"ws://127.0.0.1:8546",
 		"eth",
+		"newPendingBlockHeaders",
+		testChainID,
 		testDB.SetupDB(t),
 		cache.NewPanHeaderCache(),
 		dialRPCFn)
@@ -0,0 +1,61 @@
+// +build !windows
+
+package rpc
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// first file descriptor passed by systemd socket activation
+const listenFDsStart = 3
+
+var errNoActivatedSockets = errors.New("no sockets have been passed by socket activation")
+
+// activatedListeners returns the listening sockets passed by systemd socket activation by their names, which are
+// set with FileDescriptorName= of the socket unit. Sockets are taken over once, so they are not inherited by
+// child processes.
+func activatedListeners() (map[string]net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, errNoActivatedSockets
+	}
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count < 1 {
+		return nil, errNoActivatedSockets
+	}
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+	return listenersFromFDs(listenFDsStart, count, names)
+}
+
+// listenersFromFDs creates listeners of count consecutive file descriptors starting at start. Descriptors
+// without a name are named by their position.
+func listenersFromFDs(start, count int, names []string) (map[string]net.Listener, error) {
+	listeners := make(map[string]net.Listener, count)
+	for i := 0; i < count; i++ {
+		fd := start + i
+		syscall.CloseOnExec(fd)
+		name := strconv.Itoa(i)
+		if i < len(names) && names[i] != "" {
+			name = names[i]
+		}
+		file := os.NewFile(uintptr(fd), name)
+		listener, err := net.FileListener(file)
+		// listener holds a duplicate of the descriptor
+		file.Close()
+		if err != nil {
+			closeListeners(listeners)
+			return nil, errors.Wrapf(err, "socket %s passed by socket activation is not a listening socket", name)
+		}
+		listeners[name] = listener
+	}
+	return listeners, nil
+}
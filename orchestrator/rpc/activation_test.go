@@ -0,0 +1,60 @@
+// +build !windows
+
+package rpc
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+// dupFD returns a duplicate of the descriptor of a file, which is owned by the caller
+func dupFD(t *testing.T, file *os.File) int {
+	fd, err := syscall.Dup(int(file.Fd()))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	return fd
+}
+
+func TestActivatedListeners(t *testing.T) {
+	os.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()+1))
+	os.Setenv("LISTEN_FDS", "1")
+	defer os.Unsetenv("LISTEN_PID")
+	defer os.Unsetenv("LISTEN_FDS")
+	_, err := activatedListeners()
+	assert.ErrorContains(t, errNoActivatedSockets.Error(), err)
+
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	file, err := tcpListener.(*net.TCPListener).File()
+	require.NoError(t, err)
+	addr := tcpListener.Addr().String()
+	require.NoError(t, tcpListener.Close())
+
+	listeners, err := listenersFromFDs(dupFD(t, file), 1, []string{"http"})
+	require.NoError(t, err)
+	require.NotNil(t, listeners["http"])
+
+	srv := newHTTPServer(rpc.DefaultHTTPTimeouts)
+	require.NoError(t, srv.enableRPC(nil, httpConfig{}))
+	require.NoError(t, srv.setListener(listeners["http"]))
+	require.NoError(t, srv.start())
+	defer srv.stop()
+	assert.Equal(t, addr, srv.listenAddr())
+	resp := rpcRequest(t, "http://"+addr)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// descriptors which are not listening sockets are rejected
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	defer writer.Close()
+	_, err = listenersFromFDs(dupFD(t, reader), 1, nil)
+	assert.ErrorContains(t, "socket 0 passed by socket activation is not a listening socket", err)
+}
@@ -0,0 +1,12 @@
+package rpc
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// activatedListeners returns an error, socket activation is not supported on windows
+func activatedListeners() (map[string]net.Listener, error) {
+	return nil, errors.New("socket activation is not supported on windows")
+}
@@ -0,0 +1,125 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// errMaintenanceRunning is returned when another maintenance operation has not completed yet
+var errMaintenanceRunning = errors.New("another maintenance operation is running")
+
+type Backend interface {
+	Reverify(fromSlot, toSlot uint64) (*types.ReverifyResult, error)
+	NodeStatus(ctx context.Context) (*types.NodeStatus, error)
+	BackupDB(ctx context.Context) (*types.BackupResult, error)
+	PruneDB(beforeSlot uint64) (*types.PruneResult, error)
+	ScheduleDBCompaction() error
+	RestartService(name, endpoint string) error
+	Promote() (bool, error)
+}
+
+// PrivateAdminAPI offers maintenance operations of orchestrator node. It is only exposed over IPC
+// unless `admin` module is explicitly enabled.
+type PrivateAdminAPI struct {
+	backend Backend
+	// set while a maintenance operation is running, so operations triggered by several operators never overlap
+	maintenance int32
+}
+
+// NewPrivateAdminAPI returns a new PrivateAdminAPI instance.
+func NewPrivateAdminAPI(backend Backend) *PrivateAdminAPI {
+	return &PrivateAdminAPI{backend: backend}
+}
+
+// startMaintenance marks a maintenance operation as running. The returned function must be called when it completes.
+func (api *PrivateAdminAPI) startMaintenance() (func(), error) {
+	if !atomic.CompareAndSwapInt32(&api.maintenance, 0, 1) {
+		return nil, errMaintenanceRunning
+	}
+	return func() { atomic.StoreInt32(&api.maintenance, 0) }, nil
+}
+
+// Reverify refetches data of the given slot range from both chains and recomputes verification,
+// overwriting stored results
+func (api *PrivateAdminAPI) Reverify(ctx context.Context, fromSlot, toSlot uint64) (*types.ReverifyResult, error) {
+	done, err := api.startMaintenance()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	log.WithField("fromSlot", fromSlot).WithField("toSlot", toSlot).Info("Re-verification requested")
+	return api.backend.Reverify(fromSlot, toSlot)
+}
+
+// NodeStatus returns versions, connected endpoints, verification checkpoint, db size, cache sizes
+// and registered services of orchestrator node
+func (api *PrivateAdminAPI) NodeStatus(ctx context.Context) (*types.NodeStatus, error) {
+	return api.backend.NodeStatus(ctx)
+}
+
+// BackupDB writes a consistent copy of the database into the backups directory of the database directory
+func (api *PrivateAdminAPI) BackupDB(ctx context.Context) (*types.BackupResult, error) {
+	done, err := api.startMaintenance()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	log.Info("Database backup requested")
+	return api.backend.BackupDB(ctx)
+}
+
+// PruneDB removes stored verification results and consensus infos of finalized slots before the given slot
+func (api *PrivateAdminAPI) PruneDB(ctx context.Context, beforeSlot uint64) (*types.PruneResult, error) {
+	done, err := api.startMaintenance()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	log.WithField("beforeSlot", beforeSlot).Info("Database pruning requested")
+	return api.backend.PruneDB(beforeSlot)
+}
+
+// CompactDB schedules compaction of the database, which gives the space freed by pruning back to the filesystem.
+// Compaction needs exclusive access to the database, so it runs on the next start of the node.
+func (api *PrivateAdminAPI) CompactDB(ctx context.Context) (bool, error) {
+	log.Info("Database compaction requested")
+	if err := api.backend.ScheduleDBCompaction(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RestartService stops a chain service (vanguardchain or pandorachain) and starts a new instance in its place,
+// connected to the given endpoint or the configured one when it is omitted. Consensus and rpc services keep
+// running and receive the events of the new instance, so a changed endpoint does not require a node restart.
+func (api *PrivateAdminAPI) RestartService(ctx context.Context, name string, endpoint *string) (bool, error) {
+	done, err := api.startMaintenance()
+	if err != nil {
+		return false, err
+	}
+	defer done()
+
+	var target string
+	if endpoint != nil {
+		target = *endpoint
+	}
+	log.WithField("service", name).WithField("endpoint", target).Info("Service restart requested")
+	if err := api.backend.RestartService(name, target); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Promote makes a follower the leader, so it serves confirmations and subscriptions and pushes verdicts to pandora.
+// The follower has verified all along, so it takes over with a current database. It returns false when the node
+// leads already.
+func (api *PrivateAdminAPI) Promote(ctx context.Context) (bool, error) {
+	log.Info("Promotion to leader requested")
+	return api.backend.Promote()
+}
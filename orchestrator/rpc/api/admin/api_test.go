@@ -0,0 +1,111 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+type mockBackend struct {
+	reverifyStarted chan struct{}
+	reverifyDone    chan struct{}
+	compactions     int
+	restarted       []string
+	promoted        bool
+}
+
+func (b *mockBackend) Reverify(fromSlot, toSlot uint64) (*types.ReverifyResult, error) {
+	close(b.reverifyStarted)
+	<-b.reverifyDone
+	return &types.ReverifyResult{FromSlot: fromSlot, ToSlot: toSlot}, nil
+}
+
+func (b *mockBackend) NodeStatus(ctx context.Context) (*types.NodeStatus, error) {
+	return &types.NodeStatus{}, nil
+}
+
+func (b *mockBackend) BackupDB(ctx context.Context) (*types.BackupResult, error) {
+	return &types.BackupResult{Path: "backup"}, nil
+}
+
+func (b *mockBackend) PruneDB(beforeSlot uint64) (*types.PruneResult, error) {
+	return &types.PruneResult{BeforeSlot: beforeSlot}, nil
+}
+
+func (b *mockBackend) ScheduleDBCompaction() error {
+	b.compactions++
+	return nil
+}
+
+func (b *mockBackend) RestartService(name, endpoint string) error {
+	b.restarted = append(b.restarted, name+"@"+endpoint)
+	return nil
+}
+
+func (b *mockBackend) Promote() (bool, error) {
+	if b.promoted {
+		return false, nil
+	}
+	b.promoted = true
+	return true, nil
+}
+
+func TestPrivateAdminAPI_MaintenanceDoesNotOverlap(t *testing.T) {
+	backend := &mockBackend{reverifyStarted: make(chan struct{}), reverifyDone: make(chan struct{})}
+	api := NewPrivateAdminAPI(backend)
+
+	reverified := make(chan error)
+	go func() {
+		_, err := api.Reverify(context.Background(), 1, 10)
+		reverified <- err
+	}()
+	<-backend.reverifyStarted
+
+	_, err := api.BackupDB(context.Background())
+	assert.Equal(t, errMaintenanceRunning, err)
+	_, err = api.PruneDB(context.Background(), 5)
+	assert.Equal(t, errMaintenanceRunning, err)
+
+	close(backend.reverifyDone)
+	require.NoError(t, <-reverified)
+
+	backup, err := api.BackupDB(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "backup", backup.Path)
+	pruned, err := api.PruneDB(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), pruned.BeforeSlot)
+
+	scheduled, err := api.CompactDB(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, true, scheduled)
+	assert.Equal(t, 1, backend.compactions)
+}
+
+func TestPrivateAdminAPI_RestartService(t *testing.T) {
+	backend := &mockBackend{}
+	api := NewPrivateAdminAPI(backend)
+
+	endpoint := "http://127.0.0.1:8546"
+	restarted, err := api.RestartService(context.Background(), "pandorachain", &endpoint)
+	require.NoError(t, err)
+	assert.Equal(t, true, restarted)
+	_, err = api.RestartService(context.Background(), "vanguardchain", nil)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string{"pandorachain@" + endpoint, "vanguardchain@"}, backend.restarted)
+}
+
+func TestPrivateAdminAPI_Promote(t *testing.T) {
+	backend := &mockBackend{}
+	api := NewPrivateAdminAPI(backend)
+
+	promoted, err := api.Promote(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, true, promoted)
+	promoted, err = api.Promote(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, false, promoted)
+}
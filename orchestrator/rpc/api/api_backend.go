@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/ethereum/go-ethereum/common"
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/ethereum/go-ethereum/event"
@@ -21,23 +22,37 @@ type Backend struct {
 	// feed
 	ConsensusInfoFeed    iface.ConsensusInfoFeed
 	VerifiedSlotInfoFeed conIface.VerifiedSlotInfoFeed
+	ProgressReporter     conIface.ProgressReporter
+	Reverifier           conIface.Reverifier
+	ProposerProvider     iface.ProposerProvider
 
 	// db reference
 	ConsensusInfoDB    db.ROnlyConsensusInfoDB
 	VerifiedSlotInfoDB db.ROnlyVerifiedSlotInfoDB
 	InvalidSlotInfoDB  db.ROnlyInvalidSlotInfoDB
+	MaintenanceDB      db.MaintenanceDB
 
 	// cache reference
 	VanguardPendingShardingCache cache.VanguardShardCache
 	PandoraPendingHeaderCache    cache.PandoraHeaderCache
+
+	// number of subsequent verified slots before a slot is reported as verified
+	ConfirmationDepth uint64
+
+	// collects status of orchestrator node
+	NodeStatusProvider func(ctx context.Context) *types.NodeStatus
+	// restarts a single service of orchestrator node
+	ServiceRestarter func(name, endpoint string) error
+	// promotes a follower to leader
+	Promoter func() (bool, error)
 }
 
 func (backend *Backend) SubscribeNewEpochEvent(ch chan<- *types.MinimalEpochConsensusInfoV2) event.Subscription {
 	return backend.ConsensusInfoFeed.SubscribeMinConsensusInfoEvent(ch)
 }
 
-func (backend *Backend) SubscribeNewVerifiedSlotInfoEvent(ch chan<- *types.SlotInfoWithStatus) event.Subscription {
-	return backend.VerifiedSlotInfoFeed.SubscribeVerifiedSlotInfoEvent(ch)
+func (backend *Backend) SubscribeNewVerificationResultEvent(ch chan<- *types.VerificationResult) event.Subscription {
+	return backend.VerifiedSlotInfoFeed.SubscribeVerificationResultEvent(ch)
 }
 
 func (backend *Backend) ConsensusInfoByEpochRange(fromEpoch uint64) ([]*types.MinimalEpochConsensusInfoV2, error) {
@@ -54,6 +69,22 @@ func (backend *Backend) ConsensusInfoByEpochRange(fromEpoch uint64) ([]*types.Mi
 	return epochInfos, nil
 }
 
+// ConsensusInfoRange returns at most limit stored consensus infos of [fromEpoch, toEpoch] range in epoch order
+func (backend *Backend) ConsensusInfoRange(fromEpoch, toEpoch uint64, limit int) ([]*types.MinimalEpochConsensusInfoV2, error) {
+	consensusInfos, err := backend.ConsensusInfoDB.ConsensusInfoRange(fromEpoch, toEpoch, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	latestFinalizedSlot := backend.VerifiedSlotInfoDB.LatestLatestFinalizedSlot()
+	epochInfos := make([]*types.MinimalEpochConsensusInfoV2, len(consensusInfos))
+	for i, epochInfo := range consensusInfos {
+		epochInfos[i] = epochInfo.ConvertToEpochInfoV2()
+		epochInfos[i].FinalizedSlot = latestFinalizedSlot
+	}
+	return epochInfos, nil
+}
+
 func (backend *Backend) VerifiedSlotInfos(fromSlot uint64) map[uint64]*types.SlotInfo {
 	slotInfos, err := backend.VerifiedSlotInfoDB.VerifiedSlotInfos(fromSlot)
 	if err != nil {
@@ -70,6 +101,16 @@ func (backend *Backend) LatestVerifiedSlot() uint64 {
 	return backend.VerifiedSlotInfoDB.LatestSavedVerifiedSlot()
 }
 
+// LatestConfirmedSlot returns the latest verified slot which has enough subsequent verified slots
+// to be reported as verified
+func (backend *Backend) LatestConfirmedSlot() uint64 {
+	latestVerifiedSlot := backend.VerifiedSlotInfoDB.LatestSavedVerifiedSlot()
+	if latestVerifiedSlot < backend.ConfirmationDepth {
+		return 0
+	}
+	return latestVerifiedSlot - backend.ConfirmationDepth
+}
+
 func (backed *Backend) PendingPandoraHeaders() []*eth1Types.Header {
 	headers, err := backed.PandoraPendingHeaderCache.GetAll()
 	if err != nil {
@@ -82,6 +123,263 @@ func (backend *Backend) LatestFinalizedSlot() uint64 {
 	return backend.VerifiedSlotInfoDB.LatestLatestFinalizedSlot()
 }
 
+// LatestFinalizedHead returns latest finalized slot, epoch and the verified hashes of finalized slot
+func (backend *Backend) LatestFinalizedHead() *types.FinalizedHead {
+	finalizedHead := &types.FinalizedHead{
+		Slot:  backend.VerifiedSlotInfoDB.LatestLatestFinalizedSlot(),
+		Epoch: backend.VerifiedSlotInfoDB.LatestLatestFinalizedEpoch(),
+	}
+	if slotInfo, _ := backend.VerifiedSlotInfoDB.VerifiedSlotInfo(finalizedHead.Slot); slotInfo != nil {
+		finalizedHead.PandoraHeaderHash = slotInfo.PandoraHeaderHash
+		finalizedHead.VanguardBlockHash = slotInfo.VanguardBlockHash
+	}
+	return finalizedHead
+}
+
+// VerificationProgress returns verification progress of consensus service
+func (backend *Backend) VerificationProgress() *types.VerificationProgress {
+	if backend.ProgressReporter == nil {
+		return &types.VerificationProgress{
+			LatestVerifiedSlot:   backend.VerifiedSlotInfoDB.LatestSavedVerifiedSlot(),
+			LatestFinalizedSlot:  backend.VerifiedSlotInfoDB.LatestLatestFinalizedSlot(),
+			LatestFinalizedEpoch: backend.VerifiedSlotInfoDB.LatestLatestFinalizedEpoch(),
+		}
+	}
+	return backend.ProgressReporter.VerificationProgress()
+}
+
+// MismatchEvidence returns both sides of a slot which has been rejected by verification
+func (backend *Backend) MismatchEvidence(slot uint64) (*types.MismatchEvidence, error) {
+	return backend.InvalidSlotInfoDB.MismatchEvidence(slot)
+}
+
+// EpochSummaries returns stored summaries of the epochs in [fromEpoch, toEpoch] range
+func (backend *Backend) EpochSummaries(fromEpoch, toEpoch uint64) ([]*types.EpochSummary, error) {
+	return backend.VerifiedSlotInfoDB.EpochSummaries(fromEpoch, toEpoch)
+}
+
+// ReorgAuditRecords returns the stored reorgs which have invalidated verified slots
+func (backend *Backend) ReorgAuditRecords() ([]*types.ReorgAuditRecord, error) {
+	return backend.VerifiedSlotInfoDB.ReorgAuditRecords()
+}
+
+// HeadAt returns the verified head which the orchestrator had as of the given slot
+func (backend *Backend) HeadAt(asOfSlot uint64) (*types.HistoricalHead, error) {
+	return backend.VerifiedSlotInfoDB.HeadAt(asOfSlot)
+}
+
+// BlockStatusAt returns the status which the pandora block with the given header hash had as of the given slot.
+// Like the current status, a verified block is reported as pending until it had enough confirmations by then.
+func (backend *Backend) BlockStatusAt(hash common.Hash, asOfSlot uint64) (*types.HistoricalBlockStatus, error) {
+	blockStatus, err := backend.VerifiedSlotInfoDB.BlockStatusAt(hash, asOfSlot)
+	if err != nil {
+		return nil, err
+	}
+	if blockStatus == nil {
+		return &types.HistoricalBlockStatus{
+			AsOfSlot:           asOfSlot,
+			PandoraBlockStatus: types.PandoraBlockStatus{PandoraHeaderHash: hash, Status: types.Unknown},
+		}, nil
+	}
+	if blockStatus.Status == types.Verified && backend.ConfirmationDepth > 0 {
+		head, err := backend.VerifiedSlotInfoDB.HeadAt(asOfSlot)
+		if err != nil {
+			return nil, err
+		}
+		if blockStatus.Slot+backend.ConfirmationDepth > head.Slot {
+			blockStatus.Status = types.Pending
+		}
+	}
+	return blockStatus, nil
+}
+
+// ProposerForSlot returns public key of the validator which is assigned to propose the given slot
+func (backend *Backend) ProposerForSlot(slot uint64) (string, error) {
+	if backend.ProposerProvider == nil {
+		return "", errors.New("proposer assignments are not available")
+	}
+	return backend.ProposerProvider.ProposerForSlot(slot)
+}
+
+// BlockStatusBySlot returns status of the pandora block of the given slot with its paired vanguard block
+func (backend *Backend) BlockStatusBySlot(slot uint64) (*types.PandoraBlockStatus, error) {
+	status, err := backend.VerifiedSlotInfoDB.SlotStatus(slot)
+	if err != nil {
+		return nil, err
+	}
+	blockStatus := &types.PandoraBlockStatus{Slot: slot, Status: status}
+
+	var slotInfo *types.SlotInfo
+	switch status {
+	case types.Verified, types.Finalized:
+		if slotInfo, err = backend.VerifiedSlotInfoDB.VerifiedSlotInfo(slot); err != nil {
+			return nil, err
+		}
+		// verified slot is reported as pending until it gets enough confirmations
+		if status == types.Verified && slot+backend.ConfirmationDepth > backend.VerifiedSlotInfoDB.LatestSavedVerifiedSlot() {
+			blockStatus.Status = types.Pending
+		}
+	case types.Invalid:
+		if slotInfo, err = backend.InvalidSlotInfoDB.InvalidSlotInfo(slot); err != nil {
+			return nil, err
+		}
+	}
+	if slotInfo != nil {
+		blockStatus.PandoraHeaderHash = slotInfo.PandoraHeaderHash
+		blockStatus.VanguardBlockHash = slotInfo.VanguardBlockHash
+	}
+	return blockStatus, nil
+}
+
+// StoredBlockStatuses returns stored statuses of the slots in [fromSlot, toSlot] range in ascending order with
+// their paired blocks. Unlike BlockStatusBySlot, verified slots are not reported as pending before they are
+// confirmed, and slots without stored status are omitted.
+func (backend *Backend) StoredBlockStatuses(fromSlot, toSlot uint64) ([]*types.PandoraBlockStatus, error) {
+	statuses, err := backend.VerifiedSlotInfoDB.SlotStatuses(fromSlot, toSlot)
+	if err != nil {
+		return nil, err
+	}
+	blockStatuses := make([]*types.PandoraBlockStatus, 0, len(statuses))
+	for slot := fromSlot; slot <= toSlot; slot++ {
+		status, ok := statuses[slot]
+		if ok {
+			blockStatus := &types.PandoraBlockStatus{Slot: slot, Status: status}
+			var slotInfo *types.SlotInfo
+			switch status {
+			case types.Verified, types.Finalized:
+				slotInfo, err = backend.VerifiedSlotInfoDB.VerifiedSlotInfo(slot)
+			case types.Invalid:
+				slotInfo, err = backend.InvalidSlotInfoDB.InvalidSlotInfo(slot)
+			}
+			if err != nil {
+				return nil, err
+			}
+			if slotInfo != nil {
+				blockStatus.PandoraHeaderHash = slotInfo.PandoraHeaderHash
+				blockStatus.VanguardBlockHash = slotInfo.VanguardBlockHash
+			}
+			blockStatuses = append(blockStatuses, blockStatus)
+		}
+		if slot == toSlot {
+			break
+		}
+	}
+	return blockStatuses, nil
+}
+
+// BlockStatusByHash returns status of the pandora block with the given header hash
+func (backend *Backend) BlockStatusByHash(hash common.Hash) (*types.PandoraBlockStatus, error) {
+	blockStatuses, err := backend.BlockStatusesByHashes([]common.Hash{hash})
+	if err != nil {
+		return nil, err
+	}
+	return blockStatuses[0], nil
+}
+
+// BlockStatusesByHashes returns statuses of the pandora blocks with the given header hashes in the given order.
+// Stored statuses are read within a single db transaction. Pandora headers which are still waiting for their
+// vanguard block are reported as pending, unknown headers as unknown.
+func (backend *Backend) BlockStatusesByHashes(hashes []common.Hash) ([]*types.PandoraBlockStatus, error) {
+	blockStatuses, err := backend.VerifiedSlotInfoDB.PandoraBlockStatuses(hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	latestVerifiedSlot := backend.VerifiedSlotInfoDB.LatestSavedVerifiedSlot()
+	var pendingHeaders map[common.Hash]*eth1Types.Header
+	for i, blockStatus := range blockStatuses {
+		if blockStatus != nil {
+			// verified slot is reported as pending until it gets enough confirmations
+			if blockStatus.Status == types.Verified && blockStatus.Slot+backend.ConfirmationDepth > latestVerifiedSlot {
+				blockStatus.Status = types.Pending
+			}
+			continue
+		}
+
+		if pendingHeaders == nil {
+			pendingHeaders = backend.pendingHeadersByHash()
+		}
+		blockStatuses[i] = &types.PandoraBlockStatus{PandoraHeaderHash: hashes[i], Status: types.Unknown}
+		if header, ok := pendingHeaders[hashes[i]]; ok {
+			blockStatuses[i].Status = types.Pending
+			var extraData types.PanExtraDataWithBLSSig
+			if err := rlp.DecodeBytes(header.Extra, &extraData); err == nil {
+				blockStatuses[i].Slot = extraData.Slot
+			}
+		}
+	}
+	return blockStatuses, nil
+}
+
+// pendingHeadersByHash returns pandora headers which are waiting for their vanguard block keyed by header hash
+func (backend *Backend) pendingHeadersByHash() map[common.Hash]*eth1Types.Header {
+	pendingHeaders := make(map[common.Hash]*eth1Types.Header)
+	if backend.PandoraPendingHeaderCache == nil {
+		return pendingHeaders
+	}
+	headers, _ := backend.PandoraPendingHeaderCache.GetAll()
+	for _, header := range headers {
+		pendingHeaders[header.Hash()] = header
+	}
+	return pendingHeaders
+}
+
+// NodeStatus returns the status of orchestrator node
+func (backend *Backend) NodeStatus(ctx context.Context) (*types.NodeStatus, error) {
+	if backend.NodeStatusProvider == nil {
+		return nil, errors.New("node status is not supported")
+	}
+	return backend.NodeStatusProvider(ctx), nil
+}
+
+// RestartService stops the service of the given name and starts a new instance connected to the endpoint
+func (backend *Backend) RestartService(name, endpoint string) error {
+	if backend.ServiceRestarter == nil {
+		return errors.New("service restart is not supported")
+	}
+	return backend.ServiceRestarter(name, endpoint)
+}
+
+// Promote makes a follower the leader, it returns false when the node leads already
+func (backend *Backend) Promote() (bool, error) {
+	if backend.Promoter == nil {
+		return false, errors.New("promotion is not supported")
+	}
+	return backend.Promoter()
+}
+
+// Reverify recomputes verification of the given slot range
+func (backend *Backend) Reverify(fromSlot, toSlot uint64) (*types.ReverifyResult, error) {
+	if backend.Reverifier == nil {
+		return nil, errors.New("re-verification is not supported")
+	}
+	return backend.Reverifier.Reverify(fromSlot, toSlot)
+}
+
+// BackupDB writes a backup of the database
+func (backend *Backend) BackupDB(ctx context.Context) (*types.BackupResult, error) {
+	if backend.MaintenanceDB == nil {
+		return nil, errors.New("database backup is not supported")
+	}
+	return backend.MaintenanceDB.Backup(ctx)
+}
+
+// PruneDB removes stored data of the slots before the given slot
+func (backend *Backend) PruneDB(beforeSlot uint64) (*types.PruneResult, error) {
+	if backend.MaintenanceDB == nil {
+		return nil, errors.New("database pruning is not supported")
+	}
+	return backend.MaintenanceDB.PruneBefore(beforeSlot)
+}
+
+// ScheduleDBCompaction compacts the database on the next start
+func (backend *Backend) ScheduleDBCompaction() error {
+	if backend.MaintenanceDB == nil {
+		return errors.New("database compaction is not supported")
+	}
+	return backend.MaintenanceDB.ScheduleCompaction()
+}
+
 // GetSlotStatus
 func (backend *Backend) GetSlotStatus(ctx context.Context, slot uint64, hash common.Hash, requestFrom bool) types.Status {
 	// by default if nothing is found then return skipped
@@ -116,6 +414,12 @@ func (backend *Backend) GetSlotStatus(ctx context.Context, slot uint64, hash com
 			return types.Invalid
 		}
 
+		// verified slot is reported as pending until it gets enough confirmations
+		if slot+backend.ConfirmationDepth > latestVerifiedSlot {
+			logPrinter(types.Pending)
+			return types.Pending
+		}
+
 		status = types.Verified
 		logPrinter(types.Verified)
 		return status
@@ -1 +1,124 @@
 package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// TestBackend_GetSlotStatus_ConfirmationDepth checks that verified slot is reported as pending until it is confirmed
+func TestBackend_GetSlotStatus_ConfirmationDepth(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	backend := &Backend{
+		VerifiedSlotInfoDB: db,
+		InvalidSlotInfoDB:  db,
+		ConfirmationDepth:  2,
+	}
+
+	hashes := make(map[uint64]common.Hash)
+	for slot := uint64(1); slot <= 3; slot++ {
+		hashes[slot] = common.BytesToHash([]byte{byte(slot)})
+		require.NoError(t, db.SaveVerificationCheckpoint(slot, &types.SlotInfo{
+			PandoraHeaderHash: hashes[slot],
+			VanguardBlockHash: hashes[slot],
+		}))
+	}
+
+	assert.Equal(t, uint64(1), backend.LatestConfirmedSlot())
+	assert.Equal(t, types.Verified, backend.GetSlotStatus(ctx, 1, hashes[1], true))
+	assert.Equal(t, types.Pending, backend.GetSlotStatus(ctx, 2, hashes[2], true))
+	assert.Equal(t, types.Invalid, backend.GetSlotStatus(ctx, 3, hashes[1], true))
+}
+
+// TestBackend_BlockStatus checks that status of a pandora block is resolved by its slot and by its hash
+func TestBackend_BlockStatus(t *testing.T) {
+	db := testDB.SetupDB(t)
+	backend := &Backend{
+		VerifiedSlotInfoDB: db,
+		InvalidSlotInfoDB:  db,
+		ConfirmationDepth:  1,
+	}
+
+	slotInfos := make(map[uint64]*types.SlotInfo)
+	for slot := uint64(1); slot <= 4; slot++ {
+		slotInfos[slot] = &types.SlotInfo{
+			PandoraHeaderHash: common.BytesToHash([]byte{byte(slot)}),
+			VanguardBlockHash: common.BytesToHash([]byte{byte(slot), byte(slot)}),
+		}
+	}
+	for slot := uint64(1); slot <= 3; slot++ {
+		require.NoError(t, db.SaveVerificationCheckpoint(slot, slotInfos[slot]))
+		require.NoError(t, db.SaveSlotStatus(slot, types.Verified))
+	}
+	require.NoError(t, db.SaveInvalidSlotInfo(4, slotInfos[4]))
+	require.NoError(t, db.SaveSlotStatus(4, types.Invalid))
+	_, err := db.FinalizeSlotStatuses(1, 1)
+	require.NoError(t, err)
+
+	blockStatus, err := backend.BlockStatusByHash(slotInfos[2].PandoraHeaderHash)
+	require.NoError(t, err)
+	assert.DeepEqual(t, &types.PandoraBlockStatus{
+		Slot:              2,
+		PandoraHeaderHash: slotInfos[2].PandoraHeaderHash,
+		VanguardBlockHash: slotInfos[2].VanguardBlockHash,
+		Status:            types.Verified,
+	}, blockStatus)
+
+	blockStatus, err = backend.BlockStatusByHash(slotInfos[4].PandoraHeaderHash)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), blockStatus.Slot)
+	assert.Equal(t, types.Invalid, blockStatus.Status)
+
+	blockStatus, err = backend.BlockStatusBySlot(1)
+	require.NoError(t, err)
+	assert.Equal(t, types.Finalized, blockStatus.Status)
+	assert.Equal(t, slotInfos[1].VanguardBlockHash, blockStatus.VanguardBlockHash)
+
+	// latest verified slot is not confirmed yet
+	blockStatus, err = backend.BlockStatusBySlot(3)
+	require.NoError(t, err)
+	assert.Equal(t, types.Pending, blockStatus.Status)
+
+	blockStatus, err = backend.BlockStatusByHash(common.HexToHash("0xff"))
+	require.NoError(t, err)
+	assert.Equal(t, types.Unknown, blockStatus.Status)
+}
+
+// TestBackend_BlockStatusesByHashes checks that a batch of pandora block hashes is resolved in the requested order
+func TestBackend_BlockStatusesByHashes(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	pendingHeaderCache := cache.NewPanHeaderCache()
+	backend := &Backend{
+		VerifiedSlotInfoDB:        db,
+		InvalidSlotInfoDB:         db,
+		PandoraPendingHeaderCache: pendingHeaderCache,
+	}
+
+	verifiedHeader := testutil.NewEth1Header(1)
+	slotInfo := &types.SlotInfo{PandoraHeaderHash: verifiedHeader.Hash(), VanguardBlockHash: common.HexToHash("0x01")}
+	require.NoError(t, db.SaveVerificationBatch([]*types.SlotVerdict{{Slot: 1, Status: types.Verified, SlotInfo: slotInfo}}))
+	pendingHeader := testutil.NewEth1Header(2)
+	require.NoError(t, pendingHeaderCache.Put(ctx, 2, pendingHeader))
+
+	unknownHash := common.HexToHash("0xff")
+	blockStatuses, err := backend.BlockStatusesByHashes([]common.Hash{unknownHash, pendingHeader.Hash(), verifiedHeader.Hash()})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(blockStatuses))
+	assert.DeepEqual(t, &types.PandoraBlockStatus{PandoraHeaderHash: unknownHash, Status: types.Unknown}, blockStatuses[0])
+	assert.DeepEqual(t, &types.PandoraBlockStatus{Slot: 2, PandoraHeaderHash: pendingHeader.Hash(), Status: types.Pending}, blockStatuses[1])
+	assert.DeepEqual(t, &types.PandoraBlockStatus{
+		Slot:              1,
+		PandoraHeaderHash: verifiedHeader.Hash(),
+		VanguardBlockHash: slotInfo.VanguardBlockHash,
+		Status:            types.Verified,
+	}, blockStatuses[2])
+}
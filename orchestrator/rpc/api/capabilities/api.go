@@ -0,0 +1,101 @@
+package capabilities
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/version"
+)
+
+// APIVersion is the current version of `orc` namespace. Minor versions only add methods, so clients of an
+// older minor version keep working.
+const APIVersion = "1.1"
+
+// supportedAPIVersions lists the versions of `orc` namespace which are served by this node
+var supportedAPIVersions = []string{"1.0", APIVersion}
+
+// features which are always supported by `orc` namespace of this version
+const (
+	FeatureVerifiedBlocks            = "verifiedBlocks"
+	FeatureVerifiedBlocksWithHistory = "verifiedBlocksWithHistory"
+	FeatureBlockStatusBatch          = "blockStatusBatch"
+	FeatureConsensusInfoRange        = "minimalConsensusInfoRange"
+	FeatureMismatchEvidence          = "mismatchEvidence"
+	FeatureVerificationProgress      = "verificationProgress"
+	// finalized verification state is served to fresh orchestrators by orc_getSyncCheckpoint and orc_getSlotStatusRange
+	FeatureSnapshotSync = "snapshotSync"
+	// past verified heads and block statuses are served by orc_verifiedHeadAt and orc_blockStatusAt
+	FeatureHistoricalState = "historicalState"
+)
+
+// features which depend on configuration of the node
+const (
+	FeatureJWTAuth = "jwtAuth"
+	// http requests may carry an api key with its own methods and rate limit
+	FeatureAPIKeys = "apiKeys"
+	FeatureTLS     = "tls"
+	FeatureREST    = "rest"
+	FeatureGraphQL = "graphql"
+	// html status page is served on http transport
+	FeatureDashboard = "dashboard"
+	// responses of http transport are compressed with gzip
+	FeatureHTTPCompression = "httpCompression"
+	// messages of ws transport are compressed with permessage-deflate
+	FeatureWSCompression = "wsCompression"
+)
+
+// DefaultFeatures returns the features which are supported regardless of configuration
+func DefaultFeatures() []string {
+	return []string{
+		FeatureVerifiedBlocks,
+		FeatureVerifiedBlocksWithHistory,
+		FeatureBlockStatusBatch,
+		FeatureConsensusInfoRange,
+		FeatureMismatchEvidence,
+		FeatureVerificationProgress,
+		FeatureSnapshotSync,
+		FeatureHistoricalState,
+	}
+}
+
+// PublicCapabilitiesAPI offers version and feature discovery of orchestrator node in `orc` namespace
+type PublicCapabilitiesAPI struct {
+	namespaces map[string]string
+	features   []string
+}
+
+// NewPublicCapabilitiesAPI returns a new PublicCapabilitiesAPI instance which reports the given versions of
+// exposed namespaces and supported features.
+func NewPublicCapabilitiesAPI(namespaces map[string]string, features []string) *PublicCapabilitiesAPI {
+	return &PublicCapabilitiesAPI{namespaces: namespaces, features: features}
+}
+
+// ClientVersion returns the name, version and commit of orchestrator client.
+func (api *PublicCapabilitiesAPI) ClientVersion() string {
+	return version.BuildData()
+}
+
+// BuildInfo returns semantic version, git commit, build date, Go version and platform of orchestrator client.
+func (api *PublicCapabilitiesAPI) BuildInfo() *types.BuildInfo {
+	return &types.BuildInfo{
+		Version:   version.SemanticVersion(),
+		GitCommit: version.GitCommit(),
+		BuildDate: version.BuildDate(),
+		GoVersion: version.GoVersion(),
+		Platform:  version.Platform(),
+	}
+}
+
+// Capabilities returns the supported api versions of `orc` namespace, versions of exposed namespaces and
+// supported features.
+func (api *PublicCapabilitiesAPI) Capabilities() *types.Capabilities {
+	namespaces := make(map[string]string, len(api.namespaces))
+	for namespace, v := range api.namespaces {
+		namespaces[namespace] = v
+	}
+	return &types.Capabilities{
+		ClientVersion:        version.BuildData(),
+		APIVersion:           APIVersion,
+		SupportedAPIVersions: append([]string{}, supportedAPIVersions...),
+		Namespaces:           namespaces,
+		Features:             append([]string{}, api.features...),
+	}
+}
@@ -0,0 +1,109 @@
+package debug
+
+import (
+	"bytes"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"runtime/pprof"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maximum duration of a single cpu profile
+var maxCPUProfileDuration = 5 * time.Minute
+
+var errCPUProfileTooLong = errors.New("cpu profile duration is too long")
+
+// PrivateDebugAPI offers runtime diagnostics of orchestrator node. It is only exposed over IPC
+// unless `debug` module is explicitly enabled.
+type PrivateDebugAPI struct{}
+
+// NewPrivateDebugAPI returns a new PrivateDebugAPI instance.
+func NewPrivateDebugAPI() *PrivateDebugAPI {
+	return &PrivateDebugAPI{}
+}
+
+// Stacks returns a printed representation of the stacks of all goroutines.
+func (api *PrivateDebugAPI) Stacks() string {
+	buf := new(bytes.Buffer)
+	if err := pprof.Lookup("goroutine").WriteTo(buf, 2); err != nil {
+		return err.Error()
+	}
+	return buf.String()
+}
+
+// MemStats returns detailed runtime memory statistics.
+func (api *PrivateDebugAPI) MemStats() *runtime.MemStats {
+	s := new(runtime.MemStats)
+	runtime.ReadMemStats(s)
+	return s
+}
+
+// GcStats returns GC statistics.
+func (api *PrivateDebugAPI) GcStats() *debug.GCStats {
+	s := new(debug.GCStats)
+	debug.ReadGCStats(s)
+	return s
+}
+
+// FreeOSMemory forces a garbage collection and returns as much memory to the operating system as possible.
+func (api *PrivateDebugAPI) FreeOSMemory() {
+	debug.FreeOSMemory()
+}
+
+// SetGCPercent sets the garbage collection target percentage. It returns the previous setting.
+// A negative value disables GC.
+func (api *PrivateDebugAPI) SetGCPercent(v int) int {
+	return debug.SetGCPercent(v)
+}
+
+// WriteMemProfile writes an allocation profile to the given file.
+func (api *PrivateDebugAPI) WriteMemProfile(file string) error {
+	return writeProfile("heap", file)
+}
+
+// WriteGoroutineProfile writes a goroutine profile to the given file.
+func (api *PrivateDebugAPI) WriteGoroutineProfile(file string) error {
+	return writeProfile("goroutine", file)
+}
+
+// CpuProfile turns on CPU profiling for nsec seconds and writes profile data to file.
+func (api *PrivateDebugAPI) CpuProfile(file string, nsec uint) error {
+	duration := time.Duration(nsec) * time.Second
+	if duration > maxCPUProfileDuration {
+		return errors.Wrapf(errCPUProfileTooLong, "maximum %v", maxCPUProfileDuration)
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return errors.Wrap(err, "could not create cpu profile file")
+	}
+	defer f.Close()
+	if err := pprof.StartCPUProfile(f); err != nil {
+		return errors.Wrap(err, "could not start cpu profile")
+	}
+	log.WithField("file", file).WithField("duration", duration).Info("CPU profiling started")
+	time.Sleep(duration)
+	pprof.StopCPUProfile()
+	log.WithField("file", file).Info("CPU profiling stopped")
+	return nil
+}
+
+// writeProfile writes the named runtime profile to the given file
+func writeProfile(name, file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return errors.Wrapf(err, "could not create %s profile file", name)
+	}
+	defer f.Close()
+	if name == "heap" {
+		// up-to-date statistics of allocations
+		runtime.GC()
+	}
+	if err := pprof.Lookup(name).WriteTo(f, 0); err != nil {
+		return errors.Wrapf(err, "could not write %s profile", name)
+	}
+	log.WithField("file", file).WithField("profile", name).Info("Wrote profile")
+	return nil
+}
@@ -0,0 +1,28 @@
+package debug
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestPrivateDebugAPI_Stacks(t *testing.T) {
+	api := NewPrivateDebugAPI()
+	assert.Equal(t, true, strings.Contains(api.Stacks(), "TestPrivateDebugAPI_Stacks"))
+	assert.Equal(t, true, api.MemStats().HeapAlloc > 0)
+}
+
+func TestPrivateDebugAPI_WriteMemProfile(t *testing.T) {
+	api := NewPrivateDebugAPI()
+	file := filepath.Join(t.TempDir(), "heap.pprof")
+	require.NoError(t, api.WriteMemProfile(file))
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	assert.Equal(t, true, info.Size() > 0)
+
+	assert.ErrorContains(t, errCPUProfileTooLong.Error(), api.CpuProfile(file, 3600))
+}
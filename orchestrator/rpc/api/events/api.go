@@ -9,30 +9,60 @@ import (
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/eventbuffer"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/slotutil"
 	generalTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
 	"github.com/pkg/errors"
 )
 
 var lastSendEpoch uint64
 
+const (
+	// maximum number of pandora block hashes resolved by a single GetBlockStatusBatch request
+	maxBlockStatusBatchSize = 1024
+	// default number of consensus infos returned by a single page of GetMinimalConsensusInfoRange
+	defaultConsensusInfoPageSize = 64
+	// default maximum number of consensus infos returned by a single page of GetMinimalConsensusInfoRange
+	maxConsensusInfoPageSize = 1024
+	// maximum number of slots whose verification events are replayed to a verifiedBlocksWithHistory subscriber
+	maxVerificationHistorySlots = 1024
+)
+
 type Backend interface {
 	ConsensusInfoByEpochRange(fromEpoch uint64) ([]*generalTypes.MinimalEpochConsensusInfoV2, error)
+	ConsensusInfoRange(fromEpoch, toEpoch uint64, limit int) ([]*generalTypes.MinimalEpochConsensusInfoV2, error)
 	SubscribeNewEpochEvent(chan<- *generalTypes.MinimalEpochConsensusInfoV2) event.Subscription
 	GetSlotStatus(ctx context.Context, slot uint64, hash common.Hash, requestFrom bool) generalTypes.Status
 	LatestEpoch() uint64
-	SubscribeNewVerifiedSlotInfoEvent(chan<- *generalTypes.SlotInfoWithStatus) event.Subscription
+	SubscribeNewVerificationResultEvent(chan<- *generalTypes.VerificationResult) event.Subscription
 	VerifiedSlotInfos(fromSlot uint64) map[uint64]*generalTypes.SlotInfo
 	LatestVerifiedSlot() uint64
+	LatestConfirmedSlot() uint64
 	PendingPandoraHeaders() []*eth1Types.Header
 	LatestFinalizedSlot() uint64
+	LatestFinalizedHead() *generalTypes.FinalizedHead
+	VerificationProgress() *generalTypes.VerificationProgress
+	MismatchEvidence(slot uint64) (*generalTypes.MismatchEvidence, error)
+	EpochSummaries(fromEpoch, toEpoch uint64) ([]*generalTypes.EpochSummary, error)
+	ProposerForSlot(slot uint64) (string, error)
+	BlockStatusBySlot(slot uint64) (*generalTypes.PandoraBlockStatus, error)
+	StoredBlockStatuses(fromSlot, toSlot uint64) ([]*generalTypes.PandoraBlockStatus, error)
+	BlockStatusByHash(hash common.Hash) (*generalTypes.PandoraBlockStatus, error)
+	BlockStatusesByHashes(hashes []common.Hash) ([]*generalTypes.PandoraBlockStatus, error)
+	HeadAt(asOfSlot uint64) (*generalTypes.HistoricalHead, error)
+	BlockStatusAt(hash common.Hash, asOfSlot uint64) (*generalTypes.HistoricalBlockStatus, error)
 }
 
 // PublicFilterAPI offers support to create and manage filters. This will allow external clients to retrieve various
 // information related to the Ethereum protocol such als blocks, transactions and logs.
 type PublicFilterAPI struct {
-	backend Backend
-	events  *EventSystem
-	timeout time.Duration
+	backend       Backend
+	events        *EventSystem
+	timeout       time.Duration
+	subscriptions *subscriptionLimiter
+	maxPageSize   int                // maximum number of items of a single page of range queries
+	buffer        eventbuffer.Config // buffer of live events of a single subscription
 }
 
 type BlockHash struct {
@@ -40,6 +70,26 @@ type BlockHash struct {
 	Hash common.Hash `json:"hash"`
 }
 
+// BlockStatusRequest identifies a pandora block by its header hash or by its slot
+type BlockStatusRequest struct {
+	Slot *uint64      `json:"slot"`
+	Hash *common.Hash `json:"hash"`
+}
+
+// HistoryPoint selects the past state which is queried, either as of a slot or as of the end of an epoch. Slot takes
+// precedence when both are given.
+type HistoryPoint struct {
+	Slot  *uint64 `json:"slot"`
+	Epoch *uint64 `json:"epoch"`
+}
+
+// VerificationHistoryRequest selects the verification events which are replayed before live events, either
+// the events of the last slots or the events since the given slot. Exactly one of them must be given.
+type VerificationHistoryRequest struct {
+	FromSlot  *uint64 `json:"fromSlot"`
+	LastSlots *uint64 `json:"lastSlots"`
+}
+
 type BlockStatus struct {
 	BlockHash
 	Status generalTypes.Status
@@ -48,9 +98,12 @@ type BlockStatus struct {
 // NewPublicFilterAPI returns a new PublicFilterAPI instance.
 func NewPublicFilterAPI(backend Backend, timeout time.Duration) *PublicFilterAPI {
 	api := &PublicFilterAPI{
-		backend: backend,
-		events:  NewEventSystem(backend),
-		timeout: timeout,
+		backend:       backend,
+		events:        NewEventSystem(backend),
+		timeout:       timeout,
+		subscriptions: newSubscriptionLimiter(),
+		maxPageSize:   maxConsensusInfoPageSize,
+		buffer:        eventbuffer.DefaultConfig,
 	}
 
 	return api
@@ -61,8 +114,10 @@ func (api *PublicFilterAPI) ConfirmPanBlockHashes(
 	ctx context.Context,
 	requests []*BlockHash,
 ) ([]*BlockStatus, error) {
-	if len(requests) < 1 {
-		err := fmt.Errorf("invalid request")
+	if err := api.subscriptions.requireLeader(); err != nil {
+		return nil, err
+	}
+	if err := validateBlockHashes(requests); err != nil {
 		return nil, err
 	}
 	res := make([]*BlockStatus, 0)
@@ -87,8 +142,10 @@ func (api *PublicFilterAPI) ConfirmVanBlockHashes(
 	ctx context.Context,
 	requests []*BlockHash,
 ) (response []*BlockStatus, err error) {
-	if len(requests) < 1 {
-		err := fmt.Errorf("invalid request")
+	if err := api.subscriptions.requireLeader(); err != nil {
+		return nil, err
+	}
+	if err := validateBlockHashes(requests); err != nil {
 		return nil, err
 	}
 	res := make([]*BlockStatus, 0)
@@ -108,102 +165,287 @@ func (api *PublicFilterAPI) ConfirmVanBlockHashes(
 	return res, nil
 }
 
-// MinimalConsensusInfo
-func (api *PublicFilterAPI) MinimalConsensusInfo(ctx context.Context, requestedEpoch uint64) (*rpc.Subscription, error) {
+// FinalizedHead returns latest finalized slot and epoch with the verified hashes of finalized slot
+func (api *PublicFilterAPI) FinalizedHead(ctx context.Context) (*generalTypes.FinalizedHead, error) {
+	return api.backend.LatestFinalizedHead(), nil
+}
+
+// VerificationProgress returns latest verified and finalized slots, number of pending and unmatched slots
+// and verification throughput
+func (api *PublicFilterAPI) VerificationProgress(ctx context.Context) (*generalTypes.VerificationProgress, error) {
+	return api.backend.VerificationProgress(), nil
+}
+
+// MismatchEvidence returns vanguard shard info and pandora header of a slot which has been rejected by verification
+func (api *PublicFilterAPI) MismatchEvidence(ctx context.Context, slot uint64) (*generalTypes.MismatchEvidence, error) {
+	if err := validateSlot("slot", slot); err != nil {
+		return nil, err
+	}
+	evidence, err := api.backend.MismatchEvidence(slot)
+	if err != nil {
+		return nil, err
+	}
+	if evidence == nil {
+		return nil, fmt.Errorf("no mismatch evidence found for slot %d", slot)
+	}
+	return evidence, nil
+}
+
+// EpochSummaries returns stored summaries of the epochs in [fromEpoch, toEpoch] range, epochs which have not
+// been summarized are omitted. At most as many epochs as fit into a page of consensus infos can be requested.
+func (api *PublicFilterAPI) EpochSummaries(ctx context.Context, fromEpoch uint64, toEpoch uint64) ([]*generalTypes.EpochSummary, error) {
+	if err := validateEpochRange(fromEpoch, toEpoch, api.maxPageSize); err != nil {
+		return nil, err
+	}
+	return api.backend.EpochSummaries(fromEpoch, toEpoch)
+}
+
+// ProposerForSlot returns public key of the validator which is assigned to propose the given slot
+func (api *PublicFilterAPI) ProposerForSlot(ctx context.Context, slot uint64) (string, error) {
+	if err := validateSlot("slot", slot); err != nil {
+		return "", err
+	}
+	return api.backend.ProposerForSlot(slot)
+}
+
+// GetBlockStatus returns orchestrator status of a pandora block which is requested by its header hash or by its slot,
+// together with the vanguard block it has been paired with. Hash takes precedence when both are given.
+func (api *PublicFilterAPI) GetBlockStatus(ctx context.Context, request *BlockStatusRequest) (*generalTypes.PandoraBlockStatus, error) {
+	switch {
+	case request == nil || (request.Hash == nil && request.Slot == nil):
+		return nil, invalidParams("either hash or slot of the pandora block must be given")
+	case request.Hash != nil:
+		if err := validateHash("hash", *request.Hash); err != nil {
+			return nil, err
+		}
+		return api.backend.BlockStatusByHash(*request.Hash)
+	default:
+		if err := validateSlot("slot", *request.Slot); err != nil {
+			return nil, err
+		}
+		return api.backend.BlockStatusBySlot(*request.Slot)
+	}
+}
+
+// GetBlockStatusBatch returns orchestrator statuses of the given pandora block hashes in the given order. All the
+// hashes are resolved within a single db transaction, so that explorers can backfill without a request per block.
+func (api *PublicFilterAPI) GetBlockStatusBatch(ctx context.Context, hashes []common.Hash) ([]*generalTypes.PandoraBlockStatus, error) {
+	if err := validateBatch("hashes", len(hashes), maxBlockStatusBatchSize); err != nil {
+		return nil, err
+	}
+	for i, hash := range hashes {
+		if err := validateHash(fmt.Sprintf("hash %d", i), hash); err != nil {
+			return nil, err
+		}
+	}
+	return api.backend.BlockStatusesByHashes(hashes)
+}
+
+// VerifiedHeadAt returns the verified head and the finalized slot which the orchestrator had as of the given slot or
+// epoch, together with the latest reorg by then, so explorers can explain past reorg decisions
+func (api *PublicFilterAPI) VerifiedHeadAt(ctx context.Context, point *HistoryPoint) (*generalTypes.HistoricalHead, error) {
+	asOfSlot, err := point.slot()
+	if err != nil {
+		return nil, err
+	}
+	return api.backend.HeadAt(asOfSlot)
+}
+
+// BlockStatusAt returns the status which the pandora block with the given header hash had as of the given slot or
+// epoch. A block which had been invalidated by a reorg by then is returned with the reorg.
+func (api *PublicFilterAPI) BlockStatusAt(ctx context.Context, hash common.Hash, point *HistoryPoint) (*generalTypes.HistoricalBlockStatus, error) {
+	if err := validateHash("hash", hash); err != nil {
+		return nil, err
+	}
+	asOfSlot, err := point.slot()
+	if err != nil {
+		return nil, err
+	}
+	return api.backend.BlockStatusAt(hash, asOfSlot)
+}
+
+// slot returns the slot of the history point, the last slot of the epoch when it is given by epoch
+func (point *HistoryPoint) slot() (uint64, error) {
+	switch {
+	case point == nil || (point.Slot == nil && point.Epoch == nil):
+		return 0, invalidParams("either slot or epoch of the history point must be given")
+	case point.Slot != nil:
+		return *point.Slot, validateSlot("slot", *point.Slot)
+	default:
+		if err := validateEpoch("epoch", *point.Epoch); err != nil {
+			return 0, err
+		}
+		return slotutil.EpochEndSlot(*point.Epoch), nil
+	}
+}
+
+// GetSyncCheckpoint returns the finalized head with the chain parameters, a fresh orchestrator downloads the
+// verification state up to this head by snapshot sync
+func (api *PublicFilterAPI) GetSyncCheckpoint(ctx context.Context) (*generalTypes.SyncCheckpoint, error) {
+	network := params.ActiveNetworkConfig()
+	return &generalTypes.SyncCheckpoint{
+		FinalizedHead: api.backend.LatestFinalizedHead(),
+		LatestEpoch:   api.backend.LatestEpoch(),
+		ChainConfig: &generalTypes.ChainConfig{
+			SlotsPerEpoch:  network.SlotsPerEpoch,
+			SecondsPerSlot: network.SecondsPerSlot,
+			GenesisTime:    network.GenesisTime,
+		},
+	}, nil
+}
+
+// GetSlotStatusRange returns stored statuses of the slots in [fromSlot, toSlot] range with their paired blocks,
+// slots without status are omitted. At most as many slots as fit into a page can be requested.
+func (api *PublicFilterAPI) GetSlotStatusRange(ctx context.Context, fromSlot uint64, toSlot uint64) ([]*generalTypes.PandoraBlockStatus, error) {
+	if err := validateSlotRange(fromSlot, toSlot, api.maxPageSize); err != nil {
+		return nil, err
+	}
+	return api.backend.StoredBlockStatuses(fromSlot, toSlot)
+}
+
+// GetMinimalConsensusInfoRange returns consensus infos of [fromEpoch, toEpoch] range page by page, so that
+// a restarted validator can pull the epochs it has missed. When the range does not fit into a single page,
+// the next page is requested with the returned page token of the same range, or from the returned next epoch.
+func (api *PublicFilterAPI) GetMinimalConsensusInfoRange(
+	ctx context.Context,
+	fromEpoch uint64,
+	toEpoch uint64,
+	pageSize *int,
+	pageToken *string,
+) (*generalTypes.ConsensusInfoPage, error) {
+	if err := validateEpochRange(fromEpoch, toEpoch, 0); err != nil {
+		return nil, err
+	}
+	limit, err := api.pageLimit(pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if pageToken != nil {
+		if fromEpoch, err = decodePageToken(*pageToken, fromEpoch, toEpoch); err != nil {
+			return nil, err
+		}
+	}
+
+	// one more consensus info is fetched to find out the first epoch of the next page
+	consensusInfos, err := api.backend.ConsensusInfoRange(fromEpoch, toEpoch, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &generalTypes.ConsensusInfoPage{ConsensusInfos: consensusInfos}
+	if len(consensusInfos) > limit {
+		nextEpoch := consensusInfos[limit].Epoch
+		page.ConsensusInfos = consensusInfos[:limit]
+		page.NextEpoch = &nextEpoch
+		page.NextPageToken = encodePageToken(nextEpoch, toEpoch)
+	}
+	return page, nil
+}
+
+// MinimalConsensusInfo streams consensus infos to the subscriber. Stored epochs from fromEpoch onwards are replayed
+// from db before switching to live events, so that a reconnecting client gets every epoch without a gap. Live events
+// are buffered while replaying, epochs which have already been sent are skipped and epochs which are missing between
+// two live events are filled from db. When fromEpoch is not given, only the epochs after the latest stored epoch
+// are streamed.
+func (api *PublicFilterAPI) MinimalConsensusInfo(ctx context.Context, fromEpoch *uint64) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
+	if fromEpoch != nil {
+		if err := validateEpoch("from epoch", *fromEpoch); err != nil {
+			return &rpc.Subscription{}, err
+		}
+	}
+	release, term, err := api.subscriptions.acquire(notifier.Closed())
+	if err != nil {
+		return &rpc.Subscription{}, err
+	}
 	rpcSub := notifier.CreateSubscription()
 
+	nextEpoch := api.backend.LatestEpoch() + 1
+	if fromEpoch != nil {
+		nextEpoch = *fromEpoch
+	}
+
 	go func() {
+		defer release()
+		// subscribing before replay guarantees that no live event is missed in between
+		consensusInfo := make(chan *generalTypes.MinimalEpochConsensusInfoV2, api.buffer.Size)
+		consensusInfoSub := api.events.SubscribeConsensusInfo(consensusInfo, nextEpoch)
+		defer consensusInfoSub.Unsubscribe()
 
-		batchSender := func(start, end uint64) error {
-			epochInfos, err := api.backend.ConsensusInfoByEpochRange(start)
-			if err != nil {
-				log.WithError(err).Error("Some epoch infos are missing in db.")
-				return errors.Wrap(err, "Missing epoch infos in db. Could not send over stream.")
-			}
-			latestFinalizedSlot := api.backend.LatestFinalizedSlot()
-			for _, ei := range epochInfos {
-				if err := notifier.Notify(rpcSub.ID, &generalTypes.MinimalEpochConsensusInfoV2{
-					Epoch:            ei.Epoch,
-					ValidatorList:    ei.ValidatorList,
-					EpochStartTime:   ei.EpochStartTime,
-					SlotTimeDuration: ei.SlotTimeDuration,
-					FinalizedSlot:    latestFinalizedSlot,
-				}); err != nil {
-					log.WithField("start", start).
-						WithField("end", end).
-						WithError(err).
-						Error("Failed to send epoch info. Could not send over stream.")
-					return errors.Wrap(err, "Failed to send epoch info. Could not send over stream.")
-				}
-				log.WithField("epoch", ei.Epoch).WithField("latestFinalizedSlot", latestFinalizedSlot).
-					Info("published epoch info to pandora")
+		send := func(ei *generalTypes.MinimalEpochConsensusInfoV2) error {
+			if err := notifier.Notify(rpcSub.ID, &generalTypes.MinimalEpochConsensusInfoV2{
+				Epoch:            ei.Epoch,
+				ValidatorList:    ei.ValidatorList,
+				EpochStartTime:   ei.EpochStartTime,
+				SlotTimeDuration: ei.SlotTimeDuration,
+				ReorgInfo:        ei.ReorgInfo,
+				FinalizedSlot:    ei.FinalizedSlot,
+			}); err != nil {
+				log.WithField("epoch", ei.Epoch).WithError(err).Error("Failed to notify consensus info")
+				return err
 			}
+			log.WithField("epoch", ei.Epoch).WithField("latestFinalizedSlot", ei.FinalizedSlot).
+				Info("published epoch info to pandora")
 			return nil
 		}
 
-		startEpoch := requestedEpoch
-		endEpoch := api.backend.LatestEpoch()
-		if startEpoch <= endEpoch {
-			log.WithField("startEpoch", startEpoch).WithField("endEpoch", endEpoch).Debug("Sending previous epoch infos to pandora")
-			if err := batchSender(startEpoch, endEpoch); err != nil {
-				return
+		// replay sends stored consensus infos from the next epoch up to toEpoch
+		replay := func(toEpoch uint64) error {
+			for nextEpoch <= toEpoch {
+				epochInfos, err := api.backend.ConsensusInfoRange(nextEpoch, toEpoch, maxConsensusInfoPageSize)
+				if err != nil {
+					log.WithError(err).Error("Could not read stored epoch infos")
+					return errors.Wrap(err, "Could not read stored epoch infos")
+				}
+				if len(epochInfos) == 0 {
+					return nil
+				}
+				for _, ei := range epochInfos {
+					if err := send(ei); err != nil {
+						return err
+					}
+					nextEpoch = ei.Epoch + 1
+				}
 			}
+			return nil
 		}
 
-		consensusInfo := make(chan *generalTypes.MinimalEpochConsensusInfoV2)
-		consensusInfoSub := api.events.SubscribeConsensusInfo(consensusInfo, requestedEpoch)
-		firstTime := true
+		log.WithField("fromEpoch", nextEpoch).Debug("Sending stored epoch infos to pandora")
+		if err := replay(api.backend.LatestEpoch()); err != nil {
+			return
+		}
+		log.WithField("liveSyncEpoch", nextEpoch).Debug("start publishing live epoch info to pandora")
 
 		for {
 			select {
 			case currentEpochInfo := <-consensusInfo:
-				log.WithField("epoch", currentEpochInfo.Epoch).
-					WithField("epochStartTime", currentEpochInfo.EpochStartTime).
-					Info("Sending consensus info to subscriber")
-
-				if firstTime {
-					firstTime = false
-					startEpoch = endEpoch
-					endEpoch = api.backend.LatestEpoch()
-
-					if startEpoch+1 < endEpoch {
-						log.WithField("startEpoch", startEpoch).WithField("endEpoch", endEpoch).
-							Debug("successfully published left over epoch infos")
-						if err := batchSender(startEpoch, endEpoch); err != nil {
-							return
-						}
+				// reorged epochs are always sent again, other epochs are sent only once
+				if currentEpochInfo.ReorgInfo == nil && currentEpochInfo.Epoch < nextEpoch {
+					continue
+				}
+				if currentEpochInfo.ReorgInfo == nil && currentEpochInfo.Epoch > nextEpoch {
+					if err := replay(currentEpochInfo.Epoch - 1); err != nil {
+						return
 					}
-					log.WithField("liveSyncEpoch", endEpoch+1).Debug("start publishing live epoch info to pandora")
 				}
-
-				err := notifier.Notify(rpcSub.ID, &generalTypes.MinimalEpochConsensusInfoV2{
-					Epoch:            currentEpochInfo.Epoch,
-					ValidatorList:    currentEpochInfo.ValidatorList,
-					EpochStartTime:   currentEpochInfo.EpochStartTime,
-					SlotTimeDuration: currentEpochInfo.SlotTimeDuration,
-					ReorgInfo:        currentEpochInfo.ReorgInfo,
-					FinalizedSlot:    currentEpochInfo.FinalizedSlot,
-				})
-				if nil != err {
-					log.WithField("epoch", currentEpochInfo.Epoch).WithError(err).Error(
-						"Failed to notify consensus info")
+				if err := send(currentEpochInfo); err != nil {
 					return
 				}
+				nextEpoch = currentEpochInfo.Epoch + 1
 
-				log.WithField("epoch", currentEpochInfo.Epoch).WithField("latestFinalizedSlot", currentEpochInfo.FinalizedSlot).
-					Info("published epoch info to pandora")
-
+			case <-term:
+				log.Warn("Lost leadership. Ending consensus info subscription of pandora")
+				return
+			case <-consensusInfoSub.Evicted():
+				log.Warn("Pandora fell behind. Ending consensus info subscription of pandora")
+				return
 			case <-rpcSub.Err():
 				log.Info("Unsubscribing registered pandora client")
-				consensusInfoSub.Unsubscribe()
 				return
 			case <-notifier.Closed():
 				log.Info("Closing notifier. Unsubscribing registered pandora subscriber")
-				consensusInfoSub.Unsubscribe()
 				return
 			}
 		}
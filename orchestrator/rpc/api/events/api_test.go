@@ -0,0 +1,403 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	eventTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// Test_GetMinimalConsensusInfoRange_Pagination checks that an epoch range is returned page by page
+func Test_GetMinimalConsensusInfoRange_Pagination(t *testing.T) {
+	ctx := context.Background()
+	_, eventApi := setup(t)
+
+	_, err := eventApi.GetMinimalConsensusInfoRange(ctx, 3, 1, nil, nil)
+	assert.ErrorContains(t, "from epoch 3 is greater than to epoch 1", err)
+
+	pageSize := 0
+	_, err = eventApi.GetMinimalConsensusInfoRange(ctx, 0, 4, &pageSize, nil)
+	assert.ErrorContains(t, "page size must be between", err)
+
+	pageSize = 2
+	page, err := eventApi.GetMinimalConsensusInfoRange(ctx, 1, 10, &pageSize, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(page.ConsensusInfos))
+	assert.Equal(t, uint64(1), page.ConsensusInfos[0].Epoch)
+	require.NotNil(t, page.NextEpoch)
+	assert.Equal(t, uint64(3), *page.NextEpoch)
+
+	page, err = eventApi.GetMinimalConsensusInfoRange(ctx, *page.NextEpoch, 10, &pageSize, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(page.ConsensusInfos))
+	assert.Equal(t, uint64(4), page.ConsensusInfos[1].Epoch)
+	assert.Equal(t, true, page.NextEpoch == nil)
+	assert.Equal(t, "", page.NextPageToken)
+
+	page, err = eventApi.GetMinimalConsensusInfoRange(ctx, 0, 4, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 5, len(page.ConsensusInfos))
+}
+
+// Test_EpochSummaries checks that epoch summaries are requested by a bounded epoch range
+func Test_EpochSummaries(t *testing.T) {
+	ctx := context.Background()
+	_, eventApi := setup(t)
+	eventApi.LimitPageSize(4)
+
+	_, err := eventApi.EpochSummaries(ctx, 3, 1)
+	assert.ErrorContains(t, "from epoch 3 is greater than to epoch 1", err)
+	_, err = eventApi.EpochSummaries(ctx, 1, 5)
+	assert.ErrorContains(t, "too many epochs requested, maximum 4 epochs", err)
+
+	summaries, err := eventApi.EpochSummaries(ctx, 2, 5)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(summaries))
+	assert.Equal(t, uint64(2), summaries[0].Epoch)
+	assert.Equal(t, uint64(5), summaries[3].Epoch)
+}
+
+// Test_GetSlotStatusRange checks that stored slot statuses are requested by a bounded slot range
+func Test_GetSlotStatusRange(t *testing.T) {
+	ctx := context.Background()
+	backend, eventApi := setup(t)
+	eventApi.LimitPageSize(4)
+	backend.blockStatuses = map[uint64]*eventTypes.PandoraBlockStatus{
+		2: {Slot: 2, PandoraHeaderHash: common.HexToHash("0x62"), Status: eventTypes.Finalized},
+		4: {Slot: 4, Status: eventTypes.Skipped},
+	}
+
+	_, err := eventApi.GetSlotStatusRange(ctx, 3, 1)
+	assert.ErrorContains(t, "from slot 3 is greater than to slot 1", err)
+	_, err = eventApi.GetSlotStatusRange(ctx, 1, 5)
+	assert.ErrorContains(t, "too many slots requested, maximum 4 slots", err)
+
+	blockStatuses, err := eventApi.GetSlotStatusRange(ctx, 1, 4)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(blockStatuses))
+	assert.Equal(t, eventTypes.Finalized, blockStatuses[0].Status)
+	assert.Equal(t, uint64(4), blockStatuses[1].Slot)
+}
+
+// Test_GetMinimalConsensusInfoRange_PageToken checks that pages are requested by their cursor and page size
+// is limited by the configured maximum
+func Test_GetMinimalConsensusInfoRange_PageToken(t *testing.T) {
+	ctx := context.Background()
+	_, eventApi := setup(t)
+
+	pageSize := 2
+	page, err := eventApi.GetMinimalConsensusInfoRange(ctx, 0, 10, &pageSize, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, "", page.NextPageToken)
+	token := page.NextPageToken
+
+	page, err = eventApi.GetMinimalConsensusInfoRange(ctx, 0, 10, &pageSize, &token)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(page.ConsensusInfos))
+	assert.Equal(t, uint64(2), page.ConsensusInfos[0].Epoch)
+
+	// tokens only continue the range they have been returned by
+	_, err = eventApi.GetMinimalConsensusInfoRange(ctx, 0, 11, &pageSize, &token)
+	assert.ErrorContains(t, errInvalidPageToken.Error(), err)
+	invalid := "not-a-token"
+	_, err = eventApi.GetMinimalConsensusInfoRange(ctx, 0, 10, &pageSize, &invalid)
+	assert.ErrorContains(t, errInvalidPageToken.Error(), err)
+
+	eventApi.LimitPageSize(3)
+	pageSize = 4
+	_, err = eventApi.GetMinimalConsensusInfoRange(ctx, 0, 10, &pageSize, nil)
+	assert.ErrorContains(t, "page size must be between 1 and 3", err)
+	page, err = eventApi.GetMinimalConsensusInfoRange(ctx, 0, 10, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(page.ConsensusInfos))
+}
+
+// Test_VerifiedBlocks_Subscription checks that verification results are streamed to verifiedBlocks subscribers
+func Test_VerifiedBlocks_Subscription(t *testing.T) {
+	ctx := context.Background()
+	backend, eventApi := setup(t)
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("orc", eventApi))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	verifiedBlockCh := make(chan *eventTypes.VerifiedBlockEvent, 1)
+	sub, err := client.Subscribe(ctx, "orc", verifiedBlockCh, "verifiedBlocks")
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	time.Sleep(1 * time.Second)
+	pandoraHash := common.HexToHash("0x01")
+	backend.verificationResultFeed.Send(&eventTypes.VerificationResult{
+		Slot:              7,
+		PandoraHeaderHash: pandoraHash,
+		Status:            eventTypes.Invalid,
+	})
+
+	select {
+	case verifiedBlock := <-verifiedBlockCh:
+		assert.DeepEqual(t, &eventTypes.VerifiedBlockEvent{
+			Slot:        7,
+			PandoraHash: pandoraHash,
+			Status:      eventTypes.Invalid,
+		}, verifiedBlock)
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("verified block has not been streamed")
+	}
+}
+
+// Test_MinimalConsensusInfo_Resume checks that stored epochs are replayed from the requested epoch and live epochs
+// follow without duplicates
+func Test_MinimalConsensusInfo_Resume(t *testing.T) {
+	ctx := context.Background()
+	backend, eventApi := setup(t)
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("orc", eventApi))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	consensusInfoCh := make(chan *eventTypes.MinimalEpochConsensusInfoV2, 10)
+	sub, err := client.Subscribe(ctx, "orc", consensusInfoCh, "minimalConsensusInfo", 2)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	receive := func() *eventTypes.MinimalEpochConsensusInfoV2 {
+		select {
+		case consensusInfo := <-consensusInfoCh:
+			return consensusInfo
+		case err := <-sub.Err():
+			t.Fatal(err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("consensus info has not been streamed")
+		}
+		return nil
+	}
+	for epoch := uint64(2); epoch <= 4; epoch++ {
+		assert.Equal(t, epoch, receive().Epoch)
+	}
+
+	// already sent epoch is skipped
+	backend.ConsensusInfoFeed.Send(testutil.NewMinimalConsensusInfo(4))
+	backend.ConsensusInfoFeed.Send(testutil.NewMinimalConsensusInfo(5))
+	assert.Equal(t, uint64(5), receive().Epoch)
+
+	// reorged epoch is sent again
+	reorgedInfo := testutil.NewMinimalConsensusInfo(3)
+	reorgedInfo.ReorgInfo = &eventTypes.Reorg{}
+	backend.ConsensusInfoFeed.Send(reorgedInfo)
+	consensusInfo := receive()
+	assert.Equal(t, uint64(3), consensusInfo.Epoch)
+	require.NotNil(t, consensusInfo.ReorgInfo)
+}
+
+// Test_SubscriptionLimit checks that subscriptions of a connection are limited and released when they end
+func Test_SubscriptionLimit(t *testing.T) {
+	ctx := context.Background()
+	_, eventApi := setup(t)
+	eventApi.LimitSubscriptions(1)
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("orc", eventApi))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	verifiedBlockCh := make(chan *eventTypes.VerifiedBlockEvent, 1)
+	sub, err := client.Subscribe(ctx, "orc", verifiedBlockCh, "verifiedBlocks")
+	require.NoError(t, err)
+
+	_, err = client.Subscribe(ctx, "orc", make(chan *eventTypes.VerifiedBlockEvent, 1), "verifiedBlocks")
+	assert.ErrorContains(t, "too many subscriptions on connection", err)
+
+	// another connection has its own limit
+	otherClient := rpc.DialInProc(server)
+	defer otherClient.Close()
+	otherSub, err := otherClient.Subscribe(ctx, "orc", make(chan *eventTypes.VerifiedBlockEvent, 1), "verifiedBlocks")
+	require.NoError(t, err)
+	defer otherSub.Unsubscribe()
+
+	sub.Unsubscribe()
+	time.Sleep(100 * time.Millisecond)
+	sub, err = client.Subscribe(ctx, "orc", verifiedBlockCh, "verifiedBlocks")
+	require.NoError(t, err)
+	sub.Unsubscribe()
+}
+
+// testLeadership is the leadership of a high availability pair which the test changes
+type testLeadership struct {
+	leader bool
+	term   chan struct{}
+}
+
+func (l *testLeadership) IsLeader() bool        { return l.leader }
+func (l *testLeadership) Term() <-chan struct{} { return l.term }
+
+// Test_SubscriptionLeadership checks that a standby refuses subscriptions and subscriptions of the leader end
+// when it loses leadership
+func Test_SubscriptionLeadership(t *testing.T) {
+	ctx := context.Background()
+	_, eventApi := setup(t)
+	leadership := &testLeadership{}
+	eventApi.RequireLeadership(leadership)
+	eventApi.LimitSubscriptions(1)
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("orc", eventApi))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	_, err := client.Subscribe(ctx, "orc", make(chan *eventTypes.VerifiedBlockEvent, 1), "verifiedBlocks")
+	assert.ErrorContains(t, errNotLeader.Error(), err)
+
+	leadership.leader, leadership.term = true, make(chan struct{})
+	_, err = client.Subscribe(ctx, "orc", make(chan *eventTypes.VerifiedBlockEvent, 1), "verifiedBlocks")
+	require.NoError(t, err)
+
+	// the ended subscription is released, so the limit allows a subscription in the next term
+	close(leadership.term)
+	time.Sleep(100 * time.Millisecond)
+	leadership.term = make(chan struct{})
+	sub, err := client.Subscribe(ctx, "orc", make(chan *eventTypes.VerifiedBlockEvent, 1), "verifiedBlocks")
+	require.NoError(t, err)
+	sub.Unsubscribe()
+}
+
+// Test_ConfirmationLeadership checks that a standby refuses to confirm block hashes
+func Test_ConfirmationLeadership(t *testing.T) {
+	ctx := context.Background()
+	_, eventApi := setup(t)
+	leadership := &testLeadership{}
+	eventApi.RequireLeadership(leadership)
+	requests := []*BlockHash{{Slot: 1, Hash: common.HexToHash("0x61")}}
+
+	_, err := eventApi.ConfirmPanBlockHashes(ctx, requests)
+	assert.ErrorContains(t, errNotLeader.Error(), err)
+	_, err = eventApi.ConfirmVanBlockHashes(ctx, requests)
+	assert.ErrorContains(t, errNotLeader.Error(), err)
+
+	leadership.leader = true
+	statuses, err := eventApi.ConfirmPanBlockHashes(ctx, requests)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(statuses))
+	_, err = eventApi.ConfirmVanBlockHashes(ctx, requests)
+	require.NoError(t, err)
+}
+
+// Test_VerifiedBlocksWithHistory checks that stored verification events are replayed before live events and
+// replayed events are not sent twice
+func Test_VerifiedBlocksWithHistory(t *testing.T) {
+	ctx := context.Background()
+	backend, eventApi := setup(t)
+	backend.blockStatuses = map[uint64]*eventTypes.PandoraBlockStatus{
+		97:  {Slot: 97, PandoraHeaderHash: common.HexToHash("0x61"), Status: eventTypes.Verified},
+		98:  {Slot: 98, PandoraHeaderHash: common.HexToHash("0x62"), Status: eventTypes.Invalid},
+		99:  {Slot: 99, PandoraHeaderHash: common.HexToHash("0x63"), Status: eventTypes.Finalized},
+		100: {Slot: 100, PandoraHeaderHash: common.HexToHash("0x64"), Status: eventTypes.Pending},
+	}
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("orc", eventApi))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	_, err := client.Subscribe(ctx, "orc", make(chan *eventTypes.VerifiedBlockEvent), "verifiedBlocksWithHistory",
+		&VerificationHistoryRequest{})
+	assert.ErrorContains(t, "either fromSlot or lastSlots of the history must be given", err)
+	lastSlots := uint64(2000)
+	_, err = client.Subscribe(ctx, "orc", make(chan *eventTypes.VerifiedBlockEvent), "verifiedBlocksWithHistory",
+		&VerificationHistoryRequest{LastSlots: &lastSlots})
+	assert.ErrorContains(t, "lastSlots must be between 1 and 1024", err)
+
+	verifiedBlockCh := make(chan *eventTypes.VerifiedBlockEvent, 10)
+	lastSlots = 4
+	sub, err := client.Subscribe(ctx, "orc", verifiedBlockCh, "verifiedBlocksWithHistory",
+		&VerificationHistoryRequest{LastSlots: &lastSlots})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	receive := func() *eventTypes.VerifiedBlockEvent {
+		select {
+		case verifiedBlock := <-verifiedBlockCh:
+			return verifiedBlock
+		case err := <-sub.Err():
+			t.Fatal(err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("verified block has not been streamed")
+		}
+		return nil
+	}
+	assert.DeepEqual(t, &eventTypes.VerifiedBlockEvent{
+		Slot: 97, PandoraHash: common.HexToHash("0x61"), Status: eventTypes.Verified}, receive())
+	assert.DeepEqual(t, &eventTypes.VerifiedBlockEvent{
+		Slot: 98, PandoraHash: common.HexToHash("0x62"), Status: eventTypes.Invalid}, receive())
+	assert.DeepEqual(t, &eventTypes.VerifiedBlockEvent{
+		Slot: 99, PandoraHash: common.HexToHash("0x63"), Status: eventTypes.Verified}, receive())
+
+	// replayed event is skipped, unconfirmed slot is streamed once it is confirmed
+	backend.verificationResultFeed.Send(&eventTypes.VerificationResult{
+		Slot: 99, PandoraHeaderHash: common.HexToHash("0x63"), Status: eventTypes.Verified})
+	backend.verificationResultFeed.Send(&eventTypes.VerificationResult{
+		Slot: 100, PandoraHeaderHash: common.HexToHash("0x64"), Status: eventTypes.Verified})
+	assert.DeepEqual(t, &eventTypes.VerifiedBlockEvent{
+		Slot: 100, PandoraHash: common.HexToHash("0x64"), Status: eventTypes.Verified}, receive())
+}
+
+// Test_HistoryStartSlot checks the bounds of the replayed verification history
+func Test_HistoryStartSlot(t *testing.T) {
+	fromSlot, lastSlots := uint64(2000), uint64(10)
+	startSlot, err := historyStartSlot(&VerificationHistoryRequest{FromSlot: &fromSlot}, 3000)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2000), startSlot)
+
+	startSlot, err = historyStartSlot(&VerificationHistoryRequest{LastSlots: &lastSlots}, 3000)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2991), startSlot)
+
+	startSlot, err = historyStartSlot(&VerificationHistoryRequest{LastSlots: &lastSlots}, 5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), startSlot)
+
+	_, err = historyStartSlot(&VerificationHistoryRequest{FromSlot: &fromSlot}, 4000)
+	assert.ErrorContains(t, "history is limited to 1024 slots", err)
+	_, err = historyStartSlot(&VerificationHistoryRequest{FromSlot: &fromSlot, LastSlots: &lastSlots}, 3000)
+	assert.ErrorContains(t, "either fromSlot or lastSlots", err)
+}
+
+// Test_VerifiedHeadAt checks that the past head is requested by slot or by the last slot of an epoch
+func Test_VerifiedHeadAt(t *testing.T) {
+	ctx := context.Background()
+	backend, eventApi := setup(t)
+	backend.historicalHeads = map[uint64]*eventTypes.HistoricalHead{
+		40: {AsOfSlot: 40, Slot: 38, PandoraHeaderHash: common.HexToHash("0x38")},
+		95: {AsOfSlot: 95, Slot: 94, FinalizedSlot: 64},
+	}
+
+	_, err := eventApi.VerifiedHeadAt(ctx, nil)
+	assert.ErrorContains(t, "either slot or epoch of the history point must be given", err)
+	_, err = eventApi.VerifiedHeadAt(ctx, &HistoryPoint{})
+	assert.ErrorContains(t, "either slot or epoch of the history point must be given", err)
+
+	slot, epoch := uint64(40), uint64(2)
+	head, err := eventApi.VerifiedHeadAt(ctx, &HistoryPoint{Slot: &slot})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(38), head.Slot)
+	assert.Equal(t, common.HexToHash("0x38"), head.PandoraHeaderHash)
+
+	head, err = eventApi.VerifiedHeadAt(ctx, &HistoryPoint{Epoch: &epoch})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(94), head.Slot)
+	assert.Equal(t, uint64(64), head.FinalizedSlot)
+
+	blockStatus, err := eventApi.BlockStatusAt(ctx, common.HexToHash("0x38"), &HistoryPoint{Slot: &slot, Epoch: &epoch})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(40), blockStatus.AsOfSlot)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared/feed"
 	eventTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
 	"time"
 )
@@ -14,11 +15,13 @@ var (
 )
 
 type MockBackend struct {
-	ConsensusInfoFeed    event.Feed
-	verifiedSlotInfoFeed event.Feed
+	ConsensusInfoFeed      feed.Feed
+	verificationResultFeed feed.Feed
 
 	ConsensusInfos    []*eventTypes.MinimalEpochConsensusInfoV2
 	verifiedSlotInfos map[uint64]*eventTypes.SlotInfo
+	blockStatuses     map[uint64]*eventTypes.PandoraBlockStatus
+	historicalHeads   map[uint64]*eventTypes.HistoricalHead
 	CurEpoch          uint64
 }
 
@@ -32,12 +35,26 @@ func (b *MockBackend) ConsensusInfoByEpochRange(fromEpoch uint64) ([]*eventTypes
 	return consensusInfos, nil
 }
 
+func (b *MockBackend) ConsensusInfoRange(fromEpoch, toEpoch uint64, limit int) ([]*eventTypes.MinimalEpochConsensusInfoV2, error) {
+	consensusInfos := make([]*eventTypes.MinimalEpochConsensusInfoV2, 0)
+	for _, consensusInfo := range b.ConsensusInfos {
+		if consensusInfo.Epoch < fromEpoch || consensusInfo.Epoch > toEpoch {
+			continue
+		}
+		if limit > 0 && len(consensusInfos) >= limit {
+			break
+		}
+		consensusInfos = append(consensusInfos, consensusInfo)
+	}
+	return consensusInfos, nil
+}
+
 func (b *MockBackend) SubscribeNewEpochEvent(ch chan<- *eventTypes.MinimalEpochConsensusInfoV2) event.Subscription {
 	return b.ConsensusInfoFeed.Subscribe(ch)
 }
 
-func (b *MockBackend) SubscribeNewVerifiedSlotInfoEvent(ch chan<- *eventTypes.SlotInfoWithStatus) event.Subscription {
-	return b.verifiedSlotInfoFeed.Subscribe(ch)
+func (b *MockBackend) SubscribeNewVerificationResultEvent(ch chan<- *eventTypes.VerificationResult) event.Subscription {
+	return b.verificationResultFeed.Subscribe(ch)
 }
 
 func (mb *MockBackend) GetSlotStatus(ctx context.Context, slot uint64, hash common.Hash, requestType bool) eventTypes.Status {
@@ -64,6 +81,77 @@ func (mb *MockBackend) LatestVerifiedSlot() uint64 {
 	return 100
 }
 
+func (mb *MockBackend) LatestConfirmedSlot() uint64 {
+	return 100
+}
+
 func (mb *MockBackend) LatestFinalizedSlot() uint64 {
 	return 100
 }
+
+func (mb *MockBackend) LatestFinalizedHead() *eventTypes.FinalizedHead {
+	return &eventTypes.FinalizedHead{Slot: 100, Epoch: 3}
+}
+
+func (mb *MockBackend) VerificationProgress() *eventTypes.VerificationProgress {
+	return &eventTypes.VerificationProgress{LatestVerifiedSlot: 100, LatestFinalizedSlot: 100, LatestFinalizedEpoch: 3}
+}
+
+func (mb *MockBackend) ProposerForSlot(slot uint64) (string, error) {
+	return "", nil
+}
+
+func (mb *MockBackend) BlockStatusBySlot(slot uint64) (*eventTypes.PandoraBlockStatus, error) {
+	if blockStatus, ok := mb.blockStatuses[slot]; ok {
+		return blockStatus, nil
+	}
+	return &eventTypes.PandoraBlockStatus{Slot: slot, Status: eventTypes.Unknown}, nil
+}
+
+func (mb *MockBackend) StoredBlockStatuses(fromSlot, toSlot uint64) ([]*eventTypes.PandoraBlockStatus, error) {
+	blockStatuses := make([]*eventTypes.PandoraBlockStatus, 0)
+	for slot := fromSlot; slot <= toSlot; slot++ {
+		if blockStatus, ok := mb.blockStatuses[slot]; ok {
+			blockStatuses = append(blockStatuses, blockStatus)
+		}
+	}
+	return blockStatuses, nil
+}
+
+func (mb *MockBackend) BlockStatusByHash(hash common.Hash) (*eventTypes.PandoraBlockStatus, error) {
+	return &eventTypes.PandoraBlockStatus{PandoraHeaderHash: hash, Status: eventTypes.Unknown}, nil
+}
+
+func (mb *MockBackend) BlockStatusesByHashes(hashes []common.Hash) ([]*eventTypes.PandoraBlockStatus, error) {
+	blockStatuses := make([]*eventTypes.PandoraBlockStatus, len(hashes))
+	for i, hash := range hashes {
+		blockStatuses[i], _ = mb.BlockStatusByHash(hash)
+	}
+	return blockStatuses, nil
+}
+
+func (mb *MockBackend) HeadAt(asOfSlot uint64) (*eventTypes.HistoricalHead, error) {
+	if head, ok := mb.historicalHeads[asOfSlot]; ok {
+		return head, nil
+	}
+	return &eventTypes.HistoricalHead{AsOfSlot: asOfSlot}, nil
+}
+
+func (mb *MockBackend) BlockStatusAt(hash common.Hash, asOfSlot uint64) (*eventTypes.HistoricalBlockStatus, error) {
+	return &eventTypes.HistoricalBlockStatus{
+		AsOfSlot:           asOfSlot,
+		PandoraBlockStatus: eventTypes.PandoraBlockStatus{PandoraHeaderHash: hash, Status: eventTypes.Unknown},
+	}, nil
+}
+
+func (mb *MockBackend) MismatchEvidence(slot uint64) (*eventTypes.MismatchEvidence, error) {
+	return nil, nil
+}
+
+func (mb *MockBackend) EpochSummaries(fromEpoch, toEpoch uint64) ([]*eventTypes.EpochSummary, error) {
+	summaries := make([]*eventTypes.EpochSummary, 0)
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		summaries = append(summaries, &eventTypes.EpochSummary{Epoch: epoch})
+	}
+	return summaries, nil
+}
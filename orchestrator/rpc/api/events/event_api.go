@@ -18,9 +18,20 @@ func (api *PublicFilterAPI) SteamConfirmedPanBlockHashes(
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
+	if request == nil {
+		return &rpc.Subscription{}, invalidParams("start block hash must be given")
+	}
+	if err := validateSlot("slot", request.Slot); err != nil {
+		return &rpc.Subscription{}, err
+	}
+	release, term, err := api.subscriptions.acquire(notifier.Closed())
+	if err != nil {
+		return &rpc.Subscription{}, err
+	}
 	rpcSub := notifier.CreateSubscription()
 
 	go func() {
+		defer release()
 
 		batchSender := func(start, end uint64) error {
 			slotInfos := api.backend.VerifiedSlotInfos(start)
@@ -50,7 +61,7 @@ func (api *PublicFilterAPI) SteamConfirmedPanBlockHashes(
 		}
 
 		startSlot := request.Slot
-		endSlot := api.backend.LatestVerifiedSlot()
+		endSlot := api.backend.LatestConfirmedSlot()
 		log.WithField("startSlot", startSlot).WithField("endSlot", endSlot).
 			Debug("received information from pandora")
 
@@ -60,18 +71,19 @@ func (api *PublicFilterAPI) SteamConfirmedPanBlockHashes(
 			}
 		}
 
-		slotInfoCh := make(chan *generalTypes.SlotInfoWithStatus)
-		verifiedSlotInfoSub := api.events.SubscribeVerifiedSlotInfo(slotInfoCh)
+		slotInfoCh := make(chan *generalTypes.VerificationResult, api.buffer.Size)
+		verifiedSlotInfoSub := api.events.SubscribeVerificationResult(slotInfoCh)
 		firstTime := true
 
 		for {
 			select {
-			case slotInfoWithStatus := <-slotInfoCh:
-				log.WithField("hash", slotInfoWithStatus.PandoraHeaderHash).Debug("Sending slot info status to pandora")
+			case verificationResult := <-slotInfoCh:
+				log.WithField("slot", verificationResult.Slot).WithField("hash", verificationResult.PandoraHeaderHash).
+					Debug("Sending slot info status to pandora")
 				if firstTime {
 					firstTime = false
 					startSlot = endSlot
-					endSlot = api.backend.LatestVerifiedSlot()
+					endSlot = api.backend.LatestConfirmedSlot()
 					log.WithField("startSlot", startSlot).WithField("endSlot", endSlot).Debug("for the first time")
 					if startSlot+1 < endSlot {
 						if err := batchSender(startSlot, endSlot); err != nil {
@@ -81,14 +93,22 @@ func (api *PublicFilterAPI) SteamConfirmedPanBlockHashes(
 				}
 
 				if err := notifier.Notify(rpcSub.ID, &generalTypes.BlockStatus{
-					Hash:          slotInfoWithStatus.PandoraHeaderHash,
-					Status:        slotInfoWithStatus.Status,
+					Hash:          verificationResult.PandoraHeaderHash,
+					Status:        verificationResult.Status,
 					FinalizedSlot: api.backend.LatestFinalizedSlot(),
 				}); err != nil {
-					log.WithField("hash", slotInfoWithStatus.PandoraHeaderHash).
+					log.WithField("hash", verificationResult.PandoraHeaderHash).
 						Error("Failed to notify slot info status. Could not send over stream.")
 					return
 				}
+			case <-term:
+				log.Warn("Lost leadership. Ending subscription of SteamConfirmedPanBlockHashes")
+				verifiedSlotInfoSub.Unsubscribe()
+				return
+			case <-verifiedSlotInfoSub.Evicted():
+				log.Warn("Subscriber fell behind. Ending subscription of SteamConfirmedPanBlockHashes")
+				verifiedSlotInfoSub.Unsubscribe()
+				return
 			case <-rpcSub.Err():
 				log.Info("Unsubscribing registered subscriber from SteamConfirmedPanBlockHashes")
 				verifiedSlotInfoSub.Unsubscribe()
@@ -103,3 +123,194 @@ func (api *PublicFilterAPI) SteamConfirmedPanBlockHashes(
 
 	return rpcSub, nil
 }
+
+// VerifiedBlocks streams slot, pandora header hash and status of every verification result of consensus
+// service, so that subscribers are notified about confirmations and invalidations as they happen.
+func (api *PublicFilterAPI) VerifiedBlocks(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	release, term, err := api.subscriptions.acquire(notifier.Closed())
+	if err != nil {
+		return &rpc.Subscription{}, err
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		defer release()
+		verificationResultCh := make(chan *generalTypes.VerificationResult, api.buffer.Size)
+		verificationResultSub := api.events.SubscribeVerificationResult(verificationResultCh)
+		defer verificationResultSub.Unsubscribe()
+
+		for {
+			select {
+			case verificationResult := <-verificationResultCh:
+				if err := notifier.Notify(rpcSub.ID, &generalTypes.VerifiedBlockEvent{
+					Slot:        verificationResult.Slot,
+					PandoraHash: verificationResult.PandoraHeaderHash,
+					Status:      verificationResult.Status,
+				}); err != nil {
+					log.WithField("slot", verificationResult.Slot).WithError(err).
+						Error("Failed to notify verified block. Could not send over stream.")
+					return
+				}
+			case <-term:
+				log.Warn("Lost leadership. Ending subscription of VerifiedBlocks")
+				return
+			case <-verificationResultSub.Evicted():
+				log.Warn("Subscriber fell behind. Ending subscription of VerifiedBlocks")
+				return
+			case <-rpcSub.Err():
+				log.Info("Unsubscribing registered subscriber from VerifiedBlocks")
+				return
+			case <-notifier.Closed():
+				log.Info("Closing notifier. Unsubscribing registered subscriber from VerifiedBlocks")
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// VerifiedBlocksWithHistory streams the stored verification events of the requested slots before switching to
+// live verification results, so that a subscriber which has been disconnected for a while does not need a
+// separate backfill. Only verified and invalid slots up to the latest verified slot are replayed, unconfirmed
+// slots are streamed live once they are confirmed. Live events which repeat a replayed event are skipped.
+func (api *PublicFilterAPI) VerifiedBlocksWithHistory(
+	ctx context.Context,
+	request *VerificationHistoryRequest,
+) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	latestSlot := api.backend.LatestVerifiedSlot()
+	fromSlot, err := historyStartSlot(request, latestSlot)
+	if err != nil {
+		return &rpc.Subscription{}, err
+	}
+	release, term, err := api.subscriptions.acquire(notifier.Closed())
+	if err != nil {
+		return &rpc.Subscription{}, err
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		defer release()
+		// subscribing before replay guarantees that no live event is missed in between
+		verificationResultCh := make(chan *generalTypes.VerificationResult, api.buffer.Size)
+		verificationResultSub := api.events.SubscribeVerificationResult(verificationResultCh)
+		defer verificationResultSub.Unsubscribe()
+
+		send := func(verifiedBlock *generalTypes.VerifiedBlockEvent) error {
+			if err := notifier.Notify(rpcSub.ID, verifiedBlock); err != nil {
+				log.WithField("slot", verifiedBlock.Slot).WithError(err).
+					Error("Failed to notify verified block. Could not send over stream.")
+				return err
+			}
+			return nil
+		}
+
+		log.WithField("fromSlot", fromSlot).WithField("toSlot", latestSlot).
+			Debug("Replaying verification history to subscriber")
+		replayed := make(map[uint64]generalTypes.VerifiedBlockEvent)
+		for slot := fromSlot; slot <= latestSlot; slot++ {
+			blockStatus, err := api.backend.BlockStatusBySlot(slot)
+			if err != nil {
+				log.WithField("slot", slot).WithError(err).Error("Could not read stored slot status")
+				return
+			}
+			verifiedBlock := historyEvent(blockStatus)
+			if verifiedBlock != nil {
+				if err := send(verifiedBlock); err != nil {
+					return
+				}
+				replayed[slot] = *verifiedBlock
+			}
+			if slot == latestSlot {
+				break
+			}
+		}
+
+		for {
+			select {
+			case verificationResult := <-verificationResultCh:
+				verifiedBlock := &generalTypes.VerifiedBlockEvent{
+					Slot:        verificationResult.Slot,
+					PandoraHash: verificationResult.PandoraHeaderHash,
+					Status:      verificationResult.Status,
+				}
+				if sent, ok := replayed[verifiedBlock.Slot]; ok {
+					delete(replayed, verifiedBlock.Slot)
+					if sent == *verifiedBlock {
+						continue
+					}
+				}
+				if err := send(verifiedBlock); err != nil {
+					return
+				}
+			case <-term:
+				log.Warn("Lost leadership. Ending subscription of VerifiedBlocksWithHistory")
+				return
+			case <-verificationResultSub.Evicted():
+				log.Warn("Subscriber fell behind. Ending subscription of VerifiedBlocksWithHistory")
+				return
+			case <-rpcSub.Err():
+				log.Info("Unsubscribing registered subscriber from VerifiedBlocksWithHistory")
+				return
+			case <-notifier.Closed():
+				log.Info("Closing notifier. Unsubscribing registered subscriber from VerifiedBlocksWithHistory")
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// historyStartSlot returns the first slot of the requested verification history which ends at the latest slot
+func historyStartSlot(request *VerificationHistoryRequest, latestSlot uint64) (uint64, error) {
+	switch {
+	case request == nil || (request.FromSlot == nil) == (request.LastSlots == nil):
+		return 0, invalidParams("either fromSlot or lastSlots of the history must be given")
+	case request.LastSlots != nil:
+		lastSlots := *request.LastSlots
+		if lastSlots < 1 || lastSlots > maxVerificationHistorySlots {
+			return 0, invalidParams("lastSlots must be between 1 and %d", maxVerificationHistorySlots)
+		}
+		if lastSlots > latestSlot {
+			return 0, nil
+		}
+		return latestSlot - lastSlots + 1, nil
+	default:
+		fromSlot := *request.FromSlot
+		if err := validateSlot("fromSlot", fromSlot); err != nil {
+			return 0, err
+		}
+		if fromSlot <= latestSlot && latestSlot-fromSlot >= maxVerificationHistorySlots {
+			return 0, invalidParams("history is limited to %d slots, slot %d is too old", maxVerificationHistorySlots, fromSlot)
+		}
+		return fromSlot, nil
+	}
+}
+
+// historyEvent converts a stored slot status into the verification event which has been sent for it. Slots without
+// a verdict and verified slots which have not been confirmed yet are not part of the history.
+func historyEvent(blockStatus *generalTypes.PandoraBlockStatus) *generalTypes.VerifiedBlockEvent {
+	var status generalTypes.Status
+	switch blockStatus.Status {
+	case generalTypes.Verified, generalTypes.Finalized:
+		status = generalTypes.Verified
+	case generalTypes.Invalid:
+		status = generalTypes.Invalid
+	default:
+		return nil
+	}
+	return &generalTypes.VerifiedBlockEvent{
+		Slot:        blockStatus.Slot,
+		PandoraHash: blockStatus.PandoraHeaderHash,
+		Status:      status,
+	}
+}
@@ -4,11 +4,16 @@ import (
 	"github.com/ethereum/go-ethereum/event"
 	ethLog "github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/eventbuffer"
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"reflect"
 	"sync"
 	"time"
 )
 
+var rpcMetrics = metrics.NewCollector("rpc")
+
 // Type determines the kind of filter and is used to put the filter in to
 // the correct bucket when added.
 type Type byte
@@ -34,10 +39,17 @@ type subscription struct {
 	created   time.Time
 	installed chan struct{} // closed when the filter is installed
 	err       chan error    // closed when the filter is uninstalled
+	done      chan struct{} // closed when the subscription is being uninstalled, events are not sent anymore
 
-	epoch         uint64 // last served epoch number
+	epoch         uint64             // last served epoch number
+	policy        eventbuffer.Policy // what happens to events when the channel of subscription is full
 	consensusInfo chan *types.MinimalEpochConsensusInfoV2
-	slotInfo      chan *types.SlotInfoWithStatus
+	slotInfo      chan *types.VerificationResult
+
+	// slow and evicted are only accessed by the event loop
+	slow         bool          // the channel has been full
+	evicted      bool          // the subscription has been dropped by its policy
+	evictedClose chan struct{} // closed when the subscription has been dropped
 }
 
 // EventSystem creates subscriptions, processes events and broadcasts them to the
@@ -53,7 +65,13 @@ type EventSystem struct {
 	install         chan *subscription                      // install filter for event notification
 	uninstall       chan *subscription                      // remove filter for event notification
 	consensusInfoCh chan *types.MinimalEpochConsensusInfoV2 // Channel to receive new new consensus info event
-	slotInfoCh      chan *types.SlotInfoWithStatus
+	slotInfoCh      chan *types.VerificationResult
+
+	policy eventbuffer.Policy // policy of full subscription channels, it is set before subscriptions are made
+
+	dropped metrics.Counter // number of events dropped because the buffer of a subscription was full
+	slow    metrics.Counter // number of subscriptions whose buffer has been full, each subscription is counted once
+	evicted metrics.Counter // number of subscriptions which have been ended because their client fell behind
 }
 
 // NewEventSystem creates a new manager that listens for event on the given mux,
@@ -68,7 +86,11 @@ func NewEventSystem(backend Backend) *EventSystem {
 		install:         make(chan *subscription),
 		uninstall:       make(chan *subscription),
 		consensusInfoCh: make(chan *types.MinimalEpochConsensusInfoV2, 1),
-		slotInfoCh:      make(chan *types.SlotInfoWithStatus, 1),
+		slotInfoCh:      make(chan *types.VerificationResult, 1),
+		policy:          eventbuffer.Block,
+		dropped:         rpcMetrics.Counter("subscriptions/dropped"),
+		slow:            rpcMetrics.Counter("subscriptions/slow"),
+		evicted:         rpcMetrics.Counter("subscriptions/evicted"),
 	}
 
 	// Subscribe events
@@ -77,9 +99,9 @@ func NewEventSystem(backend Backend) *EventSystem {
 	if m.consensusInfoSub == nil {
 		ethLog.Crit("Subscribe for minimal consensus info event system failed")
 	}
-	m.verifiedSlotInfoSub = m.backend.SubscribeNewVerifiedSlotInfoEvent(m.slotInfoCh)
+	m.verifiedSlotInfoSub = m.backend.SubscribeNewVerificationResultEvent(m.slotInfoCh)
 	// Make sure none of the subscriptions are empty
-	if m.consensusInfoSub == nil {
+	if m.verifiedSlotInfoSub == nil {
 		ethLog.Crit("Subscribe for verified slot info event system failed")
 	}
 
@@ -100,9 +122,17 @@ func (sub *Subscription) Err() <-chan error {
 	return sub.f.err
 }
 
+// Evicted returns a channel that is closed when the subscription has fallen behind and has been dropped by
+// drop-subscriber policy. No more events are delivered, so the subscription must be ended.
+func (sub *Subscription) Evicted() <-chan struct{} {
+	return sub.f.evictedClose
+}
+
 // Unsubscribe uninstalls the subscription from the event broadcast loop.
 func (sub *Subscription) Unsubscribe() {
 	sub.unsubOnce.Do(func() {
+		// an event loop which blocks on the full channel of the subscription gives up the event
+		close(sub.f.done)
 	uninstallLoop:
 		for {
 			// write uninstall request and consume logs/hashes. This prevents
@@ -113,6 +143,7 @@ func (sub *Subscription) Unsubscribe() {
 			case sub.es.uninstall <- sub.f:
 				break uninstallLoop
 			case <-sub.f.consensusInfo:
+			case <-sub.f.slotInfo:
 			}
 		}
 
@@ -125,6 +156,9 @@ func (sub *Subscription) Unsubscribe() {
 
 // subscribe installs the subscription in the event broadcast loop.
 func (es *EventSystem) subscribe(sub *subscription) *Subscription {
+	sub.policy = es.policy
+	sub.done = make(chan struct{})
+	sub.evictedClose = make(chan struct{})
 	es.install <- sub
 	<-sub.installed
 	return &Subscription{ID: sub.id, f: sub, es: es}
@@ -145,8 +179,8 @@ func (es *EventSystem) SubscribeConsensusInfo(consensusInfo chan *types.MinimalE
 	return es.subscribe(sub)
 }
 
-// SubscribeVerifiedSlotInfo
-func (es *EventSystem) SubscribeVerifiedSlotInfo(slotInfo chan *types.SlotInfoWithStatus) *Subscription {
+// SubscribeVerificationResult
+func (es *EventSystem) SubscribeVerificationResult(slotInfo chan *types.VerificationResult) *Subscription {
 	sub := &subscription{
 		id:        rpc.NewID(),
 		typ:       VerifiedSlotInfoSubscription,
@@ -163,14 +197,32 @@ type filterIndex map[Type]map[rpc.ID]*subscription
 // handleConsensusInfoEvent
 func (es *EventSystem) handleConsensusInfoEvent(filters filterIndex, ev *types.MinimalEpochConsensusInfoV2) {
 	for _, f := range filters[MinConsensusInfoSubscription] {
-		f.consensusInfo <- ev
+		es.deliver(f, f.consensusInfo, ev)
 	}
 }
 
-// handleVerifiedSlotInfoEvent
-func (es *EventSystem) handleVerifiedSlotInfoEvent(filters filterIndex, si *types.SlotInfoWithStatus) {
+// handleVerificationResultEvent
+func (es *EventSystem) handleVerificationResultEvent(filters filterIndex, si *types.VerificationResult) {
 	for _, f := range filters[VerifiedSlotInfoSubscription] {
-		f.slotInfo <- si
+		es.deliver(f, f.slotInfo, si)
+	}
+}
+
+// deliver sends the event into the channel of the subscription by its policy. A subscription whose channel is full
+// is counted as slow once, with drop-subscriber policy it is evicted and receives no more events.
+func (es *EventSystem) deliver(f *subscription, ch interface{}, ev interface{}) {
+	if f.evicted {
+		return
+	}
+	if chValue := reflect.ValueOf(ch); !f.slow && chValue.Cap() > 0 && chValue.Len() >= chValue.Cap() {
+		f.slow = true
+		es.slow.Inc(1)
+		log.WithField("id", f.id).WithField("policy", f.policy).Warn("Subscriber falls behind, its buffer is full")
+	}
+	if !eventbuffer.Send(ch, ev, f.policy, f.done, es.dropped) && f.policy == eventbuffer.DropSubscriber {
+		f.evicted = true
+		close(f.evictedClose)
+		es.evicted.Inc(1)
 	}
 }
 
@@ -191,7 +243,7 @@ func (es *EventSystem) eventLoop() {
 		case ev := <-es.consensusInfoCh:
 			es.handleConsensusInfoEvent(index, ev)
 		case si := <-es.slotInfoCh:
-			es.handleVerifiedSlotInfoEvent(index, si)
+			es.handleVerificationResultEvent(index, si)
 		case f := <-es.install:
 			index[f.typ][f.id] = f
 			close(f.installed)
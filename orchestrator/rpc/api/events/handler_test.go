@@ -1,6 +1,8 @@
 package events
 
 import (
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/eventbuffer"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
 	eventTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
@@ -96,3 +98,91 @@ func Test_MinimalConsensusInfo_With_Future_Epoch(t *testing.T) {
 
 	<-subscriber.Err()
 }
+
+// Test_MinimalConsensusInfo_DropOldest checks that a subscriber which does not read keeps the latest consensus
+// info instead of stalling the event loop
+func Test_MinimalConsensusInfo_DropOldest(t *testing.T) {
+	backend, eventApi := setup(t)
+	eventApi.SetSubscriptionBuffer(eventbuffer.Config{Size: 1, Policy: eventbuffer.DropOldest})
+
+	receiverChan := make(chan *eventTypes.MinimalEpochConsensusInfoV2, 1)
+	subscriber := eventApi.events.SubscribeConsensusInfo(receiverChan, 5)
+	for epoch := uint64(5); epoch <= 7; epoch++ {
+		backend.ConsensusInfoFeed.Send(testutil.NewMinimalConsensusInfo(epoch))
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	assert.DeepEqual(t, testutil.NewMinimalConsensusInfo(7), <-receiverChan)
+	subscriber.Unsubscribe()
+}
+
+// Test_MinimalConsensusInfo_DropSubscriber checks that a subscriber which does not read is evicted once its buffer
+// is full and receives no more events
+func Test_MinimalConsensusInfo_DropSubscriber(t *testing.T) {
+	// metrics are enabled after the start of the process, e.g. by the config file
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = false }()
+	for _, name := range []string{"subscriptions/slow", "subscriptions/evicted"} {
+		metrics.DefaultRegistry.Unregister(rpcMetrics.Name(name))
+	}
+	backend, eventApi := setup(t)
+	eventApi.SetSubscriptionBuffer(eventbuffer.Config{Size: 1, Policy: eventbuffer.DropSubscriber})
+
+	receiverChan := make(chan *eventTypes.MinimalEpochConsensusInfoV2, 1)
+	subscriber := eventApi.events.SubscribeConsensusInfo(receiverChan, 5)
+	backend.ConsensusInfoFeed.Send(testutil.NewMinimalConsensusInfo(5))
+	select {
+	case <-subscriber.Evicted():
+		t.Fatal("subscriber has been evicted before its buffer was full")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	for epoch := uint64(6); epoch <= 7; epoch++ {
+		backend.ConsensusInfoFeed.Send(testutil.NewMinimalConsensusInfo(epoch))
+	}
+	select {
+	case <-subscriber.Evicted():
+	case <-time.After(time.Second):
+		t.Fatal("slow subscriber has not been evicted")
+	}
+	assert.DeepEqual(t, testutil.NewMinimalConsensusInfo(5), <-receiverChan)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 0, len(receiverChan))
+	assert.Equal(t, int64(1), eventApi.events.slow.Count())
+	assert.Equal(t, int64(1), eventApi.events.evicted.Count())
+	subscriber.Unsubscribe()
+}
+
+// Test_VerificationResult_UnsubscribeFull checks that a verified slot subscriber whose buffer is full can
+// unsubscribe while the event loop blocks on it, and the event loop keeps serving other subscribers
+func Test_VerificationResult_UnsubscribeFull(t *testing.T) {
+	backend, eventApi := setup(t)
+	fullChan := make(chan *eventTypes.VerificationResult, 1)
+	fullSub := eventApi.events.SubscribeVerificationResult(fullChan)
+	for slot := uint64(1); slot <= 2; slot++ {
+		backend.verificationResultFeed.Send(&eventTypes.VerificationResult{Slot: slot})
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	unsubscribed := make(chan struct{})
+	go func() {
+		fullSub.Unsubscribe()
+		close(unsubscribed)
+	}()
+	select {
+	case <-unsubscribed:
+	case <-time.After(time.Second):
+		t.Fatal("unsubscribe blocks on the event loop")
+	}
+
+	receiverChan := make(chan *eventTypes.VerificationResult, 1)
+	subscriber := eventApi.events.SubscribeVerificationResult(receiverChan)
+	defer subscriber.Unsubscribe()
+	backend.verificationResultFeed.Send(&eventTypes.VerificationResult{Slot: 3})
+	select {
+	case result := <-receiverChan:
+		assert.Equal(t, uint64(3), result.Slot)
+	case <-time.After(time.Second):
+		t.Fatal("event loop does not deliver events anymore")
+	}
+}
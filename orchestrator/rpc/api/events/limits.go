@@ -0,0 +1,93 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/ha"
+	"github.com/lukso-network/lukso-orchestrator/shared/eventbuffer"
+)
+
+// errNotLeader refuses subscriptions and confirmations on a standby orchestrator
+var errNotLeader = errors.New("orchestrator is a standby which does not serve authoritative feeds, use the leader")
+
+// subscriptionLimiter limits the number of active subscriptions of a single rpc connection. Connections are
+// identified by their closed channel which is shared by all notifiers of the connection.
+type subscriptionLimiter struct {
+	max        int
+	lock       sync.Mutex
+	counts     map[<-chan interface{}]int
+	leadership ha.Leadership // nil when subscriptions are served regardless of leadership
+}
+
+func newSubscriptionLimiter() *subscriptionLimiter {
+	return &subscriptionLimiter{counts: make(map[<-chan interface{}]int)}
+}
+
+// acquire reserves a subscription of the given connection. The returned function must be called once
+// the subscription ends. Zero max disables the limit. The returned channel is closed when this orchestrator
+// loses leadership, so the subscription ends and the client reconnects to the new leader, it is nil when
+// leadership is not required.
+func (l *subscriptionLimiter) acquire(conn <-chan interface{}) (func(), <-chan struct{}, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var term <-chan struct{}
+	if l.leadership != nil {
+		if !l.leadership.IsLeader() {
+			return nil, nil, errNotLeader
+		}
+		term = l.leadership.Term()
+	}
+	if l.max > 0 && l.counts[conn] >= l.max {
+		return nil, nil, fmt.Errorf("too many subscriptions on connection, maximum %d subscriptions", l.max)
+	}
+	l.counts[conn]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.lock.Lock()
+			defer l.lock.Unlock()
+			if l.counts[conn]--; l.counts[conn] <= 0 {
+				delete(l.counts, conn)
+			}
+		})
+	}, term, nil
+}
+
+// requireLeader returns errNotLeader when leadership is required and this orchestrator does not lead
+func (l *subscriptionLimiter) requireLeader() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.leadership != nil && !l.leadership.IsLeader() {
+		return errNotLeader
+	}
+	return nil
+}
+
+// LimitSubscriptions sets the maximum number of active subscriptions of a single connection, zero disables the limit
+func (api *PublicFilterAPI) LimitSubscriptions(max int) {
+	api.subscriptions.lock.Lock()
+	defer api.subscriptions.lock.Unlock()
+	api.subscriptions.max = max
+}
+
+// RequireLeadership refuses subscriptions and block hash confirmations while this orchestrator is not the leader
+// and ends subscriptions when it loses leadership, so only the leader serves the authoritative feeds
+func (api *PublicFilterAPI) RequireLeadership(leadership ha.Leadership) {
+	api.subscriptions.lock.Lock()
+	defer api.subscriptions.lock.Unlock()
+	api.subscriptions.leadership = leadership
+}
+
+// SetSubscriptionBuffer sets the number of live events buffered for a single subscription and what happens to
+// events of a subscription whose client falls behind, zero size keeps the default buffer
+func (api *PublicFilterAPI) SetSubscriptionBuffer(buffer eventbuffer.Config) {
+	if buffer.Size < 1 {
+		return
+	}
+	api.buffer = buffer
+	api.events.policy = buffer.Policy
+}
@@ -0,0 +1,54 @@
+package events
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+)
+
+var errInvalidPageToken = &InvalidParamsError{Message: "invalid page token"}
+
+// encodePageToken returns the opaque token of the page of a range query which starts at next. The end of the
+// range is part of the token, so a token can only continue the query it has been returned by.
+func encodePageToken(next, to uint64) string {
+	buf := make([]byte, 16)
+	binary.BigEndian.PutUint64(buf, next)
+	binary.BigEndian.PutUint64(buf[8:], to)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// decodePageToken returns the start of the page of [from, to] range query which is referenced by the token
+func decodePageToken(token string, from, to uint64) (uint64, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(buf) != 16 {
+		return 0, errInvalidPageToken
+	}
+	next := binary.BigEndian.Uint64(buf)
+	if binary.BigEndian.Uint64(buf[8:]) != to || next < from || next > to {
+		return 0, invalidParams("%s, token belongs to another range", errInvalidPageToken)
+	}
+	return next, nil
+}
+
+// pageLimit returns the number of items of a single page, the default page size is used when size is not given
+func (api *PublicFilterAPI) pageLimit(size *int) (int, error) {
+	limit := defaultConsensusInfoPageSize
+	if limit > api.maxPageSize {
+		limit = api.maxPageSize
+	}
+	if size != nil {
+		limit = *size
+	}
+	if limit < 1 || limit > api.maxPageSize {
+		return 0, invalidParams("page size must be between 1 and %d", api.maxPageSize)
+	}
+	return limit, nil
+}
+
+// LimitPageSize sets the maximum number of items returned by a single page of range queries, so a single
+// response never holds an unbounded number of items. Values below one keep the default maximum.
+func (api *PublicFilterAPI) LimitPageSize(max int) {
+	if max < 1 {
+		max = maxConsensusInfoPageSize
+	}
+	api.maxPageSize = max
+}
@@ -0,0 +1,118 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+)
+
+const (
+	// json-rpc error code of requests with invalid parameters
+	invalidParamsCode = -32602
+	// maximum slot which is accepted by the api, it is far beyond the lifetime of any chain and keeps slot
+	// arithmetic of the backend from overflowing
+	maxSlotParam = uint64(1) << 40
+	// maximum number of block hashes which are confirmed by a single ConfirmPanBlockHashes or ConfirmVanBlockHashes
+	// request
+	maxConfirmationBatchSize = 1024
+)
+
+// InvalidParamsError rejects a request whose parameters are malformed or exceed the limits of the api before they
+// reach the database. Json-rpc clients receive it with the invalid params error code.
+type InvalidParamsError struct {
+	Message string
+}
+
+func (e *InvalidParamsError) Error() string {
+	return e.Message
+}
+
+// ErrorCode returns the json-rpc error code of the error
+func (e *InvalidParamsError) ErrorCode() int {
+	return invalidParamsCode
+}
+
+func invalidParams(format string, args ...interface{}) error {
+	return &InvalidParamsError{Message: fmt.Sprintf(format, args...)}
+}
+
+// validateSlot rejects slots beyond the maximum slot of the api
+func validateSlot(name string, slot uint64) error {
+	if slot > maxSlotParam {
+		return invalidParams("%s %d is beyond maximum slot %d", name, slot, maxSlotParam)
+	}
+	return nil
+}
+
+// validateEpoch rejects epochs whose slots are beyond the maximum slot of the api
+func validateEpoch(name string, epoch uint64) error {
+	if maxEpoch := maxSlotParam / params.ActiveNetworkConfig().SlotsPerEpoch; epoch > maxEpoch {
+		return invalidParams("%s %d is beyond maximum epoch %d", name, epoch, maxEpoch)
+	}
+	return nil
+}
+
+// validateSlotRange rejects [fromSlot, toSlot] ranges which are reversed or hold more than max slots
+func validateSlotRange(fromSlot, toSlot uint64, max int) error {
+	if err := validateSlot("to slot", toSlot); err != nil {
+		return err
+	}
+	if fromSlot > toSlot {
+		return invalidParams("from slot %d is greater than to slot %d", fromSlot, toSlot)
+	}
+	if toSlot-fromSlot >= uint64(max) {
+		return invalidParams("too many slots requested, maximum %d slots", max)
+	}
+	return nil
+}
+
+// validateEpochRange rejects [fromEpoch, toEpoch] ranges which are reversed or, unless max is zero, hold more than
+// max epochs
+func validateEpochRange(fromEpoch, toEpoch uint64, max int) error {
+	if err := validateEpoch("to epoch", toEpoch); err != nil {
+		return err
+	}
+	if fromEpoch > toEpoch {
+		return invalidParams("from epoch %d is greater than to epoch %d", fromEpoch, toEpoch)
+	}
+	if max > 0 && toEpoch-fromEpoch >= uint64(max) {
+		return invalidParams("too many epochs requested, maximum %d epochs", max)
+	}
+	return nil
+}
+
+// validateHash rejects the zero hash, which is never the hash of a block
+func validateHash(name string, hash common.Hash) error {
+	if hash == (common.Hash{}) {
+		return invalidParams("%s must not be zero", name)
+	}
+	return nil
+}
+
+// validateBatch rejects batches of more than max items
+func validateBatch(name string, size int, max int) error {
+	if size > max {
+		return invalidParams("too many %s requested, maximum %d %s", name, max, name)
+	}
+	return nil
+}
+
+// validateBlockHashes rejects confirmation requests which are empty, oversized or hold invalid block hashes
+func validateBlockHashes(requests []*BlockHash) error {
+	if len(requests) < 1 {
+		return invalidParams("no block hashes given")
+	}
+	if err := validateBatch("block hashes", len(requests), maxConfirmationBatchSize); err != nil {
+		return err
+	}
+	for i, req := range requests {
+		if req == nil {
+			return invalidParams("block hash %d is null", i)
+		}
+		if err := validateSlot(fmt.Sprintf("slot of block hash %d", i), req.Slot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+// Test_InvalidParams checks that malformed and oversized parameters are rejected with the invalid params code
+func Test_InvalidParams(t *testing.T) {
+	ctx := context.Background()
+	_, eventApi := setup(t)
+	absurdSlot, absurdEpoch := maxSlotParam+1, maxSlotParam
+	zeroHash := common.Hash{}
+	tooManyHashes := make([]*BlockHash, maxConfirmationBatchSize+1)
+	for i := range tooManyHashes {
+		tooManyHashes[i] = &BlockHash{Slot: uint64(i), Hash: common.HexToHash("0x01")}
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		err  string
+	}{
+		{
+			name: "zero hash",
+			call: func() error {
+				_, err := eventApi.GetBlockStatus(ctx, &BlockStatusRequest{Hash: &zeroHash})
+				return err
+			},
+			err: "hash must not be zero",
+		},
+		{
+			name: "absurd slot",
+			call: func() error {
+				_, err := eventApi.GetBlockStatus(ctx, &BlockStatusRequest{Slot: &absurdSlot})
+				return err
+			},
+			err: "beyond maximum slot",
+		},
+		{
+			name: "absurd epoch",
+			call: func() error {
+				_, err := eventApi.VerifiedHeadAt(ctx, &HistoryPoint{Epoch: &absurdEpoch})
+				return err
+			},
+			err: "beyond maximum epoch",
+		},
+		{
+			name: "absurd epoch range",
+			call: func() error {
+				_, err := eventApi.GetMinimalConsensusInfoRange(ctx, 0, absurdEpoch, nil, nil)
+				return err
+			},
+			err: "beyond maximum epoch",
+		},
+		{
+			name: "reversed slot range",
+			call: func() error {
+				_, err := eventApi.GetSlotStatusRange(ctx, 3, 1)
+				return err
+			},
+			err: "from slot 3 is greater than to slot 1",
+		},
+		{
+			name: "empty confirmation",
+			call: func() error {
+				_, err := eventApi.ConfirmPanBlockHashes(ctx, nil)
+				return err
+			},
+			err: "no block hashes given",
+		},
+		{
+			name: "null block hash",
+			call: func() error {
+				_, err := eventApi.ConfirmVanBlockHashes(ctx, []*BlockHash{nil})
+				return err
+			},
+			err: "block hash 0 is null",
+		},
+		{
+			name: "oversized confirmation",
+			call: func() error {
+				_, err := eventApi.ConfirmPanBlockHashes(ctx, tooManyHashes)
+				return err
+			},
+			err: "too many block hashes requested, maximum 1024 block hashes",
+		},
+		{
+			name: "oversized batch",
+			call: func() error {
+				_, err := eventApi.GetBlockStatusBatch(ctx, make([]common.Hash, maxBlockStatusBatchSize+1))
+				return err
+			},
+			err: "too many hashes requested, maximum 1024 hashes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			assert.ErrorContains(t, tt.err, err)
+			invalidParams, ok := err.(*InvalidParamsError)
+			require.Equal(t, true, ok)
+			assert.Equal(t, invalidParamsCode, invalidParams.ErrorCode())
+		})
+	}
+}
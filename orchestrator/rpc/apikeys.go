@@ -0,0 +1,190 @@
+package rpc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/secrets"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// header which carries the api key of a http request
+const apiKeyHeader = "X-API-Key"
+
+// method pattern which allows every method, also the requests of REST, graphql and dashboard
+const allMethods = "*"
+
+// APIKey grants a consumer of http rpc access to the allowed methods within its own rate limit
+type APIKey struct {
+	// Name identifies the consumer in logs and metrics
+	Name string `yaml:"name"`
+	Key  string `yaml:"key"`
+	// Methods are the allowed json-rpc methods, a trailing * allows methods with the prefix, e.g. orc_*
+	Methods []string `yaml:"methods"`
+	// RateLimit is the number of requests per second, zero disables the limit
+	RateLimit float64 `yaml:"rateLimit"`
+	Burst     int     `yaml:"burst"`
+}
+
+// ReadAPIKeys reads the api keys of http rpc consumers from a yaml file, e.g.
+//
+//   - name: explorer
+//     key: 5c2d8a0e6f...
+//     methods: [orc_getBlockStatusBatch, orc_getMinimalConsensusInfoRange]
+//     rateLimit: 10
+//     burst: 20
+func ReadAPIKeys(path string) ([]APIKey, error) {
+	data, err := secrets.Load(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read api keys file")
+	}
+	var keys []APIKey
+	if err := yaml.UnmarshalStrict(data.Bytes(), &keys); err != nil {
+		return nil, errors.Wrap(err, "could not decode api keys file")
+	}
+	names, values := make(map[string]bool), make(map[string]bool)
+	for _, key := range keys {
+		switch {
+		case key.Name == "":
+			return nil, errors.New("api key without name")
+		case names[key.Name]:
+			return nil, errors.Errorf("duplicate api key name %s", key.Name)
+		case len(key.Key) < 16:
+			return nil, errors.Errorf("api key %s must be at least 16 characters long", key.Name)
+		case values[key.Key]:
+			return nil, errors.Errorf("api key %s is given to another consumer too", key.Name)
+		case len(key.Methods) == 0:
+			return nil, errors.Errorf("api key %s allows no method", key.Name)
+		case key.RateLimit < 0 || key.Burst < 0:
+			return nil, errors.Errorf("rate limit of api key %s must not be negative", key.Name)
+		}
+		names[key.Name], values[key.Key] = true, true
+	}
+	return keys, nil
+}
+
+// allows reports whether the key allows all the given methods, an empty list of methods is never allowed
+func (k *APIKey) allows(methods []string) bool {
+	if len(methods) == 0 {
+		return false
+	}
+	for _, method := range methods {
+		allowed := false
+		for _, pattern := range k.Methods {
+			prefix := strings.TrimSuffix(pattern, "*")
+			if pattern == method || prefix != pattern && strings.HasPrefix(method, prefix) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// apiKeyConsumer is an api key with its rate limiter
+type apiKeyConsumer struct {
+	key     APIKey
+	limiter *rateLimiter // nil when the key is not limited
+}
+
+// apiKeyHandler serves requests which carry an api key with the methods and the rate limit of the key.
+// Requests without api key are passed to the anonymous handler, which authenticates them with a jwt token
+// when it is enabled, or they are rejected.
+type apiKeyHandler struct {
+	consumers map[[sha256.Size]byte]*apiKeyConsumer // by hash of the key
+	jsonrpc   bool                                  // requests are json-rpc requests whose methods are checked
+	keyed     http.Handler
+	anonymous http.Handler
+}
+
+// newAPIKeyHandler creates a handler which serves requests with api keys by the keyed handler, others by the
+// anonymous handler. Requests without api key are rejected when anonymous handler is nil. Methods of the keys
+// are checked against json-rpc requests when jsonrpc is true, otherwise only keys which allow * are accepted.
+func newAPIKeyHandler(keys []APIKey, jsonrpc bool, keyed, anonymous http.Handler) http.Handler {
+	consumers := make(map[[sha256.Size]byte]*apiKeyConsumer, len(keys))
+	for _, key := range keys {
+		consumer := &apiKeyConsumer{key: key}
+		if key.RateLimit > 0 {
+			consumer.limiter = newRateLimiter(RateLimitConfig{RequestsPerSecond: key.RateLimit, Burst: key.Burst})
+		}
+		consumers[sha256.Sum256([]byte(key.Key))] = consumer
+	}
+	return &apiKeyHandler{consumers: consumers, jsonrpc: jsonrpc, keyed: keyed, anonymous: anonymous}
+}
+
+func (h *apiKeyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	value := r.Header.Get(apiKeyHeader)
+	if value == "" {
+		if h.anonymous == nil {
+			http.Error(w, "missing api key", http.StatusUnauthorized)
+			return
+		}
+		h.anonymous.ServeHTTP(w, r)
+		return
+	}
+	consumer, ok := h.consumers[sha256.Sum256([]byte(value))]
+	if !ok {
+		log.WithField("remoteAddr", r.RemoteAddr).Debug("Rejected rpc request with unknown api key")
+		http.Error(w, "invalid api key", http.StatusUnauthorized)
+		return
+	}
+
+	methods := []string{allMethods}
+	if h.jsonrpc && r.Method == http.MethodPost && r.Body != nil {
+		body, err := ioutil.ReadAll(io.LimitReader(r.Body, rateLimitMaxBodySize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+		methods = methods[:0]
+		for _, msg := range decodeJSONRPCMessages(body) {
+			methods = append(methods, msg.Method)
+		}
+		if len(methods) == 0 {
+			http.Error(w, "invalid json-rpc request", http.StatusBadRequest)
+			return
+		}
+	}
+	if !consumer.key.allows(methods) {
+		log.WithField("apiKey", consumer.key.Name).WithField("methods", methods).
+			Debug("Rejected rpc request of methods which api key does not allow")
+		http.Error(w, "method not allowed by api key", http.StatusForbidden)
+		return
+	}
+	if consumer.limiter != nil && !consumer.limiter.allow(consumer.key.Name, map[methodClass]int{defaultMethods: len(methods)}) {
+		log.WithField("apiKey", consumer.key.Name).Debug("Rate limit of api key exceeded")
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+	rpcMetrics.Counter("apikeys/" + consumer.key.Name).Inc(1)
+	h.keyed.ServeHTTP(w, r)
+}
+
+// withAuthentication wraps the handler of http requests with jwt authentication, per client rate limit and api
+// keys of the config. Requests with an api key are limited by the key instead of the client address.
+func withAuthentication(handler http.Handler, config httpConfig, jsonrpc bool) http.Handler {
+	keyed := handler
+	if len(config.jwtSecret) > 0 {
+		handler = newJWTHandler(config.jwtSecret, handler)
+	}
+	if config.rateLimiter != nil {
+		handler = newRateLimitHandler(config.rateLimiter, handler)
+	}
+	if len(config.apiKeys) > 0 {
+		var anonymous http.Handler
+		if len(config.jwtSecret) > 0 {
+			anonymous = handler
+		}
+		handler = newAPIKeyHandler(config.apiKeys, jsonrpc, keyed, anonymous)
+	}
+	return handler
+}
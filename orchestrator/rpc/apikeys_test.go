@@ -0,0 +1,112 @@
+package rpc
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestReadAPIKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apikeys.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+- name: explorer
+  key: 0123456789abcdef0123
+  methods: [orc_getBlockStatusBatch, orc_*]
+  rateLimit: 10
+  burst: 20
+- name: indexer
+  key: fedcba98765432100123
+  methods: ["*"]
+`), 0600))
+	keys, err := ReadAPIKeys(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(keys))
+	assert.Equal(t, "explorer", keys[0].Name)
+	assert.Equal(t, float64(10), keys[0].RateLimit)
+	assert.Equal(t, 20, keys[0].Burst)
+	assert.DeepEqual(t, []string{"*"}, keys[1].Methods)
+
+	invalid := map[string]string{
+		"- name: a\n  key: short\n  methods: [orc_*]\n":                                                       "at least 16 characters",
+		"- name: a\n  key: 0123456789abcdef\n":                                                                "allows no method",
+		"- key: 0123456789abcdef\n  methods: [orc_*]\n":                                                       "without name",
+		"- name: a\n  key: 0123456789abcdef\n  methods: [orc_*]\n  rate: 1\n":                                 "could not decode",
+		"- name: a\n  key: 0123456789abcdef\n  methods: [orc_*]\n  rateLimit: -1\n":                           "must not be negative",
+		"- {name: a, key: 0123456789abcdef, methods: [x]}\n- {name: a, key: 0123456789abcdefg, methods: [x]}": "duplicate api key name",
+		"- {name: a, key: 0123456789abcdef, methods: [x]}\n- {name: b, key: 0123456789abcdef, methods: [x]}":  "given to another consumer",
+	}
+	for content, want := range invalid {
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+		_, err := ReadAPIKeys(path)
+		assert.ErrorContains(t, want, err, content)
+	}
+}
+
+func TestAPIKey_Allows(t *testing.T) {
+	key := &APIKey{Methods: []string{"orc_getBlockStatusBatch", "admin_status*"}}
+	assert.Equal(t, true, key.allows([]string{"orc_getBlockStatusBatch"}))
+	assert.Equal(t, true, key.allows([]string{"admin_statusReport", "orc_getBlockStatusBatch"}))
+	assert.Equal(t, false, key.allows([]string{"orc_getBlockStatusBatch", "orc_finalizedHead"}))
+	assert.Equal(t, false, key.allows([]string{allMethods}))
+	assert.Equal(t, true, (&APIKey{Methods: []string{allMethods}}).allows([]string{allMethods, "admin_pruneDB"}))
+	assert.Equal(t, false, (&APIKey{Methods: []string{allMethods}}).allows(nil))
+}
+
+// TestAPIKeyHandler checks that requests with a key are served within the methods and the rate limit of the key,
+// and requests without key fall back to jwt authentication
+func TestAPIKeyHandler(t *testing.T) {
+	secret := make([]byte, jwtSecretLength)
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	keys := []APIKey{
+		{Name: "explorer", Key: "0123456789abcdef", Methods: []string{"orc_*"}, RateLimit: 1, Burst: 2},
+		{Name: "indexer", Key: "fedcba9876543210", Methods: []string{allMethods}},
+	}
+	serve := func(handler http.Handler, key, body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(apiKeyHeader, key)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+	const call = `{"jsonrpc":"2.0","id":1,"method":"orc_finalizedHead","params":[]}`
+
+	handler := withAuthentication(ok, httpConfig{apiKeys: keys, jwtSecret: secret}, true)
+	assert.Equal(t, http.StatusOK, serve(handler, "0123456789abcdef", call))
+	assert.Equal(t, http.StatusForbidden, serve(handler, "0123456789abcdef",
+		`[`+call+`,{"jsonrpc":"2.0","id":2,"method":"admin_pruneDB","params":[]}]`))
+	// rpc server serves the first json value of a body, trailing data does not hide its methods
+	assert.Equal(t, http.StatusForbidden, serve(handler, "0123456789abcdef",
+		`{"jsonrpc":"2.0","id":2,"method":"admin_pruneDB","params":[]}trailing`))
+	assert.Equal(t, http.StatusBadRequest, serve(handler, "0123456789abcdef", `not json`))
+	assert.Equal(t, http.StatusOK, serve(handler, "0123456789abcdef", call))
+	assert.Equal(t, http.StatusTooManyRequests, serve(handler, "0123456789abcdef", call))
+	assert.Equal(t, http.StatusOK, serve(handler, "fedcba9876543210", call))
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "unknown-key-0123", call))
+	// requests without key must carry a jwt token
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "", call))
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(call))
+	req.Header.Set("Authorization", "Bearer "+NewJWT(secret, time.Now()))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	// requests without key are rejected when jwt authentication is disabled
+	handler = withAuthentication(ok, httpConfig{apiKeys: keys}, true)
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "", call))
+
+	// methods of REST and graphql requests are not known, so only keys which allow all methods are accepted
+	handler = withAuthentication(ok, httpConfig{apiKeys: keys}, false)
+	assert.Equal(t, http.StatusOK, serve(handler, "fedcba9876543210", `{}`))
+	assert.Equal(t, http.StatusForbidden, serve(handler, "0123456789abcdef", `{}`))
+}
@@ -0,0 +1,126 @@
+// Package dashboard serves a self contained HTML status page of the orchestrator for operators who do not run
+// a monitoring stack. The page shows chain heads, verification progress and lag, connections to vanguard and
+// pandora, services and recent reorgs. It refreshes itself and needs no javascript or external assets.
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/slotutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// Path under which the dashboard is served
+const Path = "/dashboard"
+
+// number of latest reorgs which are shown
+const maxReorgs = 10
+
+// interval in which the page reloads itself
+const refreshInterval = 5 * time.Second
+
+// timeout of collecting the status of the node
+const statusTimeout = 3 * time.Second
+
+// Backend provides the state of orchestrator which is shown on the dashboard
+type Backend interface {
+	NodeStatus(ctx context.Context) (*types.NodeStatus, error)
+	VerificationProgress() *types.VerificationProgress
+	LatestFinalizedHead() *types.FinalizedHead
+	LatestConfirmedSlot() uint64
+	ReorgAuditRecords() ([]*types.ReorgAuditRecord, error)
+}
+
+// status is the content of the page
+type status struct {
+	Generated      time.Time
+	RefreshSeconds int
+	CurrentSlot    uint64
+	CurrentSlotOK  bool
+	Node           *types.NodeStatus
+	Progress       *types.VerificationProgress
+	FinalizedHead  *types.FinalizedHead
+	ConfirmedSlot  uint64
+	Reorgs         []*types.ReorgAuditRecord
+	TotalReorgs    int
+	Errors         []string
+	Healthy        bool
+	Disconnected   int
+}
+
+type handler struct {
+	backend Backend
+}
+
+// New returns the http handler of the dashboard which shows the state provided by the backend
+func New(backend Backend) http.Handler {
+	return &handler{backend: backend}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), statusTimeout)
+	defer cancel()
+
+	var page bytes.Buffer
+	if err := pageTemplate.Execute(&page, h.collect(ctx)); err != nil {
+		log.WithError(err).Error("Could not render dashboard")
+		http.Error(w, "could not render dashboard", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write(page.Bytes())
+}
+
+// collect gathers the state which is shown on the page, parts which can not be collected are reported on it
+func (h *handler) collect(ctx context.Context) *status {
+	s := &status{
+		Generated:      time.Now(),
+		RefreshSeconds: int(refreshInterval / time.Second),
+		Progress:       h.backend.VerificationProgress(),
+		FinalizedHead:  h.backend.LatestFinalizedHead(),
+		ConfirmedSlot:  h.backend.LatestConfirmedSlot(),
+	}
+	s.CurrentSlot, s.CurrentSlotOK = slotutil.CurrentSlot()
+
+	node, err := h.backend.NodeStatus(ctx)
+	if err != nil {
+		s.Errors = append(s.Errors, "node status: "+err.Error())
+	}
+	s.Node = node
+
+	reorgs, err := h.backend.ReorgAuditRecords()
+	if err != nil {
+		s.Errors = append(s.Errors, "reorg history: "+err.Error())
+	}
+	s.TotalReorgs = len(reorgs)
+	// records are stored in insertion order, the latest are shown first
+	for i := len(reorgs) - 1; i >= 0 && len(s.Reorgs) < maxReorgs; i-- {
+		s.Reorgs = append(s.Reorgs, reorgs[i])
+	}
+
+	s.Healthy = len(s.Errors) == 0
+	if node != nil {
+		for _, endpoint := range node.Endpoints {
+			if !endpoint.Connected {
+				s.Disconnected++
+				s.Healthy = false
+			}
+		}
+		for _, service := range node.Services {
+			if service.Error != "" {
+				s.Healthy = false
+			}
+		}
+	}
+	return s
+}
@@ -0,0 +1,116 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+type mockBackend struct {
+	node     *types.NodeStatus
+	reorgs   []*types.ReorgAuditRecord
+	reorgErr error
+}
+
+func (b *mockBackend) NodeStatus(ctx context.Context) (*types.NodeStatus, error) {
+	if b.node == nil {
+		return nil, errors.New("node status is not supported")
+	}
+	return b.node, nil
+}
+
+func (b *mockBackend) VerificationProgress() *types.VerificationProgress {
+	return &types.VerificationProgress{
+		LatestVerifiedSlot:     40,
+		VerifiedSlots:          120,
+		VerifiedSlotsPerMinute: 12.5,
+		PendingSlots:           2,
+		VerificationLag:        3,
+	}
+}
+
+func (b *mockBackend) LatestFinalizedHead() *types.FinalizedHead {
+	return &types.FinalizedHead{Slot: 31, Epoch: 3, VanguardBlockHash: common.HexToHash("0xabcdef")}
+}
+
+func (b *mockBackend) LatestConfirmedSlot() uint64 {
+	return 38
+}
+
+func (b *mockBackend) ReorgAuditRecords() ([]*types.ReorgAuditRecord, error) {
+	return b.reorgs, b.reorgErr
+}
+
+func serve(t *testing.T, backend Backend, method string) (*httptest.ResponseRecorder, string) {
+	recorder := httptest.NewRecorder()
+	New(backend).ServeHTTP(recorder, httptest.NewRequest(method, Path, nil))
+	return recorder, recorder.Body.String()
+}
+
+func TestDashboard(t *testing.T) {
+	backend := &mockBackend{
+		node: &types.NodeStatus{
+			Version:    "v0.2.0",
+			Checkpoint: &types.VerificationCheckpoint{Slot: 40},
+			Endpoints: []*types.EndpointStatus{
+				{Name: "vanguard", Endpoint: "127.0.0.1:4000", Connected: true, Version: "vanguard/v1"},
+				{Name: "pandora", Endpoint: "ws://127.0.0.1:8546", Error: "connection refused"},
+			},
+			Services: []*types.ServiceStatus{{Name: "*consensus.Service", Started: true}},
+		},
+	}
+	for i := uint64(1); i <= maxReorgs+2; i++ {
+		backend.reorgs = append(backend.reorgs, &types.ReorgAuditRecord{NewSlot: 100 + i, InvalidatedSlots: []uint64{i}})
+	}
+
+	recorder, body := serve(t, backend, http.MethodGet)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+	for _, want := range []string{
+		"v0.2.0",
+		"degraded",
+		"<th>Latest confirmed slot</th><td>38</td>",
+		"<th>Finalized slot / epoch</th><td>31 / 3</td>",
+		"3 slots",
+		"120 (12.5 per minute)",
+		"ws://127.0.0.1:8546",
+		"connection refused",
+		"running",
+		"10 latest of 12 reorgs",
+	} {
+		assert.Equal(t, true, strings.Contains(body, want), "page does not contain %q", want)
+	}
+	assert.Equal(t, true, strings.Index(body, "<td>112</td>") < strings.Index(body, "<td>103</td>"), "latest reorgs are shown first")
+	assert.Equal(t, false, strings.Contains(body, "<td>102</td>"), "only latest reorgs are shown")
+}
+
+func TestDashboard_Healthy(t *testing.T) {
+	backend := &mockBackend{node: &types.NodeStatus{
+		Endpoints: []*types.EndpointStatus{{Name: "vanguard", Connected: true}},
+	}}
+	_, body := serve(t, backend, http.MethodGet)
+	assert.Equal(t, true, strings.Contains(body, "healthy"))
+	assert.Equal(t, true, strings.Contains(body, "No reorgs have been recorded."))
+}
+
+func TestDashboard_ReportsErrors(t *testing.T) {
+	backend := &mockBackend{reorgErr: errors.New("bucket not found")}
+	recorder, body := serve(t, backend, http.MethodGet)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, true, strings.Contains(body, "node status: node status is not supported"))
+	assert.Equal(t, true, strings.Contains(body, "reorg history: bucket not found"))
+	assert.Equal(t, true, strings.Contains(body, "degraded"))
+}
+
+func TestDashboard_MethodNotAllowed(t *testing.T) {
+	recorder, _ := serve(t, &mockBackend{}, http.MethodPost)
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	assert.Equal(t, "GET, HEAD", recorder.Header().Get("Allow"))
+}
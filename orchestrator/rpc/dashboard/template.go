@@ -0,0 +1,112 @@
+package dashboard
+
+import (
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// format of times shown on the page
+const timeFormat = "2006-01-02 15:04:05 UTC"
+
+var pageTemplate = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"unixTime":  unixTime,
+	"shortHash": shortHash,
+	"since":     since,
+}).Parse(pageHTML))
+
+// unixTime formats unix seconds as UTC time, zero is not known
+func unixTime(seconds int64) string {
+	if seconds == 0 {
+		return "-"
+	}
+	return time.Unix(seconds, 0).UTC().Format(timeFormat)
+}
+
+// shortHash abbreviates a hash to its first and last bytes
+func shortHash(hash common.Hash) string {
+	if hash == (common.Hash{}) {
+		return "-"
+	}
+	hex := hash.Hex()
+	return hex[:10] + "…" + hex[len(hex)-6:]
+}
+
+// since formats the time elapsed since unix seconds
+func since(seconds uint64) string {
+	if seconds == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s ago", time.Since(time.Unix(int64(seconds), 0)).Round(time.Second))
+}
+
+const pageHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="{{.RefreshSeconds}}">
+<title>LUKSO Orchestrator{{if .Node}} {{.Node.Version}}{{end}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+h1 { font-size: 1.4em; }
+h2 { font-size: 1.1em; margin-top: 1.6em; }
+table { border-collapse: collapse; }
+th, td { text-align: left; padding: 0.25em 1em 0.25em 0; border-bottom: 1px solid #ddd; }
+code { font-size: 0.95em; }
+.ok { color: #18794e; }
+.bad { color: #c62828; }
+.muted { color: #777; }
+</style>
+</head>
+<body>
+<h1>LUKSO Orchestrator {{if .Healthy}}<span class="ok">healthy</span>{{else}}<span class="bad">degraded</span>{{end}}</h1>
+<p class="muted">{{if .Node}}Version {{.Node.Version}} · {{end}}Updated {{unixTime .Generated.Unix}}, refreshes every {{.RefreshSeconds}}s</p>
+{{range .Errors}}<p class="bad">{{.}}</p>
+{{end}}
+<h2>Chain heads</h2>
+<table>
+<tr><th>Current slot</th><td>{{if .CurrentSlotOK}}{{.CurrentSlot}}{{else}}<span class="muted">genesis time not known</span>{{end}}</td></tr>
+{{with .Node}}{{with .Checkpoint}}<tr><th>Verified head slot</th><td>{{.Slot}}</td></tr>
+<tr><th>Verified vanguard block</th><td><code>{{shortHash .VanguardBlockHash}}</code></td></tr>
+<tr><th>Verified pandora header</th><td><code>{{shortHash .PandoraHeaderHash}}</code></td></tr>
+{{end}}{{end}}<tr><th>Latest confirmed slot</th><td>{{.ConfirmedSlot}}</td></tr>
+{{with .FinalizedHead}}<tr><th>Finalized slot / epoch</th><td>{{.Slot}} / {{.Epoch}}</td></tr>
+<tr><th>Finalized vanguard block</th><td><code>{{shortHash .VanguardBlockHash}}</code></td></tr>
+<tr><th>Finalized pandora header</th><td><code>{{shortHash .PandoraHeaderHash}}</code></td></tr>
+{{end}}</table>
+
+{{with .Progress}}<h2>Verification progress</h2>
+<table>
+<tr><th>Verification lag</th><td>{{if gt .VerificationLag 0}}<span class="bad">{{.VerificationLag}} slots</span>{{else}}<span class="ok">0 slots</span>{{end}}</td></tr>
+<tr><th>Latest verified slot</th><td>{{.LatestVerifiedSlot}}</td></tr>
+<tr><th>Verified slots</th><td>{{.VerifiedSlots}} ({{printf "%.1f" .VerifiedSlotsPerMinute}} per minute)</td></tr>
+<tr><th>Pending slots</th><td>{{.PendingSlots}}</td></tr>
+<tr><th>Unmatched slots</th><td>{{.UnmatchedSlots}}</td></tr>
+<tr><th>Skipped slots</th><td>{{.SkippedSlots}}</td></tr>
+</table>
+{{end}}
+{{with .Node}}<h2>Connections</h2>
+<table>
+<tr><th>Name</th><th>Endpoint</th><th>State</th><th>Version</th></tr>
+{{range .Endpoints}}<tr><td>{{.Name}}</td><td><code>{{.Endpoint}}</code></td><td>{{if .Connected}}<span class="ok">connected</span>{{else}}<span class="bad">disconnected</span>{{if .Error}} <span class="muted">{{.Error}}</span>{{end}}{{end}}</td><td>{{.Version}}</td></tr>
+{{end}}</table>
+
+<h2>Services</h2>
+<table>
+<tr><th>Name</th><th>State</th><th>Since</th><th>Restarts</th></tr>
+{{range .Services}}<tr><td>{{.Name}}</td><td>{{if .Error}}<span class="bad">{{if .State}}{{.State}}{{else}}failed{{end}}</span> <span class="muted">{{.Error}}</span>{{else if .State}}{{.State}}{{else if .Started}}running{{else}}not started{{end}}</td><td>{{since .Since}}</td><td>{{.Restarts}}</td></tr>
+{{end}}</table>
+{{end}}
+<h2>Reorg history</h2>
+{{if .Reorgs}}<p class="muted">{{len .Reorgs}} latest of {{.TotalReorgs}} reorgs</p>
+<table>
+<tr><th>Time</th><th>New slot</th><th>Reverted to slot</th><th>Invalidated slots</th></tr>
+{{range .Reorgs}}<tr><td>{{unixTime .Timestamp}}</td><td>{{.NewSlot}}</td><td>{{.RevertSlot}}</td><td>{{len .InvalidatedSlots}}</td></tr>
+{{end}}</table>
+{{else}}<p class="muted">No reorgs have been recorded.</p>
+{{end}}
+</body>
+</html>
+`
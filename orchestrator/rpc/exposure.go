@@ -0,0 +1,36 @@
+package rpc
+
+import (
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// isLoopback reports whether the listening host is only reachable from this machine. Host names other than
+// localhost are not resolved, they are treated as reachable from other machines.
+func isLoopback(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
+
+// checkExposure refuses to serve the transport on a host which other machines can reach when its requests are not
+// authenticated, unless unprotected serving is allowed explicitly. Allowed unprotected servers are logged with a
+// warning, since anyone who can reach them can read consensus info and verification results.
+func (s *Service) checkExposure(transport, host string, protected bool) error {
+	if protected || isLoopback(host) {
+		return nil
+	}
+	if !s.config.AllowUnprotected {
+		return errors.Errorf("refusing to serve %s rpc on %s without authentication, set --rpc-jwt-secret or "+
+			"--rpc-api-keys-file, bind to a loopback address or allow it with --rpc-allow-unprotected",
+			transport, host)
+	}
+	log.WithField("transport", transport).WithField("host", host).Warn("######## UNPROTECTED RPC ########: " +
+		"serving rpc without authentication on an address which other machines can reach. Anyone who reaches " +
+		"it can use the api, make sure it is not exposed to the internet")
+	return nil
+}
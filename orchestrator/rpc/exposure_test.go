@@ -0,0 +1,41 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestIsLoopback(t *testing.T) {
+	for host, want := range map[string]bool{
+		"localhost":     true,
+		"LOCALHOST":     true,
+		"127.0.0.1":     true,
+		"127.0.1.1":     true,
+		"::1":           true,
+		"[::1]":         true,
+		"0.0.0.0":       false,
+		"::":            false,
+		"192.168.1.10":  false,
+		"orchestrator":  false,
+		"":              false,
+		"localhost.com": false,
+	} {
+		assert.Equal(t, want, isLoopback(host), host)
+	}
+}
+
+func TestCheckExposure(t *testing.T) {
+	hook := logTest.NewGlobal()
+	s := &Service{config: &Config{}}
+	require.NoError(t, s.checkExposure(transportHTTP, "127.0.0.1", false))
+	require.NoError(t, s.checkExposure(transportHTTP, "0.0.0.0", true))
+	assert.ErrorContains(t, "refusing to serve ws rpc on 0.0.0.0 without authentication", s.checkExposure(transportWS, "0.0.0.0", false))
+	require.LogsDoNotContain(t, hook, "UNPROTECTED RPC")
+
+	s.config.AllowUnprotected = true
+	require.NoError(t, s.checkExposure(transportWS, "0.0.0.0", false))
+	require.LogsContain(t, hook, "UNPROTECTED RPC")
+}
@@ -0,0 +1,246 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+type mockBackend struct {
+	consensusInfos []*types.MinimalEpochConsensusInfoV2
+	blockStatuses  map[uint64]*types.PandoraBlockStatus
+	evidences      map[uint64]*types.MismatchEvidence
+	reorgs         []*types.ReorgAuditRecord
+}
+
+func (b *mockBackend) ConsensusInfoRange(fromEpoch, toEpoch uint64, limit int) ([]*types.MinimalEpochConsensusInfoV2, error) {
+	var consensusInfos []*types.MinimalEpochConsensusInfoV2
+	for _, consensusInfo := range b.consensusInfos {
+		if consensusInfo.Epoch >= fromEpoch && consensusInfo.Epoch <= toEpoch && len(consensusInfos) < limit {
+			consensusInfos = append(consensusInfos, consensusInfo)
+		}
+	}
+	return consensusInfos, nil
+}
+
+func (b *mockBackend) LatestEpoch() uint64 {
+	return b.consensusInfos[len(b.consensusInfos)-1].Epoch
+}
+
+func (b *mockBackend) LatestVerifiedSlot() uint64 {
+	return 40
+}
+
+func (b *mockBackend) LatestFinalizedSlot() uint64 {
+	return 31
+}
+
+func (b *mockBackend) BlockStatusBySlot(slot uint64) (*types.PandoraBlockStatus, error) {
+	if blockStatus, ok := b.blockStatuses[slot]; ok {
+		return blockStatus, nil
+	}
+	return &types.PandoraBlockStatus{Slot: slot, Status: types.Unknown}, nil
+}
+
+func (b *mockBackend) BlockStatusByHash(hash common.Hash) (*types.PandoraBlockStatus, error) {
+	for _, blockStatus := range b.blockStatuses {
+		if blockStatus.PandoraHeaderHash == hash {
+			return blockStatus, nil
+		}
+	}
+	return &types.PandoraBlockStatus{PandoraHeaderHash: hash, Status: types.Unknown}, nil
+}
+
+func (b *mockBackend) MismatchEvidence(slot uint64) (*types.MismatchEvidence, error) {
+	return b.evidences[slot], nil
+}
+
+func (b *mockBackend) ReorgAuditRecords() ([]*types.ReorgAuditRecord, error) {
+	return b.reorgs, nil
+}
+
+func newMockBackend() *mockBackend {
+	b := &mockBackend{
+		blockStatuses: make(map[uint64]*types.PandoraBlockStatus),
+		evidences:     make(map[uint64]*types.MismatchEvidence),
+	}
+	for epoch := uint64(0); epoch < 2; epoch++ {
+		b.consensusInfos = append(b.consensusInfos, &types.MinimalEpochConsensusInfoV2{
+			Epoch:            epoch,
+			ValidatorList:    []string{"0x01", "0x02"},
+			EpochStartTime:   1000 + epoch*params.SlotsPerEpoch*6,
+			SlotTimeDuration: 6,
+			FinalizedSlot:    31,
+		})
+	}
+	for slot := uint64(0); slot < 2*params.SlotsPerEpoch; slot++ {
+		b.blockStatuses[slot] = &types.PandoraBlockStatus{
+			Slot:              slot,
+			Status:            types.Verified,
+			PandoraHeaderHash: common.BytesToHash([]byte{byte(slot), 1}),
+			VanguardBlockHash: common.BytesToHash([]byte{byte(slot), 2}),
+		}
+	}
+	b.blockStatuses[33].Status = types.Invalid
+	b.evidences[33] = &types.MismatchEvidence{Slot: 33, Reason: "extra data mismatch"}
+	b.reorgs = []*types.ReorgAuditRecord{
+		{Timestamp: 100, NewSlot: 20, RevertSlot: 18, InvalidatedSlots: []uint64{19}},
+		{Timestamp: 200, NewSlot: 35, RevertSlot: 32, InvalidatedSlots: []uint64{33, 34}},
+	}
+	return b
+}
+
+func executeWith(t *testing.T, resolver *queryResolver, query string, variables map[string]interface{}) *graphql.Response {
+	s, err := graphql.ParseSchema(schemaSDL, resolver, graphql.MaxDepth(maxQueryDepth))
+	require.NoError(t, err)
+	return s.Exec(withBudget(context.Background()), query, "", variables)
+}
+
+func execute(t *testing.T, query string, variables map[string]interface{}) *graphql.Response {
+	return executeWith(t, &queryResolver{backend: newMockBackend()}, query, variables)
+}
+
+func TestExecute_NestedQuery(t *testing.T) {
+	result := execute(t, `{
+		latestEpoch
+		e: epoch(number: 1) {
+			number startTime slotDuration validators
+			slots(status: Invalid) { ...slotFields }
+		}
+		missing: epoch(number: 5) { number }
+	}
+	fragment slotFields on Slot { number status mismatchReason epoch { number } }`, nil)
+	require.Equal(t, 0, len(result.Errors))
+	assert.Equal(t,
+		`{"latestEpoch":1,"e":{"number":1,"startTime":1192,"slotDuration":6,"validators":["0x01","0x02"],`+
+			`"slots":[{"number":33,"status":"Invalid","mismatchReason":"extra data mismatch","epoch":{"number":1}}]},`+
+			`"missing":null}`,
+		string(result.Data))
+}
+
+func TestExecute_Variables(t *testing.T) {
+	hash := common.BytesToHash([]byte{5, 1})
+	result := execute(t, `query Slots($from: Long!, $to: Long!, $status: [Status!], $hash: Hash!) {
+		slots(from: $from, to: $to, status: $status) { number }
+		block(hash: $hash) { number status vanguardBlockHash }
+		__typename
+	}`, map[string]interface{}{
+		"from":   json.Number("2"),
+		"to":     "5",
+		"status": []interface{}{"Verified"},
+		"hash":   hash.Hex(),
+	})
+	require.Equal(t, 0, len(result.Errors))
+	assert.Equal(t,
+		`{"slots":[{"number":2},{"number":3},{"number":4},{"number":5}],`+
+			`"block":{"number":5,"status":"Verified","vanguardBlockHash":"`+common.BytesToHash([]byte{5, 2}).Hex()+`"},`+
+			`"__typename":"Query"}`,
+		string(result.Data))
+}
+
+func TestExecute_Reorgs(t *testing.T) {
+	result := execute(t, `{ reorgs(fromSlot: 30) { newSlot revertSlot invalidatedSlots { number status } } }`, nil)
+	require.Equal(t, 0, len(result.Errors))
+	assert.Equal(t,
+		`{"reorgs":[{"newSlot":35,"revertSlot":32,"invalidatedSlots":[{"number":33,"status":"Invalid"},{"number":34,"status":"Verified"}]}]}`,
+		string(result.Data))
+}
+
+func TestExecute_Errors(t *testing.T) {
+	tests := map[string]string{
+		`{ epoch(number: 1) { number`:                        "syntax error",
+		`{ epoch(number: 1) { ...epochFields } }`:            `Unknown fragment "epochFields"`,
+		`mutation { latestEpoch }`:                           "no mutations are offered by the schema",
+		`{ unknown }`:                                        `Cannot query field "unknown" on type "Query"`,
+		`{ epoch(number: 1) }`:                               "must have a selection of subfields",
+		`{ latestEpoch { number } }`:                         "must not have a selection",
+		`{ epoch { number } }`:                               `argument "number" of type "Long!" is required`,
+		`{ epoch(number: -1) { number } }`:                   "invalid value -1 of type Long",
+		`{ epoch(number: 1, foo: 2) { number } }`:            `Unknown argument "foo"`,
+		`{ slots(from: 0, to: 1, status: Bad) { number } }`:  `Expected type "Status", found Bad`,
+		`{ block(hash: "0x01") { number } }`:                 "invalid value 0x01 of type Hash",
+		`{ slot(number: $slot) { number } }`:                 `Variable "$slot" is not defined`,
+		`query ($s: String) { slot(number: $s) { number } }`: `used in position expecting type "Long!"`,
+		`{ e: epoch(number: 1) { slots { epoch { slots { epoch { slots { epoch { slots { number } } } } } } } } }`: "exceeds max depth 8",
+	}
+	for query, expected := range tests {
+		result := execute(t, query, nil)
+		require.Equal(t, true, len(result.Errors) > 0, query)
+		assert.Equal(t, true, strings.Contains(result.Errors[0].Message, expected), result.Errors[0].Message)
+	}
+}
+
+func TestExecute_FieldErrors(t *testing.T) {
+	result := execute(t, `{ latestEpoch e: epoch(number: 1) { number } slots(from: 0, to: 5000) { number } }`, nil)
+	require.Equal(t, 1, len(result.Errors))
+	assert.Equal(t, "slot range is too large, maximum 1024 slots", result.Errors[0].Message)
+	assert.DeepEqual(t, []interface{}{"slots"}, result.Errors[0].Path)
+	// slots are non null, so the error nulls the whole result
+	assert.Equal(t, `null`, string(result.Data))
+
+	// maximum size of range queries is configurable
+	resolver := &queryResolver{backend: newMockBackend(), maxResults: 8}
+	result = executeWith(t, resolver, `{ epochs(from: 0, limit: 10) { number } }`, nil)
+	require.Equal(t, 1, len(result.Errors))
+	assert.Equal(t, "limit must be between 1 and 8", result.Errors[0].Message)
+
+	// nested lists are bounded by the number of resolved objects
+	slots := `slots(from: 0, to: 1023) { epoch { slots { number } } }`
+	result = execute(t, `{ a: `+slots+` b: `+slots+` c: `+slots+` }`, nil)
+	require.Equal(t, true, len(result.Errors) > 0)
+	assert.Equal(t, "query is too expensive, maximum 10000 resolved objects", result.Errors[0].Message)
+}
+
+func TestHandler(t *testing.T) {
+	handler, err := New(newMockBackend(), 0)
+	require.NoError(t, err)
+
+	// GET request with url parameters
+	rec := httptest.NewRecorder()
+	query := url.Values{"query": {`query ($n: Long!) { slot(number: $n) { status } }`}, "variables": {`{"n": 33}`}}
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path+"?"+query.Encode(), nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"data":{"slot":{"status":"Invalid"}}}`+"\n", rec.Body.String())
+
+	// POST request with json body
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, Path, strings.NewReader(`{"query": "{ finalizedSlot }"}`))
+	req.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"data":{"finalizedSlot":31}}`+"\n", rec.Body.String())
+
+	// POST request with graphql body
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, Path, strings.NewReader(`{ latestVerifiedSlot }`))
+	req.Header.Set("Content-Type", "application/graphql")
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, `{"data":{"latestVerifiedSlot":40}}`+"\n", rec.Body.String())
+
+	// invalid query
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path+"?query=%7B+foo+%7D", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, `{"errors":[{"message":"Cannot query field \"foo\" on type \"Query\".","locations":[{"line":1,"column":3}]}]}`+"\n", rec.Body.String())
+
+	// schema
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path+"/schema", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, schemaSDL, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, Path, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,116 @@
+// Package graphql serves orchestrator epochs, slot statuses and reorg history over a read only graphql endpoint.
+// The schema is served as SDL next to the endpoint, mutations and subscriptions are not defined.
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+)
+
+// Path under which the graphql endpoint is served. The schema is served at Path + "/schema".
+const Path = "/graphql"
+
+const (
+	// maximum size of a graphql request body
+	maxRequestSize = 64 * 1024
+	// maximum nesting of selection sets
+	maxQueryDepth = 8
+)
+
+// request is the body of a graphql POST request
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type handler struct {
+	schema *graphql.Schema
+}
+
+// New returns the http handler of the graphql endpoint which resolves queries from the given backend.
+// Queries are accepted as GET requests with `query`, `operationName` and json encoded `variables`
+// url parameters, or as POST requests with a json body or an application/graphql body. Range queries return
+// at most maxResults epochs or slots, zero keeps the default maximum.
+func New(backend Backend, maxResults int) (http.Handler, error) {
+	s, err := graphql.ParseSchema(schemaSDL, &queryResolver{backend: backend, maxResults: maxResults},
+		graphql.MaxDepth(maxQueryDepth))
+	if err != nil {
+		return nil, err
+	}
+	return &handler{schema: s}, nil
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == Path+"/schema" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte(schemaSDL))
+		return
+	}
+
+	var req request
+	switch r.Method {
+	case http.MethodGet:
+		params := r.URL.Query()
+		req.Query = params.Get("query")
+		req.OperationName = params.Get("operationName")
+		if variables := params.Get("variables"); variables != "" {
+			if err := decodeJSON([]byte(variables), &req.Variables); err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("invalid variables: %v", err))
+				return
+			}
+		}
+	case http.MethodPost:
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+		if err != nil {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("request is too large, maximum %d bytes", maxRequestSize))
+			return
+		}
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediaType == "application/graphql" {
+			req.Query = string(body)
+		} else if err := decodeJSON(body, &req); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %v", err))
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+		return
+	}
+	if req.Query == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("query must be provided"))
+		return
+	}
+
+	result := h.schema.Exec(withBudget(r.Context()), req.Query, req.OperationName, req.Variables)
+	status := http.StatusOK
+	if len(result.Data) == 0 {
+		status = http.StatusBadRequest
+	}
+	writeResponse(w, status, result)
+}
+
+// decodeJSON decodes numbers as json.Number, so that Long values keep their precision
+func decodeJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeResponse(w, status, &graphql.Response{Errors: []*errors.QueryError{{Message: err.Error()}}})
+}
+
+func writeResponse(w http.ResponseWriter, status int, result *graphql.Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,351 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/slotutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+const (
+	// default maximum number of epochs returned by Query.epochs
+	maxEpochs = 1024
+	// default maximum number of slots of Query.slots range
+	maxSlotRange = 1024
+	// maximum number of epochs, slots and reorgs resolved by a single query, which bounds the cost of nested lists
+	maxResolvedObjects = 10000
+)
+
+// Backend provides orchestrator data to the graphql resolvers
+type Backend interface {
+	ConsensusInfoRange(fromEpoch, toEpoch uint64, limit int) ([]*types.MinimalEpochConsensusInfoV2, error)
+	LatestEpoch() uint64
+	LatestVerifiedSlot() uint64
+	LatestFinalizedSlot() uint64
+	BlockStatusBySlot(slot uint64) (*types.PandoraBlockStatus, error)
+	BlockStatusByHash(hash common.Hash) (*types.PandoraBlockStatus, error)
+	MismatchEvidence(slot uint64) (*types.MismatchEvidence, error)
+	ReorgAuditRecords() ([]*types.ReorgAuditRecord, error)
+}
+
+type budgetKey struct{}
+
+// withBudget returns a context which limits the number of objects resolved by a query to maxResolvedObjects
+func withBudget(ctx context.Context) context.Context {
+	remaining := int64(maxResolvedObjects)
+	return context.WithValue(ctx, budgetKey{}, &remaining)
+}
+
+// charge takes n objects of the query budget and fails once the budget is exhausted. Queries executed without
+// budget are not limited.
+func charge(ctx context.Context, n int) error {
+	remaining, ok := ctx.Value(budgetKey{}).(*int64)
+	if !ok {
+		return nil
+	}
+	if atomic.AddInt64(remaining, -int64(n)) < 0 {
+		return fmt.Errorf("query is too expensive, maximum %d resolved objects", maxResolvedObjects)
+	}
+	return nil
+}
+
+// queryResolver resolves the root Query type
+type queryResolver struct {
+	backend Backend
+	// maximum number of epochs and slots of a single range query, zero keeps the defaults
+	maxResults int
+}
+
+// limit returns the maximum number of items of a range query whose default maximum is given
+func (r *queryResolver) limit(defaultMax int) int {
+	if r.maxResults > 0 {
+		return r.maxResults
+	}
+	return defaultMax
+}
+
+func (r *queryResolver) Epoch(ctx context.Context, args struct{ Number Long }) (*epochResolver, error) {
+	return r.epoch(ctx, uint64(args.Number))
+}
+
+func (r *queryResolver) Epochs(ctx context.Context, args struct {
+	From  Long
+	To    *Long
+	Limit int32
+}) ([]*epochResolver, error) {
+	from, to := uint64(args.From), ^uint64(0)
+	if args.To != nil {
+		to = uint64(*args.To)
+	}
+	limit, max := int(args.Limit), r.limit(maxEpochs)
+	if limit <= 0 || limit > max {
+		return nil, fmt.Errorf("limit must be between 1 and %d", max)
+	}
+	if from > to {
+		return nil, fmt.Errorf("from epoch %d is greater than to epoch %d", from, to)
+	}
+	consensusInfos, err := r.backend.ConsensusInfoRange(from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	if err := charge(ctx, len(consensusInfos)); err != nil {
+		return nil, err
+	}
+	epochs := make([]*epochResolver, len(consensusInfos))
+	for i, consensusInfo := range consensusInfos {
+		epochs[i] = &epochResolver{query: r, consensusInfo: consensusInfo}
+	}
+	return epochs, nil
+}
+
+func (r *queryResolver) LatestEpoch() Long {
+	return Long(r.backend.LatestEpoch())
+}
+
+func (r *queryResolver) Slot(ctx context.Context, args struct{ Number Long }) (*slotResolver, error) {
+	if err := charge(ctx, 1); err != nil {
+		return nil, err
+	}
+	return r.slot(uint64(args.Number))
+}
+
+func (r *queryResolver) Slots(ctx context.Context, args struct {
+	From   Long
+	To     Long
+	Status *[]string
+}) ([]*slotResolver, error) {
+	from, to := uint64(args.From), uint64(args.To)
+	if from > to {
+		return nil, fmt.Errorf("from slot %d is greater than to slot %d", from, to)
+	}
+	if max := r.limit(maxSlotRange); to-from >= uint64(max) {
+		return nil, fmt.Errorf("slot range is too large, maximum %d slots", max)
+	}
+	return r.slots(ctx, from, to, statusFilter(args.Status))
+}
+
+func (r *queryResolver) Block(ctx context.Context, args struct{ Hash Hash }) (*slotResolver, error) {
+	if err := charge(ctx, 1); err != nil {
+		return nil, err
+	}
+	blockStatus, err := r.backend.BlockStatusByHash(common.Hash(args.Hash))
+	if err != nil {
+		return nil, err
+	}
+	return &slotResolver{query: r, blockStatus: blockStatus}, nil
+}
+
+// Reorgs returns resolvers of reorg audit records whose new slot is within the optional [fromSlot, toSlot] range
+func (r *queryResolver) Reorgs(ctx context.Context, args struct {
+	FromSlot *Long
+	ToSlot   *Long
+}) ([]*reorgResolver, error) {
+	records, err := r.backend.ReorgAuditRecords()
+	if err != nil {
+		return nil, err
+	}
+	reorgs := make([]*reorgResolver, 0, len(records))
+	for _, record := range records {
+		if args.FromSlot != nil && record.NewSlot < uint64(*args.FromSlot) {
+			continue
+		}
+		if args.ToSlot != nil && record.NewSlot > uint64(*args.ToSlot) {
+			continue
+		}
+		reorgs = append(reorgs, &reorgResolver{query: r, record: record})
+	}
+	if err := charge(ctx, len(reorgs)); err != nil {
+		return nil, err
+	}
+	return reorgs, nil
+}
+
+func (r *queryResolver) LatestVerifiedSlot() Long {
+	return Long(r.backend.LatestVerifiedSlot())
+}
+
+func (r *queryResolver) FinalizedSlot() Long {
+	return Long(r.backend.LatestFinalizedSlot())
+}
+
+// epoch returns resolver of a stored epoch or nil when the epoch is not stored
+func (r *queryResolver) epoch(ctx context.Context, epoch uint64) (*epochResolver, error) {
+	if err := charge(ctx, 1); err != nil {
+		return nil, err
+	}
+	consensusInfos, err := r.backend.ConsensusInfoRange(epoch, epoch, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(consensusInfos) == 0 {
+		return nil, nil
+	}
+	return &epochResolver{query: r, consensusInfo: consensusInfos[0]}, nil
+}
+
+func (r *queryResolver) slot(slot uint64) (*slotResolver, error) {
+	blockStatus, err := r.backend.BlockStatusBySlot(slot)
+	if err != nil {
+		return nil, err
+	}
+	return &slotResolver{query: r, blockStatus: blockStatus}, nil
+}
+
+// slots returns resolvers of [from, to] slots whose status is one of the given statuses
+func (r *queryResolver) slots(ctx context.Context, from, to uint64, statuses map[types.Status]bool) ([]*slotResolver, error) {
+	if err := charge(ctx, int(to-from)+1); err != nil {
+		return nil, err
+	}
+	slots := make([]*slotResolver, 0)
+	for slot := from; slot <= to; slot++ {
+		s, err := r.slot(slot)
+		if err != nil {
+			return nil, err
+		}
+		if statuses == nil || statuses[s.blockStatus.Status] {
+			slots = append(slots, s)
+		}
+		if slot == to {
+			break
+		}
+	}
+	return slots, nil
+}
+
+func (r *queryResolver) slotList(ctx context.Context, slots []uint64, statuses map[types.Status]bool) ([]*slotResolver, error) {
+	if err := charge(ctx, len(slots)); err != nil {
+		return nil, err
+	}
+	list := make([]*slotResolver, 0, len(slots))
+	for _, slot := range slots {
+		s, err := r.slot(slot)
+		if err != nil {
+			return nil, err
+		}
+		if statuses == nil || statuses[s.blockStatus.Status] {
+			list = append(list, s)
+		}
+	}
+	return list, nil
+}
+
+// statusFilter converts [Status!] argument into a set, nil means no filtering
+func statusFilter(arg *[]string) map[types.Status]bool {
+	if arg == nil {
+		return nil
+	}
+	statuses := make(map[types.Status]bool)
+	for _, status := range *arg {
+		statuses[types.Status(status)] = true
+	}
+	return statuses
+}
+
+// epochResolver resolves the Epoch type
+type epochResolver struct {
+	query         *queryResolver
+	consensusInfo *types.MinimalEpochConsensusInfoV2
+}
+
+func (r *epochResolver) Number() Long {
+	return Long(r.consensusInfo.Epoch)
+}
+
+func (r *epochResolver) StartTime() Long {
+	return Long(r.consensusInfo.EpochStartTime)
+}
+
+func (r *epochResolver) SlotDuration() Long {
+	// consensus info keeps slot duration in seconds
+	return Long(r.consensusInfo.SlotTimeDuration)
+}
+
+func (r *epochResolver) Validators() []string {
+	if r.consensusInfo.ValidatorList == nil {
+		return []string{}
+	}
+	return r.consensusInfo.ValidatorList
+}
+
+func (r *epochResolver) FinalizedSlot() Long {
+	return Long(r.consensusInfo.FinalizedSlot)
+}
+
+func (r *epochResolver) Slots(ctx context.Context, args struct{ Status *[]string }) ([]*slotResolver, error) {
+	epoch := r.consensusInfo.Epoch
+	return r.query.slots(ctx, slotutil.EpochStartSlot(epoch), slotutil.EpochEndSlot(epoch), statusFilter(args.Status))
+}
+
+// slotResolver resolves the Slot type
+type slotResolver struct {
+	query       *queryResolver
+	blockStatus *types.PandoraBlockStatus
+}
+
+func (r *slotResolver) Number() Long {
+	return Long(r.blockStatus.Slot)
+}
+
+func (r *slotResolver) Status() string {
+	return string(r.blockStatus.Status)
+}
+
+func (r *slotResolver) PandoraHeaderHash() *Hash {
+	return hashOrNil(r.blockStatus.PandoraHeaderHash)
+}
+
+func (r *slotResolver) VanguardBlockHash() *Hash {
+	return hashOrNil(r.blockStatus.VanguardBlockHash)
+}
+
+func (r *slotResolver) Epoch(ctx context.Context) (*epochResolver, error) {
+	return r.query.epoch(ctx, slotutil.ToEpoch(r.blockStatus.Slot))
+}
+
+func (r *slotResolver) MismatchReason() (*string, error) {
+	if r.blockStatus.Status != types.Invalid {
+		return nil, nil
+	}
+	evidence, err := r.query.backend.MismatchEvidence(r.blockStatus.Slot)
+	if err != nil {
+		return nil, err
+	}
+	if evidence == nil {
+		return nil, nil
+	}
+	return &evidence.Reason, nil
+}
+
+// hashOrNil serializes zero hash as null
+func hashOrNil(hash common.Hash) *Hash {
+	if hash == (common.Hash{}) {
+		return nil
+	}
+	h := Hash(hash)
+	return &h
+}
+
+// reorgResolver resolves the Reorg type
+type reorgResolver struct {
+	query  *queryResolver
+	record *types.ReorgAuditRecord
+}
+
+func (r *reorgResolver) Timestamp() Long {
+	return Long(r.record.Timestamp)
+}
+
+func (r *reorgResolver) NewSlot() Long {
+	return Long(r.record.NewSlot)
+}
+
+func (r *reorgResolver) RevertSlot() Long {
+	return Long(r.record.RevertSlot)
+}
+
+func (r *reorgResolver) InvalidatedSlots(ctx context.Context, args struct{ Status *[]string }) ([]*slotResolver, error) {
+	return r.query.slotList(ctx, r.record.InvalidatedSlots, statusFilter(args.Status))
+}
@@ -0,0 +1,132 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// schemaSDL describes the data served by the graphql endpoint. It is parsed on start up together with the
+// resolvers, which must match the fields and the arguments of its types.
+const schemaSDL = `
+# Long is an unsigned 64 bit integer, accepted as a decimal string, a number variable or a number literal
+# of at most 2^31-1
+scalar Long
+# Hash is a 0x prefixed hex encoded 32 bytes hash
+scalar Hash
+
+# Status is the orchestrator verification status of a slot
+enum Status { Pending Verified Invalid Skipped Unknown Finalized Orphaned }
+
+type Query {
+	# consensus info of an epoch, null when the epoch has not been stored yet
+	epoch(number: Long!): Epoch
+	# stored epochs of [from, to] range in epoch order, at most 1024 epochs
+	epochs(from: Long!, to: Long, limit: Int = 64): [Epoch!]!
+	# latest stored epoch
+	latestEpoch: Long!
+	# status of a slot with the vanguard block and the pandora header paired in it
+	slot(number: Long!): Slot!
+	# slots of [from, to] range with one of the given statuses, at most 1024 slots
+	slots(from: Long!, to: Long!, status: [Status!]): [Slot!]!
+	# slot of a pandora block by its header hash
+	block(hash: Hash!): Slot!
+	# reorgs which have invalidated verified slots, optionally limited to a range of new slots
+	reorgs(fromSlot: Long, toSlot: Long): [Reorg!]!
+	# latest verified slot
+	latestVerifiedSlot: Long!
+	# latest finalized slot
+	finalizedSlot: Long!
+}
+
+type Epoch {
+	number: Long!
+	# unix time of the first slot of the epoch
+	startTime: Long!
+	# slot duration in seconds
+	slotDuration: Long!
+	# public keys of the validators assigned to the slots of the epoch in slot order
+	validators: [String!]!
+	finalizedSlot: Long!
+	# slots of the epoch with one of the given statuses
+	slots(status: [Status!]): [Slot!]!
+}
+
+type Slot {
+	number: Long!
+	status: Status!
+	pandoraHeaderHash: Hash
+	vanguardBlockHash: Hash
+	# epoch of the slot, null when the epoch has not been stored yet
+	epoch: Epoch
+	# reason why verification has rejected the slot, null unless the slot is invalid
+	mismatchReason: String
+}
+
+type Reorg {
+	# unix time when the reorg has been processed
+	timestamp: Long!
+	newSlot: Long!
+	revertSlot: Long!
+	# verified slots invalidated by the reorg with their current status
+	invalidatedSlots(status: [Status!]): [Slot!]!
+}
+`
+
+// Long is an unsigned 64 bit integer scalar. It is accepted as a decimal string, a json number variable or a 32 bit
+// int literal, and is serialized as a number.
+type Long uint64
+
+// ImplementsGraphQLType returns true if Long implements the provided GraphQL type.
+func (Long) ImplementsGraphQLType(name string) bool { return name == "Long" }
+
+// UnmarshalGraphQL unmarshals the provided GraphQL query data.
+func (l *Long) UnmarshalGraphQL(input interface{}) error {
+	var (
+		value uint64
+		err   error
+	)
+	switch input := input.(type) {
+	case int32:
+		if input < 0 {
+			return fmt.Errorf("invalid value %d of type Long", input)
+		}
+		value = uint64(input)
+	case json.Number:
+		value, err = strconv.ParseUint(input.String(), 10, 64)
+	case string:
+		value, err = strconv.ParseUint(input, 10, 64)
+	default:
+		return fmt.Errorf("invalid value %v of type Long", input)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %v of type Long", input)
+	}
+	*l = Long(value)
+	return nil
+}
+
+// Hash is a 0x prefixed hex encoded 32 bytes hash scalar
+type Hash common.Hash
+
+// ImplementsGraphQLType returns true if Hash implements the provided GraphQL type.
+func (Hash) ImplementsGraphQLType(name string) bool { return name == "Hash" }
+
+// UnmarshalGraphQL unmarshals the provided GraphQL query data.
+func (h *Hash) UnmarshalGraphQL(input interface{}) error {
+	if input, ok := input.(string); ok {
+		if hash, err := hexutil.Decode(input); err == nil && len(hash) == common.HashLength {
+			*h = Hash(common.BytesToHash(hash))
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value %v of type Hash", input)
+}
+
+// MarshalJSON serializes the hash as 0x prefixed hex string
+func (h Hash) MarshalJSON() ([]byte, error) {
+	return json.Marshal(common.Hash(h).Hex())
+}
@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	"net/http"
+)
+
+const (
+	healthPath    = "/healthz"
+	readinessPath = "/readyz"
+)
+
+// healthHandler reports that the orchestrator process is up
+func healthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok\n"))
+	})
+}
+
+// readinessHandler reports whether the orchestrator is ready to serve traffic. Orchestrator is ready when the
+// given check passes, otherwise the reason is returned with 503 status.
+func readinessHandler(check func() error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if check != nil {
+			if err := check(); err != nil {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(err.Error() + "\n"))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok\n"))
+	})
+}
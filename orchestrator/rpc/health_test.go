@@ -0,0 +1,32 @@
+package rpc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+// TestReadinessHandler checks that readiness reports the reason of not being ready with 503 status
+func TestReadinessHandler(t *testing.T) {
+	var checkErr error
+	handler := readinessHandler(func() error {
+		return checkErr
+	})
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, readinessPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	checkErr = errors.New("verification lag 100 slots exceeds 64 slots")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, readinessPath, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, "verification lag 100 slots exceeds 64 slots\n", recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	healthHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, healthPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
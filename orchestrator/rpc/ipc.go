@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"os"
+	"os/user"
+	"runtime"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// applyIPCPermissions sets the permission bits and the owner group of the unix socket
+// at endpoint. Named pipes on windows are left untouched.
+func applyIPCPermissions(endpoint string, perm os.FileMode, group string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	if group != "" {
+		gid, err := lookupGroupID(group)
+		if err != nil {
+			return err
+		}
+		if err := os.Chown(endpoint, -1, gid); err != nil {
+			return errors.Wrapf(err, "could not change group of ipc socket to %s", group)
+		}
+	}
+	if perm != 0 {
+		if err := os.Chmod(endpoint, perm); err != nil {
+			return errors.Wrapf(err, "could not change permissions of ipc socket to %#o", perm)
+		}
+	}
+	return nil
+}
+
+// lookupGroupID resolves a group name or a numeric group id.
+func lookupGroupID(group string) (int, error) {
+	if gid, err := strconv.Atoi(group); err == nil {
+		return gid, nil
+	}
+	g, err := user.LookupGroup(group)
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not find ipc group %s", group)
+	}
+	gid, err := strconv.Atoi(g.Gid)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid id %s of ipc group %s", g.Gid, group)
+	}
+	return gid, nil
+}
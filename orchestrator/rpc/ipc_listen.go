@@ -0,0 +1,23 @@
+// +build !windows
+
+package rpc
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// ipcListen creates the unix socket of the ipc endpoint, a leftover socket of a previous run is removed
+func ipcListen(endpoint string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(endpoint), 0751); err != nil {
+		return nil, err
+	}
+	os.Remove(endpoint)
+	listener, err := net.Listen("unix", endpoint)
+	if err != nil {
+		return nil, err
+	}
+	os.Chmod(endpoint, 0600)
+	return listener, nil
+}
@@ -0,0 +1,12 @@
+package rpc
+
+import (
+	"net"
+
+	"gopkg.in/natefinch/npipe.v2"
+)
+
+// ipcListen creates the named pipe of the ipc endpoint
+func ipcListen(endpoint string) (net.Listener, error) {
+	return npipe.Listen(endpoint)
+}
@@ -0,0 +1,44 @@
+package rpc
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestIPCServer_Permissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets only")
+	}
+	endpoint := filepath.Join(t.TempDir(), "orchestrator.ipc")
+	server := newIPCServer(endpoint)
+	server.perm = 0660
+	server.group = strconv.Itoa(os.Getgid())
+	require.NoError(t, server.start(nil))
+	defer server.stop()
+
+	info, err := os.Stat(endpoint)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0660), info.Mode().Perm())
+
+	conn, err := net.Dial("unix", endpoint)
+	require.NoError(t, err)
+	conn.Close()
+}
+
+func TestIPCServer_UnknownGroup(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets only")
+	}
+	endpoint := filepath.Join(t.TempDir(), "orchestrator.ipc")
+	server := newIPCServer(endpoint)
+	server.group = "no-such-orchestrator-group"
+	assert.ErrorContains(t, "could not find ipc group", server.start(nil))
+	assert.Equal(t, nil, server.listener)
+}
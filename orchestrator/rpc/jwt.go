@@ -0,0 +1,165 @@
+package rpc
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/secrets"
+	"github.com/pkg/errors"
+)
+
+// maximum difference between issued-at claim of a token and local time
+const jwtIssuedAtTolerance = 60 * time.Second
+
+// length of the shared secret in bytes
+const jwtSecretLength = 32
+
+// url parameter which carries the token of websocket clients which can not set headers of the handshake
+const jwtQueryParam = "token"
+
+var (
+	errMissingToken     = errors.New("missing bearer token")
+	errMalformedToken   = errors.New("malformed token")
+	errUnsupportedAlg   = errors.New("unsupported signing algorithm, only HS256 is allowed")
+	errInvalidSignature = errors.New("invalid token signature")
+	errStaleToken       = errors.New("token issued-at is too far from current time")
+)
+
+// ReadJWTSecret reads hex encoded 32 bytes shared secret from the given file, or from the environment variable of
+// an env:NAME reference
+func ReadJWTSecret(ref string) ([]byte, error) {
+	data, err := secrets.Load(ref)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read jwt secret")
+	}
+	encoded := strings.TrimPrefix(data.Text(), "0x")
+	secret, err := hex.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.Wrap(err, "jwt secret must be hex encoded")
+	}
+	if len(secret) != jwtSecretLength {
+		return nil, errors.Errorf("jwt secret must be %d bytes, got %d bytes", jwtSecretLength, len(secret))
+	}
+	return secret, nil
+}
+
+// jwtHandler rejects requests which do not carry a bearer token signed with the shared secret
+type jwtHandler struct {
+	secret []byte
+	next   http.Handler
+	// counts requests which are rejected for a missing or invalid token
+	rejected metrics.Counter
+}
+
+// newJWTHandler wraps the given handler with verification of HS256 signed bearer tokens. Tokens must carry
+// an issued-at claim close to current time, so a leaked token can not be replayed for long.
+func newJWTHandler(secret []byte, next http.Handler) http.Handler {
+	return &jwtHandler{secret: secret, next: next, rejected: rpcMetrics.Counter("auth/rejected")}
+}
+
+func (h *jwtHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, err := requestToken(r)
+	if err == nil {
+		err = verifyJWT(h.secret, token, time.Now())
+	}
+	if err != nil {
+		h.rejected.Inc(1)
+		log.WithError(err).WithField("remoteAddr", r.RemoteAddr).WithField("path", r.URL.Path).
+			Debug("Rejected unauthenticated rpc request")
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// requestToken returns the bearer token of the request. Websocket clients of pandora and vanguard can not set
+// headers of the handshake, so their token may be passed in the token url parameter instead. Every subscription
+// of the connection is authenticated by the token of its handshake.
+func requestToken(r *http.Request) (string, error) {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		if !strings.HasPrefix(auth, "Bearer ") {
+			return "", errMissingToken
+		}
+		return strings.TrimPrefix(auth, "Bearer "), nil
+	}
+	if isWebsocket(r) {
+		if token := r.URL.Query().Get(jwtQueryParam); token != "" {
+			return token, nil
+		}
+	}
+	return "", errMissingToken
+}
+
+// NewJWT creates a HS256 token with the given issued-at claim signed with the shared secret. Clients create a new
+// token for every connection, since the token is only accepted close to the time it was issued at.
+func NewJWT(secret []byte, issuedAt time.Time) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"iat":%d}`, issuedAt.Unix())))
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(header + "." + claims))
+	return header + "." + claims + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// verifyJWT checks signature and issued-at claim of the given HS256 token
+func verifyJWT(secret []byte, token string, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errMalformedToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return err
+	}
+	if header.Alg != "HS256" {
+		return errUnsupportedAlg
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return errMalformedToken
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return errInvalidSignature
+	}
+
+	var claims struct {
+		IssuedAt *int64 `json:"iat"`
+	}
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return err
+	}
+	if claims.IssuedAt == nil {
+		return errors.Wrap(errMalformedToken, "missing issued-at claim")
+	}
+	diff := now.Sub(time.Unix(*claims.IssuedAt, 0))
+	if diff > jwtIssuedAtTolerance || diff < -jwtIssuedAtTolerance {
+		return errStaleToken
+	}
+	return nil
+}
+
+// decodeJWTPart decodes base64url encoded json part of a token
+func decodeJWTPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return errMalformedToken
+	}
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(v); err != nil {
+		return errMalformedToken
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package rpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+// signJWT creates a token with the given algorithm and issued-at claim signed with the given secret
+func signJWT(secret []byte, alg string, issuedAt int64) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"alg":"%s","typ":"JWT"}`, alg)))
+	claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"iat":%d}`, issuedAt)))
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(header + "." + claims))
+	return header + "." + claims + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyJWT(t *testing.T) {
+	secret := make([]byte, jwtSecretLength)
+	secret[0] = 1
+	now := time.Now()
+
+	assert.NoError(t, verifyJWT(secret, signJWT(secret, "HS256", now.Unix()), now))
+	assert.ErrorContains(t, errMalformedToken.Error(), verifyJWT(secret, "abc.def", now))
+	assert.ErrorContains(t, errUnsupportedAlg.Error(), verifyJWT(secret, signJWT(secret, "none", now.Unix()), now))
+	assert.ErrorContains(t, errInvalidSignature.Error(), verifyJWT(secret, signJWT(make([]byte, jwtSecretLength), "HS256", now.Unix()), now))
+	assert.ErrorContains(t, errStaleToken.Error(), verifyJWT(secret, signJWT(secret, "HS256", now.Add(-2*time.Minute).Unix()), now))
+}
+
+func TestJWTHandler(t *testing.T) {
+	secret := make([]byte, jwtSecretLength)
+	handler := newJWTHandler(secret, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+signJWT(secret, "HS256", time.Now().Unix()))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestReadJWTSecret(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "jwt.hex")
+	require.NoError(t, ioutil.WriteFile(path, []byte("0x0102030405060708091011121314151617181920212223242526272829303132\n"), 0600))
+	secret, err := ReadJWTSecret(path)
+	require.NoError(t, err)
+	assert.Equal(t, jwtSecretLength, len(secret))
+	assert.Equal(t, byte(0x32), secret[31])
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("0x0102"), 0600))
+	_, err = ReadJWTSecret(path)
+	assert.ErrorContains(t, "jwt secret must be 32 bytes", err)
+}
+
+// TestJWTHandler_QueryToken checks that the token url parameter is accepted on websocket handshakes only
+func TestJWTHandler_QueryToken(t *testing.T) {
+	secret := make([]byte, jwtSecretLength)
+	secret[0] = 1
+	// metrics are enabled after the start of the process, e.g. by the config file
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = false }()
+	metrics.DefaultRegistry.Unregister(rpcMetrics.Name("auth/rejected"))
+	handler := newJWTHandler(secret, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	wsRequest := func(token string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/?"+jwtQueryParam+"="+token, nil)
+		req.Header.Set("Connection", "upgrade")
+		req.Header.Set("Upgrade", "websocket")
+		return req
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, wsRequest(NewJWT(secret, time.Now())))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, wsRequest(NewJWT(make([]byte, jwtSecretLength), time.Now())))
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/?"+jwtQueryParam+"="+NewJWT(secret, time.Now()), nil))
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.Equal(t, int64(2), handler.(*jwtHandler).rejected.Count())
+}
+
+func TestNewJWT(t *testing.T) {
+	secret := make([]byte, jwtSecretLength)
+	now := time.Unix(1620000000, 0)
+	assert.Equal(t, signJWT(secret, "HS256", now.Unix()), NewJWT(secret, now))
+	assert.NoError(t, verifyJWT(secret, NewJWT(secret, now), now.Add(time.Second)))
+}
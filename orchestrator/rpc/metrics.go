@@ -0,0 +1,215 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+)
+
+// rpcMetrics collects metrics of rpc calls
+var rpcMetrics = metrics.NewCollector("rpc")
+
+// metric label of methods which are not served by orchestrator, so that clients can't register arbitrary metrics
+const unknownMethod = "unknown"
+
+// maximum size of a response which is inspected to count failed calls
+const metricsMaxResponseSize = 5 * 1024 * 1024
+
+// jsonrpcMessage contains the fields of json-rpc requests and responses which are needed by middlewares
+type jsonrpcMessage struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  json.RawMessage `json:"error,omitempty"`
+}
+
+// failed reports whether the message is an error response
+func (m *jsonrpcMessage) failed() bool {
+	return len(m.Error) > 0 && string(m.Error) != "null"
+}
+
+// errorMessage returns the message of an error response
+func (m *jsonrpcMessage) errorMessage() string {
+	var rpcError struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(m.Error, &rpcError); err != nil || rpcError.Message == "" {
+		return string(m.Error)
+	}
+	return rpcError.Message
+}
+
+// decodeJSONRPCMessages decodes a single or a batch json-rpc message. Like the rpc server, only the first json
+// value of the body is decoded and trailing data is ignored, so the returned messages are the ones which are served.
+// It returns nil for undecodable input.
+func decodeJSONRPCMessages(body []byte) []*jsonrpcMessage {
+	var raw json.RawMessage
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&raw); err != nil {
+		return nil
+	}
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '[' {
+		var msgs []*jsonrpcMessage
+		if err := json.Unmarshal(raw, &msgs); err != nil {
+			return nil
+		}
+		return msgs
+	}
+	var msg jsonrpcMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return nil
+	}
+	return []*jsonrpcMessage{&msg}
+}
+
+// methodMetrics keeps call count, error count and latency of every rpc method
+type methodMetrics struct {
+	methods map[string]bool
+
+	lock   sync.Mutex
+	calls  map[string]metrics.Counter
+	errors map[string]metrics.Counter
+	timers map[string]metrics.Timer
+}
+
+// newMethodMetrics creates metrics of the methods which are served by the given apis
+func newMethodMetrics(apis []rpc.API) *methodMetrics {
+	return &methodMetrics{
+		methods: apiMethodNames(apis),
+		calls:   make(map[string]metrics.Counter),
+		errors:  make(map[string]metrics.Counter),
+		timers:  make(map[string]metrics.Timer),
+	}
+}
+
+// record updates metrics of a single method call
+func (m *methodMetrics) record(method string, failed bool, duration time.Duration) {
+	if !m.methods[method] {
+		method = unknownMethod
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.calls[method]; !ok {
+		m.calls[method] = rpcMetrics.Counter("calls/" + method)
+		m.errors[method] = rpcMetrics.Counter("errors/" + method)
+		m.timers[method] = rpcMetrics.Timer("duration/" + method)
+	}
+	m.calls[method].Inc(1)
+	if failed {
+		m.errors[method].Inc(1)
+	}
+	m.timers[method].Update(duration)
+}
+
+// apiMethodNames returns json-rpc names of all the methods of the given apis, e.g. orc_finalizedHead
+func apiMethodNames(apis []rpc.API) map[string]bool {
+	names := map[string]bool{"rpc_modules": true}
+	for _, api := range apis {
+		names[api.Namespace+"_subscribe"] = true
+		names[api.Namespace+"_unsubscribe"] = true
+		serviceType := reflect.TypeOf(api.Service)
+		for i := 0; i < serviceType.NumMethod(); i++ {
+			name := []rune(serviceType.Method(i).Name)
+			name[0] = unicode.ToLower(name[0])
+			names[api.Namespace+"_"+string(name)] = true
+		}
+	}
+	return names
+}
+
+// responseRecorder passes the response through and keeps a copy of its beginning and its status
+type responseRecorder struct {
+	http.ResponseWriter
+	body      bytes.Buffer
+	truncated bool
+	status    int
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// statusCode returns the status of the response, which is OK when it has not been written explicitly
+func (r *responseRecorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if !r.truncated {
+		if r.body.Len()+len(b) > metricsMaxResponseSize {
+			r.truncated = true
+		} else {
+			r.body.Write(b)
+		}
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// metricsHandler measures calls of json-rpc methods over http and logs requests slower than slowThreshold.
+// Zero slowThreshold disables logging of slow requests.
+type metricsHandler struct {
+	metrics       *methodMetrics
+	slowThreshold time.Duration
+	next          http.Handler
+}
+
+func newMetricsHandler(metrics *methodMetrics, slowThreshold time.Duration, next http.Handler) http.Handler {
+	return &metricsHandler{metrics: metrics, slowThreshold: slowThreshold, next: next}
+}
+
+func (h *metricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.Body == nil {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, rateLimitMaxBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+	requests := decodeJSONRPCMessages(body)
+
+	recorder := &responseRecorder{ResponseWriter: w}
+	start := time.Now()
+	h.next.ServeHTTP(recorder, r)
+	duration := time.Since(start)
+
+	// failed calls are matched with their requests by id
+	failed := make(map[string]bool)
+	if !recorder.truncated {
+		for _, response := range decodeJSONRPCMessages(recorder.body.Bytes()) {
+			if response.failed() {
+				failed[string(response.ID)] = true
+			}
+		}
+	}
+	methods := make([]string, len(requests))
+	for i, request := range requests {
+		methods[i] = request.Method
+		h.metrics.record(request.Method, failed[string(request.ID)], duration)
+	}
+
+	if h.slowThreshold > 0 && duration >= h.slowThreshold {
+		log.WithField("methods", strings.Join(methods, ",")).WithField("duration", duration).
+			WithField("remoteAddr", r.RemoteAddr).Warn("Slow rpc request")
+	}
+}
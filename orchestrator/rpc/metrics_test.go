@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+type echoService struct{}
+
+func (s *echoService) Echo(value int) int {
+	return value
+}
+
+func TestMetricsHandler(t *testing.T) {
+	metrics.Enabled = true
+	hook := logTest.NewGlobal()
+	apis := []rpc.API{{Namespace: "metricstest", Version: "1.0", Service: &echoService{}, Public: true}}
+	srv := rpc.NewServer()
+	require.NoError(t, RegisterApisFromWhitelist(apis, nil, srv, false))
+	methodMetrics := newMethodMetrics(apis)
+	assert.Equal(t, true, methodMetrics.methods["metricstest_echo"])
+
+	// slow request threshold of 1ns logs every request
+	handler := newMetricsHandler(methodMetrics, 1, srv)
+	request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[
+		{"jsonrpc":"2.0","id":1,"method":"metricstest_echo","params":[1]},
+		{"jsonrpc":"2.0","id":2,"method":"metricstest_echo","params":["x"]},
+		{"jsonrpc":"2.0","id":3,"method":"metricstest_random","params":[]}
+	]`))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	assert.Equal(t, int64(2), rpcMetrics.Counter("calls/metricstest_echo").Count())
+	assert.Equal(t, int64(1), rpcMetrics.Counter("errors/metricstest_echo").Count())
+	assert.Equal(t, int64(2), rpcMetrics.Timer("duration/metricstest_echo").Count())
+	assert.Equal(t, int64(1), rpcMetrics.Counter("errors/"+unknownMethod).Count())
+	assert.Equal(t, false, metrics.DefaultRegistry.Get(rpcMetrics.Name("calls/metricstest_random")) != nil)
+	require.LogsContain(t, hook, "Slow rpc request")
+}
@@ -0,0 +1,202 @@
+package rpc
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// methodClass groups rpc methods which share a rate limit
+type methodClass int
+
+const (
+	defaultMethods methodClass = iota
+	// methods which read many slots or epochs from db in one request
+	expensiveMethods
+)
+
+// rpc methods which belong to expensive method class
+var expensiveMethodNames = map[string]bool{
+	"orc_getMinimalConsensusInfoRange": true,
+	"orc_getBlockStatusBatch":          true,
+	"admin_reverify":                   true,
+	"admin_backupDB":                   true,
+	"admin_pruneDB":                    true,
+}
+
+// maximum size of a request body which is inspected to find out requested methods
+const rateLimitMaxBodySize = 5 * 1024 * 1024
+
+// buckets which have not been used for this period are full again, so they are dropped
+var rateLimitSweepPeriod = time.Minute
+
+// RateLimitConfig defines token bucket limits of a single client. Zero requests per second disables the limit
+// of the method class.
+type RateLimitConfig struct {
+	RequestsPerSecond          float64
+	Burst                      int
+	ExpensiveRequestsPerSecond float64
+	ExpensiveBurst             int
+}
+
+// enabled returns true when any of method classes is limited
+func (c RateLimitConfig) enabled() bool {
+	return c.RequestsPerSecond > 0 || c.ExpensiveRequestsPerSecond > 0
+}
+
+// tokenBucket is refilled with rate tokens per second up to burst tokens
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+type bucketKey struct {
+	ip    string
+	class methodClass
+}
+
+// rateLimiter keeps a token bucket per client ip and method class
+type rateLimiter struct {
+	rates  map[methodClass]float64
+	bursts map[methodClass]int
+
+	lock      sync.Mutex
+	buckets   map[bucketKey]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newRateLimiter(config RateLimitConfig) *rateLimiter {
+	return &rateLimiter{
+		rates: map[methodClass]float64{
+			defaultMethods:   config.RequestsPerSecond,
+			expensiveMethods: config.ExpensiveRequestsPerSecond,
+		},
+		bursts: map[methodClass]int{
+			defaultMethods:   config.Burst,
+			expensiveMethods: config.ExpensiveBurst,
+		},
+		buckets: make(map[bucketKey]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// update replaces the limits of method classes, buckets of clients are kept and capped by the new burst
+func (l *rateLimiter) update(config RateLimitConfig) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.rates[defaultMethods] = config.RequestsPerSecond
+	l.rates[expensiveMethods] = config.ExpensiveRequestsPerSecond
+	l.bursts[defaultMethods] = config.Burst
+	l.bursts[expensiveMethods] = config.ExpensiveBurst
+}
+
+// allow takes the given number of tokens per method class from buckets of the client. No token is taken
+// when any of buckets has not enough tokens.
+func (l *rateLimiter) allow(ip string, cost map[methodClass]int) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) > rateLimitSweepPeriod {
+		l.sweep(now)
+	}
+
+	buckets := make(map[methodClass]*tokenBucket, len(cost))
+	for class, n := range cost {
+		rate := l.rates[class]
+		if rate <= 0 {
+			continue
+		}
+		burst := float64(l.bursts[class])
+		if burst < 1 {
+			burst = 1
+		}
+		key := bucketKey{ip: ip, class: class}
+		bucket, ok := l.buckets[key]
+		if !ok {
+			bucket = &tokenBucket{tokens: burst, lastRefill: now}
+			l.buckets[key] = bucket
+		}
+		bucket.tokens += now.Sub(bucket.lastRefill).Seconds() * rate
+		if bucket.tokens > burst {
+			bucket.tokens = burst
+		}
+		bucket.lastRefill = now
+		if bucket.tokens < float64(n) {
+			return false
+		}
+		buckets[class] = bucket
+	}
+	for class, bucket := range buckets {
+		bucket.tokens -= float64(cost[class])
+	}
+	return true
+}
+
+// sweep drops buckets which would be full by now. The caller must hold l.lock.
+func (l *rateLimiter) sweep(now time.Time) {
+	for key, bucket := range l.buckets {
+		refilled := bucket.tokens + now.Sub(bucket.lastRefill).Seconds()*l.rates[key.class]
+		if refilled >= float64(l.bursts[key.class]) {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// rateLimitHandler rejects requests of clients which exceeded their rate limit with 429 status
+type rateLimitHandler struct {
+	limiter *rateLimiter
+	next    http.Handler
+}
+
+func newRateLimitHandler(limiter *rateLimiter, next http.Handler) http.Handler {
+	return &rateLimitHandler{limiter: limiter, next: next}
+}
+
+func (h *rateLimitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// only the connection address is trusted, forwarded headers can be forged by clients
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	cost := map[methodClass]int{defaultMethods: 1}
+	if r.Method == http.MethodPost && r.Body != nil {
+		body, err := ioutil.ReadAll(io.LimitReader(r.Body, rateLimitMaxBodySize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+		cost = requestCost(body)
+	}
+	if !h.limiter.allow(ip, cost) {
+		log.WithField("ip", ip).Debug("Rate limit exceeded")
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// requestCost counts methods of single or batch json-rpc request per method class. Only the first json value of the
+// body is counted, which is the request the rpc server serves. Undecodable requests cost a single default token, rpc
+// server rejects them without serving any method.
+func requestCost(body []byte) map[methodClass]int {
+	cost := make(map[methodClass]int)
+	for _, msg := range decodeJSONRPCMessages(body) {
+		if expensiveMethodNames[msg.Method] {
+			cost[expensiveMethods]++
+		} else {
+			cost[defaultMethods]++
+		}
+	}
+	if len(cost) == 0 {
+		cost[defaultMethods] = 1
+	}
+	return cost
+}
@@ -0,0 +1,105 @@
+package rpc
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+func TestRequestCost(t *testing.T) {
+	assert.DeepEqual(t, map[methodClass]int{defaultMethods: 1},
+		requestCost([]byte(`{"jsonrpc":"2.0","id":1,"method":"orc_finalizedHead","params":[]}`)))
+	assert.DeepEqual(t, map[methodClass]int{defaultMethods: 1, expensiveMethods: 2},
+		requestCost([]byte(` [{"method":"orc_getBlockStatusBatch"},{"method":"orc_finalizedHead"},{"method":"admin_reverify"}]`)))
+	assert.DeepEqual(t, map[methodClass]int{defaultMethods: 1}, requestCost([]byte(`not json`)))
+	// trailing data after the request which is served does not hide its methods
+	assert.DeepEqual(t, map[methodClass]int{expensiveMethods: 2},
+		requestCost([]byte(`[{"method":"admin_reverify"},{"method":"admin_reverify"}] garbage`)))
+}
+
+// TestRateLimiter_Allow checks that buckets are kept per client ip and method class and refilled over time
+func TestRateLimiter_Allow(t *testing.T) {
+	now := time.Now()
+	limiter := newRateLimiter(RateLimitConfig{
+		RequestsPerSecond:          10,
+		Burst:                      2,
+		ExpensiveRequestsPerSecond: 1,
+		ExpensiveBurst:             1,
+	})
+	limiter.now = func() time.Time { return now }
+	single := map[methodClass]int{defaultMethods: 1}
+	expensive := map[methodClass]int{expensiveMethods: 1}
+
+	assert.Equal(t, true, limiter.allow("10.0.0.1", single))
+	assert.Equal(t, true, limiter.allow("10.0.0.1", single))
+	assert.Equal(t, false, limiter.allow("10.0.0.1", single))
+	assert.Equal(t, true, limiter.allow("10.0.0.2", single))
+
+	assert.Equal(t, true, limiter.allow("10.0.0.1", expensive))
+	assert.Equal(t, false, limiter.allow("10.0.0.1", expensive))
+
+	// batch is rejected as a whole and takes no token when one of classes is exhausted
+	now = now.Add(100 * time.Millisecond)
+	assert.Equal(t, false, limiter.allow("10.0.0.1", map[methodClass]int{defaultMethods: 1, expensiveMethods: 1}))
+	assert.Equal(t, true, limiter.allow("10.0.0.1", single))
+
+	now = now.Add(time.Second)
+	assert.Equal(t, true, limiter.allow("10.0.0.1", expensive))
+
+	// full buckets are dropped
+	now = now.Add(2 * rateLimitSweepPeriod)
+	assert.Equal(t, true, limiter.allow("10.0.0.3", single))
+	assert.Equal(t, 1, len(limiter.buckets))
+}
+
+// TestRateLimiter_Update checks that new limits apply to existing buckets
+func TestRateLimiter_Update(t *testing.T) {
+	now := time.Now()
+	limiter := newRateLimiter(RateLimitConfig{RequestsPerSecond: 1, Burst: 1})
+	limiter.now = func() time.Time { return now }
+	single := map[methodClass]int{defaultMethods: 1}
+
+	assert.Equal(t, true, limiter.allow("10.0.0.1", single))
+	assert.Equal(t, false, limiter.allow("10.0.0.1", single))
+
+	limiter.update(RateLimitConfig{RequestsPerSecond: 10, Burst: 5})
+	now = now.Add(200 * time.Millisecond)
+	assert.Equal(t, true, limiter.allow("10.0.0.1", single))
+	assert.Equal(t, true, limiter.allow("10.0.0.1", single))
+	assert.Equal(t, false, limiter.allow("10.0.0.1", single))
+
+	// zero rate disables the limit
+	limiter.update(RateLimitConfig{})
+	assert.Equal(t, true, limiter.allow("10.0.0.1", single))
+}
+
+func TestRateLimitHandler(t *testing.T) {
+	limiter := newRateLimiter(RateLimitConfig{ExpensiveRequestsPerSecond: 1, ExpensiveBurst: 1})
+	var received string
+	handler := newRateLimitHandler(limiter, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		received = string(body)
+	}))
+
+	request := `{"jsonrpc":"2.0","id":1,"method":"orc_getMinimalConsensusInfoRange","params":[0,10]}`
+	for i := 0; i < 2; i++ {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(request)))
+		if i == 0 {
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, request, received)
+			continue
+		}
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	}
+
+	// default methods are not limited
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"method":"orc_finalizedHead"}`)))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
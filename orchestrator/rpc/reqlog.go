@@ -0,0 +1,255 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// verbosity of request logging
+const (
+	// RequestLogBasic logs client, method, id, duration and error of every call
+	RequestLogBasic = "basic"
+	// RequestLogFull logs params of requests and results of responses as well
+	RequestLogFull = "full"
+)
+
+// transports whose requests can be logged
+const (
+	transportHTTP = "http"
+	transportWS   = "ws"
+	transportIPC  = "ipc"
+)
+
+// maximum size of logged params, results and query strings, longer values are truncated
+const requestLogMaxValueSize = 1024
+
+// replaces secrets in logged values
+const redactedValue = "REDACTED"
+
+// names of json fields and url parameters whose values are never logged
+var sensitiveNames = []string{"token", "secret", "password", "passphrase", "jwt", "authorization", "privatekey"}
+
+// bearer credentials and jwt tokens which are redacted from any logged value
+var tokenPattern = regexp.MustCompile(`(?i)bearer\s+[a-z0-9._~+/=-]+|eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+
+// RequestLogConfig selects the transports whose requests are logged, nothing is logged when no transport is selected
+type RequestLogConfig struct {
+	HTTP bool
+	WS   bool
+	IPC  bool
+	// Full logs params of requests and results of responses next to methods, ids, durations and errors
+	Full bool
+}
+
+// ParseRequestLogConfig builds request log config from the names of the logged transports and the verbosity. An
+// empty verbosity logs basic request details.
+func ParseRequestLogConfig(transports []string, verbosity string) (RequestLogConfig, error) {
+	var config RequestLogConfig
+	for _, transport := range transports {
+		switch strings.ToLower(transport) {
+		case transportHTTP:
+			config.HTTP = true
+		case transportWS:
+			config.WS = true
+		case transportIPC:
+			config.IPC = true
+		default:
+			return RequestLogConfig{}, errors.Errorf("unknown rpc log transport %q, expected http, ws or ipc", transport)
+		}
+	}
+	switch verbosity {
+	case RequestLogBasic, "":
+	case RequestLogFull:
+		config.Full = true
+	default:
+		return RequestLogConfig{}, errors.Errorf("unknown rpc log verbosity %q, expected %s or %s",
+			verbosity, RequestLogBasic, RequestLogFull)
+	}
+	return config, nil
+}
+
+// logger returns the request logger of the given transport, nil when the transport is not logged
+func (c RequestLogConfig) logger(transport string) *requestLogger {
+	enabled := map[string]bool{transportHTTP: c.HTTP, transportWS: c.WS, transportIPC: c.IPC}
+	if !enabled[transport] {
+		return nil
+	}
+	return &requestLogger{transport: transport, full: c.Full}
+}
+
+// requestLogger writes a structured log entry of every json-rpc call of a transport. Auth tokens and values
+// of sensitive fields are redacted from logged values.
+type requestLogger struct {
+	transport string
+	full      bool
+}
+
+// clientFields returns the log fields which identify the client of a request or a connection
+func (l *requestLogger) clientFields(remoteAddr string, authorization string) logrus.Fields {
+	fields := logrus.Fields{"transport": l.transport, "remoteAddr": remoteAddr}
+	if authorization != "" {
+		fields["authorization"] = redactAuthorization(authorization)
+	}
+	return fields
+}
+
+// logCall logs a json-rpc call together with its response, response is nil when it is not known
+func (l *requestLogger) logCall(fields logrus.Fields, request, response *jsonrpcMessage, duration time.Duration) {
+	entry := log.WithFields(fields).WithField("method", request.Method)
+	if len(request.ID) > 0 {
+		entry = entry.WithField("id", string(request.ID))
+	}
+	if l.full && len(request.Params) > 0 {
+		entry = entry.WithField("params", redactJSON(request.Params))
+	}
+	if response != nil {
+		entry = entry.WithField("duration", duration)
+		if response.failed() {
+			entry = entry.WithField("error", redactString(response.errorMessage()))
+		} else if l.full && len(response.Result) > 0 {
+			entry = entry.WithField("result", redactJSON(response.Result))
+		}
+	}
+	entry.Info("RPC request")
+}
+
+// logHTTPRequest logs a http request which does not carry json-rpc calls, e.g. a REST or graphql request
+func (l *requestLogger) logHTTPRequest(fields logrus.Fields, r *http.Request, duration time.Duration) {
+	entry := log.WithFields(fields).WithField("httpMethod", r.Method).WithField("path", r.URL.Path).
+		WithField("duration", duration)
+	if l.full && r.URL.RawQuery != "" {
+		entry = entry.WithField("query", redactQuery(r.URL.Query()))
+	}
+	entry.Info("HTTP request")
+}
+
+// redactAuthorization keeps the scheme of an authorization header only
+func redactAuthorization(authorization string) string {
+	scheme := strings.Fields(authorization)
+	if len(scheme) == 0 {
+		return redactedValue
+	}
+	return scheme[0] + " " + redactedValue
+}
+
+// isSensitive reports whether the value of a field with the given name must not be logged
+func isSensitive(name string) bool {
+	name = strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+	for _, sensitive := range sensitiveNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactJSON replaces values of sensitive fields and tokens of a json value and truncates it
+func redactJSON(raw json.RawMessage) string {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return redactString(string(raw))
+	}
+	encoded, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return redactString(string(raw))
+	}
+	return redactString(string(encoded))
+}
+
+func redactValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, v := range value {
+			if isSensitive(key) {
+				value[key] = redactedValue
+			} else {
+				value[key] = redactValue(v)
+			}
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = redactValue(v)
+		}
+	}
+	return value
+}
+
+// redactQuery replaces values of sensitive url parameters and tokens of a query string and truncates it
+func redactQuery(query url.Values) string {
+	for key := range query {
+		if isSensitive(key) {
+			query[key] = []string{redactedValue}
+		}
+	}
+	return redactString(query.Encode())
+}
+
+// redactString replaces tokens of a logged value and truncates it
+func redactString(value string) string {
+	value = tokenPattern.ReplaceAllString(value, redactedValue)
+	if len(value) > requestLogMaxValueSize {
+		value = value[:requestLogMaxValueSize] + "..."
+	}
+	return value
+}
+
+// requestLogHandler logs json-rpc calls of http requests, other requests are logged by their path.
+// Requests which are rejected by authentication or rate limiting are logged with their status as well.
+type requestLogHandler struct {
+	logger *requestLogger
+	next   http.Handler
+}
+
+func newRequestLogHandler(logger *requestLogger, next http.Handler) http.Handler {
+	return &requestLogHandler{logger: logger, next: next}
+}
+
+func (h *requestLogHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body []byte
+	if r.Method == http.MethodPost && r.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(io.LimitReader(r.Body, rateLimitMaxBodySize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+	}
+
+	recorder := &responseRecorder{ResponseWriter: w}
+	start := time.Now()
+	h.next.ServeHTTP(recorder, r)
+	duration := time.Since(start)
+
+	fields := h.logger.clientFields(r.RemoteAddr, r.Header.Get("Authorization"))
+	fields["status"] = recorder.statusCode()
+	responses := make(map[string]*jsonrpcMessage)
+	if !recorder.truncated {
+		for _, response := range decodeJSONRPCMessages(recorder.body.Bytes()) {
+			responses[string(response.ID)] = response
+		}
+	}
+	calls := 0
+	for _, request := range decodeJSONRPCMessages(body) {
+		if request.Method == "" {
+			continue
+		}
+		h.logger.logCall(fields, request, responses[string(request.ID)], duration)
+		calls++
+	}
+	if calls == 0 {
+		h.logger.logHTTPRequest(fields, r, duration)
+	}
+}
@@ -0,0 +1,394 @@
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maximum number of requests of a connection which are waiting for their response to be logged
+	requestLogMaxPending = 1024
+	// maximum size of a ws or ipc message which is inspected, logging of the connection stops at larger messages
+	requestLogMaxMessageSize = 16 * 1024 * 1024
+	// maximum size of the http response of a websocket handshake
+	wsMaxHandshakeSize = 64 * 1024
+)
+
+// websocket frame opcodes
+const (
+	wsContinuationFrame = 0x0
+	wsTextFrame         = 0x1
+	wsBinaryFrame       = 0x2
+)
+
+// first reserved bit of a websocket frame, it marks messages compressed by permessage-deflate
+const wsCompressedBit = 0x40
+
+var errMessageTooLarge = errors.New("decompressed message is too large to be logged")
+
+// appended to a message compressed by permessage-deflate, so it is read as a complete deflate stream
+var deflateTail = []byte{0x00, 0x00, 0xff, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff}
+
+// pendingRequest is a request of a connection which waits for its response
+type pendingRequest struct {
+	request *jsonrpcMessage
+	start   time.Time
+}
+
+// connLogger pairs json-rpc requests and responses of a single ws or ipc connection and logs every call
+// once it has been answered. Notifications of subscriptions are not logged.
+type connLogger struct {
+	logger *requestLogger
+	fields logrus.Fields
+
+	lock    sync.Mutex
+	pending map[string]pendingRequest
+}
+
+func (l *requestLogger) newConnLogger(fields logrus.Fields) *connLogger {
+	log.WithFields(fields).Info("RPC connection opened")
+	return &connLogger{logger: l, fields: fields, pending: make(map[string]pendingRequest)}
+}
+
+// requests registers json-rpc requests of a message which has been read from the client
+func (c *connLogger) requests(message []byte) {
+	now := time.Now()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, request := range decodeJSONRPCMessages(message) {
+		if request.Method == "" {
+			continue
+		}
+		id := string(request.ID)
+		if len(request.ID) == 0 || len(c.pending) >= requestLogMaxPending {
+			c.logger.logCall(c.fields, request, nil, 0)
+			continue
+		}
+		if previous, ok := c.pending[id]; ok {
+			c.logger.logCall(c.fields, previous.request, nil, 0)
+		}
+		c.pending[id] = pendingRequest{request: request, start: now}
+	}
+}
+
+// responses logs the calls which are answered by a message which has been written to the client
+func (c *connLogger) responses(message []byte) {
+	now := time.Now()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, response := range decodeJSONRPCMessages(message) {
+		if response.Method != "" {
+			continue
+		}
+		pending, ok := c.pending[string(response.ID)]
+		if !ok {
+			continue
+		}
+		delete(c.pending, string(response.ID))
+		c.logger.logCall(c.fields, pending.request, response, now.Sub(pending.start))
+	}
+}
+
+// close logs the requests which have not been answered before the connection has been closed
+func (c *connLogger) close() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for id, pending := range c.pending {
+		c.logger.logCall(c.fields, pending.request, nil, 0)
+		delete(c.pending, id)
+	}
+	log.WithFields(c.fields).Info("RPC connection closed")
+}
+
+// messageSplitter reassembles messages from the bytes of a stream
+type messageSplitter interface {
+	feed(b []byte)
+}
+
+// loggedConn passes json-rpc messages which are read and written on a ws or ipc connection to its logger.
+// Data is passed through unchanged.
+type loggedConn struct {
+	net.Conn
+	logger    *connLogger
+	in        messageSplitter
+	out       messageSplitter
+	closeOnce sync.Once
+}
+
+func (c *loggedConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.in.feed(b[:n])
+	}
+	return n, err
+}
+
+func (c *loggedConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	if n > 0 {
+		c.out.feed(b[:n])
+	}
+	return n, err
+}
+
+func (c *loggedConn) Close() error {
+	c.closeOnce.Do(c.logger.close)
+	return c.Conn.Close()
+}
+
+// loggedListener wraps accepted ipc connections into logged connections
+type loggedListener struct {
+	net.Listener
+	logger *requestLogger
+}
+
+func (l *loggedListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	remoteAddr := conn.RemoteAddr().String()
+	if remoteAddr == "" {
+		remoteAddr = l.Addr().String()
+	}
+	connLogger := l.logger.newConnLogger(l.logger.clientFields(remoteAddr, ""))
+	return &loggedConn{
+		Conn:   conn,
+		logger: connLogger,
+		in:     &jsonSplitter{onMessage: connLogger.requests},
+		out:    &jsonSplitter{onMessage: connLogger.responses},
+	}, nil
+}
+
+// requestLogWriter wraps the connection which is hijacked by websocket upgrade into a logged connection.
+// Handshakes which are rejected, e.g. by authentication, are logged with their status.
+type requestLogWriter struct {
+	http.ResponseWriter
+	logger *requestLogger
+	fields logrus.Fields
+}
+
+func (w *requestLogWriter) WriteHeader(status int) {
+	if status >= http.StatusBadRequest {
+		log.WithFields(w.fields).WithField("status", status).Info("Rejected RPC connection")
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *requestLogWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errNotHijacker
+	}
+	conn, brw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	connLogger := w.logger.newConnLogger(w.fields)
+	wrapped := &loggedConn{
+		Conn:   conn,
+		logger: connLogger,
+		in:     &wsFrameSplitter{onMessage: connLogger.requests},
+		// http response of the handshake is written to the connection before the first frame
+		out: &wsFrameSplitter{onMessage: connLogger.responses, handshake: true},
+	}
+	// reads must go through the wrapped connection, client does not send data before handshake is complete
+	return wrapped, bufio.NewReadWriter(bufio.NewReader(wrapped), brw.Writer), nil
+}
+
+// jsonSplitter splits a stream of json values, e.g. json-rpc messages of an ipc connection, into single values
+type jsonSplitter struct {
+	onMessage func(message []byte)
+
+	buf      []byte
+	depth    int
+	inString bool
+	escaped  bool
+	failed   bool
+}
+
+func (s *jsonSplitter) feed(b []byte) {
+	for _, c := range b {
+		if s.failed {
+			return
+		}
+		if s.depth == 0 {
+			// json-rpc messages are objects or batches, anything between them is skipped
+			if c == '{' || c == '[' {
+				s.depth = 1
+				s.buf = append(s.buf[:0], c)
+			}
+			continue
+		}
+
+		s.buf = append(s.buf, c)
+		switch {
+		case s.inString:
+			if s.escaped {
+				s.escaped = false
+			} else if c == '\\' {
+				s.escaped = true
+			} else if c == '"' {
+				s.inString = false
+			}
+		case c == '"':
+			s.inString = true
+		case c == '{' || c == '[':
+			s.depth++
+		case c == '}' || c == ']':
+			if s.depth--; s.depth == 0 {
+				s.onMessage(s.buf)
+			}
+		}
+		if len(s.buf) > requestLogMaxMessageSize {
+			log.Debug("RPC message is too large to be logged, logging of the connection is stopped")
+			s.failed, s.buf = true, nil
+		}
+	}
+}
+
+// wsFrameSplitter reassembles text and binary messages from the frames of a websocket stream.
+// Messages compressed by permessage-deflate are decompressed.
+type wsFrameSplitter struct {
+	onMessage func(message []byte)
+	// the stream starts with the http response of the handshake, which is skipped
+	handshake bool
+
+	buf        []byte
+	message    []byte
+	compressed bool
+	failed     bool
+}
+
+func (s *wsFrameSplitter) feed(b []byte) {
+	if s.failed {
+		return
+	}
+	s.buf = append(s.buf, b...)
+	if s.handshake {
+		end := bytes.Index(s.buf, []byte("\r\n\r\n"))
+		if end < 0 {
+			if len(s.buf) > wsMaxHandshakeSize {
+				s.fail()
+			}
+			return
+		}
+		s.buf = s.buf[end+4:]
+		s.handshake = false
+	}
+	for !s.failed {
+		n := s.frame()
+		if n == 0 {
+			break
+		}
+		s.buf = s.buf[n:]
+	}
+	if len(s.buf) == 0 {
+		s.buf = nil
+	}
+}
+
+// frame consumes the first frame of the buffer and returns its size, zero is returned for an incomplete frame
+func (s *wsFrameSplitter) frame() int {
+	if len(s.buf) < 2 {
+		return 0
+	}
+	if s.buf[0]&0x70&^wsCompressedBit != 0 {
+		// other reserved bits are only set by extensions which are not negotiated by the rpc server
+		s.fail()
+		return 0
+	}
+	fin, opcode, compressed := s.buf[0]&0x80 != 0, s.buf[0]&0x0f, s.buf[0]&wsCompressedBit != 0
+	masked, length, pos := s.buf[1]&0x80 != 0, uint64(s.buf[1]&0x7f), 2
+	switch length {
+	case 126:
+		if len(s.buf) < 4 {
+			return 0
+		}
+		length, pos = uint64(binary.BigEndian.Uint16(s.buf[2:])), 4
+	case 127:
+		if len(s.buf) < 10 {
+			return 0
+		}
+		length, pos = binary.BigEndian.Uint64(s.buf[2:]), 10
+	}
+	if length > requestLogMaxMessageSize-uint64(len(s.message)) {
+		s.fail()
+		return 0
+	}
+	var mask []byte
+	if masked {
+		if len(s.buf) < pos+4 {
+			return 0
+		}
+		mask, pos = s.buf[pos:pos+4], pos+4
+	}
+	end := pos + int(length)
+	if len(s.buf) < end {
+		return 0
+	}
+
+	payload := s.buf[pos:end]
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	switch opcode {
+	case wsTextFrame, wsBinaryFrame:
+		s.message = append(s.message[:0], payload...)
+		s.compressed = compressed
+	case wsContinuationFrame:
+		s.message = append(s.message, payload...)
+	default:
+		// control frames
+		return end
+	}
+	if fin {
+		message := s.message
+		if s.compressed {
+			var err error
+			if message, err = inflateMessage(s.message); err != nil {
+				s.fail()
+				return 0
+			}
+		}
+		s.onMessage(message)
+		s.message = s.message[:0]
+	}
+	return end
+}
+
+// inflateMessage decompresses a message of permessage-deflate extension. Messages of the rpc server are
+// compressed without context takeover, so every message is decompressed on its own.
+func inflateMessage(message []byte) ([]byte, error) {
+	reader := flate.NewReader(io.MultiReader(bytes.NewReader(message), bytes.NewReader(deflateTail)))
+	defer reader.Close()
+	inflated, err := ioutil.ReadAll(io.LimitReader(reader, requestLogMaxMessageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(inflated) > requestLogMaxMessageSize {
+		return nil, errMessageTooLarge
+	}
+	return inflated, nil
+}
+
+func (s *wsFrameSplitter) fail() {
+	log.Debug("RPC message is too large or unsupported, logging of the connection is stopped")
+	s.failed, s.buf, s.message = true, nil, nil
+}
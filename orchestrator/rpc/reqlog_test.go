@@ -0,0 +1,222 @@
+package rpc
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+type loginService struct{}
+
+func (s *loginService) Login(credentials map[string]string) bool {
+	return credentials["user"] != ""
+}
+
+// waitForLog waits until the log entry is written by the connection goroutines of a server
+func waitForLog(t *testing.T, hook *logTest.Hook, want string) {
+	for i := 0; i < 100; i++ {
+		for _, entry := range hook.AllEntries() {
+			if msg, err := entry.String(); err == nil && strings.Contains(msg, want) {
+				return
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("log %q has not been written", want)
+}
+
+func TestParseRequestLogConfig(t *testing.T) {
+	config, err := ParseRequestLogConfig([]string{"http", "IPC"}, RequestLogFull)
+	require.NoError(t, err)
+	assert.DeepEqual(t, RequestLogConfig{HTTP: true, IPC: true, Full: true}, config)
+	assert.Equal(t, true, config.logger(transportWS) == nil)
+	assert.Equal(t, transportHTTP, config.logger(transportHTTP).transport)
+
+	_, err = ParseRequestLogConfig([]string{"grpc"}, RequestLogBasic)
+	assert.ErrorContains(t, "unknown rpc log transport", err)
+	config, err = ParseRequestLogConfig(nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, false, config.Full)
+	_, err = ParseRequestLogConfig(nil, "verbose")
+	assert.ErrorContains(t, "unknown rpc log verbosity", err)
+}
+
+func TestRedaction(t *testing.T) {
+	token := "eyJhbGciOiJIUzI1NiJ9.eyJpYXQiOjF9.c2lnbmF0dXJl"
+	assert.Equal(t,
+		`[{"api_token":"REDACTED","nested":[{"Password":"REDACTED","user":"alice"}],"note":"REDACTED"}]`,
+		redactJSON([]byte(`[{"nested":[{"Password":"hunter2","user":"alice"}],"api_token":"abc","note":"`+token+`"}]`)))
+	assert.Equal(t, `"REDACTED"`, redactJSON([]byte(`"Bearer abc.def"`)))
+	assert.Equal(t, "Bearer REDACTED", redactAuthorization("Bearer "+token))
+	assert.Equal(t, "query=%7B+slot+%7D&token=REDACTED", redactQuery(map[string][]string{
+		"token": {"abc"}, "query": {"{ slot }"}}))
+	assert.Equal(t, requestLogMaxValueSize+3, len(redactString(strings.Repeat("a", 2*requestLogMaxValueSize))))
+}
+
+func TestRequestLogHandler(t *testing.T) {
+	hook := logTest.NewGlobal()
+	apis := []rpc.API{{Namespace: "logtest", Version: "1.0", Service: &loginService{}, Public: true}}
+	srv := rpc.NewServer()
+	require.NoError(t, RegisterApisFromWhitelist(apis, nil, srv, false))
+	config := RequestLogConfig{HTTP: true, Full: true}
+	handler := newRequestLogHandler(config.logger(transportHTTP), srv)
+
+	request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[
+		{"jsonrpc":"2.0","id":1,"method":"logtest_login","params":[{"user":"alice","password":"hunter2"}]},
+		{"jsonrpc":"2.0","id":2,"method":"logtest_unknown","params":[]}
+	]`))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer secret-token")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	require.LogsContain(t, hook, "method=logtest_login")
+	require.LogsContain(t, hook, `{\"password\":\"REDACTED\",\"user\":\"alice\"}`)
+	require.LogsContain(t, hook, "result=true")
+	require.LogsContain(t, hook, "does not exist")
+	require.LogsContain(t, hook, "Bearer REDACTED")
+	require.LogsDoNotContain(t, hook, "hunter2")
+	require.LogsDoNotContain(t, hook, "secret-token")
+
+	// requests which are not json-rpc calls are logged by their path
+	hook.Reset()
+	handler = newRequestLogHandler(config.logger(transportHTTP), http.NotFoundHandler())
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/graphql?query=x&jwt=abc", nil))
+	require.LogsContain(t, hook, "path=/graphql")
+	require.LogsContain(t, hook, "status=404")
+	require.LogsContain(t, hook, "jwt=REDACTED")
+}
+
+func TestJSONSplitter(t *testing.T) {
+	var messages []string
+	splitter := &jsonSplitter{onMessage: func(message []byte) {
+		messages = append(messages, string(message))
+	}}
+	stream := `{"a":"}\"{"}` + "\n" + `[1,{"b":[2]}]{"c":3}`
+	for i := 0; i < len(stream); i++ {
+		splitter.feed([]byte{stream[i]})
+	}
+	assert.DeepEqual(t, []string{`{"a":"}\"{"}`, `[1,{"b":[2]}]`, `{"c":3}`}, messages)
+}
+
+// wsFrame encodes a websocket frame, frames of clients are masked
+func wsFrame(fin bool, opcode byte, payload []byte, mask []byte) []byte {
+	frame := []byte{opcode, 0}
+	if fin {
+		frame[0] |= 0x80
+	}
+	switch {
+	case len(payload) < 126:
+		frame[1] = byte(len(payload))
+	case len(payload) <= 0xffff:
+		frame[1] = 126
+		frame = append(frame, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(len(payload)))
+	}
+	if mask == nil {
+		return append(frame, payload...)
+	}
+	frame[1] |= 0x80
+	frame = append(frame, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	return frame
+}
+
+func TestWSFrameSplitter(t *testing.T) {
+	var messages []string
+	splitter := &wsFrameSplitter{handshake: true, onMessage: func(message []byte) {
+		messages = append(messages, string(message))
+	}}
+	long := strings.Repeat("x", 300)
+	var stream []byte
+	stream = append(stream, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\n\r\n"...)
+	stream = append(stream, wsFrame(true, wsTextFrame, []byte(`{"id":1}`), nil)...)
+	stream = append(stream, wsFrame(false, wsTextFrame, []byte(`{"a":"`), []byte{1, 2, 3, 4})...)
+	stream = append(stream, wsFrame(true, 0x9, []byte("ping"), nil)...)
+	stream = append(stream, wsFrame(true, wsContinuationFrame, []byte(long+`"}`), []byte{5, 6, 7, 8})...)
+	for i := 0; i < len(stream); i += 7 {
+		end := i + 7
+		if end > len(stream) {
+			end = len(stream)
+		}
+		splitter.feed(stream[i:end])
+	}
+	assert.DeepEqual(t, []string{`{"id":1}`, `{"a":"` + long + `"}`}, messages)
+
+	// messages compressed by permessage-deflate are decompressed
+	compressed := wsFrame(true, wsTextFrame, deflate(t, `{"id":2,"result":"`+long+`"}`), nil)
+	compressed[0] |= wsCompressedBit
+	splitter.feed(compressed)
+	assert.Equal(t, `{"id":2,"result":"`+long+`"}`, messages[len(messages)-1])
+
+	// frames of other extensions are not supported
+	splitter.feed([]byte{0xa1, 0})
+	assert.Equal(t, true, splitter.failed)
+}
+
+// deflate compresses a message like permessage-deflate extension
+func deflate(t *testing.T, message string) []byte {
+	var buf bytes.Buffer
+	writer, err := flate.NewWriter(&buf, flate.BestSpeed)
+	require.NoError(t, err)
+	_, err = writer.Write([]byte(message))
+	require.NoError(t, err)
+	require.NoError(t, writer.Flush())
+	return bytes.TrimSuffix(buf.Bytes(), []byte{0x00, 0x00, 0xff, 0xff})
+}
+
+func TestRequestLog_WebsocketAndIPC(t *testing.T) {
+	hook := logTest.NewGlobal()
+	config := RequestLogConfig{WS: true, IPC: true, Full: true}
+	apis := []rpc.API{{Namespace: "logtest", Version: "1.0", Service: &loginService{}, Public: true}}
+	credentials := map[string]string{"user": "alice", "secret": "hunter2"}
+
+	srv := newHTTPServer(rpc.DefaultHTTPTimeouts)
+	require.NoError(t, srv.enableWS(apis, wsConfig{Origins: []string{"*"}, requestLog: config.logger(transportWS)}))
+	require.NoError(t, srv.setListenAddr("localhost", 0))
+	require.NoError(t, srv.start())
+	defer srv.stop()
+
+	client, err := rpc.Dial("ws://" + srv.listenAddr())
+	require.NoError(t, err)
+	var ok bool
+	require.NoError(t, client.Call(&ok, "logtest_login", credentials))
+	assert.Equal(t, true, ok)
+	client.Close()
+	waitForLog(t, hook, "transport=ws")
+	waitForLog(t, hook, "RPC connection closed")
+	require.LogsContain(t, hook, `{\"secret\":\"REDACTED\",\"user\":\"alice\"}`)
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	hook.Reset()
+	ipc := newIPCServer(filepath.Join(t.TempDir(), "orchestrator.ipc"))
+	ipc.requestLog = config.logger(transportIPC)
+	require.NoError(t, ipc.start(apis))
+	defer ipc.stop()
+
+	client, err = rpc.Dial(ipc.endpoint)
+	require.NoError(t, err)
+	defer client.Close()
+	require.NoError(t, client.Call(&ok, "logtest_login", credentials))
+	waitForLog(t, hook, "result=true")
+	require.LogsContain(t, hook, "transport=ipc")
+	require.LogsContain(t, hook, "method=logtest_login")
+	require.LogsDoNotContain(t, hook, "hunter2")
+}
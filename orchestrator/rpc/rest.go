@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/events"
+	"github.com/pkg/errors"
+)
+
+// path under which the REST api is served
+const restPathPrefix = "/orchestrator/v1/"
+
+// restError is the body of a failed REST response
+type restError struct {
+	Error string `json:"error"`
+}
+
+// restHandler serves a read only REST api which maps plain HTTP GET requests onto the `orc` json-rpc api:
+//
+//	GET /orchestrator/v1/epochs/{epoch}/consensus-info
+//	GET /orchestrator/v1/blocks/{hash}/status
+//	GET /orchestrator/v1/slots/{slot}/status
+//	GET /orchestrator/v1/slots/{slot}/mismatch-evidence
+//	GET /orchestrator/v1/finalized-head
+//	GET /orchestrator/v1/verification-progress
+type restHandler struct {
+	api *events.PublicFilterAPI
+}
+
+func newRESTHandler(api *events.PublicFilterAPI) http.Handler {
+	return &restHandler{api: api}
+}
+
+func (h *restHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeRESTError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+		return
+	}
+	ctx := r.Context()
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, restPathPrefix), "/"), "/")
+
+	switch {
+	case len(parts) == 3 && parts[0] == "epochs" && parts[2] == "consensus-info":
+		epoch, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			writeRESTError(w, http.StatusBadRequest, fmt.Errorf("invalid epoch %q", parts[1]))
+			return
+		}
+		page, err := h.api.GetMinimalConsensusInfoRange(ctx, epoch, epoch, nil, nil)
+		if err != nil {
+			writeRESTError(w, restErrorStatus(err, http.StatusInternalServerError), err)
+			return
+		}
+		if len(page.ConsensusInfos) == 0 {
+			writeRESTError(w, http.StatusNotFound, fmt.Errorf("no consensus info found for epoch %d", epoch))
+			return
+		}
+		writeRESTResponse(w, page.ConsensusInfos[0])
+
+	case len(parts) == 3 && parts[0] == "blocks" && parts[2] == "status":
+		hash, err := hexutil.Decode(parts[1])
+		if err != nil || len(hash) != common.HashLength {
+			writeRESTError(w, http.StatusBadRequest, fmt.Errorf("invalid block hash %q", parts[1]))
+			return
+		}
+		blockHash := common.BytesToHash(hash)
+		h.serveBlockStatus(w, r, &events.BlockStatusRequest{Hash: &blockHash})
+
+	case len(parts) == 3 && parts[0] == "slots" && parts[2] == "status":
+		slot, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			writeRESTError(w, http.StatusBadRequest, fmt.Errorf("invalid slot %q", parts[1]))
+			return
+		}
+		h.serveBlockStatus(w, r, &events.BlockStatusRequest{Slot: &slot})
+
+	case len(parts) == 3 && parts[0] == "slots" && parts[2] == "mismatch-evidence":
+		slot, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			writeRESTError(w, http.StatusBadRequest, fmt.Errorf("invalid slot %q", parts[1]))
+			return
+		}
+		evidence, err := h.api.MismatchEvidence(ctx, slot)
+		if err != nil {
+			writeRESTError(w, restErrorStatus(err, http.StatusNotFound), err)
+			return
+		}
+		writeRESTResponse(w, evidence)
+
+	case len(parts) == 1 && parts[0] == "finalized-head":
+		head, err := h.api.FinalizedHead(ctx)
+		if err != nil {
+			writeRESTError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeRESTResponse(w, head)
+
+	case len(parts) == 1 && parts[0] == "verification-progress":
+		progress, err := h.api.VerificationProgress(ctx)
+		if err != nil {
+			writeRESTError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeRESTResponse(w, progress)
+
+	default:
+		writeRESTError(w, http.StatusNotFound, fmt.Errorf("unknown path %s", r.URL.Path))
+	}
+}
+
+// serveBlockStatus writes orchestrator status of the requested pandora block
+func (h *restHandler) serveBlockStatus(w http.ResponseWriter, r *http.Request, request *events.BlockStatusRequest) {
+	status, err := h.api.GetBlockStatus(r.Context(), request)
+	if err != nil {
+		writeRESTError(w, restErrorStatus(err, http.StatusInternalServerError), err)
+		return
+	}
+	writeRESTResponse(w, status)
+}
+
+// restErrorStatus returns the http status of a failed request, requests whose parameters have been rejected by
+// the api are bad requests
+func restErrorStatus(err error, status int) int {
+	var invalidParams *events.InvalidParamsError
+	if errors.As(err, &invalidParams) {
+		return http.StatusBadRequest
+	}
+	return status
+}
+
+func writeRESTResponse(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithError(err).Debug("Could not write REST response")
+	}
+}
+
+func writeRESTError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(&restError{Error: err.Error()}); err != nil {
+		log.WithError(err).Debug("Could not write REST error response")
+	}
+}
@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/events"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestRESTHandler(t *testing.T) {
+	backend := &events.MockBackend{
+		ConsensusInfos: []*types.MinimalEpochConsensusInfoV2{{Epoch: 0}, {Epoch: 1}},
+	}
+	handler := newRESTHandler(events.NewPublicFilterAPI(backend, time.Minute))
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+		return recorder
+	}
+
+	recorder := serve(http.MethodGet, restPathPrefix+"epochs/1/consensus-info")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var consensusInfo types.MinimalEpochConsensusInfoV2
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &consensusInfo))
+	assert.Equal(t, uint64(1), consensusInfo.Epoch)
+
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, restPathPrefix+"epochs/5/consensus-info").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, restPathPrefix+"epochs/x/consensus-info").Code)
+
+	hash := common.HexToHash("0x01")
+	recorder = serve(http.MethodGet, restPathPrefix+"blocks/"+hash.Hex()+"/status")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var blockStatus types.PandoraBlockStatus
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &blockStatus))
+	assert.Equal(t, hash, blockStatus.PandoraHeaderHash)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, restPathPrefix+"blocks/0x01/status").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, restPathPrefix+"blocks/"+common.Hash{}.Hex()+"/status").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, restPathPrefix+"slots/18446744073709551615/status").Code)
+
+	recorder = serve(http.MethodGet, restPathPrefix+"slots/7/status")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &blockStatus))
+	assert.Equal(t, uint64(7), blockStatus.Slot)
+
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, restPathPrefix+"slots/7/mismatch-evidence").Code)
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, restPathPrefix+"finalized-head").Code)
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, restPathPrefix+"verification-progress").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, restPathPrefix+"unknown").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodPost, restPathPrefix+"finalized-head").Code)
+}
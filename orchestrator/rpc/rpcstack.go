@@ -3,34 +3,54 @@ package rpc
 import (
 	"compress/gzip"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
+	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
 	"github.com/rs/cors"
 )
 
+// time between two checks of open websocket connections while the server is stopped
+var wsDrainPollPeriod = 50 * time.Millisecond
+
 // httpConfig is the JSON-RPC/HTTP configuration.
 type httpConfig struct {
 	Modules            []string
 	CorsAllowedOrigins []string
 	Vhosts             []string
-	prefix             string // path prefix on which to mount http handler
+	prefix             string         // path prefix on which to mount http handler
+	jwtSecret          []byte         // requests must carry a token signed with this secret when set
+	apiKeys            []APIKey       // requests with a key are served within its methods and rate limit when set
+	rateLimiter        *rateLimiter   // requests over the limit of the client are rejected when set
+	methodMetrics      *methodMetrics // calls of json-rpc methods are measured when set
+	slowThreshold      time.Duration  // requests which take longer are logged when non zero
+	requestLog         *requestLogger // requests are logged when set
+	compression        bool           // responses are compressed with gzip for clients which accept it
 }
 
 // wsConfig is the JSON-RPC/Websocket configuration
 type wsConfig struct {
-	Origins []string
-	Modules []string
-	prefix  string // path prefix on which to mount ws handler
+	Origins     []string
+	Modules     []string
+	prefix      string         // path prefix on which to mount ws handler
+	jwtSecret   []byte         // requests must carry a token signed with this secret when set
+	rateLimiter *rateLimiter   // connection attempts over the limit of the client are rejected when set
+	maxConns    int64          // connections over the limit are rejected, zero disables the limit
+	idleTimeout time.Duration  // connections which send nothing for this duration are closed, zero disables it
+	requestLog  *requestLogger // requests of connections are logged when set
+	compression bool           // permessage-deflate is negotiated with clients which offer it
 }
 
 type rpcHandler struct {
@@ -45,6 +65,11 @@ type httpServer struct {
 	mu       sync.Mutex
 	server   *http.Server
 	listener net.Listener // non-nil when server is running
+	// listening socket opened by another process, e.g. passed by socket activation, it is served on next start
+	inherited net.Listener
+
+	// serves https and wss when set
+	tlsConfig *tls.Config
 
 	// HTTP RPC handler things.
 
@@ -54,6 +79,10 @@ type httpServer struct {
 	// WebSocket handler things.
 	wsConfig  wsConfig
 	wsHandler atomic.Value // *rpcHandler
+	wsConns   int64        // number of open websocket connections, accessed atomically
+
+	// time given to in-flight requests and websocket subscriptions to complete on stop
+	shutdownTimeout time.Duration
 
 	// These are set by setListenAddr.
 	endpoint string
@@ -86,6 +115,40 @@ func (h *httpServer) setListenAddr(host string, port int) error {
 	return nil
 }
 
+// setListener configures the server to serve on a listening socket which has been opened by another process.
+// The listener can only be set while the server isn't running.
+func (h *httpServer) setListener(listener net.Listener) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.listener != nil {
+		return fmt.Errorf("HTTP server already running on %s", h.endpoint)
+	}
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		return err
+	}
+	h.port, err = strconv.Atoi(port)
+	if err != nil {
+		return err
+	}
+	h.inherited, h.host, h.endpoint = listener, host, listener.Addr().String()
+	return nil
+}
+
+// setTLSConfig configures the server to terminate TLS with the given config.
+// The config can only be set while the server isn't running.
+func (h *httpServer) setTLSConfig(config *tls.Config) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.listener != nil {
+		return fmt.Errorf("HTTP server already running on %s", h.endpoint)
+	}
+	h.tlsConfig = config
+	return nil
+}
+
 // listenAddr returns the listening address of the server.
 func (h *httpServer) listenAddr() string {
 	h.mu.Lock()
@@ -115,20 +178,29 @@ func (h *httpServer) start() error {
 		h.server.IdleTimeout = h.timeouts.IdleTimeout
 	}
 
-	// Start the server.
-	listener, err := net.Listen("tcp", h.endpoint)
-	if err != nil {
-		// If the server fails to start, we need to clear out the RPC and WS
-		// configuration so they can be configured another time.
-		h.disableRPC()
-		h.disableWS()
-		return err
+	// Start the server, an inherited socket is served instead of opening a new one.
+	listener := h.inherited
+	h.inherited = nil
+	if listener == nil {
+		var err error
+		if listener, err = net.Listen("tcp", h.endpoint); err != nil {
+			// If the server fails to start, we need to clear out the RPC and WS
+			// configuration so they can be configured another time.
+			h.disableRPC()
+			h.disableWS()
+			return err
+		}
+	}
+	httpScheme, wsScheme := "http", "ws"
+	if h.tlsConfig != nil {
+		listener = tls.NewListener(listener, h.tlsConfig)
+		httpScheme, wsScheme = "https", "wss"
 	}
 	h.listener = listener
 	go h.server.Serve(listener)
 
 	if h.wsAllowed() {
-		url := fmt.Sprintf("ws://%v", listener.Addr())
+		url := fmt.Sprintf("%s://%v", wsScheme, listener.Addr())
 		if h.wsConfig.prefix != "" {
 			url += h.wsConfig.prefix
 		}
@@ -140,6 +212,7 @@ func (h *httpServer) start() error {
 	}
 	// Log http endpoint.
 	log.WithField("endpoint", listener.Addr()).WithField(
+		"tls", h.tlsConfig != nil).WithField(
 		"prefix", h.httpConfig.prefix).WithField(
 		"cors", strings.Join(h.httpConfig.CorsAllowedOrigins, ",")).WithField(
 		"vhosts", strings.Join(h.httpConfig.Vhosts, ",")).Info("HTTP server started")
@@ -155,7 +228,7 @@ func (h *httpServer) start() error {
 		name := h.handlerNames[path]
 		if !logged[name] {
 			log.WithField("server", name).WithField(
-				"url", "http://"+listener.Addr().String()+path).Info("listening on port")
+				"url", httpScheme+"://"+listener.Addr().String()+path).Info("listening on port")
 			logged[name] = true
 		}
 	}
@@ -167,6 +240,23 @@ func (h *httpServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ws := h.wsHandler.Load().(*rpcHandler)
 	if ws != nil && isWebsocket(r) {
 		if checkPath(r, h.wsConfig.prefix) {
+			if h.wsConfig.requestLog != nil {
+				w = &requestLogWriter{
+					ResponseWriter: w,
+					logger:         h.wsConfig.requestLog,
+					fields:         h.wsConfig.requestLog.clientFields(r.RemoteAddr, r.Header.Get("Authorization")),
+				}
+			}
+			// websocket handler blocks until the connection is closed
+			conns := atomic.AddInt64(&h.wsConns, 1)
+			defer atomic.AddInt64(&h.wsConns, -1)
+			if h.wsConfig.maxConns > 0 && conns > h.wsConfig.maxConns {
+				http.Error(w, "too many websocket connections", http.StatusServiceUnavailable)
+				return
+			}
+			if h.wsConfig.idleTimeout > 0 {
+				w = &idleTimeoutWriter{ResponseWriter: w, timeout: h.wsConfig.idleTimeout}
+			}
 			ws.ServeHTTP(w, r)
 		}
 		return
@@ -192,6 +282,16 @@ func (h *httpServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotFound)
 }
 
+// registerHandler mounts the given handler on the given path of the http server. Handlers are served when
+// http-rpc is enabled on the server.
+func (h *httpServer) registerHandler(name, path string, handler http.Handler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.mux.Handle(path, handler)
+	h.handlerNames[path] = name
+}
+
 // checkPath checks whether a given request URL matches a given path prefix.
 func checkPath(r *http.Request, path string) bool {
 	// if no prefix has been specified, request URL must be on root
@@ -227,13 +327,29 @@ func (h *httpServer) stop() {
 }
 
 func (h *httpServer) doStop() {
+	if h.inherited != nil {
+		// inherited socket which has never been served
+		h.inherited.Close()
+		h.inherited = nil
+	}
 	if h.listener == nil {
 		return // not running
 	}
 
-	// Shut down the server.
+	ctx, cancel := context.WithTimeout(context.Background(), h.shutdownTimeout)
+	defer cancel()
+
+	// Stop accepting new connections and wait for in-flight http requests.
+	if err := h.server.Shutdown(ctx); err != nil {
+		log.WithError(err).Warn("In-flight HTTP requests have not completed within shutdown timeout")
+		h.server.Close()
+	}
+	// Hijacked websocket connections are not tracked by http server, so they get the rest of the timeout.
+	h.waitForWebsockets(ctx)
+
+	// Shut down the rpc handlers, remaining websocket connections are closed.
 	httpHandler := h.httpHandler.Load().(*rpcHandler)
-	wsHandler := h.httpHandler.Load().(*rpcHandler)
+	wsHandler := h.wsHandler.Load().(*rpcHandler)
 	if httpHandler != nil {
 		h.httpHandler.Store((*rpcHandler)(nil))
 		httpHandler.server.Stop()
@@ -242,7 +358,6 @@ func (h *httpServer) doStop() {
 		h.wsHandler.Store((*rpcHandler)(nil))
 		wsHandler.server.Stop()
 	}
-	h.server.Shutdown(context.Background())
 	h.listener.Close()
 	log.WithField("endpoint", h.listener.Addr()).Info("HTTP server stopped")
 
@@ -251,6 +366,27 @@ func (h *httpServer) doStop() {
 	h.server, h.listener = nil, nil
 }
 
+// waitForWebsockets waits until all websocket connections are closed by their clients or the context is done.
+func (h *httpServer) waitForWebsockets(ctx context.Context) {
+	if atomic.LoadInt64(&h.wsConns) == 0 {
+		return
+	}
+	ticker := time.NewTicker(wsDrainPollPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if atomic.LoadInt64(&h.wsConns) == 0 {
+				return
+			}
+		case <-ctx.Done():
+			log.WithField("connections", atomic.LoadInt64(&h.wsConns)).
+				Info("Closing websocket connections which are still open after shutdown timeout")
+			return
+		}
+	}
+}
+
 // enableRPC turns on JSON-RPC over HTTP on the server.
 func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	h.mu.Lock()
@@ -266,8 +402,16 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 		return err
 	}
 	h.httpConfig = config
+	var handler http.Handler = srv
+	if config.methodMetrics != nil {
+		handler = newMetricsHandler(config.methodMetrics, config.slowThreshold, handler)
+	}
+	handler = withAuthentication(handler, config, true)
+	if config.requestLog != nil {
+		handler = newRequestLogHandler(config.requestLog, handler)
+	}
 	h.httpHandler.Store(&rpcHandler{
-		Handler: NewHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts),
+		Handler: newHTTPHandlerStack(handler, config.CorsAllowedOrigins, config.Vhosts, config.compression),
 		server:  srv,
 	})
 	return nil
@@ -298,8 +442,18 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 		return err
 	}
 	h.wsConfig = config
+	handler := srv.WebsocketHandler(config.Origins)
+	if config.compression {
+		handler = newCompressedWSHandler(srv, config.Origins)
+	}
+	if len(config.jwtSecret) > 0 {
+		handler = newJWTHandler(config.jwtSecret, handler)
+	}
+	if config.rateLimiter != nil {
+		handler = newRateLimitHandler(config.rateLimiter, handler)
+	}
 	h.wsHandler.Store(&rpcHandler{
-		Handler: srv.WebsocketHandler(config.Origins),
+		Handler: handler,
 		server:  srv,
 	})
 	return nil
@@ -345,9 +499,17 @@ func isWebsocket(r *http.Request) bool {
 
 // NewHTTPHandlerStack returns wrapped http-related handlers
 func NewHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string) http.Handler {
+	return newHTTPHandlerStack(srv, cors, vhosts, true)
+}
+
+// newHTTPHandlerStack returns wrapped http-related handlers, responses are only compressed when compression is set
+func newHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string, compression bool) http.Handler {
 	// Wrap the CORS-handler within a host-handler
 	handler := newCorsHandler(srv, cors)
 	handler = newVHostHandler(vhosts, handler)
+	if !compression {
+		return handler
+	}
 	return newGzipHandler(handler)
 }
 
@@ -435,6 +597,7 @@ func (w *gzipResponseWriter) Write(b []byte) (int, error) {
 
 func newGzipHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
 		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
 			next.ServeHTTP(w, r)
 			return
@@ -453,7 +616,10 @@ func newGzipHandler(next http.Handler) http.Handler {
 }
 
 type ipcServer struct {
-	endpoint string
+	endpoint   string
+	perm       os.FileMode    // Applied to the unix socket once opened, zero keeps the default
+	group      string         // Owner group applied to the unix socket once opened
+	requestLog *requestLogger // Requests of connections are logged when set
 
 	mu       sync.Mutex
 	listener net.Listener
@@ -472,11 +638,33 @@ func (is *ipcServer) start(apis []rpc.API) error {
 	if is.listener != nil {
 		return nil // already running
 	}
-	listener, srv, err := rpc.StartIPCEndpoint(is.endpoint, apis)
+	if err := fileutil.ValidateIpcEndpoint(is.endpoint); err != nil {
+		log.WithField("url", is.endpoint).WithField("error", err).Warn("IPC opening failed")
+		return err
+	}
+	srv := rpc.NewServer()
+	for _, api := range apis {
+		if err := srv.RegisterName(api.Namespace, api.Service); err != nil {
+			log.WithField("namespace", api.Namespace).WithField("error", err).Warn("IPC registration failed")
+			return err
+		}
+	}
+	listener, err := ipcListen(is.endpoint)
 	if err != nil {
 		log.WithField("url", is.endpoint).WithField("error", err).Warn("IPC opening failed")
+		srv.Stop()
 		return err
 	}
+	if err := applyIPCPermissions(is.endpoint, is.perm, is.group); err != nil {
+		log.WithField("url", is.endpoint).WithField("error", err).Warn("IPC opening failed")
+		listener.Close()
+		srv.Stop()
+		return err
+	}
+	if is.requestLog != nil {
+		listener = &loggedListener{Listener: listener, logger: is.requestLog}
+	}
+	go srv.ServeListener(listener)
 	log.WithField("url", is.endpoint).Info("IPC endpoint opened")
 	is.listener, is.srv = listener, srv
 	return nil
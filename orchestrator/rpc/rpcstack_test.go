@@ -2,12 +2,15 @@ package rpc
 
 import (
 	"bytes"
+	"compress/gzip"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/gorilla/websocket"
@@ -124,20 +127,23 @@ func TestWebsocketOrigins(t *testing.T) {
 				"http://bad", "https://bad", "http://bad:8540", "https://bad:8540"},
 		},
 	}
-	for _, tc := range tests {
-		srv := createAndStartServer(t, &httpConfig{}, true, &wsConfig{Origins: splitAndTrim(tc.spec)})
-		url := fmt.Sprintf("ws://%v", srv.listenAddr())
-		for _, origin := range tc.expOk {
-			if err := wsRequest(t, url, origin); err != nil {
-				t.Errorf("spec '%v', origin '%v': expected ok, got %v", tc.spec, origin, err)
+	// origins are validated the same way by the handler which negotiates compression
+	for _, compression := range []bool{false, true} {
+		for _, tc := range tests {
+			srv := createAndStartServer(t, &httpConfig{}, true, &wsConfig{Origins: splitAndTrim(tc.spec), compression: compression})
+			url := fmt.Sprintf("ws://%v", srv.listenAddr())
+			for _, origin := range tc.expOk {
+				if err := wsRequest(t, url, origin); err != nil {
+					t.Errorf("spec '%v', origin '%v': expected ok, got %v", tc.spec, origin, err)
+				}
 			}
-		}
-		for _, origin := range tc.expFail {
-			if err := wsRequest(t, url, origin); err == nil {
-				t.Errorf("spec '%v', origin '%v': expected not to allow,  got ok", tc.spec, origin)
+			for _, origin := range tc.expFail {
+				if err := wsRequest(t, url, origin); err == nil {
+					t.Errorf("spec '%v', origin '%v': expected not to allow,  got ok", tc.spec, origin)
+				}
 			}
+			srv.stop()
 		}
-		srv.stop()
 	}
 }
 
@@ -273,3 +279,108 @@ func rpcRequest(t *testing.T, url string, extraHeaders ...string) *http.Response
 	}
 	return resp
 }
+
+type sleepService struct{}
+
+func (s *sleepService) Sleep(ms int) string {
+	time.Sleep(time.Duration(ms) * time.Millisecond)
+	return "done"
+}
+
+// TestHTTPServer_GracefulStop checks that in-flight requests complete and open websocket connections
+// get the shutdown timeout before the server is stopped
+func TestHTTPServer_GracefulStop(t *testing.T) {
+	apis := []rpc.API{{Namespace: "test", Version: "1.0", Service: &sleepService{}, Public: true}}
+	srv := newHTTPServer(rpc.DefaultHTTPTimeouts)
+	srv.shutdownTimeout = time.Second
+	assert.NoError(t, srv.enableRPC(apis, httpConfig{}))
+	assert.NoError(t, srv.enableWS(apis, wsConfig{Origins: []string{"*"}}))
+	assert.NoError(t, srv.setListenAddr("localhost", 0))
+	assert.NoError(t, srv.start())
+	url := "http://" + srv.listenAddr()
+
+	wsConn, _, err := websocket.DefaultDialer.Dial("ws://"+srv.listenAddr(), nil)
+	assert.NoError(t, err)
+	defer wsConn.Close()
+
+	client, err := rpc.Dial(url)
+	assert.NoError(t, err)
+	defer client.Close()
+	result := make(chan error, 1)
+	go func() {
+		var reply string
+		result <- client.Call(&reply, "test_sleep", 300)
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	stopped := time.Now()
+	srv.stop()
+	assert.NoError(t, <-result)
+	// websocket connection is kept open until the shutdown timeout
+	assert.True(t, time.Since(stopped) >= time.Second)
+	_, _, err = wsConn.ReadMessage()
+	assert.Error(t, err)
+}
+
+// TestWebsocketLimits checks that connections over the limit are rejected and idle connections are closed
+func TestWebsocketLimits(t *testing.T) {
+	srv := createAndStartServer(t, &httpConfig{}, true, &wsConfig{
+		Origins:     []string{"*"},
+		maxConns:    1,
+		idleTimeout: 200 * time.Millisecond,
+	})
+	defer srv.stop()
+	url := "ws://" + srv.listenAddr()
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.Error(t, err)
+	if resp != nil {
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	}
+
+	// silent connection is closed by the server
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	start := time.Now()
+	_, _, err = conn.ReadMessage()
+	assert.Error(t, err)
+	assert.True(t, time.Since(start) < 5*time.Second)
+}
+
+func TestCompression(t *testing.T) {
+	srv := createAndStartServer(t, &httpConfig{compression: true}, true, &wsConfig{compression: true})
+	defer srv.stop()
+
+	resp := rpcRequest(t, "http://"+srv.listenAddr(), "accept-encoding", "gzip")
+	assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+	reader, err := gzip.NewReader(resp.Body)
+	assert.NoError(t, err)
+	body, err := ioutil.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"rpc":"1.0"`)
+
+	dialer := websocket.Dialer{EnableCompression: true}
+	conn, wsResp, err := dialer.Dial("ws://"+srv.listenAddr(), nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+	assert.Contains(t, wsResp.Header.Get("Sec-Websocket-Extensions"), "permessage-deflate")
+	assert.NoError(t, conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": "rpc_modules"}))
+	var result struct {
+		Result map[string]string `json:"result"`
+	}
+	assert.NoError(t, conn.ReadJSON(&result))
+	assert.Equal(t, "1.0", result.Result["rpc"])
+
+	// compression is not negotiated when it is disabled
+	plain := createAndStartServer(t, &httpConfig{}, true, &wsConfig{})
+	defer plain.stop()
+	resp = rpcRequest(t, "http://"+plain.listenAddr(), "accept-encoding", "gzip")
+	assert.Equal(t, "", resp.Header.Get("Content-Encoding"))
+	plainConn, wsResp, err := dialer.Dial("ws://"+plain.listenAddr(), nil)
+	assert.NoError(t, err)
+	defer plainConn.Close()
+	assert.Equal(t, "", wsResp.Header.Get("Sec-Websocket-Extensions"))
+}
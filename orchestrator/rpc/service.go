@@ -2,13 +2,28 @@ package rpc
 
 import (
 	"context"
+	"crypto/tls"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	conIface "github.com/lukso-network/lukso-orchestrator/orchestrator/consensus/iface"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/ha"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/admin"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/capabilities"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/debug"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/events"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/dashboard"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/graphql"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain/iface"
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/eventbuffer"
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	"net"
+	"net/http"
+	"os"
 	"sync"
 	"time"
 )
@@ -17,11 +32,58 @@ import (
 type Config struct {
 	ConsensusInfoFeed            iface.ConsensusInfoFeed
 	VerifiedSlotInfoFeed         conIface.VerifiedSlotInfoFeed
+	ProgressReporter             conIface.ProgressReporter
+	Reverifier                   conIface.Reverifier
+	ProposerProvider             iface.ProposerProvider
+	ConfirmationDepth            uint64
 	Db                           db.Database
 	VanguardPendingShardingCache cache.VanguardShardCache
 	PandoraPendingHeaderCache    cache.PandoraHeaderCache
+	// JWTSecret is the shared secret of tokens which http and ws requests must carry, authentication is disabled when empty
+	JWTSecret []byte
+	// APIKeys grant consumers of http rpc access to the allowed methods within their own rate limits. Requests
+	// without api key must carry a jwt token when JWTSecret is set, otherwise they are rejected.
+	APIKeys []APIKey
+	// AllowUnprotected serves http and ws on addresses which other machines can reach without authentication
+	AllowUnprotected bool
+	// TLSConfig terminates tls on http and ws listeners, plain http and ws are served when nil
+	TLSConfig *tls.Config
+	// RateLimit defines per client limits of http requests and ws connection attempts
+	RateLimit RateLimitConfig
+	// NodeStatusProvider collects status of orchestrator node which is served by admin api
+	NodeStatusProvider func(ctx context.Context) *types.NodeStatus
+	// ServiceRestarter restarts a single service of orchestrator node on request of admin api
+	ServiceRestarter func(name, endpoint string) error
+	// Promoter promotes a follower to leader on request of admin api
+	Promoter func() (bool, error)
+	// ShutdownTimeout is the time given to in-flight requests and websocket subscriptions to complete on stop
+	ShutdownTimeout time.Duration
+	// SlowRequestThreshold is the duration above which http rpc requests are logged, zero disables logging
+	SlowRequestThreshold time.Duration
+	// WSMaxConnections is the maximum number of concurrent websocket connections, zero disables the limit
+	WSMaxConnections int
+	// WSMaxSubscriptions is the maximum number of subscriptions of a single connection, zero disables the limit
+	WSMaxSubscriptions int
+	// WSIdleTimeout closes websocket connections which send nothing for the given duration, zero disables it
+	WSIdleTimeout time.Duration
+	// MaxPageSize is the maximum number of items of a single page of range queries, zero keeps the default
+	MaxPageSize int
+	// SubscriptionBuffer defines the buffer of events of a single subscription, zero size keeps the default
+	SubscriptionBuffer eventbuffer.Config
+	// Leadership refuses event subscriptions while this orchestrator is the standby of a high availability pair,
+	// nil serves them always
+	Leadership ha.Leadership
+	// RequestLog selects the transports whose requests are logged for audits
+	RequestLog RequestLogConfig
+	// ReadinessCheck returns an error when orchestrator is not ready to serve traffic
+	ReadinessCheck func() error
+	// SocketActivation serves http and ws on the sockets named http and ws which are passed by systemd socket
+	// activation instead of opening them, so restarts do not refuse connections
+	SocketActivation bool
 	// ipc config
-	IPCPath string
+	IPCPath        string
+	IPCPermissions os.FileMode
+	IPCGroup       string
 	// http config
 	HTTPEnable       bool
 	HTTPHost         string
@@ -31,12 +93,39 @@ type Config struct {
 	HTTPModules      []string
 	HTTPTimeouts     rpc.HTTPTimeouts
 	HTTPPathPrefix   string
+	// HTTPCompression compresses http responses with gzip for clients which accept it
+	HTTPCompression bool
+	// GraphQLEnable serves the graphql endpoint on the http server
+	GraphQLEnable bool
+	// DashboardEnable serves the html status page on the http server
+	DashboardEnable bool
+	// AdminRPCEnabled exposes admin namespace on the http and ws servers next to the public namespaces
+	AdminRPCEnabled bool
 	// WebSocket config
 	WSEnable     bool
 	WSHost       string
 	WSPort       int
 	WSPathPrefix string
 	WSOrigins    []string
+	// WSCompression negotiates permessage-deflate compression of websocket messages with clients which offer it
+	WSCompression bool
+	// Networks are additional networks verified by the same node, each is served in its own namespace
+	Networks []*NetworkConfig
+}
+
+// NetworkConfig defines the feeds and database of an additional network whose filter api is served in the
+// namespace of the network next to the orc namespace of the main network
+type NetworkConfig struct {
+	Namespace                    string
+	ConsensusInfoFeed            iface.ConsensusInfoFeed
+	VerifiedSlotInfoFeed         conIface.VerifiedSlotInfoFeed
+	ProgressReporter             conIface.ProgressReporter
+	Reverifier                   conIface.Reverifier
+	ProposerProvider             iface.ProposerProvider
+	ConfirmationDepth            uint64
+	Db                           db.Database
+	VanguardPendingShardingCache cache.VanguardShardCache
+	PandoraPendingHeaderCache    cache.PandoraHeaderCache
 }
 
 // Service defining an RPC server for a orchestrator node.
@@ -50,11 +139,15 @@ type Service struct {
 
 	backend       *api.Backend
 	config        *Config
-	rpcAPIs       []rpc.API   // List of APIs currently provided by the node
-	http          *httpServer //
-	ws            *httpServer //
-	ipc           *ipcServer  // Stores information about the ipc http server
-	inprocHandler *rpc.Server // In-process RPC request handler to process the API requests
+	rpcAPIs       []rpc.API               // List of APIs currently provided by the node
+	filterAPI     *events.PublicFilterAPI // Shared by `orc` json-rpc namespace and REST api
+	networkAPIs   []rpc.API               // Filter apis of additional networks in their own namespaces
+	http          *httpServer             //
+	ws            *httpServer             //
+	ipc           *ipcServer              // Stores information about the ipc http server
+	rateLimiter   *rateLimiter            // Shared by http and ws servers, nil when rate limiting is disabled
+	methodMetrics *methodMetrics          // Measures http rpc calls, nil when neither metrics nor slow request logging is enabled
+	inprocHandler *rpc.Server             // In-process RPC request handler to process the API requests
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -73,20 +166,74 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 			ConsensusInfoDB:              cfg.Db,
 			VerifiedSlotInfoDB:           cfg.Db,
 			InvalidSlotInfoDB:            cfg.Db,
+			MaintenanceDB:                cfg.Db,
 			PandoraPendingHeaderCache:    cfg.PandoraPendingHeaderCache,
 			VanguardPendingShardingCache: cfg.VanguardPendingShardingCache,
 			VerifiedSlotInfoFeed:         cfg.VerifiedSlotInfoFeed,
+			ProgressReporter:             cfg.ProgressReporter,
+			Reverifier:                   cfg.Reverifier,
+			ProposerProvider:             cfg.ProposerProvider,
+			ConfirmationDepth:            cfg.ConfirmationDepth,
+			NodeStatusProvider:           cfg.NodeStatusProvider,
+			ServiceRestarter:             cfg.ServiceRestarter,
+			Promoter:                     cfg.Promoter,
 		},
 	}
 	// Configure RPC servers.
+	service.filterAPI = newFilterAPI(service.backend, cfg)
+	for _, network := range cfg.Networks {
+		backend := &api.Backend{
+			ConsensusInfoFeed:            network.ConsensusInfoFeed,
+			ConsensusInfoDB:              network.Db,
+			VerifiedSlotInfoDB:           network.Db,
+			InvalidSlotInfoDB:            network.Db,
+			MaintenanceDB:                network.Db,
+			PandoraPendingHeaderCache:    network.PandoraPendingHeaderCache,
+			VanguardPendingShardingCache: network.VanguardPendingShardingCache,
+			VerifiedSlotInfoFeed:         network.VerifiedSlotInfoFeed,
+			ProgressReporter:             network.ProgressReporter,
+			Reverifier:                   network.Reverifier,
+			ProposerProvider:             network.ProposerProvider,
+			ConfirmationDepth:            network.ConfirmationDepth,
+		}
+		service.networkAPIs = append(service.networkAPIs, rpc.API{
+			Namespace: network.Namespace,
+			Version:   capabilities.APIVersion,
+			Service:   newFilterAPI(backend, cfg),
+			Public:    true,
+		})
+	}
 	service.rpcAPIs = service.APIs()
 	service.http = newHTTPServer(rpc.DefaultHTTPTimeouts)
 	service.ws = newHTTPServer(rpc.DefaultHTTPTimeouts)
+	service.http.shutdownTimeout = cfg.ShutdownTimeout
+	service.ws.shutdownTimeout = cfg.ShutdownTimeout
 	service.ipc = newIPCServer(service.config.IPCPath)
+	service.ipc.perm = cfg.IPCPermissions
+	service.ipc.group = cfg.IPCGroup
+	service.ipc.requestLog = cfg.RequestLog.logger(transportIPC)
+	if cfg.RateLimit.enabled() {
+		service.rateLimiter = newRateLimiter(cfg.RateLimit)
+	}
+	if metrics.Enabled() || cfg.SlowRequestThreshold > 0 {
+		service.methodMetrics = newMethodMetrics(service.rpcAPIs)
+	}
 
 	return service, nil
 }
 
+// newFilterAPI creates the filter api of a network with the subscription and page limits of the rpc servers
+func newFilterAPI(backend *api.Backend, cfg *Config) *events.PublicFilterAPI {
+	filterAPI := events.NewPublicFilterAPI(backend, 5*time.Minute)
+	filterAPI.LimitSubscriptions(cfg.WSMaxSubscriptions)
+	filterAPI.LimitPageSize(cfg.MaxPageSize)
+	filterAPI.SetSubscriptionBuffer(cfg.SubscriptionBuffer)
+	if cfg.Leadership != nil {
+		filterAPI.RequireLeadership(cfg.Leadership)
+	}
+	return filterAPI
+}
+
 // Start a consensus info fetcher service's main event loop.
 func (s *Service) Start() {
 	if s.isRunning {
@@ -95,6 +242,7 @@ func (s *Service) Start() {
 	}
 
 	go func() {
+		defer shared.RecoverService(s)
 		// start RPC endpoints
 		err := s.startRPC()
 		if err != nil {
@@ -127,6 +275,16 @@ func (s *Service) Status() error {
 	return nil
 }
 
+// UpdateRateLimit applies new per client limits to running http and ws servers. Rate limiting which has been
+// disabled on startup is not wired into the servers, so it can not be enabled without restarting them.
+func (s *Service) UpdateRateLimit(config RateLimitConfig) error {
+	if s.rateLimiter == nil {
+		return errors.New("rate limiting has been disabled on startup")
+	}
+	s.rateLimiter.update(config)
+	return nil
+}
+
 // configureRPC is a helper method to configure all the various RPC endpoints during node
 // startup. It's not meant to be called at any time afterwards as it makes certain
 // assumptions about the state of the node.
@@ -143,31 +301,83 @@ func (s *Service) startRPC() error {
 		}
 	}
 
+	var activated map[string]net.Listener
+	if s.config.SocketActivation {
+		listeners, err := activatedListeners()
+		if err != nil {
+			return err
+		}
+		defer closeUnusedListeners(listeners, s.http, s.ws)
+		activated = listeners
+	}
+
 	// Configure HTTP.
 	if s.config.HTTPEnable && s.config.HTTPHost != "" {
 		config := httpConfig{
-			CorsAllowedOrigins: nil,
-			Vhosts:             nil,
-			Modules:            nil,
+			CorsAllowedOrigins: s.config.HTTPCors,
+			Vhosts:             s.config.HTTPVirtualHosts,
+			Modules:            s.modules(),
 			prefix:             "",
+			jwtSecret:          s.config.JWTSecret,
+			apiKeys:            s.config.APIKeys,
+			rateLimiter:        s.rateLimiter,
+			methodMetrics:      s.methodMetrics,
+			slowThreshold:      s.config.SlowRequestThreshold,
+			requestLog:         s.config.RequestLog.logger(transportHTTP),
+			compression:        s.config.HTTPCompression,
+		}
+		if err := s.listen(s.http, s.config.HTTPHost, s.config.HTTPPort, transportHTTP, activated,
+			len(config.jwtSecret) > 0 || len(config.apiKeys) > 0); err != nil {
+			return err
 		}
-		if err := s.http.setListenAddr(s.config.HTTPHost, s.config.HTTPPort); err != nil {
+		if err := s.http.setTLSConfig(s.config.TLSConfig); err != nil {
 			return err
 		}
 		if err := s.http.enableRPC(s.rpcAPIs, config); err != nil {
 			return err
 		}
+		s.http.registerHandler("health", healthPath, healthHandler())
+		s.http.registerHandler("readiness", readinessPath, readinessHandler(s.config.ReadinessCheck))
+		s.http.registerHandler("rest", restPathPrefix, s.wrapHTTPHandler(newRESTHandler(s.filterAPI), config))
+		if s.config.GraphQLEnable {
+			handler, err := graphql.New(s.backend, s.config.MaxPageSize)
+			if err != nil {
+				return err
+			}
+			handler = s.wrapHTTPHandler(handler, config)
+			s.http.registerHandler("graphql", graphql.Path, handler)
+			s.http.registerHandler("graphql", graphql.Path+"/schema", handler)
+		}
+		if s.config.DashboardEnable {
+			s.http.registerHandler("dashboard", dashboard.Path, s.wrapHTTPHandler(dashboard.New(s.backend), config))
+		}
 	}
 
 	// Configure WebSocket.
 	if s.config.WSEnable && s.config.WSHost != "" {
-		server := s.wsServerForPort(s.config.WSPort)
+		server, name := s.wsServerForPort(s.config.WSPort), transportWS
+		if activated != nil {
+			// servers are selected by the passed sockets, ws is served next to http when it has no socket
+			server = s.ws
+			if activated[transportWS] == nil {
+				server, name = s.http, transportHTTP
+			}
+		}
 		config := wsConfig{
-			Modules: nil,
-			Origins: []string{"*"},
-			prefix:  "",
+			Modules:     s.modules(),
+			Origins:     []string{"*"},
+			prefix:      "",
+			jwtSecret:   s.config.JWTSecret,
+			rateLimiter: s.rateLimiter,
+			maxConns:    int64(s.config.WSMaxConnections),
+			idleTimeout: s.config.WSIdleTimeout,
+			requestLog:  s.config.RequestLog.logger(transportWS),
+			compression: s.config.WSCompression,
 		}
-		if err := server.setListenAddr(s.config.WSHost, s.config.WSPort); err != nil {
+		if err := s.listen(server, s.config.WSHost, s.config.WSPort, name, activated, len(config.jwtSecret) > 0); err != nil {
+			return err
+		}
+		if err := server.setTLSConfig(s.config.TLSConfig); err != nil {
 			return err
 		}
 		if err := server.enableWS(s.rpcAPIs, config); err != nil {
@@ -186,6 +396,111 @@ func (s *Service) startRPC() error {
 	return nil
 }
 
+// listen configures the listening socket of a server, the socket with the given name is taken from the sockets
+// passed by socket activation when they are given. Servers whose requests are not protected by authentication
+// are only served on loopback addresses unless it is allowed explicitly.
+func (s *Service) listen(server *httpServer, host string, port int, name string, activated map[string]net.Listener,
+	protected bool) error {
+	if activated == nil {
+		if err := s.checkExposure(name, host, protected); err != nil {
+			return err
+		}
+		return server.setListenAddr(host, port)
+	}
+	listener, ok := activated[name]
+	if !ok {
+		return errors.Errorf("no socket named %s has been passed by socket activation", name)
+	}
+	if activatedHost, _, err := net.SplitHostPort(listener.Addr().String()); err == nil {
+		if err := s.checkExposure(name, activatedHost, protected); err != nil {
+			return err
+		}
+	}
+	log.WithField("name", name).WithField("addr", listener.Addr()).Info("Using socket passed by socket activation")
+	return server.setListener(listener)
+}
+
+// closeUnusedListeners closes the activated sockets which are not served by any of the servers
+func closeUnusedListeners(listeners map[string]net.Listener, servers ...*httpServer) {
+	used := make(map[net.Listener]bool)
+	for _, server := range servers {
+		server.mu.Lock()
+		used[server.listener] = true
+		used[server.inherited] = true
+		server.mu.Unlock()
+	}
+	for name, listener := range listeners {
+		if !used[listener] {
+			log.WithField("name", name).Warn("Closing unused socket passed by socket activation")
+			listener.Close()
+		}
+	}
+}
+
+// closeListeners closes all the given listeners
+func closeListeners(listeners map[string]net.Listener) {
+	for _, listener := range listeners {
+		listener.Close()
+	}
+}
+
+// wrapHTTPHandler wraps REST and graphql apis with the same authentication, rate limiting, request logging,
+// CORS and virtual host checks and compression as json-rpc over http
+func (s *Service) wrapHTTPHandler(handler http.Handler, config httpConfig) http.Handler {
+	handler = withAuthentication(handler, config, false)
+	if config.requestLog != nil {
+		handler = newRequestLogHandler(config.requestLog, handler)
+	}
+	return newHTTPHandlerStack(handler, config.CorsAllowedOrigins, config.Vhosts, config.compression)
+}
+
+// modules returns the namespaces exposed on the http and ws servers, nil exposes the public namespaces only
+func (s *Service) modules() []string {
+	if !s.config.AdminRPCEnabled {
+		return nil
+	}
+	var modules []string
+	exposed := make(map[string]bool)
+	for _, api := range s.rpcAPIs {
+		// several services can share a namespace, e.g. events and capabilities of `orc`
+		if api.Public && !exposed[api.Namespace] {
+			modules = append(modules, api.Namespace)
+			exposed[api.Namespace] = true
+		}
+	}
+	return append(modules, "admin")
+}
+
+// features returns the features of `orc` namespace which are supported with the configuration of the service
+func (s *Service) features() []string {
+	features := capabilities.DefaultFeatures()
+	if len(s.config.JWTSecret) > 0 {
+		features = append(features, capabilities.FeatureJWTAuth)
+	}
+	if len(s.config.APIKeys) > 0 {
+		features = append(features, capabilities.FeatureAPIKeys)
+	}
+	if s.config.TLSConfig != nil {
+		features = append(features, capabilities.FeatureTLS)
+	}
+	if s.config.HTTPEnable && s.config.HTTPHost != "" {
+		features = append(features, capabilities.FeatureREST)
+		if s.config.GraphQLEnable {
+			features = append(features, capabilities.FeatureGraphQL)
+		}
+		if s.config.DashboardEnable {
+			features = append(features, capabilities.FeatureDashboard)
+		}
+		if s.config.HTTPCompression {
+			features = append(features, capabilities.FeatureHTTPCompression)
+		}
+	}
+	if s.config.WSEnable && s.config.WSHost != "" && s.config.WSCompression {
+		features = append(features, capabilities.FeatureWSCompression)
+	}
+	return features
+}
+
 // startInProc registers all RPC APIs on the inproc server.
 func (s *Service) startInProc() error {
 	for _, api := range s.rpcAPIs {
@@ -222,12 +537,38 @@ func (s *Service) Wait() {
 
 func (s *Service) APIs() []rpc.API {
 	// Append all the local APIs and return
-	return []rpc.API{
+	apis := []rpc.API{
 		{
 			Namespace: "orc",
-			Version:   "1.0",
-			Service:   events.NewPublicFilterAPI(s.backend, 5*time.Minute),
+			Version:   capabilities.APIVersion,
+			Service:   s.filterAPI,
 			Public:    true,
 		},
+		{
+			Namespace: "admin",
+			Version:   "1.0",
+			Service:   admin.NewPrivateAdminAPI(s.backend),
+			Public:    false,
+		},
+		{
+			Namespace: "debug",
+			Version:   "1.0",
+			Service:   debug.NewPrivateDebugAPI(),
+			Public:    false,
+		},
+	}
+	apis = append(apis, s.networkAPIs...)
+	// capabilities report the namespaces which are reachable over http and ws
+	namespaces := make(map[string]string)
+	for _, api := range apis {
+		if api.Public || (s.config.AdminRPCEnabled && api.Namespace == "admin") {
+			namespaces[api.Namespace] = api.Version
+		}
 	}
+	return append(apis, rpc.API{
+		Namespace: "orc",
+		Version:   capabilities.APIVersion,
+		Service:   capabilities.NewPublicCapabilitiesAPI(namespaces, s.features()),
+		Public:    true,
+	})
 }
@@ -2,13 +2,17 @@ package rpc
 
 import (
 	"context"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/consensus"
 	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/capabilities"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain"
 	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/version"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 	"testing"
 )
@@ -17,7 +21,9 @@ func setup(t *testing.T) (*Config, error) {
 	orchestratorDB := testDB.SetupDB(t)
 	consensusInfoFeed, err := vanguardchain.NewService(
 		context.Background(),
+		"",
 		cmd.DefaultVanguardGRPCEndpoint,
+		nil,
 		orchestratorDB,
 		cache.NewVanShardInfoCache(1<<10),
 	)
@@ -28,12 +34,10 @@ func setup(t *testing.T) (*Config, error) {
 	consensusSvr := consensus.New(
 		context.Background(),
 		&consensus.Config{
-			orchestratorDB,
-			orchestratorDB,
-			cache.NewVanShardInfoCache(1 << 10),
-			cache.NewPanHeaderCache(),
-			nil,
-			nil,
+			VerifiedSlotInfoDB:           orchestratorDB,
+			InvalidSlotInfoDB:            orchestratorDB,
+			VanguardPendingShardingCache: cache.NewVanShardInfoCache(1 << 10),
+			PandoraPendingHeaderCache:    cache.NewPanHeaderCache(),
 		})
 
 	return &Config{
@@ -72,3 +76,76 @@ func TestServerStart_Success(t *testing.T) {
 	hook.Reset()
 	assert.NoError(t, rpcService.Stop())
 }
+
+func TestService_Modules(t *testing.T) {
+	config, err := setup(t)
+	require.NoError(t, err)
+
+	rpcService, err := NewService(context.Background(), config)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []string(nil), rpcService.modules())
+
+	config.AdminRPCEnabled = true
+	assert.DeepEqual(t, []string{"orc", "admin"}, rpcService.modules())
+}
+
+func TestService_Capabilities(t *testing.T) {
+	config, err := setup(t)
+	require.NoError(t, err)
+	config.GraphQLEnable = true
+
+	rpcService, err := NewService(context.Background(), config)
+	require.NoError(t, err)
+	require.NoError(t, rpcService.startInProc())
+	defer rpcService.stopInProc()
+	client := rpc.DialInProc(rpcService.inprocHandler)
+	defer client.Close()
+
+	var clientVersion string
+	require.NoError(t, client.Call(&clientVersion, "orc_clientVersion"))
+	assert.Equal(t, version.BuildData(), clientVersion)
+
+	var buildInfo types.BuildInfo
+	require.NoError(t, client.Call(&buildInfo, "orc_buildInfo"))
+	assert.Equal(t, version.SemanticVersion(), buildInfo.Version)
+	assert.Equal(t, version.GitCommit(), buildInfo.GitCommit)
+	assert.Equal(t, version.GoVersion(), buildInfo.GoVersion)
+
+	var result types.Capabilities
+	require.NoError(t, client.Call(&result, "orc_capabilities"))
+	assert.Equal(t, capabilities.APIVersion, result.APIVersion)
+	assert.DeepEqual(t, []string{"1.0", capabilities.APIVersion}, result.SupportedAPIVersions)
+	assert.DeepEqual(t, map[string]string{"orc": capabilities.APIVersion}, result.Namespaces)
+	assert.DeepEqual(t, append(capabilities.DefaultFeatures(), capabilities.FeatureREST, capabilities.FeatureGraphQL),
+		result.Features)
+
+	// methods of events api stay available in the shared namespace
+	var progress *types.VerificationProgress
+	require.NoError(t, client.Call(&progress, "orc_verificationProgress"))
+}
+
+func TestService_Networks(t *testing.T) {
+	config, err := setup(t)
+	require.NoError(t, err)
+	config.Networks = []*NetworkConfig{{
+		Namespace:            "testnet",
+		ConsensusInfoFeed:    config.ConsensusInfoFeed,
+		VerifiedSlotInfoFeed: config.VerifiedSlotInfoFeed,
+		Db:                   testDB.SetupDB(t),
+	}}
+
+	rpcService, err := NewService(context.Background(), config)
+	require.NoError(t, err)
+	require.NoError(t, rpcService.startInProc())
+	defer rpcService.stopInProc()
+	client := rpc.DialInProc(rpcService.inprocHandler)
+	defer client.Close()
+
+	var result types.Capabilities
+	require.NoError(t, client.Call(&result, "orc_capabilities"))
+	assert.DeepEqual(t, map[string]string{"orc": capabilities.APIVersion, "testnet": capabilities.APIVersion},
+		result.Namespaces)
+
+	var progress *types.VerificationProgress
+	require.NoError(t, client.Call(&progress, "testnet_verificationProgress"))
+}
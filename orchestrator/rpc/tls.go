@@ -0,0 +1,30 @@
+package rpc
+
+import (
+	"crypto/tls"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/secrets"
+	"github.com/pkg/errors"
+)
+
+var errIncompleteTLSConfig = errors.New("both tls certificate and tls key must be provided")
+
+// LoadTLSConfig loads PEM encoded certificate and private key which are used by http and ws listeners
+// to serve https and wss. The key may be given as env:NAME reference. It returns nil config when neither file is
+// given.
+func LoadTLSConfig(certFile, keyFile string) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, errIncompleteTLSConfig
+	}
+	cert, err := secrets.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load tls certificate and key")
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
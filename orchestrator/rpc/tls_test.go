@@ -0,0 +1,101 @@
+package rpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+// writeSelfSignedCert writes self-signed certificate of localhost and its private key into dir
+func writeSelfSignedCert(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestLoadTLSConfig(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCert(t, t.TempDir())
+
+	config, err := LoadTLSConfig("", "")
+	require.NoError(t, err)
+	assert.Equal(t, true, config == nil)
+
+	_, err = LoadTLSConfig(certFile, "")
+	assert.ErrorContains(t, errIncompleteTLSConfig.Error(), err)
+
+	_, err = LoadTLSConfig(keyFile, certFile)
+	assert.ErrorContains(t, "could not load tls certificate and key", err)
+
+	config, err = LoadTLSConfig(certFile, keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(config.Certificates))
+}
+
+// TestHTTPServer_TLS checks that http server terminates tls when configured
+func TestHTTPServer_TLS(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCert(t, t.TempDir())
+	config, err := LoadTLSConfig(certFile, keyFile)
+	require.NoError(t, err)
+
+	srv := newHTTPServer(rpc.DefaultHTTPTimeouts)
+	require.NoError(t, srv.enableRPC(nil, httpConfig{}))
+	srv.registerHandler("health", healthPath, healthHandler())
+	require.NoError(t, srv.setListenAddr("localhost", 0))
+	require.NoError(t, srv.setTLSConfig(config))
+	require.NoError(t, srv.start())
+	defer srv.stop()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(mustParseCert(t, config.Certificates[0].Certificate[0]))
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+	resp, err := client.Get("https://" + srv.listenAddr() + healthPath)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// plain http requests are not served
+	resp, err = http.Get("http://" + srv.listenAddr() + healthPath)
+	if err == nil {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+
+	assert.ErrorContains(t, "already running", srv.setTLSConfig(nil))
+}
+
+func mustParseCert(t *testing.T, der []byte) *x509.Certificate {
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
@@ -0,0 +1,164 @@
+package rpc
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
+)
+
+// websocket settings of json-rpc server, same as the ones of go-ethereum websocket handler
+const (
+	wsReadBuffer       = 1024
+	wsWriteBuffer      = 1024
+	wsPingInterval     = 60 * time.Second
+	wsPingWriteTimeout = 5 * time.Second
+	wsMessageSizeLimit = 15 * 1024 * 1024
+)
+
+var wsBufferPool = new(sync.Pool)
+
+// newCompressedWSHandler serves json-rpc over websocket like rpc.Server.WebsocketHandler, but negotiates
+// permessage-deflate compression with clients which offer it. Messages are compressed without context
+// takeover, so memory of a connection does not grow with compression.
+func newCompressedWSHandler(srv *rpc.Server, allowedOrigins []string) http.Handler {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:    wsReadBuffer,
+		WriteBufferSize:   wsWriteBuffer,
+		WriteBufferPool:   wsBufferPool,
+		EnableCompression: true,
+		CheckOrigin:       wsHandshakeValidator(allowedOrigins),
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.WithError(err).Debug("WebSocket upgrade failed")
+			return
+		}
+		conn.SetReadLimit(wsMessageSizeLimit)
+		conn.EnableWriteCompression(true)
+		codec := rpc.NewFuncCodec(conn, conn.WriteJSON, conn.ReadJSON)
+		// ServeCodec blocks until the connection is closed
+		closed := make(chan struct{})
+		go wsPingLoop(conn, closed)
+		srv.ServeCodec(codec, 0)
+		close(closed)
+	})
+}
+
+// wsPingLoop pings the client periodically, so connections are kept alive by proxies and
+// dead clients are detected
+func wsPingLoop(conn *websocket.Conn, closed <-chan struct{}) {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			deadline := time.Now().Add(wsPingWriteTimeout)
+			if err := conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// wsHandshakeValidator returns a handler that verifies the origin during the
+// websocket upgrade process. When a '*' is specified as an allowed origins all
+// connections are accepted.
+func wsHandshakeValidator(allowedOrigins []string) func(*http.Request) bool {
+	origins := make(map[string]struct{})
+	allowAllOrigins := false
+
+	for _, origin := range allowedOrigins {
+		if origin == "*" {
+			allowAllOrigins = true
+		}
+		if origin != "" {
+			origins[strings.ToLower(origin)] = struct{}{}
+		}
+	}
+	// allow localhost if no allowedOrigins are specified.
+	if len(origins) == 0 {
+		origins["http://localhost"] = struct{}{}
+		if hostname, err := os.Hostname(); err == nil {
+			origins["http://"+strings.ToLower(hostname)] = struct{}{}
+		}
+	}
+
+	return func(req *http.Request) bool {
+		// Skip origin verification if no Origin header is present. The origin check
+		// is supposed to protect against browser based attacks. Browsers always set
+		// Origin. Non-browser software can put anything in origin and checking it doesn't
+		// provide additional security.
+		if _, ok := req.Header["Origin"]; !ok {
+			return true
+		}
+		// Verify origin against allow list.
+		origin := strings.ToLower(req.Header.Get("Origin"))
+		if allowAllOrigins || originIsAllowed(origins, origin) {
+			return true
+		}
+		log.WithField("origin", origin).Warn("Rejected WebSocket connection")
+		return false
+	}
+}
+
+func originIsAllowed(allowedOrigins map[string]struct{}, browserOrigin string) bool {
+	for origin := range allowedOrigins {
+		if ruleAllowsOrigin(origin, browserOrigin) {
+			return true
+		}
+	}
+	return false
+}
+
+func ruleAllowsOrigin(allowedOrigin string, browserOrigin string) bool {
+	allowedScheme, allowedHostname, allowedPort, err := parseOriginURL(allowedOrigin)
+	if err != nil {
+		log.WithError(err).WithField("spec", allowedOrigin).Warn("Error parsing allowed origin specification")
+		return false
+	}
+	browserScheme, browserHostname, browserPort, err := parseOriginURL(browserOrigin)
+	if err != nil {
+		log.WithError(err).WithField("origin", browserOrigin).Warn("Error parsing browser 'Origin' field")
+		return false
+	}
+	if allowedScheme != "" && allowedScheme != browserScheme {
+		return false
+	}
+	if allowedHostname != "" && allowedHostname != browserHostname {
+		return false
+	}
+	if allowedPort != "" && allowedPort != browserPort {
+		return false
+	}
+	return true
+}
+
+func parseOriginURL(origin string) (string, string, string, error) {
+	parsedURL, err := url.Parse(strings.ToLower(origin))
+	if err != nil {
+		return "", "", "", err
+	}
+	var scheme, hostname, port string
+	if strings.Contains(origin, "://") {
+		scheme = parsedURL.Scheme
+		hostname = parsedURL.Hostname()
+		port = parsedURL.Port()
+	} else {
+		scheme = ""
+		hostname = parsedURL.Scheme
+		port = parsedURL.Opaque
+		if hostname == "" {
+			hostname = origin
+		}
+	}
+	return scheme, hostname, port, nil
+}
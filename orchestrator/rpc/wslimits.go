@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var errNotHijacker = errors.New("response writer does not support hijacking")
+
+// idleConn closes the underlying connection when nothing has been read from it for the idle timeout
+type idleConn struct {
+	net.Conn
+	lastRead  int64 // unix nano, accessed atomically
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newIdleConn(conn net.Conn, timeout time.Duration) *idleConn {
+	c := &idleConn{Conn: conn, lastRead: time.Now().UnixNano(), done: make(chan struct{})}
+	go c.watch(timeout)
+	return c
+}
+
+func (c *idleConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		atomic.StoreInt64(&c.lastRead, time.Now().UnixNano())
+	}
+	return n, err
+}
+
+func (c *idleConn) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+	return c.Conn.Close()
+}
+
+// watch closes the connection once it has been idle for the given timeout
+func (c *idleConn) watch(timeout time.Duration) {
+	ticker := time.NewTicker(timeout / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			idle := time.Since(time.Unix(0, atomic.LoadInt64(&c.lastRead)))
+			if idle >= timeout {
+				log.WithField("remoteAddr", c.RemoteAddr()).WithField("idle", idle).
+					Debug("Closing idle websocket connection")
+				c.Close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// idleTimeoutWriter wraps the connection which is hijacked by websocket upgrade into idleConn
+type idleTimeoutWriter struct {
+	http.ResponseWriter
+	timeout time.Duration
+}
+
+func (w *idleTimeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errNotHijacker
+	}
+	conn, brw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	wrapped := newIdleConn(conn, w.timeout)
+	// reads must go through the wrapped connection, client does not send data before handshake is complete
+	return wrapped, bufio.NewReadWriter(bufio.NewReader(wrapped), brw.Writer), nil
+}
@@ -0,0 +1,39 @@
+package snapshot
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// header which carries the api key of a http request to orchestrator rpc
+const apiKeyHeader = "X-API-Key"
+
+// apiKeyTransport adds the api key to every request
+type apiKeyTransport struct {
+	apiKey string
+	base   http.RoundTripper
+}
+
+func (t *apiKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(apiKeyHeader, t.apiKey)
+	return t.base.RoundTrip(req)
+}
+
+// Dial connects to rpc of the trusted orchestrator. The api key is sent with every request, it needs a http
+// endpoint and is not sent when it is empty.
+func Dial(ctx context.Context, endpoint, apiKey string) (*rpc.Client, error) {
+	if apiKey == "" {
+		return rpc.DialContext(ctx, endpoint)
+	}
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		return nil, errors.Errorf("api key of snapshot sync needs a http endpoint, got %s", endpoint)
+	}
+	return rpc.DialHTTPWithClient(endpoint, &http.Client{
+		Transport: &apiKeyTransport{apiKey: apiKey, base: http.DefaultTransport},
+	})
+}
@@ -0,0 +1,5 @@
+package snapshot
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "snapshot")
@@ -0,0 +1,230 @@
+// Package snapshot downloads the finalized verification state of a trusted orchestrator into a fresh database,
+// so a new orchestrator continues with live verification from the finalized head of the trusted one instead
+// of verifying both chains from genesis.
+package snapshot
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// number of consensus infos requested by a single page
+	consensusInfoPageSize = 256
+	// number of slots requested by a single page, it is halved while the trusted orchestrator refuses pages of
+	// this size
+	slotPageSize = 256
+	// json-rpc error code of requests with invalid parameters, e.g. a page which holds too many slots
+	invalidParamsCode = -32602
+)
+
+// Caller calls json-rpc methods of the trusted orchestrator, it is implemented by rpc.Client
+type Caller interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// Sync downloads consensus infos and slot statuses up to the finalized head of the trusted orchestrator into
+// the database and stores the latest verified slot of them as verification checkpoint, verification continues
+// after it. Every page is checked to continue the previous one and the downloaded slots must end with the hashes
+// of the finalized head. The database must be fresh, it is left incomplete when an error is returned.
+func Sync(ctx context.Context, remote Caller, d db.Database) (*types.SyncCheckpoint, error) {
+	start := time.Now()
+	var checkpoint *types.SyncCheckpoint
+	if err := remote.CallContext(ctx, &checkpoint, "orc_getSyncCheckpoint"); err != nil {
+		return nil, errors.Wrap(err, "could not get sync checkpoint of trusted orchestrator")
+	}
+	if err := checkCheckpoint(checkpoint); err != nil {
+		return nil, err
+	}
+	head := checkpoint.FinalizedHead
+	log.WithField("finalizedSlot", head.Slot).WithField("finalizedEpoch", head.Epoch).
+		Info("Downloading verification state from trusted orchestrator")
+
+	if err := syncConsensusInfos(ctx, remote, d, head.Epoch); err != nil {
+		return nil, err
+	}
+	latestVerified, err := syncSlots(ctx, remote, d, head)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := d.FinalizeSlotStatuses(0, head.Slot); err != nil {
+		return nil, err
+	}
+	if err := d.SaveLatestFinalizedEpoch(head.Epoch); err != nil {
+		return nil, err
+	}
+	if err := d.SaveLatestFinalizedSlot(head.Slot); err != nil {
+		return nil, err
+	}
+	if err := d.SaveVerificationCheckpoint(latestVerified.Slot, latestVerified.SlotInfo); err != nil {
+		return nil, err
+	}
+	if err := d.SaveChainConfig(checkpoint.ChainConfig); err != nil {
+		return nil, err
+	}
+	log.WithField("finalizedSlot", head.Slot).WithField("finalizedEpoch", head.Epoch).
+		WithField("elapsed", time.Since(start)).Info("Downloaded verification state from trusted orchestrator")
+	return checkpoint, nil
+}
+
+// checkCheckpoint makes sure that the trusted orchestrator follows the same chain and has finalized a slot
+func checkCheckpoint(checkpoint *types.SyncCheckpoint) error {
+	if checkpoint == nil || checkpoint.FinalizedHead == nil || checkpoint.ChainConfig == nil {
+		return errors.New("trusted orchestrator returned an empty sync checkpoint")
+	}
+	network := params.ActiveNetworkConfig()
+	remote := checkpoint.ChainConfig
+	if remote.SlotsPerEpoch != network.SlotsPerEpoch {
+		return errors.Errorf("trusted orchestrator uses %d slots per epoch, this node %d", remote.SlotsPerEpoch,
+			network.SlotsPerEpoch)
+	}
+	if remote.SecondsPerSlot != 0 && network.SecondsPerSlot != 0 && remote.SecondsPerSlot != network.SecondsPerSlot {
+		return errors.Errorf("trusted orchestrator uses %d seconds per slot, this node %d", remote.SecondsPerSlot,
+			network.SecondsPerSlot)
+	}
+	if remote.GenesisTime != 0 && network.GenesisTime != 0 && remote.GenesisTime != network.GenesisTime {
+		return errors.Errorf("trusted orchestrator follows a chain with genesis time %d, this node %d",
+			remote.GenesisTime, network.GenesisTime)
+	}
+	if checkpoint.FinalizedHead.Slot == 0 {
+		return errors.New("trusted orchestrator has not finalized any slot yet")
+	}
+	return nil
+}
+
+// syncConsensusInfos downloads consensus infos of the epochs up to toEpoch, every epoch must follow the
+// previous one
+func syncConsensusInfos(ctx context.Context, remote Caller, d db.Database, toEpoch uint64) error {
+	nextEpoch := uint64(0)
+	var lastStartTime uint64
+	pageSize := consensusInfoPageSize
+	for nextEpoch <= toEpoch {
+		var page *types.ConsensusInfoPage
+		if err := remote.CallContext(ctx, &page, "orc_getMinimalConsensusInfoRange", nextEpoch, toEpoch, &pageSize); err != nil {
+			return errors.Wrapf(err, "could not download consensus infos from epoch %d", nextEpoch)
+		}
+		if page == nil || len(page.ConsensusInfos) == 0 {
+			return errors.Errorf("trusted orchestrator has no consensus info of epoch %d", nextEpoch)
+		}
+		for _, consensusInfo := range page.ConsensusInfos {
+			if consensusInfo.Epoch != nextEpoch {
+				return errors.Errorf("trusted orchestrator returned consensus info of epoch %d, expected epoch %d",
+					consensusInfo.Epoch, nextEpoch)
+			}
+			if nextEpoch > 0 && consensusInfo.EpochStartTime <= lastStartTime {
+				return errors.Errorf("epoch %d starts at %d, not after the previous epoch at %d", nextEpoch,
+					consensusInfo.EpochStartTime, lastStartTime)
+			}
+			if err := d.SaveConsensusInfo(ctx, consensusInfo.ConvertToEpochInfo()); err != nil {
+				return err
+			}
+			lastStartTime = consensusInfo.EpochStartTime
+			nextEpoch++
+			if nextEpoch > toEpoch {
+				break
+			}
+		}
+		log.WithField("epoch", nextEpoch-1).WithField("finalizedEpoch", toEpoch).Debug("Downloaded consensus infos")
+	}
+	return d.SaveLatestEpoch(ctx, toEpoch)
+}
+
+// syncSlots downloads the slot statuses up to the finalized head and returns the verdict of the latest verified
+// slot, which is the finalized head unless no block has been verified in the head slot. Skipped slots are stored
+// by status, undecided slots are not stored and are not verified again. Pages are requested with smaller size
+// when the trusted orchestrator serves fewer slots per request.
+func syncSlots(ctx context.Context, remote Caller, d db.Database, head *types.FinalizedHead) (*types.SlotVerdict, error) {
+	var latestVerified *types.SlotVerdict
+	pageSize := uint64(slotPageSize)
+	for fromSlot := uint64(1); fromSlot <= head.Slot; {
+		toSlot := fromSlot + pageSize - 1
+		if toSlot > head.Slot {
+			toSlot = head.Slot
+		}
+		var blockStatuses []*types.PandoraBlockStatus
+		if err := remote.CallContext(ctx, &blockStatuses, "orc_getSlotStatusRange", fromSlot, toSlot); err != nil {
+			if pageSize > 1 && isInvalidParams(err) {
+				pageSize /= 2
+				log.WithError(err).WithField("pageSize", pageSize).Debug("Requesting smaller pages of slot statuses")
+				continue
+			}
+			return nil, errors.Wrapf(err, "could not download slot statuses from slot %d", fromSlot)
+		}
+		verdicts, err := slotVerdicts(blockStatuses, fromSlot, toSlot)
+		if err != nil {
+			return nil, err
+		}
+		if len(verdicts) > 0 {
+			if err := d.SaveVerificationBatch(verdicts); err != nil {
+				return nil, err
+			}
+		}
+		for _, verdict := range verdicts {
+			if verdict.Status == types.Verified || verdict.Status == types.Finalized {
+				latestVerified = verdict
+			}
+		}
+		log.WithField("slot", toSlot).WithField("finalizedSlot", head.Slot).Debug("Downloaded slot statuses")
+		fromSlot = toSlot + 1
+	}
+
+	// the finalized head has hashes only when its slot has been verified
+	headVerified := head.PandoraHeaderHash != (common.Hash{})
+	switch {
+	case latestVerified == nil:
+		return nil, errors.New("trusted orchestrator has not verified any slot")
+	case headVerified && (latestVerified.Slot != head.Slot ||
+		latestVerified.SlotInfo.PandoraHeaderHash != head.PandoraHeaderHash ||
+		latestVerified.SlotInfo.VanguardBlockHash != head.VanguardBlockHash):
+		return nil, errors.Errorf("downloaded slot %d does not match finalized head of trusted orchestrator", head.Slot)
+	case !headVerified && latestVerified.Slot == head.Slot:
+		return nil, errors.Errorf("downloaded slot %d is verified, but finalized head of trusted orchestrator is not", head.Slot)
+	}
+	return latestVerified, nil
+}
+
+// isInvalidParams tells whether the trusted orchestrator has refused the parameters of a request, e.g. because the
+// requested range exceeds its page size
+func isInvalidParams(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == invalidParamsCode
+}
+
+// slotVerdicts converts the statuses of a page into verdicts which are stored. Statuses must be in ascending
+// order within the requested range and verdicts of a verified or invalid slot must carry both hashes.
+func slotVerdicts(blockStatuses []*types.PandoraBlockStatus, fromSlot, toSlot uint64) ([]*types.SlotVerdict, error) {
+	verdicts := make([]*types.SlotVerdict, 0, len(blockStatuses))
+	nextSlot := fromSlot
+	for _, blockStatus := range blockStatuses {
+		if blockStatus == nil || blockStatus.Slot < nextSlot || blockStatus.Slot > toSlot {
+			return nil, errors.Errorf("trusted orchestrator returned slot statuses out of range [%d, %d]", fromSlot, toSlot)
+		}
+		nextSlot = blockStatus.Slot + 1
+		verdict := &types.SlotVerdict{Slot: blockStatus.Slot, Status: blockStatus.Status}
+		switch blockStatus.Status {
+		case types.Verified, types.Finalized, types.Invalid:
+			if blockStatus.PandoraHeaderHash == (common.Hash{}) || blockStatus.VanguardBlockHash == (common.Hash{}) {
+				return nil, errors.Errorf("trusted orchestrator returned %s slot %d without hashes",
+					blockStatus.Status, blockStatus.Slot)
+			}
+			verdict.SlotInfo = &types.SlotInfo{
+				PandoraHeaderHash: blockStatus.PandoraHeaderHash,
+				VanguardBlockHash: blockStatus.VanguardBlockHash,
+			}
+		case types.Skipped:
+		default:
+			// slots which have never been decided before finality are not verified again
+			continue
+		}
+		verdicts = append(verdicts, verdict)
+	}
+	return verdicts, nil
+}
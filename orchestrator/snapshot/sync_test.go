@@ -0,0 +1,264 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// fakeRemote serves the sync methods of a trusted orchestrator from memory
+type fakeRemote struct {
+	checkpoint     *types.SyncCheckpoint
+	consensusInfos []*types.MinimalEpochConsensusInfoV2
+	blockStatuses  []*types.PandoraBlockStatus
+	// maxSlots is the page size of slot statuses, zero does not limit pages
+	maxSlots uint64
+}
+
+// invalidParamsError is the json-rpc error which orchestrator returns for requests with invalid parameters
+type invalidParamsError struct {
+	message string
+}
+
+func (e *invalidParamsError) Error() string  { return e.message }
+func (e *invalidParamsError) ErrorCode() int { return invalidParamsCode }
+
+func (r *fakeRemote) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	var response interface{}
+	switch method {
+	case "orc_getSyncCheckpoint":
+		response = r.checkpoint
+	case "orc_getMinimalConsensusInfoRange":
+		fromEpoch, toEpoch, pageSize := args[0].(uint64), args[1].(uint64), *args[2].(*int)
+		page := &types.ConsensusInfoPage{}
+		for _, consensusInfo := range r.consensusInfos {
+			if consensusInfo.Epoch >= fromEpoch && consensusInfo.Epoch <= toEpoch && len(page.ConsensusInfos) < pageSize {
+				page.ConsensusInfos = append(page.ConsensusInfos, consensusInfo)
+			}
+		}
+		response = page
+	case "orc_getSlotStatusRange":
+		fromSlot, toSlot := args[0].(uint64), args[1].(uint64)
+		if r.maxSlots > 0 && toSlot-fromSlot+1 > r.maxSlots {
+			return &invalidParamsError{message: fmt.Sprintf("too many slots requested, maximum %d slots", r.maxSlots)}
+		}
+		blockStatuses := make([]*types.PandoraBlockStatus, 0)
+		for _, blockStatus := range r.blockStatuses {
+			if blockStatus.Slot >= fromSlot && blockStatus.Slot <= toSlot {
+				blockStatuses = append(blockStatuses, blockStatus)
+			}
+		}
+		response = blockStatuses
+	default:
+		return fmt.Errorf("method %s not found", method)
+	}
+	// results are passed through json like over rpc
+	enc, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(enc, result)
+}
+
+// newFakeRemote returns a trusted orchestrator which has finalized the given slot of 300 verified slots
+func newFakeRemote(finalizedSlot uint64) *fakeRemote {
+	network := params.ActiveNetworkConfig()
+	remote := &fakeRemote{}
+	for epoch := uint64(0); epoch <= 300/network.SlotsPerEpoch; epoch++ {
+		remote.consensusInfos = append(remote.consensusInfos, &types.MinimalEpochConsensusInfoV2{
+			Epoch:          epoch,
+			ValidatorList:  []string{"0x01"},
+			EpochStartTime: 1000 + epoch*network.SlotsPerEpoch*6,
+		})
+	}
+	for slot := uint64(1); slot <= 300; slot++ {
+		blockStatus := &types.PandoraBlockStatus{
+			Slot:              slot,
+			PandoraHeaderHash: common.BigToHash(big.NewInt(int64(slot))),
+			VanguardBlockHash: common.BigToHash(big.NewInt(int64(slot + 1000))),
+			Status:            types.Verified,
+		}
+		if slot%10 == 0 {
+			blockStatus = &types.PandoraBlockStatus{Slot: slot, Status: types.Skipped}
+		}
+		remote.blockStatuses = append(remote.blockStatuses, blockStatus)
+	}
+	head := remote.blockStatuses[finalizedSlot-1]
+	remote.checkpoint = &types.SyncCheckpoint{
+		FinalizedHead: &types.FinalizedHead{
+			Slot:              finalizedSlot,
+			Epoch:             finalizedSlot / network.SlotsPerEpoch,
+			PandoraHeaderHash: head.PandoraHeaderHash,
+			VanguardBlockHash: head.VanguardBlockHash,
+		},
+		LatestEpoch: 300 / network.SlotsPerEpoch,
+		ChainConfig: &types.ChainConfig{SlotsPerEpoch: network.SlotsPerEpoch},
+	}
+	return remote
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	d := testDB.SetupDB(t)
+	remote := newFakeRemote(287)
+
+	checkpoint, err := Sync(ctx, remote, d)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(287), checkpoint.FinalizedHead.Slot)
+
+	verificationCheckpoint, err := d.VerificationCheckpoint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(287), verificationCheckpoint.Slot)
+	assert.Equal(t, remote.blockStatuses[286].PandoraHeaderHash, verificationCheckpoint.PandoraHeaderHash)
+	assert.Equal(t, uint64(287), d.LatestLatestFinalizedSlot())
+	assert.Equal(t, checkpoint.FinalizedHead.Epoch, d.LatestSavedEpoch())
+
+	status, err := d.SlotStatus(286)
+	require.NoError(t, err)
+	assert.Equal(t, types.Finalized, status)
+	status, err = d.SlotStatus(280)
+	require.NoError(t, err)
+	assert.Equal(t, types.Skipped, status)
+	// slots after the finalized head are verified live
+	status, err = d.SlotStatus(288)
+	require.NoError(t, err)
+	assert.NotEqual(t, types.Finalized, status)
+
+	consensusInfo, err := d.ConsensusInfo(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, remote.consensusInfos[3].EpochStartTime, consensusInfo.EpochStartTime)
+}
+
+// TestSync_SmallerPages checks that slot statuses are downloaded with smaller pages when the trusted orchestrator
+// serves fewer slots per request
+func TestSync_SmallerPages(t *testing.T) {
+	d := testDB.SetupDB(t)
+	remote := newFakeRemote(287)
+	remote.maxSlots = 100
+	_, err := Sync(context.Background(), remote, d)
+	require.NoError(t, err)
+	verificationCheckpoint, err := d.VerificationCheckpoint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(287), verificationCheckpoint.Slot)
+	status, err := d.SlotStatus(150)
+	require.NoError(t, err)
+	assert.Equal(t, types.Skipped, status)
+}
+
+// TestSync_SecondsPerSlot checks that nothing is downloaded from a trusted orchestrator with other slot duration
+func TestSync_SecondsPerSlot(t *testing.T) {
+	defer params.UseNetworkConfig(params.ActiveNetworkConfig())
+	params.UseNetworkConfig(&params.NetworkConfig{SlotsPerEpoch: params.SlotsPerEpoch, SecondsPerSlot: 6})
+
+	d := testDB.SetupDB(t)
+	remote := newFakeRemote(287)
+	remote.checkpoint.ChainConfig.SecondsPerSlot = 12
+	_, err := Sync(context.Background(), remote, d)
+	assert.ErrorContains(t, "trusted orchestrator uses 12 seconds per slot, this node 6", err)
+	assert.Equal(t, uint64(0), d.LatestSavedEpoch())
+	status, err := d.SlotStatus(1)
+	require.NoError(t, err)
+	assert.Equal(t, types.Unknown, status)
+
+	// slot duration which is not known is not compared
+	remote.checkpoint.ChainConfig.SecondsPerSlot = 0
+	_, err = Sync(context.Background(), remote, d)
+	require.NoError(t, err)
+}
+
+// TestSync_SkippedFinalizedSlot checks that verification continues after the latest verified slot when no block
+// has been verified in the finalized slot
+func TestSync_SkippedFinalizedSlot(t *testing.T) {
+	d := testDB.SetupDB(t)
+	_, err := Sync(context.Background(), newFakeRemote(290), d)
+	require.NoError(t, err)
+	verificationCheckpoint, err := d.VerificationCheckpoint()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(289), verificationCheckpoint.Slot)
+}
+
+func TestSync_Discontinuity(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(remote *fakeRemote)
+		wantErr string
+	}{
+		{
+			name: "other slots per epoch",
+			modify: func(remote *fakeRemote) {
+				remote.checkpoint.ChainConfig.SlotsPerEpoch++
+			},
+			wantErr: "slots per epoch",
+		},
+		{
+			name: "nothing finalized",
+			modify: func(remote *fakeRemote) {
+				remote.checkpoint.FinalizedHead = &types.FinalizedHead{}
+			},
+			wantErr: "has not finalized any slot",
+		},
+		{
+			name: "missing epoch",
+			modify: func(remote *fakeRemote) {
+				remote.consensusInfos = append(remote.consensusInfos[:2], remote.consensusInfos[3:]...)
+			},
+			wantErr: "expected epoch 2",
+		},
+		{
+			name: "epoch start goes back",
+			modify: func(remote *fakeRemote) {
+				remote.consensusInfos[2].EpochStartTime = remote.consensusInfos[1].EpochStartTime
+			},
+			wantErr: "not after the previous epoch",
+		},
+		{
+			name: "verified slot without hashes",
+			modify: func(remote *fakeRemote) {
+				remote.blockStatuses[5].PandoraHeaderHash = common.Hash{}
+			},
+			wantErr: "slot 6 without hashes",
+		},
+		{
+			name: "other finalized head",
+			modify: func(remote *fakeRemote) {
+				remote.checkpoint.FinalizedHead.PandoraHeaderHash = common.HexToHash("0x01")
+			},
+			wantErr: "does not match finalized head",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remote := newFakeRemote(287)
+			tt.modify(remote)
+			_, err := Sync(context.Background(), remote, testDB.SetupDB(t))
+			assert.ErrorContains(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestSlotVerdicts(t *testing.T) {
+	blockStatuses := []*types.PandoraBlockStatus{
+		{Slot: 3, Status: types.Skipped},
+		{Slot: 4, Status: types.Pending},
+		{Slot: 5, Status: types.Invalid, PandoraHeaderHash: common.HexToHash("0x05"), VanguardBlockHash: common.HexToHash("0x15")},
+	}
+	verdicts, err := slotVerdicts(blockStatuses, 1, 5)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(verdicts))
+	assert.Equal(t, types.Skipped, verdicts[0].Status)
+	assert.Equal(t, common.HexToHash("0x05"), verdicts[1].SlotInfo.PandoraHeaderHash)
+
+	_, err = slotVerdicts(blockStatuses, 4, 5)
+	assert.ErrorContains(t, "out of range", err)
+	_, err = slotVerdicts([]*types.PandoraBlockStatus{blockStatuses[2], blockStatuses[0]}, 1, 5)
+	assert.ErrorContains(t, "out of range", err)
+}
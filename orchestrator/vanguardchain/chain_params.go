@@ -0,0 +1,27 @@
+package vanguardchain
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// checkChainParams warns when consensus info of vanguard contradicts the chain parameters which orchestrator
+// runs with, which means that they belong to another network. It reports whether the parameters match.
+func checkChainParams(consensusInfo *types.MinimalEpochConsensusInfoV2) bool {
+	config := params.ActiveNetworkConfig()
+	matches := true
+	if uint64(len(consensusInfo.ValidatorList)) != config.SlotsPerEpoch {
+		log.WithField("epoch", consensusInfo.Epoch).WithField("proposers", len(consensusInfo.ValidatorList)).
+			WithField("slotsPerEpoch", config.SlotsPerEpoch).
+			Warn("Number of proposers of vanguard epoch does not match configured slots per epoch")
+		matches = false
+	}
+	slotDuration := uint64(consensusInfo.SlotTimeDuration)
+	if config.SecondsPerSlot != 0 && slotDuration != 0 && slotDuration != config.SecondsPerSlot {
+		log.WithField("epoch", consensusInfo.Epoch).WithField("slotDuration", slotDuration).
+			WithField("secondsPerSlot", config.SecondsPerSlot).
+			Warn("Slot duration of vanguard does not match configured seconds per slot")
+		matches = false
+	}
+	return matches
+}
@@ -0,0 +1,32 @@
+package vanguardchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+// Test_CheckChainParams checks that consensus info of another network is reported
+func Test_CheckChainParams(t *testing.T) {
+	previous := params.ActiveNetworkConfig()
+	defer params.UseNetworkConfig(previous)
+	params.UseNetworkConfig(&params.NetworkConfig{SlotsPerEpoch: params.SlotsPerEpoch, SecondsPerSlot: 6})
+	hook := logTest.NewGlobal()
+
+	consensusInfo := testutil.NewMinimalConsensusInfo(1)
+	assert.Equal(t, true, checkChainParams(consensusInfo))
+	assert.Equal(t, 0, len(hook.AllEntries()))
+
+	consensusInfo.SlotTimeDuration = time.Duration(12)
+	assert.Equal(t, false, checkChainParams(consensusInfo))
+	assert.LogsContain(t, hook, "Slot duration of vanguard does not match configured seconds per slot")
+
+	consensusInfo = testutil.NewMinimalConsensusInfo(1)
+	consensusInfo.ValidatorList = consensusInfo.ValidatorList[:16]
+	assert.Equal(t, false, checkChainParams(consensusInfo))
+	assert.LogsContain(t, hook, "Number of proposers of vanguard epoch does not match configured slots per epoch")
+}
@@ -0,0 +1,50 @@
+package vanguardchain
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	eth "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/grpc"
+)
+
+// beaconChainServer serves canonical blocks of vanguard over grpc
+type beaconChainServer struct {
+	eth.UnimplementedBeaconChainServer
+}
+
+func (s *beaconChainServer) ListBlocks(ctx context.Context, req *eth.ListBlocksRequest) (*eth.ListBlocksResponse, error) {
+	block := testutil.NewBeaconBlock(uint64(req.GetSlot()))
+	return &eth.ListBlocksResponse{BlockContainers: []*eth.BeaconBlockContainer{{
+		Block:     &eth.SignedBeaconBlock{Block: block, Signature: make([]byte, 96)},
+		Canonical: true,
+	}}}, nil
+}
+
+// Test_DialGRPC checks that the generated vanguard clients work with the grpc version of the orchestrator, blocks
+// with pandora shards are requested and decoded over a real grpc connection
+func Test_DialGRPC(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	eth.RegisterBeaconChainServer(server, &beaconChainServer{})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	svc, err := NewService(context.Background(), "", listener.Addr().String(), nil, dbSetup(context.Background(), t, 3), nil)
+	require.NoError(t, err)
+	require.NoError(t, svc.dialConn())
+	defer svc.conn.Close()
+
+	shardInfo, err := svc.ShardInfoBySlot(5)
+	require.NoError(t, err)
+	require.NotNil(t, shardInfo)
+	assert.Equal(t, uint64(5), shardInfo.Slot)
+	assert.DeepEqual(t, testutil.NewBeaconBlock(5).Body.PandoraShard[0].Hash, shardInfo.ShardInfo.Hash)
+}
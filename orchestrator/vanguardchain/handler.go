@@ -3,10 +3,14 @@ package vanguardchain
 import (
 	"context"
 	"errors"
+	"sort"
 
+	"github.com/lukso-network/lukso-orchestrator/shared/tracing"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
 	eth "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/eth/v1alpha1/wrapper"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 // onNewConsensusInfo :
@@ -15,6 +19,7 @@ import (
 func (s *Service) onNewConsensusInfo(ctx context.Context, consensusInfo *types.MinimalEpochConsensusInfoV2) error {
 	nsent := s.consensusInfoFeed.Send(consensusInfo)
 	log.WithField("nsent", nsent).Trace("Send consensus info to subscribers")
+	s.cacheProposers(consensusInfo.Epoch, consensusInfo.ValidatorList)
 
 	if err := s.db.SaveConsensusInfo(ctx, consensusInfo.ConvertToEpochInfo()); err != nil {
 		log.WithError(err).Warn("failed to save consensus info into consensusInfoDB!")
@@ -38,34 +43,112 @@ func (s *Service) onNewConsensusInfo(ctx context.Context, consensusInfo *types.M
 // onNewPendingVanguardBlock
 func (s *Service) onNewPendingVanguardBlock(ctx context.Context, blockInfo *eth.StreamPendingBlockInfo) error {
 	block := blockInfo.Block
+	trace := tracing.StartTrace("slot verification", tracing.Uint("slot", uint64(block.Slot)))
+	cachedShardInfo, err := shardInfoFromBlock(block, uint64(blockInfo.FinalizedSlot), uint64(blockInfo.FinalizedEpoch))
+	if err != nil {
+		trace.Finish(err)
+		return err
+	}
+	cachedShardInfo.Trace = trace
+	trace.Stage("received")
+
+	log.WithField("slot", block.Slot).WithField("panBlockNum", cachedShardInfo.ShardInfo.BlockNumber).
+		WithField("finalizedSlot", blockInfo.FinalizedSlot).WithField("finalizedEpoch", blockInfo.FinalizedEpoch).
+		Info("New vanguard shard info has arrived")
+
+	// shard info is queued before it is handed over, so that it survives a restart while it waits in relay buffers
+	if err := s.db.SavePendingShardInfo(cachedShardInfo); err != nil {
+		log.WithError(err).WithField("slot", block.Slot).Warn("Failed to queue vanguard shard info")
+	}
+	s.vanguardShardingInfoFeed.Send(cachedShardInfo)
+	return nil
+}
+
+// shardInfoFromBlock extracts pandora shard info from vanguard block
+func shardInfoFromBlock(block *eth.BeaconBlock, finalizedSlot, finalizedEpoch uint64) (*types.VanguardShardInfo, error) {
 	blockHash, err := block.HashTreeRoot()
 	if nil != err {
 		log.WithError(err).Warn("failed to retrieve vanguard block hash from HashTreeRoot")
-		return err
+		return nil, err
 	}
 	wrappedPhase0Blk := wrapper.WrappedPhase0BeaconBlock(block)
 	pandoraShards := wrappedPhase0Blk.Body().PandoraShards()
 	if len(pandoraShards) < 1 {
 		// The first value is the sharding info. If not present throw error
 		log.WithField("pandoraShard length", len(pandoraShards)).Error("pandora sharding info not present")
-		return errors.New("invalid shard info length in vanguard block body")
+		return nil, errors.New("invalid shard info length in vanguard block body")
 	}
 
-	shardInfo := pandoraShards[0]
-	cachedShardInfo := &types.VanguardShardInfo{
+	return &types.VanguardShardInfo{
 		Slot:           uint64(block.Slot),
 		BlockHash:      blockHash[:],
-		ShardInfo:      shardInfo,
-		FinalizedSlot:  uint64(blockInfo.FinalizedSlot),
-		FinalizedEpoch: uint64(blockInfo.FinalizedEpoch),
+		ShardInfo:      pandoraShards[0],
+		FinalizedSlot:  finalizedSlot,
+		FinalizedEpoch: finalizedEpoch,
+	}, nil
+}
+
+// ShardInfoBySlot fetches the canonical vanguard block of the given slot and returns its pandora shard info.
+// It is used when pandora header of a slot has arrived but vanguard block has not been streamed.
+func (s *Service) ShardInfoBySlot(slot uint64) (*types.VanguardShardInfo, error) {
+	if s.beaconClient == nil {
+		return nil, errors.New("vanguard client is not connected")
 	}
+	resp, err := s.beaconClient.ListBlocks(s.ctx, &eth.ListBlocksRequest{
+		QueryFilter: &eth.ListBlocksRequest_Slot{Slot: eth2Types.Slot(slot)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, container := range resp.BlockContainers {
+		if container.Block == nil || container.Block.Block == nil || !container.Canonical {
+			continue
+		}
+		return shardInfoFromBlock(container.Block.Block, s.db.LatestLatestFinalizedSlot(), s.db.LatestLatestFinalizedEpoch())
+	}
+	return nil, nil
+}
 
-	log.WithField("slot", block.Slot).WithField("panBlockNum", shardInfo.BlockNumber).
-		WithField("finalizedSlot", blockInfo.FinalizedSlot).WithField("finalizedEpoch", blockInfo.FinalizedEpoch).
-		Info("New vanguard shard info has arrived")
+// ShardInfosByEpoch fetches the canonical vanguard blocks of the given epoch with a single request and returns
+// their pandora shard infos in slot order. Skipped slots are not present in the result.
+func (s *Service) ShardInfosByEpoch(epoch uint64) ([]*types.VanguardShardInfo, error) {
+	if s.beaconClient == nil {
+		return nil, errors.New("vanguard client is not connected")
+	}
+	resp, err := s.beaconClient.ListBlocks(s.ctx, &eth.ListBlocksRequest{
+		QueryFilter: &eth.ListBlocksRequest_Epoch{Epoch: eth2Types.Epoch(epoch)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	finalizedSlot, finalizedEpoch := s.db.LatestLatestFinalizedSlot(), s.db.LatestLatestFinalizedEpoch()
+	shardInfos := make([]*types.VanguardShardInfo, 0, len(resp.BlockContainers))
+	for _, container := range resp.BlockContainers {
+		if container.Block == nil || container.Block.Block == nil || !container.Canonical {
+			continue
+		}
+		shardInfo, err := shardInfoFromBlock(container.Block.Block, finalizedSlot, finalizedEpoch)
+		if err != nil {
+			return nil, err
+		}
+		shardInfos = append(shardInfos, shardInfo)
+	}
+	sort.Slice(shardInfos, func(i, j int) bool {
+		return shardInfos[i].Slot < shardInfos[j].Slot
+	})
+	return shardInfos, nil
+}
 
-	s.vanguardShardingInfoFeed.Send(cachedShardInfo)
-	return nil
+// HeadSlot returns the slot of canonical head block of vanguard chain
+func (s *Service) HeadSlot() (uint64, error) {
+	if s.beaconClient == nil {
+		return 0, errors.New("vanguard client is not connected")
+	}
+	head, err := s.beaconClient.GetChainHead(s.ctx, &emptypb.Empty{})
+	if err != nil {
+		return 0, err
+	}
+	return uint64(head.HeadSlot), nil
 }
 
 // ReSubscribeBlocksEvent method re-subscribe to vanguard block api.
@@ -86,8 +169,7 @@ func (s *Service) ReSubscribeBlocksEvent() error {
 	}
 
 	// Re-subscribe vanguard new pending blocks
-	go s.subscribeVanNewPendingBlockHash(s.ctx, finalizedSlot)
-	go s.subscribeNewConsensusInfoGRPC(s.ctx, finalizedEpoch)
+	s.startSubscriptions(finalizedEpoch, finalizedSlot)
 	return nil
 }
 
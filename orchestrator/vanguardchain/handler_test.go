@@ -1 +1,31 @@
 package vanguardchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	eth "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+)
+
+// Test_OnNewPendingVanguardBlock_Queued checks that a received shard info is queued before it is sent to subscribers,
+// so that it is not lost while it waits to be verified
+func Test_OnNewPendingVanguardBlock_Queued(t *testing.T) {
+	svc, _ := serviceInit(t, 3)
+	shardInfoCh := make(chan *types.VanguardShardInfo, 1)
+	sub := svc.SubscribeShardInfoEvent(shardInfoCh)
+	defer sub.Unsubscribe()
+
+	block := testutil.NewBeaconBlock(5)
+	require.NoError(t, svc.onNewPendingVanguardBlock(context.Background(), &eth.StreamPendingBlockInfo{Block: block}))
+
+	queued, err := svc.db.PendingShardInfos()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(queued))
+	assert.Equal(t, uint64(5), queued[0].Slot)
+	assert.DeepEqual(t, block.Body.PandoraShard[0].Hash, queued[0].ShardInfo.Hash)
+	assert.Equal(t, uint64(5), (<-shardInfoCh).Slot)
+}
@@ -9,9 +9,18 @@ type ConsensusInfoFeed interface {
 	SubscribeMinConsensusInfoEvent(chan<- *types.MinimalEpochConsensusInfoV2) event.Subscription
 }
 
+// ProposerProvider returns proposer assignments which are computed from minimal consensus info
+type ProposerProvider interface {
+	ProposerForSlot(slot uint64) (string, error)
+}
+
 type VanguardService interface {
 	SubscribeShardInfoEvent(chan<- *types.VanguardShardInfo) event.Subscription
 	SubscribeShutdownSignalEvent(chan<- *types.Reorg) event.Subscription
+	SubscribeCanonicalHeadEvent(chan<- *types.CanonicalHead) event.Subscription
 	ReSubscribeBlocksEvent() error
 	StopSubscription()
+	ShardInfoBySlot(slot uint64) (*types.VanguardShardInfo, error)
+	ShardInfosByEpoch(epoch uint64) ([]*types.VanguardShardInfo, error)
+	HeadSlot() (uint64, error)
 }
@@ -0,0 +1,55 @@
+package vanguardchain
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/slotutil"
+	"github.com/pkg/errors"
+)
+
+// maximum number of epochs whose proposer assignments are kept in memory
+var proposerCacheEpochs = uint64(64)
+
+var errProposerUnknown = errors.New("proposer assignment of the slot is not known")
+
+// cacheProposers keeps the proposer assignments of an epoch, so that proposer of a slot is not recomputed
+// for each header. Assignments of old epochs are dropped.
+func (s *Service) cacheProposers(epoch uint64, validatorList []string) {
+	assignments := make([]string, len(validatorList))
+	copy(assignments, validatorList)
+
+	s.proposersLock.Lock()
+	defer s.proposersLock.Unlock()
+	s.proposers[epoch] = assignments
+	for cachedEpoch := range s.proposers {
+		if cachedEpoch+proposerCacheEpochs <= epoch {
+			delete(s.proposers, cachedEpoch)
+		}
+	}
+}
+
+// ProposerForSlot returns public key of the validator which is assigned to propose the given slot.
+// Assignments which are not cached are loaded from consensus info db.
+func (s *Service) ProposerForSlot(slot uint64) (string, error) {
+	epoch := slotutil.ToEpoch(slot)
+
+	s.proposersLock.RLock()
+	assignments, exists := s.proposers[epoch]
+	s.proposersLock.RUnlock()
+
+	if !exists {
+		consensusInfo, err := s.db.ConsensusInfo(s.ctx, epoch)
+		if err != nil {
+			return "", errors.Wrapf(err, "could not load consensus info of epoch %d", epoch)
+		}
+		if consensusInfo == nil {
+			return "", errors.Wrapf(errProposerUnknown, "slot %d", slot)
+		}
+		s.cacheProposers(epoch, consensusInfo.ValidatorList)
+		assignments = consensusInfo.ValidatorList
+	}
+
+	index := slotutil.SlotIndex(slot)
+	if index >= uint64(len(assignments)) {
+		return "", errors.Wrapf(errProposerUnknown, "slot %d", slot)
+	}
+	return assignments[index], nil
+}
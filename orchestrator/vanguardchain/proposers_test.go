@@ -0,0 +1,42 @@
+package vanguardchain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/pkg/errors"
+)
+
+// Test_ProposerForSlot checks proposer lookup from cached assignments and from consensus info db
+func Test_ProposerForSlot(t *testing.T) {
+	s, _ := serviceInit(t, 3)
+
+	validatorList := make([]string, 32)
+	for i := range validatorList {
+		validatorList[i] = fmt.Sprintf("0x%02x", i)
+	}
+	s.cacheProposers(5, validatorList)
+
+	proposer, err := s.ProposerForSlot(5*32 + 7)
+	require.NoError(t, err)
+	assert.Equal(t, "0x07", proposer)
+
+	// epoch 1 is loaded from db and cached
+	proposer, err = s.ProposerForSlot(33)
+	require.NoError(t, err)
+	assert.Equal(t, hexutil.Encode(make([]byte, 48)), proposer)
+	assert.Equal(t, 2, len(s.proposers))
+
+	_, err = s.ProposerForSlot(10 * 32)
+	assert.Equal(t, true, errors.Is(err, errProposerUnknown))
+
+	// assignments of old epochs are dropped
+	s.cacheProposers(proposerCacheEpochs+5, validatorList)
+	_, exists := s.proposers[1]
+	assert.Equal(t, false, exists)
+	_, exists = s.proposers[5]
+	assert.Equal(t, false, exists)
+}
@@ -2,6 +2,7 @@ package vanguardchain
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"math"
@@ -15,6 +16,10 @@ import (
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/backoff"
+	"github.com/lukso-network/lukso-orchestrator/shared/eventbuffer"
+	"github.com/lukso-network/lukso-orchestrator/shared/feed"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
 	"google.golang.org/grpc"
@@ -22,10 +27,22 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-// time to wait before trying to reconnect with the vanguard node.
 var (
-	reConPeriod = 2 * time.Second
-	errDialNil  = errors.New("failed to construct dial options")
+	// delays between attempts to reconnect with the vanguard node
+	reconnectBackoff = backoff.Config{
+		InitialDelay: 2 * time.Second,
+		MaxDelay:     30 * time.Second,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+	// delays between retries of failed grpc calls to the vanguard node
+	grpcRetryBackoff = backoff.Config{
+		InitialDelay: 500 * time.Millisecond,
+		MaxDelay:     6 * time.Minute,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+	errDialNil = errors.New("failed to construct dial options")
 )
 
 // Service
@@ -44,16 +61,24 @@ type Service struct {
 	// vanguard chain related attributes
 	connectedVanguard bool
 	vanGRPCEndpoint   string
+	tlsConfig         *tls.Config // nil for an insecure connection
 	dialOpts          []grpc.DialOption
 	beaconClient      ethpb.BeaconChainClient
 	nodeClient        ethpb.NodeClient
 	conn              *grpc.ClientConn
+	ready             chan struct{}
+	readyOnce         sync.Once
 
 	// subscription
-	consensusInfoFeed        event.Feed
-	scope                    event.SubscriptionScope
-	vanguardShardingInfoFeed event.Feed
-	subscriptionShutdownFeed event.Feed
+	consensusInfoFeed        *feed.Feed
+	vanguardShardingInfoFeed *feed.Feed
+	subscriptionShutdownFeed *feed.Feed
+	canonicalHeadFeed        *feed.Feed
+
+	proposers     map[uint64][]string // epoch -> validator public keys in slot order
+	proposersLock sync.RWMutex
+
+	subscriptionErrs map[string]error // vanguard stream subscriptions which have stopped with an error
 
 	db                  db.Database              // db support
 	shardingInfoCache   cache.VanguardShardCache // lru cache support
@@ -61,10 +86,13 @@ type Service struct {
 	stopEpochInfoSubCh  chan struct{}
 }
 
-// NewService creates new service with vanguard endpoint, vanguard namespace and consensusInfoDB
+// NewService creates new service of the given network, empty for the main network, with vanguard endpoint,
+// vanguard namespace and consensusInfoDB. The connection is secured with tlsConfig unless it is nil.
 func NewService(
 	ctx context.Context,
+	network string,
 	vanGRPCEndpoint string,
+	tlsConfig *tls.Config,
 	db db.Database,
 	cache cache.VanguardShardCache,
 ) (*Service, error) {
@@ -76,10 +104,19 @@ func NewService(
 		ctx:                 ctx,
 		cancel:              cancel,
 		vanGRPCEndpoint:     vanGRPCEndpoint,
+		tlsConfig:           tlsConfig,
 		db:                  db,
 		shardingInfoCache:   cache,
 		stopPendingBlkSubCh: make(chan struct{}),
 		stopEpochInfoSubCh:  make(chan struct{}),
+		ready:               make(chan struct{}),
+		proposers:           make(map[uint64][]string),
+		subscriptionErrs:    make(map[string]error),
+
+		consensusInfoFeed:        feed.New(feed.NetworkName(network, "vanguard/consensusinfo"), (*types.MinimalEpochConsensusInfoV2)(nil), eventbuffer.DefaultConfig),
+		vanguardShardingInfoFeed: feed.New(feed.NetworkName(network, "vanguard/shardinfo"), (*types.VanguardShardInfo)(nil), eventbuffer.DefaultConfig),
+		subscriptionShutdownFeed: feed.New(feed.NetworkName(network, "vanguard/reorg"), (*types.Reorg)(nil), eventbuffer.DefaultConfig),
+		canonicalHeadFeed:        feed.New(feed.NetworkName(network, "vanguard/canonicalhead"), (*types.CanonicalHead)(nil), eventbuffer.DefaultConfig),
 	}, nil
 }
 
@@ -93,9 +130,11 @@ func (s *Service) Start() {
 
 	if err := s.dialConn(); err != nil {
 		log.WithError(err).Error("Could not create connection with vanguard node")
+		s.runError = err
 		return
 	}
 
+	s.isRunning = true
 	go s.run()
 }
 
@@ -103,27 +142,45 @@ func (s *Service) Stop() error {
 	if s.cancel != nil {
 		defer s.cancel()
 	}
-	s.scope.Close()
+	s.consensusInfoFeed.Close()
+	s.vanguardShardingInfoFeed.Close()
+	s.subscriptionShutdownFeed.Close()
+	s.canonicalHeadFeed.Close()
 	if s.conn != nil {
 		s.conn.Close()
 	}
 	return nil
 }
 
+// Status returns an error when vanguard connection could not be created or a stream subscription has stopped,
+// so a dead subscription is not mistaken for an idle chain.
 func (s *Service) Status() error {
-	// Service don't start
-	if !s.isRunning {
-		return nil
-	}
 	// get error from run function
 	if s.runError != nil {
 		return s.runError
 	}
-	return nil
+	// Service don't start
+	if !s.isRunning {
+		return nil
+	}
+	return s.subscriptionError()
+}
+
+// Ready returns a channel which is closed once the first connection with vanguard chain is established
+func (s *Service) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// markReady notifies dependent services that vanguard chain is connected
+func (s *Service) markReady() {
+	s.readyOnce.Do(func() {
+		close(s.ready)
+	})
 }
 
 // run subscribes to all the services for the ETH1.0 chain.
 func (s *Service) run() {
+	defer shared.RecoverService(s)
 
 	s.waitForConnection()
 
@@ -146,56 +203,49 @@ func (s *Service) run() {
 		i--
 	}
 
-	go s.subscribeNewConsensusInfoGRPC(s.ctx, fromEpoch)
-	go s.subscribeVanNewPendingBlockHash(s.ctx, latestFinalizedSlot)
+	s.startSubscriptions(fromEpoch, latestFinalizedSlot)
 }
 
 // waitForConnection waits for a connection with vanguard chain. Until a successful with
 // vanguard chain, it retries again and again.
 func (s *Service) waitForConnection() {
-	if err := s.dialConn(); err != nil {
-		log.WithError(err).Error("Could not create connection with vanguard node during re-subscription")
-		return
-	}
-
-	if _, err := s.beaconClient.GetChainHead(s.ctx, &emptypb.Empty{}); err == nil {
-		log.WithField("vanguardEndpoint", s.vanGRPCEndpoint).Info("Connected vanguard chain")
-		s.connectedVanguard = true
-		return
-	}
-
-	ticker := time.NewTicker(reConPeriod)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			if _, err := s.beaconClient.GetChainHead(s.ctx, &emptypb.Empty{}); err != nil {
-				log.WithField("vanguardEndpoint", s.vanGRPCEndpoint).Warn("Could not connect or subscribe to vanguard chain")
-				continue
-			}
-			s.connectedVanguard = true
-			s.runError = nil
-			log.WithField("vanguardEndpoint", s.vanGRPCEndpoint).Info("Connected vanguard chain")
-			return
-		case <-s.ctx.Done():
-			log.Info("Received cancelled context, closing existing go routine: waitForConnection")
-			return
+	err := backoff.Retry(s.ctx, reconnectBackoff, func() error {
+		if err := s.dialConn(); err != nil {
+			log.WithError(err).Error("Could not create connection with vanguard node")
+			return err
 		}
+		if _, err := s.beaconClient.GetChainHead(s.ctx, &emptypb.Empty{}); err != nil {
+			log.WithField("vanguardEndpoint", s.vanGRPCEndpoint).Warn("Could not connect or subscribe to vanguard chain")
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		log.Info("Received cancelled context, closing existing go routine: waitForConnection")
+		return
 	}
+	s.connectedVanguard = true
+	s.markReady()
+	s.runError = nil
+	log.WithField("vanguardEndpoint", s.vanGRPCEndpoint).Info("Connected vanguard chain")
 }
 
 // SubscribeMinConsensusInfoEvent registers a subscription of ChainHeadEvent.
 func (s *Service) SubscribeMinConsensusInfoEvent(ch chan<- *types.MinimalEpochConsensusInfoV2) event.Subscription {
-	return s.scope.Track(s.consensusInfoFeed.Subscribe(ch))
+	return s.consensusInfoFeed.Subscribe(ch)
 }
 
 func (s *Service) SubscribeShardInfoEvent(ch chan<- *types.VanguardShardInfo) event.Subscription {
-	return s.scope.Track(s.vanguardShardingInfoFeed.Subscribe(ch))
+	return s.vanguardShardingInfoFeed.Subscribe(ch)
+}
+
+// SubscribeCanonicalHeadEvent registers a subscription of vanguard canonical head changes
+func (s *Service) SubscribeCanonicalHeadEvent(ch chan<- *types.CanonicalHead) event.Subscription {
+	return s.canonicalHeadFeed.Subscribe(ch)
 }
 
 func (s *Service) SubscribeShutdownSignalEvent(ch chan<- *types.Reorg) event.Subscription {
-	return s.scope.Track(s.subscriptionShutdownFeed.Subscribe(ch))
+	return s.subscriptionShutdownFeed.Subscribe(ch)
 }
 
 // dialConn method creates connection with vanguard grpc server
@@ -207,14 +257,28 @@ func (s *Service) dialConn() error {
 		return nil
 	}
 
-	grpcAddress, protocol, err := resolveRpcAddressAndProtocol(s.vanGRPCEndpoint, "")
+	c, err := dialGRPC(s.ctx, s.vanGRPCEndpoint, s.tlsConfig)
+	if err != nil {
+		return err
+	}
+
+	s.conn = c
+	s.beaconClient = ethpb.NewBeaconChainClient(c)
+	s.nodeClient = ethpb.NewNodeClient(c)
+
+	return nil
+}
+
+// dialGRPC creates connection with vanguard grpc server on tcp address or unix socket
+func dialGRPC(ctx context.Context, endpoint string, tlsConfig *tls.Config) (*grpc.ClientConn, error) {
+	grpcAddress, protocol, err := resolveRpcAddressAndProtocol(endpoint, "")
 	if nil != err {
-		return nil
+		return nil, err
 	}
 
-	dialOpts := constructDialOptions(math.MaxInt32, "", 32, time.Minute*6)
+	dialOpts := constructDialOptions(math.MaxInt32, tlsConfig, 32, grpcRetryBackoff)
 	if dialOpts == nil {
-		return errDialNil
+		return nil, errDialNil
 	}
 
 	if "unix" == protocol {
@@ -225,34 +289,20 @@ func (s *Service) dialConn() error {
 		dialOpts = append(dialOpts, grpc.WithDialer(dialer))
 	}
 
-	c, err := grpc.DialContext(s.ctx, grpcAddress, dialOpts...)
-	if err != nil {
-		return err
-	}
-
-	s.conn = c
-	s.beaconClient = ethpb.NewBeaconChainClient(c)
-	s.nodeClient = ethpb.NewNodeClient(c)
-
-	return nil
+	return grpc.DialContext(ctx, grpcAddress, dialOpts...)
 }
 
 // constructDialOptions constructs a list of grpc dial options
 func constructDialOptions(
 	maxCallRecvMsgSize int,
-	withCert string,
+	tlsConfig *tls.Config,
 	grpcRetries uint,
-	grpcRetryDelay time.Duration,
+	grpcRetryBackoff backoff.Config,
 	extraOpts ...grpc.DialOption,
 ) []grpc.DialOption {
 	var transportSecurity grpc.DialOption
-	if withCert != "" {
-		creds, err := credentials.NewClientTLSFromFile(withCert, "")
-		if err != nil {
-			log.Errorf("Could not get valid credentials: %v", err)
-			return nil
-		}
-		transportSecurity = grpc.WithTransportCredentials(creds)
+	if tlsConfig != nil {
+		transportSecurity = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
 	} else {
 		transportSecurity = grpc.WithInsecure()
 		log.Warn("You are using an insecure gRPC connection. If you are running your beacon node and " +
@@ -269,7 +319,9 @@ func constructDialOptions(
 		grpc.WithDefaultCallOptions(
 			grpc.MaxCallRecvMsgSize(maxCallRecvMsgSize),
 			grpc_retry.WithMax(grpcRetries),
-			grpc_retry.WithBackoff(grpc_retry.BackoffLinear(grpcRetryDelay)),
+			grpc_retry.WithBackoff(func(attempt uint) time.Duration {
+				return grpcRetryBackoff.Delay(int(attempt))
+			}),
 		),
 	}
 
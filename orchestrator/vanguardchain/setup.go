@@ -27,7 +27,7 @@ func serviceInit(t *testing.T, numberOfElements byte) (*Service, *logTest.Hook)
 
 	testDB := dbSetup(ctx, t, numberOfElements)
 	cache := cache.NewVanShardInfoCache(1024)
-	s, err := NewService(ctx, "127.0.0.1:4000", testDB, cache)
+	s, err := NewService(ctx, "", "127.0.0.1:4000", nil, testDB, cache)
 	require.NoError(t, err)
 
 	s.beaconClient = mockedBeaconClient
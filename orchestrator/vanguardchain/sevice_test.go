@@ -45,7 +45,7 @@ Reorg test scenario:
 //
 //	testDB := testDB.SetupDB(t)
 //	cache := cache.NewVanShardInfoCache(1024)
-//	s, err := NewService(ctx, "127.0.0.1:4000", testDB, cache)
+//	s, err := NewService(ctx, "", "127.0.0.1:4000", nil, testDB, cache)
 //	require.NoError(t, err)
 //
 //	s.beaconClient = mockedBeaconClient
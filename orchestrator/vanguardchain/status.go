@@ -0,0 +1,88 @@
+package vanguardchain
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"sort"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Endpoint returns the grpc endpoint of vanguard node
+func (s *Service) Endpoint() string {
+	return s.vanGRPCEndpoint
+}
+
+// Connected reports whether the service is connected with vanguard node
+func (s *Service) Connected() bool {
+	return s.connectedVanguard
+}
+
+// EndpointStatus reports the connection state and client version of the vanguard node
+func (s *Service) EndpointStatus(ctx context.Context) *types.EndpointStatus {
+	status := &types.EndpointStatus{
+		Name:      "vanguard",
+		Endpoint:  s.vanGRPCEndpoint,
+		Connected: s.connectedVanguard,
+	}
+	if err := s.Status(); err != nil {
+		status.Error = err.Error()
+	}
+	if !s.connectedVanguard || s.nodeClient == nil {
+		return status
+	}
+	nodeVersion, err := s.nodeClient.GetVersion(ctx, &emptypb.Empty{})
+	if err != nil {
+		log.WithError(err).Debug("Could not query vanguard node version")
+		return status
+	}
+	status.Version = nodeVersion.Version
+	return status
+}
+
+// subscriptionError returns the error of the first stream subscription, by name, which has stopped with an error
+func (s *Service) subscriptionError() error {
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	names := make([]string, 0, len(s.subscriptionErrs))
+	for name := range s.subscriptionErrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := s.subscriptionErrs[name]; err != nil {
+			return fmt.Errorf("vanguard %s subscription has stopped: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// setSubscriptionError stores the error which a stream subscription has stopped with, nil clears it
+func (s *Service) setSubscriptionError(name string, err error) {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+	if err == nil {
+		delete(s.subscriptionErrs, name)
+		return
+	}
+	s.subscriptionErrs[name] = err
+}
+
+// CheckEndpoint connects to the vanguard node of the endpoint and returns its client version, without starting
+// the service
+func CheckEndpoint(ctx context.Context, endpoint string, tlsConfig *tls.Config) (string, error) {
+	conn, err := dialGRPC(ctx, endpoint, tlsConfig)
+	if err != nil {
+		return "", errors.Wrap(err, "could not dial vanguard node")
+	}
+	defer conn.Close()
+	nodeVersion, err := ethpb.NewNodeClient(conn).GetVersion(ctx, &emptypb.Empty{})
+	if err != nil {
+		return "", errors.Wrap(err, "could not query vanguard node version")
+	}
+	return nodeVersion.Version, nil
+}
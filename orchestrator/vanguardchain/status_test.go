@@ -0,0 +1,28 @@
+package vanguardchain
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+// Test_Status_StoppedSubscription checks that a subscription which has stopped with an error is reported by
+// service status until it is subscribed again
+func Test_Status_StoppedSubscription(t *testing.T) {
+	s, hook := serviceInit(t, 3)
+	s.isRunning = true
+	require.NoError(t, s.Status())
+
+	s.runSubscription(pendingBlocksSubscription, func() error {
+		return errBlockInfoNil
+	})
+	assert.ErrorContains(t, "vanguard pendingBlocks subscription has stopped", s.Status())
+	require.LogsContain(t, hook, "Vanguard subscription has stopped")
+
+	s.runSubscription(pendingBlocksSubscription, func() error {
+		require.NoError(t, s.Status())
+		return nil
+	})
+	require.NoError(t, s.Status())
+}
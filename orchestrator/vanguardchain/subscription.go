@@ -5,21 +5,57 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 	"github.com/pkg/errors"
 	eth2Types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 var (
 	errConsensusInfoNil       = errors.New("Incoming consensus info is nil")
 	errBlockInfoNil           = errors.New("Incoming block info is nil")
+	errChainHeadNil           = errors.New("Incoming chain head is nil")
 	errInvalidValidatorLength = errors.New("Incoming consensus info's validator list is invalid")
 	errConsensusInfoProcess   = errors.New("Could not process minimal consensus info")
 )
 
+// names of vanguard stream subscriptions
+const (
+	consensusInfoSubscription = "consensusInfo"
+	pendingBlocksSubscription = "pendingBlocks"
+	canonicalHeadSubscription = "canonicalHead"
+)
+
+// startSubscriptions subscribes to consensus info from the given epoch, pending blocks from the given slot
+// and canonical head of vanguard chain
+func (s *Service) startSubscriptions(fromEpoch, fromSlot uint64) {
+	go s.runSubscription(consensusInfoSubscription, func() error {
+		return s.subscribeNewConsensusInfoGRPC(s.ctx, fromEpoch)
+	})
+	go s.runSubscription(pendingBlocksSubscription, func() error {
+		return s.subscribeVanNewPendingBlockHash(s.ctx, fromSlot)
+	})
+	go s.runSubscription(canonicalHeadSubscription, func() error {
+		return s.subscribeCanonicalHead(s.ctx)
+	})
+}
+
+// runSubscription runs a stream subscription and keeps the error which it has stopped with, so service status
+// reports the dead subscription. Subscriptions which are stopped by cancelled context or re-org are not errors.
+func (s *Service) runSubscription(name string, subscribe func() error) {
+	defer shared.RecoverService(s)
+	s.setSubscriptionError(name, nil)
+	if err := subscribe(); err != nil && s.ctx.Err() == nil {
+		log.WithError(err).WithField("subscription", name).Error("Vanguard subscription has stopped")
+		s.setSubscriptionError(name, err)
+	}
+}
+
 // subscribeVanNewPendingBlockHash
 func (s *Service) subscribeVanNewPendingBlockHash(ctx context.Context, fromSlot uint64) error {
 	var blockRoot []byte
@@ -46,6 +82,9 @@ func (s *Service) subscribeVanNewPendingBlockHash(ctx context.Context, fromSlot
 		default:
 			vanBlockInfo, err := stream.Recv()
 			if err != nil {
+				if ctx.Err() == nil {
+					shared.ReportSubscriptionDrop("vanguard", "pendingBlocks", err)
+				}
 				if e, ok := status.FromError(err); ok {
 					switch e.Code() {
 					case codes.Canceled, codes.Internal, codes.Unavailable:
@@ -85,6 +124,62 @@ func (s *Service) subscribeVanNewPendingBlockHash(ctx context.Context, fromSlot
 	return nil
 }
 
+// subscribeCanonicalHead streams canonical head changes of vanguard fork choice and sends them to subscribers
+func (s *Service) subscribeCanonicalHead(ctx context.Context) error {
+	stream, err := s.beaconClient.StreamChainHead(ctx, &emptypb.Empty{})
+	if nil != err {
+		log.WithError(err).Error("Failed to subscribe to stream of vanguard chain head")
+		return err
+	}
+	log.Info("Successfully subscribed to vanguard chain head")
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Received cancelled context, exiting vanguard chain head streaming subscription!")
+			return nil
+
+		default:
+			chainHead, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					shared.ReportSubscriptionDrop("vanguard", "chainHead", err)
+				}
+				if e, ok := status.FromError(err); ok {
+					switch e.Code() {
+					case codes.Canceled, codes.Internal, codes.Unavailable:
+						log.WithError(err).Infof("Trying to restart connection. rpc status: %v", e.Code())
+						s.waitForConnection()
+						stream, err = s.beaconClient.StreamChainHead(ctx, &emptypb.Empty{})
+						if err != nil {
+							log.WithError(err).Error("Failed to subscribe to vanguard chain head stream")
+							return err
+						}
+						log.Info("Successfully re-subscribed to vanguard chain head")
+						continue
+					}
+				} else {
+					log.WithError(err).Error("Could not receive chain head from vanguard node")
+					return err
+				}
+			}
+
+			if chainHead == nil {
+				log.Error("Received nil chain head, Exiting go routine")
+				return errChainHeadNil
+			}
+
+			canonicalHead := &types.CanonicalHead{
+				Slot:      uint64(chainHead.HeadSlot),
+				BlockHash: common.BytesToHash(chainHead.HeadBlockRoot),
+			}
+			log.WithField("slot", canonicalHead.Slot).WithField("blockHash", canonicalHead.BlockHash).
+				Debug("Vanguard canonical head changed")
+			s.canonicalHeadFeed.Send(canonicalHead)
+		}
+	}
+}
+
 // subscribeNewConsensusInfoGRPC
 func (s *Service) subscribeNewConsensusInfoGRPC(ctx context.Context, fromEpoch uint64) error {
 	stream, err := s.beaconClient.StreamMinimalConsensusInfo(ctx, &ethpb.MinimalConsensusInfoRequest{FromEpoch: eth2Types.Epoch(fromEpoch)})
@@ -108,6 +203,9 @@ func (s *Service) subscribeNewConsensusInfoGRPC(ctx context.Context, fromEpoch u
 		default:
 			vanMinimalConsensusInfo, err := stream.Recv()
 			if err != nil {
+				if ctx.Err() == nil {
+					shared.ReportSubscriptionDrop("vanguard", "consensusInfo", err)
+				}
 				if e, ok := status.FromError(err); ok {
 					switch e.Code() {
 					case codes.Canceled, codes.Internal, codes.Unavailable:
@@ -160,6 +258,7 @@ func (s *Service) subscribeNewConsensusInfoGRPC(ctx context.Context, fromEpoch u
 
 			log.WithField("epoch", vanMinimalConsensusInfo.Epoch).WithField("epochInfo", fmt.Sprintf("%+v", vanMinimalConsensusInfo)).
 				Debug("Received new consensus info")
+			checkChainParams(consensusInfo)
 			if err := s.onNewConsensusInfo(ctx, consensusInfo); err != nil {
 				log.WithError(err).Error("Failed to handle consensus info. Closing epoch info subscription, Exiting go routine")
 				return err
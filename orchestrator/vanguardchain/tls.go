@@ -0,0 +1,44 @@
+package vanguardchain
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/secrets"
+	"github.com/pkg/errors"
+)
+
+var errIncompleteClientCert = errors.New("both tls client certificate and tls client key must be provided")
+
+// LoadTLSConfig loads the certificates of a secure connection with vanguard node. caCert verifies the certificate
+// of vanguard node, system roots are used when it is not given. clientCert and clientKey are presented to vanguard
+// node, so it can restrict which orchestrators may subscribe to its streams, the key may be given as env:NAME
+// reference. It returns nil config when no file is given, the connection is insecure then.
+func LoadTLSConfig(caCert, clientCert, clientKey string) (*tls.Config, error) {
+	if caCert == "" && clientCert == "" && clientKey == "" {
+		return nil, nil
+	}
+	if (clientCert == "") != (clientKey == "") {
+		return nil, errIncompleteClientCert
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caCert != "" {
+		pem, err := ioutil.ReadFile(caCert)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read tls ca certificate")
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("no PEM encoded certificate found in %s", caCert)
+		}
+	}
+	if clientCert != "" {
+		cert, err := secrets.LoadX509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not load tls client certificate and key")
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
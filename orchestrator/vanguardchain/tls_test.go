@@ -0,0 +1,125 @@
+package vanguardchain
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+// writeSelfSignedCert writes self-signed certificate of localhost and its private key into dir
+func writeSelfSignedCert(t *testing.T, dir, name string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestLoadTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	caFile, _ := writeSelfSignedCert(t, dir, "vanguard")
+	certFile, keyFile := writeSelfSignedCert(t, dir, "orchestrator")
+
+	config, err := LoadTLSConfig("", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, true, config == nil)
+
+	_, err = LoadTLSConfig(caFile, certFile, "")
+	assert.ErrorContains(t, errIncompleteClientCert.Error(), err)
+
+	_, err = LoadTLSConfig(keyFile, "", "")
+	assert.ErrorContains(t, "no PEM encoded certificate found", err)
+
+	_, err = LoadTLSConfig("", keyFile, certFile)
+	assert.ErrorContains(t, "could not load tls client certificate and key", err)
+
+	config, err = LoadTLSConfig(caFile, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, true, config.RootCAs != nil)
+	assert.Equal(t, 0, len(config.Certificates))
+
+	config, err = LoadTLSConfig("", certFile, keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, true, config.RootCAs == nil)
+	assert.Equal(t, 1, len(config.Certificates))
+}
+
+// TestLoadTLSConfig_MutualTLS checks that a server which requires client certificates accepts the connection
+// with the loaded config, and refuses a connection without client certificate
+func TestLoadTLSConfig_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	serverCert, serverKey := writeSelfSignedCert(t, dir, "vanguard")
+	clientCert, clientKey := writeSelfSignedCert(t, dir, "orchestrator")
+
+	serverConfig, err := LoadTLSConfig(clientCert, serverCert, serverKey)
+	require.NoError(t, err)
+	serverConfig.ClientCAs, serverConfig.RootCAs = serverConfig.RootCAs, nil
+	serverConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+
+	handshake := func(config *tls.Config) error {
+		conn, err := tls.Dial("tcp", listener.Addr().String(), config)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		// tls 1.3 reports a refused client certificate on the first read
+		_, err = conn.Read(make([]byte, 1))
+		if err != nil && err.Error() != "EOF" {
+			return err
+		}
+		return nil
+	}
+
+	config, err := LoadTLSConfig(serverCert, clientCert, clientKey)
+	require.NoError(t, err)
+	require.NoError(t, handshake(config))
+
+	config, err = LoadTLSConfig(serverCert, "", "")
+	require.NoError(t, err)
+	assert.NotNil(t, handshake(config))
+}
@@ -0,0 +1,95 @@
+// Package backoff retries operations with exponentially growing delays. Delays are randomized by jitter, so
+// clients which lost the same node do not reconnect at the same moment.
+package backoff
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Config defines delays between retries of an operation
+type Config struct {
+	// InitialDelay is the delay before the first retry
+	InitialDelay time.Duration
+	// MaxDelay limits the delay, it is reached by multiplying the previous delay. Zero means no limit.
+	MaxDelay time.Duration
+	// Multiplier grows the delay between retries, values below one keep the delay constant
+	Multiplier float64
+	// Jitter randomizes every delay by up to the given fraction of it in both directions, 0.2 means ±20%
+	Jitter float64
+	// MaxRetries limits the number of retries after the first attempt, zero retries until context is cancelled
+	MaxRetries int
+}
+
+// DefaultConfig retries with delays from one second to half a minute until context is cancelled
+var DefaultConfig = Config{
+	InitialDelay: time.Second,
+	MaxDelay:     30 * time.Second,
+	Multiplier:   2,
+	Jitter:       0.2,
+}
+
+// Constant returns the config of retries with the same delay without jitter until context is cancelled
+func Constant(delay time.Duration) Config {
+	return Config{InitialDelay: delay, MaxDelay: delay, Multiplier: 1}
+}
+
+var (
+	randLock sync.Mutex
+	random   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// Delay returns the delay before the given retry, starting at one
+func (c Config) Delay(retry int) time.Duration {
+	delay := float64(c.InitialDelay)
+	for i := 1; i < retry && c.Multiplier > 1; i++ {
+		delay *= c.Multiplier
+		if c.MaxDelay > 0 && delay >= float64(c.MaxDelay) {
+			break
+		}
+	}
+	if c.MaxDelay > 0 && delay > float64(c.MaxDelay) {
+		delay = float64(c.MaxDelay)
+	}
+	if c.Jitter > 0 {
+		randLock.Lock()
+		delay += delay * c.Jitter * (2*random.Float64() - 1)
+		randLock.Unlock()
+	}
+	return time.Duration(delay)
+}
+
+// Retry calls op until it succeeds, waiting the delays of the config between the attempts. It returns the error of
+// the context when the context is cancelled, and the last error of op when max retries are reached.
+func Retry(ctx context.Context, config Config, op func() error) error {
+	for retry := 0; ; retry++ {
+		if retry > 0 {
+			if err := Sleep(ctx, config.Delay(retry)); err != nil {
+				return err
+			}
+		}
+		err := op()
+		if err == nil {
+			return nil
+		}
+		if config.MaxRetries > 0 && retry >= config.MaxRetries {
+			return errors.Wrapf(err, "gave up after %d retries", retry)
+		}
+	}
+}
+
+// Sleep waits for the given duration and returns the error of the context when it is cancelled before
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,69 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+func TestConfig_Delay(t *testing.T) {
+	config := Config{InitialDelay: time.Second, MaxDelay: 5 * time.Second, Multiplier: 2}
+	assert.Equal(t, time.Second, config.Delay(1))
+	assert.Equal(t, 2*time.Second, config.Delay(2))
+	assert.Equal(t, 4*time.Second, config.Delay(3))
+	assert.Equal(t, 5*time.Second, config.Delay(4))
+	assert.Equal(t, 5*time.Second, config.Delay(1000))
+
+	assert.Equal(t, 3*time.Second, Constant(3*time.Second).Delay(10))
+}
+
+func TestConfig_DelayJitter(t *testing.T) {
+	config := Config{InitialDelay: time.Second, Multiplier: 2, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		delay := config.Delay(2)
+		if delay < time.Second || delay > 3*time.Second {
+			t.Fatalf("delay %v is out of jitter range", delay)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	attempts := 0
+	err := Retry(ctx, Constant(time.Millisecond), func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRetry_MaxRetries(t *testing.T) {
+	config := Constant(time.Millisecond)
+	config.MaxRetries = 2
+	attempts := 0
+	err := Retry(context.Background(), config, func() error {
+		attempts++
+		return errors.New("failed")
+	})
+	assert.ErrorContains(t, "gave up after 2 retries: failed", err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRetry_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	err := Retry(ctx, Constant(time.Hour), func() error {
+		attempts++
+		cancel()
+		return errors.New("failed")
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 1, attempts)
+}
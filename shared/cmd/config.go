@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"github.com/urfave/cli/v2/altsrc"
+	"gopkg.in/yaml.v2"
+)
+
+// key of app metadata which keeps the values of config file that have been applied to flags
+const configValuesMetadataKey = "config-file-values"
+
+// LoadFlagsFromConfig sets the flags from the file of config-file flag. Keys of the file are flag names, files
+// with .toml extension are read as toml and other files as yaml. Flags which are given on the command line
+// or by environment variables take precedence over the file, unknown keys are rejected.
+func LoadFlagsFromConfig(cliCtx *cli.Context, flags []cli.Flag) error {
+	path := cliCtx.String(ConfigFileFlag.Name)
+	if path == "" {
+		return nil
+	}
+	values, err := readConfig(path, flags)
+	if err != nil {
+		return err
+	}
+
+	applied := make(map[string][]string, len(values))
+	for _, key := range sortedKeys(values) {
+		if isSetByUser(cliCtx, values[key].flag) {
+			continue
+		}
+		if err := setFlag(cliCtx, key, isSliceFlag(values[key].flag), values[key].elements); err != nil {
+			return errors.Wrapf(err, "invalid value of %s in config file %s", key, path)
+		}
+		applied[key] = values[key].elements
+	}
+	if cliCtx.App.Metadata == nil {
+		cliCtx.App.Metadata = make(map[string]interface{})
+	}
+	cliCtx.App.Metadata[configValuesMetadataKey] = applied
+	return nil
+}
+
+// ReloadFlagsFromConfig reads the config file of config-file flag again and returns the names of flags whose
+// value has changed since the file was loaded, sorted by name. Changed flags are set to the new value, keys
+// which have been removed from the file restore the default value. Slice flags and flags which are given on
+// the command line are not set, slice flags are still reported as changed.
+func ReloadFlagsFromConfig(cliCtx *cli.Context, flags []cli.Flag) ([]string, error) {
+	path := cliCtx.String(ConfigFileFlag.Name)
+	if path == "" {
+		return nil, errors.New("node has not been started with a config file")
+	}
+	values, err := readConfig(path, flags)
+	if err != nil {
+		return nil, err
+	}
+	applied, _ := cliCtx.App.Metadata[configValuesMetadataKey].(map[string][]string)
+
+	known := make(map[string]cli.Flag)
+	for _, f := range flags {
+		for _, name := range f.Names() {
+			known[name] = f
+		}
+	}
+	names := make(map[string]bool)
+	for key := range values {
+		names[key] = true
+	}
+	for key := range applied {
+		names[key] = true
+	}
+
+	var changed []string
+	reloaded := make(map[string][]string, len(values))
+	for name := range names {
+		value, inFile := values[name]
+		previous, wasApplied := applied[name]
+		f := known[name]
+		if inFile {
+			f = value.flag
+		}
+		// flags of the command line take precedence over config file
+		if !wasApplied && isSetByUser(cliCtx, f) {
+			continue
+		}
+		if inFile {
+			reloaded[name] = value.elements
+		}
+		if inFile && wasApplied && strings.Join(previous, ",") == strings.Join(value.elements, ",") {
+			continue
+		}
+		if !inFile && !wasApplied {
+			continue
+		}
+		changed = append(changed, name)
+		if isSliceFlag(f) {
+			continue
+		}
+		// flags which have been removed from config file are reset to their defaults
+		elements := []string{defaultValue(f)}
+		if inFile {
+			elements = value.elements
+		}
+		if err := setFlag(cliCtx, name, false, elements); err != nil {
+			return nil, errors.Wrapf(err, "invalid value of %s in config file %s", name, path)
+		}
+	}
+	if cliCtx.App.Metadata == nil {
+		cliCtx.App.Metadata = make(map[string]interface{})
+	}
+	cliCtx.App.Metadata[configValuesMetadataKey] = reloaded
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// configEntry is a key of config file along with its flag and value formatted as command line values
+type configEntry struct {
+	flag     cli.Flag
+	elements []string
+}
+
+// readConfig reads the config file and validates its keys, keys without value are left out
+func readConfig(path string, flags []cli.Flag) (map[string]*configEntry, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read config file")
+	}
+	values, err := parseConfig(path, data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse config file %s", path)
+	}
+
+	known := make(map[string]cli.Flag)
+	for _, f := range flags {
+		for _, name := range f.Names() {
+			known[name] = f
+		}
+	}
+	entries := make(map[string]*configEntry, len(values))
+	for key, value := range values {
+		f, ok := known[key]
+		if !ok || key == ConfigFileFlag.Name {
+			return nil, errors.Errorf("unknown flag %s in config file %s", key, path)
+		}
+		if value == nil {
+			continue
+		}
+		elements, err := configElements(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value of %s in config file %s", key, path)
+		}
+		entries[key] = &configEntry{flag: f, elements: elements}
+	}
+	return entries, nil
+}
+
+func sortedKeys(entries map[string]*configEntry) []string {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// defaultValue formats the default value of a flag as command line value
+func defaultValue(f cli.Flag) string {
+	switch t := f.(type) {
+	case *cli.BoolFlag:
+		return strconv.FormatBool(t.Value)
+	case *altsrc.BoolFlag:
+		return strconv.FormatBool(t.Value)
+	case cli.DocGenerationFlag:
+		return t.GetValue()
+	}
+	return ""
+}
+
+// parseConfig decodes the top level keys of a yaml or toml config file
+func parseConfig(path string, data []byte) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		if _, err := toml.Decode(string(data), &values); err != nil {
+			return nil, err
+		}
+		return values, nil
+	}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// isSetByUser reports whether any name of the flag has been given on the command line or by environment variable
+func isSetByUser(cliCtx *cli.Context, f cli.Flag) bool {
+	for _, name := range f.Names() {
+		if cliCtx.IsSet(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSliceFlag(f cli.Flag) bool {
+	switch f.(type) {
+	case *cli.StringSliceFlag, *altsrc.StringSliceFlag, *cli.IntSliceFlag, *altsrc.IntSliceFlag:
+		return true
+	}
+	return false
+}
+
+// configElements formats a value of the config file as command line values, a scalar becomes a single element
+func configElements(value interface{}) ([]string, error) {
+	list, ok := value.([]interface{})
+	if !ok {
+		s, err := configValue(value)
+		if err != nil {
+			return nil, err
+		}
+		return []string{s}, nil
+	}
+	elements := make([]string, 0, len(list))
+	for _, element := range list {
+		s, err := configValue(element)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, s)
+	}
+	return elements, nil
+}
+
+// setFlag sets a value of the config file. Every element of a list is added to slice flags, other flags
+// receive the elements as comma separated string.
+func setFlag(cliCtx *cli.Context, name string, slice bool, elements []string) error {
+	if !slice {
+		return cliCtx.Set(name, strings.Join(elements, ","))
+	}
+	for _, element := range elements {
+		if err := cliCtx.Set(name, element); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configValue formats a scalar of the config file as command line value
+func configValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", errors.Errorf("unsupported value %v", value)
+	}
+}
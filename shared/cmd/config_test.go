@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/urfave/cli/v2"
+)
+
+func configContext(t *testing.T, args ...string) *cli.Context {
+	flags := []cli.Flag{
+		ConfigFileFlag,
+		HTTPListenAddrFlag,
+		HTTPPortFlag,
+		HTTPCORSDomainsFlag,
+		HTTPEnabledFlag,
+		PendingTimeoutFlag,
+		AlertWebhookFlag,
+	}
+	set := flag.NewFlagSet("test", 0)
+	for i, f := range flags {
+		// slice flags collect values in their definition, so every context gets its own copy
+		if sliceFlag, ok := f.(*cli.StringSliceFlag); ok {
+			cpy := *sliceFlag
+			cpy.Value = cli.NewStringSlice()
+			flags[i] = &cpy
+		}
+		require.NoError(t, flags[i].Apply(set))
+	}
+	require.NoError(t, set.Parse(args))
+	return cli.NewContext(&cli.App{Flags: flags}, set, nil)
+}
+
+func TestLoadFlagsFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "orchestrator.yaml")
+	require.NoError(t, ioutil.WriteFile(yamlFile, []byte(`
+http: true
+http.addr: 0.0.0.0
+http.port: 9545
+http-cors-domains: [a.example, b.example]
+pending-timeout: 1m
+alert-webhook:
+  - http://hooks.example/1
+  - http://hooks.example/2
+`), 0600))
+
+	cliCtx := configContext(t, "--config-file", yamlFile, "--http.port", "7545")
+	require.NoError(t, LoadFlagsFromConfig(cliCtx, cliCtx.App.Flags))
+	assert.Equal(t, true, cliCtx.Bool(HTTPEnabledFlag.Name))
+	assert.Equal(t, "0.0.0.0", cliCtx.String(HTTPListenAddrFlag.Name))
+	// command line takes precedence over config file
+	assert.Equal(t, 7545, cliCtx.Int(HTTPPortFlag.Name))
+	assert.Equal(t, "a.example,b.example", cliCtx.String(HTTPCORSDomainsFlag.Name))
+	assert.Equal(t, time.Minute, cliCtx.Duration(PendingTimeoutFlag.Name))
+	assert.DeepEqual(t, []string{"http://hooks.example/1", "http://hooks.example/2"},
+		cliCtx.StringSlice(AlertWebhookFlag.Name))
+
+	tomlFile := filepath.Join(dir, "orchestrator.toml")
+	require.NoError(t, ioutil.WriteFile(tomlFile, []byte(`
+"http.port" = 9545
+pending-timeout = "5s"
+`), 0600))
+	cliCtx = configContext(t, "--config-file", tomlFile)
+	require.NoError(t, LoadFlagsFromConfig(cliCtx, cliCtx.App.Flags))
+	assert.Equal(t, 9545, cliCtx.Int(HTTPPortFlag.Name))
+	assert.Equal(t, 5*time.Second, cliCtx.Duration(PendingTimeoutFlag.Name))
+	assert.Equal(t, DefaultHTTPHost, cliCtx.String(HTTPListenAddrFlag.Name))
+
+	require.NoError(t, ioutil.WriteFile(yamlFile, []byte("http-port: 9545\n"), 0600))
+	cliCtx = configContext(t, "--config-file", yamlFile)
+	assert.ErrorContains(t, "unknown flag http-port", LoadFlagsFromConfig(cliCtx, cliCtx.App.Flags))
+
+	require.NoError(t, ioutil.WriteFile(yamlFile, []byte("http.port: many\n"), 0600))
+	cliCtx = configContext(t, "--config-file", yamlFile)
+	assert.ErrorContains(t, "invalid value of http.port", LoadFlagsFromConfig(cliCtx, cliCtx.App.Flags))
+}
+
+func TestReloadFlagsFromConfig(t *testing.T) {
+	yamlFile := filepath.Join(t.TempDir(), "orchestrator.yaml")
+	require.NoError(t, ioutil.WriteFile(yamlFile, []byte(`
+http.addr: 0.0.0.0
+http.port: 9545
+pending-timeout: 1m
+alert-webhook: [http://hooks.example/1]
+`), 0600))
+	cliCtx := configContext(t, "--config-file", yamlFile, "--http", "--http.port", "7545")
+	require.NoError(t, LoadFlagsFromConfig(cliCtx, cliCtx.App.Flags))
+
+	changed, err := ReloadFlagsFromConfig(cliCtx, cliCtx.App.Flags)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(changed))
+
+	require.NoError(t, ioutil.WriteFile(yamlFile, []byte(`
+http: false
+http.port: 9546
+pending-timeout: 2m
+alert-webhook: [http://hooks.example/2]
+`), 0600))
+	changed, err = ReloadFlagsFromConfig(cliCtx, cliCtx.App.Flags)
+	require.NoError(t, err)
+	// http and http.port are given on the command line
+	assert.DeepEqual(t, []string{AlertWebhookFlag.Name, HTTPListenAddrFlag.Name, PendingTimeoutFlag.Name}, changed)
+	assert.Equal(t, true, cliCtx.Bool(HTTPEnabledFlag.Name))
+	assert.Equal(t, 7545, cliCtx.Int(HTTPPortFlag.Name))
+	assert.Equal(t, DefaultHTTPHost, cliCtx.String(HTTPListenAddrFlag.Name))
+	assert.Equal(t, 2*time.Minute, cliCtx.Duration(PendingTimeoutFlag.Name))
+	// slice flags are reported but not set
+	assert.DeepEqual(t, []string{"http://hooks.example/1"}, cliCtx.StringSlice(AlertWebhookFlag.Name))
+
+	require.NoError(t, ioutil.WriteFile(yamlFile, []byte("pending-timeout: many\n"), 0600))
+	_, err = ReloadFlagsFromConfig(cliCtx, cliCtx.App.Flags)
+	assert.ErrorContains(t, "invalid value of pending-timeout", err)
+}
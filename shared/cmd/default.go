@@ -5,16 +5,49 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 )
 
 const (
-	DefaultHTTPHost             = "localhost" // Default host interface for the HTTP RPC server
-	DefaultHTTPPort             = 8545        // Default TCP port for the HTTP RPC server
-	DefaultWSHost               = "localhost" // Default host interface for the websocket RPC server
-	DefaultWSPort               = 8546        // Default TCP port for the websocket RPC server
-	DefaultIpcPath              = "orchestrator.ipc"
-	DefaultVanguardGRPCEndpoint = "127.0.0.1:4000"
-	DefaultPandoraRPCEndpoint   = "http://127.0.0.1:8545"
+	DefaultHTTPHost                   = "localhost" // Default host interface for the HTTP RPC server
+	DefaultHTTPPort                   = 8545        // Default TCP port for the HTTP RPC server
+	DefaultHTTPVirtualHosts           = "localhost" // Default virtual hosts accepted by the HTTP RPC server
+	DefaultWSHost                     = "localhost" // Default host interface for the websocket RPC server
+	DefaultWSPort                     = 8546        // Default TCP port for the websocket RPC server
+	DefaultWSMaxConnections           = 256         // Default maximum number of concurrent websocket connections
+	DefaultWSMaxSubscriptions         = 64          // Default maximum number of subscriptions of a websocket connection
+	DefaultIpcPath                    = "orchestrator.ipc"
+	DefaultIpcPermissions             = "0600"
+	DefaultVanguardGRPCEndpoint       = "127.0.0.1:4000"
+	DefaultPandoraRPCEndpoint         = "http://127.0.0.1:8545"
+	DefaultPandoraRPCNamespace        = "eth"
+	DefaultPandoraSubMethod           = "newPendingBlockHeaders"
+	DefaultPendingTimeout             = 30 * time.Second
+	DefaultVerificationWorkers        = 4
+	DefaultVerificationBatchSize      = 256
+	DefaultOrphanMaxAge               = 10 * time.Minute
+	DefaultDivergenceWarnSlots        = 8  // Default divergence of chain heads in slots which is logged as warning
+	DefaultDivergenceAlertSlots       = 32 // Default divergence of chain heads in slots which raises an alert
+	DefaultClockSkewThreshold         = 2 * time.Second
+	DefaultReadinessMaxLag            = 64
+	DefaultRPCRateLimitBurst          = 100
+	DefaultRPCExpensiveRateLimitBurst = 10
+	DefaultRPCShutdownTimeout         = 5 * time.Second
+	DefaultShutdownTimeout            = 30 * time.Second
+	DefaultStatusLogPeriod            = time.Minute
+	DefaultRPCLogVerbosity            = "basic"
+	DefaultRPCMaxPageSize             = 1024        // Default maximum number of items of a single page of range queries
+	DefaultSecondsPerSlot             = 6           // Default duration of a vanguard slot in seconds
+	DefaultEventBufferSize            = 64          // Default number of events buffered for a slow consumer
+	DefaultEventBufferPolicy          = "block"     // Default policy of full event buffers
+	DefaultMetricsHost                = "127.0.0.1" // Default host interface for the metrics server
+	DefaultMetricsPort                = 6060        // Default TCP port for the metrics server
+	DefaultLogMaxSize                 = 100         // Default size in megabytes of the log file which triggers its rotation
+	DefaultLogMaxBackups              = 5           // Default number of rotated log files to keep
+	DefaultAuditLogMaxSize            = 100         // Default size in megabytes of the audit log which triggers its rotation
+	DefaultAuditLogMaxBackups         = 10          // Default number of rotated audit logs to keep
+	DefaultHALeaseDuration            = 15 * time.Second
+	DefaultBenchmarkSlots             = 3200 // Default number of synthetic slots of the benchmark, 100 epochs
 )
 
 // DefaultConfigDir is the default config directory to use for the vaults and other
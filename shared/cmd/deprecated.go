@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"github.com/urfave/cli/v2/altsrc"
+)
+
+// DeprecatedFlag is the old name of a renamed flag. The old name is still accepted on the command line and in
+// config file, its value is applied to the new flag with a warning, so units of previous releases keep working.
+type DeprecatedFlag struct {
+	Name    string   // old name of the flag
+	NewFlag cli.Flag // flag which replaces the old name
+}
+
+// DeprecatedFlags lists the old names of renamed flags, a name is removed one release after it was deprecated.
+var DeprecatedFlags []*DeprecatedFlag
+
+// WithDeprecatedFlags returns the flags along with hidden flags of the old names of the given flags.
+func WithDeprecatedFlags(flags []cli.Flag) []cli.Flag {
+	return withDeprecatedFlags(flags, DeprecatedFlags)
+}
+
+func withDeprecatedFlags(flags []cli.Flag, deprecated []*DeprecatedFlag) []cli.Flag {
+	names := make(map[string]bool, len(flags))
+	for _, f := range flags {
+		names[f.Names()[0]] = true
+	}
+	all := append([]cli.Flag{}, flags...)
+	for _, d := range deprecated {
+		if !names[d.NewFlag.Names()[0]] {
+			continue
+		}
+		usage := "Deprecated, use --" + d.NewFlag.Names()[0]
+		switch {
+		case isBoolFlag(d.NewFlag):
+			all = append(all, &cli.BoolFlag{Name: d.Name, Usage: usage, Hidden: true})
+		case isSliceFlag(d.NewFlag):
+			all = append(all, &cli.StringSliceFlag{Name: d.Name, Usage: usage, Hidden: true})
+		default:
+			all = append(all, &cli.StringFlag{Name: d.Name, Usage: usage, Hidden: true})
+		}
+	}
+	return all
+}
+
+// ApplyDeprecatedFlags sets the new flags from the values of old names given on the command line or in config
+// file and warns about the old names. The new flag takes precedence when both names are given.
+func ApplyDeprecatedFlags(cliCtx *cli.Context) error {
+	return applyDeprecatedFlags(cliCtx, DeprecatedFlags)
+}
+
+func applyDeprecatedFlags(cliCtx *cli.Context, deprecated []*DeprecatedFlag) error {
+	for _, d := range deprecated {
+		if !cliCtx.IsSet(d.Name) {
+			continue
+		}
+		newName := d.NewFlag.Names()[0]
+		logger := log.WithField("flag", d.Name).WithField("newFlag", newName)
+		if isSetByUser(cliCtx, d.NewFlag) {
+			logger.Warn("Deprecated flag is ignored, the new flag is given as well")
+			continue
+		}
+		logger.Warn("Flag is deprecated and will be removed in a future release, please use the new flag")
+		elements := []string{cliCtx.String(d.Name)}
+		if isSliceFlag(d.NewFlag) {
+			elements = cliCtx.StringSlice(d.Name)
+		}
+		if err := setFlag(cliCtx, newName, isSliceFlag(d.NewFlag), elements); err != nil {
+			return errors.Wrapf(err, "invalid value of deprecated flag %s", d.Name)
+		}
+	}
+	return nil
+}
+
+// isDeprecated tells whether the name is the old name of a renamed flag
+func isDeprecated(name string) bool {
+	for _, d := range DeprecatedFlags {
+		if d.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isBoolFlag(f cli.Flag) bool {
+	switch f.(type) {
+	case *cli.BoolFlag, *altsrc.BoolFlag:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/urfave/cli/v2"
+)
+
+func TestApplyDeprecatedFlags(t *testing.T) {
+	hook := logTest.NewGlobal()
+	webhooks := &cli.StringSliceFlag{Name: "alert-webhook"}
+	deprecated := []*DeprecatedFlag{
+		{Name: "http.corsdomain", NewFlag: HTTPCORSDomainsFlag},
+		{Name: "rpc", NewFlag: HTTPEnabledFlag},
+		{Name: "webhook", NewFlag: webhooks},
+		{Name: "http.host", NewFlag: HTTPListenAddrFlag},
+	}
+	flags := withDeprecatedFlags([]cli.Flag{HTTPCORSDomainsFlag, HTTPEnabledFlag, webhooks, HTTPListenAddrFlag},
+		deprecated)
+	require.Equal(t, 8, len(flags))
+
+	set := flag.NewFlagSet("test", 0)
+	for _, f := range flags {
+		require.NoError(t, f.Apply(set))
+	}
+	require.NoError(t, set.Parse([]string{
+		"--http.corsdomain", "a.example", "--rpc", "--webhook", "https://hooks.example/1",
+		"--webhook", "https://hooks.example/2", "--http.host", "0.0.0.0", "--http.addr", "127.0.0.1",
+	}))
+	cliCtx := cli.NewContext(&cli.App{Flags: flags}, set, nil)
+	require.NoError(t, applyDeprecatedFlags(cliCtx, deprecated))
+
+	assert.Equal(t, "a.example", cliCtx.String(HTTPCORSDomainsFlag.Name))
+	assert.Equal(t, true, cliCtx.Bool(HTTPEnabledFlag.Name))
+	assert.DeepEqual(t, []string{"https://hooks.example/1", "https://hooks.example/2"},
+		cliCtx.StringSlice(webhooks.Name))
+	assert.Equal(t, "127.0.0.1", cliCtx.String(HTTPListenAddrFlag.Name))
+	assert.LogsContain(t, hook, "Flag is deprecated")
+	assert.LogsContain(t, hook, "Deprecated flag is ignored")
+}
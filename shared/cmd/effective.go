@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// sources of effective flag values
+const (
+	SourceDefault = "default"
+	SourceFlag    = "flag"
+	SourceEnv     = "env"
+	SourceConfig  = "config"
+)
+
+// value which replaces secrets in effective configuration
+const redacted = "<redacted>"
+
+// parts of flag names whose values are secrets themselves
+var sensitiveFlagNameParts = []string{"password", "token", "apikey", "api-key"}
+
+// ConfigValue is the effective value of a flag along with where the value comes from
+type ConfigValue struct {
+	Value  string
+	Source string
+}
+
+// EffectiveConfig returns the resolved value of every flag by the name of the flag, after command line, config
+// file and defaults have been applied. Secrets are redacted, so the configuration can be logged and shared.
+func EffectiveConfig(cliCtx *cli.Context, flags []cli.Flag) map[string]*ConfigValue {
+	applied, _ := cliCtx.App.Metadata[configValuesMetadataKey].(map[string][]string)
+	config := make(map[string]*ConfigValue, len(flags))
+	for _, f := range flags {
+		name := f.Names()[0]
+		if isDeprecated(name) {
+			continue
+		}
+		source := SourceDefault
+		if isSetByUser(cliCtx, f) {
+			source = SourceFlag
+			if isSetByEnv(cliCtx, f) {
+				source = SourceEnv
+			}
+		}
+		for _, alias := range f.Names() {
+			if _, ok := applied[alias]; ok {
+				source = SourceConfig
+			}
+		}
+		config[name] = &ConfigValue{
+			Value:  RedactValue(name, formatValue(cliCtx.Value(name))),
+			Source: source,
+		}
+	}
+	return config
+}
+
+// isSetByEnv tells whether the value of the flag comes from its environment variable rather than the command line
+func isSetByEnv(cliCtx *cli.Context, f cli.Flag) bool {
+	for _, name := range cliCtx.LocalFlagNames() {
+		for _, alias := range f.Names() {
+			if name == alias {
+				return false
+			}
+		}
+	}
+	_, ok := os.LookupEnv(EnvVarName(f.Names()[0]))
+	return ok
+}
+
+// RedactValue hides the value of sensitive flags and credentials of urls
+func RedactValue(name, value string) string {
+	if value == "" {
+		return value
+	}
+	lowerName := strings.ToLower(name)
+	for _, part := range sensitiveFlagNameParts {
+		if strings.Contains(lowerName, part) {
+			return redacted
+		}
+	}
+	if strings.Contains(value, "://") {
+		if u, err := url.Parse(value); err == nil && u.User != nil {
+			return u.Redacted()
+		}
+	}
+	return value
+}
+
+// formatValue formats the value of a flag like it is given on the command line
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case cli.StringSlice:
+		return strings.Join(v.Value(), ",")
+	case *cli.StringSlice:
+		return strings.Join(v.Value(), ",")
+	case cli.IntSlice:
+		return joinInts(v.Value())
+	case *cli.IntSlice:
+		return joinInts(v.Value())
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(value)
+}
+
+func joinInts(values []int) string {
+	elements := make([]string, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, strconv.Itoa(value))
+	}
+	return strings.Join(elements, ",")
+}
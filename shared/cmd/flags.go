@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"math"
+
 	"github.com/urfave/cli/v2"
 )
 
@@ -17,6 +19,13 @@ var (
 		Name:  "force-clear-db",
 		Usage: "Clear any previously stored data at the data directory",
 	}
+	// NonInteractiveFlag accepts confirmation prompts, so the node never waits for input.
+	NonInteractiveFlag = &cli.BoolFlag{
+		Name:    "non-interactive",
+		Aliases: []string{"accept-terms-of-use"},
+		Usage: "Accepts every confirmation prompt, e.g. of --clear-db, instead of asking. Without it prompts fail " +
+			"when standard input is not a terminal",
+	}
 	// ClearDB prompts user to see if they want to remove any previously stored data at the data directory.
 	ClearDB = &cli.BoolFlag{
 		Name:  "clear-db",
@@ -27,6 +36,22 @@ var (
 		Name:  "ipcpath",
 		Usage: "Filename for IPC socket/pipe within the datadir (explicit paths escape it)",
 	}
+	// IPCFileFlag specifies the full path of the IPC socket, taking precedence over ipcpath.
+	IPCFileFlag = &cli.StringFlag{
+		Name:  "ipc-file",
+		Usage: "Full path of the IPC socket/pipe file, overrides --ipcpath",
+	}
+	// IPCPermissionsFlag specifies the unix permission bits applied to the IPC socket.
+	IPCPermissionsFlag = &cli.StringFlag{
+		Name:  "ipc-permissions",
+		Usage: "Octal unix permission bits of the IPC socket (ignored on windows)",
+		Value: DefaultIpcPermissions,
+	}
+	// IPCGroupFlag specifies the owner group of the IPC socket.
+	IPCGroupFlag = &cli.StringFlag{
+		Name:  "ipc-group",
+		Usage: "Group name or id owning the IPC socket, combine with --ipc-permissions=0660 to grant its members access (ignored on windows)",
+	}
 
 	HTTPEnabledFlag = &cli.BoolFlag{
 		Name:  "http",
@@ -45,6 +70,35 @@ var (
 		Value: DefaultHTTPPort,
 	}
 
+	HTTPCORSDomainsFlag = &cli.StringFlag{
+		Name:  "http-cors-domains",
+		Usage: "Comma separated list of domains from which to accept cross origin requests (browser enforced)",
+		Value: "",
+	}
+
+	HTTPVirtualHostsFlag = &cli.StringFlag{
+		Name:  "http-vhosts",
+		Usage: "Comma separated list of virtual hostnames from which to accept requests (server enforced). Accepts '*' wildcard.",
+		Value: DefaultHTTPVirtualHosts,
+	}
+
+	// HTTPCompressionFlag defines whether http responses are compressed for clients which accept gzip.
+	HTTPCompressionFlag = &cli.BoolFlag{
+		Name:  "http-compression",
+		Usage: "Compress HTTP-RPC, REST and GraphQL responses with gzip for clients which accept it (disable with --http-compression=false)",
+		Value: true,
+	}
+
+	GraphQLEnabledFlag = &cli.BoolFlag{
+		Name:  "graphql",
+		Usage: "Enable the read only GraphQL query endpoint at /graphql on the HTTP-RPC server",
+	}
+
+	DashboardEnabledFlag = &cli.BoolFlag{
+		Name:  "dashboard",
+		Usage: "Serve a status page with chain heads, verification lag, connections and reorg history at /dashboard on the HTTP-RPC server",
+	}
+
 	WSEnabledFlag = &cli.BoolFlag{
 		Name:  "ws",
 		Usage: "Enable the WS-RPC server",
@@ -62,11 +116,52 @@ var (
 		Value: DefaultWSPort,
 	}
 
+	// WSMaxConnectionsFlag defines the maximum number of concurrent websocket connections.
+	WSMaxConnectionsFlag = &cli.IntFlag{
+		Name:  "ws-max-connections",
+		Usage: "Maximum number of concurrent WS-RPC connections (0 disables the limit)",
+		Value: DefaultWSMaxConnections,
+	}
+
+	// WSMaxSubscriptionsFlag defines the maximum number of subscriptions of a single websocket connection.
+	WSMaxSubscriptionsFlag = &cli.IntFlag{
+		Name:  "ws-max-subscriptions",
+		Usage: "Maximum number of active subscriptions of a single WS-RPC connection (0 disables the limit)",
+		Value: DefaultWSMaxSubscriptions,
+	}
+
+	// WSIdleTimeoutFlag defines the duration after which silent websocket connections are closed.
+	WSIdleTimeoutFlag = &cli.DurationFlag{
+		Name:  "ws-idle-timeout",
+		Usage: "Close WS-RPC connections which have sent nothing, including ping responses, for the given duration (0 disables the timeout)",
+	}
+
+	// WSCompressionFlag defines whether permessage-deflate compression is negotiated on websocket connections.
+	WSCompressionFlag = &cli.BoolFlag{
+		Name:  "ws-compression",
+		Usage: "Negotiate permessage-deflate compression of WS-RPC messages with clients which offer it",
+	}
+
 	VanguardGRPCEndpoint = &cli.StringFlag{
 		Name:  "vanguard-grpc-endpoint",
 		Usage: "Vanguard node gRPC provider endpoint",
 		Value: DefaultVanguardGRPCEndpoint,
 	}
+	// VanguardTLSCACertFlag defines the certificate authority which verifies the certificate of vanguard node.
+	VanguardTLSCACertFlag = &cli.StringFlag{
+		Name:  "vanguard-tls-ca-cert",
+		Usage: "Path to a PEM encoded CA certificate which verifies the certificate of vanguard node. When set, the vanguard gRPC connection is secured with TLS",
+	}
+	// VanguardTLSClientCertFlag defines the certificate which the orchestrator presents to vanguard node.
+	VanguardTLSClientCertFlag = &cli.StringFlag{
+		Name:  "vanguard-tls-client-cert",
+		Usage: "Path to a PEM encoded client certificate which is presented to vanguard node over TLS, so it can restrict which orchestrators may subscribe to its streams",
+	}
+	// VanguardTLSClientKeyFlag defines the private key of the client certificate of vanguard connection.
+	VanguardTLSClientKeyFlag = &cli.StringFlag{
+		Name:  "vanguard-tls-client-key",
+		Usage: "Path to a PEM encoded private key of the --vanguard-tls-client-cert certificate, or env:NAME to read it from an environment variable",
+	}
 
 	// PandoraRPCEndpoint provides an WSS/IPC access endpoint to an Pandora RPC.
 	PandoraRPCEndpoint = &cli.StringFlag{
@@ -75,10 +170,329 @@ var (
 		Value: DefaultPandoraRPCEndpoint,
 	}
 
+	// PandoraRPCNamespace defines the rpc namespace under which pandora exposes pending header subscription.
+	PandoraRPCNamespace = &cli.StringFlag{
+		Name:  "pandora-rpc-namespace",
+		Usage: "Pandora node RPC namespace of the pending header subscription",
+		Value: DefaultPandoraRPCNamespace,
+	}
+
+	// PandoraSubscriptionMethod defines the subscription method name of pandora pending header stream.
+	PandoraSubscriptionMethod = &cli.StringFlag{
+		Name:  "pandora-subscription-method",
+		Usage: "Pandora node RPC subscription method for pending block headers",
+		Value: DefaultPandoraSubMethod,
+	}
+
+	// PandoraChainID defines the expected chain id of pandora node. Zero disables the check.
+	PandoraChainID = &cli.Uint64Flag{
+		Name:  "pandora-chain-id",
+		Usage: "Expected pandora chain id, headers from pandora node with different chain id are not verified (0 = no check)",
+	}
+
+	// PendingTimeoutFlag defines the time to wait for the other chain before querying it actively.
+	PendingTimeoutFlag = &cli.DurationFlag{
+		Name:  "pending-timeout",
+		Usage: "Time to wait for a slot from the other chain before actively querying it and declaring the slot unmatched",
+		Value: DefaultPendingTimeout,
+	}
+
+	// VerificationWorkersFlag defines the number of workers which verify matched slots concurrently.
+	VerificationWorkersFlag = &cli.IntFlag{
+		Name:  "verification-workers",
+		Usage: "Number of workers which verify matched slots concurrently. Speeds up catch-up after long downtime",
+		Value: DefaultVerificationWorkers,
+	}
+
+	// VerificationBatchSizeFlag defines the maximum number of matched slots which are verified together.
+	VerificationBatchSizeFlag = &cli.IntFlag{
+		Name:  "verification-batch-size",
+		Usage: "Maximum number of queued matched slots which are verified together by the verification workers",
+		Value: DefaultVerificationBatchSize,
+	}
+
+	// ConfirmationDepthFlag defines the number of subsequent verified slots before a slot is reported as verified.
+	ConfirmationDepthFlag = &cli.Uint64Flag{
+		Name:  "confirmation-depth",
+		Usage: "Number of subsequent slots which must be verified before a slot is reported as verified. Trades latency for reorg safety",
+		Value: 0,
+	}
+
+	// OrphanMaxAgeFlag defines the maximum time a slot may stay pending before it is evicted as orphan.
+	OrphanMaxAgeFlag = &cli.DurationFlag{
+		Name:  "orphan-max-age",
+		Usage: "Maximum time a slot may stay pending (e.g. on a deep non-canonical fork) before it is moved to the orphan bucket",
+		Value: DefaultOrphanMaxAge,
+	}
+
+	// DivergenceWarnSlotsFlag defines the divergence of vanguard and pandora heads which is logged as warning.
+	DivergenceWarnSlotsFlag = &cli.Uint64Flag{
+		Name:  "divergence-warn-slots",
+		Usage: "Number of slots the latest pandora header may diverge from vanguard head before a warning is logged (0 = disabled)",
+		Value: DefaultDivergenceWarnSlots,
+	}
+	// DivergenceAlertSlotsFlag defines the divergence of vanguard and pandora heads which raises an alert.
+	DivergenceAlertSlotsFlag = &cli.Uint64Flag{
+		Name:  "divergence-alert-slots",
+		Usage: "Number of slots the latest pandora header may diverge from vanguard head before an alert is raised, e.g. when one of the clients is stuck (0 = disabled)",
+		Value: DefaultDivergenceAlertSlots,
+	}
+	// ClockSkewThresholdFlag defines the skew of local clock against vanguard slot timing which is logged as warning.
+	ClockSkewThresholdFlag = &cli.DurationFlag{
+		Name:  "clock-skew-threshold",
+		Usage: "Skew of the local clock against the slot timing of vanguard canonical heads before a warning is logged, e.g. on NTP drift of the host (0 = disabled)",
+		Value: DefaultClockSkewThreshold,
+	}
+
+	// AlertWebhookFlag defines webhook URLs which receive alerts of the consensus pipeline.
+	AlertWebhookFlag = &cli.StringSliceFlag{
+		Name:  "alert-webhook",
+		Usage: "Webhook URL which receives a JSON payload on verification mismatch, equivocation, prolonged unmatched slot or chain divergence (can be repeated). Also env:NAME or file:PATH to read the URL from an environment variable or file",
+	}
+
+	// RPCJWTSecretFlag defines the file with the shared secret of tokens which authenticate http and ws requests.
+	RPCJWTSecretFlag = &cli.StringFlag{
+		Name:  "rpc-jwt-secret",
+		Usage: "Path to a file with hex encoded 32 bytes secret, or env:NAME to read it from an environment variable. When set, http and ws requests must carry a HS256 bearer token signed with it. WS clients which can not set headers may pass the token in the token url parameter",
+	}
+
+	// RPCAPIKeysFileFlag defines the file with api keys of http rpc consumers.
+	RPCAPIKeysFileFlag = &cli.StringFlag{
+		Name:  "rpc-api-keys-file",
+		Usage: "Path to a yaml file with api keys of http rpc consumers, each with allowed methods and rate limit. When set, http requests must carry a key in the X-API-Key header, or a jwt token when --rpc-jwt-secret is set",
+	}
+
+	// RPCAllowUnprotectedFlag allows serving http and ws rpc without authentication on non-loopback addresses.
+	RPCAllowUnprotectedFlag = &cli.BoolFlag{
+		Name:  "rpc-allow-unprotected",
+		Usage: "Allow serving HTTP-RPC and WS-RPC without authentication on addresses which other machines can reach. Without it, the node refuses to start unless --rpc-jwt-secret or --rpc-api-keys-file is set",
+	}
+
+	// RPCAdminFlag exposes admin namespace on http and ws rpc.
+	RPCAdminFlag = &cli.BoolFlag{
+		Name:  "rpc-admin",
+		Usage: "Expose admin namespace (backup, pruning, compaction, re-verification) on HTTP-RPC and WS-RPC, requires --rpc-jwt-secret",
+	}
+
+	// RPCTLSCertFlag defines the certificate which is used to serve https and wss.
+	RPCTLSCertFlag = &cli.StringFlag{
+		Name:  "rpc-tls-cert",
+		Usage: "Path to a PEM encoded certificate. When set together with --rpc-tls-key, http and ws rpc are served over https and wss",
+	}
+
+	// RPCTLSKeyFlag defines the private key of the certificate which is used to serve https and wss.
+	RPCTLSKeyFlag = &cli.StringFlag{
+		Name:  "rpc-tls-key",
+		Usage: "Path to a PEM encoded private key of the --rpc-tls-cert certificate, or env:NAME to read it from an environment variable",
+	}
+
+	// RPCRateLimitFlag defines the number of http requests per second which a single client may send.
+	RPCRateLimitFlag = &cli.Float64Flag{
+		Name:  "rpc-rate-limit",
+		Usage: "Maximum number of http rpc requests and ws connection attempts per second of a single client ip, 0 disables the limit",
+		Value: 0,
+	}
+
+	// RPCRateLimitBurstFlag defines the number of requests which a client may send at once.
+	RPCRateLimitBurstFlag = &cli.IntFlag{
+		Name:  "rpc-rate-limit-burst",
+		Usage: "Maximum number of requests which a single client ip may send at once before --rpc-rate-limit applies",
+		Value: DefaultRPCRateLimitBurst,
+	}
+
+	// RPCExpensiveRateLimitFlag defines the number of expensive requests per second which a single client may send.
+	RPCExpensiveRateLimitFlag = &cli.Float64Flag{
+		Name:  "rpc-expensive-rate-limit",
+		Usage: "Maximum number of range, batch and re-verification requests per second of a single client ip, 0 disables the limit",
+		Value: 0,
+	}
+
+	// RPCExpensiveRateLimitBurstFlag defines the number of expensive requests which a client may send at once.
+	RPCExpensiveRateLimitBurstFlag = &cli.IntFlag{
+		Name:  "rpc-expensive-rate-limit-burst",
+		Usage: "Maximum number of range, batch and re-verification requests which a single client ip may send at once",
+		Value: DefaultRPCExpensiveRateLimitBurst,
+	}
+
+	// RPCShutdownTimeoutFlag defines the time given to in-flight rpc requests and subscriptions on shutdown.
+	RPCShutdownTimeoutFlag = &cli.DurationFlag{
+		Name:  "rpc-shutdown-timeout",
+		Usage: "Time given to in-flight http requests and ws subscriptions to complete before rpc servers are closed on shutdown",
+		Value: DefaultRPCShutdownTimeout,
+	}
+
+	// RPCSlowThresholdFlag defines the duration above which rpc requests are logged.
+	RPCSlowThresholdFlag = &cli.DurationFlag{
+		Name:  "rpc-slow-threshold",
+		Usage: "Log http rpc requests which take longer than the given duration, e.g. 500ms (0 disables logging)",
+	}
+
+	// RPCLogFlag defines the transports whose rpc requests are logged.
+	RPCLogFlag = &cli.StringFlag{
+		Name:  "rpc-log",
+		Usage: "Comma separated list of rpc transports whose requests are logged for audits (http, ws, ipc). Auth tokens are redacted",
+	}
+
+	// RPCLogVerbosityFlag defines how much of every logged rpc request is logged.
+	RPCLogVerbosityFlag = &cli.StringFlag{
+		Name:  "rpc-log-verbosity",
+		Usage: "Verbosity of rpc request logging: basic (client, method, duration and error) or full (params and results as well)",
+		Value: DefaultRPCLogVerbosity,
+	}
+
+	// RPCSocketActivationFlag defines whether http and ws servers use the sockets passed by systemd socket activation.
+	RPCSocketActivationFlag = &cli.BoolFlag{
+		Name:  "rpc-socket-activation",
+		Usage: "Serve HTTP-RPC and WS-RPC on the listening sockets passed by systemd socket activation, named http and ws with FileDescriptorName= (ws is served on the http socket when it has none)",
+	}
+
+	// RPCMaxPageSizeFlag defines the maximum number of items returned by a single page of range queries.
+	RPCMaxPageSizeFlag = &cli.IntFlag{
+		Name:  "rpc-max-page-size",
+		Usage: "Maximum number of epochs or slots returned by a single page of range queries, larger ranges are paginated",
+		Value: DefaultRPCMaxPageSize,
+	}
+	// RPCSubscriptionBufferSizeFlag defines the number of events buffered for a single rpc subscription.
+	RPCSubscriptionBufferSizeFlag = &cli.IntFlag{
+		Name:  "rpc-subscription-buffer-size",
+		Usage: "Number of events buffered for a single rpc subscription whose client falls behind",
+		Value: DefaultEventBufferSize,
+	}
+	// RPCSubscriptionBufferPolicyFlag defines what happens to events of an rpc subscription whose buffer is full.
+	RPCSubscriptionBufferPolicyFlag = &cli.StringFlag{
+		Name: "rpc-subscription-buffer-policy",
+		Usage: "What happens to events of an rpc subscription whose buffer is full: block (the feed pauses until " +
+			"the slow client reads, which stalls other subscriptions), drop-oldest, drop-newest or drop-subscriber " +
+			"(the subscription of the slow client ends, so it resubscribes and catches up from stored history)",
+		Value: DefaultEventBufferPolicy,
+	}
+	// RelayBufferSizeFlag defines the number of chain events buffered for consensus and rpc services.
+	RelayBufferSizeFlag = &cli.IntFlag{
+		Name:  "relay-buffer-size",
+		Usage: "Number of events of vanguard and pandora chain services buffered for consensus and rpc services",
+		Value: DefaultEventBufferSize,
+	}
+	// RelayBufferPolicyFlag defines what happens to chain events when the relay buffer is full.
+	RelayBufferPolicyFlag = &cli.StringFlag{
+		Name: "relay-buffer-policy",
+		Usage: "What happens to chain events when the relay buffer is full: block (chain services wait for " +
+			"consensus), drop-oldest or drop-newest. Reorgs are never dropped",
+		Value: DefaultEventBufferPolicy,
+	}
+
+	// ReadinessMaxLagFlag defines the maximum verification lag of a ready orchestrator.
+	ReadinessMaxLagFlag = &cli.Uint64Flag{
+		Name:  "readiness-max-lag",
+		Usage: "Maximum number of slots verification may lag behind vanguard head before /readyz reports the orchestrator as not ready",
+		Value: DefaultReadinessMaxLag,
+	}
+
+	// MetricsEnabledFlag enables collection and serving of metrics.
+	MetricsEnabledFlag = &cli.BoolFlag{
+		Name:  "metrics",
+		Usage: "Enable metrics collection and serve them in prometheus format on the metrics server",
+	}
+	// MetricsHostFlag defines the listening interface of the metrics server.
+	MetricsHostFlag = &cli.StringFlag{
+		Name:  "metrics-addr",
+		Usage: "Metrics server listening interface",
+		Value: DefaultMetricsHost,
+	}
+	// MetricsPortFlag defines the listening port of the metrics server.
+	MetricsPortFlag = &cli.IntFlag{
+		Name:  "metrics-port",
+		Usage: "Metrics server listening port",
+		Value: DefaultMetricsPort,
+	}
+	// PprofFlag enables the pprof handlers on the metrics server.
+	PprofFlag = &cli.BoolFlag{
+		Name:  "pprof",
+		Usage: "Enable the pprof HTTP handlers on the metrics server under /debug/pprof/",
+	}
+	// TracingEndpointFlag defines the OpenTelemetry collector which traces of the verification pipeline are exported to.
+	TracingEndpointFlag = &cli.StringFlag{
+		Name:  "tracing-endpoint",
+		Usage: "OTLP/HTTP endpoint of an OpenTelemetry collector, e.g. http://localhost:4318, which receives a trace of every slot from vanguard block to verification result (empty = tracing disabled)",
+	}
+	// SentryDSNFlag defines the Sentry project which crashes and repeated errors are reported to.
+	SentryDSNFlag = &cli.StringFlag{
+		Name:  "sentry-dsn",
+		Usage: "Data source name of a Sentry project which receives panics and errors logged repeatedly, tagged with version and network of the node; no report is sent unless it is given (empty = disabled). Also env:NAME or file:PATH to read it from an environment variable or file",
+	}
+
+	// ReplayFlag defines whether the orchestrator replays stored verification results instead of running the node.
+	ReplayFlag = &cli.BoolFlag{
+		Name:  "replay",
+		Usage: "Re-derives verification results purely from the orchestrator database without connecting to any chain, stores them apart and reports the slots which differ from stored results",
+	}
+
+	// FromSlotFlag defines the first slot of a slot range.
+	FromSlotFlag = &cli.Uint64Flag{
+		Name:  "from-slot",
+		Usage: "First slot of the slot range (inclusive)",
+	}
+
+	// ToSlotFlag defines the last slot of a slot range.
+	ToSlotFlag = &cli.Uint64Flag{
+		Name:  "to-slot",
+		Usage: "Last slot of the slot range (inclusive)",
+		Value: math.MaxUint64,
+	}
+
+	// FromEpochFlag defines the first epoch of an epoch range.
+	FromEpochFlag = &cli.Uint64Flag{
+		Name:  "from-epoch",
+		Usage: "First epoch of the epoch range (inclusive)",
+	}
+
+	// ToEpochFlag defines the last epoch of an epoch range.
+	ToEpochFlag = &cli.Uint64Flag{
+		Name:  "to-epoch",
+		Usage: "Last epoch of the epoch range (inclusive)",
+		Value: math.MaxUint64,
+	}
+
+	// BeforeSlotFlag defines the slot before which stored data is removed.
+	BeforeSlotFlag = &cli.Uint64Flag{
+		Name:  "before-slot",
+		Usage: "Slot before which verification results are removed (exclusive), it must not be after latest finalized slot",
+	}
+
+	// BackupFileFlag defines the database backup file to restore.
+	BackupFileFlag = &cli.StringFlag{
+		Name:  "backup-file",
+		Usage: "Path of the database backup file to restore",
+	}
+
+	// ForceFlag skips confirmation prompts of destructive database operations.
+	ForceFlag = &cli.BoolFlag{
+		Name:  "force",
+		Usage: "Skips the confirmation prompt",
+	}
+
+	// BenchmarkSlotsFlag defines the number of synthetic slots which the benchmark feeds through the pipeline.
+	BenchmarkSlotsFlag = &cli.Uint64Flag{
+		Name:  "slots",
+		Usage: "Number of synthetic slots which are fed through the verification pipeline",
+		Value: DefaultBenchmarkSlots,
+	}
+
+	// BenchmarkVanguardRateFlag defines the number of vanguard shard infos which the benchmark streams per second.
+	BenchmarkVanguardRateFlag = &cli.Float64Flag{
+		Name:  "vanguard-rate",
+		Usage: "Number of synthetic vanguard shard infos streamed per second (0 streams them as fast as the pipeline takes them)",
+	}
+
+	// BenchmarkPandoraRateFlag defines the number of pandora headers which the benchmark streams per second.
+	BenchmarkPandoraRateFlag = &cli.Float64Flag{
+		Name:  "pandora-rate",
+		Usage: "Number of synthetic pandora headers streamed per second (0 streams them as fast as the pipeline takes them)",
+	}
+
 	// VerbosityFlag defines the logrus configuration.
 	VerbosityFlag = &cli.StringFlag{
 		Name:  "verbosity",
-		Usage: "Logging verbosity (trace, debug, info=default, warn, error, fatal, panic)",
+		Usage: "Logging verbosity (trace, debug, info=default, warn, error, fatal, panic), optionally followed by comma separated module levels, e.g. info,consensus=debug,rpc=warn",
 		Value: "info",
 	}
 
@@ -89,10 +503,73 @@ var (
 		Value: 536870912, // 512 Mb as a default value.
 	}
 
+	// StatusLogPeriodFlag specifies the period of status summary logs.
+	StatusLogPeriodFlag = &cli.DurationFlag{
+		Name:  "status-log-period",
+		Usage: "Period of the status summary log line with current epoch, verified slot, lag, pending slots and chain connections (0 disables it)",
+		Value: DefaultStatusLogPeriod,
+	}
+
+	// MemoryLimitFlag specifies the soft limit of heap memory.
+	MemoryLimitFlag = &cli.Uint64Flag{
+		Name:  "memory-limit",
+		Usage: "Soft limit of heap memory in megabytes like GOMEMLIMIT, garbage is collected more often as the heap approaches it, to leave memory to pandora and vanguard running on the same host (0 disables it)",
+	}
+
+	// PIDFileFlag specifies the file which the process id of running node is written to.
+	PIDFileFlag = &cli.StringFlag{
+		Name:  "pidfile",
+		Usage: "File to write the process id of the running node to, it is removed on shutdown",
+	}
+
+	// ShutdownTimeoutFlag specifies the time after which a hanging shutdown is aborted.
+	ShutdownTimeoutFlag = &cli.DurationFlag{
+		Name:  "shutdown-timeout",
+		Usage: "Time given to services to stop on shutdown, afterwards stacks of all goroutines are dumped and the process exits with an error (0 waits forever)",
+		Value: DefaultShutdownTimeout,
+	}
+
+	// SyncFromFlag specifies the rpc endpoint of a trusted orchestrator which a fresh node downloads its state from.
+	SyncFromFlag = &cli.StringFlag{
+		Name:  "sync-from",
+		Usage: "Rpc endpoint of a trusted orchestrator of the same network. When the node has no database yet, it downloads consensus infos and slot statuses up to the finalized head of the trusted orchestrator and continues with live verification from there, instead of verifying both chains from genesis",
+	}
+
+	// SyncAPIKeyFlag specifies the api key which snapshot sync sends to the trusted orchestrator.
+	SyncAPIKeyFlag = &cli.StringFlag{
+		Name:  "sync-api-key",
+		Usage: "Api key of this node at the trusted orchestrator of --sync-from, needs a http endpoint. It may be given as env:NAME or file:PATH",
+	}
+
+	// HALeaseFileFlag enables high availability mode with the lease file shared by the orchestrators of a pair.
+	HALeaseFileFlag = &cli.StringFlag{
+		Name:  "ha-lease-file",
+		Usage: "Lease file on storage shared by two orchestrators of the same vanguard and pandora pair, e.g. an NFS mount. Enables high availability mode, only the orchestrator holding the lease serves event subscriptions and confirmations, pushes verdicts to pandora and reports ready, the other one keeps verifying as hot standby. Clocks of the hosts must be synchronized",
+	}
+
+	// HANodeIDFlag specifies the identity of the orchestrator in the lease.
+	HANodeIDFlag = &cli.StringFlag{
+		Name:  "ha-node-id",
+		Usage: "Identity of this orchestrator in the lease file, it must differ between the orchestrators of a pair (default: host name)",
+	}
+
+	// HALeaseDurationFlag specifies the time after which the lease of a failed leader is taken over.
+	HALeaseDurationFlag = &cli.DurationFlag{
+		Name:  "ha-lease-duration",
+		Usage: "Time after which the lease of a leader which stopped renewing it is taken over by the standby orchestrator",
+		Value: DefaultHALeaseDuration,
+	}
+
+	// FollowerFlag starts the node as cold standby which is promoted to leader by an operator.
+	FollowerFlag = &cli.BoolFlag{
+		Name:  "follower",
+		Usage: "Start as cold standby which verifies both chains into its database like the leader, but refuses confirmations and event subscriptions, reports not ready and pushes no verdicts to pandora until it is promoted by admin_promote. Can not be combined with --ha-lease-file",
+	}
+
 	// LogFormat specifies the log output format.
 	LogFormat = &cli.StringFlag{
 		Name:  "log-format",
-		Usage: "Specify log formatting. Supports: text, json, fluentd, journald.",
+		Usage: "Specify log formatting. Supports: text, json, fluentd, journald. json writes an object per line with time, level, msg and component keys.",
 		Value: "text",
 	}
 
@@ -101,4 +578,57 @@ var (
 		Name:  "log-file",
 		Usage: "Specify log file name, relative or absolute",
 	}
+
+	// LogMaxSizeFlag specifies the size of log file which triggers its rotation.
+	LogMaxSizeFlag = &cli.IntFlag{
+		Name:  "log-max-size",
+		Usage: "Maximum size in megabytes of the log file before it is rotated, 0 disables rotation",
+		Value: DefaultLogMaxSize,
+	}
+
+	// LogMaxBackupsFlag specifies the number of rotated log files which are kept.
+	LogMaxBackupsFlag = &cli.IntFlag{
+		Name:  "log-max-backups",
+		Usage: "Maximum number of rotated log files to keep, the oldest ones are removed",
+		Value: DefaultLogMaxBackups,
+	}
+
+	// AuditLogFlag specifies the file which receives audit records of orchestrator decisions.
+	AuditLogFlag = &cli.StringFlag{
+		Name:  "audit-log",
+		Usage: "Append every received consensus info, verified or invalidated slot, reorg and dropped subscription as a JSON line to the given file, relative or absolute (empty = disabled)",
+	}
+
+	// AuditLogMaxSizeFlag specifies the size of audit log which triggers its rotation.
+	AuditLogMaxSizeFlag = &cli.IntFlag{
+		Name:  "audit-log-max-size",
+		Usage: "Maximum size in megabytes of the audit log before it is rotated, 0 disables rotation",
+		Value: DefaultAuditLogMaxSize,
+	}
+
+	// AuditLogMaxBackupsFlag specifies the number of rotated audit logs which are kept.
+	AuditLogMaxBackupsFlag = &cli.IntFlag{
+		Name:  "audit-log-max-backups",
+		Usage: "Maximum number of rotated audit logs to keep, the oldest ones are removed",
+		Value: DefaultAuditLogMaxBackups,
+	}
+
+	// ConfigFileFlag specifies the yaml or toml file which flags are loaded from.
+	ConfigFileFlag = &cli.StringFlag{
+		Name:  "config-file",
+		Usage: "Load flags from a yaml or toml file (.toml extension), flags given on the command line take precedence",
+	}
+
+	// ConfigFormatFlag defines the format of a generated config file.
+	ConfigFormatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: "Format of the generated config file (yaml, toml)",
+		Value: "yaml",
+	}
+
+	// ConfigOutputFlag defines the file which a generated config file is written to.
+	ConfigOutputFlag = &cli.StringFlag{
+		Name:  "output",
+		Usage: "File which the generated config file is written to, it is written to stdout when empty",
+	}
 )
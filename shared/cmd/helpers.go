@@ -3,17 +3,40 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"github.com/lukso-network/lukso-orchestrator/shared/eventbuffer"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 )
 
 var log = logrus.WithField("prefix", "node")
 
+// isTerminal tells whether the file is a terminal which a user can answer prompts in
+var isTerminal = func(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
 // ConfirmAction uses the passed in actionText as the confirmation text displayed in the terminal.
 // The user must enter Y or N to indicate whether they confirm the action detailed in the warning text.
-// Returns a boolean representing the user's answer.
-func ConfirmAction(actionText, deniedText string) (bool, error) {
+// Returns a boolean representing the user's answer. The action is accepted without asking in non-interactive
+// mode, and it fails when standard input is not a terminal, so containers do not wait for input forever.
+func ConfirmAction(cliCtx *cli.Context, actionText, deniedText string) (bool, error) {
+	if cliCtx.Bool(NonInteractiveFlag.Name) {
+		log.Warn(actionText)
+		log.WithField("flag", NonInteractiveFlag.Name).Warn("Accepted in non-interactive mode")
+		return true, nil
+	}
+	if !isTerminal(os.Stdin) {
+		return false, errors.Errorf("cannot ask for confirmation, standard input is not a terminal. "+
+			"Use --%s to accept it", NonInteractiveFlag.Name)
+	}
+
 	var confirmed bool
 	reader := bufio.NewReader(os.Stdin)
 	log.Warn(actionText)
@@ -41,3 +64,47 @@ func ConfirmAction(actionText, deniedText string) (bool, error) {
 
 	return confirmed, nil
 }
+
+// IpcEndpoint resolves the IPC endpoint from the ipc-file and ipcpath flags.
+// Returns an empty string when IPC is not configured.
+func IpcEndpoint(cliCtx *cli.Context) string {
+	if ipcFile := cliCtx.String(IPCFileFlag.Name); ipcFile != "" {
+		return fileutil.IpcEndpoint(ipcFile, "")
+	}
+	if ipcPath := cliCtx.String(IPCPathFlag.Name); ipcPath != "" {
+		return fileutil.IpcEndpoint(filepath.Join(ipcPath, DefaultIpcPath), "")
+	}
+	return ""
+}
+
+// IpcPermissions parses the octal permission bits of the ipc-permissions flag.
+func IpcPermissions(cliCtx *cli.Context) (os.FileMode, error) {
+	value := cliCtx.String(IPCPermissionsFlag.Name)
+	if value == "" {
+		value = DefaultIpcPermissions
+	}
+	perm, err := strconv.ParseUint(value, 8, 32)
+	if err != nil || perm > uint64(os.ModePerm) {
+		return 0, errors.Errorf("invalid %s %q, expected octal permission bits like 0660", IPCPermissionsFlag.Name, value)
+	}
+	return os.FileMode(perm), nil
+}
+
+// EventBufferConfig reads the event buffer config of the given size and policy flags. Flags which are unset or
+// zero fall back to eventbuffer.DefaultConfig.
+func EventBufferConfig(cliCtx *cli.Context, sizeFlag, policyFlag string) (eventbuffer.Config, error) {
+	config := eventbuffer.DefaultConfig
+	if size := cliCtx.Int(sizeFlag); size < 0 {
+		return eventbuffer.Config{}, errors.Errorf("invalid %s %d, at least one event must be buffered", sizeFlag, size)
+	} else if size > 0 {
+		config.Size = size
+	}
+	if name := cliCtx.String(policyFlag); name != "" {
+		policy, err := eventbuffer.ParsePolicy(name)
+		if err != nil {
+			return eventbuffer.Config{}, errors.Wrapf(err, "invalid %s", policyFlag)
+		}
+		config.Policy = policy
+	}
+	return config, nil
+}
@@ -1 +1,94 @@
 package cmd
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/eventbuffer"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestIpcEndpoint(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets only")
+	}
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(IPCPathFlag.Name, "", "")
+	set.String(IPCFileFlag.Name, "", "")
+	assert.Equal(t, "", IpcEndpoint(cli.NewContext(&app, set, nil)))
+
+	require.NoError(t, set.Set(IPCPathFlag.Name, "/data"))
+	assert.Equal(t, filepath.Join("/data", DefaultIpcPath), IpcEndpoint(cli.NewContext(&app, set, nil)))
+
+	require.NoError(t, set.Set(IPCFileFlag.Name, "/run/orchestrator/node.sock"))
+	assert.Equal(t, "/run/orchestrator/node.sock", IpcEndpoint(cli.NewContext(&app, set, nil)))
+}
+
+func TestIpcPermissions(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(IPCPermissionsFlag.Name, DefaultIpcPermissions, "")
+
+	perm, err := IpcPermissions(cli.NewContext(&app, set, nil))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), perm)
+
+	require.NoError(t, set.Set(IPCPermissionsFlag.Name, "660"))
+	perm, err = IpcPermissions(cli.NewContext(&app, set, nil))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0660), perm)
+
+	for _, value := range []string{"rw-rw----", "0999", "17777"} {
+		require.NoError(t, set.Set(IPCPermissionsFlag.Name, value))
+		_, err = IpcPermissions(cli.NewContext(&app, set, nil))
+		assert.ErrorContains(t, "invalid ipc-permissions", err)
+	}
+}
+
+func TestEventBufferConfig(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.Int(RelayBufferSizeFlag.Name, DefaultEventBufferSize, "")
+	set.String(RelayBufferPolicyFlag.Name, DefaultEventBufferPolicy, "")
+
+	config, err := EventBufferConfig(cli.NewContext(&app, set, nil), RelayBufferSizeFlag.Name, RelayBufferPolicyFlag.Name)
+	require.NoError(t, err)
+	assert.Equal(t, eventbuffer.DefaultConfig, config)
+
+	require.NoError(t, set.Set(RelayBufferPolicyFlag.Name, "drop-all"))
+	_, err = EventBufferConfig(cli.NewContext(&app, set, nil), RelayBufferSizeFlag.Name, RelayBufferPolicyFlag.Name)
+	assert.ErrorContains(t, "invalid relay-buffer-policy", err)
+
+	require.NoError(t, set.Set(RelayBufferSizeFlag.Name, "-1"))
+	_, err = EventBufferConfig(cli.NewContext(&app, set, nil), RelayBufferSizeFlag.Name, RelayBufferPolicyFlag.Name)
+	assert.ErrorContains(t, "invalid relay-buffer-size", err)
+
+	// unset flags fall back to the default buffer
+	config, err = EventBufferConfig(cli.NewContext(&app, flag.NewFlagSet("test", 0), nil),
+		RelayBufferSizeFlag.Name, RelayBufferPolicyFlag.Name)
+	require.NoError(t, err)
+	assert.Equal(t, eventbuffer.DefaultConfig, config)
+}
+
+func TestConfirmAction_NonInteractive(t *testing.T) {
+	defer func(f func(*os.File) bool) { isTerminal = f }(isTerminal)
+	isTerminal = func(*os.File) bool { return false }
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.Bool(NonInteractiveFlag.Name, false, "")
+
+	_, err := ConfirmAction(cli.NewContext(&app, set, nil), "Proceed? (Y/N)", "Denied.")
+	assert.ErrorContains(t, "standard input is not a terminal", err)
+
+	require.NoError(t, set.Set(NonInteractiveFlag.Name, "true"))
+	confirmed, err := ConfirmAction(cli.NewContext(&app, set, nil), "Proceed? (Y/N)", "Denied.")
+	require.NoError(t, err)
+	assert.Equal(t, true, confirmed)
+}
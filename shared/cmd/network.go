@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strconv"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// L15Flag selects LUKSO L15 test network.
+	L15Flag = &cli.BoolFlag{
+		Name:  "l15",
+		Usage: "Runs on LUKSO L15 test network: sets default endpoints and pandora chain id, data is stored in l15 subfolder of datadir",
+	}
+	// L16Flag selects LUKSO L16 test network.
+	L16Flag = &cli.BoolFlag{
+		Name:  "l16",
+		Usage: "Runs on LUKSO L16 test network: sets default endpoints and pandora chain id, data is stored in l16 subfolder of datadir",
+	}
+	// DevFlag selects a local development network.
+	DevFlag = &cli.BoolFlag{
+		Name:  "dev",
+		Usage: "Runs on local development network: sets default endpoints and pandora chain id, data is stored in dev subfolder of datadir",
+	}
+	// SlotsPerEpochFlag defines the number of slots in a vanguard epoch.
+	SlotsPerEpochFlag = &cli.Uint64Flag{
+		Name:  "slots-per-epoch",
+		Usage: "Number of slots in a vanguard epoch, it must match the database which is stored on first run",
+		Value: params.SlotsPerEpoch,
+	}
+	// SecondsPerSlotFlag defines the duration of a vanguard slot.
+	SecondsPerSlotFlag = &cli.Uint64Flag{
+		Name:  "seconds-per-slot",
+		Usage: "Duration of a vanguard slot in seconds, it must match the database which is stored on first run",
+		Value: DefaultSecondsPerSlot,
+	}
+	// GenesisTimeFlag defines the genesis time of vanguard chain.
+	GenesisTimeFlag = &cli.Uint64Flag{
+		Name:  "genesis-time",
+		Usage: "Unix time of vanguard genesis, 0 takes the time stored in the database (0 = unknown)",
+	}
+)
+
+// networkPresets are the network configs by the name of their preset flag
+var networkPresets = map[string]*params.NetworkConfig{
+	L15Flag.Name: params.L15Config,
+	L16Flag.Name: params.L16Config,
+	DevFlag.Name: params.DevConfig,
+}
+
+// ApplyNetworkPreset selects the network of the given preset flag. Endpoints, pandora chain id and chain
+// parameters of the network are set unless they are given on the command line or in config file, and datadir is moved into the subfolder
+// of the network, so data of different networks is never mixed. At most one preset flag may be given.
+func ApplyNetworkPreset(cliCtx *cli.Context) error {
+	var config *params.NetworkConfig
+	for name, preset := range networkPresets {
+		if !cliCtx.Bool(name) {
+			continue
+		}
+		if config != nil {
+			return errors.Errorf("only one of --%s, --%s and --%s can be given", L15Flag.Name, L16Flag.Name, DevFlag.Name)
+		}
+		config = preset
+	}
+	if config == nil {
+		return nil
+	}
+
+	defaults := []struct {
+		name  string
+		value string
+	}{
+		{VanguardGRPCEndpoint.Name, config.VanguardGRPCEndpoint},
+		{PandoraRPCEndpoint.Name, config.PandoraRPCEndpoint},
+		{PandoraChainID.Name, strconv.FormatUint(config.PandoraChainID, 10)},
+		{SlotsPerEpochFlag.Name, strconv.FormatUint(config.SlotsPerEpoch, 10)},
+		{SecondsPerSlotFlag.Name, strconv.FormatUint(config.SecondsPerSlot, 10)},
+		{GenesisTimeFlag.Name, strconv.FormatUint(config.GenesisTime, 10)},
+	}
+	for _, d := range defaults {
+		if cliCtx.IsSet(d.name) {
+			continue
+		}
+		if err := cliCtx.Set(d.name, d.value); err != nil {
+			return errors.Wrapf(err, "could not set %s of network %s", d.name, config.Name)
+		}
+	}
+	dataDir := filepath.Join(cliCtx.String(DataDirFlag.Name), config.Name)
+	if err := cliCtx.Set(DataDirFlag.Name, dataDir); err != nil {
+		return errors.Wrapf(err, "could not set %s of network %s", DataDirFlag.Name, config.Name)
+	}
+	params.UseNetworkConfig(config)
+	log.WithField("network", config.Name).WithField("datadir", dataDir).Info("Selected network preset")
+	return nil
+}
+
+// ApplyChainConfig sets chain parameters of the network which the node runs on from their flags, so they are
+// set by network presets, the command line or config file.
+func ApplyChainConfig(cliCtx *cli.Context) error {
+	slotsPerEpoch := cliCtx.Uint64(SlotsPerEpochFlag.Name)
+	if slotsPerEpoch == 0 {
+		return errors.Errorf("%s must be positive", SlotsPerEpochFlag.Name)
+	}
+	config := *params.ActiveNetworkConfig()
+	config.SlotsPerEpoch = slotsPerEpoch
+	config.SecondsPerSlot = cliCtx.Uint64(SecondsPerSlotFlag.Name)
+	config.GenesisTime = cliCtx.Uint64(GenesisTimeFlag.Name)
+	params.UseNetworkConfig(&config)
+	return nil
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/urfave/cli/v2"
+)
+
+func networkContext(t *testing.T, args ...string) *cli.Context {
+	flags := []cli.Flag{
+		L15Flag,
+		L16Flag,
+		DevFlag,
+		DataDirFlag,
+		VanguardGRPCEndpoint,
+		PandoraRPCEndpoint,
+		PandoraChainID,
+		SlotsPerEpochFlag,
+		SecondsPerSlotFlag,
+		GenesisTimeFlag,
+	}
+	set := flag.NewFlagSet("test", 0)
+	for _, f := range flags {
+		require.NoError(t, f.Apply(set))
+	}
+	require.NoError(t, set.Parse(args))
+	return cli.NewContext(&cli.App{Flags: flags}, set, nil)
+}
+
+func TestApplyNetworkPreset(t *testing.T) {
+	defer params.UseNetworkConfig(params.ActiveNetworkConfig())
+
+	dataDir := t.TempDir()
+	cliCtx := networkContext(t, "--l16", "--datadir", dataDir, "--vanguard-grpc-endpoint", "10.0.0.1:4000")
+	require.NoError(t, ApplyNetworkPreset(cliCtx))
+
+	assert.Equal(t, params.L16Config, params.ActiveNetworkConfig())
+	assert.Equal(t, filepath.Join(dataDir, "l16"), cliCtx.String(DataDirFlag.Name))
+	assert.Equal(t, "10.0.0.1:4000", cliCtx.String(VanguardGRPCEndpoint.Name))
+	assert.Equal(t, params.L16Config.PandoraRPCEndpoint, cliCtx.String(PandoraRPCEndpoint.Name))
+	assert.Equal(t, params.L16Config.PandoraChainID, cliCtx.Uint64(PandoraChainID.Name))
+	assert.Equal(t, params.L16Config.SecondsPerSlot, cliCtx.Uint64(SecondsPerSlotFlag.Name))
+	assert.Equal(t, params.L16Config.GenesisTime, cliCtx.Uint64(GenesisTimeFlag.Name))
+}
+
+func TestApplyNetworkPreset_NoPreset(t *testing.T) {
+	cliCtx := networkContext(t)
+	require.NoError(t, ApplyNetworkPreset(cliCtx))
+	assert.Equal(t, DefaultConfigDir(), cliCtx.String(DataDirFlag.Name))
+	assert.Equal(t, "", params.ActiveNetworkConfig().Name)
+}
+
+func TestApplyNetworkPreset_Conflict(t *testing.T) {
+	cliCtx := networkContext(t, "--l15", "--dev")
+	assert.ErrorContains(t, "only one of", ApplyNetworkPreset(cliCtx))
+}
+
+func TestApplyChainConfig(t *testing.T) {
+	defer params.UseNetworkConfig(params.ActiveNetworkConfig())
+
+	cliCtx := networkContext(t, "--l15", "--seconds-per-slot", "12")
+	require.NoError(t, ApplyNetworkPreset(cliCtx))
+	require.NoError(t, ApplyChainConfig(cliCtx))
+	config := params.ActiveNetworkConfig()
+	assert.Equal(t, "l15", config.Name)
+	assert.Equal(t, uint64(params.SlotsPerEpoch), config.SlotsPerEpoch)
+	assert.Equal(t, uint64(12), config.SecondsPerSlot)
+	assert.Equal(t, params.L15Config.GenesisTime, config.GenesisTime)
+	assert.Equal(t, uint64(6), params.L15Config.SecondsPerSlot, "preset must not be changed")
+
+	cliCtx = networkContext(t, "--slots-per-epoch", "0")
+	assert.ErrorContains(t, "slots-per-epoch must be positive", ApplyChainConfig(cliCtx))
+}
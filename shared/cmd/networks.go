@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// NetworksFileFlag defines the file of additional networks which are verified by the same process.
+var NetworksFileFlag = &cli.StringFlag{
+	Name:  "networks-file",
+	Usage: "Yaml or toml file of additional networks verified by this process next to the main network. Top level keys are network names, which name the data directory subfolder networks/<name> and the rpc namespace of the network, values are flags of the network. Networks share chain parameters of the main network",
+}
+
+// NetworkFlags are the flags which can be set for an additional network, other flags are shared with the main
+// network
+var NetworkFlags = []cli.Flag{
+	VanguardGRPCEndpoint,
+	VanguardTLSCACertFlag,
+	VanguardTLSClientCertFlag,
+	VanguardTLSClientKeyFlag,
+	PandoraRPCEndpoint,
+	PandoraRPCNamespace,
+	PandoraSubscriptionMethod,
+	PandoraChainID,
+	PendingTimeoutFlag,
+	VerificationWorkersFlag,
+	VerificationBatchSizeFlag,
+	ConfirmationDepthFlag,
+	OrphanMaxAgeFlag,
+	DivergenceWarnSlotsFlag,
+	DivergenceAlertSlotsFlag,
+	AlertWebhookFlag,
+	AuditLogFlag,
+	SyncFromFlag,
+	SyncAPIKeyFlag,
+}
+
+// NetworkOwnFlags are the flags which an additional network never takes over from the main network, they keep
+// their default unless the network sets them. They point to the chains, files and trusted nodes of a single network.
+var NetworkOwnFlags = []cli.Flag{
+	VanguardTLSCACertFlag,
+	VanguardTLSClientCertFlag,
+	VanguardTLSClientKeyFlag,
+	AuditLogFlag,
+	SyncFromFlag,
+	SyncAPIKeyFlag,
+}
+
+// flags which every additional network must set
+var requiredNetworkFlags = []cli.Flag{VanguardGRPCEndpoint, PandoraRPCEndpoint, PandoraChainID}
+
+// network names are used as rpc namespaces, which must not contain the separator of namespace and method
+var networkNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// namespaces which are served for the main network
+var reservedNetworkNames = map[string]bool{"orc": true, "admin": true, "debug": true, "rpc": true}
+
+// Network is an additional network which is verified by the same process as the main network
+type Network struct {
+	Name string
+	// Flags are the values of network flags by flag name, formatted as command line values. Slice flags may have
+	// several values.
+	Flags map[string][]string
+}
+
+// LoadNetworks reads the file of additional networks and validates their names and flags. Networks are sorted by
+// name.
+func LoadNetworks(path string) ([]*Network, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read networks file")
+	}
+	values, err := parseConfig(path, data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse networks file %s", path)
+	}
+
+	known := make(map[string]cli.Flag)
+	for _, f := range NetworkFlags {
+		for _, name := range f.Names() {
+			known[name] = f
+		}
+	}
+	networks := make([]*Network, 0, len(values))
+	for name, value := range values {
+		if !networkNamePattern.MatchString(name) || reservedNetworkNames[name] {
+			return nil, errors.Errorf("invalid network name %s in networks file %s, names are lowercase letters "+
+				"and digits and must not be orc, admin, debug or rpc", name, path)
+		}
+		flagValues, err := networkFlagValues(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid network %s in networks file %s", name, path)
+		}
+		network := &Network{Name: name, Flags: make(map[string][]string, len(flagValues))}
+		for key, flagValue := range flagValues {
+			f, ok := known[key]
+			if !ok {
+				return nil, errors.Errorf("flag %s can not be set for network %s in networks file %s", key, name, path)
+			}
+			elements, err := configElements(flagValue)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid value of %s of network %s in networks file %s", key, name, path)
+			}
+			// like in the config file, other flags than slice flags receive a list as comma separated string
+			if !isSliceFlag(f) {
+				elements = []string{strings.Join(elements, ",")}
+			}
+			network.Flags[f.Names()[0]] = elements
+		}
+		for _, f := range requiredNetworkFlags {
+			if _, ok := network.Flags[f.Names()[0]]; !ok {
+				return nil, errors.Errorf("network %s in networks file %s must set %s", name, path, f.Names()[0])
+			}
+		}
+		networks = append(networks, network)
+	}
+	sort.Slice(networks, func(i, j int) bool { return networks[i].Name < networks[j].Name })
+	return networks, nil
+}
+
+// networkFlagValues returns the flags of a network, yaml decodes nested keys as interfaces and toml as strings
+func networkFlagValues(value interface{}) (map[string]interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, nil
+	case map[interface{}]interface{}:
+		values := make(map[string]interface{}, len(v))
+		for key, flagValue := range v {
+			name, ok := key.(string)
+			if !ok {
+				return nil, errors.Errorf("flag name %v is not a string", key)
+			}
+			values[name] = flagValue
+		}
+		return values, nil
+	default:
+		return nil, errors.New("network must be a map of flag names to values")
+	}
+}
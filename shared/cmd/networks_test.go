@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestLoadNetworks(t *testing.T) {
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "networks.yaml")
+	require.NoError(t, ioutil.WriteFile(yamlFile, []byte(`
+testnet:
+  vanguard-grpc-endpoint: 127.0.0.1:4001
+  pandora-rpc-endpoint: ws://127.0.0.1:8547
+  pandora-chain-id: 4004182
+  alert-webhook:
+    - http://hooks.example/1
+    - http://hooks.example/2
+devnet:
+  vanguard-grpc-endpoint: 127.0.0.1:4002
+  pandora-rpc-endpoint: ws://127.0.0.1:8548
+  pandora-chain-id: 4004183
+`), 0600))
+
+	networks, err := LoadNetworks(yamlFile)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(networks))
+	assert.Equal(t, "devnet", networks[0].Name)
+	assert.Equal(t, "testnet", networks[1].Name)
+	assert.DeepEqual(t, []string{"127.0.0.1:4001"}, networks[1].Flags[VanguardGRPCEndpoint.Name])
+	assert.DeepEqual(t, []string{"4004182"}, networks[1].Flags[PandoraChainID.Name])
+	assert.DeepEqual(t, []string{"http://hooks.example/1", "http://hooks.example/2"},
+		networks[1].Flags[AlertWebhookFlag.Name])
+
+	tomlFile := filepath.Join(dir, "networks.toml")
+	require.NoError(t, ioutil.WriteFile(tomlFile, []byte(`
+[testnet]
+vanguard-grpc-endpoint = "127.0.0.1:4001"
+pandora-rpc-endpoint = "ws://127.0.0.1:8547"
+pandora-chain-id = 4004182
+`), 0600))
+	networks, err = LoadNetworks(tomlFile)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(networks))
+	assert.DeepEqual(t, []string{"ws://127.0.0.1:8547"}, networks[0].Flags[PandoraRPCEndpoint.Name])
+}
+
+func TestLoadNetworks_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "reserved name",
+			content: "orc:\n  pandora-chain-id: 1\n",
+			err:     "invalid network name orc",
+		},
+		{
+			name:    "namespace separator in name",
+			content: "test_net:\n  pandora-chain-id: 1\n",
+			err:     "invalid network name test_net",
+		},
+		{
+			name:    "shared flag",
+			content: "testnet:\n  slots-per-epoch: 16\n",
+			err:     "flag slots-per-epoch can not be set for network testnet",
+		},
+		{
+			name:    "missing endpoint",
+			content: "testnet:\n  vanguard-grpc-endpoint: 127.0.0.1:4001\n  pandora-chain-id: 1\n",
+			err:     "network testnet in networks file",
+		},
+		{
+			name:    "no flags",
+			content: "testnet: 1\n",
+			err:     "network must be a map of flag names to values",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "networks.yaml")
+			require.NoError(t, ioutil.WriteFile(path, []byte(tt.content), 0600))
+			_, err := LoadNetworks(path)
+			assert.ErrorContains(t, tt.err, err)
+		})
+	}
+}
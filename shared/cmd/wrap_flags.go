@@ -2,33 +2,53 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/urfave/cli/v2"
 	"github.com/urfave/cli/v2/altsrc"
 )
 
-// WrapFlags so that they can be loaded from alternative sources.
+// EnvVarPrefix is the prefix of the environment variables which flags are loaded from.
+const EnvVarPrefix = "LUKSO_ORCHESTRATOR_"
+
+// EnvVarName returns the name of the environment variable of the flag, e.g. LUKSO_ORCHESTRATOR_HTTP_ADDR for
+// http.addr.
+func EnvVarName(flagName string) string {
+	return EnvVarPrefix + strings.NewReplacer("-", "_", ".", "_").Replace(strings.ToUpper(flagName))
+}
+
+// WrapFlags so that they can be loaded from alternative sources. Every flag is bound to its environment variable,
+// a value given on the command line takes precedence over the environment, which takes precedence over config file.
 func WrapFlags(flags []cli.Flag) []cli.Flag {
 	wrapped := make([]cli.Flag, 0, len(flags))
 	for _, f := range flags {
 		switch t := f.(type) {
 		case *cli.BoolFlag:
+			t.EnvVars = bindEnvVar(t.Name, t.EnvVars)
 			f = altsrc.NewBoolFlag(t)
 		case *cli.DurationFlag:
+			t.EnvVars = bindEnvVar(t.Name, t.EnvVars)
 			f = altsrc.NewDurationFlag(t)
 		case *cli.GenericFlag:
+			t.EnvVars = bindEnvVar(t.Name, t.EnvVars)
 			f = altsrc.NewGenericFlag(t)
 		case *cli.Float64Flag:
+			t.EnvVars = bindEnvVar(t.Name, t.EnvVars)
 			f = altsrc.NewFloat64Flag(t)
 		case *cli.IntFlag:
+			t.EnvVars = bindEnvVar(t.Name, t.EnvVars)
 			f = altsrc.NewIntFlag(t)
 		case *cli.StringFlag:
+			t.EnvVars = bindEnvVar(t.Name, t.EnvVars)
 			f = altsrc.NewStringFlag(t)
 		case *cli.StringSliceFlag:
+			t.EnvVars = bindEnvVar(t.Name, t.EnvVars)
 			f = altsrc.NewStringSliceFlag(t)
 		case *cli.Uint64Flag:
+			t.EnvVars = bindEnvVar(t.Name, t.EnvVars)
 			f = altsrc.NewUint64Flag(t)
 		case *cli.UintFlag:
+			t.EnvVars = bindEnvVar(t.Name, t.EnvVars)
 			f = altsrc.NewUintFlag(t)
 		case *cli.Int64Flag:
 			// Int64Flag does not work. See https://github.com/prysmaticlabs/prysm/issues/6478
@@ -40,3 +60,14 @@ func WrapFlags(flags []cli.Flag) []cli.Flag {
 	}
 	return wrapped
 }
+
+// bindEnvVar adds the environment variable of the flag, flags are shared by commands so they may be wrapped again
+func bindEnvVar(name string, envVars []string) []string {
+	envVar := EnvVarName(name)
+	for _, v := range envVars {
+		if v == envVar {
+			return envVars
+		}
+	}
+	return append(envVars, envVar)
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestEnvVarName(t *testing.T) {
+	assert.Equal(t, "LUKSO_ORCHESTRATOR_HTTP_ADDR", EnvVarName("http.addr"))
+	assert.Equal(t, "LUKSO_ORCHESTRATOR_VANGUARD_GRPC_ENDPOINT", EnvVarName("vanguard-grpc-endpoint"))
+}
+
+func TestWrapFlags_EnvVars(t *testing.T) {
+	require.NoError(t, os.Setenv(EnvVarName(HTTPListenAddrFlag.Name), "0.0.0.0"))
+	require.NoError(t, os.Setenv(EnvVarName(HTTPPortFlag.Name), "9545"))
+	defer func() {
+		require.NoError(t, os.Unsetenv(EnvVarName(HTTPListenAddrFlag.Name)))
+		require.NoError(t, os.Unsetenv(EnvVarName(HTTPPortFlag.Name)))
+	}()
+
+	flags := WrapFlags([]cli.Flag{HTTPListenAddrFlag, HTTPPortFlag, HTTPEnabledFlag})
+	// wrapping again must not bind the environment variable twice
+	WrapFlags([]cli.Flag{HTTPPortFlag})
+	assert.DeepEqual(t, []string{EnvVarName(HTTPPortFlag.Name)}, HTTPPortFlag.EnvVars)
+
+	var config map[string]*ConfigValue
+	app := &cli.App{
+		Flags: flags,
+		Action: func(cliCtx *cli.Context) error {
+			config = EffectiveConfig(cliCtx, flags)
+			return nil
+		},
+	}
+	require.NoError(t, app.Run([]string{"orchestrator", "--http.port", "7545"}))
+	assert.DeepEqual(t, &ConfigValue{Value: "0.0.0.0", Source: SourceEnv}, config[HTTPListenAddrFlag.Name])
+	assert.DeepEqual(t, &ConfigValue{Value: "7545", Source: SourceFlag}, config[HTTPPortFlag.Name])
+	assert.DeepEqual(t, &ConfigValue{Value: "false", Source: SourceDefault}, config[HTTPEnabledFlag.Name])
+}
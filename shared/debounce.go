@@ -0,0 +1,98 @@
+package shared
+
+import (
+	"context"
+	"time"
+)
+
+// DebounceEdge selects the edges of a burst of events on which Debounce calls the handler
+type DebounceEdge int
+
+const (
+	// TrailingEdge handles the latest event of a burst once no event has arrived for the interval
+	TrailingEdge DebounceEdge = 1 << iota
+	// LeadingEdge handles the first event of a burst right away
+	LeadingEdge
+)
+
+// DebounceConfig describes how Debounce coalesces bursts of events
+type DebounceConfig struct {
+	// Interval is the time without events which ends a burst
+	Interval time.Duration
+	// Edges on which the handler is called, zero stands for TrailingEdge
+	Edges DebounceEdge
+	// MaxWait ends a burst which lasts longer, so a steady stream of events is still handled. Zero waits forever.
+	MaxWait time.Duration
+}
+
+// Debounce coalesces bursts of events of the channel into calls of the handler on the edges of the config, so
+// e.g. a burst of reorgs is handled once. It returns when the context is done or the channel is closed, the
+// latest event of the burst is handled on the trailing edge before it returns on a closed channel.
+func Debounce(
+	ctx context.Context,
+	cfg DebounceConfig,
+	eventsChan <-chan interface{},
+	handler func(interface{}),
+) {
+	edges := cfg.Edges
+	if edges == 0 {
+		edges = TrailingEdge
+	}
+	var (
+		quiet, deadline *time.Timer
+		latest          interface{}
+		pending         bool // the latest event waits for the trailing edge
+	)
+	timerC := func(t *time.Timer) <-chan time.Time {
+		if t == nil {
+			return nil
+		}
+		return t.C
+	}
+	stopTimers := func() {
+		for _, t := range []*time.Timer{quiet, deadline} {
+			if t != nil {
+				t.Stop()
+			}
+		}
+		quiet, deadline = nil, nil
+	}
+	defer stopTimers()
+	endBurst := func() {
+		stopTimers()
+		if pending && edges&TrailingEdge != 0 {
+			handler(latest)
+		}
+		latest, pending = nil, false
+	}
+
+	for {
+		select {
+		case event, ok := <-eventsChan:
+			if !ok {
+				endBurst()
+				return
+			}
+			if quiet == nil {
+				if cfg.MaxWait > 0 {
+					deadline = time.NewTimer(cfg.MaxWait)
+				}
+				if edges&LeadingEdge != 0 {
+					handler(event)
+					quiet = time.NewTimer(cfg.Interval)
+					continue
+				}
+			} else {
+				quiet.Stop()
+			}
+			quiet = time.NewTimer(cfg.Interval)
+			latest, pending = event, true
+		case <-timerC(quiet):
+			endBurst()
+		case <-timerC(deadline):
+			endBurst()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
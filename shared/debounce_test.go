@@ -0,0 +1,139 @@
+package shared
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func Test_Debounce(t *testing.T) {
+	debounceFunc := func(
+		capacity int,
+		interval time.Duration,
+		checkAfter time.Duration,
+		expectedHandlerCalls int,
+	) {
+		eventChannel := make(chan interface{}, capacity)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		triggeredChannel := make(chan bool)
+
+		handler := func(triggeredTimes interface{}) {
+			triggeredChannel <- true
+		}
+
+		dummyEvent := struct {
+			Something int
+		}{}
+
+		for index := 0; index < capacity; index++ {
+			eventChannel <- dummyEvent
+		}
+
+		triggeredTimes := 0
+
+		go Debounce(ctx, DebounceConfig{Interval: interval}, eventChannel, handler)
+		go func() {
+			for {
+				<-triggeredChannel
+				triggeredTimes++
+			}
+		}()
+
+		time.Sleep(checkAfter)
+		require.Equal(t, expectedHandlerCalls, triggeredTimes)
+	}
+
+	t.Run("should be invoked 1 time", func(t *testing.T) {
+		capacity := 20
+		interval := time.Millisecond * 10
+		checkAfter := interval*time.Duration(capacity) + time.Millisecond*2
+		debounceFunc(capacity, interval, checkAfter, 1)
+	})
+
+	t.Run("should not be invoked", func(t *testing.T) {
+		capacity := 500000
+		interval := time.Millisecond * 10
+		checkAfter := time.Millisecond * 15
+		debounceFunc(capacity, interval, checkAfter, 0)
+	})
+}
+
+// runDebounce sends the events into Debounce, closes the channel and returns the handled events
+func runDebounce(t *testing.T, cfg DebounceConfig, send func(chan<- interface{})) []interface{} {
+	events := make(chan interface{})
+	handled := make([]interface{}, 0)
+	done := make(chan struct{})
+	go func() {
+		Debounce(context.Background(), cfg, events, func(event interface{}) {
+			handled = append(handled, event)
+		})
+		close(done)
+	}()
+	send(events)
+	close(events)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("debounce has not returned")
+	}
+	return handled
+}
+
+func TestDebounce_Edges(t *testing.T) {
+	burst := func(events chan<- interface{}) {
+		for i := 1; i <= 3; i++ {
+			events <- i
+		}
+		time.Sleep(100 * time.Millisecond)
+		events <- 4
+	}
+	interval := 50 * time.Millisecond
+
+	handled := runDebounce(t, DebounceConfig{Interval: interval}, burst)
+	assert.DeepEqual(t, []interface{}{3, 4}, handled)
+
+	handled = runDebounce(t, DebounceConfig{Interval: interval, Edges: LeadingEdge}, burst)
+	assert.DeepEqual(t, []interface{}{1, 4}, handled)
+
+	// an event which has been handled on the leading edge is not handled on the trailing edge again
+	handled = runDebounce(t, DebounceConfig{Interval: interval, Edges: LeadingEdge | TrailingEdge}, burst)
+	assert.DeepEqual(t, []interface{}{1, 3, 4}, handled)
+}
+
+func TestDebounce_MaxWait(t *testing.T) {
+	steady := func(events chan<- interface{}) {
+		for i := 1; i <= 10; i++ {
+			events <- i
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+	handled := runDebounce(t, DebounceConfig{Interval: time.Second, MaxWait: 50 * time.Millisecond}, steady)
+	assert.Equal(t, true, len(handled) > 1, "steady stream is handled before it ends")
+	assert.Equal(t, 10, handled[len(handled)-1])
+
+	handled = runDebounce(t, DebounceConfig{Interval: time.Second}, steady)
+	assert.DeepEqual(t, []interface{}{10}, handled)
+}
+
+func TestDebounce_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan interface{}, 1)
+	done := make(chan struct{})
+	go func() {
+		Debounce(ctx, DebounceConfig{Interval: time.Second}, events, func(interface{}) {
+			t.Error("handler must not be called after context is done")
+		})
+		close(done)
+	}()
+	events <- 1
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("debounce has not returned")
+	}
+}
@@ -0,0 +1,79 @@
+// Package eventbuffer delivers events into buffered channels by a policy which decides what happens when the
+// consumer of the channel falls behind and the buffer is full.
+package eventbuffer
+
+import (
+	"reflect"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+	"github.com/pkg/errors"
+)
+
+// Policy decides what happens to an event when the buffer is full
+type Policy string
+
+const (
+	// Block waits until the consumer makes room, so no event is lost but a slow consumer stalls the producer
+	Block Policy = "block"
+	// DropOldest removes the oldest buffered event to make room for the new one
+	DropOldest Policy = "drop-oldest"
+	// DropNewest discards the new event
+	DropNewest Policy = "drop-newest"
+	// DropSubscriber discards the new event like DropNewest and the consumer is expected to end the subscription,
+	// so a slow subscriber reconnects and catches up instead of silently missing events
+	DropSubscriber Policy = "drop-subscriber"
+)
+
+// Policies are the names of all policies
+var Policies = []string{string(Block), string(DropOldest), string(DropNewest), string(DropSubscriber)}
+
+// ParsePolicy returns the policy of the given name
+func ParsePolicy(name string) (Policy, error) {
+	for _, p := range Policies {
+		if name == p {
+			return Policy(name), nil
+		}
+	}
+	return "", errors.Errorf("unknown buffer policy %q, must be one of %v", name, Policies)
+}
+
+// Config of an event buffer
+type Config struct {
+	Size   int
+	Policy Policy
+}
+
+// DefaultConfig blocks the producer like plain channels do
+var DefaultConfig = Config{Size: 64, Policy: Block}
+
+// Send delivers the value into the channel ch by the policy and counts dropped events. With Block policy it gives
+// up when done is closed. It returns false when the value has not been delivered.
+func Send(ch interface{}, value interface{}, policy Policy, done <-chan struct{}, dropped metrics.Counter) bool {
+	chValue := reflect.ValueOf(ch)
+	v := reflect.ValueOf(value)
+	if policy == DropOldest && chValue.Cap() == 0 {
+		// there is no buffered event to drop
+		policy = DropNewest
+	}
+	switch policy {
+	case DropNewest, DropSubscriber:
+		if chValue.TrySend(v) {
+			return true
+		}
+		dropped.Inc(1)
+		return false
+	case DropOldest:
+		for !chValue.TrySend(v) {
+			if _, ok := chValue.TryRecv(); ok {
+				dropped.Inc(1)
+			}
+		}
+		return true
+	}
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: chValue, Send: v},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(done)},
+	}
+	chosen, _, _ := reflect.Select(cases)
+	return chosen == 0
+}
@@ -0,0 +1,69 @@
+package eventbuffer
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy("drop-oldest")
+	require.NoError(t, err)
+	assert.Equal(t, DropOldest, policy)
+
+	_, err = ParsePolicy("drop-all")
+	assert.ErrorContains(t, "unknown buffer policy", err)
+}
+
+func TestSend_DropNewest(t *testing.T) {
+	dropped := metrics.NewCounterForced()
+	ch := make(chan int, 2)
+	assert.Equal(t, true, Send(ch, 1, DropNewest, nil, dropped))
+	assert.Equal(t, true, Send(ch, 2, DropNewest, nil, dropped))
+	assert.Equal(t, false, Send(ch, 3, DropNewest, nil, dropped))
+	assert.Equal(t, int64(1), dropped.Count())
+	assert.Equal(t, 1, <-ch)
+	assert.Equal(t, 2, <-ch)
+}
+
+func TestSend_DropSubscriber(t *testing.T) {
+	dropped := metrics.NewCounterForced()
+	ch := make(chan int, 1)
+	assert.Equal(t, true, Send(ch, 1, DropSubscriber, nil, dropped))
+	assert.Equal(t, false, Send(ch, 2, DropSubscriber, nil, dropped))
+	assert.Equal(t, int64(1), dropped.Count())
+	assert.Equal(t, 1, <-ch)
+}
+
+func TestSend_DropOldest(t *testing.T) {
+	dropped := metrics.NewCounterForced()
+	ch := make(chan int, 2)
+	for i := 1; i <= 4; i++ {
+		assert.Equal(t, true, Send(ch, i, DropOldest, nil, dropped))
+	}
+	assert.Equal(t, int64(2), dropped.Count())
+	assert.Equal(t, 3, <-ch)
+	assert.Equal(t, 4, <-ch)
+
+	unbuffered := make(chan int)
+	assert.Equal(t, false, Send(unbuffered, 1, DropOldest, nil, dropped))
+	assert.Equal(t, int64(3), dropped.Count())
+}
+
+func TestSend_Block(t *testing.T) {
+	dropped := metrics.NewCounterForced()
+	ch := make(chan int, 1)
+	assert.Equal(t, true, Send(ch, 1, Block, nil, dropped))
+
+	done := make(chan struct{})
+	close(done)
+	assert.Equal(t, false, Send(ch, 2, Block, done, dropped))
+	assert.Equal(t, int64(0), dropped.Count())
+
+	go func() {
+		<-ch
+	}()
+	assert.Equal(t, true, Send(ch, 3, Block, make(chan struct{}), dropped))
+}
@@ -0,0 +1,235 @@
+// Package feed delivers events of one type from the service which produces them to its subscribers. Unlike
+// event.Feed every subscriber receives events through its own buffer, so a slow subscriber is handled by the policy
+// of its buffer instead of stalling the producer and the other subscribers.
+package feed
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared/eventbuffer"
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+	"github.com/pkg/errors"
+)
+
+// feedMetrics collects events dropped by feeds, counted by the name of the feed
+var feedMetrics = metrics.NewCollector("feed")
+
+// ErrClosed is delivered on the error channel of the subscriptions which end because their feed is closed
+var ErrClosed = errors.New("feed closed")
+
+// Feed delivers events of one type to subscribed channels of that type. The zero value is a feed of the type of
+// the first subscribed channel or sent event, which buffers events of subscribers by eventbuffer.DefaultConfig.
+// Events which have a Copy method returning their own type are copied for every subscriber, so subscribers never
+// share an event with the producer or with each other.
+type Feed struct {
+	once    sync.Once
+	name    string
+	etype   reflect.Type
+	buffer  eventbuffer.Config
+	dropped metrics.Counter
+
+	lock   sync.Mutex
+	subs   map[*subscription]struct{}
+	closed bool
+}
+
+// NetworkName returns the name of a feed of the given network, so events dropped by feeds of additional networks
+// are counted by feed/<network>/<name>/dropped. Feeds of the main network, whose name is empty, keep their name.
+func NetworkName(network, name string) string {
+	if network == "" {
+		return name
+	}
+	return network + "/" + name
+}
+
+// New creates a feed of events of the type of sample, e.g. (*types.CanonicalHead)(nil). Subscribers receive
+// events through a buffer of the given config, events dropped by its policy are counted by feed/<name>/dropped.
+func New(name string, sample interface{}, buffer eventbuffer.Config) *Feed {
+	f := &Feed{
+		name:    name,
+		etype:   reflect.TypeOf(sample),
+		buffer:  buffer,
+		dropped: feedMetrics.Counter(name + "/dropped"),
+	}
+	f.init()
+	return f
+}
+
+func (f *Feed) init() {
+	f.once.Do(func() {
+		if f.buffer.Policy == "" {
+			f.buffer = eventbuffer.DefaultConfig
+		}
+		if f.dropped == nil {
+			f.dropped = metrics.NilCounter{}
+		}
+		f.subs = make(map[*subscription]struct{})
+	})
+}
+
+// Subscribe delivers events of the feed into the channel through a buffer of the config of the feed until the
+// subscription is unsubscribed or the feed is closed. It panics when the channel is not of the type of the feed.
+func (f *Feed) Subscribe(channel interface{}) event.Subscription {
+	f.init()
+	return f.SubscribeWithBuffer(channel, f.buffer)
+}
+
+// SubscribeWithBuffer is like Subscribe, but events are buffered by the given config
+func (f *Feed) SubscribeWithBuffer(channel interface{}, buffer eventbuffer.Config) event.Subscription {
+	f.init()
+	chanValue := reflect.ValueOf(channel)
+	chanType := chanValue.Type()
+	if chanType.Kind() != reflect.Chan || chanType.ChanDir()&reflect.SendDir == 0 {
+		panic(errors.Errorf("feed %s: subscribed %v, want a channel which can be sent to", f.name, chanType))
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.etype == nil {
+		f.etype = chanType.Elem()
+	}
+	if chanType.Elem() != f.etype {
+		panic(errors.Errorf("feed %s: subscribed channel of %v, want channel of %v", f.name, chanType.Elem(), f.etype))
+	}
+	sub := &subscription{
+		feed:    f,
+		channel: chanValue,
+		buffer:  reflect.MakeChan(reflect.ChanOf(reflect.BothDir, f.etype), buffer.Size),
+		policy:  buffer.Policy,
+		done:    make(chan struct{}),
+		err:     make(chan error, 1),
+	}
+	if f.closed {
+		sub.end(ErrClosed)
+		return sub
+	}
+	f.subs[sub] = struct{}{}
+	go sub.forward()
+	return sub
+}
+
+// Send delivers the event into the buffers of all subscribers by their policies and returns the number of
+// subscribers which received it. It panics when the event is not of the type of the feed.
+func (f *Feed) Send(value interface{}) int {
+	f.init()
+	v := reflect.ValueOf(value)
+
+	f.lock.Lock()
+	if f.etype == nil {
+		f.etype = v.Type()
+	}
+	if v.Type() != f.etype {
+		f.lock.Unlock()
+		panic(errors.Errorf("feed %s: sent %v, want %v", f.name, v.Type(), f.etype))
+	}
+	subs := make([]*subscription, 0, len(f.subs))
+	for sub := range f.subs {
+		subs = append(subs, sub)
+	}
+	f.lock.Unlock()
+
+	copyEvent := copyMethod(v)
+	nsent := 0
+	for _, sub := range subs {
+		event := value
+		if copyEvent.IsValid() {
+			event = copyEvent.Call(nil)[0].Interface()
+		}
+		if eventbuffer.Send(sub.buffer.Interface(), event, sub.policy, sub.done, f.dropped) {
+			nsent++
+		}
+	}
+	return nsent
+}
+
+// copyMethod returns the Copy method of the event when it returns a copy of the event type
+func copyMethod(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return reflect.Value{}
+	}
+	method := v.MethodByName("Copy")
+	if !method.IsValid() {
+		return reflect.Value{}
+	}
+	methodType := method.Type()
+	if methodType.NumIn() != 0 || methodType.NumOut() != 1 || methodType.Out(0) != v.Type() {
+		return reflect.Value{}
+	}
+	return method
+}
+
+// Close ends all subscriptions with ErrClosed, later subscriptions end right away
+func (f *Feed) Close() {
+	f.init()
+	f.lock.Lock()
+	f.closed = true
+	subs := f.subs
+	f.subs = make(map[*subscription]struct{})
+	f.lock.Unlock()
+
+	for sub := range subs {
+		sub.end(ErrClosed)
+	}
+}
+
+func (f *Feed) remove(sub *subscription) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.subs, sub)
+}
+
+// subscription forwards buffered events of a feed into the channel of a subscriber
+type subscription struct {
+	feed    *Feed
+	channel reflect.Value
+	buffer  reflect.Value
+	policy  eventbuffer.Policy
+	done    chan struct{}
+	err     chan error
+	once    sync.Once
+}
+
+func (s *subscription) forward() {
+	done := reflect.ValueOf(s.done)
+	recvCases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: s.buffer},
+		{Dir: reflect.SelectRecv, Chan: done},
+	}
+	for {
+		chosen, value, _ := reflect.Select(recvCases)
+		if chosen == 1 {
+			return
+		}
+		sendCases := []reflect.SelectCase{
+			{Dir: reflect.SelectSend, Chan: s.channel, Send: value},
+			{Dir: reflect.SelectRecv, Chan: done},
+		}
+		if chosen, _, _ := reflect.Select(sendCases); chosen == 1 {
+			return
+		}
+	}
+}
+
+// Err returns the channel which receives ErrClosed when the feed is closed, it is closed when the subscription ends
+func (s *subscription) Err() <-chan error {
+	return s.err
+}
+
+// Unsubscribe stops delivery of events, buffered events are dropped
+func (s *subscription) Unsubscribe() {
+	s.feed.remove(s)
+	s.end(nil)
+}
+
+// end stops the subscription, err is delivered on the error channel before it is closed unless it is nil
+func (s *subscription) end(err error) {
+	s.once.Do(func() {
+		close(s.done)
+		if err != nil {
+			s.err <- err
+		}
+		close(s.err)
+	})
+}
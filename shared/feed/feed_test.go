@@ -0,0 +1,127 @@
+package feed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/eventbuffer"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+func TestFeed_Send(t *testing.T) {
+	f := New("test", 0, eventbuffer.DefaultConfig)
+	ch := make(chan int)
+	sub := f.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	for i := 1; i <= 3; i++ {
+		assert.Equal(t, 1, f.Send(i))
+	}
+	for i := 1; i <= 3; i++ {
+		assert.Equal(t, i, <-ch)
+	}
+}
+
+func TestFeed_WrongType(t *testing.T) {
+	f := New("test", 0, eventbuffer.DefaultConfig)
+	assertPanics(t, func() { f.Subscribe(make(chan string)) })
+	assertPanics(t, func() { f.Subscribe(make(<-chan int)) })
+	assertPanics(t, func() { f.Send("1") })
+
+	// zero value takes the type of the first subscribed channel
+	var zero Feed
+	zero.Subscribe(make(chan int))
+	assertPanics(t, func() { zero.Send("1") })
+}
+
+// TestFeed_SlowSubscriber checks that a subscriber which does not receive does not stall the others
+func TestFeed_SlowSubscriber(t *testing.T) {
+	f := New("test", 0, eventbuffer.DefaultConfig)
+	slow := make(chan int)
+	slowSub := f.SubscribeWithBuffer(slow, eventbuffer.Config{Size: 1, Policy: eventbuffer.DropNewest})
+	defer slowSub.Unsubscribe()
+	fast := make(chan int, 10)
+	fastSub := f.Subscribe(fast)
+	defer fastSub.Unsubscribe()
+
+	sent := make(chan struct{})
+	go func() {
+		for i := 1; i <= 10; i++ {
+			f.Send(i)
+		}
+		close(sent)
+	}()
+	select {
+	case <-sent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("slow subscriber stalled the feed")
+	}
+	for i := 1; i <= 10; i++ {
+		assert.Equal(t, i, <-fast)
+	}
+	assert.Equal(t, 1, <-slow)
+}
+
+func TestFeed_Unsubscribe(t *testing.T) {
+	f := New("test", 0, eventbuffer.DefaultConfig)
+	ch := make(chan int)
+	sub := f.Subscribe(ch)
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+
+	assert.Equal(t, 0, f.Send(1))
+	err, ok := <-sub.Err()
+	assert.Equal(t, false, ok)
+	assert.NoError(t, err)
+}
+
+func TestFeed_Close(t *testing.T) {
+	f := New("test", 0, eventbuffer.DefaultConfig)
+	sub := f.Subscribe(make(chan int))
+	f.Close()
+
+	err := <-sub.Err()
+	assert.Equal(t, ErrClosed, err)
+	_, ok := <-sub.Err()
+	assert.Equal(t, false, ok)
+	assert.Equal(t, 0, f.Send(1))
+
+	// subscriptions of a closed feed end right away
+	sub = f.Subscribe(make(chan int))
+	assert.Equal(t, ErrClosed, <-sub.Err())
+}
+
+func assertPanics(t *testing.T, fn func()) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	fn()
+}
+
+type copiedEvent struct {
+	values []int
+}
+
+func (e *copiedEvent) Copy() *copiedEvent {
+	return &copiedEvent{values: append([]int{}, e.values...)}
+}
+
+// TestFeed_CopiesEvents checks that every subscriber receives its own copy of events which can be copied
+func TestFeed_CopiesEvents(t *testing.T) {
+	f := New("test", (*copiedEvent)(nil), eventbuffer.DefaultConfig)
+	first := make(chan *copiedEvent, 1)
+	firstSub := f.Subscribe(first)
+	defer firstSub.Unsubscribe()
+	second := make(chan *copiedEvent, 1)
+	secondSub := f.Subscribe(second)
+	defer secondSub.Unsubscribe()
+
+	event := &copiedEvent{values: []int{1, 2}}
+	assert.Equal(t, 2, f.Send(event))
+	received := <-first
+	received.values[0] = 3
+	assert.DeepEqual(t, []int{1, 2}, event.values)
+	assert.DeepEqual(t, []int{1, 2}, (<-second).values)
+}
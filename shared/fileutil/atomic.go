@@ -0,0 +1,87 @@
+package fileutil
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// WriteFileAtomic writes data to the file like ioutil.WriteFile, but the file has either its previous or the new
+// content after a crash or power loss, never a part of it.
+func WriteFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	return WriteAtomic(filename, perm, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// WriteAtomic writes the file by write into a temporary file in the directory of the file, which is synced and
+// renamed over the file. The temporary file is removed when write fails.
+func WriteAtomic(filename string, perm os.FileMode, write func(w io.Writer) error) (err error) {
+	dir := filepath.Dir(filename)
+	f, err := ioutil.TempFile(dir, "."+filepath.Base(filename)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "could not create temporary file")
+	}
+	tmpName := f.Name()
+	defer func() {
+		if err == nil {
+			return
+		}
+		// the file may already be closed, the error of closing it again does not matter
+		_ = f.Close()
+		if removeErr := os.Remove(tmpName); removeErr != nil && !os.IsNotExist(removeErr) {
+			log.WithError(removeErr).WithField("path", tmpName).Warn("Could not remove temporary file")
+		}
+	}()
+
+	if err = write(f); err != nil {
+		return err
+	}
+	if err = f.Chmod(perm); err != nil {
+		return errors.Wrap(err, "could not set permissions of temporary file")
+	}
+	if err = f.Sync(); err != nil {
+		return errors.Wrap(err, "could not sync temporary file")
+	}
+	if err = f.Close(); err != nil {
+		return errors.Wrap(err, "could not close temporary file")
+	}
+	return ReplaceFile(tmpName, filename)
+}
+
+// ReplaceFile renames src to dst and syncs their directories, so the rename survives a crash or power loss
+func ReplaceFile(src, dst string) error {
+	if err := os.Rename(src, dst); err != nil {
+		return err
+	}
+	if err := SyncDir(filepath.Dir(dst)); err != nil {
+		return err
+	}
+	if filepath.Dir(src) != filepath.Dir(dst) {
+		return SyncDir(filepath.Dir(src))
+	}
+	return nil
+}
+
+// SyncDir flushes the entries of the directory to disk, so files created, renamed or removed in it are not lost
+// on power loss. Directories can not be synced on windows, where it does nothing.
+func SyncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return errors.Wrap(err, "could not open directory")
+	}
+	err = d.Sync()
+	if closeErr := d.Close(); err == nil {
+		err = closeErr
+	}
+	return errors.Wrapf(err, "could not sync directory %s", dir)
+}
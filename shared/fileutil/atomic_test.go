@@ -0,0 +1,75 @@
+package fileutil_test
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file")
+	require.NoError(t, fileutil.WriteFileAtomic(path, []byte("first"), 0600))
+	require.NoError(t, fileutil.WriteFileAtomic(path, []byte("second"), 0600))
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+	entries, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(entries), "temporary file left in directory")
+}
+
+func TestWriteAtomic_FailedWriteKeepsFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file")
+	require.NoError(t, fileutil.WriteFileAtomic(path, []byte("content"), 0600))
+
+	err := fileutil.WriteAtomic(path, 0600, func(w io.Writer) error {
+		if _, err := w.Write([]byte("partial")); err != nil {
+			return err
+		}
+		return errors.New("write failed")
+	})
+	assert.ErrorContains(t, "write failed", err)
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+	entries, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(entries), "temporary file left in directory")
+}
+
+func TestReplaceFile(t *testing.T) {
+	dir := t.TempDir()
+	src, dst := filepath.Join(dir, "src"), filepath.Join(dir, "dst")
+	require.NoError(t, ioutil.WriteFile(src, []byte("new"), 0600))
+	require.NoError(t, ioutil.WriteFile(dst, []byte("old"), 0600))
+
+	require.NoError(t, fileutil.ReplaceFile(src, dst))
+	data, err := ioutil.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+	assert.Equal(t, false, fileutil.FileExists(src))
+}
+
+func TestSyncDir(t *testing.T) {
+	assert.NoError(t, fileutil.SyncDir(t.TempDir()))
+	if runtime.GOOS != "windows" {
+		assert.ErrorContains(t, "could not open directory", fileutil.SyncDir(filepath.Join(t.TempDir(), "nonexistent")))
+	}
+}
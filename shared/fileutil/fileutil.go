@@ -190,16 +190,28 @@ func DirFiles(dir string) ([]string, error) {
 	return files, nil
 }
 
-// ipcEndpoint resolves an IPC endpoint based on a configured value, taking into
+// windowsPipePrefix is the namespace every named pipe on windows lives in.
+const windowsPipePrefix = `\\.\pipe\`
+
+// ErrIpcUnsupported is returned when IPC is requested on a platform that has no
+// IPC transport (neither unix domain sockets nor named pipes).
+var ErrIpcUnsupported = errors.New("IPC is not supported on this platform")
+
+// IpcEndpoint resolves an IPC endpoint based on a configured value, taking into
 // account the set data folders as well as the designated platform we're currently
 // running on.
 func IpcEndpoint(ipcPath, datadir string) string {
-	// On windows we can only use plain top-level pipes
-	if runtime.GOOS == "windows" {
-		if strings.HasPrefix(ipcPath, `\\.\pipe\`) {
+	return ipcEndpoint(runtime.GOOS, ipcPath, datadir)
+}
+
+func ipcEndpoint(goos, ipcPath, datadir string) string {
+	// On windows we can only use plain top-level pipes. The pipe namespace is flat,
+	// so only the file name of a socket path can be used as the pipe name.
+	if goos == "windows" {
+		if strings.HasPrefix(ipcPath, windowsPipePrefix) {
 			return ipcPath
 		}
-		return `\\.\pipe\` + ipcPath
+		return windowsPipePrefix + windowsPipeName(ipcPath)
 	}
 	// Resolve names into the data directory full paths otherwise
 	if filepath.Base(ipcPath) == ipcPath {
@@ -211,6 +223,45 @@ func IpcEndpoint(ipcPath, datadir string) string {
 	return ipcPath
 }
 
+// windowsPipeName strips any directory part (with either separator, so unix style
+// paths from configs behave the same) off the given path.
+func windowsPipeName(ipcPath string) string {
+	if i := strings.LastIndexAny(ipcPath, `\/`); i >= 0 {
+		ipcPath = ipcPath[i+1:]
+	}
+	return ipcPath
+}
+
+// ValidateIpcEndpoint checks that the resolved IPC endpoint can actually be opened
+// on the current platform, so callers can fail with a clear message instead of an
+// opaque listen or dial error.
+func ValidateIpcEndpoint(endpoint string) error {
+	return validateIpcEndpoint(runtime.GOOS, endpoint)
+}
+
+func validateIpcEndpoint(goos, endpoint string) error {
+	switch goos {
+	case "js", "plan9":
+		return errors.Wrapf(ErrIpcUnsupported, "%s", goos)
+	case "windows":
+		name := strings.TrimPrefix(endpoint, windowsPipePrefix)
+		if name == endpoint || name == "" || strings.ContainsAny(name, `\/`) {
+			return errors.Errorf("invalid named pipe %q, expected %s<name>", endpoint, windowsPipePrefix)
+		}
+		return nil
+	}
+	// Unix domain socket paths are limited by the size of sockaddr_un.sun_path,
+	// which includes the terminating NUL.
+	maxLen := 108
+	if goos != "linux" {
+		maxLen = 104
+	}
+	if len(endpoint) >= maxLen {
+		return errors.Errorf("IPC socket path %q is too long (%d bytes, max %d), use a shorter --ipcpath", endpoint, len(endpoint), maxLen-1)
+	}
+	return nil
+}
+
 // MkdirAll takes in a path, expands it if necessary, and looks through the
 // permissions of every directory along the path, ensuring we are not attempting
 // to overwrite any existing permissions. Finally, creates the directory accordingly
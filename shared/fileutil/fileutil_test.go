@@ -10,7 +10,9 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"runtime"
 	"sort"
+	"strings"
 	"testing"
 )
 
@@ -190,3 +192,14 @@ func tmpDirWithContentsForRecursiveFind(t *testing.T) (string, []string) {
 	sort.Strings(fnames)
 	return dir, fnames
 }
+
+func TestIpcEndpoint_Unix(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets only")
+	}
+	assert.Equal(t, filepath.Join(os.TempDir(), "orchestrator.ipc"), fileutil.IpcEndpoint("orchestrator.ipc", ""))
+	assert.Equal(t, "/data/orchestrator.ipc", fileutil.IpcEndpoint("orchestrator.ipc", "/data"))
+	assert.Equal(t, "/tmp/x/orchestrator.ipc", fileutil.IpcEndpoint("/tmp/x/orchestrator.ipc", "/data"))
+	assert.NoError(t, fileutil.ValidateIpcEndpoint("/tmp/x/orchestrator.ipc"))
+	assert.ErrorContains(t, "too long", fileutil.ValidateIpcEndpoint("/"+strings.Repeat("a", 200)))
+}
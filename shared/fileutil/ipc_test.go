@@ -0,0 +1,37 @@
+package fileutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/pkg/errors"
+)
+
+func TestIpcEndpoint_Windows(t *testing.T) {
+	tests := map[string]string{
+		"orchestrator.ipc":                     `\\.\pipe\orchestrator.ipc`,
+		`C:\Users\lukso\data\orchestrator.ipc`: `\\.\pipe\orchestrator.ipc`,
+		"/tmp/orchestrator/orchestrator.ipc":   `\\.\pipe\orchestrator.ipc`,
+		`\\.\pipe\custom`:                      `\\.\pipe\custom`,
+	}
+	for path, expected := range tests {
+		endpoint := ipcEndpoint("windows", path, "")
+		assert.Equal(t, expected, endpoint)
+		assert.NoError(t, validateIpcEndpoint("windows", endpoint))
+	}
+}
+
+func TestValidateIpcEndpoint(t *testing.T) {
+	assert.ErrorContains(t, "invalid named pipe", validateIpcEndpoint("windows", `C:\data\orchestrator.ipc`))
+	assert.ErrorContains(t, "invalid named pipe", validateIpcEndpoint("windows", `\\.\pipe\a\b`))
+	assert.ErrorContains(t, "invalid named pipe", validateIpcEndpoint("windows", `\\.\pipe\`))
+
+	err := validateIpcEndpoint("js", "/tmp/orchestrator.ipc")
+	assert.Equal(t, true, errors.Is(err, ErrIpcUnsupported))
+
+	assert.NoError(t, validateIpcEndpoint("darwin", "/tmp/orchestrator.ipc"))
+	long := "/" + strings.Repeat("a", 105)
+	assert.ErrorContains(t, "too long", validateIpcEndpoint("darwin", long))
+	assert.NoError(t, validateIpcEndpoint("linux", long))
+}
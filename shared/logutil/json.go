@@ -0,0 +1,52 @@
+package logutil
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Keys of json log entries which are shared by all services, log pipelines can rely on them instead of
+// parsing messages.
+const (
+	JSONTimeKey      = "time"
+	JSONLevelKey     = "level"
+	JSONMessageKey   = "msg"
+	JSONComponentKey = "component"
+)
+
+// field which names the service of a log entry, it is set by the package loggers of every service
+const prefixField = "prefix"
+
+// jsonFormatter writes an entry as a json object per line, the prefix of package loggers becomes component key
+type jsonFormatter struct {
+	logrus.JSONFormatter
+}
+
+// NewJSONFormatter returns the formatter of json log format. Timestamps have RFC3339 format with nanoseconds
+// in UTC, so entries of several nodes can be ordered.
+func NewJSONFormatter() logrus.Formatter {
+	return &jsonFormatter{logrus.JSONFormatter{
+		TimestampFormat: time.RFC3339Nano,
+		FieldMap: logrus.FieldMap{
+			logrus.FieldKeyTime:  JSONTimeKey,
+			logrus.FieldKeyLevel: JSONLevelKey,
+			logrus.FieldKeyMsg:   JSONMessageKey,
+		},
+	}}
+}
+
+func (f *jsonFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	clone := *entry
+	clone.Time = entry.Time.UTC()
+	if prefix, ok := entry.Data[prefixField]; ok {
+		clone.Data = make(logrus.Fields, len(entry.Data))
+		for key, value := range entry.Data {
+			if key != prefixField {
+				clone.Data[key] = value
+			}
+		}
+		clone.Data[JSONComponentKey] = prefix
+	}
+	return f.JSONFormatter.Format(&clone)
+}
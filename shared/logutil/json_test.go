@@ -0,0 +1,35 @@
+package logutil
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/sirupsen/logrus"
+)
+
+func TestJSONFormatter(t *testing.T) {
+	logger := logrus.New()
+	entry := logger.WithField("prefix", "consensus").WithField("slot", 12).WithError(errors.New("mismatch"))
+	entry.Time = time.Date(2021, 5, 4, 12, 0, 0, 500, time.FixedZone("CEST", 2*60*60))
+	entry.Level = logrus.WarnLevel
+	entry.Message = "Invalid slot"
+
+	out, err := NewJSONFormatter().Format(entry)
+	require.NoError(t, err)
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &fields))
+	assert.DeepEqual(t, map[string]interface{}{
+		JSONTimeKey:      "2021-05-04T10:00:00.0000005Z",
+		JSONLevelKey:     "warning",
+		JSONMessageKey:   "Invalid slot",
+		JSONComponentKey: "consensus",
+		logrus.ErrorKey:  "mismatch",
+		"slot":           float64(12),
+	}, fields)
+	// the entry of the caller is left untouched
+	assert.Equal(t, "consensus", entry.Data["prefix"])
+}
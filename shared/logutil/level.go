@@ -0,0 +1,117 @@
+package logutil
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ModuleLevels are log levels of verbosity flag. Modules are named by the prefix of their package logger,
+// modules without level of their own log at the default level.
+type ModuleLevels struct {
+	Default logrus.Level
+	Modules map[string]logrus.Level
+}
+
+// ParseVerbosity parses the default level and comma separated module levels, for example
+// "info,consensus=debug,rpc=warn". The default level is info when it is not given.
+func ParseVerbosity(verbosity string) (*ModuleLevels, error) {
+	levels := &ModuleLevels{Default: logrus.InfoLevel, Modules: make(map[string]logrus.Level)}
+	defaultSet := false
+	for _, part := range strings.Split(verbosity, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		module, levelName := "", part
+		if i := strings.Index(part, "="); i >= 0 {
+			module, levelName = strings.TrimSpace(part[:i]), strings.TrimSpace(part[i+1:])
+			if module == "" {
+				return nil, fmt.Errorf("missing module name in verbosity %q", part)
+			}
+		}
+		level, err := logrus.ParseLevel(levelName)
+		if err != nil {
+			return nil, err
+		}
+		if module == "" {
+			if defaultSet {
+				return nil, fmt.Errorf("default verbosity is given twice in %q", verbosity)
+			}
+			levels.Default, defaultSet = level, true
+			continue
+		}
+		levels.Modules[module] = level
+	}
+	return levels, nil
+}
+
+// maxLevel returns the most verbose level of all modules
+func (l *ModuleLevels) maxLevel() logrus.Level {
+	max := l.Default
+	for _, level := range l.Modules {
+		if level > max {
+			max = level
+		}
+	}
+	return max
+}
+
+// enabled reports whether the entry is logged by the level of its module
+func (l *ModuleLevels) enabled(entry *logrus.Entry) bool {
+	level := l.Default
+	if prefix, ok := entry.Data[prefixField].(string); ok {
+		if moduleLevel, ok := l.Modules[prefix]; ok {
+			level = moduleLevel
+		}
+	}
+	return entry.Level <= level
+}
+
+// levelFormatter leaves out the entries of modules whose level is less verbose than the entry
+type levelFormatter struct {
+	next logrus.Formatter
+
+	lock   sync.RWMutex
+	levels *ModuleLevels
+}
+
+func (f *levelFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	f.lock.RLock()
+	enabled := f.levels.enabled(entry)
+	f.lock.RUnlock()
+	if !enabled {
+		return nil, nil
+	}
+	return f.next.Format(entry)
+}
+
+// SetVerbosity applies the levels of verbosity flag to the standard logger. The logger logs at the most verbose
+// level of all modules and its formatter leaves out the entries which are filtered by their module level, so
+// it must be called after the formatter is set. Calling it again replaces the levels. Hooks like journald
+// receive the entries of the most verbose level.
+func SetVerbosity(verbosity string) error {
+	levels, err := ParseVerbosity(verbosity)
+	if err != nil {
+		return err
+	}
+	logger := logrus.StandardLogger()
+	if len(levels.Modules) == 0 {
+		if filter, ok := logger.Formatter.(*levelFormatter); ok {
+			logger.SetFormatter(filter.next)
+		}
+		logger.SetLevel(levels.Default)
+		return nil
+	}
+	if filter, ok := logger.Formatter.(*levelFormatter); ok {
+		filter.lock.Lock()
+		filter.levels = levels
+		filter.lock.Unlock()
+	} else {
+		logger.SetFormatter(&levelFormatter{next: logger.Formatter, levels: levels})
+	}
+	logger.SetLevel(levels.maxLevel())
+	return nil
+}
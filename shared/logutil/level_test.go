@@ -0,0 +1,63 @@
+package logutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/sirupsen/logrus"
+)
+
+func TestParseVerbosity(t *testing.T) {
+	levels, err := ParseVerbosity("debug")
+	require.NoError(t, err)
+	assert.Equal(t, logrus.DebugLevel, levels.Default)
+	assert.Equal(t, 0, len(levels.Modules))
+
+	levels, err = ParseVerbosity("consensus=debug, rpc=warn")
+	require.NoError(t, err)
+	assert.Equal(t, logrus.InfoLevel, levels.Default)
+	assert.DeepEqual(t, map[string]logrus.Level{"consensus": logrus.DebugLevel, "rpc": logrus.WarnLevel}, levels.Modules)
+	assert.Equal(t, logrus.DebugLevel, levels.maxLevel())
+
+	_, err = ParseVerbosity("info,consensus=loud")
+	assert.ErrorContains(t, "not a valid logrus Level", err)
+	_, err = ParseVerbosity("info,=debug")
+	assert.ErrorContains(t, "missing module name", err)
+	_, err = ParseVerbosity("info,warn")
+	assert.ErrorContains(t, "default verbosity is given twice", err)
+}
+
+func TestSetVerbosity(t *testing.T) {
+	logger := logrus.StandardLogger()
+	formatter, level, out := logger.Formatter, logger.Level, logger.Out
+	defer func() {
+		logger.SetFormatter(formatter)
+		logger.SetLevel(level)
+		logger.SetOutput(out)
+	}()
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+	logger.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true})
+
+	require.NoError(t, SetVerbosity("warn,consensus=debug"))
+	logrus.WithField("prefix", "consensus").Debug("consensus debug")
+	logrus.WithField("prefix", "rpc").Info("rpc info")
+	logrus.WithField("prefix", "rpc").Warn("rpc warn")
+	assert.Equal(t, true, bytes.Contains(buf.Bytes(), []byte("consensus debug")))
+	assert.Equal(t, false, bytes.Contains(buf.Bytes(), []byte("rpc info")))
+	assert.Equal(t, true, bytes.Contains(buf.Bytes(), []byte("rpc warn")))
+
+	// levels are replaced without wrapping the formatter again
+	require.NoError(t, SetVerbosity("warn,rpc=info"))
+	filter, ok := logger.Formatter.(*levelFormatter)
+	require.Equal(t, true, ok)
+	_, wrapped := filter.next.(*levelFormatter)
+	assert.Equal(t, false, wrapped)
+
+	require.NoError(t, SetVerbosity("error"))
+	_, ok = logger.Formatter.(*levelFormatter)
+	assert.Equal(t, false, ok)
+	assert.Equal(t, logrus.ErrorLevel, logger.Level)
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/lukso-network/lukso-orchestrator/shared/params"
 	"io"
 	"net/url"
-	"os"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -17,10 +16,13 @@ func addLogWriter(w io.Writer) {
 	logrus.SetOutput(mw)
 }
 
-// ConfigurePersistentLogging adds a log-to-file writer. File content is identical to stdout.
-func ConfigurePersistentLogging(logFileName string) error {
-	logrus.WithField("logFileName", logFileName).Info("Logs will be made persistent")
-	f, err := os.OpenFile(logFileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, params.OrchestratorIoConfig().ReadWritePermissions)
+// ConfigurePersistentLogging adds a log-to-file writer. File content is identical to stdout. The file is rotated
+// once it would exceed maxSizeMB megabytes, keeping maxBackups rotated files. Zero maxSizeMB disables rotation.
+func ConfigurePersistentLogging(logFileName string, maxSizeMB int, maxBackups int) error {
+	logrus.WithField("logFileName", logFileName).WithField("maxSizeMB", maxSizeMB).
+		WithField("maxBackups", maxBackups).Info("Logs will be made persistent")
+	f, err := OpenRotatingFile(logFileName, params.OrchestratorIoConfig().ReadWritePermissions,
+		int64(maxSizeMB)*1024*1024, maxBackups)
 	if err != nil {
 		return err
 	}
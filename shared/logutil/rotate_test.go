@@ -0,0 +1,60 @@
+package logutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func readLog(t *testing.T, path string) string {
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orchestrator.log")
+	require.NoError(t, ioutil.WriteFile(path, []byte("old\n"), 0600))
+
+	f, err := OpenRotatingFile(path, 0600, 10, 2)
+	require.NoError(t, err)
+	// history of previous run is kept
+	_, err = f.Write([]byte("first\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "old\nfirst\n", readLog(t, path))
+
+	for _, entry := range []string{"second\n", "third\n", "fourth\n"} {
+		_, err = f.Write([]byte(entry))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, "fourth\n", readLog(t, path))
+	assert.Equal(t, "third\n", readLog(t, path+".1"))
+	assert.Equal(t, "second\n", readLog(t, path+".2"))
+	_, err = os.Stat(path + ".3")
+	assert.Equal(t, true, os.IsNotExist(err))
+
+	// entries longer than the limit are written to a file of their own
+	_, err = f.Write([]byte("a long entry\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "a long entry\n", readLog(t, path))
+	assert.Equal(t, "fourth\n", readLog(t, path+".1"))
+	require.NoError(t, f.file.Close())
+}
+
+func TestRotatingFile_NoBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orchestrator.log")
+	f, err := OpenRotatingFile(path, 0600, 8, 0)
+	require.NoError(t, err)
+	for _, entry := range []string{"first\n", "second\n"} {
+		_, err = f.Write([]byte(entry))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, "second\n", readLog(t, path))
+	_, err = os.Stat(path + ".1")
+	assert.Equal(t, true, os.IsNotExist(err))
+	require.NoError(t, f.file.Close())
+}
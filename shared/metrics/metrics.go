@@ -0,0 +1,103 @@
+// Package metrics registers metrics of orchestrator services. Every service registers its metrics through its own
+// collector, which names them orchestrator/<service>/<name>, so they are served in prometheus format as
+// orchestrator_<service>_<name> and services never deal with the registry or prometheus themselves.
+package metrics
+
+import (
+	"net/http"
+	"strings"
+
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/metrics/prometheus"
+)
+
+// Namespace is the first part of names of all orchestrator metrics
+const Namespace = "orchestrator"
+
+// Metric types which services keep
+type (
+	Counter  = gethmetrics.Counter
+	Gauge    = gethmetrics.Gauge
+	Meter    = gethmetrics.Meter
+	Timer    = gethmetrics.Timer
+	Registry = gethmetrics.Registry
+
+	NilCounter = gethmetrics.NilCounter
+)
+
+// Enable turns collection of metrics on. It must be called before services register their metrics, metrics which
+// are registered while collection is disabled do nothing.
+func Enable() {
+	gethmetrics.Enabled = true
+}
+
+// Enabled tells whether metrics are collected
+func Enabled() bool {
+	return gethmetrics.Enabled
+}
+
+// DefaultRegistry returns the registry which collectors register metrics in unless they are given another one
+func DefaultRegistry() Registry {
+	return gethmetrics.DefaultRegistry
+}
+
+// Handler serves the metrics of the registry in prometheus text format
+func Handler(registry Registry) http.Handler {
+	return prometheus.Handler(registry)
+}
+
+// Collector registers metrics of a single service in a registry
+type Collector struct {
+	prefix   string
+	registry Registry
+}
+
+// NewCollector creates the collector of metrics of the given service in the default registry
+func NewCollector(service string) *Collector {
+	return NewCollectorWithRegistry(DefaultRegistry(), service)
+}
+
+// NewCollectorWithRegistry creates the collector of metrics of the given service in the given registry
+func NewCollectorWithRegistry(registry Registry, service string) *Collector {
+	return &Collector{
+		prefix:   Namespace + "/" + strings.Trim(service, "/") + "/",
+		registry: registry,
+	}
+}
+
+// Sub returns a collector which registers metrics of the service under the given name, e.g. the name of an
+// additional network. An empty name returns the collector itself, so the main network keeps its metric names.
+func (c *Collector) Sub(name string) *Collector {
+	if strings.Trim(name, "/") == "" {
+		return c
+	}
+	return &Collector{
+		prefix:   c.prefix + strings.Trim(name, "/") + "/",
+		registry: c.registry,
+	}
+}
+
+// Name returns the full name of the metric of the service with the given name
+func (c *Collector) Name(name string) string {
+	return c.prefix + name
+}
+
+// Counter returns the counter of the given name, it is registered on first use
+func (c *Collector) Counter(name string) Counter {
+	return gethmetrics.GetOrRegisterCounter(c.Name(name), c.registry)
+}
+
+// Gauge returns the gauge of the given name, it is registered on first use
+func (c *Collector) Gauge(name string) Gauge {
+	return gethmetrics.GetOrRegisterGauge(c.Name(name), c.registry)
+}
+
+// Meter returns the meter of the given name, it is registered on first use
+func (c *Collector) Meter(name string) Meter {
+	return gethmetrics.GetOrRegisterMeter(c.Name(name), c.registry)
+}
+
+// Timer returns the timer of the given name, it is registered on first use
+func (c *Collector) Timer(name string) Timer {
+	return gethmetrics.GetOrRegisterTimer(c.Name(name), c.registry)
+}
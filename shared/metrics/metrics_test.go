@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestCollector(t *testing.T) {
+	Enable()
+	registry := gethmetrics.NewRegistry()
+	collector := NewCollectorWithRegistry(registry, "consensus")
+	assert.Equal(t, "orchestrator/consensus/slots/invalid", collector.Name("slots/invalid"))
+
+	collector.Counter("slots/invalid").Inc(3)
+	assert.Equal(t, int64(3), collector.Counter("slots/invalid").Count(), "counter is registered once")
+	collector.Sub("testnet").Gauge("lag").Update(2)
+	assert.Equal(t, true, registry.Get("orchestrator/consensus/testnet/lag") != nil)
+	// metrics of the main network keep their names, additional networks collect theirs apart
+	assert.Equal(t, collector, collector.Sub(""))
+	collector.Sub("l16").Counter("slots/invalid").Inc(1)
+	assert.Equal(t, int64(3), collector.Counter("slots/invalid").Count())
+
+	recorder := httptest.NewRecorder()
+	Handler(registry).ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := ioutil.ReadAll(recorder.Body)
+	require.NoError(t, err)
+	assert.Equal(t, true, strings.Contains(string(body), "orchestrator_consensus_slots_invalid 3"))
+	assert.Equal(t, true, strings.Contains(string(body), "orchestrator_consensus_testnet_lag 2"))
+}
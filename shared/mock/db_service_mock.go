@@ -203,6 +203,20 @@ func (mr *MockDatabaseMockRecorder) DatabasePath() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DatabasePath", reflect.TypeOf((*MockDatabase)(nil).DatabasePath))
 }
 
+// MMapSize mocks base method
+func (m *MockDatabase) MMapSize() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MMapSize")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// MMapSize indicates an expected call of MMapSize
+func (mr *MockDatabaseMockRecorder) MMapSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MMapSize", reflect.TypeOf((*MockDatabase)(nil).MMapSize))
+}
+
 // ClearDB mocks base method
 func (m *MockDatabase) ClearDB() error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,5 @@
+package params
+
+// SlotsPerEpoch is the default number of slots in a vanguard epoch. Minimal consensus info of an epoch lists one
+// proposer per slot. The number of the network which the node runs on is ActiveNetworkConfig().SlotsPerEpoch.
+const SlotsPerEpoch = 32
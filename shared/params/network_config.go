@@ -0,0 +1,57 @@
+package params
+
+// NetworkConfig describes a known LUKSO network, it provides defaults of the node for the network
+type NetworkConfig struct {
+	Name                 string // also the name of the data directory subfolder of the network
+	GenesisTime          uint64 // unix time of vanguard genesis, zero when it is not known
+	SecondsPerSlot       uint64
+	SlotsPerEpoch        uint64
+	VanguardGRPCEndpoint string
+	PandoraRPCEndpoint   string
+	PandoraChainID       uint64
+}
+
+// L15Config is the config of LUKSO L15 test network
+var L15Config = &NetworkConfig{
+	Name:                 "l15",
+	GenesisTime:          1633082400,
+	SecondsPerSlot:       6,
+	SlotsPerEpoch:        SlotsPerEpoch,
+	VanguardGRPCEndpoint: "127.0.0.1:4000",
+	PandoraRPCEndpoint:   "ws://127.0.0.1:8546",
+	PandoraChainID:       23,
+}
+
+// L16Config is the config of LUKSO L16 test network
+var L16Config = &NetworkConfig{
+	Name:                 "l16",
+	GenesisTime:          1646065800,
+	SecondsPerSlot:       12,
+	SlotsPerEpoch:        SlotsPerEpoch,
+	VanguardGRPCEndpoint: "127.0.0.1:4000",
+	PandoraRPCEndpoint:   "ws://127.0.0.1:8546",
+	PandoraChainID:       2828,
+}
+
+// DevConfig is the config of a local development network, its genesis time differs on every setup
+var DevConfig = &NetworkConfig{
+	Name:                 "dev",
+	SecondsPerSlot:       6,
+	SlotsPerEpoch:        SlotsPerEpoch,
+	VanguardGRPCEndpoint: "127.0.0.1:4000",
+	PandoraRPCEndpoint:   "ws://127.0.0.1:8546",
+	PandoraChainID:       4004,
+}
+
+// config of the network which the node runs on, it is empty when no network preset has been selected
+var activeNetworkConfig = &NetworkConfig{SlotsPerEpoch: SlotsPerEpoch}
+
+// ActiveNetworkConfig returns the config of the network which the node runs on
+func ActiveNetworkConfig() *NetworkConfig {
+	return activeNetworkConfig
+}
+
+// UseNetworkConfig selects the network which the node runs on
+func UseNetworkConfig(config *NetworkConfig) {
+	activeNetworkConfig = config
+}
@@ -0,0 +1,162 @@
+// Package secrets loads secrets of the node, e.g. jwt secrets, tls keys and webhook urls with tokens, from files
+// or environment variables. Loaded values are wrapped in Secret, which is never printed by fmt, logrus or json,
+// and errors name the source of a secret but never its value.
+package secrets
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	// EnvPrefix references an environment variable which holds the secret, e.g. env:ORCHESTRATOR_JWT_SECRET
+	EnvPrefix = "env:"
+	// FilePrefix references a file which holds the secret, e.g. file:/run/secrets/jwt.hex
+	FilePrefix = "file:"
+)
+
+// printed instead of the value of a secret
+const redacted = "<redacted>"
+
+// Secret is the value of a secret along with where it has been loaded from
+type Secret struct {
+	value  []byte
+	source string
+}
+
+// Bytes returns the value of the secret
+func (s *Secret) Bytes() []byte {
+	return s.value
+}
+
+// Text returns the value of the secret without surrounding white space, e.g. the trailing new line of a file
+func (s *Secret) Text() string {
+	return strings.TrimSpace(string(s.value))
+}
+
+// Source describes where the secret has been loaded from, e.g. file /run/secrets/jwt.hex or env FOO
+func (s *Secret) Source() string {
+	return s.source
+}
+
+// String hides the value of the secret, so it is not logged by accident
+func (s *Secret) String() string {
+	return redacted
+}
+
+// GoString hides the value of the secret from %#v
+func (s *Secret) GoString() string {
+	return redacted
+}
+
+// MarshalText hides the value of the secret from json and text encoders
+func (s *Secret) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
+
+// Load loads the secret of the reference, which is env:NAME for an environment variable or a path of a file,
+// optionally prefixed with file:. Files which other users can read are loaded with a warning.
+func Load(ref string) (*Secret, error) {
+	if name := strings.TrimPrefix(ref, EnvPrefix); name != ref {
+		return loadEnv(name)
+	}
+	return loadFile(strings.TrimPrefix(ref, FilePrefix))
+}
+
+// Resolve returns the value of flags whose value is a secret itself, e.g. a webhook url with a token. Values with
+// env: or file: prefix are loaded from the referenced environment variable or file, other values are returned as
+// they are.
+func Resolve(value string) (string, error) {
+	if !strings.HasPrefix(value, EnvPrefix) && !strings.HasPrefix(value, FilePrefix) {
+		return value, nil
+	}
+	secret, err := Load(value)
+	if err != nil {
+		return "", err
+	}
+	return secret.Text(), nil
+}
+
+func loadEnv(name string) (*Secret, error) {
+	if name == "" {
+		return nil, errors.New("secret reference env: has no variable name")
+	}
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, errors.Errorf("environment variable %s of secret is not set", name)
+	}
+	if strings.TrimSpace(value) == "" {
+		return nil, errors.Errorf("environment variable %s of secret is empty", name)
+	}
+	return &Secret{value: []byte(value), source: "env " + name}, nil
+}
+
+func loadFile(path string) (*Secret, error) {
+	if path == "" {
+		return nil, errors.New("secret file path is empty")
+	}
+	expanded, err := fileutil.ExpandPath(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not expand secret file path %s", path)
+	}
+	info, err := os.Stat(expanded)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read secret file")
+	}
+	if info.IsDir() {
+		return nil, errors.Errorf("secret file %s is a directory", path)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		log.WithField("path", path).WithField("permissions", info.Mode().Perm().String()).
+			Warn("Secret file can be read by other users, restrict its permissions to the owner")
+	}
+	value, err := ioutil.ReadFile(expanded)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read secret file")
+	}
+	if len(strings.TrimSpace(string(value))) == 0 {
+		return nil, errors.Errorf("secret file %s is empty", path)
+	}
+	return &Secret{value: value, source: "file " + path}, nil
+}
+
+// CheckPEM checks that the secret holds a PEM encoded block whose type ends with the given suffix, e.g.
+// PRIVATE KEY for RSA, EC and PKCS #8 private keys
+func (s *Secret) CheckPEM(typeSuffix string) error {
+	block, _ := pem.Decode(s.value)
+	if block == nil {
+		return errors.Errorf("secret of %s is not PEM encoded", s.source)
+	}
+	if !strings.HasSuffix(block.Type, typeSuffix) {
+		return errors.Errorf("secret of %s is a PEM %s block, expected %s", s.source, block.Type, typeSuffix)
+	}
+	return nil
+}
+
+// LoadX509KeyPair loads a PEM encoded certificate from the file and its private key from the secret of keyRef
+func LoadX509KeyPair(certFile, keyRef string) (tls.Certificate, error) {
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "could not read certificate")
+	}
+	key, err := Load(keyRef)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	if err := key.CheckPEM("PRIVATE KEY"); err != nil {
+		return tls.Certificate{}, err
+	}
+	cert, err := tls.X509KeyPair(certPEM, key.Bytes())
+	if err != nil {
+		// errors of the key pair do not contain the key, but name its source
+		return tls.Certificate{}, errors.Wrapf(err, "certificate %s does not match key of %s", certFile, key.Source())
+	}
+	return cert, nil
+}
@@ -0,0 +1,132 @@
+package secrets
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestLoad(t *testing.T) {
+	hook := logTest.NewGlobal()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "jwt.hex")
+	require.NoError(t, ioutil.WriteFile(path, []byte("0x0102\n"), 0600))
+
+	secret, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "0x0102", secret.Text())
+	assert.Equal(t, "file "+path, secret.Source())
+	secret, err = Load(FilePrefix + path)
+	require.NoError(t, err)
+	assert.Equal(t, "0x0102\n", string(secret.Bytes()))
+	require.LogsDoNotContain(t, hook, "can be read by other users")
+
+	require.NoError(t, os.Chmod(path, 0644))
+	_, err = Load(path)
+	require.NoError(t, err)
+	require.LogsContain(t, hook, "can be read by other users")
+
+	require.NoError(t, os.Setenv("SECRETS_TEST_VALUE", "0x0304"))
+	defer os.Unsetenv("SECRETS_TEST_VALUE")
+	secret, err = Load(EnvPrefix + "SECRETS_TEST_VALUE")
+	require.NoError(t, err)
+	assert.Equal(t, "0x0304", secret.Text())
+	assert.Equal(t, "env SECRETS_TEST_VALUE", secret.Source())
+
+	empty := filepath.Join(dir, "empty")
+	require.NoError(t, ioutil.WriteFile(empty, []byte(" \n"), 0600))
+	require.NoError(t, os.Setenv("SECRETS_TEST_EMPTY", ""))
+	defer os.Unsetenv("SECRETS_TEST_EMPTY")
+	for ref, want := range map[string]string{
+		EnvPrefix + "SECRETS_TEST_MISSING": "environment variable SECRETS_TEST_MISSING of secret is not set",
+		EnvPrefix + "SECRETS_TEST_EMPTY":   "environment variable SECRETS_TEST_EMPTY of secret is empty",
+		EnvPrefix:                          "has no variable name",
+		filepath.Join(dir, "missing"):      "could not read secret file",
+		dir:                                "is a directory",
+		empty:                              "is empty",
+	} {
+		_, err := Load(ref)
+		assert.ErrorContains(t, want, err, ref)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	require.NoError(t, os.Setenv("SECRETS_TEST_WEBHOOK", "https://hooks.example/T0/B0/token\n"))
+	defer os.Unsetenv("SECRETS_TEST_WEBHOOK")
+	value, err := Resolve(EnvPrefix + "SECRETS_TEST_WEBHOOK")
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.example/T0/B0/token", value)
+
+	value, err = Resolve("https://hooks.example/1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.example/1", value)
+	value, err = Resolve("")
+	require.NoError(t, err)
+	assert.Equal(t, "", value)
+
+	_, err = Resolve(EnvPrefix + "SECRETS_TEST_MISSING")
+	assert.ErrorContains(t, "is not set", err)
+}
+
+// TestSecret_Redacted checks that the value of a secret is not printed by fmt and json
+func TestSecret_Redacted(t *testing.T) {
+	secret := &Secret{value: []byte("hunter2"), source: "env PASSWORD"}
+	for _, printed := range []string{
+		fmt.Sprint(secret), fmt.Sprintf("%s %v %+v %#v", secret, secret, secret, secret),
+	} {
+		assert.Equal(t, false, strings.Contains(printed, "hunter2"), printed)
+	}
+	encoded, err := json.Marshal(map[string]interface{}{"secret": secret})
+	require.NoError(t, err)
+	var decoded map[string]string
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, redacted, decoded["secret"])
+}
+
+func TestLoadX509KeyPair(t *testing.T) {
+	dir := t.TempDir()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certFile := filepath.Join(dir, "tls.crt")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	require.NoError(t, ioutil.WriteFile(certFile, certPEM, 0600))
+	require.NoError(t, os.Setenv("SECRETS_TEST_TLS_KEY", string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))))
+	defer os.Unsetenv("SECRETS_TEST_TLS_KEY")
+
+	cert, err := LoadX509KeyPair(certFile, EnvPrefix+"SECRETS_TEST_TLS_KEY")
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(cert.Certificate))
+
+	_, err = LoadX509KeyPair(certFile, certFile)
+	assert.ErrorContains(t, "is a PEM CERTIFICATE block, expected PRIVATE KEY", err)
+
+	require.NoError(t, os.Setenv("SECRETS_TEST_TLS_KEY", "not a key"))
+	_, err = LoadX509KeyPair(certFile, EnvPrefix+"SECRETS_TEST_TLS_KEY")
+	assert.ErrorContains(t, "secret of env SECRETS_TEST_TLS_KEY is not PEM encoded", err)
+}
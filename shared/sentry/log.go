@@ -0,0 +1,5 @@
+package sentry
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "sentry")
@@ -0,0 +1,298 @@
+// Package sentry reports crashes and repeated errors of the orchestrator to a Sentry project, so maintainers
+// get crash telemetry from node operators who opt in by giving the data source name of the project.
+package sentry
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sentrygo "github.com/getsentry/sentry-go"
+	"github.com/lukso-network/lukso-orchestrator/shared"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ClientName identifies the orchestrator to Sentry
+const ClientName = "lukso-orchestrator"
+
+const (
+	// timeout of sending the queued events when the process exits or the reporter stops
+	flushTimeout = 10 * time.Second
+	// an error is reported once it is logged repeatThreshold times within repeatWindow, at most once per window
+	repeatThreshold = 3
+	repeatWindow    = 10 * time.Minute
+	// number of distinct error messages whose repetitions are counted
+	maxTrackedErrors = 1024
+)
+
+// urlPattern matches urls within log messages and errors, whose credentials are redacted before they are reported
+var urlPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s"'<>]+`)
+
+// sentryMetrics collects events reported to Sentry and events which could not be sent in time
+var sentryMetrics = metrics.NewCollector("sentry")
+
+// active is the reporter which receives panics of the main goroutine, it holds a reporterHolder
+var active atomic.Value
+
+type reporterHolder struct {
+	reporter *Reporter
+}
+
+// Enable makes the reporter receive panics passed to CapturePanic
+func Enable(reporter *Reporter) {
+	active.Store(reporterHolder{reporter: reporter})
+}
+
+// CapturePanic reports the panic to the enabled reporter and waits until it is sent, so it is reported before
+// the process exits. It does nothing when error reporting is not enabled.
+func CapturePanic(reason interface{}, stack []byte) {
+	holder, _ := active.Load().(reporterHolder)
+	if holder.reporter == nil {
+		return
+	}
+	holder.reporter.capture(holder.reporter.panicEvent("main", reason, stack))
+	holder.reporter.flush()
+}
+
+// Config defines the Sentry project which receives reports and the tags of every report
+type Config struct {
+	DSN string
+	// Release is the version of the orchestrator which is reported
+	Release string
+	// Tags are attached to every event, e.g. version and network
+	Tags map[string]string
+	// Transport sends the events, the HTTP transport of sentry-go is used when it is nil
+	Transport sentrygo.Transport
+}
+
+// Reporter sends crashes of services and errors which repeat to Sentry. It is a logrus hook which receives
+// every error of the node, and a service of the node which reports crashes of the other services.
+type Reporter struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+	runError  error
+	errLock   sync.Mutex
+
+	hub  *sentrygo.Hub
+	done chan struct{}
+
+	repeatLock sync.Mutex
+	repeats    map[string]*repeat
+
+	sent   metrics.Counter
+	failed metrics.Counter
+}
+
+// repeat counts the occurrences of an error message within the window which starts with its first occurrence
+type repeat struct {
+	since time.Time
+	count int
+}
+
+// NewReporter creates a reporter to the Sentry project of the data source name of the config
+func NewReporter(ctx context.Context, cfg *Config) (*Reporter, error) {
+	client, err := sentrygo.NewClient(sentrygo.ClientOptions{
+		Dsn:       cfg.DSN,
+		Release:   cfg.Release,
+		Transport: cfg.Transport,
+		// the host name of the operator is not reported
+		ServerName: ClientName,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create sentry client")
+	}
+	scope := sentrygo.NewScope()
+	scope.SetTags(cfg.Tags)
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+	return &Reporter{
+		ctx:     ctx,
+		cancel:  cancel,
+		hub:     sentrygo.NewHub(client, scope),
+		done:    make(chan struct{}),
+		repeats: make(map[string]*repeat),
+		sent:    sentryMetrics.Counter("sent"),
+		failed:  sentryMetrics.Counter("failed"),
+	}, nil
+}
+
+// Start starts reporting crashes of services
+func (r *Reporter) Start() {
+	if r.isRunning {
+		log.Error("Attempted to start error reporter when it was already started")
+		return
+	}
+	r.isRunning = true
+	log.Info("Started error reporter")
+	go r.run()
+}
+
+// Stop sends queued events and stops the reporter
+func (r *Reporter) Stop() error {
+	r.cancel()
+	if r.isRunning {
+		r.isRunning = false
+		<-r.done
+	}
+	r.flush()
+	return nil
+}
+
+// Status returns an error when queued events could not be sent in time, it is nil once they are sent again
+func (r *Reporter) Status() error {
+	r.errLock.Lock()
+	defer r.errLock.Unlock()
+	return r.runError
+}
+
+func (r *Reporter) run() {
+	defer close(r.done)
+	defer shared.RecoverService(r)
+
+	crashCh := make(chan *shared.ServiceCrash, 1)
+	crashSub := shared.SubscribeServiceCrashes(crashCh)
+	defer crashSub.Unsubscribe()
+
+	for {
+		select {
+		case crash := <-crashCh:
+			r.capture(r.panicEvent(crash.Service.String(), crash.Reason, crash.Stack))
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+// Levels returns the log levels which are reported, it implements logrus.Hook
+func (r *Reporter) Levels() []logrus.Level {
+	return []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel}
+}
+
+// Fire reports fatal errors right away and errors once they repeat, it implements logrus.Hook
+func (r *Reporter) Fire(entry *logrus.Entry) error {
+	switch entry.Level {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		// the process exits after the entry is logged, so it is sent before
+		r.capture(r.logEvent(entry, 1))
+		r.flush()
+	default:
+		if n := r.repeated(entry.Message, entry.Time); n > 0 {
+			r.capture(r.logEvent(entry, n))
+		}
+	}
+	return nil
+}
+
+// repeated counts the occurrence of the error message and returns the number of occurrences within the window
+// when the error is due to be reported, or zero otherwise
+func (r *Reporter) repeated(message string, now time.Time) int {
+	if now.IsZero() {
+		now = time.Now()
+	}
+	r.repeatLock.Lock()
+	defer r.repeatLock.Unlock()
+
+	rep, ok := r.repeats[message]
+	if !ok || now.Sub(rep.since) > repeatWindow {
+		if !ok && len(r.repeats) >= maxTrackedErrors {
+			r.pruneRepeats(now)
+			if len(r.repeats) >= maxTrackedErrors {
+				return 0
+			}
+		}
+		rep = &repeat{since: now}
+		r.repeats[message] = rep
+	}
+	rep.count++
+	if rep.count == repeatThreshold {
+		return rep.count
+	}
+	return 0
+}
+
+// pruneRepeats forgets errors whose window has ended
+func (r *Reporter) pruneRepeats(now time.Time) {
+	for message, rep := range r.repeats {
+		if now.Sub(rep.since) > repeatWindow {
+			delete(r.repeats, message)
+		}
+	}
+}
+
+// capture queues the event in the transport of the hub, it never blocks the caller which logs the error
+func (r *Reporter) capture(event *sentrygo.Event) {
+	if r.hub.CaptureEvent(event) == nil {
+		r.failed.Inc(1)
+		return
+	}
+	r.sent.Inc(1)
+}
+
+// flush waits until the queued events are sent
+func (r *Reporter) flush() {
+	var err error
+	if !r.hub.Flush(flushTimeout) {
+		err = errors.Errorf("could not send error reports within %s", flushTimeout)
+		log.WithError(err).Warn("Could not send error reports")
+	}
+	r.errLock.Lock()
+	r.runError = err
+	r.errLock.Unlock()
+}
+
+// panicEvent creates an event of a panic which has been recovered in the given goroutine
+func (r *Reporter) panicEvent(goroutine string, reason interface{}, stack []byte) *sentrygo.Event {
+	value := redactText("", fmt.Sprint(reason))
+	event := sentrygo.NewEvent()
+	event.Level = sentrygo.LevelFatal
+	event.Logger = goroutine
+	event.Message = fmt.Sprintf("panic in %s: %s", goroutine, value)
+	event.Exception = []sentrygo.Exception{{
+		Type:       "panic",
+		Value:      value,
+		Stacktrace: &sentrygo.Stacktrace{Frames: parseStack(stack)},
+	}}
+	return event
+}
+
+// logEvent creates an event of the log entry which has occurred the given number of times
+func (r *Reporter) logEvent(entry *logrus.Entry, occurrences int) *sentrygo.Event {
+	event := sentrygo.NewEvent()
+	event.Level = sentrygo.LevelError
+	if entry.Level == logrus.PanicLevel || entry.Level == logrus.FatalLevel {
+		event.Level = sentrygo.LevelFatal
+	}
+	event.Timestamp = entry.Time
+	// the message and the fields, the error included, may hold endpoints whose credentials must not leave the node
+	event.Message = redactText("", entry.Message)
+	for key, value := range entry.Data {
+		if key == "prefix" {
+			event.Logger = fmt.Sprint(value)
+			continue
+		}
+		event.Extra[key] = redactText(key, fmt.Sprint(value))
+	}
+	if occurrences > 1 {
+		event.Extra["occurrences"] = occurrences
+		event.Extra["window"] = repeatWindow.String()
+	}
+	return event
+}
+
+// redactText hides the value of a sensitive field, and the credentials of every url within the text
+func redactText(name, text string) string {
+	text = cmd.RedactValue(name, text)
+	return urlPattern.ReplaceAllStringFunc(text, func(match string) string {
+		// punctuation which ends a sentence or precedes the next part of an error is not part of the url
+		u := strings.TrimRight(match, ".,:;)")
+		return cmd.RedactValue(name, u) + match[len(u):]
+	})
+}
@@ -0,0 +1,52 @@
+package sentry
+
+import (
+	"strconv"
+	"strings"
+
+	sentrygo "github.com/getsentry/sentry-go"
+)
+
+// module path of the orchestrator, frames of its packages are marked as application code
+const modulePath = "github.com/lukso-network/lukso-orchestrator"
+
+// parseStack converts a goroutine stack of runtime/debug.Stack into frames, the outermost call first as Sentry
+// expects. Lines which are not part of a frame are skipped.
+func parseStack(stack []byte) []sentrygo.Frame {
+	lines := strings.Split(strings.TrimSpace(string(stack)), "\n")
+	var frames []sentrygo.Frame
+	for i := 0; i+1 < len(lines); i++ {
+		function := strings.TrimSpace(lines[i])
+		location := lines[i+1]
+		if function == "" || !strings.HasPrefix(location, "\t") {
+			continue
+		}
+		i++
+		function = strings.TrimPrefix(function, "created by ")
+		if creator := strings.Index(function, " in goroutine "); creator > 0 {
+			function = function[:creator]
+		}
+		if paren := strings.LastIndex(function, "("); paren > 0 {
+			function = function[:paren]
+		}
+		f := sentrygo.Frame{
+			Function: function,
+			InApp:    strings.HasPrefix(function, modulePath) || strings.HasPrefix(function, "main."),
+		}
+		location = strings.TrimSpace(location)
+		if offset := strings.LastIndex(location, " +0x"); offset >= 0 {
+			location = location[:offset]
+		}
+		if colon := strings.LastIndex(location, ":"); colon >= 0 {
+			f.AbsPath = location[:colon]
+			f.Lineno, _ = strconv.Atoi(location[colon+1:])
+		} else {
+			f.AbsPath = location
+		}
+		frames = append(frames, f)
+	}
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+	return frames
+}
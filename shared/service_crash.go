@@ -0,0 +1,56 @@
+package shared
+
+import (
+	"fmt"
+	"reflect"
+	"runtime/debug"
+
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared/eventbuffer"
+	"github.com/lukso-network/lukso-orchestrator/shared/feed"
+)
+
+// ServiceCrash is a panic which has been recovered in a goroutine of a service
+type ServiceCrash struct {
+	Service reflect.Type
+	// Registry is the registry which the crashed service is registered in, nodes of several networks run services
+	// of the same type in their own registries. It is nil for services which are not registered.
+	Registry *ServiceRegistry
+	Reason   interface{}
+	Stack    []byte
+}
+
+func (c *ServiceCrash) Error() string {
+	return fmt.Sprintf("service %v crashed: %v", c.Service, c.Reason)
+}
+
+// serviceCrashFeed sends crashes of all services, services report them without knowing their registry
+var serviceCrashFeed = feed.New("service/crash", (*ServiceCrash)(nil), eventbuffer.DefaultConfig)
+
+// RecoverService recovers a panic of the calling goroutine and reports it as a crash of the service. It must be
+// deferred by long running goroutines of services, the goroutine ends after the crash is reported.
+func RecoverService(service Service) {
+	reason := recover()
+	if reason == nil {
+		return
+	}
+	reportCrash(service, reason)
+}
+
+// reportCrash reports the recovered panic as a crash of the service
+func reportCrash(service Service, reason interface{}) *ServiceCrash {
+	crash := &ServiceCrash{
+		Service:  reflect.TypeOf(service),
+		Registry: registryOf(service),
+		Reason:   reason,
+		Stack:    debug.Stack(),
+	}
+	log.WithField("service", crash.Service.String()).Errorf("Service crashed: %v\n%s", reason, crash.Stack)
+	serviceCrashFeed.Send(crash)
+	return crash
+}
+
+// SubscribeServiceCrashes subscribes to crashes which are recovered by RecoverService
+func SubscribeServiceCrashes(ch chan<- *ServiceCrash) event.Subscription {
+	return serviceCrashFeed.Subscribe(ch)
+}
@@ -0,0 +1,51 @@
+package shared
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+type panickingService struct {
+	mockService
+}
+
+func (m *panickingService) Start() { panic("start failed") }
+
+// TestRecoverService checks that panics of service goroutines and of Start are reported as crashes
+func TestRecoverService(t *testing.T) {
+	crashes := make(chan *ServiceCrash, 2)
+	sub := SubscribeServiceCrashes(crashes)
+	defer sub.Unsubscribe()
+
+	svc := newMockService()
+	go func() {
+		defer RecoverService(svc)
+		panic("goroutine failed")
+	}()
+	select {
+	case crash := <-crashes:
+		assert.Equal(t, reflect.TypeOf(svc), crash.Service)
+		assert.Equal(t, true, crash.Registry == nil, "service is not registered")
+		assert.Equal(t, "goroutine failed", crash.Reason)
+		assert.ErrorContains(t, "crashed: goroutine failed", crash)
+	case <-time.After(time.Second):
+		t.Fatal("crash has not been reported")
+	}
+
+	registry := NewServiceRegistry()
+	require.NoError(t, registry.RegisterService(&panickingService{*newMockService()}))
+	assert.ErrorContains(t, "start failed", registry.StartAll())
+	select {
+	case crash := <-crashes:
+		assert.Equal(t, "start failed", crash.Reason)
+		assert.Equal(t, registry, crash.Registry)
+	case <-time.After(time.Second):
+		t.Fatal("crash has not been reported")
+	}
+	// crashed service is not started
+	assert.Equal(t, 1, len(registry.NotStarted()))
+}
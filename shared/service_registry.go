@@ -4,6 +4,9 @@ package shared
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 )
@@ -22,45 +25,396 @@ type Service interface {
 	Status() error
 }
 
+// ReadyNotifier is implemented by services which other services depend on. The returned channel
+// is closed once the service is ready to be used by its dependents.
+type ReadyNotifier interface {
+	Ready() <-chan struct{}
+}
+
+// ServiceState is the stage of its lifecycle which a registered service is in
+type ServiceState string
+
+const (
+	// ServiceRegistered services have not been started by StartAll yet
+	ServiceRegistered ServiceState = "registered"
+	// ServiceWaiting services wait for their dependencies to be started and ready
+	ServiceWaiting ServiceState = "waiting"
+	// ServiceStarted services have been started successfully
+	ServiceStarted ServiceState = "started"
+	// ServiceFailed services have panicked in Start or reported an error by Status right after Start
+	ServiceFailed ServiceState = "failed"
+	// ServiceStopped services have been stopped along with the registry
+	ServiceStopped ServiceState = "stopped"
+)
+
+// StartError holds the errors of the services which failed to start by their type
+type StartError map[reflect.Type]error
+
+func (e StartError) Error() string {
+	failures := make([]string, 0, len(e))
+	for kind, err := range e {
+		failures = append(failures, fmt.Sprintf("%v: %v", kind, err))
+	}
+	sort.Strings(failures)
+	return "services failed to start: " + strings.Join(failures, "; ")
+}
+
 // ServiceRegistry provides a useful pattern for managing services.
 // It allows for ease of dependency management and ensures services
 // dependent on others use the same references in memory.
 type ServiceRegistry struct {
-	services     map[reflect.Type]Service // map of types to services.
-	serviceTypes []reflect.Type           // keep an ordered slice of registered service types.
+	services     map[reflect.Type]Service        // map of types to services.
+	serviceTypes []reflect.Type                  // keep an ordered slice of registered service types.
+	dependencies map[reflect.Type][]reflect.Type // map of types to services which must be started and ready before.
+	started      map[reflect.Type]chan struct{}  // map of types to channels which are closed once Start has returned.
+	settled      map[reflect.Type]chan struct{}  // map of types to channels which are closed once a start attempt ends.
+	states       map[reflect.Type]ServiceState   // map of types to the stage of their lifecycle.
+	startErrs    map[reflect.Type]error          // map of types to the reason why they failed to start.
+	starting     map[reflect.Type]chan struct{}  // map of types to channels which are closed once a running Start returns.
+	replaced     chan struct{}                   // closed and renewed whenever a service is replaced.
+	stop         chan struct{}                   // closed when services are stopped.
+	lock         sync.RWMutex                    // guards the maps and replaced, it is never held while Start or Stop run.
+}
+
+// owners maps registered services to their registry, so crashes of services tell which registry they belong to
+var (
+	owners     = make(map[Service]*ServiceRegistry)
+	ownersLock sync.RWMutex
+)
+
+// registryOf returns the registry which the service is registered in, or nil
+func registryOf(service Service) *ServiceRegistry {
+	if !reflect.TypeOf(service).Comparable() {
+		return nil
+	}
+	ownersLock.RLock()
+	defer ownersLock.RUnlock()
+	return owners[service]
+}
+
+// setOwner records the registry of the service, a nil registry forgets the service
+func setOwner(service Service, registry *ServiceRegistry) {
+	if !reflect.TypeOf(service).Comparable() {
+		return
+	}
+	ownersLock.Lock()
+	defer ownersLock.Unlock()
+	if registry == nil {
+		delete(owners, service)
+		return
+	}
+	owners[service] = registry
 }
 
 // NewServiceRegistry starts a registry instance for convenience
 func NewServiceRegistry() *ServiceRegistry {
 	return &ServiceRegistry{
-		services: make(map[reflect.Type]Service),
+		services:     make(map[reflect.Type]Service),
+		dependencies: make(map[reflect.Type][]reflect.Type),
+		started:      make(map[reflect.Type]chan struct{}),
+		settled:      make(map[reflect.Type]chan struct{}),
+		states:       make(map[reflect.Type]ServiceState),
+		startErrs:    make(map[reflect.Type]error),
+		starting:     make(map[reflect.Type]chan struct{}),
+		replaced:     make(chan struct{}),
+		stop:         make(chan struct{}),
+	}
+}
+
+// StartAll initializes services in topological order of their dependencies. A service is started once
+// all of its dependencies have been started and report ready, services which do not depend on each other
+// are started concurrently. No service is started when a dependency is not registered or dependencies
+// form a cycle.
+//
+// StartAll waits for the start of every service whose dependencies are ready, services which wait for a
+// dependency to get ready keep starting in the background and their failures are only recorded in the state of
+// the registry. When a service fails to start, all services are stopped and the errors are returned as StartError.
+func (s *ServiceRegistry) StartAll() error {
+	order, err := s.topologicalOrder()
+	if err != nil {
+		return err
+	}
+	log.Debugf("Starting %d services: %v", len(order), order)
+	s.lock.Lock()
+	for _, kind := range order {
+		s.states[kind] = ServiceWaiting
+	}
+	s.lock.Unlock()
+	for _, kind := range order {
+		go s.startWhenReady(kind)
 	}
+
+	waiting := make(map[reflect.Type]bool)
+	startErrs := make(StartError)
+	for _, kind := range order {
+		if s.waitsForDependency(kind, waiting) {
+			waiting[kind] = true
+			continue
+		}
+		s.lock.RLock()
+		settled := s.settled[kind]
+		s.lock.RUnlock()
+		<-settled
+
+		s.lock.RLock()
+		err := s.startErrs[kind]
+		s.lock.RUnlock()
+		if err != nil {
+			startErrs[kind] = err
+		}
+	}
+	if len(startErrs) > 0 {
+		s.StopAll()
+		return startErrs
+	}
+	return nil
+}
+
+// waitsForDependency tells whether a dependency of the service has not been started or is not ready yet. The
+// given services are known to wait for their dependencies already.
+func (s *ServiceRegistry) waitsForDependency(kind reflect.Type, waiting map[reflect.Type]bool) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	for _, dep := range s.dependencies[kind] {
+		if waiting[dep] || s.states[dep] != ServiceStarted {
+			return true
+		}
+		if notifier, ok := s.services[dep].(ReadyNotifier); ok {
+			select {
+			case <-notifier.Ready():
+			default:
+				return true
+			}
+		}
+	}
+	return false
 }
 
-// StartAll initialized each service in order of registration.
-func (s *ServiceRegistry) StartAll() {
-	log.Debugf("Starting %d services: %v", len(s.serviceTypes), s.serviceTypes)
+// topologicalOrder sorts service types so every service follows its dependencies. Services which do not
+// depend on each other keep the order of registration.
+func (s *ServiceRegistry) topologicalOrder() ([]reflect.Type, error) {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	order := make([]reflect.Type, 0, len(s.serviceTypes))
+	state := make(map[reflect.Type]int, len(s.serviceTypes))
+	var visit func(kind reflect.Type) error
+	visit = func(kind reflect.Type) error {
+		switch state[kind] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle of service %v", kind)
+		}
+		state[kind] = visiting
+		for _, dep := range s.dependencies[kind] {
+			if _, exists := s.services[dep]; !exists {
+				return fmt.Errorf("service %v depends on unregistered service %v", kind, dep)
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[kind] = visited
+		order = append(order, kind)
+		return nil
+	}
 	for _, kind := range s.serviceTypes {
-		log.Debugf("Starting service type %v", kind)
-		go s.services[kind].Start()
+		if err := visit(kind); err != nil {
+			return nil, err
+		}
 	}
+	return order, nil
 }
 
-// StopAll ends every service in reverse order of registration, logging a
-// panic if any of them fail to stop.
+// startWhenReady waits for every dependency to be started and ready and starts the service afterwards.
+// It gives up when the registry is stopped or the service is replaced in the meantime.
+func (s *ServiceRegistry) startWhenReady(kind reflect.Type) {
+	s.lock.RLock()
+	service, started, settled := s.services[kind], s.started[kind], s.settled[kind]
+	s.lock.RUnlock()
+	defer close(settled)
+
+	for _, dep := range s.dependencies[kind] {
+		log.Debugf("Service type %v is waiting for dependency %v", kind, dep)
+		if !s.waitForDependency(dep) {
+			log.Debugf("Registry stopped before dependencies of service type %v got ready", kind)
+			return
+		}
+	}
+
+	s.lock.Lock()
+	select {
+	case <-s.stop:
+		s.lock.Unlock()
+		log.Debugf("Registry stopped before service type %v got started", kind)
+		return
+	default:
+	}
+	if s.services[kind] != service {
+		s.lock.Unlock()
+		log.Debugf("Service type %v has been replaced before it got started", kind)
+		return
+	}
+	// stopping the service waits for Start to return, the lock is released so that Start can use the registry
+	starting := make(chan struct{})
+	s.starting[kind] = starting
+	s.lock.Unlock()
+
+	log.Debugf("Starting service type %v", kind)
+	err := startService(service)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.starting, kind)
+	close(starting)
+	if err != nil {
+		log.WithError(err).Errorf("Could not start service type %v", kind)
+		s.states[kind] = ServiceFailed
+		s.startErrs[kind] = err
+		return
+	}
+	s.states[kind] = ServiceStarted
+	close(started)
+	select {
+	case <-s.stop:
+		log.Debugf("Registry stopped while service type %v got started, it is stopped along", kind)
+	default:
+	}
+}
+
+// startService starts the service and returns the error which the service reports right after. A panic of
+// Start is reported as a crash of the service and returned.
+func startService(service Service) (err error) {
+	defer func() {
+		if reason := recover(); reason != nil {
+			err = reportCrash(service, reason)
+		}
+	}()
+	service.Start()
+	return service.Status()
+}
+
+// waitForDependency blocks until the registered instance of the dependency has been started and is ready.
+// It follows replacements of the dependency and returns false when the registry is stopped before.
+func (s *ServiceRegistry) waitForDependency(dep reflect.Type) bool {
+	for {
+		s.lock.RLock()
+		service, started, replaced := s.services[dep], s.started[dep], s.replaced
+		s.lock.RUnlock()
+
+		select {
+		case <-started:
+		case <-replaced:
+			continue
+		case <-s.stop:
+			return false
+		}
+		if notifier, ok := service.(ReadyNotifier); ok {
+			select {
+			case <-notifier.Ready():
+			case <-replaced:
+				continue
+			case <-s.stop:
+				return false
+			}
+		}
+		// the dependency may have been replaced while its previous instance got started or ready
+		s.lock.RLock()
+		current := s.services[dep]
+		s.lock.RUnlock()
+		if current == service {
+			return true
+		}
+	}
+}
+
+// NotStarted returns types of the registered services which have not been started yet, e.g. because
+// they are still waiting for their dependencies.
+func (s *ServiceRegistry) NotStarted() []reflect.Type {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	kinds := make([]reflect.Type, 0)
+	for _, kind := range s.serviceTypes {
+		select {
+		case <-s.started[kind]:
+		default:
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
+// StopAll ends every service in reverse topological order, so services are stopped before their
+// dependencies, logging an error if any of them fail to stop. Services are stopped only once. A service
+// whose Start is running is stopped once Start has returned.
 func (s *ServiceRegistry) StopAll() {
-	for i := len(s.serviceTypes) - 1; i >= 0; i-- {
-		kind := s.serviceTypes[i]
-		service := s.services[kind]
-		if err := service.Stop(); err != nil {
+	s.lock.Lock()
+	// closed under the lock, so no service is started once services are being stopped
+	select {
+	case <-s.stop:
+		s.lock.Unlock()
+		return
+	default:
+		close(s.stop)
+	}
+	order, err := s.topologicalOrder()
+	if err != nil {
+		// services have not been started, the order of registration is good enough
+		order = s.serviceTypes
+	}
+	services := make([]Service, len(order))
+	starting := make([]chan struct{}, len(order))
+	for i, kind := range order {
+		services[i], starting[i] = s.services[kind], s.starting[kind]
+	}
+	// services are stopped without the lock, so that stopping services can still use the registry
+	s.lock.Unlock()
+
+	for i := len(order) - 1; i >= 0; i-- {
+		kind := order[i]
+		if starting[i] != nil {
+			<-starting[i]
+		}
+		if err := services[i].Stop(); err != nil {
 			log.WithError(err).Errorf("Could not stop the following service: %v", kind)
 		}
+		s.lock.Lock()
+		if s.states[kind] != ServiceFailed {
+			s.states[kind] = ServiceStopped
+		}
+		s.lock.Unlock()
+	}
+}
+
+// States returns the stage of lifecycle of every registered service by its type
+func (s *ServiceRegistry) States() map[reflect.Type]ServiceState {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	m := make(map[reflect.Type]ServiceState, len(s.serviceTypes))
+	for _, kind := range s.serviceTypes {
+		m[kind] = s.states[kind]
 	}
+	return m
+}
+
+// StartErrors returns the reason why a service failed to start by the type of every failed service
+func (s *ServiceRegistry) StartErrors() map[reflect.Type]error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	m := make(map[reflect.Type]error, len(s.startErrs))
+	for kind, err := range s.startErrs {
+		m[kind] = err
+	}
+	return m
 }
 
 // Statuses returns a map of Service type -> error. The map will be populated
 // with the results of each service.Status() method call.
 func (s *ServiceRegistry) Statuses() map[reflect.Type]error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 	m := make(map[reflect.Type]error, len(s.serviceTypes))
 	for _, kind := range s.serviceTypes {
 		m[kind] = s.services[kind].Status()
@@ -68,15 +422,66 @@ func (s *ServiceRegistry) Statuses() map[reflect.Type]error {
 	return m
 }
 
-// RegisterService appends a service constructor function to the service
-// registry.
-func (s *ServiceRegistry) RegisterService(service Service) error {
+// RegisterService appends a service to the service registry. The service is started only after all the
+// given dependencies have been started and are ready, dependencies which do not implement ReadyNotifier are
+// ready once started. Dependencies may be registered after the service.
+func (s *ServiceRegistry) RegisterService(service Service, dependencies ...Service) error {
 	kind := reflect.TypeOf(service)
 	if _, exists := s.services[kind]; exists {
 		return fmt.Errorf("service already exists: %v", kind)
 	}
 	s.services[kind] = service
 	s.serviceTypes = append(s.serviceTypes, kind)
+	s.started[kind] = make(chan struct{})
+	s.settled[kind] = make(chan struct{})
+	s.states[kind] = ServiceRegistered
+	setOwner(service, s)
+	for _, dep := range dependencies {
+		s.dependencies[kind] = append(s.dependencies[kind], reflect.TypeOf(dep))
+	}
+	return nil
+}
+
+// ReplaceService stops the registered service of the same type and registers the given service in its place.
+// The new service is started once its dependencies are ready. Services which depend on the replaced service
+// keep their references, so they must reach it through an indirection which follows the replacement.
+func (s *ServiceRegistry) ReplaceService(service Service) error {
+	kind := reflect.TypeOf(service)
+	s.lock.Lock()
+	select {
+	case <-s.stop:
+		s.lock.Unlock()
+		return fmt.Errorf("registry is stopped, could not replace service: %v", kind)
+	default:
+	}
+	previous, exists := s.services[kind]
+	if !exists {
+		s.lock.Unlock()
+		return fmt.Errorf("unknown service: %v", kind)
+	}
+	starting := s.starting[kind]
+	s.services[kind] = service
+	s.started[kind] = make(chan struct{})
+	s.settled[kind] = make(chan struct{})
+	s.states[kind] = ServiceWaiting
+	delete(s.startErrs, kind)
+	// dependents which wait for the previous instance wait for the new one instead
+	close(s.replaced)
+	s.replaced = make(chan struct{})
+	s.lock.Unlock()
+
+	// crashes of the previous instance while it stops are no crashes of the registry
+	setOwner(previous, nil)
+	setOwner(service, s)
+
+	// the previous instance is stopped without the lock before the new one is started
+	if starting != nil {
+		<-starting
+	}
+	if err := previous.Stop(); err != nil {
+		log.WithError(err).Errorf("Could not stop the following service: %v", kind)
+	}
+	go s.startWhenReady(kind)
 	return nil
 }
 
@@ -88,6 +493,8 @@ func (s *ServiceRegistry) FetchService(service interface{}) error {
 		return fmt.Errorf("input must be of pointer type, received value type instead: %T", service)
 	}
 	element := reflect.ValueOf(service).Elem()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 	if running, ok := s.services[element.Type()]; ok {
 		element.Set(reflect.ValueOf(running))
 		return nil
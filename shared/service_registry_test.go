@@ -1 +1,329 @@
 package shared
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+type mockService struct {
+	started chan struct{}
+	ready   chan struct{}
+}
+
+func newMockService() *mockService {
+	return &mockService{started: make(chan struct{}), ready: make(chan struct{})}
+}
+
+func (m *mockService) Start()                 { close(m.started) }
+func (m *mockService) Stop() error            { return nil }
+func (m *mockService) Status() error          { return nil }
+func (m *mockService) Ready() <-chan struct{} { return m.ready }
+
+type dependentService struct {
+	mockService
+}
+
+// isClosed waits a bit for the channel to be closed
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	case <-time.After(100 * time.Millisecond):
+		return false
+	}
+}
+
+// TestServiceRegistry_StartWithDependencies checks that dependent service is started only after
+// its dependency reports ready
+func TestServiceRegistry_StartWithDependencies(t *testing.T) {
+	registry := NewServiceRegistry()
+	dep := newMockService()
+	svc := &dependentService{*newMockService()}
+
+	require.NoError(t, registry.RegisterService(dep))
+	require.NoError(t, registry.RegisterService(svc, dep))
+
+	require.NoError(t, registry.StartAll())
+	assert.Equal(t, true, isClosed(dep.started))
+	assert.Equal(t, false, isClosed(svc.started))
+
+	close(dep.ready)
+	assert.Equal(t, true, isClosed(svc.started))
+	registry.StopAll()
+}
+
+// TestServiceRegistry_StopBeforeReady checks that dependent service is not started when registry is
+// stopped before its dependency reports ready
+func TestServiceRegistry_StopBeforeReady(t *testing.T) {
+	registry := NewServiceRegistry()
+	dep := newMockService()
+	svc := &dependentService{*newMockService()}
+
+	require.NoError(t, registry.RegisterService(dep))
+	require.NoError(t, registry.RegisterService(svc, dep))
+
+	require.NoError(t, registry.StartAll())
+	registry.StopAll()
+	// stopping twice must not panic
+	registry.StopAll()
+
+	close(dep.ready)
+	assert.Equal(t, false, isClosed(svc.started))
+}
+
+// TestServiceRegistry_NoStartAfterStop checks that no service is started once the registry is stopped, even
+// when its dependencies are ready
+func TestServiceRegistry_NoStartAfterStop(t *testing.T) {
+	registry := NewServiceRegistry()
+	dep := newMockService()
+	close(dep.ready)
+	svc := &dependentService{*newMockService()}
+	require.NoError(t, registry.RegisterService(dep))
+	require.NoError(t, registry.RegisterService(svc, dep))
+
+	registry.StopAll()
+	require.NoError(t, registry.StartAll())
+	assert.Equal(t, false, isClosed(dep.started))
+	assert.Equal(t, false, isClosed(svc.started))
+}
+
+// TestServiceRegistry_NotStarted checks that services waiting for their dependencies are reported as not started
+func TestServiceRegistry_NotStarted(t *testing.T) {
+	registry := NewServiceRegistry()
+	dep := newMockService()
+	svc := &dependentService{*newMockService()}
+
+	require.NoError(t, registry.RegisterService(dep))
+	require.NoError(t, registry.RegisterService(svc, dep))
+	assert.Equal(t, 2, len(registry.NotStarted()))
+
+	require.NoError(t, registry.StartAll())
+	require.Equal(t, true, isClosed(dep.started))
+	time.Sleep(10 * time.Millisecond)
+	notStarted := registry.NotStarted()
+	require.Equal(t, 1, len(notStarted))
+	assert.Equal(t, reflect.TypeOf(svc), notStarted[0])
+
+	close(dep.ready)
+	require.Equal(t, true, isClosed(svc.started))
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, 0, len(registry.NotStarted()))
+	registry.StopAll()
+}
+
+type stopRecorder struct {
+	stopped []string
+}
+
+type firstService struct {
+	mockService
+	recorder *stopRecorder
+}
+
+func (m *firstService) Stop() error {
+	m.recorder.stopped = append(m.recorder.stopped, "first")
+	return nil
+}
+
+type secondService struct {
+	mockService
+	recorder *stopRecorder
+}
+
+func (m *secondService) Stop() error {
+	m.recorder.stopped = append(m.recorder.stopped, "second")
+	return nil
+}
+
+// TestServiceRegistry_TopologicalOrder checks that a dependency registered after its dependent service is
+// started before and stopped after it
+func TestServiceRegistry_TopologicalOrder(t *testing.T) {
+	registry := NewServiceRegistry()
+	recorder := &stopRecorder{}
+	first := &firstService{*newMockService(), recorder}
+	second := &secondService{*newMockService(), recorder}
+
+	require.NoError(t, registry.RegisterService(second, first))
+	require.NoError(t, registry.RegisterService(first))
+	order, err := registry.topologicalOrder()
+	require.NoError(t, err)
+	assert.DeepEqual(t, []reflect.Type{reflect.TypeOf(first), reflect.TypeOf(second)}, order)
+
+	require.NoError(t, registry.StartAll())
+	assert.Equal(t, true, isClosed(first.started))
+	assert.Equal(t, false, isClosed(second.started))
+	close(first.ready)
+	assert.Equal(t, true, isClosed(second.started))
+
+	registry.StopAll()
+	assert.DeepEqual(t, []string{"second", "first"}, recorder.stopped)
+}
+
+// TestServiceRegistry_InvalidDependencies checks that no service is started when dependencies form a cycle
+// or are not registered
+func TestServiceRegistry_InvalidDependencies(t *testing.T) {
+	registry := NewServiceRegistry()
+	dep := newMockService()
+	svc := &dependentService{*newMockService()}
+	require.NoError(t, registry.RegisterService(dep, svc))
+	require.NoError(t, registry.RegisterService(svc, dep))
+	assert.ErrorContains(t, "dependency cycle", registry.StartAll())
+	assert.Equal(t, false, isClosed(dep.started))
+	registry.StopAll()
+
+	registry = NewServiceRegistry()
+	require.NoError(t, registry.RegisterService(svc, dep))
+	assert.ErrorContains(t, "depends on unregistered service", registry.StartAll())
+}
+
+// TestServiceRegistry_ReplaceService checks that a replaced service is started in place of the previous one and
+// that dependent service waits for the new instance to be ready
+func TestServiceRegistry_ReplaceService(t *testing.T) {
+	registry := NewServiceRegistry()
+	dep := newMockService()
+	svc := &dependentService{*newMockService()}
+
+	require.NoError(t, registry.RegisterService(dep))
+	require.NoError(t, registry.RegisterService(svc, dep))
+	require.NoError(t, registry.StartAll())
+	require.Equal(t, true, isClosed(dep.started))
+
+	replacement := newMockService()
+	require.NoError(t, registry.ReplaceService(replacement))
+	assert.Equal(t, true, isClosed(replacement.started))
+
+	// the previous instance is not followed anymore
+	close(dep.ready)
+	assert.Equal(t, false, isClosed(svc.started))
+	close(replacement.ready)
+	assert.Equal(t, true, isClosed(svc.started))
+
+	var fetched *mockService
+	require.NoError(t, registry.FetchService(&fetched))
+	assert.Equal(t, replacement, fetched)
+
+	assert.ErrorContains(t, "unknown service", registry.ReplaceService(&secondService{}))
+	registry.StopAll()
+	assert.ErrorContains(t, "registry is stopped", registry.ReplaceService(newMockService()))
+}
+
+type failingService struct {
+	mockService
+	stopped bool
+}
+
+func (m *failingService) Status() error { return errors.New("could not listen") }
+func (m *failingService) Stop() error {
+	m.stopped = true
+	return nil
+}
+
+type stoppableService struct {
+	mockService
+	stopped int
+}
+
+func (m *stoppableService) Stop() error {
+	m.stopped++
+	return nil
+}
+
+// TestServiceRegistry_StartFailure checks that a failed start is returned and started services are stopped
+func TestServiceRegistry_StartFailure(t *testing.T) {
+	registry := NewServiceRegistry()
+	started := &stoppableService{mockService: *newMockService()}
+	failing := &failingService{mockService: *newMockService()}
+	require.NoError(t, registry.RegisterService(started))
+	require.NoError(t, registry.RegisterService(failing))
+
+	err := registry.StartAll()
+	assert.ErrorContains(t, "could not listen", err)
+	startErr, ok := err.(StartError)
+	require.Equal(t, true, ok)
+	assert.Equal(t, 1, len(startErr))
+	assert.ErrorContains(t, "could not listen", startErr[reflect.TypeOf(failing)])
+
+	assert.Equal(t, 1, started.stopped)
+	assert.Equal(t, true, failing.stopped)
+	states := registry.States()
+	assert.Equal(t, ServiceStopped, states[reflect.TypeOf(started)])
+	assert.Equal(t, ServiceFailed, states[reflect.TypeOf(failing)])
+	assert.Equal(t, 1, len(registry.StartErrors()))
+
+	// services are stopped only once
+	registry.StopAll()
+	assert.Equal(t, 1, started.stopped)
+}
+
+// TestServiceRegistry_States checks that states follow services waiting for their dependencies
+func TestServiceRegistry_States(t *testing.T) {
+	registry := NewServiceRegistry()
+	dep := newMockService()
+	svc := &dependentService{*newMockService()}
+	require.NoError(t, registry.RegisterService(dep))
+	require.NoError(t, registry.RegisterService(svc, dep))
+	assert.Equal(t, ServiceRegistered, registry.States()[reflect.TypeOf(dep)])
+
+	require.NoError(t, registry.StartAll())
+	states := registry.States()
+	assert.Equal(t, ServiceStarted, states[reflect.TypeOf(dep)])
+	assert.Equal(t, ServiceWaiting, states[reflect.TypeOf(svc)])
+
+	close(dep.ready)
+	require.Equal(t, true, isClosed(svc.started))
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, ServiceStarted, registry.States()[reflect.TypeOf(svc)])
+	registry.StopAll()
+	assert.Equal(t, ServiceStopped, registry.States()[reflect.TypeOf(svc)])
+}
+
+type registryService struct {
+	mockService
+	registry *ServiceRegistry
+	release  chan struct{}
+	states   map[reflect.Type]ServiceState
+}
+
+func (m *registryService) Start() {
+	<-m.release
+	close(m.started)
+}
+
+func (m *registryService) Stop() error {
+	// stopping services may use the registry
+	m.states = m.registry.States()
+	return nil
+}
+
+// TestServiceRegistry_StopWhileStarting checks that the registry lock is not held while services start or
+// stop and that a service which is being started is stopped once its Start returns
+func TestServiceRegistry_StopWhileStarting(t *testing.T) {
+	registry := NewServiceRegistry()
+	svc := &registryService{mockService: *newMockService(), registry: registry, release: make(chan struct{})}
+	require.NoError(t, registry.RegisterService(svc))
+
+	startErr := make(chan error)
+	go func() {
+		startErr <- registry.StartAll()
+	}()
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, ServiceWaiting, registry.States()[reflect.TypeOf(svc)])
+
+	stopped := make(chan struct{})
+	go func() {
+		registry.StopAll()
+		close(stopped)
+	}()
+	assert.Equal(t, false, isClosed(stopped))
+
+	close(svc.release)
+	require.Equal(t, true, isClosed(stopped))
+	require.NoError(t, <-startErr)
+	assert.Equal(t, ServiceStarted, svc.states[reflect.TypeOf(svc)])
+	assert.Equal(t, ServiceStopped, registry.States()[reflect.TypeOf(svc)])
+}
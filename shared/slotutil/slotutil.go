@@ -0,0 +1,64 @@
+// Package slotutil converts between vanguard slots, epochs and wall clock time by the chain parameters of the
+// network which the node runs on, see params.ActiveNetworkConfig.
+package slotutil
+
+import (
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+)
+
+// SlotsPerEpoch returns the number of slots in a vanguard epoch of the network which the node runs on
+func SlotsPerEpoch() uint64 {
+	return params.ActiveNetworkConfig().SlotsPerEpoch
+}
+
+// ToEpoch returns the epoch of the slot
+func ToEpoch(slot uint64) uint64 {
+	return slot / SlotsPerEpoch()
+}
+
+// SlotIndex returns the position of the slot within its epoch, which is the index of its proposer in consensus info
+func SlotIndex(slot uint64) uint64 {
+	return slot % SlotsPerEpoch()
+}
+
+// IsEpochStart tells whether the slot is the first slot of its epoch
+func IsEpochStart(slot uint64) bool {
+	return SlotIndex(slot) == 0
+}
+
+// EpochStartSlot returns the first slot of the epoch
+func EpochStartSlot(epoch uint64) uint64 {
+	return epoch * SlotsPerEpoch()
+}
+
+// EpochEndSlot returns the last slot of the epoch
+func EpochEndSlot(epoch uint64) uint64 {
+	return EpochStartSlot(epoch+1) - 1
+}
+
+// SlotStartTime returns the time when the slot starts, it fails when genesis time or slot duration are not known
+func SlotStartTime(slot uint64) (time.Time, bool) {
+	config := params.ActiveNetworkConfig()
+	if config.GenesisTime == 0 || config.SecondsPerSlot == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(config.GenesisTime+slot*config.SecondsPerSlot), 0), true
+}
+
+// SlotAt returns the slot at the given time, it fails when genesis time or slot duration are not known or the
+// time is before genesis
+func SlotAt(t time.Time) (uint64, bool) {
+	config := params.ActiveNetworkConfig()
+	if config.GenesisTime == 0 || config.SecondsPerSlot == 0 || t.Before(time.Unix(int64(config.GenesisTime), 0)) {
+		return 0, false
+	}
+	return uint64(t.Sub(time.Unix(int64(config.GenesisTime), 0)) / (time.Duration(config.SecondsPerSlot) * time.Second)), true
+}
+
+// CurrentSlot returns the wall clock slot, it fails when genesis time or slot duration are not known or genesis
+// has not come yet
+func CurrentSlot() (uint64, bool) {
+	return SlotAt(time.Now())
+}
@@ -0,0 +1,51 @@
+package slotutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+func useNetworkConfig(t *testing.T, config *params.NetworkConfig) {
+	previous := params.ActiveNetworkConfig()
+	params.UseNetworkConfig(config)
+	t.Cleanup(func() {
+		params.UseNetworkConfig(previous)
+	})
+}
+
+func TestEpochMath(t *testing.T) {
+	useNetworkConfig(t, &params.NetworkConfig{SlotsPerEpoch: 32})
+
+	assert.Equal(t, uint64(0), ToEpoch(31))
+	assert.Equal(t, uint64(1), ToEpoch(32))
+	assert.Equal(t, uint64(2), SlotIndex(66))
+	assert.Equal(t, true, IsEpochStart(64))
+	assert.Equal(t, false, IsEpochStart(65))
+	assert.Equal(t, uint64(64), EpochStartSlot(2))
+	assert.Equal(t, uint64(95), EpochEndSlot(2))
+}
+
+func TestSlotTime(t *testing.T) {
+	useNetworkConfig(t, &params.NetworkConfig{SlotsPerEpoch: 32})
+	_, ok := SlotStartTime(1)
+	assert.Equal(t, false, ok)
+	_, ok = SlotAt(time.Now())
+	assert.Equal(t, false, ok)
+
+	useNetworkConfig(t, &params.NetworkConfig{SlotsPerEpoch: 32, SecondsPerSlot: 6, GenesisTime: 1000})
+	start, ok := SlotStartTime(10)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, int64(1060), start.Unix())
+
+	slot, ok := SlotAt(time.Unix(1065, 999))
+	assert.Equal(t, true, ok)
+	assert.Equal(t, uint64(10), slot)
+	slot, ok = SlotAt(time.Unix(1066, 0))
+	assert.Equal(t, true, ok)
+	assert.Equal(t, uint64(11), slot)
+	_, ok = SlotAt(time.Unix(999, 0))
+	assert.Equal(t, false, ok)
+}
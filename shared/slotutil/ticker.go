@@ -0,0 +1,68 @@
+package slotutil
+
+import (
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+)
+
+// SlotTicker sends the number of every slot on its channel when the slot starts
+type SlotTicker struct {
+	c    chan uint64
+	done chan struct{}
+}
+
+// C returns the channel which receives slots
+func (t *SlotTicker) C() <-chan uint64 {
+	return t.c
+}
+
+// Done stops the ticker
+func (t *SlotTicker) Done() {
+	close(t.done)
+}
+
+// NewSlotTicker starts a ticker of the slots of the network which the node runs on, it fails when genesis time
+// or slot duration are not known
+func NewSlotTicker() (*SlotTicker, bool) {
+	config := params.ActiveNetworkConfig()
+	if config.GenesisTime == 0 || config.SecondsPerSlot == 0 {
+		return nil, false
+	}
+	return newSlotTicker(time.Unix(int64(config.GenesisTime), 0), time.Duration(config.SecondsPerSlot)*time.Second, time.Now), true
+}
+
+func newSlotTicker(genesis time.Time, slotDuration time.Duration, now func() time.Time) *SlotTicker {
+	ticker := &SlotTicker{
+		c:    make(chan uint64),
+		done: make(chan struct{}),
+	}
+	go ticker.run(genesis, slotDuration, now)
+	return ticker
+}
+
+func (t *SlotTicker) run(genesis time.Time, slotDuration time.Duration, now func() time.Time) {
+	nextSlot := uint64(0)
+	if sinceGenesis := now().Sub(genesis); sinceGenesis > 0 {
+		nextSlot = uint64(sinceGenesis/slotDuration) + 1
+	}
+	for {
+		timer := time.NewTimer(genesis.Add(time.Duration(nextSlot) * slotDuration).Sub(now()))
+		select {
+		case <-timer.C:
+		case <-t.done:
+			timer.Stop()
+			return
+		}
+		select {
+		case t.c <- nextSlot:
+		case <-t.done:
+			return
+		}
+		// slots which passed while the receiver was busy are skipped like ticks of time.Ticker
+		nextSlot++
+		if current := uint64(now().Sub(genesis)/slotDuration) + 1; current > nextSlot {
+			nextSlot = current
+		}
+	}
+}
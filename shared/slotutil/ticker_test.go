@@ -0,0 +1,45 @@
+package slotutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+func TestSlotTicker(t *testing.T) {
+	slotDuration := 20 * time.Millisecond
+	// the ticker starts in the middle of slot 5
+	genesis := time.Now().Add(-5*slotDuration - slotDuration/2)
+	ticker := newSlotTicker(genesis, slotDuration, time.Now)
+	defer ticker.Done()
+
+	for _, want := range []uint64{6, 7, 8} {
+		select {
+		case slot := <-ticker.C():
+			assert.Equal(t, want, slot)
+		case <-time.After(time.Second):
+			t.Fatalf("slot %d has not been ticked", want)
+		}
+	}
+}
+
+func TestSlotTicker_BeforeGenesis(t *testing.T) {
+	slotDuration := 20 * time.Millisecond
+	ticker := newSlotTicker(time.Now().Add(slotDuration), slotDuration, time.Now)
+	defer ticker.Done()
+
+	select {
+	case slot := <-ticker.C():
+		assert.Equal(t, uint64(0), slot)
+	case <-time.After(time.Second):
+		t.Fatal("genesis slot has not been ticked")
+	}
+}
+
+func TestNewSlotTicker_UnknownGenesis(t *testing.T) {
+	useNetworkConfig(t, &params.NetworkConfig{SlotsPerEpoch: 32, SecondsPerSlot: 6})
+	_, ok := NewSlotTicker()
+	assert.Equal(t, false, ok)
+}
@@ -0,0 +1,27 @@
+package shared
+
+import (
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared/eventbuffer"
+	"github.com/lukso-network/lukso-orchestrator/shared/feed"
+)
+
+// SubscriptionDrop is reported when a subscription of orchestrator to vanguard or pandora ends with an error
+type SubscriptionDrop struct {
+	Chain        string
+	Subscription string
+	Err          error
+}
+
+// subscriptionDropFeed sends drops of subscriptions of all chain services
+var subscriptionDropFeed = feed.New("service/subscriptiondrop", (*SubscriptionDrop)(nil), eventbuffer.DefaultConfig)
+
+// ReportSubscriptionDrop reports that the named subscription to the chain has ended with the error
+func ReportSubscriptionDrop(chain, subscription string, err error) {
+	subscriptionDropFeed.Send(&SubscriptionDrop{Chain: chain, Subscription: subscription, Err: err})
+}
+
+// SubscribeSubscriptionDrops subscribes to drops which are reported by ReportSubscriptionDrop
+func SubscribeSubscriptionDrops(ch chan<- *SubscriptionDrop) event.Subscription {
+	return subscriptionDropFeed.Subscribe(ch)
+}
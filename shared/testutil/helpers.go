@@ -113,6 +113,7 @@ func NewPandoraShard(panHeader *eth1Types.Header) *ethpb.PandoraShard {
 		StateRoot:   panHeader.Root.Bytes(),
 		TxHash:      panHeader.TxHash.Bytes(),
 		ReceiptHash: panHeader.ReceiptHash.Bytes(),
+		SealHash:    SealHash(panHeader).Bytes(),
 		Signature:   []byte("df7284286281db4c0bea60b338a62ddfde0d34736ad2657f2bea159fc8c6675cd5bbb68373e9f3d4bba017a82ed0d9b9"),
 	}
 }
@@ -0,0 +1,143 @@
+package tracing
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/metrics"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// ServiceName is the name of the orchestrator in exported traces
+const ServiceName = "lukso-orchestrator"
+
+// path of the OTLP/HTTP traces endpoint of a collector
+const tracesPath = "/v1/traces"
+
+const (
+	// number of spans which wait for export, spans are dropped when the queue is full
+	queueSize = 4096
+	// number of spans exported in one request
+	batchSize = 512
+	// interval in which queued spans are exported
+	exportInterval = 5 * time.Second
+	// timeout of one export request
+	exportTimeout = 10 * time.Second
+)
+
+// tracingMetrics collects spans exported to and rejected by the collector
+var tracingMetrics = metrics.NewCollector("tracing")
+
+// Exporter sends finished spans in batches to an OpenTelemetry collector by OTLP over HTTP. It is a service of the
+// node, queued spans are exported when it is stopped.
+type Exporter struct {
+	isRunning bool
+	runError  error
+	errLock   sync.Mutex
+
+	endpoint string
+	provider *sdktrace.TracerProvider
+
+	exported metrics.Counter
+	failed   metrics.Counter
+}
+
+// NewExporter creates an exporter to the collector at the given endpoint, e.g. http://localhost:4318. Spans are
+// posted to the /v1/traces path of the endpoint unless the endpoint has a path already.
+func NewExporter(ctx context.Context, endpoint string) (*Exporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse tracing endpoint")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("tracing endpoint %s must be an http or https url", endpoint)
+	}
+	if strings.Trim(u.Path, "/") == "" {
+		u.Path = tracesPath
+	}
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(u.Host),
+		otlptracehttp.WithURLPath(u.Path),
+		otlptracehttp.WithTimeout(exportTimeout),
+	}
+	if u.Scheme == "http" {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	client, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create tracing exporter")
+	}
+	e := &Exporter{
+		endpoint: u.String(),
+		exported: tracingMetrics.Counter("exported"),
+		failed:   tracingMetrics.Counter("failed"),
+	}
+	e.provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(&statusExporter{SpanExporter: client, exporter: e},
+			sdktrace.WithMaxQueueSize(queueSize),
+			sdktrace.WithMaxExportBatchSize(batchSize),
+			sdktrace.WithBatchTimeout(exportInterval),
+			sdktrace.WithExportTimeout(exportTimeout),
+		),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(ServiceName))),
+	)
+	return e, nil
+}
+
+// Endpoint returns the url which spans are posted to
+func (e *Exporter) Endpoint() string {
+	return e.endpoint
+}
+
+// Start logs the endpoint of the exporter, spans are exported in the background from the start of the node
+func (e *Exporter) Start() {
+	if e.isRunning {
+		log.Error("Attempted to start tracing exporter when it was already started")
+		return
+	}
+	e.isRunning = true
+	log.WithField("endpoint", e.endpoint).Info("Started tracing exporter")
+}
+
+// Stop exports queued spans and stops the exporter
+func (e *Exporter) Stop() error {
+	e.isRunning = false
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	return e.provider.Shutdown(ctx)
+}
+
+// Status returns the error of the latest export, it is nil once an export succeeds again
+func (e *Exporter) Status() error {
+	e.errLock.Lock()
+	defer e.errLock.Unlock()
+	return e.runError
+}
+
+// statusExporter records the result of every export into the status and the metrics of the exporter
+type statusExporter struct {
+	sdktrace.SpanExporter
+	exporter *Exporter
+}
+
+func (s *statusExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := s.SpanExporter.ExportSpans(ctx, spans)
+	e := s.exporter
+	e.errLock.Lock()
+	e.runError = err
+	e.errLock.Unlock()
+	if err != nil {
+		e.failed.Inc(int64(len(spans)))
+		log.WithError(err).WithField("spans", len(spans)).Warn("Could not export spans")
+		return err
+	}
+	e.exported.Inc(int64(len(spans)))
+	return nil
+}
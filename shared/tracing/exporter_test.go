@@ -0,0 +1,100 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewExporter_Endpoint(t *testing.T) {
+	exporter, err := NewExporter(context.Background(), "http://localhost:4318")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:4318/v1/traces", exporter.Endpoint())
+
+	exporter, err = NewExporter(context.Background(), "https://collector:443/custom/traces")
+	require.NoError(t, err)
+	assert.Equal(t, "https://collector:443/custom/traces", exporter.Endpoint())
+
+	_, err = NewExporter(context.Background(), "localhost:4318")
+	assert.ErrorContains(t, "must be an http or https url", err)
+}
+
+func TestExporter_ExportsOnStop(t *testing.T) {
+	requests := make(chan *collectortrace.ExportTraceServiceRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		request := &collectortrace.ExportTraceServiceRequest{}
+		assert.NoError(t, proto.Unmarshal(body, request))
+		requests <- request
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	defer server.Close()
+
+	exporter, err := NewExporter(context.Background(), server.URL)
+	require.NoError(t, err)
+	exporter.Start()
+	start := time.Unix(100, 0)
+	tr := newTrace(exporter.provider.Tracer(ServiceName), "slot verification", start, Uint("slot", 7))
+	tr.Stage("received")
+	tr.Finish(errors.New("could not cache"))
+	require.NoError(t, exporter.Stop())
+	require.NoError(t, exporter.Status())
+
+	var request *collectortrace.ExportTraceServiceRequest
+	select {
+	case request = <-requests:
+	default:
+		t.Fatal("spans were not exported")
+	}
+	resourceSpans := request.ResourceSpans[0]
+	var serviceName string
+	for _, attr := range resourceSpans.Resource.Attributes {
+		if attr.Key == "service.name" {
+			serviceName = attr.Value.GetStringValue()
+		}
+	}
+	assert.Equal(t, ServiceName, serviceName)
+	spans := resourceSpans.InstrumentationLibrarySpans[0].Spans
+	require.Equal(t, 2, len(spans))
+
+	stage, root := spans[0], spans[1]
+	assert.Equal(t, "slot verification", root.Name)
+	assert.Equal(t, tr.TraceID().String(), hex.EncodeToString(root.TraceId))
+	assert.Equal(t, uint64(100000000000), root.StartTimeUnixNano)
+	assert.Equal(t, 0, len(root.ParentSpanId))
+	assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, root.Status.Code)
+	assert.Equal(t, "could not cache", root.Status.Message)
+	require.Equal(t, 1, len(root.Attributes))
+	assert.Equal(t, "slot", root.Attributes[0].Key)
+	assert.Equal(t, int64(7), root.Attributes[0].Value.GetIntValue())
+
+	assert.Equal(t, "received", stage.Name)
+	assert.DeepEqual(t, root.SpanId, stage.ParentSpanId)
+	assert.Equal(t, tracepb.Status_STATUS_CODE_UNSET, stage.Status.GetCode())
+}
+
+func TestExporter_Status(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid spans", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	exporter, err := NewExporter(context.Background(), server.URL)
+	require.NoError(t, err)
+	newTrace(exporter.provider.Tracer(ServiceName), "slot verification", time.Now()).Finish(nil)
+	assert.NoError(t, exporter.Stop())
+	assert.ErrorContains(t, "400 Bad Request", exporter.Status())
+}
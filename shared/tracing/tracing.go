@@ -0,0 +1,149 @@
+// Package tracing follows slots through the verification pipeline of the orchestrator. Every stage of a slot is
+// recorded as a span from the end of the previous stage, so the spans show where the time between a vanguard block
+// and its verification result is spent. Spans are exported to an OpenTelemetry collector, tracing costs nothing
+// unless an exporter is enabled.
+package tracing
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attribute is a key value pair describing a span
+type Attribute = attribute.KeyValue
+
+// String returns a string attribute
+func String(key, value string) Attribute {
+	return attribute.String(key, value)
+}
+
+// Int returns an integer attribute
+func Int(key string, value int64) Attribute {
+	return attribute.Int64(key, value)
+}
+
+// Uint returns an integer attribute of an unsigned value, e.g. a slot
+func Uint(key string, value uint64) Attribute {
+	return attribute.Int64(key, int64(value))
+}
+
+// Bool returns a boolean attribute
+func Bool(key string, value bool) Attribute {
+	return attribute.Bool(key, value)
+}
+
+// active holds the tracer of enabled tracing
+var active atomic.Value
+
+type tracerHolder struct {
+	tracer trace.Tracer
+}
+
+// Enable starts recording traces into the exporter, it is called before services which trace are started
+func Enable(exporter *Exporter) {
+	active.Store(tracerHolder{tracer: exporter.provider.Tracer(ServiceName)})
+}
+
+// Disable stops recording traces, traces which are already started are still recorded
+func Disable() {
+	active.Store(tracerHolder{})
+}
+
+// Enabled tells whether traces are recorded
+func Enabled() bool {
+	return activeTracer() != nil
+}
+
+func activeTracer() trace.Tracer {
+	holder, _ := active.Load().(tracerHolder)
+	return holder.tracer
+}
+
+// Trace follows one slot through the stages of the pipeline. It is carried by the events of the slot from one
+// service to the next, every service records its stage and the last one finishes the trace. A nil trace is
+// valid and records nothing, so events which are not traced need no special handling.
+type Trace struct {
+	tracer trace.Tracer
+	// ctx carries the root span, stages are recorded as its children
+	ctx  context.Context
+	root trace.Span
+
+	lock     sync.Mutex
+	last     time.Time
+	finished bool
+}
+
+// StartTrace starts a trace whose root span has the given name. It returns nil when tracing is not enabled.
+func StartTrace(name string, attrs ...Attribute) *Trace {
+	tracer := activeTracer()
+	if tracer == nil {
+		return nil
+	}
+	return newTrace(tracer, name, time.Now(), attrs...)
+}
+
+func newTrace(tracer trace.Tracer, name string, start time.Time, attrs ...Attribute) *Trace {
+	ctx, root := tracer.Start(context.Background(), name,
+		trace.WithNewRoot(), trace.WithTimestamp(start), trace.WithAttributes(attrs...))
+	return &Trace{
+		tracer: tracer,
+		ctx:    ctx,
+		root:   root,
+		last:   start,
+	}
+}
+
+// TraceID returns the id of the trace, it is zero for a nil trace
+func (t *Trace) TraceID() trace.TraceID {
+	if t == nil {
+		return trace.TraceID{}
+	}
+	return t.root.SpanContext().TraceID()
+}
+
+// Stage records a span of the given name from the end of the previous stage, or the start of the trace, until now.
+// Stages of a finished trace are ignored.
+func (t *Trace) Stage(name string, attrs ...Attribute) {
+	if t == nil {
+		return
+	}
+	now := time.Now()
+	t.lock.Lock()
+	if t.finished {
+		t.lock.Unlock()
+		return
+	}
+	start := t.last
+	t.last = now
+	t.lock.Unlock()
+
+	_, span := t.tracer.Start(t.ctx, name, trace.WithTimestamp(start), trace.WithAttributes(attrs...))
+	span.End(trace.WithTimestamp(now))
+}
+
+// Finish records the root span of the trace from its start until now, marked as failed when err is not nil.
+// Only the first call records the root span.
+func (t *Trace) Finish(err error) {
+	if t == nil {
+		return
+	}
+	now := time.Now()
+	t.lock.Lock()
+	if t.finished {
+		t.lock.Unlock()
+		return
+	}
+	t.finished = true
+	t.lock.Unlock()
+
+	if err != nil {
+		t.root.SetStatus(codes.Error, err.Error())
+	}
+	t.root.End(trace.WithTimestamp(now))
+}
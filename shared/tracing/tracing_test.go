@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTrace_Stages(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)).Tracer(ServiceName)
+	start := time.Now().Add(-time.Second)
+	tr := newTrace(tracer, "slot verification", start, Uint("slot", 5))
+	tr.Stage("received")
+	tr.Stage("cached", Bool("valid", true))
+	tr.Finish(nil)
+	tr.Stage("notified")
+	tr.Finish(errors.New("ignored"))
+
+	spans := rec.Ended()
+	require.Equal(t, 3, len(spans))
+	received, cached, root := spans[0], spans[1], spans[2]
+	assert.Equal(t, "received", received.Name())
+	assert.Equal(t, true, start.Equal(received.StartTime()), "first stage starts with the trace")
+	assert.Equal(t, true, received.EndTime().Equal(cached.StartTime()), "stage starts where the previous one ends")
+	assert.DeepEqual(t, []Attribute{Bool("valid", true)}, cached.Attributes())
+
+	assert.Equal(t, "slot verification", root.Name())
+	assert.Equal(t, false, root.Parent().IsValid())
+	assert.Equal(t, true, start.Equal(root.StartTime()))
+	assert.Equal(t, codes.Unset, root.Status().Code)
+	assert.DeepEqual(t, []Attribute{Int("slot", 5)}, root.Attributes())
+	for _, span := range spans {
+		assert.Equal(t, tr.TraceID(), span.SpanContext().TraceID())
+	}
+	assert.Equal(t, root.SpanContext().SpanID(), received.Parent().SpanID())
+	assert.Equal(t, root.SpanContext().SpanID(), cached.Parent().SpanID())
+	assert.Equal(t, true, received.SpanContext().SpanID() != cached.SpanContext().SpanID())
+}
+
+func TestTrace_Nil(t *testing.T) {
+	var tr *Trace
+	tr.Stage("received")
+	tr.Finish(errors.New("failed"))
+	assert.Equal(t, trace.TraceID{}, tr.TraceID())
+}
+
+func TestStartTrace_Disabled(t *testing.T) {
+	Disable()
+	assert.Equal(t, false, Enabled())
+	assert.Equal(t, true, StartTrace("slot verification") == nil)
+
+	exporter, err := NewExporter(context.Background(), "http://localhost:4318")
+	require.NoError(t, err)
+	Enable(exporter)
+	defer Disable()
+	assert.Equal(t, true, Enabled())
+	tr := StartTrace("slot verification")
+	require.NotNil(t, tr)
+	assert.Equal(t, true, tr.TraceID().IsValid())
+}
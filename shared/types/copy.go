@@ -0,0 +1,149 @@
+package types
+
+import (
+	"bytes"
+)
+
+// Copy returns a deep copy of the reorg info
+func (reorg *Reorg) Copy() *Reorg {
+	if reorg == nil {
+		return nil
+	}
+	return &Reorg{
+		VanParentHash: copyBytes(reorg.VanParentHash),
+		PanParentHash: copyBytes(reorg.PanParentHash),
+		NewSlot:       reorg.NewSlot,
+	}
+}
+
+// Equal tells whether both reorg infos have the same content, nil is only equal to nil
+func (reorg *Reorg) Equal(other *Reorg) bool {
+	if reorg == nil || other == nil {
+		return reorg == other
+	}
+	return reorg.NewSlot == other.NewSlot &&
+		bytes.Equal(reorg.VanParentHash, other.VanParentHash) &&
+		bytes.Equal(reorg.PanParentHash, other.PanParentHash)
+}
+
+// Copy returns a deep copy of the consensus info, so it can be handed to another goroutine
+func (info *MinimalEpochConsensusInfo) Copy() *MinimalEpochConsensusInfo {
+	if info == nil {
+		return nil
+	}
+	return &MinimalEpochConsensusInfo{
+		Epoch:            info.Epoch,
+		ValidatorList:    copyStrings(info.ValidatorList),
+		EpochStartTime:   info.EpochStartTime,
+		SlotTimeDuration: info.SlotTimeDuration,
+	}
+}
+
+// Equal tells whether both consensus infos have the same content, nil is only equal to nil
+func (info *MinimalEpochConsensusInfo) Equal(other *MinimalEpochConsensusInfo) bool {
+	if info == nil || other == nil {
+		return info == other
+	}
+	return info.Epoch == other.Epoch &&
+		info.EpochStartTime == other.EpochStartTime &&
+		info.SlotTimeDuration == other.SlotTimeDuration &&
+		equalStrings(info.ValidatorList, other.ValidatorList)
+}
+
+// Copy returns a deep copy of the consensus info along with its reorg info, so it can be handed to another goroutine
+func (info *MinimalEpochConsensusInfoV2) Copy() *MinimalEpochConsensusInfoV2 {
+	if info == nil {
+		return nil
+	}
+	return &MinimalEpochConsensusInfoV2{
+		Epoch:            info.Epoch,
+		ValidatorList:    copyStrings(info.ValidatorList),
+		EpochStartTime:   info.EpochStartTime,
+		SlotTimeDuration: info.SlotTimeDuration,
+		ReorgInfo:        info.ReorgInfo.Copy(),
+		FinalizedSlot:    info.FinalizedSlot,
+	}
+}
+
+// Equal tells whether both consensus infos have the same content including reorg info, nil is only equal to nil
+func (info *MinimalEpochConsensusInfoV2) Equal(other *MinimalEpochConsensusInfoV2) bool {
+	if info == nil || other == nil {
+		return info == other
+	}
+	return info.Epoch == other.Epoch &&
+		info.EpochStartTime == other.EpochStartTime &&
+		info.SlotTimeDuration == other.SlotTimeDuration &&
+		info.FinalizedSlot == other.FinalizedSlot &&
+		info.ReorgInfo.Equal(other.ReorgInfo) &&
+		equalStrings(info.ValidatorList, other.ValidatorList)
+}
+
+// Copy returns a copy of the header info with a deep copy of its header
+func (info *PandoraHeaderInfo) Copy() *PandoraHeaderInfo {
+	if info == nil {
+		return nil
+	}
+	cpy := &PandoraHeaderInfo{Slot: info.Slot}
+	if info.Header != nil {
+		cpy.Header = CopyHeader(info.Header)
+	}
+	return cpy
+}
+
+// Equal tells whether both header infos are of the same slot and header hash, nil is only equal to nil
+func (info *PandoraHeaderInfo) Equal(other *PandoraHeaderInfo) bool {
+	if info == nil || other == nil {
+		return info == other
+	}
+	if info.Slot != other.Slot {
+		return false
+	}
+	if info.Header == nil || other.Header == nil {
+		return info.Header == other.Header
+	}
+	return info.Header.Hash() == other.Header.Hash()
+}
+
+// Copy returns a copy of the header hash
+func (h *HeaderHash) Copy() *HeaderHash {
+	if h == nil {
+		return nil
+	}
+	cpy := *h
+	return &cpy
+}
+
+// Equal tells whether both header hashes have the same hash, slot, epoch, source chain and status, nil is only
+// equal to nil
+func (h *HeaderHash) Equal(other *HeaderHash) bool {
+	if h == nil || other == nil {
+		return h == other
+	}
+	return *h == *other
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
+
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string{}, s...)
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+func TestMinimalEpochConsensusInfoV2_Copy(t *testing.T) {
+	info := &MinimalEpochConsensusInfoV2{
+		Epoch:            3,
+		ValidatorList:    []string{"0x01", "0x02"},
+		EpochStartTime:   1000,
+		SlotTimeDuration: 6,
+		ReorgInfo:        &Reorg{VanParentHash: []byte{1}, PanParentHash: []byte{2}, NewSlot: 96},
+		FinalizedSlot:    64,
+	}
+	cpy := info.Copy()
+	assert.Equal(t, true, info.Equal(cpy))
+
+	cpy.ValidatorList[0] = "0x03"
+	cpy.ReorgInfo.VanParentHash[0] = 3
+	assert.Equal(t, "0x01", info.ValidatorList[0])
+	assert.Equal(t, byte(1), info.ReorgInfo.VanParentHash[0])
+	assert.Equal(t, false, info.Equal(cpy))
+
+	var nilInfo *MinimalEpochConsensusInfoV2
+	assert.Equal(t, true, nilInfo.Equal(nilInfo.Copy()))
+	assert.Equal(t, false, nilInfo.Equal(info))
+}
+
+func TestMinimalEpochConsensusInfo_Equal(t *testing.T) {
+	info := &MinimalEpochConsensusInfo{Epoch: 3, ValidatorList: []string{"0x01"}, EpochStartTime: 1000, SlotTimeDuration: 6}
+	cpy := info.Copy()
+	assert.Equal(t, true, info.Equal(cpy))
+	cpy.ValidatorList = append(cpy.ValidatorList, "0x02")
+	assert.Equal(t, false, info.Equal(cpy))
+	assert.Equal(t, 1, len(info.ValidatorList))
+}
+
+func TestPandoraHeaderInfo_Copy(t *testing.T) {
+	info := &PandoraHeaderInfo{Slot: 5, Header: &eth1Types.Header{Number: big.NewInt(7), Extra: []byte{1}}}
+	cpy := info.Copy()
+	assert.Equal(t, true, info.Equal(cpy))
+
+	cpy.Header.Extra[0] = 2
+	assert.Equal(t, byte(1), info.Header.Extra[0])
+	assert.Equal(t, false, info.Equal(cpy))
+}
+
+func TestHeaderHash_Copy(t *testing.T) {
+	headerHash := NewHeaderHash(PandoraChain, common.HexToHash("0x01"), 5, 0, Pending)
+	cpy := headerHash.Copy()
+	assert.Equal(t, true, headerHash.Equal(cpy))
+
+	cpy.Status = Verified
+	assert.Equal(t, Pending, headerHash.Status)
+	assert.Equal(t, false, headerHash.Equal(cpy))
+
+	var nilHash *HeaderHash
+	assert.Equal(t, true, nilHash.Equal(nilHash.Copy()))
+	assert.Equal(t, false, nilHash.Equal(headerHash))
+}
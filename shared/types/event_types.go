@@ -3,6 +3,7 @@ package types
 import (
 	"github.com/ethereum/go-ethereum/common"
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/tracing"
 	eth2Types "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
 	"time"
 )
@@ -24,6 +25,14 @@ type MinimalEpochConsensusInfoV2 struct {
 	FinalizedSlot    uint64        `json:"finalizedSlot"`
 }
 
+// ConsensusInfoPage is a single page of consensus infos of an epoch range. NextEpoch is the first epoch
+// of the next page and NextPageToken is the cursor which requests it, both are empty on the last page.
+type ConsensusInfoPage struct {
+	ConsensusInfos []*MinimalEpochConsensusInfoV2 `json:"consensusInfos"`
+	NextEpoch      *uint64                        `json:"nextEpoch"`
+	NextPageToken  string                         `json:"nextPageToken,omitempty"`
+}
+
 type MinimalEpochConsensusInfo struct {
 	Epoch            uint64        `json:"epoch"`
 	ValidatorList    []string      `json:"validatorList"`
@@ -31,6 +40,13 @@ type MinimalEpochConsensusInfo struct {
 	SlotTimeDuration time.Duration `json:"slotTimeDuration"`
 }
 
+// VerifiedBlockEvent is pushed to subscribers of verified blocks when a pandora block is confirmed or invalidated
+type VerifiedBlockEvent struct {
+	Slot        uint64      `json:"slot"`
+	PandoraHash common.Hash `json:"pandoraHash"`
+	Status      Status      `json:"status"`
+}
+
 type BlockStatus struct {
 	Hash          common.Hash `json:"hash"`
 	Status        Status      `json:"status"`
@@ -65,15 +81,20 @@ type VanguardShardInfo struct {
 	BlockHash      []byte
 	FinalizedSlot  uint64
 	FinalizedEpoch uint64
+	// Trace follows the slot through verification, it is nil unless tracing is enabled
+	Trace *tracing.Trace `json:"-"`
 }
 
 type BlsSignatureBytes [BLSSignatureSize]byte
 
-// SlotInfo
-type SlotInfoWithStatus struct {
+// VerificationResult is published for every verification decision of a slot
+type VerificationResult struct {
+	Slot              uint64
 	VanguardBlockHash common.Hash
 	PandoraHeaderHash common.Hash
 	Status
+	// Trace of the slot which is finished once subscribers are notified
+	Trace *tracing.Trace `json:"-"`
 }
 
 func (info *MinimalEpochConsensusInfoV2) ConvertToEpochInfo() *MinimalEpochConsensusInfo {
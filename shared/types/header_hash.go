@@ -0,0 +1,127 @@
+package types
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// Chain is the chain which a header hash originates from
+type Chain string
+
+const (
+	VanguardChain Chain = "vanguard"
+	PandoraChain  Chain = "pandora"
+)
+
+// HeaderHashSize is the length of binary encoded header hash
+const HeaderHashSize = common.HashLength + 8 + 8 + 1 + 1
+
+// chainCodes and statusCodes are the stable binary codes of chains and statuses. Codes are never reused or
+// reordered, new values get the next free code.
+var (
+	chainCodes = map[Chain]byte{
+		VanguardChain: 1,
+		PandoraChain:  2,
+	}
+	statusCodes = map[Status]byte{
+		Pending:   1,
+		Verified:  2,
+		Invalid:   3,
+		Skipped:   4,
+		Finalized: 5,
+		Unknown:   6,
+		Orphaned:  7,
+	}
+)
+
+// HeaderHash is the hash of a vanguard or pandora header together with its slot, epoch and orchestrator status
+type HeaderHash struct {
+	Hash   common.Hash `json:"hash"`
+	Slot   uint64      `json:"slot"`
+	Epoch  uint64      `json:"epoch"`
+	Source Chain       `json:"source"`
+	Status Status      `json:"status"`
+}
+
+// NewHeaderHash returns the header hash of the given chain at slot and epoch
+func NewHeaderHash(source Chain, hash common.Hash, slot, epoch uint64, status Status) *HeaderHash {
+	return &HeaderHash{
+		Hash:   hash,
+		Slot:   slot,
+		Epoch:  epoch,
+		Source: source,
+		Status: status,
+	}
+}
+
+// Validate returns an error when source chain or status of header hash is not known
+func (h *HeaderHash) Validate() error {
+	if _, ok := chainCodes[h.Source]; !ok {
+		return errors.Errorf("unknown header hash source chain %q", h.Source)
+	}
+	if _, ok := statusCodes[h.Status]; !ok {
+		return errors.Errorf("unknown header hash status %q", h.Status)
+	}
+	return nil
+}
+
+// MarshalBinary encodes header hash as hash, big endian slot and epoch, source chain code and status code
+func (h *HeaderHash) MarshalBinary() ([]byte, error) {
+	if err := h.Validate(); err != nil {
+		return nil, err
+	}
+	enc := make([]byte, HeaderHashSize)
+	copy(enc, h.Hash[:])
+	binary.BigEndian.PutUint64(enc[common.HashLength:], h.Slot)
+	binary.BigEndian.PutUint64(enc[common.HashLength+8:], h.Epoch)
+	enc[HeaderHashSize-2] = chainCodes[h.Source]
+	enc[HeaderHashSize-1] = statusCodes[h.Status]
+	return enc, nil
+}
+
+// UnmarshalBinary decodes header hash which has been encoded by MarshalBinary
+func (h *HeaderHash) UnmarshalBinary(enc []byte) error {
+	if len(enc) != HeaderHashSize {
+		return errors.Errorf("invalid header hash length %d, expected %d", len(enc), HeaderHashSize)
+	}
+	decoded := HeaderHash{
+		Hash:  common.BytesToHash(enc[:common.HashLength]),
+		Slot:  binary.BigEndian.Uint64(enc[common.HashLength:]),
+		Epoch: binary.BigEndian.Uint64(enc[common.HashLength+8:]),
+	}
+	for chain, code := range chainCodes {
+		if code == enc[HeaderHashSize-2] {
+			decoded.Source = chain
+		}
+	}
+	for status, code := range statusCodes {
+		if code == enc[HeaderHashSize-1] {
+			decoded.Status = status
+		}
+	}
+	if decoded.Source == "" {
+		return errors.Errorf("unknown header hash source chain code %d", enc[HeaderHashSize-2])
+	}
+	if decoded.Status == "" {
+		return errors.Errorf("unknown header hash status code %d", enc[HeaderHashSize-1])
+	}
+	*h = decoded
+	return nil
+}
+
+// UnmarshalJSON decodes header hash and rejects unknown source chains and statuses
+func (h *HeaderHash) UnmarshalJSON(data []byte) error {
+	type headerHash HeaderHash
+	var decoded headerHash
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if err := (*HeaderHash)(&decoded).Validate(); err != nil {
+		return err
+	}
+	*h = HeaderHash(decoded)
+	return nil
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestHeaderHash_Binary(t *testing.T) {
+	headerHash := NewHeaderHash(PandoraChain, common.HexToHash("0x01"), 65, 2, Finalized)
+
+	enc, err := headerHash.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, HeaderHashSize, len(enc))
+	// encoding must not change between releases since it is persisted
+	assert.Equal(t, "0000000000000000000000000000000000000000000000000000000000000001"+
+		"0000000000000041"+"0000000000000002"+"02"+"05", common.Bytes2Hex(enc))
+
+	decoded := new(HeaderHash)
+	require.NoError(t, decoded.UnmarshalBinary(enc))
+	assert.DeepEqual(t, headerHash, decoded)
+
+	enc[HeaderHashSize-1] = 0xff
+	assert.ErrorContains(t, "unknown header hash status code 255", decoded.UnmarshalBinary(enc))
+	assert.ErrorContains(t, "invalid header hash length 3", decoded.UnmarshalBinary([]byte{1, 2, 3}))
+
+	_, err = NewHeaderHash("beacon", common.Hash{}, 0, 0, Pending).MarshalBinary()
+	assert.ErrorContains(t, "unknown header hash source chain", err)
+}
+
+func TestHeaderHash_JSON(t *testing.T) {
+	headerHash := NewHeaderHash(VanguardChain, common.HexToHash("0x02"), 3, 0, Verified)
+
+	enc, err := json.Marshal(headerHash)
+	require.NoError(t, err)
+	assert.Equal(t, `{"hash":"0x0000000000000000000000000000000000000000000000000000000000000002",`+
+		`"slot":3,"epoch":0,"source":"vanguard","status":"Verified"}`, string(enc))
+
+	decoded := new(HeaderHash)
+	require.NoError(t, json.Unmarshal(enc, decoded))
+	assert.DeepEqual(t, headerHash, decoded)
+
+	err = json.Unmarshal([]byte(`{"source":"vanguard","status":"Lost"}`), decoded)
+	assert.ErrorContains(t, "unknown header hash status", err)
+}
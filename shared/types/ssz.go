@@ -0,0 +1,183 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// SSZ schema of MinimalEpochConsensusInfo:
+//
+//	Container {
+//	    epoch:              uint64
+//	    validator_list:     List[Vector[byte, 48], MaxValidatorListSize]
+//	    epoch_start_time:   uint64
+//	    slot_time_duration: uint64
+//	}
+const (
+	// BLSPubkeySize is the size of a validator public key
+	BLSPubkeySize = 48
+	// MaxValidatorListSize is the ssz limit of the validator list of an epoch
+	MaxValidatorListSize = 8192
+
+	// epoch, offset of validator list, epoch start time and slot duration
+	consensusInfoFixedSize = 8 + 4 + 8 + 8
+)
+
+var (
+	errSSZSize          = errors.New("incorrect ssz size")
+	errSSZOffset        = errors.New("incorrect ssz offset")
+	errValidatorListLen = errors.Errorf("validator list is longer than %d", MaxValidatorListSize)
+)
+
+// zeroHashes[i] is the root of a merkle tree of depth i with zero leaves
+var zeroHashes = func() [][32]byte {
+	hashes := make([][32]byte, 64)
+	for i := 1; i < len(hashes); i++ {
+		hashes[i] = hashPair(hashes[i-1], hashes[i-1])
+	}
+	return hashes
+}()
+
+// SizeSSZ returns the size of ssz encoding of the consensus info
+func (info *MinimalEpochConsensusInfo) SizeSSZ() int {
+	return consensusInfoFixedSize + len(info.ValidatorList)*BLSPubkeySize
+}
+
+// MarshalSSZ encodes the consensus info by ssz. Validators must be hex encoded public keys.
+func (info *MinimalEpochConsensusInfo) MarshalSSZ() ([]byte, error) {
+	pubkeys, err := info.pubkeys()
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 0, info.SizeSSZ())
+	buf = appendUint64(buf, info.Epoch)
+	buf = appendUint32(buf, consensusInfoFixedSize)
+	buf = appendUint64(buf, info.EpochStartTime)
+	buf = appendUint64(buf, uint64(info.SlotTimeDuration))
+	for _, pubkey := range pubkeys {
+		buf = append(buf, pubkey...)
+	}
+	return buf, nil
+}
+
+// UnmarshalSSZ decodes ssz encoding of a consensus info, validators are set as hex encoded public keys
+func (info *MinimalEpochConsensusInfo) UnmarshalSSZ(buf []byte) error {
+	if len(buf) < consensusInfoFixedSize {
+		return errSSZSize
+	}
+	if binary.LittleEndian.Uint32(buf[8:12]) != consensusInfoFixedSize {
+		return errSSZOffset
+	}
+	list := buf[consensusInfoFixedSize:]
+	if len(list)%BLSPubkeySize != 0 {
+		return errSSZSize
+	}
+	if len(list)/BLSPubkeySize > MaxValidatorListSize {
+		return errValidatorListLen
+	}
+
+	info.Epoch = binary.LittleEndian.Uint64(buf[0:8])
+	info.EpochStartTime = binary.LittleEndian.Uint64(buf[12:20])
+	info.SlotTimeDuration = time.Duration(binary.LittleEndian.Uint64(buf[20:28]))
+	info.ValidatorList = make([]string, 0, len(list)/BLSPubkeySize)
+	for i := 0; i < len(list); i += BLSPubkeySize {
+		info.ValidatorList = append(info.ValidatorList, hexutil.Encode(list[i:i+BLSPubkeySize]))
+	}
+	return nil
+}
+
+// HashTreeRoot returns ssz hash tree root of the consensus info, so consensus infos can be compared between
+// orchestrators without comparing their encoding
+func (info *MinimalEpochConsensusInfo) HashTreeRoot() ([32]byte, error) {
+	pubkeys, err := info.pubkeys()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	pubkeyRoots := make([][32]byte, 0, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		var chunks [2][32]byte
+		copy(chunks[0][:], pubkey[:32])
+		copy(chunks[1][:], pubkey[32:])
+		pubkeyRoots = append(pubkeyRoots, hashPair(chunks[0], chunks[1]))
+	}
+	listRoot := mixInLength(merkleize(pubkeyRoots, MaxValidatorListSize), uint64(len(pubkeys)))
+
+	return merkleize([][32]byte{
+		uint64Chunk(info.Epoch),
+		listRoot,
+		uint64Chunk(info.EpochStartTime),
+		uint64Chunk(uint64(info.SlotTimeDuration)),
+	}, 4), nil
+}
+
+// pubkeys decodes the validator list
+func (info *MinimalEpochConsensusInfo) pubkeys() ([][]byte, error) {
+	if len(info.ValidatorList) > MaxValidatorListSize {
+		return nil, errValidatorListLen
+	}
+	pubkeys := make([][]byte, 0, len(info.ValidatorList))
+	for i, validator := range info.ValidatorList {
+		pubkey, err := hexutil.Decode(validator)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode public key of validator %d", i)
+		}
+		if len(pubkey) != BLSPubkeySize {
+			return nil, errors.Errorf("public key of validator %d has %d bytes, want %d", i, len(pubkey), BLSPubkeySize)
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
+
+func appendUint64(buf []byte, v uint64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	return append(buf, b[:]...)
+}
+
+func appendUint32(buf []byte, v uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	return append(buf, b[:]...)
+}
+
+func uint64Chunk(v uint64) [32]byte {
+	var chunk [32]byte
+	binary.LittleEndian.PutUint64(chunk[:8], v)
+	return chunk
+}
+
+func hashPair(a, b [32]byte) [32]byte {
+	return sha256.Sum256(append(a[:], b[:]...))
+}
+
+// merkleize returns the root of the merkle tree of chunks padded with zero chunks to the limit
+func merkleize(chunks [][32]byte, limit uint64) [32]byte {
+	depth := 0
+	for uint64(1)<<depth < limit {
+		depth++
+	}
+	if len(chunks) == 0 {
+		return zeroHashes[depth]
+	}
+	layer := append([][32]byte{}, chunks...)
+	for d := 0; d < depth; d++ {
+		if len(layer)%2 == 1 {
+			layer = append(layer, zeroHashes[d])
+		}
+		next := make([][32]byte, len(layer)/2)
+		for i := range next {
+			next[i] = hashPair(layer[2*i], layer[2*i+1])
+		}
+		layer = next
+	}
+	return layer[0]
+}
+
+func mixInLength(root [32]byte, length uint64) [32]byte {
+	return hashPair(root, uint64Chunk(length))
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func testConsensusInfo() *MinimalEpochConsensusInfo {
+	validatorList := make([]string, 32)
+	for i := range validatorList {
+		pubkey := make([]byte, BLSPubkeySize)
+		pubkey[0] = byte(i)
+		validatorList[i] = hexutil.Encode(pubkey)
+	}
+	return &MinimalEpochConsensusInfo{
+		Epoch:            5,
+		ValidatorList:    validatorList,
+		EpochStartTime:   765544433,
+		SlotTimeDuration: 6,
+	}
+}
+
+func TestMinimalEpochConsensusInfo_SSZ(t *testing.T) {
+	info := testConsensusInfo()
+	enc, err := info.MarshalSSZ()
+	require.NoError(t, err)
+	assert.Equal(t, info.SizeSSZ(), len(enc))
+
+	decoded := &MinimalEpochConsensusInfo{}
+	require.NoError(t, decoded.UnmarshalSSZ(enc))
+	assert.DeepEqual(t, info, decoded)
+
+	assert.ErrorContains(t, "incorrect ssz size", decoded.UnmarshalSSZ(enc[:len(enc)-1]))
+	assert.ErrorContains(t, "incorrect ssz size", decoded.UnmarshalSSZ(enc[:10]))
+}
+
+// TestMinimalEpochConsensusInfo_HashTreeRoot checks the root against a fixed value, so that roots stay
+// comparable between orchestrator versions
+func TestMinimalEpochConsensusInfo_HashTreeRoot(t *testing.T) {
+	root, err := testConsensusInfo().HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, "0x2886b80d1617a7be353ae512d6b4e0228ada387082e5e1f116ce381749466be4", hexutil.Encode(root[:]))
+
+	root, err = (&MinimalEpochConsensusInfo{}).HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, "0x58d22ba00445e8b7395ec3939245c6f15a2991a5703fc505881057de9df36410", hexutil.Encode(root[:]))
+
+	invalid := testConsensusInfo()
+	invalid.ValidatorList[3] = "0x00"
+	_, err = invalid.HashTreeRoot()
+	assert.ErrorContains(t, "public key of validator 3 has 1 bytes", err)
+}
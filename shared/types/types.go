@@ -10,11 +10,13 @@ import (
 type Status string
 
 const (
-	Pending  Status = "Pending"
-	Verified Status = "Verified"
-	Invalid  Status = "Invalid"
-	Skipped  Status = "Skipped"
-	Unknown  Status = "Unknown"
+	Pending   Status = "Pending"
+	Verified  Status = "Verified"
+	Invalid   Status = "Invalid"
+	Skipped   Status = "Skipped"
+	Unknown   Status = "Unknown"
+	Finalized Status = "Finalized"
+	Orphaned  Status = "Orphaned"
 )
 
 // ExtraData
@@ -30,6 +32,283 @@ type SlotInfo struct {
 	PandoraHeaderHash common.Hash
 }
 
+// FinalizedHead
+type FinalizedHead struct {
+	Slot              uint64      `json:"slot"`
+	Epoch             uint64      `json:"epoch"`
+	PandoraHeaderHash common.Hash `json:"pandoraHeaderHash"`
+	VanguardBlockHash common.Hash `json:"vanguardBlockHash"`
+}
+
+// VerificationProgress describes how far the orchestrator has verified both chains
+type VerificationProgress struct {
+	LatestVerifiedSlot     uint64  `json:"latestVerifiedSlot"`
+	LatestFinalizedSlot    uint64  `json:"latestFinalizedSlot"`
+	LatestFinalizedEpoch   uint64  `json:"latestFinalizedEpoch"`
+	PendingSlots           uint64  `json:"pendingSlots"`
+	UnmatchedSlots         uint64  `json:"unmatchedSlots"`
+	SkippedSlots           uint64  `json:"skippedSlots"`
+	VerifiedSlots          uint64  `json:"verifiedSlots"`
+	VerifiedSlotsPerMinute float64 `json:"verifiedSlotsPerMinute"`
+	VerificationLag        uint64  `json:"verificationLag"`
+}
+
+// MismatchEvidence keeps both sides of a slot which has been rejected by verification
+type MismatchEvidence struct {
+	Slot              uint64             `json:"slot"`
+	Reason            string             `json:"reason"`
+	VanguardShardInfo *VanguardShardInfo `json:"vanguardShardInfo"`
+	PandoraHeader     *eth1Types.Header  `json:"pandoraHeader"`
+}
+
+// SlotVerdict is the verification verdict of a slot which is stored as a part of verification batch.
+// Evidence is only set for rejected slots.
+type SlotVerdict struct {
+	Slot     uint64
+	Status   Status
+	SlotInfo *SlotInfo
+	Evidence *MismatchEvidence
+}
+
+// OrphanSlot is a slot which has stayed pending beyond maximum age and has been evicted from verification
+type OrphanSlot struct {
+	Slot              uint64      `json:"slot"`
+	Reason            string      `json:"reason"`
+	PendingSince      int64       `json:"pendingSince"`
+	EvictedAt         int64       `json:"evictedAt"`
+	VanguardBlockHash common.Hash `json:"vanguardBlockHash"`
+	PandoraHeaderHash common.Hash `json:"pandoraHeaderHash"`
+}
+
+// kinds of alerts which are raised by consensus pipeline
+const (
+	AlertMismatch     = "mismatch"
+	AlertEquivocation = "equivocation"
+	AlertUnmatched    = "unmatched"
+	AlertDivergence   = "divergence"
+)
+
+// Alert is sent to alert sinks when consensus pipeline detects a problem which needs operator attention
+type Alert struct {
+	Kind              string      `json:"kind"`
+	Slot              uint64      `json:"slot"`
+	Message           string      `json:"message"`
+	Timestamp         int64       `json:"timestamp"`
+	VanguardBlockHash common.Hash `json:"vanguardBlockHash,omitempty"`
+	PandoraHeaderHash common.Hash `json:"pandoraHeaderHash,omitempty"`
+}
+
+// CanonicalHead is the head block of vanguard chain which is selected by fork choice
+type CanonicalHead struct {
+	Slot      uint64      `json:"slot"`
+	BlockHash common.Hash `json:"blockHash"`
+}
+
+// VerificationCheckpoint is the latest verified slot from which verification is resumed after restart
+type VerificationCheckpoint struct {
+	Slot              uint64      `json:"slot"`
+	PandoraHeaderHash common.Hash `json:"pandoraHeaderHash"`
+	VanguardBlockHash common.Hash `json:"vanguardBlockHash"`
+}
+
+// ChainConfig holds the chain parameters which a database has been created with, zero seconds per slot and
+// genesis time are not known
+type ChainConfig struct {
+	SlotsPerEpoch  uint64 `json:"slotsPerEpoch"`
+	SecondsPerSlot uint64 `json:"secondsPerSlot"`
+	GenesisTime    uint64 `json:"genesisTime"`
+}
+
+// SyncCheckpoint is the finalized verification state which a fresh orchestrator downloads from a trusted
+// orchestrator before it continues with live verification
+type SyncCheckpoint struct {
+	FinalizedHead *FinalizedHead `json:"finalizedHead"`
+	LatestEpoch   uint64         `json:"latestEpoch"`
+	ChainConfig   *ChainConfig   `json:"chainConfig"`
+}
+
+// ReverifyResult summarizes re-verification of a slot range
+type ReverifyResult struct {
+	FromSlot      uint64   `json:"fromSlot"`
+	ToSlot        uint64   `json:"toSlot"`
+	VerifiedSlots uint64   `json:"verifiedSlots"`
+	InvalidSlots  uint64   `json:"invalidSlots"`
+	MissingSlots  uint64   `json:"missingSlots"`
+	ChangedSlots  []uint64 `json:"changedSlots"`
+}
+
+// PandoraBlockStatus is the orchestrator status of a pandora block with the vanguard block it has been paired with
+type PandoraBlockStatus struct {
+	Slot              uint64      `json:"slot"`
+	PandoraHeaderHash common.Hash `json:"pandoraHeaderHash"`
+	VanguardBlockHash common.Hash `json:"vanguardBlockHash"`
+	Status            Status      `json:"status"`
+}
+
+// ReplayResult is the verdict of a slot which has been re-derived from stored data by replay
+type ReplayResult struct {
+	Slot           uint64 `json:"slot"`
+	StoredStatus   Status `json:"storedStatus"`
+	ReplayedStatus Status `json:"replayedStatus"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// ReplaySummary summarizes replay of stored verification results
+type ReplaySummary struct {
+	ReplayedSlots   uint64   `json:"replayedSlots"`
+	ReverifiedSlots uint64   `json:"reverifiedSlots"`
+	MismatchedSlots []uint64 `json:"mismatchedSlots"`
+}
+
+// EpochSummary summarizes verification of the slots of an epoch once verification has moved past the epoch
+type EpochSummary struct {
+	Epoch    uint64 `json:"epoch"`
+	FromSlot uint64 `json:"fromSlot"`
+	ToSlot   uint64 `json:"toSlot"`
+	// number of slots by their status at the time of the summary, finalized slots are counted as verified
+	VerifiedSlots uint64 `json:"verifiedSlots"`
+	SkippedSlots  uint64 `json:"skippedSlots"`
+	InvalidSlots  uint64 `json:"invalidSlots"`
+	OrphanedSlots uint64 `json:"orphanedSlots"`
+	PendingSlots  uint64 `json:"pendingSlots"`
+	UnknownSlots  uint64 `json:"unknownSlots"`
+	// ConfirmedSlots is the number of slots which have been reported as verified while the node was running,
+	// AvgConfirmationLatencyMs is their average time in milliseconds from the start of the slot until the
+	// report. The latency is zero when genesis time is not known.
+	ConfirmedSlots           uint64 `json:"confirmedSlots"`
+	AvgConfirmationLatencyMs uint64 `json:"avgConfirmationLatencyMs"`
+	// ProposerParticipation is the share of slots with a vanguard block among the slots which have been seen
+	ProposerParticipation float64 `json:"proposerParticipation"`
+	// MissedProposers are public keys of the validators which were assigned to the skipped slots
+	MissedProposers []string `json:"missedProposers,omitempty"`
+	Timestamp       int64    `json:"timestamp"`
+}
+
+// ReorgAuditRecord describes the verified slots which have been invalidated by a reorg
+type ReorgAuditRecord struct {
+	Timestamp        int64    `json:"timestamp"`
+	NewSlot          uint64   `json:"newSlot"`
+	RevertSlot       uint64   `json:"revertSlot"`
+	InvalidatedSlots []uint64 `json:"invalidatedSlots"`
+	// InvalidatedSlotInfos are the blocks of the invalidated slots in the same order, records which have been
+	// stored by older versions do not have them
+	InvalidatedSlotInfos []*SlotInfo `json:"invalidatedSlotInfos,omitempty"`
+}
+
+// HistoricalHead is the verified head which the orchestrator had as of a past slot, it is reconstructed from the
+// verified slots, the reorg audit records and the finalization history
+type HistoricalHead struct {
+	AsOfSlot          uint64      `json:"asOfSlot"`
+	Slot              uint64      `json:"slot"`
+	PandoraHeaderHash common.Hash `json:"pandoraHeaderHash"`
+	VanguardBlockHash common.Hash `json:"vanguardBlockHash"`
+	FinalizedSlot     uint64      `json:"finalizedSlot"`
+	// LatestReorg is the latest reorg up to the slot, nil when no reorg had happened by then
+	LatestReorg *ReorgAuditRecord `json:"latestReorg,omitempty"`
+}
+
+// HistoricalBlockStatus is the status which a pandora block had as of a past slot
+type HistoricalBlockStatus struct {
+	AsOfSlot uint64 `json:"asOfSlot"`
+	PandoraBlockStatus
+	// Reorg is the reorg which had invalidated the block by then, nil when the block was not reorged
+	Reorg *ReorgAuditRecord `json:"reorg,omitempty"`
+}
+
+// BackupResult describes a database backup
+type BackupResult struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+	// latest verified slot at the time of the backup
+	Slot uint64 `json:"slot"`
+}
+
+// DatabaseInspection summarizes the content of orchestrator database
+type DatabaseInspection struct {
+	Path                 string         `json:"path"`
+	Size                 int64          `json:"size"`
+	LatestVerifiedSlot   uint64         `json:"latestVerifiedSlot"`
+	LatestFinalizedSlot  uint64         `json:"latestFinalizedSlot"`
+	LatestFinalizedEpoch uint64         `json:"latestFinalizedEpoch"`
+	LatestEpoch          uint64         `json:"latestEpoch"`
+	Buckets              map[string]int `json:"buckets"` // number of entries by bucket name
+}
+
+// DatabaseWriteStats describes the writes of committed transactions since the database has been opened
+type DatabaseWriteStats struct {
+	// PageWrites is the number of write calls of dirty pages, including meta pages
+	PageWrites int64 `json:"pageWrites"`
+	// BytesWritten is the size of dirty pages which have been written
+	BytesWritten int64 `json:"bytesWritten"`
+	// LiveBytes is the size of keys and values which are currently stored in all buckets
+	LiveBytes int64 `json:"liveBytes"`
+}
+
+// PruneResult summarizes stored data which has been removed by pruning
+type PruneResult struct {
+	BeforeSlot    uint64 `json:"beforeSlot"`
+	BeforeEpoch   uint64 `json:"beforeEpoch"`
+	PrunedSlots   uint64 `json:"prunedSlots"`
+	PrunedEpochs  uint64 `json:"prunedEpochs"`
+	PrunedEntries uint64 `json:"prunedEntries"`
+}
+
+// EndpointStatus describes the connection of orchestrator with a vanguard or pandora node
+type EndpointStatus struct {
+	Name      string `json:"name"`
+	Endpoint  string `json:"endpoint"`
+	Connected bool   `json:"connected"`
+	Version   string `json:"version,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// CacheStatus describes the number of entries of an in-memory cache
+type CacheStatus struct {
+	Name    string `json:"name"`
+	Entries int    `json:"entries"`
+}
+
+// ServiceStatus describes the state of a registered service
+type ServiceStatus struct {
+	Name     string `json:"name"`
+	Started  bool   `json:"started"`
+	State    string `json:"state,omitempty"` // stage of lifecycle in service registry, e.g. waiting or failed
+	Error    string `json:"error,omitempty"`
+	Since    uint64 `json:"since,omitempty"`    // unix time of the latest change of the state
+	Restarts uint64 `json:"restarts,omitempty"` // number of restarts after crashes
+}
+
+// Capabilities describes the api versions and features supported by orchestrator node, so clients can
+// negotiate behavior across orchestrator upgrades
+type Capabilities struct {
+	ClientVersion        string            `json:"clientVersion"`
+	APIVersion           string            `json:"apiVersion"`
+	SupportedAPIVersions []string          `json:"supportedApiVersions"`
+	Namespaces           map[string]string `json:"namespaces"`
+	Features             []string          `json:"features"`
+}
+
+// BuildInfo describes the build of orchestrator client
+type BuildInfo struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"gitCommit"`
+	BuildDate string `json:"buildDate"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
+
+// NodeStatus summarizes the state of orchestrator node for operators
+type NodeStatus struct {
+	Version    string                  `json:"version"`
+	Endpoints  []*EndpointStatus       `json:"endpoints"`
+	Checkpoint *VerificationCheckpoint `json:"checkpoint"`
+	Progress   *VerificationProgress   `json:"progress"`
+	DBPath     string                  `json:"dbPath"`
+	DBSize     int64                   `json:"dbSize"`
+	Caches     []*CacheStatus          `json:"caches"`
+	Services   []*ServiceStatus        `json:"services"`
+}
+
 // CopyHeader creates a deep copy of a block header to prevent side effects from
 // modifying a header variable.
 func CopyHeader(h *eth1Types.Header) *eth1Types.Header {
@@ -6,22 +6,22 @@ import (
 	"fmt"
 	"log"
 	"os/exec"
+	"runtime"
 	"strings"
 	"time"
 )
 
-// The value of these vars are set through linker options.
+// The value of these vars are set through linker options, e.g.
+// go build -ldflags "-X github.com/lukso-network/lukso-orchestrator/shared/version.gitTag=v0.1.0
+// -X github.com/lukso-network/lukso-orchestrator/shared/version.gitCommit=$(git rev-parse HEAD)
+// -X github.com/lukso-network/lukso-orchestrator/shared/version.buildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
 var gitCommit = "Local build"
 var buildDate = "Moments ago"
 var gitTag = "Unknown"
 
 // Version returns the version string of this build.
 func Version() string {
-	if buildDate == "{DATE}" {
-		now := time.Now().Format(time.RFC3339)
-		buildDate = now
-	}
-	return fmt.Sprintf("%s. Built at: %s", BuildData(), buildDate)
+	return fmt.Sprintf("%s. Built at: %s", BuildData(), BuildDate())
 }
 
 // SemanticVersion returns the Major.Minor.Patch version of this build.
@@ -31,6 +31,11 @@ func SemanticVersion() string {
 
 // BuildData returns the git tag and commit of the current build.
 func BuildData() string {
+	return fmt.Sprintf("Orchestrator/%s/%s", gitTag, GitCommit())
+}
+
+// GitCommit returns the git commit of this build.
+func GitCommit() string {
 	// if doing a local build, these values are not interpolated
 	if gitCommit == "{STABLE_GIT_COMMIT}" {
 		commit, err := exec.Command("git", "rev-parse", "HEAD").Output()
@@ -40,5 +45,24 @@ func BuildData() string {
 			gitCommit = strings.TrimRight(string(commit), "\r\n")
 		}
 	}
-	return fmt.Sprintf("Orchestrator/%s/%s", gitTag, gitCommit)
+	return gitCommit
+}
+
+// BuildDate returns the date of this build.
+func BuildDate() string {
+	if buildDate == "{DATE}" {
+		now := time.Now().Format(time.RFC3339)
+		buildDate = now
+	}
+	return buildDate
+}
+
+// GoVersion returns the version of Go which this build has been compiled with.
+func GoVersion() string {
+	return runtime.Version()
+}
+
+// Platform returns the operating system and architecture of this build.
+func Platform() string {
+	return runtime.GOOS + "/" + runtime.GOARCH
 }
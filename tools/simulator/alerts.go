@@ -0,0 +1,65 @@
+package simulator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// alertReceiver is the webhook which orchestrator delivers its alerts to
+type alertReceiver struct {
+	http *httptest.Server
+
+	lock    sync.Mutex
+	alerts  []*types.Alert
+	changed chan struct{} // closed and replaced when an alert is received
+}
+
+func newAlertReceiver() *alertReceiver {
+	r := &alertReceiver{changed: make(chan struct{})}
+	r.http = httptest.NewServer(http.HandlerFunc(r.serveHTTP))
+	return r
+}
+
+func (r *alertReceiver) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	alert := new(types.Alert)
+	if err := json.NewDecoder(req.Body).Decode(alert); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	log.WithField("kind", alert.Kind).WithField("slot", alert.Slot).Info("Received alert of orchestrator")
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.alerts = append(r.alerts, alert)
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// waitForAlert waits until orchestrator has raised an alert of the given kind for the slot
+func (r *alertReceiver) waitForAlert(ctx context.Context, kind string, slot uint64) (*types.Alert, error) {
+	for {
+		r.lock.Lock()
+		changed := r.changed
+		for _, alert := range r.alerts {
+			if alert.Kind == kind && alert.Slot == slot {
+				r.lock.Unlock()
+				return alert, nil
+			}
+		}
+		r.lock.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "no %s alert of slot %d", kind, slot)
+		}
+	}
+}
+
+func (r *alertReceiver) close() {
+	r.http.Close()
+}
@@ -0,0 +1,103 @@
+package simulator
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Slot holds the vanguard block and the pandora header which are produced for a slot. The pandora shard of the
+// block matches the header unless the slot has been produced as a mismatch.
+type Slot struct {
+	Number uint64
+	Block  *ethpb.BeaconBlock
+	Header *eth1Types.Header
+}
+
+// BlockRoot returns the hash tree root of the vanguard block which orchestrator uses as vanguard block hash
+func (s *Slot) BlockRoot() common.Hash {
+	root, err := s.Block.HashTreeRoot()
+	if err != nil {
+		// blocks of the simulator are always complete
+		panic(err)
+	}
+	return common.Hash(root)
+}
+
+// chain builds slots on top of the latest produced slots. Every built slot is unique, so two slots of the same
+// number are two competing blocks of that slot.
+type chain struct {
+	blocks  uint64 // number of slots which have been built so far
+	genesis uint64
+	parents map[uint64]*Slot
+}
+
+func newChain() *chain {
+	genesis := params.ActiveNetworkConfig().GenesisTime
+	if genesis == 0 {
+		genesis = uint64(time.Now().Unix())
+	}
+	return &chain{genesis: genesis, parents: make(map[uint64]*Slot)}
+}
+
+// build creates a vanguard block with a matching pandora header for the slot, they are children of the latest
+// produced blocks of the previous slot
+func (c *chain) build(number uint64) *Slot {
+	c.blocks++
+	header := testutil.NewEth1Header(number)
+	header.Nonce = eth1Types.EncodeNonce(c.blocks)
+	header.Time = c.genesis + number*params.ActiveNetworkConfig().SecondsPerSlot
+
+	block := testutil.NewBeaconBlock(number)
+	binary.BigEndian.PutUint64(block.Body.Graffiti, c.blocks)
+	if number > 0 && c.parents[number-1] != nil {
+		parent := c.parents[number-1]
+		header.ParentHash = parent.Header.Hash()
+		parentRoot := parent.BlockRoot()
+		block.ParentRoot = parentRoot[:]
+	}
+	block.Body.PandoraShard = []*ethpb.PandoraShard{testutil.NewPandoraShard(header)}
+	return &Slot{Number: number, Block: block, Header: header}
+}
+
+// buildMismatch creates a slot whose vanguard block carries the shard of another pandora header than the one
+// which is returned, so orchestrator must reject it
+func (c *chain) buildMismatch(number uint64) *Slot {
+	slot := c.build(number)
+	other := c.build(number)
+	slot.Block.Body.PandoraShard = other.Block.Body.PandoraShard
+	return slot
+}
+
+// produced makes the slot the parent of the slots which are built for the next slot
+func (c *chain) produced(slot *Slot) {
+	c.parents[slot.Number] = slot
+}
+
+// parent returns the latest produced slot of the given number
+func (c *chain) parent(number uint64) *Slot {
+	return c.parents[number]
+}
+
+// consensusInfo creates minimal consensus info of the epoch with one proposer per slot
+func (c *chain) consensusInfo(epoch uint64) *ethpb.MinimalConsensusInfo {
+	config := params.ActiveNetworkConfig()
+	validators := make([]string, config.SlotsPerEpoch)
+	for i := range validators {
+		validators[i] = hexutil.Encode(make([]byte, 48))
+	}
+	return &ethpb.MinimalConsensusInfo{
+		Epoch:            eth2Types.Epoch(epoch),
+		ValidatorList:    validators,
+		EpochTimeStart:   c.genesis + epoch*config.SlotsPerEpoch*config.SecondsPerSlot,
+		SlotTimeDuration: &durationpb.Duration{Seconds: int64(config.SecondsPerSlot)},
+	}
+}
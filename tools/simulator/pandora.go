@@ -0,0 +1,270 @@
+package simulator
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// Pandora is an in-process pandora node which serves the websocket json-rpc api that orchestrator consumes. Headers
+// are stored when they are published, so subscriptions which are opened after a disconnect replay them. Verdicts
+// which orchestrator pushes by orc_confirmBlock are recorded.
+type Pandora struct {
+	chainID uint64
+	http    *httptest.Server
+
+	lock     sync.Mutex
+	server   *rpc.Server
+	ws       http.Handler
+	headers  []*eth1Types.Header // canonical headers in slot order
+	verdicts map[common.Hash]types.Status
+	changed  chan struct{} // closed and replaced when a verdict is recorded
+
+	headerFeed event.Feed
+}
+
+// NewPandora starts a fake pandora node on a random local port
+func NewPandora(chainID uint64) (*Pandora, error) {
+	p := &Pandora{
+		chainID:  chainID,
+		verdicts: make(map[common.Hash]types.Status),
+		changed:  make(chan struct{}),
+	}
+	if err := p.Reconnect(); err != nil {
+		return nil, err
+	}
+	p.http = httptest.NewServer(http.HandlerFunc(p.serveHTTP))
+	log.WithField("endpoint", p.Endpoint()).Info("Serving pandora websocket rpc")
+	return p, nil
+}
+
+// Endpoint returns the websocket endpoint which orchestrator connects to
+func (p *Pandora) Endpoint() string {
+	return "ws" + strings.TrimPrefix(p.http.URL, "http")
+}
+
+// serveHTTP upgrades connections to the current rpc server and refuses them while pandora is disconnected
+func (p *Pandora) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	p.lock.Lock()
+	ws := p.ws
+	p.lock.Unlock()
+	if ws == nil {
+		http.Error(w, "pandora is disconnected", http.StatusServiceUnavailable)
+		return
+	}
+	ws.ServeHTTP(w, r)
+}
+
+// Disconnect closes all connections of orchestrator and refuses new ones until Reconnect is called
+func (p *Pandora) Disconnect() {
+	p.lock.Lock()
+	server := p.server
+	p.server, p.ws = nil, nil
+	p.lock.Unlock()
+	if server != nil {
+		server.Stop()
+		log.Info("Disconnected pandora")
+	}
+}
+
+// Reconnect accepts connections of orchestrator again
+func (p *Pandora) Reconnect() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.server != nil {
+		return nil
+	}
+	server := rpc.NewServer()
+	api := &pandoraAPI{pandora: p}
+	for _, namespace := range []string{"eth", "orc", "web3"} {
+		if err := server.RegisterName(namespace, api); err != nil {
+			return err
+		}
+	}
+	p.server, p.ws = server, server.WebsocketHandler([]string{"*"})
+	return nil
+}
+
+// Close stops the pandora node for good
+func (p *Pandora) Close() {
+	p.Disconnect()
+	p.http.Close()
+}
+
+// PublishHeader appends the header to the canonical headers and streams it to orchestrator. A header of a slot
+// which already has a header replaces it and all the following headers.
+func (p *Pandora) PublishHeader(header *eth1Types.Header) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.truncate(header.Number.Uint64())
+	p.headers = append(p.headers, header)
+	p.headerFeed.Send(header)
+}
+
+// Replace replaces the canonical headers from the number of the first given header without streaming them, open
+// subscriptions only see the new headers once they are opened again
+func (p *Pandora) Replace(headers []*eth1Types.Header) {
+	if len(headers) == 0 {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.truncate(headers[0].Number.Uint64())
+	p.headers = append(p.headers, headers...)
+}
+
+// truncate removes the headers from the given number, the lock must be held
+func (p *Pandora) truncate(number uint64) {
+	for i, header := range p.headers {
+		if header.Number.Uint64() >= number {
+			p.headers = p.headers[:i]
+			return
+		}
+	}
+}
+
+// Verdict returns the latest verdict which orchestrator has pushed for the header
+func (p *Pandora) Verdict(hash common.Hash) (types.Status, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	status, ok := p.verdicts[hash]
+	return status, ok
+}
+
+// WaitForVerdict waits until the latest verdict which orchestrator has pushed for the header is the given status
+func (p *Pandora) WaitForVerdict(ctx context.Context, hash common.Hash, status types.Status) error {
+	for {
+		p.lock.Lock()
+		verdict, ok := p.verdicts[hash]
+		changed := p.changed
+		p.lock.Unlock()
+		if ok && verdict == status {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			if !ok {
+				verdict = types.Unknown
+			}
+			return errors.Wrapf(ctx.Err(), "pandora header %s is %s, expected %s", hash.Hex(), verdict, status)
+		}
+	}
+}
+
+// recordVerdict stores the verdict which orchestrator has pushed and wakes up the waiting scenarios
+func (p *Pandora) recordVerdict(blockStatus *types.BlockStatus) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.verdicts[blockStatus.Hash] = blockStatus.Status
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// pandoraAPI is registered under eth, orc and web3 namespaces
+type pandoraAPI struct {
+	pandora *Pandora
+}
+
+// ChainId
+func (api *pandoraAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(new(big.Int).SetUint64(api.pandora.chainID))
+}
+
+// Syncing reports that the fake pandora node is always synced
+func (api *pandoraAPI) Syncing() bool {
+	return false
+}
+
+// ClientVersion
+func (api *pandoraAPI) ClientVersion() string {
+	return "Pandora/simulator"
+}
+
+// BlockNumber returns the number of the latest canonical header
+func (api *pandoraAPI) BlockNumber() hexutil.Uint64 {
+	api.pandora.lock.Lock()
+	defer api.pandora.lock.Unlock()
+	if len(api.pandora.headers) == 0 {
+		return 0
+	}
+	return hexutil.Uint64(api.pandora.headers[len(api.pandora.headers)-1].Number.Uint64())
+}
+
+// GetBlockByNumber returns the canonical header of the given number or nil when it does not exist
+func (api *pandoraAPI) GetBlockByNumber(number hexutil.Uint64, fullTx bool) *eth1Types.Header {
+	api.pandora.lock.Lock()
+	defer api.pandora.lock.Unlock()
+	for _, header := range api.pandora.headers {
+		if header.Number.Uint64() == uint64(number) {
+			return header
+		}
+	}
+	return nil
+}
+
+// ConfirmBlock records the verification verdict which orchestrator pushes
+func (api *pandoraAPI) ConfirmBlock(blockStatus *types.BlockStatus) bool {
+	api.pandora.recordVerdict(blockStatus)
+	return true
+}
+
+// NewPendingBlockHeaders replays the canonical headers after the header of the filter, or all of them when the
+// header is not known, and streams new headers afterwards
+func (api *pandoraAPI) NewPendingBlockHeaders(
+	ctx context.Context,
+	filter types.PandoraPendingHeaderFilter,
+) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	p := api.pandora
+	ch := make(chan *eth1Types.Header, streamBuffer)
+	p.lock.Lock()
+	sub := p.headerFeed.Subscribe(ch)
+	replay := p.headers
+	for i, header := range p.headers {
+		if header.Hash() == filter.FromBlockHash {
+			replay = p.headers[i+1:]
+			break
+		}
+	}
+	replay = append([]*eth1Types.Header{}, replay...)
+	p.lock.Unlock()
+
+	go func() {
+		defer sub.Unsubscribe()
+		for _, header := range replay {
+			if err := notifier.Notify(rpcSub.ID, header); err != nil {
+				return
+			}
+		}
+		for {
+			select {
+			case header := <-ch:
+				if err := notifier.Notify(rpcSub.ID, header); err != nil {
+					return
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
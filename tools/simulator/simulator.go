@@ -0,0 +1,258 @@
+// Package simulator runs a real orchestrator node against in-process vanguard and pandora nodes which are scripted
+// by scenarios: epochs, blocks, mismatches, equivocations, reorgs and disconnects. Scenarios observe orchestrator
+// only from the outside, through the verdicts which it pushes to pandora and the alerts which it delivers to its
+// webhook, so regressions in the wiring between its services are caught by go test instead of on devnets.
+package simulator
+
+import (
+	"context"
+	"flag"
+	"strconv"
+	"sync"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/node"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/urfave/cli/v2"
+)
+
+// chain id of the simulated pandora network
+const pandoraChainID = 4004181
+
+// nodeFlags are the flags of orchestrator node which the simulation sets, all other flags keep their zero values
+var nodeFlags = []cli.Flag{
+	cmd.DataDirFlag,
+	cmd.VanguardGRPCEndpoint,
+	cmd.PandoraRPCEndpoint,
+	cmd.PandoraRPCNamespace,
+	cmd.PandoraSubscriptionMethod,
+	cmd.PandoraChainID,
+	cmd.PendingTimeoutFlag,
+	cmd.ConfirmationDepthFlag,
+	cmd.VerificationWorkersFlag,
+	cmd.AlertWebhookFlag,
+	cmd.RelayBufferSizeFlag,
+	cmd.RelayBufferPolicyFlag,
+	cmd.RPCSubscriptionBufferSizeFlag,
+	cmd.RPCSubscriptionBufferPolicyFlag,
+}
+
+// Config of a simulation
+type Config struct {
+	// DataDir is the data directory of orchestrator node, it must not be shared between simulations
+	DataDir string
+	// Flags overrides flags of orchestrator node by name, only flags of nodeFlags can be overridden
+	Flags map[string]string
+}
+
+// Simulator runs orchestrator node against fake vanguard and pandora nodes
+type Simulator struct {
+	Vanguard *Vanguard
+	Pandora  *Pandora
+	Node     *node.OrchestratorNode
+
+	lock    sync.Mutex
+	chain   *chain
+	alerts  *alertReceiver
+	stopped chan error
+}
+
+// New starts fake vanguard and pandora nodes and creates orchestrator node which is connected to them
+func New(ctx context.Context, cfg *Config) (*Simulator, error) {
+	if cfg == nil || cfg.DataDir == "" {
+		return nil, errors.New("data directory of simulated orchestrator is required")
+	}
+	vanguard, err := NewVanguard()
+	if err != nil {
+		return nil, err
+	}
+	pandora, err := NewPandora(pandoraChainID)
+	if err != nil {
+		vanguard.Close()
+		return nil, err
+	}
+	sim := &Simulator{
+		Vanguard: vanguard,
+		Pandora:  pandora,
+		chain:    newChain(),
+		alerts:   newAlertReceiver(),
+	}
+
+	set := flag.NewFlagSet("simulator", flag.ContinueOnError)
+	for _, f := range nodeFlags {
+		if err := f.Apply(set); err != nil {
+			sim.closeChains()
+			return nil, err
+		}
+	}
+	values := map[string]string{
+		cmd.DataDirFlag.Name:          cfg.DataDir,
+		cmd.VanguardGRPCEndpoint.Name: vanguard.Endpoint(),
+		cmd.PandoraRPCEndpoint.Name:   pandora.Endpoint(),
+		cmd.PandoraChainID.Name:       strconv.Itoa(pandoraChainID),
+		cmd.AlertWebhookFlag.Name:     sim.alerts.http.URL,
+	}
+	for name, value := range cfg.Flags {
+		values[name] = value
+	}
+	for name, value := range values {
+		if err := set.Set(name, value); err != nil {
+			sim.closeChains()
+			return nil, errors.Wrapf(err, "could not set flag %s of simulated orchestrator", name)
+		}
+	}
+	cliCtx := cli.NewContext(&cli.App{}, set, nil)
+	cliCtx.Context = ctx
+
+	sim.Node, err = node.New(cliCtx)
+	if err != nil {
+		sim.closeChains()
+		return nil, errors.Wrap(err, "could not create simulated orchestrator")
+	}
+	return sim, nil
+}
+
+// Start starts orchestrator node in background
+func (s *Simulator) Start() {
+	s.stopped = make(chan error, 1)
+	go func() {
+		s.stopped <- s.Node.Start()
+	}()
+}
+
+// Stop stops orchestrator node and the fake chains. It returns the failure which has stopped the node, if any.
+func (s *Simulator) Stop() error {
+	s.Node.Close()
+	var err error
+	if s.stopped != nil {
+		err = <-s.stopped
+	}
+	s.closeChains()
+	return err
+}
+
+func (s *Simulator) closeChains() {
+	s.Vanguard.Close()
+	s.Pandora.Close()
+	s.alerts.close()
+}
+
+// ProduceEpoch publishes minimal consensus info of the epoch on vanguard
+func (s *Simulator) ProduceEpoch(epoch uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.Vanguard.PublishConsensusInfo(s.chain.consensusInfo(epoch))
+}
+
+// NewSlot builds a vanguard block and a matching pandora header of the slot on top of the latest produced slot
+// without publishing them. Every call builds a different pair, so two calls for the same slot build competing
+// blocks.
+func (s *Simulator) NewSlot(number uint64) *Slot {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.chain.build(number)
+}
+
+// NewMismatch builds a slot whose vanguard block carries the shard of another pandora header
+func (s *Simulator) NewMismatch(number uint64) *Slot {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.chain.buildMismatch(number)
+}
+
+// PublishVanguard publishes the vanguard block of the slot
+func (s *Simulator) PublishVanguard(slot *Slot) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := s.Vanguard.PublishBlock(slot.Block); err != nil {
+		return err
+	}
+	s.chain.produced(slot)
+	return nil
+}
+
+// PublishPandora publishes the pandora header of the slot
+func (s *Simulator) PublishPandora(slot *Slot) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.Pandora.PublishHeader(slot.Header)
+	s.chain.produced(slot)
+}
+
+// Publish publishes both the vanguard block and the pandora header of the slot
+func (s *Simulator) Publish(slot *Slot) error {
+	if err := s.PublishVanguard(slot); err != nil {
+		return err
+	}
+	s.PublishPandora(slot)
+	return nil
+}
+
+// ProduceSlots builds and publishes matching blocks of both chains for the slots in the range
+func (s *Simulator) ProduceSlots(fromSlot, toSlot uint64) ([]*Slot, error) {
+	slots := make([]*Slot, 0, toSlot-fromSlot+1)
+	for number := fromSlot; number <= toSlot; number++ {
+		slot := s.NewSlot(number)
+		if err := s.Publish(slot); err != nil {
+			return nil, err
+		}
+		slots = append(slots, slot)
+	}
+	return slots, nil
+}
+
+// Finalize makes vanguard report the given finalized checkpoint with every following block
+func (s *Simulator) Finalize(slot, epoch uint64) {
+	s.Vanguard.Finalize(slot, epoch)
+}
+
+// Reorg replaces the blocks of both chains from fromSlot to toSlot with a new fork and announces it by consensus info
+// of the epoch which carries reorg info, the same as vanguard does when fork choice switches to another fork. The new
+// blocks are only delivered when orchestrator subscribes again.
+func (s *Simulator) Reorg(epoch, fromSlot, toSlot uint64) ([]*Slot, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	parent := s.chain.parent(fromSlot - 1)
+	if parent == nil {
+		return nil, errors.Errorf("slot %d has not been produced, reorg needs a common ancestor", fromSlot-1)
+	}
+	slots := make([]*Slot, 0, toSlot-fromSlot+1)
+	blocks := make([]*ethpb.BeaconBlock, 0, cap(slots))
+	headers := make([]*eth1Types.Header, 0, cap(slots))
+	for number := fromSlot; number <= toSlot; number++ {
+		slot := s.chain.build(number)
+		s.chain.produced(slot)
+		slots = append(slots, slot)
+		blocks = append(blocks, slot.Block)
+		headers = append(headers, slot.Header)
+	}
+	if err := s.Vanguard.Replace(blocks); err != nil {
+		return nil, err
+	}
+	s.Pandora.Replace(headers)
+
+	parentRoot := parent.BlockRoot()
+	consensusInfo := s.chain.consensusInfo(epoch)
+	consensusInfo.ReorgInfo = &ethpb.Reorg{
+		VanParentHash: parentRoot[:],
+		PanParentHash: parent.Header.Hash().Bytes(),
+		NewSlot:       eth2Types.Slot(fromSlot),
+	}
+	s.Vanguard.PublishConsensusInfo(consensusInfo)
+	log.WithField("fromSlot", fromSlot).WithField("toSlot", toSlot).Info("Reorganized vanguard and pandora")
+	return slots, nil
+}
+
+// WaitForVerdict waits until orchestrator has pushed the given verdict of the pandora header of the slot
+func (s *Simulator) WaitForVerdict(ctx context.Context, slot *Slot, status types.Status) error {
+	return s.Pandora.WaitForVerdict(ctx, slot.Header.Hash(), status)
+}
+
+// WaitForAlert waits until orchestrator has delivered an alert of the given kind for the slot to its webhook
+func (s *Simulator) WaitForAlert(ctx context.Context, kind string, slot uint64) (*types.Alert, error) {
+	return s.alerts.waitForAlert(ctx, kind, slot)
+}
@@ -0,0 +1,117 @@
+package simulator
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// maximum time which a scenario waits for orchestrator, it covers reconnect backoff of chain services
+const scenarioTimeout = 30 * time.Second
+
+// startSimulator runs orchestrator against fake chains which have produced the first epoch
+func startSimulator(t *testing.T) (*Simulator, context.Context) {
+	sim, err := New(context.Background(), &Config{DataDir: filepath.Join(t.TempDir(), "datadir")})
+	require.NoError(t, err)
+	sim.Start()
+	t.Cleanup(func() {
+		assert.NoError(t, sim.Stop())
+	})
+	sim.ProduceEpoch(0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), scenarioTimeout)
+	t.Cleanup(cancel)
+	return sim, ctx
+}
+
+func waitForVerified(ctx context.Context, t *testing.T, sim *Simulator, slots []*Slot) {
+	for _, slot := range slots {
+		require.NoError(t, sim.WaitForVerdict(ctx, slot, types.Verified), "slot %d", slot.Number)
+	}
+}
+
+func TestSimulator_VerifiesMatchingSlots(t *testing.T) {
+	sim, ctx := startSimulator(t)
+
+	slots, err := sim.ProduceSlots(1, 8)
+	require.NoError(t, err)
+	waitForVerified(ctx, t, sim, slots)
+}
+
+// TestSimulator_RejectsMismatch checks that a slot whose vanguard shard does not match the pandora header is
+// reported invalid to pandora and to the alert webhook, while the following slots are verified
+func TestSimulator_RejectsMismatch(t *testing.T) {
+	sim, ctx := startSimulator(t)
+
+	slots, err := sim.ProduceSlots(1, 2)
+	require.NoError(t, err)
+	waitForVerified(ctx, t, sim, slots)
+
+	mismatch := sim.NewMismatch(3)
+	require.NoError(t, sim.Publish(mismatch))
+	require.NoError(t, sim.WaitForVerdict(ctx, mismatch, types.Invalid))
+	alert, err := sim.WaitForAlert(ctx, types.AlertMismatch, 3)
+	require.NoError(t, err)
+	assert.Equal(t, mismatch.Header.Hash(), alert.PandoraHeaderHash)
+
+	slots, err = sim.ProduceSlots(4, 5)
+	require.NoError(t, err)
+	waitForVerified(ctx, t, sim, slots)
+}
+
+// TestSimulator_Equivocation checks that two pandora headers of the same slot raise an alert and the header which
+// vanguard confirms is verified
+func TestSimulator_Equivocation(t *testing.T) {
+	sim, ctx := startSimulator(t)
+
+	slots, err := sim.ProduceSlots(1, 2)
+	require.NoError(t, err)
+	waitForVerified(ctx, t, sim, slots)
+
+	first, second := sim.NewSlot(3), sim.NewSlot(3)
+	sim.PublishPandora(first)
+	sim.PublishPandora(second)
+	_, err = sim.WaitForAlert(ctx, types.AlertEquivocation, 3)
+	require.NoError(t, err)
+
+	require.NoError(t, sim.PublishVanguard(second))
+	require.NoError(t, sim.WaitForVerdict(ctx, second, types.Verified))
+	_, ok := sim.Pandora.Verdict(first.Header.Hash())
+	assert.Equal(t, false, ok)
+}
+
+// TestSimulator_Reconnect checks that orchestrator catches up with the slots which both chains have produced while
+// they were disconnected
+func TestSimulator_Reconnect(t *testing.T) {
+	sim, ctx := startSimulator(t)
+
+	slots, err := sim.ProduceSlots(1, 3)
+	require.NoError(t, err)
+	waitForVerified(ctx, t, sim, slots)
+
+	sim.Vanguard.Disconnect()
+	sim.Pandora.Disconnect()
+	slots, err = sim.ProduceSlots(4, 6)
+	require.NoError(t, err)
+	require.NoError(t, sim.Vanguard.Reconnect())
+	require.NoError(t, sim.Pandora.Reconnect())
+	waitForVerified(ctx, t, sim, slots)
+}
+
+// TestSimulator_Reorg checks that orchestrator follows vanguard to a new fork and verifies its blocks
+func TestSimulator_Reorg(t *testing.T) {
+	sim, ctx := startSimulator(t)
+
+	slots, err := sim.ProduceSlots(1, 6)
+	require.NoError(t, err)
+	waitForVerified(ctx, t, sim, slots)
+
+	fork, err := sim.Reorg(1, 4, 6)
+	require.NoError(t, err)
+	waitForVerified(ctx, t, sim, fork)
+}
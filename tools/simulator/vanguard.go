@@ -0,0 +1,322 @@
+package simulator
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/pkg/errors"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// number of live events buffered for a single stream of the fake vanguard node
+const streamBuffer = 256
+
+// Vanguard is an in-process vanguard node which serves the gRPC api that orchestrator consumes. Blocks and consensus
+// infos are stored when they are published, so streams which are opened after a disconnect replay them.
+type Vanguard struct {
+	ethpb.UnimplementedBeaconChainServer
+
+	lock           sync.Mutex
+	addr           string
+	server         *grpc.Server
+	blocks         map[uint64]*ethpb.BeaconBlock // canonical block of every slot
+	consensusInfos map[uint64]*ethpb.MinimalConsensusInfo
+	head           *ethpb.ChainHead
+	finalizedSlot  uint64
+	finalizedEpoch uint64
+
+	blockFeed         event.Feed
+	consensusInfoFeed event.Feed
+	headFeed          event.Feed
+}
+
+// vanguardNode serves the node api of the fake vanguard node
+type vanguardNode struct {
+	ethpb.UnimplementedNodeServer
+}
+
+// GetVersion
+func (n *vanguardNode) GetVersion(context.Context, *emptypb.Empty) (*ethpb.Version, error) {
+	return &ethpb.Version{Version: "Vanguard/simulator"}, nil
+}
+
+// NewVanguard starts a fake vanguard node on a random local port
+func NewVanguard() (*Vanguard, error) {
+	v := &Vanguard{
+		addr:           "127.0.0.1:0",
+		blocks:         make(map[uint64]*ethpb.BeaconBlock),
+		consensusInfos: make(map[uint64]*ethpb.MinimalConsensusInfo),
+		head:           &ethpb.ChainHead{HeadBlockRoot: make([]byte, 32)},
+	}
+	if err := v.Reconnect(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Endpoint returns the gRPC endpoint which orchestrator connects to
+func (v *Vanguard) Endpoint() string {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.addr
+}
+
+// Disconnect stops the gRPC server, so all streams of orchestrator fail and it has to reconnect
+func (v *Vanguard) Disconnect() {
+	v.lock.Lock()
+	server, addr := v.server, v.addr
+	v.server = nil
+	v.lock.Unlock()
+	if server != nil {
+		server.Stop()
+		log.WithField("endpoint", addr).Info("Disconnected vanguard")
+	}
+}
+
+// Reconnect serves the gRPC api again on the same address
+func (v *Vanguard) Reconnect() error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if v.server != nil {
+		return nil
+	}
+	listener, err := net.Listen("tcp", v.addr)
+	if err != nil {
+		return errors.Wrap(err, "could not listen for vanguard gRPC")
+	}
+	v.addr = listener.Addr().String()
+	v.server = grpc.NewServer()
+	ethpb.RegisterBeaconChainServer(v.server, v)
+	ethpb.RegisterNodeServer(v.server, &vanguardNode{})
+	go func(server *grpc.Server) {
+		if err := server.Serve(listener); err != nil {
+			log.WithError(err).Error("Vanguard gRPC server failed")
+		}
+	}(v.server)
+	log.WithField("endpoint", v.addr).Info("Serving vanguard gRPC")
+	return nil
+}
+
+// Close stops the gRPC server for good
+func (v *Vanguard) Close() {
+	v.Disconnect()
+}
+
+// PublishBlock stores the block as canonical block of its slot, makes it the chain head and streams it to
+// orchestrator
+func (v *Vanguard) PublishBlock(block *ethpb.BeaconBlock) error {
+	root, err := block.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute vanguard block root")
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.blocks[uint64(block.Slot)] = block
+	v.head = &ethpb.ChainHead{HeadSlot: block.Slot, HeadBlockRoot: root[:]}
+	v.blockFeed.Send(v.pendingBlockInfo(block))
+	v.headFeed.Send(v.head)
+	return nil
+}
+
+// PublishConsensusInfo stores the consensus info of an epoch and streams it to orchestrator. Reorg info is only
+// streamed live, replayed consensus infos do not carry it, the same as a real vanguard node.
+func (v *Vanguard) PublishConsensusInfo(consensusInfo *ethpb.MinimalConsensusInfo) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	stored := proto.Clone(consensusInfo).(*ethpb.MinimalConsensusInfo)
+	stored.ReorgInfo = nil
+	v.consensusInfos[uint64(consensusInfo.Epoch)] = stored
+	v.consensusInfoFeed.Send(consensusInfo)
+}
+
+// Finalize sets the finalized checkpoint which is sent with every following block
+func (v *Vanguard) Finalize(slot, epoch uint64) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.finalizedSlot, v.finalizedEpoch = slot, epoch
+}
+
+// Replace replaces the canonical blocks from the slot of the first given block without streaming them, open
+// streams only see the new blocks once they are opened again
+func (v *Vanguard) Replace(blocks []*ethpb.BeaconBlock) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+	last := blocks[len(blocks)-1]
+	root, err := last.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute vanguard block root")
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	for slot := range v.blocks {
+		if slot >= uint64(blocks[0].Slot) {
+			delete(v.blocks, slot)
+		}
+	}
+	for _, block := range blocks {
+		v.blocks[uint64(block.Slot)] = block
+	}
+	v.head = &ethpb.ChainHead{HeadSlot: last.Slot, HeadBlockRoot: root[:]}
+	return nil
+}
+
+// pendingBlockInfo wraps the block with the current finalized checkpoint, the lock must be held
+func (v *Vanguard) pendingBlockInfo(block *ethpb.BeaconBlock) *ethpb.StreamPendingBlockInfo {
+	return &ethpb.StreamPendingBlockInfo{
+		Block:          block,
+		FinalizedSlot:  eth2Types.Slot(v.finalizedSlot),
+		FinalizedEpoch: eth2Types.Epoch(v.finalizedEpoch),
+	}
+}
+
+// sortedSlots returns the slots of stored blocks from the given slot in ascending order, the lock must be held
+func (v *Vanguard) sortedSlots(fromSlot uint64) []uint64 {
+	slots := make([]uint64, 0, len(v.blocks))
+	for slot := range v.blocks {
+		if slot >= fromSlot {
+			slots = append(slots, slot)
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+	return slots
+}
+
+// StreamNewPendingBlocks replays the stored blocks from the requested slot and streams new blocks afterwards
+func (v *Vanguard) StreamNewPendingBlocks(
+	req *ethpb.StreamPendingBlocksRequest,
+	stream ethpb.BeaconChain_StreamNewPendingBlocksServer,
+) error {
+	ch := make(chan *ethpb.StreamPendingBlockInfo, streamBuffer)
+	v.lock.Lock()
+	sub := v.blockFeed.Subscribe(ch)
+	replay := make([]*ethpb.StreamPendingBlockInfo, 0, len(v.blocks))
+	for _, slot := range v.sortedSlots(uint64(req.FromSlot)) {
+		replay = append(replay, v.pendingBlockInfo(v.blocks[slot]))
+	}
+	v.lock.Unlock()
+	defer sub.Unsubscribe()
+
+	for _, blockInfo := range replay {
+		if err := stream.Send(blockInfo); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case blockInfo := <-ch:
+			if err := stream.Send(blockInfo); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// StreamChainHead streams the current chain head and every following head
+func (v *Vanguard) StreamChainHead(_ *emptypb.Empty, stream ethpb.BeaconChain_StreamChainHeadServer) error {
+	ch := make(chan *ethpb.ChainHead, streamBuffer)
+	v.lock.Lock()
+	sub := v.headFeed.Subscribe(ch)
+	head := v.head
+	v.lock.Unlock()
+	defer sub.Unsubscribe()
+
+	if err := stream.Send(head); err != nil {
+		return err
+	}
+	for {
+		select {
+		case head := <-ch:
+			if err := stream.Send(head); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// StreamMinimalConsensusInfo replays the stored consensus infos from the requested epoch and streams new consensus
+// infos afterwards
+func (v *Vanguard) StreamMinimalConsensusInfo(
+	req *ethpb.MinimalConsensusInfoRequest,
+	stream ethpb.BeaconChain_StreamMinimalConsensusInfoServer,
+) error {
+	ch := make(chan *ethpb.MinimalConsensusInfo, streamBuffer)
+	v.lock.Lock()
+	sub := v.consensusInfoFeed.Subscribe(ch)
+	replay := make([]*ethpb.MinimalConsensusInfo, 0, len(v.consensusInfos))
+	for epoch := uint64(req.FromEpoch); v.consensusInfos[epoch] != nil; epoch++ {
+		replay = append(replay, v.consensusInfos[epoch])
+	}
+	v.lock.Unlock()
+	defer sub.Unsubscribe()
+
+	for _, consensusInfo := range replay {
+		if err := stream.Send(consensusInfo); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case consensusInfo := <-ch:
+			if err := stream.Send(consensusInfo); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// GetChainHead
+func (v *Vanguard) GetChainHead(context.Context, *emptypb.Empty) (*ethpb.ChainHead, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.head, nil
+}
+
+// ListBlocks returns the canonical blocks of the requested slot or epoch
+func (v *Vanguard) ListBlocks(_ context.Context, req *ethpb.ListBlocksRequest) (*ethpb.ListBlocksResponse, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	var fromSlot, toSlot uint64
+	switch filter := req.QueryFilter.(type) {
+	case *ethpb.ListBlocksRequest_Slot:
+		fromSlot, toSlot = uint64(filter.Slot), uint64(filter.Slot)
+	case *ethpb.ListBlocksRequest_Epoch:
+		slotsPerEpoch := params.ActiveNetworkConfig().SlotsPerEpoch
+		fromSlot = uint64(filter.Epoch) * slotsPerEpoch
+		toSlot = fromSlot + slotsPerEpoch - 1
+	default:
+		return nil, errors.New("only blocks of a slot or an epoch can be listed")
+	}
+	resp := &ethpb.ListBlocksResponse{}
+	for _, slot := range v.sortedSlots(fromSlot) {
+		if slot > toSlot {
+			break
+		}
+		block := v.blocks[slot]
+		root, err := block.HashTreeRoot()
+		if err != nil {
+			return nil, err
+		}
+		resp.BlockContainers = append(resp.BlockContainers, &ethpb.BeaconBlockContainer{
+			Block:     &ethpb.SignedBeaconBlock{Block: block, Signature: make([]byte, 96)},
+			BlockRoot: root[:],
+			Canonical: true,
+		})
+	}
+	resp.TotalSize = int32(len(resp.BlockContainers))
+	return resp, nil
+}